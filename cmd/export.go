@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/spf13/cobra"
+)
+
+var exportFlags struct {
+	Output string
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the database state as JSON",
+	Long: `Write a versioned JSON dump of the media items, keep requests and history to stdout or a file.
+
+The dump can be restored with the import command and is safe to create while the server is running.`,
+	Example: `jellysweep export --config config.yml > backup.json
+jellysweep export --output backup.json`,
+	RunE: export,
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportFlags.Output, "output", "o", "", "File to write the dump to (default: stdout)")
+	rootCmd.AddCommand(exportCmd)
+}
+
+func export(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.Load(rootCmdPersistentFlags.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	db, _, err := database.New(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	var w io.Writer = cmd.OutOrStdout()
+	if exportFlags.Output != "" {
+		f, err := os.Create(exportFlags.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close() //nolint:errcheck
+		w = f
+	}
+
+	if err := db.ExportState(cmd.Context(), w); err != nil {
+		return fmt.Errorf("failed to export state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/engine"
+	"github.com/spf13/cobra"
+)
+
+var forceDeleteExpiredFlags struct {
+	Yes bool
+}
+
+var forceDeleteExpiredCmd = &cobra.Command{
+	Use:   "force-delete-expired",
+	Short: "Delete all media whose deletion date has passed, regardless of the disk usage",
+	Long: `Delete every marked media item whose default or disk usage deletion date has passed,
+without waiting for the disk usage thresholds to be reached. Protected media is never deleted.
+
+Without --yes the affected items are only listed. The deletion is refused while a jellysweep server
+answers on the configured listen address, since the command can't coordinate with the cleanup of that
+server. Use POST /admin/api/media/force-delete-expired of the running server instead. Servers running
+on another host aren't detected.`,
+	Example: `jellysweep force-delete-expired --config config.yml
+jellysweep force-delete-expired --yes`,
+	RunE: forceDeleteExpired,
+}
+
+func init() {
+	forceDeleteExpiredCmd.Flags().BoolVar(&forceDeleteExpiredFlags.Yes, "yes", false, "Confirm the deletion of the listed items")
+	rootCmd.AddCommand(forceDeleteExpiredCmd)
+}
+
+func forceDeleteExpired(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.Load(rootCmdPersistentFlags.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if forceDeleteExpiredFlags.Yes && serverRunning(cfg.Listen) {
+		return fmt.Errorf("a jellysweep server is running on %s, use POST /admin/api/media/force-delete-expired instead", cfg.Listen)
+	}
+
+	db, _, err := database.New(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	e, err := engine.New(cfg, db, false)
+	if err != nil {
+		return fmt.Errorf("failed to create engine: %w", err)
+	}
+	defer e.Close() //nolint:errcheck
+
+	result, err := e.ForceDeleteExpired(cmd.Context(), !forceDeleteExpiredFlags.Yes)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if len(result.Items) == 0 {
+		fmt.Fprintln(out, "No expired media items found.")
+		return nil
+	}
+
+	switch {
+	case !forceDeleteExpiredFlags.Yes:
+		fmt.Fprintf(out, "Found %d expired media items, run again with --yes to delete them:\n", len(result.Items))
+	case result.DryRun:
+		fmt.Fprintf(out, "Dry run enabled, would delete %d expired media items:\n", len(result.Items))
+	default:
+		fmt.Fprintf(out, "Deleted %d expired media items:\n", len(result.Items))
+	}
+	for _, item := range result.Items {
+		fmt.Fprintf(out, "  - [%s] %s (%d), due %s\n", item.LibraryName, item.Title, item.Year, item.DefaultDeleteAt.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// serverRunning reports whether a jellysweep server answers the health check on the listen address on this host.
+func serverRunning(listen string) bool {
+	host, port, err := net.SplitHostPort(listen)
+	if err != nil {
+		return false
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+
+	client := &http.Client{Timeout: 2 * time.Second}
+	resp, err := client.Get("http://" + net.JoinHostPort(host, port) + "/health")
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	return resp.StatusCode == http.StatusOK
+}
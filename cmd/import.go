@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import a database state exported as JSON",
+	Long: `Restore a dump created by the export command. Reads from stdin if no file is given.
+
+Media items are matched by their arr ID, media type and library and updated in place, so importing the same dump again is safe.
+The import runs in a single transaction and is rolled back completely on errors.`,
+	Example: `jellysweep import backup.json --config config.yml
+jellysweep import < backup.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: importState,
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+}
+
+func importState(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load(rootCmdPersistentFlags.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	db, _, err := database.New(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	var r io.Reader = cmd.InOrStdin()
+	if len(args) == 1 {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open dump: %w", err)
+		}
+		defer f.Close() //nolint:errcheck
+		r = f
+	}
+
+	if err := db.ImportState(cmd.Context(), r); err != nil {
+		return fmt.Errorf("failed to import state: %w", err)
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), "State imported.")
+	return nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate the database schema and exit",
+	Long: `Create and update the database tables without starting the server or the scheduler.
+
+Together with database.auto_migrate set to false, this runs schema changes as a separate step of a rollout.`,
+	Example: `jellysweep migrate --config config.yml
+jellysweep migrate status`,
+	Args: cobra.NoArgs,
+	RunE: migrateDatabase,
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:     "status",
+	Short:   "List the tables and columns the migration would create",
+	Long:    `Compare the database with the current schema and list the missing tables and columns, without changing anything.`,
+	Example: `jellysweep migrate status --config config.yml`,
+	Args:    cobra.NoArgs,
+	RunE:    migrateStatus,
+}
+
+func init() {
+	migrateCmd.AddCommand(migrateStatusCmd)
+	rootCmd.AddCommand(migrateCmd)
+}
+
+func migrateDatabase(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.Load(rootCmdPersistentFlags.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	statuses, err := database.Migrate(cfg.Database)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	printTableStatuses(out, statuses)
+	fmt.Fprintf(out, "Database schema is up to date (%d tables).\n", len(statuses))
+	return nil
+}
+
+func migrateStatus(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.Load(rootCmdPersistentFlags.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	statuses, err := database.MigrationStatus(cfg.Database)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	printTableStatuses(out, statuses)
+
+	var pending int
+	for _, status := range statuses {
+		if status.Pending() {
+			pending++
+		}
+	}
+	if pending == 0 {
+		fmt.Fprintln(out, "No pending migrations.")
+	} else {
+		fmt.Fprintf(out, "%d of %d tables have pending migrations, run jellysweep migrate to apply them.\n", pending, len(statuses))
+	}
+	return nil
+}
+
+func printTableStatuses(out io.Writer, statuses []database.TableStatus) {
+	for _, status := range statuses {
+		switch {
+		case !status.Exists:
+			fmt.Fprintf(out, "  - %s: pending (table missing)\n", status.Table)
+		case len(status.MissingColumns) > 0:
+			fmt.Fprintf(out, "  - %s: pending (missing columns: %s)\n", status.Table, strings.Join(status.MissingColumns, ", "))
+		default:
+			fmt.Fprintf(out, "  - %s: applied\n", status.Table)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/engine"
+	"github.com/spf13/cobra"
+)
+
+var orphanedTagsFlags struct {
+	Clean bool
+}
+
+var orphanedTagsCmd = &cobra.Command{
+	Use:   "orphaned-tags",
+	Short: "Report jellysweep tags without a corresponding database entry",
+	Long: `List the Sonarr and Radarr items that still carry jellysweep tags but are not tracked in the database,
+e.g. leftovers from the tag based system. The ignore tag is never reported.
+
+Use --clean to remove the reported tags.`,
+	Example: `jellysweep orphaned-tags --config config.yml
+jellysweep orphaned-tags --clean`,
+	RunE: orphanedTags,
+}
+
+func init() {
+	orphanedTagsCmd.Flags().BoolVar(&orphanedTagsFlags.Clean, "clean", false, "Remove the orphaned tags")
+	rootCmd.AddCommand(orphanedTagsCmd)
+}
+
+func orphanedTags(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.Load(rootCmdPersistentFlags.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	db, _, err := database.New(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	e, err := engine.New(cfg, db, false)
+	if err != nil {
+		return fmt.Errorf("failed to create engine: %w", err)
+	}
+	defer e.Close() //nolint:errcheck
+
+	var orphans []engine.OrphanReport
+	if orphanedTagsFlags.Clean {
+		orphans, err = e.CleanOrphanedTags(cmd.Context())
+	} else {
+		orphans, err = e.FindOrphanedTags(cmd.Context())
+	}
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if len(orphans) == 0 {
+		fmt.Fprintln(out, "No orphaned tags found.")
+		return nil
+	}
+
+	if orphanedTagsFlags.Clean {
+		fmt.Fprintf(out, "Removed orphaned tags from %d items:\n", len(orphans))
+	} else {
+		fmt.Fprintf(out, "Found %d items with orphaned tags:\n", len(orphans))
+	}
+	for _, o := range orphans {
+		fmt.Fprintf(out, "  - [%s] %s (%d): %s\n", o.Service, o.Title, o.ArrID, strings.Join(o.Tags, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/engine"
+	"github.com/spf13/cobra"
+)
+
+var previewFlags struct {
+	Library string
+}
+
+var previewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "Preview which media of a library would be marked for deletion",
+	Long: `Gather and filter the media of a single library like a cleanup run and print the result.
+
+Items that would be marked for deletion are listed first, followed by the items excluded by a filter together with the name of that filter.
+Items already tracked in the database are excluded by the database filter.
+The preview always runs in dry-run mode and neither records nor deletes anything.`,
+	Example: `jellysweep preview --library "Movies" --config config.yml`,
+	RunE:    preview,
+}
+
+func init() {
+	previewCmd.Flags().StringVar(&previewFlags.Library, "library", "", "Name of the library to preview")
+	_ = previewCmd.MarkFlagRequired("library")
+	rootCmd.AddCommand(previewCmd)
+}
+
+func preview(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.Load(rootCmdPersistentFlags.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	// never delete anything, even if the config disables the dry run
+	cfg.DryRun = true
+
+	db, _, err := database.New(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	e, err := engine.New(cfg, db, false)
+	if err != nil {
+		return fmt.Errorf("failed to create engine: %w", err)
+	}
+	defer e.Close() //nolint:errcheck
+
+	items, err := e.PreviewLibrary(cmd.Context(), previewFlags.Library)
+	if err != nil {
+		return err
+	}
+
+	var candidates, dropped []engine.PreviewItem
+	for _, item := range items {
+		if item.DroppedBy == "" {
+			candidates = append(candidates, item)
+		} else {
+			dropped = append(dropped, item)
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Library %q: %d items\n", previewFlags.Library, len(items))
+
+	fmt.Fprintf(out, "\nWould be marked for deletion (%d):\n", len(candidates))
+	for _, item := range candidates {
+		fmt.Fprintf(out, "  - [%s] %s (%d), %s\n", item.MediaType, item.Title, item.Year, humanize.Bytes(uint64(item.FileSize))) //nolint:gosec
+	}
+
+	fmt.Fprintf(out, "\nExcluded by a filter (%d):\n", len(dropped))
+	for _, item := range dropped {
+		fmt.Fprintf(out, "  - [%s] %s (%d): %s\n", item.MediaType, item.Title, item.Year, item.DroppedBy)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/dustin/go-humanize"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/engine"
+	"github.com/spf13/cobra"
+)
+
+var reclaimableCmd = &cobra.Command{
+	Use:   "reclaimable",
+	Short: "Estimate how much disk space a cleanup run started now would free",
+	Long: `Gather and filter the media like a cleanup run and sum the size of the items the run would delete,
+respecting the deletion policies, the disk usage thresholds and the safeguards of the cleanup.
+
+Deletions are assumed to succeed. The estimate always runs in dry-run mode and neither records nor deletes anything.`,
+	Example: `jellysweep reclaimable --config config.yml`,
+	RunE:    reclaimable,
+}
+
+func init() {
+	rootCmd.AddCommand(reclaimableCmd)
+}
+
+func reclaimable(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.Load(rootCmdPersistentFlags.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	// never delete anything, even if the config disables the dry run
+	cfg.DryRun = true
+
+	db, _, err := database.New(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	e, err := engine.New(cfg, db, false)
+	if err != nil {
+		return fmt.Errorf("failed to create engine: %w", err)
+	}
+	defer e.Close() //nolint:errcheck
+
+	perLibrary, total, err := e.EstimateReclaimable(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	for _, library := range slices.Sorted(maps.Keys(perLibrary)) {
+		fmt.Fprintf(out, "%s: %s\n", library, humanize.Bytes(uint64(perLibrary[library]))) //nolint:gosec
+	}
+	fmt.Fprintf(out, "Total: %s\n", humanize.Bytes(uint64(total))) //nolint:gosec
+	return nil
+}
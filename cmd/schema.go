@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON schema of the config file",
+	Long: `Print the JSON schema of the config file.
+
+Editors can use the schema to offer autocompletion and validation for the config file.`,
+	Example: `jellysweep schema > config.schema.json`,
+	RunE:    schema,
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
+
+func schema(cmd *cobra.Command, _ []string) error {
+	data, err := config.JSONSchema()
+	if err != nil {
+		return fmt.Errorf("failed to generate config schema: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	return nil
+}
@@ -34,7 +34,7 @@ func startServer(cmd *cobra.Command, _ []string) {
 		log.Fatal("failed to load config", "error", err)
 	}
 
-	db, isNewDatabase, err := database.New(cfg.Database)
+	db, tagMigrationPending, err := database.New(cfg.Database)
 	if err != nil {
 		log.Fatal("failed to initialize database", "error", err)
 	}
@@ -42,7 +42,7 @@ func startServer(cmd *cobra.Command, _ []string) {
 	ctx, cancel := context.WithCancel(cmd.Context())
 	defer cancel()
 
-	engine, err := engine.New(cfg, db, isNewDatabase)
+	engine, err := engine.New(cfg, db, tagMigrationPending)
 	if err != nil {
 		log.Fatal("failed to create engine", "error", err)
 	}
@@ -59,6 +59,23 @@ func startServer(cmd *cobra.Command, _ []string) {
 		}
 	}()
 
+	// Rebuild the media server, stats and arr clients on SIGHUP, e.g. after an API key was rotated
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case <-hup:
+				log.Info("received SIGHUP, reloading clients")
+				if err := engine.ReloadClients(rootCmdPersistentFlags.ConfigFile); err != nil {
+					log.Error("failed to reload clients", "error", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
 	// Wait for interrupt signal to gracefully shutdown
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
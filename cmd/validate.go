@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the configuration",
+	Long: `Validate the configuration without starting the server.
+
+The config file, environment variables and defaults are loaded exactly like the server does.
+Deprecated options and misordered disk usage thresholds are reported as warnings. Exits non-zero if the configuration is invalid.`,
+	Example: `jellysweep validate --config config.yml`,
+	RunE:    validate,
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
+
+var errInvalidConfig = errors.New("configuration is invalid")
+
+func validate(cmd *cobra.Command, _ []string) error {
+	report, err := config.Check(rootCmdPersistentFlags.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if report.ConfigFile != "" {
+		fmt.Fprintf(out, "Config file: %s\n", report.ConfigFile)
+	} else {
+		fmt.Fprintln(out, "Config file: none found, using defaults and environment variables")
+	}
+
+	if warnings := len(report.Deprecations) + len(report.ThresholdWarnings); warnings > 0 {
+		fmt.Fprintf(out, "\nWarnings (%d):\n", warnings)
+		for _, d := range report.Deprecations {
+			fmt.Fprintf(out, "  - library %q: %s\n", d.Library, d)
+		}
+		for _, w := range report.ThresholdWarnings {
+			fmt.Fprintf(out, "  - library %q: %s\n", w.Library, w)
+		}
+	}
+
+	if report.Err != nil {
+		fmt.Fprintln(out, "\nErrors (1):")
+		fmt.Fprintf(out, "  - %s\n", report.Err)
+		return errInvalidConfig
+	}
+
+	fmt.Fprintln(out, "\nConfiguration is valid.")
+	return nil
+}
@@ -83,9 +83,9 @@ func (s *Server) setupRoutes() error {
 	}
 	s.ginEngine.StaticFS("/static", http.FS(staticSub))
 
-	s.ginEngine.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "ok"})
-	})
+	s.ginEngine.GET("/health", h.Healthz)
+	s.ginEngine.GET("/healthz", h.Healthz)
+	s.ginEngine.GET("/readyz", h.Readyz)
 
 	// Serve robots.txt from root
 	s.ginEngine.GET("/robots.txt", func(c *gin.Context) {
@@ -101,8 +101,11 @@ func (s *Server) setupRoutes() error {
 
 	// Auth routes
 	s.ginEngine.POST("/auth/jellyfin/login", s.authProvider.Login)
+	s.ginEngine.POST("/auth/ldap/login", s.authProvider.Login)
 	s.ginEngine.GET("/auth/oidc/callback", s.authProvider.Callback)
 	s.ginEngine.GET("/auth/oidc/login", s.authProvider.Login)
+	s.ginEngine.GET("/auth/oidc/:provider/callback", s.authProvider.Callback)
+	s.ginEngine.GET("/auth/oidc/:provider/login", s.authProvider.Login)
 
 	protected := s.ginEngine.Group("/")
 	protected.Use(s.authProvider.RequireAuth())
@@ -113,6 +116,8 @@ func (s *Server) setupRoutes() error {
 	// API routes
 	api := protected.Group("/api")
 	api.GET("/me", h.Me)
+	api.GET("/me/notifications", h.GetNotificationPrefs)
+	api.PUT("/me/notifications", h.UpdateNotificationPrefs)
 	api.GET("/media", h.GetMediaItems)
 	api.POST("/media/:id/request-keep", h.RequestKeepMedia)
 
@@ -151,9 +156,13 @@ func (s *Server) setupAdminRoutes() {
 	adminAPI := adminGroup.Group("/api")
 	adminAPI.POST("/keep-requests/:id/accept", h.AcceptKeepRequest)
 	adminAPI.POST("/keep-requests/:id/decline", h.DeclineKeepRequest)
+	adminAPI.POST("/keep-requests/batch", h.HandleKeepRequests)
 	adminAPI.POST("/media/:id/keep", h.MarkMediaAsProtected)
 	adminAPI.POST("/media/:id/delete", h.MarkMediaAsUnkeepable)
 	adminAPI.POST("/media/:id/keep-forever", h.MarkMediaAsKeepForever)
+	adminAPI.PUT("/media/:id/ignored", h.SetMediaIgnored)
+	adminAPI.DELETE("/media/:id/deletion-failure", h.ResetDeletionFailure)
+	adminAPI.POST("/media/force-delete-expired", h.ForceDeleteExpired)
 
 	adminAPI.GET("/keep-requests", h.GetKeepRequests)
 	adminAPI.GET("/media", h.GetAdminMediaItems)
@@ -163,9 +172,17 @@ func (s *Server) setupAdminRoutes() {
 	adminAPI.POST("/scheduler/jobs/:id/run", h.RunSchedulerJob)
 	adminAPI.POST("/scheduler/jobs/:id/enable", h.EnableSchedulerJob)
 	adminAPI.POST("/scheduler/jobs/:id/disable", h.DisableSchedulerJob)
+	adminAPI.POST("/scheduler/pause", h.PauseScheduler)
+	adminAPI.POST("/scheduler/resume", h.ResumeScheduler)
+	adminAPI.POST("/maintenance/enable", h.EnableMaintenanceMode)
+	adminAPI.POST("/maintenance/disable", h.DisableMaintenanceMode)
 	adminAPI.GET("/scheduler/cache/stats", h.GetSchedulerCacheStats)
 	adminAPI.POST("/scheduler/cache/clear", h.ClearSchedulerCache)
 
+	// Orphaned tag endpoints
+	adminAPI.GET("/tags/orphaned", h.GetOrphanedTags)
+	adminAPI.POST("/tags/orphaned/clean", h.CleanOrphanedTags)
+
 	// History endpoints
 	adminAPI.GET("/history", h.GetHistory)
 
@@ -187,6 +204,34 @@ func (s *Server) setupPluginRoutes() error {
 
 	pluginAPI.GET("/health", h.GetHealth)
 	pluginAPI.POST("/check", h.CheckMediaItem)
+	// the item IDs of the webhook are only known if Jellyfin is the media server
+	if s.cfg.Jellyfin != nil {
+		pluginAPI.POST("/webhook/jellyfin", h.JellyfinWebhook)
+	}
+
+	return nil
+}
+
+func (s *Server) setupV1Routes() error {
+	if s.cfg.APIKey == "" {
+		return fmt.Errorf("API key is required for the v1 API")
+	}
+	v1API := s.ginEngine.Group("/api/v1")
+
+	tokenAuth := auth.NewAPIKeyProvider(s.cfg.APIKey)
+	v1API.Use(tokenAuth.RequireAuth())
+
+	h := handler.NewV1(s.engine)
+
+	v1API.GET("/runs", h.GetRuns)
+	v1API.GET("/runs/:id", h.GetRun)
+	v1API.GET("/deletions", h.GetDeletions)
+	v1API.GET("/estimations", h.GetEstimations)
+	v1API.GET("/marked/diff", h.GetMarkedDiff)
+	v1API.GET("/reclaimable", h.GetReclaimable)
+	v1API.GET("/stats", h.GetStats)
+	v1API.GET("/jobs", h.GetJobs)
+	v1API.GET("/audit", h.GetAudit)
 
 	return nil
 }
@@ -201,6 +246,9 @@ func (s *Server) Run(ctx context.Context) error {
 	if err := s.setupPluginRoutes(); err != nil {
 		log.Warn("Plugin routes not enabled", "error", err)
 	}
+	if err := s.setupV1Routes(); err != nil {
+		log.Warn("v1 API routes not enabled", "error", err)
+	}
 	s.setupAdminRoutes()
 
 	srv := &http.Server{
@@ -30,14 +30,16 @@ type AuthProvider interface {
 
 // MultiProvider wraps multiple auth providers.
 type MultiProvider struct {
-	db               database.UserDB
-	oidcProvider     *OIDCProvider
+	db database.UserDB
+	// oidcProviders holds the OIDC providers by their ID, the single oidc provider usually has an empty ID.
+	oidcProviders    map[string]*OIDCProvider
 	jellyfinProvider *JellyfinProvider
+	ldapProvider     *LDAPProvider
 	cfg              *config.AuthConfig
 	gravatarCfg      *config.GravatarConfig
 }
 
-// NewProvider creates a multi-provider that supports both OIDC and Jellyfin authentication.
+// NewProvider creates a multi-provider that supports OIDC, Jellyfin and LDAP authentication.
 func NewProvider(ctx context.Context, cfg *config.Config, gravatarCfg *config.GravatarConfig, db database.UserDB) (AuthProvider, error) {
 	if cfg == nil {
 		return nil, fmt.Errorf("auth config is required")
@@ -45,22 +47,30 @@ func NewProvider(ctx context.Context, cfg *config.Config, gravatarCfg *config.Gr
 
 	mp := &MultiProvider{cfg: cfg.Auth, gravatarCfg: gravatarCfg, db: db}
 
-	// Initialize OIDC provider if enabled
-	if cfg.Auth.OIDC != nil && cfg.Auth.OIDC.Enabled {
-		oidcProvider, err := NewOIDCProvider(ctx, cfg.Auth.OIDC, gravatarCfg, db)
+	// Initialize the enabled OIDC providers
+	for _, oidcCfg := range cfg.Auth.EnabledOIDCProviders() {
+		oidcProvider, err := NewOIDCProvider(ctx, oidcCfg, gravatarCfg, db)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create OIDC provider: %w", err)
+			return nil, fmt.Errorf("failed to create OIDC provider %q: %w", oidcCfg.DisplayName(), err)
 		}
-		mp.oidcProvider = oidcProvider
+		if mp.oidcProviders == nil {
+			mp.oidcProviders = make(map[string]*OIDCProvider)
+		}
+		mp.oidcProviders[oidcCfg.ID] = oidcProvider
 	}
 
 	// Initialize Jellyfin provider if enabled
 	if cfg.Jellyfin != nil && cfg.Auth.Jellyfin.Enabled {
-		mp.jellyfinProvider = NewJellyfinProvider(cfg.Jellyfin, db, cfg.Auth.Jellyfin, gravatarCfg)
+		mp.jellyfinProvider = NewJellyfinProvider(cfg.Jellyfin, cfg.Proxy, db, cfg.Auth.Jellyfin, gravatarCfg)
+	}
+
+	// Initialize LDAP provider if enabled
+	if cfg.Auth.LDAP != nil && cfg.Auth.LDAP.Enabled {
+		mp.ldapProvider = NewLDAPProvider(cfg.Auth.LDAP, db, gravatarCfg)
 	}
 
 	// At least one provider must be enabled
-	if mp.oidcProvider == nil && mp.jellyfinProvider == nil {
+	if len(mp.oidcProviders) == 0 && mp.jellyfinProvider == nil && mp.ldapProvider == nil {
 		return nil, fmt.Errorf("no authentication provider is enabled")
 	}
 
@@ -69,17 +79,21 @@ func NewProvider(ctx context.Context, cfg *config.Config, gravatarCfg *config.Gr
 
 // Login handles login for the appropriate provider.
 func (mp *MultiProvider) Login(c *gin.Context) {
-	// Check if this is a Jellyfin login request (has username/password form data)
+	// Check if this is a Jellyfin or LDAP login request (has username/password form data)
 	if c.Request.Method == "POST" && (c.PostForm("username") != "" || c.PostForm("password") != "") {
+		if mp.ldapProvider != nil && (c.Request.URL.Path == "/auth/ldap/login" || mp.jellyfinProvider == nil) {
+			mp.ldapProvider.Login(c)
+			return
+		}
 		if mp.jellyfinProvider != nil {
 			mp.jellyfinProvider.Login(c)
 			return
 		}
 	}
 
-	// Default to OIDC login
-	if mp.oidcProvider != nil {
-		mp.oidcProvider.Login(c)
+	// Default to OIDC login, the provider is selected by the :provider route parameter
+	if oidcProvider, ok := mp.oidcProviders[c.Param("provider")]; ok {
+		oidcProvider.Login(c)
 		return
 	}
 
@@ -87,9 +101,10 @@ func (mp *MultiProvider) Login(c *gin.Context) {
 }
 
 // Callback handles OAuth callbacks (OIDC only).
+// The provider is selected by the :provider route parameter.
 func (mp *MultiProvider) Callback(c *gin.Context) {
-	if mp.oidcProvider != nil {
-		mp.oidcProvider.Callback(c)
+	if oidcProvider, ok := mp.oidcProviders[c.Param("provider")]; ok {
+		oidcProvider.Callback(c)
 		return
 	}
 
@@ -108,13 +123,17 @@ func (mp *MultiProvider) RequireAdmin() gin.HandlerFunc {
 
 // Helper methods for the MultiProvider.
 func (mp *MultiProvider) HasOIDC() bool {
-	return mp.oidcProvider != nil
+	return len(mp.oidcProviders) > 0
 }
 
 func (mp *MultiProvider) HasJellyfin() bool {
 	return mp.jellyfinProvider != nil
 }
 
+func (mp *MultiProvider) HasLDAP() bool {
+	return mp.ldapProvider != nil
+}
+
 // requireAuth is the shared implementation for RequireAuth middleware.
 func requireAuth(gravatarCfg *config.GravatarConfig) gin.HandlerFunc {
 	return func(c *gin.Context) {
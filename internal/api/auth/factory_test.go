@@ -16,6 +16,7 @@ import (
 	"github.com/jon4hz/jellysweep/internal/database"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/oauth2"
 	"gorm.io/gorm"
 )
 
@@ -145,7 +146,7 @@ func (s *FactoryTestSuite) TestNewProvider_OnlyJellyfinEnabled() {
 	mp, ok := provider.(*MultiProvider)
 	assert.True(s.T(), ok)
 	assert.NotNil(s.T(), mp.jellyfinProvider)
-	assert.Nil(s.T(), mp.oidcProvider)
+	assert.Empty(s.T(), mp.oidcProviders)
 }
 
 func (s *FactoryTestSuite) TestNewProvider_InvalidOIDCConfig() {
@@ -217,6 +218,46 @@ func (s *FactoryTestSuite) TestMultiProvider_Login_NoProviders() {
 	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
 }
 
+func (s *FactoryTestSuite) TestMultiProvider_Login_SelectsOIDCProvider() {
+	newProvider := func(authURL string) *OIDCProvider {
+		return &OIDCProvider{
+			cfg:    &config.OIDCConfig{Enabled: true},
+			config: &oauth2.Config{ClientID: "client-id", Endpoint: oauth2.Endpoint{AuthURL: authURL}},
+		}
+	}
+	mp := &MultiProvider{
+		cfg: &config.AuthConfig{},
+		oidcProviders: map[string]*OIDCProvider{
+			"":       newProvider("https://authentik.example.com/authorize"),
+			"google": newProvider("https://accounts.google.com/o/oauth2/auth"),
+		},
+	}
+
+	s.router.GET("/auth/oidc/login", mp.Login)
+	s.router.GET("/auth/oidc/:provider/login", mp.Login)
+
+	tests := []struct {
+		path     string
+		code     int
+		location string
+	}{
+		{path: "/auth/oidc/login", code: http.StatusFound, location: "https://authentik.example.com/authorize?"},
+		{path: "/auth/oidc/google/login", code: http.StatusFound, location: "https://accounts.google.com/o/oauth2/auth?"},
+		{path: "/auth/oidc/unknown/login", code: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.path, nil)
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, req)
+
+		assert.Equal(s.T(), tt.code, w.Code, tt.path)
+		if tt.location != "" {
+			assert.True(s.T(), strings.HasPrefix(w.Header().Get("Location"), tt.location), tt.path)
+		}
+	}
+}
+
 func (s *FactoryTestSuite) TestMultiProvider_Callback_NoOIDC() {
 	mp := &MultiProvider{
 		cfg:         &config.AuthConfig{},
@@ -401,7 +442,7 @@ func (s *FactoryTestSuite) TestMultiProvider_HasOIDC() {
 	assert.False(s.T(), mp.HasOIDC())
 
 	// Test with OIDC
-	mp.oidcProvider = &OIDCProvider{}
+	mp.oidcProviders = map[string]*OIDCProvider{"": {}}
 	assert.True(s.T(), mp.HasOIDC())
 }
 
@@ -416,6 +457,17 @@ func (s *FactoryTestSuite) TestMultiProvider_HasJellyfin() {
 	assert.True(s.T(), mp.HasJellyfin())
 }
 
+func (s *FactoryTestSuite) TestMultiProvider_HasLDAP() {
+	mp := &MultiProvider{}
+
+	// Test without LDAP
+	assert.False(s.T(), mp.HasLDAP())
+
+	// Test with LDAP
+	mp.ldapProvider = &LDAPProvider{}
+	assert.True(s.T(), mp.HasLDAP())
+}
+
 func (s *FactoryTestSuite) TestGetSessionString() {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -1,16 +1,25 @@
 package auth
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
 	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
 )
 
 type HandlerTestSuite struct {
@@ -257,6 +266,75 @@ func (s *HandlerTestSuite) TestOIDCCallback_AdminGroupDetection() {
 	}
 }
 
+// usersDB creates a user per username and records the requested usernames.
+type usersDB struct {
+	MockDB
+	users map[string]uint
+}
+
+func (m *usersDB) GetOrCreateUser(_ context.Context, username string) (*database.User, error) {
+	if _, ok := m.users[username]; !ok {
+		m.users[username] = uint(len(m.users) + 1)
+	}
+	return &database.User{Username: username, Model: gorm.Model{ID: m.users[username]}}, nil
+}
+
+// newTestOIDCProvider creates a provider whose token endpoint returns an unsigned ID token with the given username.
+// The signature isn't checked, so the test doesn't need a key set.
+func newTestOIDCProvider(t *testing.T, id string, db database.UserDB) *OIDCProvider {
+	t.Helper()
+	const issuer = "https://issuer.example.com"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		encode := func(v any) string {
+			b, err := json.Marshal(v)
+			require.NoError(t, err)
+			return base64.RawURLEncoding.EncodeToString(b)
+		}
+		idToken := encode(map[string]string{"alg": "RS256"}) + "." + encode(map[string]any{
+			"iss":                issuer,
+			"aud":                id,
+			"sub":                id + "-subject",
+			"exp":                time.Now().Add(time.Hour).Unix(),
+			"preferred_username": "alice",
+		}) + "." + base64.RawURLEncoding.EncodeToString([]byte("signature"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "token", "token_type": "Bearer", "id_token": idToken})
+	}))
+	t.Cleanup(server.Close)
+
+	return &OIDCProvider{
+		db:       db,
+		cfg:      &config.OIDCConfig{ID: id, Enabled: true, ClientID: id},
+		config:   &oauth2.Config{ClientID: id, Endpoint: oauth2.Endpoint{TokenURL: server.URL}},
+		verifier: oidc.NewVerifier(issuer, &oidc.StaticKeySet{}, &oidc.Config{ClientID: id, InsecureSkipSignatureCheck: true}),
+	}
+}
+
+func (s *HandlerTestSuite) TestOIDCCallback_SameUsernameAtTwoProviders() {
+	db := &usersDB{users: make(map[string]uint)}
+	providers := map[string]*OIDCProvider{
+		"authentik": newTestOIDCProvider(s.T(), "authentik", db),
+		"google":    newTestOIDCProvider(s.T(), "google", db),
+	}
+
+	s.router.GET("/auth/oidc/:provider/callback", func(c *gin.Context) {
+		session := sessions.Default(c)
+		session.Set("oauth_state", "state")
+		providers[c.Param("provider")].Callback(c)
+	})
+
+	for _, id := range []string{"authentik", "google"} {
+		req := httptest.NewRequest(http.MethodGet, "/auth/oidc/"+id+"/callback?code=code&state=state", nil)
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		s.Require().Equal(http.StatusFound, w.Code, w.Body.String())
+	}
+
+	assert.Equal(s.T(), map[string]uint{"authentik:alice": 1, "google:alice": 2}, db.users, "users of different providers aren't merged")
+}
+
 func TestHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(HandlerTestSuite))
 }
@@ -21,7 +21,7 @@ type JellyfinProvider struct {
 	gravatarCfg *config.GravatarConfig
 }
 
-func NewJellyfinProvider(cfg *config.JellyfinConfig, db database.UserDB, authCfg *config.JellyfinAuthConfig, gravatarCfg *config.GravatarConfig) *JellyfinProvider {
+func NewJellyfinProvider(cfg *config.JellyfinConfig, proxy *config.ProxyConfig, db database.UserDB, authCfg *config.JellyfinAuthConfig, gravatarCfg *config.GravatarConfig) *JellyfinProvider {
 	clientConfig := jellyfin.NewConfiguration()
 	clientConfig.Servers = jellyfin.ServerConfigurations{
 		{
@@ -36,7 +36,7 @@ func NewJellyfinProvider(cfg *config.JellyfinConfig, db database.UserDB, authCfg
 			version.Version,
 		),
 	}
-	clientConfig.HTTPClient = &http.Client{Timeout: config.TimeoutDuration(cfg.Timeout)}
+	clientConfig.HTTPClient = config.NewHTTPClient(cfg.Timeout, proxy, cfg.Proxy)
 	return &JellyfinProvider{
 		db:          db,
 		client:      jellyfin.NewAPIClient(clientConfig),
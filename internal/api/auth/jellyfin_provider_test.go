@@ -37,7 +37,7 @@ func (s *JellyfinProviderTestSuite) SetupTest() {
 	authCfg := &config.JellyfinAuthConfig{
 		Enabled: true,
 	}
-	s.provider = NewJellyfinProvider(jellyfinCfg, &MockDB{}, authCfg, nil)
+	s.provider = NewJellyfinProvider(jellyfinCfg, nil, &MockDB{}, authCfg, nil)
 }
 
 func (s *JellyfinProviderTestSuite) TestNewJellyfinProvider() {
@@ -49,7 +49,7 @@ func (s *JellyfinProviderTestSuite) TestNewJellyfinProvider() {
 		Enabled: true,
 	}
 
-	provider := NewJellyfinProvider(jellyfinCfg, &MockDB{}, authCfg, nil)
+	provider := NewJellyfinProvider(jellyfinCfg, nil, &MockDB{}, authCfg, nil)
 
 	assert.NotNil(s.T(), provider)
 	assert.Equal(s.T(), authCfg, provider.cfg)
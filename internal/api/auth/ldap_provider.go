@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
+)
+
+var errLDAPInvalidCredentials = errors.New("invalid credentials")
+
+// LDAPProvider authenticates users against an LDAP or Active Directory server.
+type LDAPProvider struct {
+	db          database.UserDB
+	cfg         *config.LDAPConfig
+	gravatarCfg *config.GravatarConfig
+}
+
+// ldapUser holds the attributes resolved for an authenticated LDAP user.
+type ldapUser struct {
+	username string
+	name     string
+	email    string
+	groups   []string
+}
+
+func NewLDAPProvider(cfg *config.LDAPConfig, db database.UserDB, gravatarCfg *config.GravatarConfig) *LDAPProvider {
+	return &LDAPProvider{
+		db:          db,
+		cfg:         cfg,
+		gravatarCfg: gravatarCfg,
+	}
+}
+
+func (p *LDAPProvider) Login(c *gin.Context) {
+	username := c.PostForm("username")
+	password := c.PostForm("password")
+
+	if username == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Username is required"})
+		return
+	}
+	// An empty password would result in an unauthenticated bind, which most servers accept.
+	if password == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		return
+	}
+
+	ldapUser, err := p.authenticate(username, password)
+	if err != nil {
+		if errors.Is(err, errLDAPInvalidCredentials) {
+			log.Warn("LDAP authentication failed", "username", username)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+			return
+		}
+		log.Error("Failed to authenticate user via LDAP", "error", err, "username", username)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Authentication failed"})
+		return
+	}
+
+	// Save user info in session
+	session := sessions.Default(c)
+	session.Set("user_email", ldapUser.email) // required for gravatar
+	session.Set("user_name", ldapUser.name)
+	session.Set("user_username", ldapUser.username)
+	session.Set("user_is_admin", p.cfg.AdminGroup != "" && ldapUser.memberOf(p.cfg.AdminGroup))
+
+	// Get or create user in database
+	user, err := p.db.GetOrCreateUser(c.Request.Context(), ldapUser.username)
+	if err != nil {
+		log.Error("Failed to get or create user", "error", err, "username", ldapUser.username)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Authentication failed"})
+		return
+	}
+	session.Set("user_id", user.ID)
+
+	// Update auto-approval permission based on LDAP group membership
+	// Only update if auto_approve_group is configured
+	if p.cfg.AutoApproveGroup != "" {
+		hasAutoApprove := ldapUser.memberOf(p.cfg.AutoApproveGroup)
+		if err := p.db.UpdateUserAutoApproval(c.Request.Context(), user.ID, hasAutoApprove); err != nil {
+			log.Error("Failed to update user auto-approval permission", "error", err, "user_id", user.ID)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Authentication failed"})
+			return
+		}
+	}
+
+	if err := session.Save(); err != nil {
+		log.Error("Failed to save session", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "redirect": "/"})
+}
+
+// authenticate looks up the user with the service account, verifies the password
+// by binding as the user and returns the resolved user attributes.
+func (p *LDAPProvider) authenticate(username, password string) (*ldapUser, error) {
+	conn, err := ldap.DialURL(p.cfg.URL, ldap.DialWithDialer(&net.Dialer{Timeout: config.TimeoutDuration(p.cfg.Timeout)}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to LDAP server: %w", err)
+	}
+	defer conn.Close() //nolint:errcheck
+	conn.SetTimeout(config.TimeoutDuration(p.cfg.Timeout))
+
+	if p.cfg.BindDN != "" {
+		err = conn.Bind(p.cfg.BindDN, p.cfg.BindPassword)
+	} else {
+		err = conn.UnauthenticatedBind("")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind service account: %w", err)
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		p.cfg.BaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		2, // we only need to know if the filter is ambiguous
+		0,
+		false,
+		strings.ReplaceAll(p.cfg.UserFilter, "%s", ldap.EscapeFilter(username)),
+		[]string{"dn", "uid", "sAMAccountName", "cn", "displayName", "mail", "memberOf"},
+		nil,
+	)
+	result, err := conn.Search(searchRequest)
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("failed to search for user: %w", err)
+	}
+	if result == nil || len(result.Entries) != 1 {
+		return nil, errLDAPInvalidCredentials
+	}
+	entry := result.Entries[0]
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, errLDAPInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to bind as user: %w", err)
+	}
+
+	user := &ldapUser{
+		username: firstNonEmpty(entry.GetAttributeValue("uid"), entry.GetAttributeValue("sAMAccountName"), username),
+		name:     firstNonEmpty(entry.GetAttributeValue("displayName"), entry.GetAttributeValue("cn"), username),
+		email:    entry.GetAttributeValue("mail"),
+		groups:   entry.GetAttributeValues("memberOf"),
+	}
+	return user, nil
+}
+
+// memberOf reports whether the user is a member of the given group.
+// The group can either be a full DN or just the common name of the group.
+func (u *ldapUser) memberOf(group string) bool {
+	for _, dn := range u.groups {
+		if strings.EqualFold(dn, group) {
+			return true
+		}
+		parsed, err := ldap.ParseDN(dn)
+		if err != nil || len(parsed.RDNs) == 0 {
+			continue
+		}
+		for _, attr := range parsed.RDNs[0].Attributes {
+			if strings.EqualFold(attr.Type, "cn") && strings.EqualFold(attr.Value, group) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func (p *LDAPProvider) Callback(c *gin.Context) {
+	// LDAP doesn't use OAuth callback flow, this is a no-op
+	c.JSON(http.StatusNotFound, gin.H{"error": "Not implemented"})
+}
+
+func (p *LDAPProvider) RequireAuth() gin.HandlerFunc {
+	return requireAuth(p.gravatarCfg)
+}
+
+func (p *LDAPProvider) RequireAdmin() gin.HandlerFunc {
+	return requireAdmin()
+}
@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLDAPUserMemberOf(t *testing.T) {
+	user := &ldapUser{
+		groups: []string{
+			"CN=Jellysweep Admins,OU=Groups,DC=example,DC=com",
+			"cn=media,ou=groups,dc=example,dc=com",
+		},
+	}
+
+	tests := []struct {
+		name  string
+		group string
+		want  bool
+	}{
+		{name: "full DN", group: "cn=media,ou=groups,dc=example,dc=com", want: true},
+		{name: "full DN case insensitive", group: "cn=jellysweep admins,ou=groups,dc=example,dc=com", want: true},
+		{name: "common name", group: "Jellysweep Admins", want: true},
+		{name: "common name case insensitive", group: "MEDIA", want: true},
+		{name: "not a member", group: "other", want: false},
+		{name: "parent OU is not a group", group: "Groups", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, user.memberOf(tt.group))
+		})
+	}
+}
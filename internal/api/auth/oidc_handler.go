@@ -133,20 +133,21 @@ func (p *OIDCProvider) Callback(c *gin.Context) {
 	// Save user ID in session
 	session.Set("user_email", claims.Email) // required for gravatar
 	session.Set("user_name", claims.Name)
-	session.Set("user_username", claims.PreferredUsername)
 
 	isAdmin := slices.Contains(claims.Groups, p.cfg.AdminGroup)
 	session.Set("user_is_admin", isAdmin)
 
 	// Get or create user in database
-	user, err := p.db.GetOrCreateUser(c.Request.Context(), claims.PreferredUsername)
+	username := p.username(claims.PreferredUsername)
+	user, err := p.db.GetOrCreateUser(c.Request.Context(), username)
 	if err != nil {
-		log.Error("Failed to get or create user", "error", err, "username", claims.PreferredUsername)
+		log.Error("Failed to get or create user", "error", err, "username", username)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Authentication failed"})
 		c.Abort()
 		return
 	}
 	session.Set("user_id", user.ID)
+	session.Set("user_username", user.Username)
 
 	// Update auto-approval permission based on OIDC group membership
 	// Only update if auto_approve_group is configured
@@ -170,6 +171,15 @@ func (p *OIDCProvider) Callback(c *gin.Context) {
 	c.Redirect(http.StatusFound, "/")
 }
 
+// username returns the name of the user in the database.
+// Users of named providers are prefixed with the provider ID, so the same username at different issuers isn't merged into one user.
+func (p *OIDCProvider) username(preferredUsername string) string {
+	if p.cfg.ID == "" {
+		return preferredUsername
+	}
+	return p.cfg.ID + ":" + preferredUsername
+}
+
 // generateCodeVerifier creates a random code verifier for PKCE.
 func generateCodeVerifier() (string, error) {
 	b := make([]byte, 48)
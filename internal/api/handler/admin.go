@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
@@ -52,7 +54,7 @@ func (h *AdminHandler) AdminPanel(c *gin.Context) {
 	adminMediaItems := models.ToAdminMediaItems(mediaItems, h.config)
 
 	c.Header("Content-Type", "text/html")
-	if err := pages.AdminPanel(user, adminRequests, adminMediaItems, h.config.DryRun).Render(c.Request.Context(), c.Writer); err != nil {
+	if err := pages.AdminPanel(user, adminRequests, adminMediaItems, h.config.DryRun, h.engine.MaintenanceMode()).Render(c.Request.Context(), c.Writer); err != nil {
 		log.Error("Failed to render admin panel", "error", err)
 	}
 }
@@ -71,11 +73,26 @@ func (h *AdminHandler) AcceptKeepRequest(c *gin.Context) {
 		return
 	}
 
-	err = h.engine.HandleKeepRequest(c.Request.Context(), user.ID, mediaID, true)
+	seasons, err := bindKeepSeasons(c)
+	if err != nil {
+		jsonError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	err = h.engine.HandleKeepRequest(c.Request.Context(), user.ID, mediaID, true, seasons)
+	if errors.Is(err, engine.ErrMaintenanceMode) {
+		jsonError(c, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if errors.Is(err, engine.ErrRequestAlreadyProcessed) {
+		jsonError(c, http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		jsonError(c, http.StatusBadRequest, err.Error())
 		return
 	}
+	h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionKeepRequestApproved, &mediaID, "")
 
 	jsonSuccess(c, "Keep request accepted successfully")
 }
@@ -94,15 +111,70 @@ func (h *AdminHandler) DeclineKeepRequest(c *gin.Context) {
 		return
 	}
 
-	err = h.engine.HandleKeepRequest(c.Request.Context(), user.ID, mediaID, false)
+	err = h.engine.HandleKeepRequest(c.Request.Context(), user.ID, mediaID, false, nil)
+	if errors.Is(err, engine.ErrMaintenanceMode) {
+		jsonError(c, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if errors.Is(err, engine.ErrRequestAlreadyProcessed) {
+		jsonError(c, http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		jsonError(c, http.StatusBadRequest, err.Error())
 		return
 	}
+	h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionKeepRequestDenied, &mediaID, "")
 
 	jsonSuccess(c, "Keep request declined successfully")
 }
 
+// HandleKeepRequests accepts or declines multiple keep requests at once.
+func (h *AdminHandler) HandleKeepRequests(c *gin.Context) {
+	user := getUser(c)
+	if user == nil {
+		return
+	}
+
+	var req struct {
+		MediaIDs []uint `json:"mediaIds"`
+		Accept   *bool  `json:"accept"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || len(req.MediaIDs) == 0 || req.Accept == nil {
+		jsonError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	results, err := h.engine.HandleKeepRequests(c.Request.Context(), user.ID, req.MediaIDs, *req.Accept)
+	if errors.Is(err, engine.ErrMaintenanceMode) {
+		jsonError(c, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if errors.Is(err, engine.ErrRequestAlreadyProcessed) {
+		jsonError(c, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		jsonError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	action := database.AuditActionKeepRequestDenied
+	if *req.Accept {
+		action = database.AuditActionKeepRequestApproved
+	}
+	for _, result := range results {
+		if result.Success {
+			h.engine.RecordAuditEntry(c.Request.Context(), user.Username, action, &result.MediaID, "")
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"results": results,
+	})
+}
+
 // MarkMediaAsProtected marks a media item as protected for a set duration.
 func (h *AdminHandler) MarkMediaAsProtected(c *gin.Context) {
 	user := getUser(c)
@@ -118,10 +190,15 @@ func (h *AdminHandler) MarkMediaAsProtected(c *gin.Context) {
 	}
 
 	err = h.engine.MarkMediaAsProtected(c.Request.Context(), mediaID, user.ID)
+	if errors.Is(err, engine.ErrMaintenanceMode) {
+		jsonError(c, http.StatusServiceUnavailable, err.Error())
+		return
+	}
 	if err != nil {
 		jsonError(c, http.StatusBadRequest, err.Error())
 		return
 	}
+	h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionMediaProtected, &mediaID, "")
 
 	jsonSuccess(c, "Media protected successfully")
 }
@@ -141,10 +218,15 @@ func (h *AdminHandler) MarkMediaAsUnkeepable(c *gin.Context) {
 	}
 
 	err = h.engine.MarkMediaAsUnkeepable(c.Request.Context(), mediaID, user.ID)
+	if errors.Is(err, engine.ErrMaintenanceMode) {
+		jsonError(c, http.StatusServiceUnavailable, err.Error())
+		return
+	}
 	if err != nil {
 		jsonError(c, http.StatusBadRequest, err.Error())
 		return
 	}
+	h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionMediaUnkeepable, &mediaID, "")
 
 	jsonSuccess(c, "Media marked for deletion successfully")
 }
@@ -165,14 +247,90 @@ func (h *AdminHandler) MarkMediaAsKeepForever(c *gin.Context) {
 	}
 
 	err = h.engine.MarkMediaAsKeepForever(c.Request.Context(), mediaID, user.ID)
+	if errors.Is(err, engine.ErrMaintenanceMode) {
+		jsonError(c, http.StatusServiceUnavailable, err.Error())
+		return
+	}
 	if err != nil {
 		jsonError(c, http.StatusBadRequest, err.Error())
 		return
 	}
+	h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionKeepForever, &mediaID, "")
 
 	jsonSuccess(c, "Media protected forever")
 }
 
+// SetMediaIgnored permanently ignores or unignores a media item, independent of the arr ignore tag.
+func (h *AdminHandler) SetMediaIgnored(c *gin.Context) {
+	user := getUser(c)
+	if user == nil {
+		return
+	}
+
+	mediaID, err := parseUintParam(c.Param("id"))
+	if err != nil {
+		jsonError(c, http.StatusBadRequest, "Invalid media ID")
+		return
+	}
+
+	var req struct {
+		Ignored *bool `json:"ignored"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || req.Ignored == nil {
+		jsonError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	err = h.engine.SetMediaIgnored(c.Request.Context(), mediaID, *req.Ignored)
+	if errors.Is(err, engine.ErrMaintenanceMode) {
+		jsonError(c, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if err != nil {
+		jsonError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if *req.Ignored {
+		h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionMediaIgnored, &mediaID, "")
+		jsonSuccess(c, "Media ignored permanently")
+		return
+	}
+	h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionMediaUnignored, &mediaID, "")
+	jsonSuccess(c, "Media no longer ignored")
+}
+
+// ResetDeletionFailure removes the recorded deletion failure of a media item, so a given up deletion is retried.
+func (h *AdminHandler) ResetDeletionFailure(c *gin.Context) {
+	user := getUser(c)
+	if user == nil {
+		return
+	}
+
+	mediaID, err := parseUintParam(c.Param("id"))
+	if err != nil {
+		jsonError(c, http.StatusBadRequest, "Invalid media ID")
+		return
+	}
+
+	err = h.engine.ResetDeletionFailure(c.Request.Context(), mediaID)
+	if errors.Is(err, engine.ErrMaintenanceMode) {
+		jsonError(c, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if errors.Is(err, engine.ErrNoDeletionFailure) {
+		jsonError(c, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		jsonError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionDeletionFailureReset, &mediaID, "")
+
+	jsonSuccess(c, "Deletion failure reset successfully")
+}
+
 // GetKeepRequests returns keep requests as JSON.
 func (h *AdminHandler) GetKeepRequests(c *gin.Context) {
 	requests, err := h.engine.GetMediaWithPendingRequest(c.Request.Context())
@@ -214,24 +372,40 @@ func (h *AdminHandler) GetSchedulerJobs(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"jobs":    jobs,
+		"paused":  h.engine.Paused(),
 	})
 }
 
 // RunSchedulerJob manually triggers a scheduler job.
 func (h *AdminHandler) RunSchedulerJob(c *gin.Context) {
+	user := getUser(c)
+	if user == nil {
+		return
+	}
+
 	jobID := c.Param("id")
 
-	err := h.engine.GetScheduler().RunJobNow(jobID)
+	err := h.engine.RunJobNow(jobID)
+	if errors.Is(err, engine.ErrMaintenanceMode) {
+		jsonError(c, http.StatusServiceUnavailable, err.Error())
+		return
+	}
 	if err != nil {
 		jsonError(c, http.StatusBadRequest, err.Error())
 		return
 	}
+	h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionJobTriggered, nil, jobID)
 
 	jsonSuccess(c, "Job triggered successfully")
 }
 
 // EnableSchedulerJob enables a scheduler job.
 func (h *AdminHandler) EnableSchedulerJob(c *gin.Context) {
+	user := getUser(c)
+	if user == nil {
+		return
+	}
+
 	jobID := c.Param("id")
 
 	err := h.engine.GetScheduler().EnableJob(jobID)
@@ -239,12 +413,18 @@ func (h *AdminHandler) EnableSchedulerJob(c *gin.Context) {
 		jsonError(c, http.StatusBadRequest, err.Error())
 		return
 	}
+	h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionJobEnabled, nil, jobID)
 
 	jsonSuccess(c, "Job enabled successfully")
 }
 
 // DisableSchedulerJob disables a scheduler job.
 func (h *AdminHandler) DisableSchedulerJob(c *gin.Context) {
+	user := getUser(c)
+	if user == nil {
+		return
+	}
+
 	jobID := c.Param("id")
 
 	err := h.engine.GetScheduler().DisableJob(jobID)
@@ -252,22 +432,104 @@ func (h *AdminHandler) DisableSchedulerJob(c *gin.Context) {
 		jsonError(c, http.StatusBadRequest, err.Error())
 		return
 	}
+	h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionJobDisabled, nil, jobID)
 
 	jsonSuccess(c, "Job disabled successfully")
 }
 
+// PauseScheduler suspends all scheduler jobs.
+func (h *AdminHandler) PauseScheduler(c *gin.Context) {
+	user := getUser(c)
+	if user == nil {
+		return
+	}
+
+	if err := h.engine.Pause(c.Request.Context()); err != nil {
+		log.Error("Failed to pause scheduler", "error", err)
+		jsonError(c, http.StatusInternalServerError, "Failed to pause scheduler")
+		return
+	}
+	h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionSchedulerPaused, nil, "")
+
+	jsonSuccess(c, "Scheduler paused successfully")
+}
+
+// ResumeScheduler resumes all scheduler jobs.
+func (h *AdminHandler) ResumeScheduler(c *gin.Context) {
+	user := getUser(c)
+	if user == nil {
+		return
+	}
+
+	if err := h.engine.Resume(c.Request.Context()); err != nil {
+		log.Error("Failed to resume scheduler", "error", err)
+		jsonError(c, http.StatusInternalServerError, "Failed to resume scheduler")
+		return
+	}
+	h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionSchedulerResumed, nil, "")
+
+	jsonSuccess(c, "Scheduler resumed successfully")
+}
+
+// EnableMaintenanceMode enables the maintenance mode.
+func (h *AdminHandler) EnableMaintenanceMode(c *gin.Context) {
+	user := getUser(c)
+	if user == nil {
+		return
+	}
+
+	if err := h.engine.SetMaintenanceMode(c.Request.Context(), true); err != nil {
+		log.Error("Failed to enable maintenance mode", "error", err)
+		jsonError(c, http.StatusInternalServerError, "Failed to enable maintenance mode")
+		return
+	}
+	h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionMaintenanceEnabled, nil, "")
+
+	jsonSuccess(c, "Maintenance mode enabled successfully")
+}
+
+// DisableMaintenanceMode disables the maintenance mode.
+func (h *AdminHandler) DisableMaintenanceMode(c *gin.Context) {
+	user := getUser(c)
+	if user == nil {
+		return
+	}
+
+	if err := h.engine.SetMaintenanceMode(c.Request.Context(), false); err != nil {
+		log.Error("Failed to disable maintenance mode", "error", err)
+		jsonError(c, http.StatusInternalServerError, "Failed to disable maintenance mode")
+		return
+	}
+	h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionMaintenanceDisabled, nil, "")
+
+	jsonSuccess(c, "Maintenance mode disabled successfully")
+}
+
 // GetSchedulerCacheStats returns cache statistics.
 func (h *AdminHandler) GetSchedulerCacheStats(c *gin.Context) {
 	stats := h.engine.GetEngineCache().GetStats()
 
+	imageCacheStats, err := h.engine.GetImageCache().Stats()
+	if err != nil {
+		log.Error("Failed to get image cache stats", "error", err)
+		jsonError(c, http.StatusInternalServerError, "Failed to get image cache stats")
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"stats":   stats,
+		"success":    true,
+		"stats":      stats,
+		"imageCache": imageCacheStats,
 	})
 }
 
 // ClearSchedulerCache clears the engine cache.
 func (h *AdminHandler) ClearSchedulerCache(c *gin.Context) {
+	user := getUser(c)
+	if user == nil {
+		return
+	}
+
 	engineCache := h.engine.GetEngineCache()
 	if engineCache == nil {
 		jsonSuccess(c, "Cache cleared successfully")
@@ -293,15 +555,110 @@ func (h *AdminHandler) ClearSchedulerCache(c *gin.Context) {
 		return nil
 	})
 
+	g.Go(func() error {
+		if err := engineCache.ReadarrTagsCache.Clear(c.Request.Context()); err != nil {
+			log.Error("Failed to clear Readarr tags cache", "error", err)
+			return err
+		}
+		return nil
+	})
+
 	// Wait for all cache clearing operations to complete
 	if err := g.Wait(); err != nil {
 		jsonError(c, http.StatusInternalServerError, "Failed to clear one or more caches")
 		return
 	}
+	h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionCacheCleared, nil, "")
 
 	jsonSuccess(c, "Cache cleared successfully")
 }
 
+// GetOrphanedTags returns the Sonarr and Radarr items with jellysweep tags but without a media item in the database.
+func (h *AdminHandler) GetOrphanedTags(c *gin.Context) {
+	orphans, err := h.engine.FindOrphanedTags(c.Request.Context())
+	if err != nil {
+		log.Error("Failed to find orphaned tags", "error", err)
+		jsonError(c, http.StatusInternalServerError, "Failed to find orphaned tags")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"orphans": orphans,
+	})
+}
+
+// CleanOrphanedTags removes the jellysweep tags from all orphaned Sonarr and Radarr items.
+func (h *AdminHandler) CleanOrphanedTags(c *gin.Context) {
+	user := getUser(c)
+	if user == nil {
+		return
+	}
+
+	cleaned, err := h.engine.CleanOrphanedTags(c.Request.Context())
+	if errors.Is(err, engine.ErrMaintenanceMode) {
+		jsonError(c, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if err != nil {
+		log.Error("Failed to clean orphaned tags", "error", err)
+		jsonError(c, http.StatusInternalServerError, "Failed to clean orphaned tags")
+		return
+	}
+	h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionOrphanedTagsCleaned, nil, fmt.Sprintf("%d items", len(cleaned)))
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"cleaned": cleaned,
+	})
+}
+
+// ForceDeleteExpired deletes all media items whose deletion date has passed, regardless of the disk usage.
+// The request must be confirmed explicitly, a dry run lists the affected items without deleting them.
+func (h *AdminHandler) ForceDeleteExpired(c *gin.Context) {
+	user := getUser(c)
+	if user == nil {
+		return
+	}
+
+	var req struct {
+		Confirm bool `json:"confirm"`
+		DryRun  bool `json:"dryRun"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		jsonError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if !req.Confirm && !req.DryRun {
+		jsonError(c, http.StatusBadRequest, "Forced deletion must be confirmed")
+		return
+	}
+
+	result, err := h.engine.ForceDeleteExpired(c.Request.Context(), req.DryRun)
+	if errors.Is(err, engine.ErrMaintenanceMode) {
+		jsonError(c, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if errors.Is(err, engine.ErrDeletionInProgress) {
+		jsonError(c, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		log.Error("Failed to force delete expired media", "error", err)
+		jsonError(c, http.StatusInternalServerError, "Failed to force delete expired media")
+		return
+	}
+	if !result.DryRun {
+		h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionExpiredForceDeleted, nil, fmt.Sprintf("%d items", len(result.Items)))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"dryRun":  result.DryRun,
+		"items":   models.ToAdminMediaItems(result.Items, h.config),
+	})
+}
+
 // SchedulerPanel shows the scheduler management panel.
 func (h *AdminHandler) SchedulerPanel(c *gin.Context) {
 	user := getUser(c)
@@ -319,7 +676,7 @@ func (h *AdminHandler) SchedulerPanel(c *gin.Context) {
 	}
 
 	c.Header("Content-Type", "text/html")
-	if err := pages.SchedulerPanel(user, jobs, cacheStats, h.config.DryRun).Render(c.Request.Context(), c.Writer); err != nil {
+	if err := pages.SchedulerPanel(user, jobs, cacheStats, h.engine.Paused(), h.config.DryRun, h.engine.MaintenanceMode()).Render(c.Request.Context(), c.Writer); err != nil {
 		log.Error("Failed to render scheduler panel", "error", err)
 	}
 }
@@ -332,7 +689,7 @@ func (h *AdminHandler) HistoryPanel(c *gin.Context) {
 	}
 
 	c.Header("Content-Type", "text/html")
-	if err := pages.HistoryPanel(user, h.config.DryRun).Render(c.Request.Context(), c.Writer); err != nil {
+	if err := pages.HistoryPanel(user, h.config.DryRun, h.engine.MaintenanceMode()).Render(c.Request.Context(), c.Writer); err != nil {
 		log.Error("Failed to render history panel", "error", err)
 	}
 }
@@ -371,6 +728,11 @@ func (h *AdminHandler) GetAllUsers(c *gin.Context) {
 
 // UpdateUserPermissions updates a user's auto-approval permission.
 func (h *AdminHandler) UpdateUserPermissions(c *gin.Context) {
+	user := getUser(c)
+	if user == nil {
+		return
+	}
+
 	userIDVal := c.Param("id")
 	userID, err := parseUintParam(userIDVal)
 	if err != nil {
@@ -393,6 +755,7 @@ func (h *AdminHandler) UpdateUserPermissions(c *gin.Context) {
 		log.Error("Failed to update user permissions", "error", err)
 		return
 	}
+	h.engine.RecordAuditEntry(c.Request.Context(), user.Username, database.AuditActionUserPermissionsUpdated, nil, fmt.Sprintf("user %d: auto approval %t", userID, req.HasAutoApproval))
 
 	jsonSuccess(c, "User permissions updated successfully")
 }
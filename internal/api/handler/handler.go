@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/log"
 	"github.com/gin-contrib/sessions"
@@ -49,16 +52,16 @@ func (h *Handler) Home(c *gin.Context) {
 		requests, err := h.engine.GetMediaWithPendingRequest(c.Request.Context())
 		if err != nil {
 			// Log error but continue without pending count
-			if err := pages.Dashboard(user, userMediaItems, h.config.DryRun).Render(c.Request.Context(), c.Writer); err != nil {
+			if err := pages.Dashboard(user, userMediaItems, h.config.DryRun, h.engine.MaintenanceMode()).Render(c.Request.Context(), c.Writer); err != nil {
 				log.Error("Failed to render dashboard", "error", err)
 			}
 			return
 		}
-		if err := pages.DashboardWithPendingRequests(user, userMediaItems, len(requests), h.config.DryRun).Render(c.Request.Context(), c.Writer); err != nil {
+		if err := pages.DashboardWithPendingRequests(user, userMediaItems, len(requests), h.config.DryRun, h.engine.MaintenanceMode()).Render(c.Request.Context(), c.Writer); err != nil {
 			log.Error("Failed to render dashboard with pending requests", "error", err)
 		}
 	} else {
-		if err := pages.Dashboard(user, userMediaItems, h.config.DryRun).Render(c.Request.Context(), c.Writer); err != nil {
+		if err := pages.Dashboard(user, userMediaItems, h.config.DryRun, h.engine.MaintenanceMode()).Render(c.Request.Context(), c.Writer); err != nil {
 			log.Error("Failed to render dashboard", "error", err)
 		}
 	}
@@ -100,6 +103,29 @@ func parseUintParam(param string) (uint, error) {
 	return uint(id), nil
 }
 
+// KeepSeasonsRequest is the optional request body of a keep request, limiting the protection of a TV series to some seasons.
+type KeepSeasonsRequest struct {
+	Seasons []int32 `json:"seasons"`
+}
+
+// bindKeepSeasons reads the seasons to keep from the request body.
+// Returns nil if the request has no body.
+func bindKeepSeasons(c *gin.Context) ([]int32, error) {
+	if c.Request.ContentLength == 0 {
+		return nil, nil
+	}
+	var req KeepSeasonsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, err
+	}
+	for _, season := range req.Seasons {
+		if season < 0 {
+			return nil, fmt.Errorf("invalid season number: %d", season)
+		}
+	}
+	return req.Seasons, nil
+}
+
 // getUser extracts the authenticated user from the gin context.
 // Returns nil and sends a 401 response if the user is not found.
 func getUser(c *gin.Context) *models.User {
@@ -131,7 +157,17 @@ func (h *Handler) RequestKeepMedia(c *gin.Context) {
 		return
 	}
 
-	autoApproved, err := h.engine.RequestKeepMedia(c.Request.Context(), mediaID, user.ID, user.Username)
+	seasons, err := bindKeepSeasons(c)
+	if err != nil {
+		jsonError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	autoApproved, err := h.engine.RequestKeepMedia(c.Request.Context(), mediaID, user.ID, user.Username, seasons)
+	if errors.Is(err, engine.ErrMaintenanceMode) {
+		jsonError(c, http.StatusServiceUnavailable, err.Error())
+		return
+	}
 	if err != nil {
 		jsonError(c, http.StatusBadRequest, err.Error())
 		return
@@ -178,6 +214,83 @@ func (h *Handler) Me(c *gin.Context) {
 	})
 }
 
+// UpdateNotificationPrefsRequest represents the request body for updating notification preferences.
+// Fields that are omitted keep their current value.
+type UpdateNotificationPrefsRequest struct {
+	Email          *string `json:"email"`
+	EmailEnabled   *bool   `json:"emailEnabled"`
+	WebPushEnabled *bool   `json:"webpushEnabled"`
+	Digest         *bool   `json:"digest"`
+}
+
+// GetNotificationPrefs returns the current user's notification preferences.
+func (h *Handler) GetNotificationPrefs(c *gin.Context) {
+	user := getUser(c)
+	if user == nil {
+		return
+	}
+
+	prefs, err := h.engine.GetUserNotificationPrefs(c.Request.Context(), user.ID)
+	if err != nil {
+		jsonError(c, http.StatusInternalServerError, "Failed to get notification preferences")
+		return
+	}
+	if prefs.Email == "" {
+		prefs.Email = user.Email
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"prefs":   models.ToNotificationPrefs(*prefs),
+	})
+}
+
+// UpdateNotificationPrefs updates the current user's notification preferences.
+func (h *Handler) UpdateNotificationPrefs(c *gin.Context) {
+	user := getUser(c)
+	if user == nil {
+		return
+	}
+
+	var req UpdateNotificationPrefsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		jsonError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	prefs, err := h.engine.GetUserNotificationPrefs(c.Request.Context(), user.ID)
+	if err != nil {
+		jsonError(c, http.StatusInternalServerError, "Failed to get notification preferences")
+		return
+	}
+
+	if req.Email != nil {
+		prefs.Email = strings.TrimSpace(*req.Email)
+	}
+	if prefs.Email == "" {
+		prefs.Email = user.Email
+	}
+	if req.EmailEnabled != nil {
+		prefs.EmailEnabled = *req.EmailEnabled
+	}
+	if req.WebPushEnabled != nil {
+		prefs.WebPushEnabled = *req.WebPushEnabled
+	}
+	if req.Digest != nil {
+		prefs.Digest = *req.Digest
+	}
+
+	if err := h.engine.SetUserNotificationPrefs(c.Request.Context(), prefs); err != nil {
+		jsonError(c, http.StatusInternalServerError, "Failed to update notification preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"prefs":   models.ToNotificationPrefs(*prefs),
+	})
+}
+
 // GetMediaItems returns the current user's media items as JSON.
 func (h *Handler) GetMediaItems(c *gin.Context) {
 	mediaItems, err := h.engine.GetMediaItems(c.Request.Context(), false)
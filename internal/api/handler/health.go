@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Healthz confirms that the process is up, without checking any dependency.
+func (h *Handler) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readyz checks the configured dependencies and responds with 200 only if all mandatory ones are reachable.
+func (h *Handler) Readyz(c *gin.Context) {
+	ready, dependencies := h.engine.CheckReadiness(c.Request.Context())
+
+	status, statusText := http.StatusOK, "ready"
+	if !ready {
+		status, statusText = http.StatusServiceUnavailable, "not_ready"
+	}
+	c.JSON(status, gin.H{
+		"status":       statusText,
+		"dependencies": dependencies,
+	})
+}
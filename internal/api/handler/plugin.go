@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/log"
 	"github.com/gin-gonic/gin"
 	"github.com/jon4hz/jellysweep/internal/database"
 	"github.com/jon4hz/jellysweep/internal/engine"
@@ -93,3 +96,54 @@ func (h *PluginHandler) CheckMediaItem(c *gin.Context) {
 	// No matching item found marked for deletion
 	c.JSON(http.StatusNotFound, gin.H{"error": "Media item not found or not marked for deletion"})
 }
+
+// jellyfinWebhookTypes are the notification types of the Jellyfin webhook plugin that trigger a reevaluation.
+// A stopped playback can protect the item, a newly added episode makes its series recent again.
+var jellyfinWebhookTypes = []string{"PlaybackStop", "ItemAdded"}
+
+// JellyfinWebhookRequest represents the payload sent by the Jellyfin webhook plugin.
+type JellyfinWebhookRequest struct {
+	NotificationType string `json:"NotificationType"`
+	ItemID           string `json:"ItemId"`
+	ItemType         string `json:"ItemType"`
+	SeriesID         string `json:"SeriesId"`
+}
+
+// JellyfinWebhook reevaluates a single media item after it was played in or added to Jellyfin.
+// Other notification types are acknowledged without doing anything.
+func (h *PluginHandler) JellyfinWebhook(c *gin.Context) {
+	var request JellyfinWebhookRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if !slices.Contains(jellyfinWebhookTypes, request.NotificationType) {
+		log.Debug("Ignoring Jellyfin webhook", "notificationType", request.NotificationType)
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	// Episodes are tracked by their series
+	jellyfinID := request.ItemID
+	if request.SeriesID != "" {
+		jellyfinID = request.SeriesID
+	}
+	if strings.TrimSpace(jellyfinID) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ItemId is required"})
+		return
+	}
+
+	removed, err := h.engine.ReevaluateItem(c.Request.Context(), jellyfinID)
+	if errors.Is(err, engine.ErrMaintenanceMode) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Error("Failed to reevaluate media item", "jellyfinID", jellyfinID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reevaluate media item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"removed": removed})
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJellyfinWebhookIgnoresOtherNotificationTypes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewPlugin(nil)
+
+	for _, notificationType := range []string{"PlaybackStart", "UserCreated", "ItemDeleted", ""} {
+		t.Run(notificationType, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			body := `{"NotificationType":"` + notificationType + `","ItemId":"item","ItemType":"Movie"}`
+			c.Request = httptest.NewRequest(http.MethodPost, "/plugin/webhook/jellyfin", strings.NewReader(body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			h.JellyfinWebhook(c)
+			c.Writer.WriteHeaderNow()
+
+			assert.Equal(t, http.StatusNoContent, w.Code)
+			assert.Empty(t, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/gin-gonic/gin"
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/engine"
+)
+
+const (
+	defaultV1Limit = 50
+	maxV1Limit     = 500
+)
+
+// V1Handler serves the versioned JSON API.
+type V1Handler struct {
+	engine *engine.Engine
+}
+
+// NewV1 creates a new V1Handler.
+func NewV1(e *engine.Engine) *V1Handler {
+	return &V1Handler{
+		engine: e,
+	}
+}
+
+// listParams holds the common query parameters of the list endpoints.
+type listParams struct {
+	limit  int
+	offset int
+	since  time.Time
+}
+
+// parseListParams parses the limit, offset and since query parameters.
+// since accepts an RFC3339 timestamp.
+func parseListParams(c *gin.Context) (listParams, error) {
+	params := listParams{limit: defaultV1Limit}
+
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			return params, errors.New("limit must be a positive integer")
+		}
+		params.limit = min(limit, maxV1Limit)
+	}
+
+	if v := c.Query("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return params, errors.New("offset must be a non-negative integer")
+		}
+		params.offset = offset
+	}
+
+	if v := c.Query("since"); v != "" {
+		since, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return params, errors.New("since must be an RFC3339 timestamp")
+		}
+		params.since = since
+	}
+
+	return params, nil
+}
+
+// GetRuns returns the paginated cleanup run history.
+func (h *V1Handler) GetRuns(c *gin.Context) {
+	params, err := parseListParams(c)
+	if err != nil {
+		jsonError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	runs, total, err := h.engine.GetCleanupRunHistory(c.Request.Context(), params.limit, params.offset, params.since)
+	if err != nil {
+		log.Error("Failed to get cleanup runs", "error", err)
+		jsonError(c, http.StatusInternalServerError, "Failed to get cleanup runs")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.CleanupRunsResponse{
+		Items:  models.ToCleanupRunItems(runs),
+		Total:  total,
+		Limit:  params.limit,
+		Offset: params.offset,
+	})
+}
+
+// GetRun returns a single cleanup run with its steps.
+func (h *V1Handler) GetRun(c *gin.Context) {
+	id, err := parseUintParam(c.Param("id"))
+	if err != nil {
+		jsonError(c, http.StatusBadRequest, "Invalid run ID")
+		return
+	}
+
+	run, err := h.engine.GetCleanupRun(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, engine.ErrCleanupRunNotFound) {
+			jsonError(c, http.StatusNotFound, "Cleanup run not found")
+			return
+		}
+		log.Error("Failed to get cleanup run", "id", id, "error", err)
+		jsonError(c, http.StatusInternalServerError, "Failed to get cleanup run")
+		return
+	}
+
+	steps, err := h.engine.GetCleanupRunSteps(c.Request.Context(), run.ID)
+	if err != nil {
+		log.Error("Failed to get cleanup run steps", "id", id, "error", err)
+		jsonError(c, http.StatusInternalServerError, "Failed to get cleanup run steps")
+		return
+	}
+	run.Steps = steps
+
+	c.JSON(http.StatusOK, models.ToCleanupRunItem(*run))
+}
+
+// GetDeletions returns the paginated history of deleted media items.
+func (h *V1Handler) GetDeletions(c *gin.Context) {
+	params, err := parseListParams(c)
+	if err != nil {
+		jsonError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	items, total, err := h.engine.GetMediaDeletionHistory(c.Request.Context(), params.limit, params.offset, params.since)
+	if err != nil {
+		log.Error("Failed to get deletion history", "error", err)
+		jsonError(c, http.StatusInternalServerError, "Failed to get deletion history")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.DeletionsResponse{
+		Items:  models.ToDeletedMediaItems(items),
+		Total:  total,
+		Limit:  params.limit,
+		Offset: params.offset,
+	})
+}
+
+// GetEstimations returns the paginated upcoming deletions sorted by their projected deletion date.
+// since filters by the projected deletion date.
+func (h *V1Handler) GetEstimations(c *gin.Context) {
+	params, err := parseListParams(c)
+	if err != nil {
+		jsonError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	estimates, total, err := h.engine.GetDeletionEstimates(c.Request.Context(), params.limit, params.offset, params.since)
+	if err != nil {
+		log.Error("Failed to get deletion estimates", "error", err)
+		jsonError(c, http.StatusInternalServerError, "Failed to get deletion estimates")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.DeletionEstimatesResponse{
+		Items:  models.ToDeletionEstimateItems(estimates),
+		Total:  total,
+		Limit:  params.limit,
+		Offset: params.offset,
+	})
+}
+
+// GetAudit returns the paginated audit log of admin actions.
+func (h *V1Handler) GetAudit(c *gin.Context) {
+	params, err := parseListParams(c)
+	if err != nil {
+		jsonError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entries, total, err := h.engine.GetAuditLog(c.Request.Context(), params.limit, params.offset, params.since)
+	if err != nil {
+		log.Error("Failed to get audit log", "error", err)
+		jsonError(c, http.StatusInternalServerError, "Failed to get audit log")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.AuditLogResponse{
+		Items:  models.ToAuditLogItems(entries),
+		Total:  total,
+		Limit:  params.limit,
+		Offset: params.offset,
+	})
+}
+
+// GetMarkedDiff returns the items that would be newly marked or no longer be marked compared to the previous runs.
+// The media is gathered and filtered like in a cleanup run, so the request can take a while.
+func (h *V1Handler) GetMarkedDiff(c *gin.Context) {
+	diff, err := h.engine.GetMarkedItemsDiff(c.Request.Context())
+	if err != nil {
+		log.Error("Failed to get marked items diff", "error", err)
+		jsonError(c, http.StatusInternalServerError, "Failed to get marked items diff")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.MarkedItemsDiffResponse{
+		Added:   models.ToMarkedMediaItems(diff.Added),
+		Removed: models.ToMarkedMediaItems(diff.Removed),
+	})
+}
+
+// GetReclaimable returns how much disk space a cleanup run started now would free per library.
+// The media is gathered and filtered like in a cleanup run, so the request can take a while.
+func (h *V1Handler) GetReclaimable(c *gin.Context) {
+	perLibrary, total, err := h.engine.EstimateReclaimable(c.Request.Context())
+	if err != nil {
+		log.Error("Failed to estimate reclaimable disk space", "error", err)
+		jsonError(c, http.StatusInternalServerError, "Failed to estimate reclaimable disk space")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ReclaimableResponse{
+		Libraries: perLibrary,
+		Total:     total,
+	})
+}
+
+// GetStats returns aggregated statistics of the cleanup runs.
+func (h *V1Handler) GetStats(c *gin.Context) {
+	params, err := parseListParams(c)
+	if err != nil {
+		jsonError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	stats, err := h.engine.GetCleanupStats(c.Request.Context(), params.since)
+	if err != nil {
+		log.Error("Failed to get cleanup stats", "error", err)
+		jsonError(c, http.StatusInternalServerError, "Failed to get cleanup stats")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ToCleanupStats(*stats))
+}
+
+// GetJobs returns the run state of all scheduled jobs.
+func (h *V1Handler) GetJobs(c *gin.Context) {
+	c.JSON(http.StatusOK, models.JobsResponse{
+		Items:  models.ToJobItems(h.engine.GetScheduler().JobStatuses()),
+		Paused: h.engine.Paused(),
+	})
+}
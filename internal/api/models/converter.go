@@ -3,6 +3,7 @@ package models
 import (
 	"github.com/jon4hz/jellysweep/internal/config"
 	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/scheduler"
 )
 
 // ToUserMediaItem converts a database.Media to UserMediaItem for regular users.
@@ -17,6 +18,7 @@ func ToUserMediaItem(m database.Media, cfg *config.Config) UserMediaItem {
 		FileSize:        m.FileSize,
 		DefaultDeleteAt: m.DefaultDeleteAt,
 		Unkeepable:      m.Unkeepable,
+		DeletionReason:  m.DeletionReason,
 	}
 
 	// Add cleanup mode and keep count for TV series
@@ -62,6 +64,8 @@ func ToAdminMediaItem(m database.Media, cfg *config.Config) AdminMediaItem {
 		DefaultDeleteAt: m.DefaultDeleteAt,
 		ProtectedUntil:  m.ProtectedUntil,
 		Unkeepable:      m.Unkeepable,
+		DeletionReason:  m.DeletionReason,
+		KeptSeasons:     m.KeptSeasons,
 	}
 
 	// Add cleanup mode and keep count for TV series
@@ -73,12 +77,13 @@ func ToAdminMediaItem(m database.Media, cfg *config.Config) AdminMediaItem {
 	// Include full request info for admins
 	if m.Request.ID != 0 {
 		item.Request = &AdminRequestInfo{
-			ID:        m.Request.ID,
-			UserID:    m.Request.UserID,
-			Username:  m.Request.User.Username,
-			Status:    string(m.Request.Status),
-			CreatedAt: m.Request.CreatedAt,
-			UpdatedAt: m.Request.UpdatedAt,
+			ID:          m.Request.ID,
+			UserID:      m.Request.UserID,
+			Username:    m.Request.User.Username,
+			Status:      string(m.Request.Status),
+			CreatedAt:   m.Request.CreatedAt,
+			UpdatedAt:   m.Request.UpdatedAt,
+			KeptSeasons: m.Request.KeptSeasons,
 		}
 	}
 
@@ -125,3 +130,165 @@ func ToHistoryEventItems(items []database.HistoryEvent) []HistoryEventItem {
 	}
 	return result
 }
+
+// ToCleanupRunItem converts a database.CleanupRun to CleanupRunItem.
+func ToCleanupRunItem(r database.CleanupRun) CleanupRunItem {
+	item := CleanupRunItem{
+		ID:           r.ID,
+		StartTime:    r.StartTime,
+		EndTime:      r.EndTime,
+		Status:       string(r.Status),
+		Error:        r.Error,
+		DryRun:       r.DryRun,
+		ItemsMarked:  r.ItemsMarked,
+		ItemsDeleted: r.ItemsDeleted,
+		BytesFreed:   r.BytesFreed,
+	}
+	if len(r.Steps) > 0 {
+		item.Steps = ToCleanupRunStepItems(r.Steps)
+	}
+	return item
+}
+
+// ToCleanupRunItems converts a slice of database.CleanupRun to CleanupRunItems.
+func ToCleanupRunItems(runs []database.CleanupRun) []CleanupRunItem {
+	result := make([]CleanupRunItem, len(runs))
+	for i, run := range runs {
+		result[i] = ToCleanupRunItem(run)
+	}
+	return result
+}
+
+// ToCleanupRunStepItems converts a slice of database.CleanupRunStep to CleanupRunStepItems.
+func ToCleanupRunStepItems(steps []database.CleanupRunStep) []CleanupRunStepItem {
+	result := make([]CleanupRunStepItem, len(steps))
+	for i, step := range steps {
+		result[i] = CleanupRunStepItem{
+			Name:           step.Name,
+			StartTime:      step.StartTime,
+			EndTime:        step.EndTime,
+			Status:         string(step.Status),
+			Error:          step.Error,
+			ItemsProcessed: step.ItemsProcessed,
+		}
+	}
+	return result
+}
+
+// ToDeletedMediaItems converts a slice of deleted database.Media to DeletedMediaItems.
+func ToDeletedMediaItems(items []database.Media) []DeletedMediaItem {
+	result := make([]DeletedMediaItem, len(items))
+	for i, m := range items {
+		result[i] = DeletedMediaItem{
+			ID:          m.ID,
+			JellyfinID:  m.JellyfinID,
+			Title:       m.Title,
+			Year:        m.Year,
+			MediaType:   MediaType(m.MediaType),
+			LibraryName: m.LibraryName,
+			FileSize:    m.FileSize,
+			RequestedBy: m.RequestedBy,
+			DeletedAt:   m.DeletedAt.Time,
+		}
+	}
+	return result
+}
+
+// ToDeletionEstimateItems converts a slice of database.DeletionEstimate to DeletionEstimateItems.
+func ToDeletionEstimateItems(estimates []database.DeletionEstimate) []DeletionEstimateItem {
+	result := make([]DeletionEstimateItem, len(estimates))
+	for i, e := range estimates {
+		result[i] = DeletionEstimateItem{
+			MediaID:           e.MediaID,
+			Title:             e.Title,
+			Year:              e.Year,
+			MediaType:         MediaType(e.MediaType),
+			LibraryName:       e.LibraryName,
+			FileSize:          e.FileSize,
+			EstimatedDeleteAt: e.EstimatedDeleteAt,
+		}
+	}
+	return result
+}
+
+// ToAuditLogItems converts a slice of database.AuditLogEntry to AuditLogItems.
+func ToAuditLogItems(entries []database.AuditLogEntry) []AuditLogItem {
+	result := make([]AuditLogItem, len(entries))
+	for i, e := range entries {
+		result[i] = AuditLogItem{
+			ID:        e.ID,
+			Actor:     e.Actor,
+			Action:    string(e.Action),
+			MediaID:   e.MediaID,
+			Target:    e.Target,
+			EventTime: e.EventTime,
+		}
+		if e.Media != nil {
+			result[i].Title = e.Media.Title
+		}
+	}
+	return result
+}
+
+// ToMarkedMediaItems converts a slice of database.Media to MarkedMediaItems.
+func ToMarkedMediaItems(items []database.Media) []MarkedMediaItem {
+	result := make([]MarkedMediaItem, len(items))
+	for i, m := range items {
+		result[i] = MarkedMediaItem{
+			JellyfinID:  m.JellyfinID,
+			Title:       m.Title,
+			Year:        m.Year,
+			MediaType:   MediaType(m.MediaType),
+			LibraryName: m.LibraryName,
+			FileSize:    m.FileSize,
+			RequestedBy: m.RequestedBy,
+		}
+	}
+	return result
+}
+
+// ToCleanupStats converts database.CleanupStats to CleanupStats.
+func ToCleanupStats(s database.CleanupStats) CleanupStats {
+	return CleanupStats{
+		TotalRuns:    s.TotalRuns,
+		FailedRuns:   s.FailedRuns,
+		ItemsMarked:  s.ItemsMarked,
+		ItemsDeleted: s.ItemsDeleted,
+		BytesFreed:   s.BytesFreed,
+		LastRunAt:    s.LastRunAt,
+	}
+}
+
+// ToNotificationPrefs converts database notification preferences to the API model.
+func ToNotificationPrefs(p database.UserNotificationPrefs) NotificationPrefs {
+	return NotificationPrefs{
+		Email:          p.Email,
+		EmailEnabled:   p.EmailEnabled,
+		WebPushEnabled: p.WebPushEnabled,
+		Digest:         p.Digest,
+	}
+}
+
+// ToJobItems converts scheduler job statuses to JobItems.
+func ToJobItems(statuses []scheduler.JobRunStatus) []JobItem {
+	result := make([]JobItem, 0, len(statuses))
+	for _, s := range statuses {
+		item := JobItem{
+			ID:         s.ID,
+			Name:       s.Name,
+			Schedule:   s.Schedule,
+			Enabled:    s.Enabled,
+			Running:    s.Running,
+			LastStatus: string(s.LastStatus),
+			LastError:  s.LastError,
+		}
+		if !s.LastRun.IsZero() {
+			item.LastRun = &s.LastRun
+		}
+		if !s.NextRun.IsZero() {
+			item.NextRun = &s.NextRun
+		}
+		result = append(result, item)
+	}
+	return result
+}
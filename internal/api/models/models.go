@@ -17,6 +17,7 @@ type MediaType string
 const (
 	MediaTypeTV    MediaType = "tv"
 	MediaTypeMovie MediaType = "movie"
+	MediaTypeBook  MediaType = "book"
 )
 
 // UserMediaItem represents media information exposed to regular users.
@@ -29,6 +30,8 @@ type UserMediaItem struct {
 	FileSize        int64     `json:"FileSize"`
 	DefaultDeleteAt time.Time `json:"DefaultDeleteAt"`
 	Unkeepable      bool      `json:"Unkeepable"`
+	// Reason why the media was marked for deletion
+	DeletionReason string `json:"DeletionReason,omitempty"`
 	// Cleanup mode for TV series (only applies to MediaTypeTV)
 	CleanupMode string `json:"CleanupMode,omitempty"`
 	// Keep count for TV series cleanup (only applies to MediaTypeTV)
@@ -59,6 +62,10 @@ type AdminMediaItem struct {
 	DefaultDeleteAt time.Time  `json:"DefaultDeleteAt"`
 	ProtectedUntil  *time.Time `json:"ProtectedUntil,omitempty"`
 	Unkeepable      bool       `json:"Unkeepable"`
+	// Reason why the media was marked for deletion
+	DeletionReason string `json:"DeletionReason,omitempty"`
+	// Seasons protected by a season-level keep request (only applies to MediaTypeTV)
+	KeptSeasons []int32 `json:"KeptSeasons,omitempty"`
 	// Cleanup mode for TV series (only applies to MediaTypeTV)
 	CleanupMode string `json:"CleanupMode,omitempty"`
 	// Keep count for TV series cleanup (only applies to MediaTypeTV)
@@ -75,6 +82,8 @@ type AdminRequestInfo struct {
 	Status    string    `json:"Status"`
 	CreatedAt time.Time `json:"CreatedAt"`
 	UpdatedAt time.Time `json:"UpdatedAt"`
+	// Seasons the user asked to keep, empty for the whole media
+	KeptSeasons []int32 `json:"KeptSeasons,omitempty"`
 }
 
 // HistoryEventItem represents a history event for display.
@@ -101,3 +110,154 @@ type HistoryResponse struct {
 	PageSize   int                `json:"pageSize"`
 	TotalPages int                `json:"totalPages"`
 }
+
+// CleanupRunItem represents a cleanup run in the API.
+type CleanupRunItem struct {
+	ID           uint                 `json:"id"`
+	StartTime    time.Time            `json:"startTime"`
+	EndTime      *time.Time           `json:"endTime,omitempty"`
+	Status       string               `json:"status"`
+	Error        string               `json:"error,omitempty"`
+	DryRun       bool                 `json:"dryRun"`
+	ItemsMarked  int                  `json:"itemsMarked"`
+	ItemsDeleted int                  `json:"itemsDeleted"`
+	BytesFreed   int64                `json:"bytesFreed"`
+	Steps        []CleanupRunStepItem `json:"steps,omitempty"`
+}
+
+// CleanupRunStepItem represents a single step of a cleanup run in the API.
+type CleanupRunStepItem struct {
+	Name           string     `json:"name"`
+	StartTime      time.Time  `json:"startTime"`
+	EndTime        *time.Time `json:"endTime,omitempty"`
+	Status         string     `json:"status"`
+	Error          string     `json:"error,omitempty"`
+	ItemsProcessed int        `json:"itemsProcessed"`
+}
+
+// JobItem represents the run state of a scheduled job in the API.
+type JobItem struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Schedule   string     `json:"schedule"`
+	Enabled    bool       `json:"enabled"`
+	Running    bool       `json:"running"`
+	LastRun    *time.Time `json:"lastRun,omitempty"`
+	LastStatus string     `json:"lastStatus,omitempty"`
+	LastError  string     `json:"lastError,omitempty"`
+	NextRun    *time.Time `json:"nextRun,omitempty"`
+}
+
+// JobsResponse represents the response for the scheduled jobs.
+type JobsResponse struct {
+	Items  []JobItem `json:"items"`
+	Paused bool      `json:"paused"`
+}
+
+// CleanupRunsResponse represents the paginated response for cleanup runs.
+type CleanupRunsResponse struct {
+	Items  []CleanupRunItem `json:"items"`
+	Total  int64            `json:"total"`
+	Limit  int              `json:"limit"`
+	Offset int              `json:"offset"`
+}
+
+// DeletedMediaItem represents a media item deleted by jellysweep.
+type DeletedMediaItem struct {
+	ID          uint      `json:"id"`
+	JellyfinID  string    `json:"jellyfinId"`
+	Title       string    `json:"title"`
+	Year        int32     `json:"year"`
+	MediaType   MediaType `json:"mediaType"`
+	LibraryName string    `json:"libraryName"`
+	FileSize    int64     `json:"fileSize"`
+	RequestedBy string    `json:"requestedBy,omitempty"`
+	DeletedAt   time.Time `json:"deletedAt"`
+}
+
+// DeletionsResponse represents the paginated response for deleted media items.
+type DeletionsResponse struct {
+	Items  []DeletedMediaItem `json:"items"`
+	Total  int64              `json:"total"`
+	Limit  int                `json:"limit"`
+	Offset int                `json:"offset"`
+}
+
+// DeletionEstimateItem represents a media item with its projected deletion date.
+type DeletionEstimateItem struct {
+	MediaID           uint      `json:"mediaId"`
+	Title             string    `json:"title"`
+	Year              int32     `json:"year"`
+	MediaType         MediaType `json:"mediaType"`
+	LibraryName       string    `json:"libraryName"`
+	FileSize          int64     `json:"fileSize"`
+	EstimatedDeleteAt time.Time `json:"estimatedDeleteAt"`
+}
+
+// DeletionEstimatesResponse represents the paginated response for upcoming deletions.
+type DeletionEstimatesResponse struct {
+	Items  []DeletionEstimateItem `json:"items"`
+	Total  int64                  `json:"total"`
+	Limit  int                    `json:"limit"`
+	Offset int                    `json:"offset"`
+}
+
+// AuditLogItem represents an action performed by an admin.
+type AuditLogItem struct {
+	ID        uint      `json:"id"`
+	Actor     string    `json:"actor"`
+	Action    string    `json:"action"`
+	MediaID   *uint     `json:"mediaId,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	Target    string    `json:"target,omitempty"`
+	EventTime time.Time `json:"eventTime"`
+}
+
+// AuditLogResponse represents the paginated response for the audit log.
+type AuditLogResponse struct {
+	Items  []AuditLogItem `json:"items"`
+	Total  int64          `json:"total"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+// MarkedMediaItem represents a media item that is or was marked for deletion.
+type MarkedMediaItem struct {
+	JellyfinID  string    `json:"jellyfinId"`
+	Title       string    `json:"title"`
+	Year        int32     `json:"year"`
+	MediaType   MediaType `json:"mediaType"`
+	LibraryName string    `json:"libraryName"`
+	FileSize    int64     `json:"fileSize"`
+	RequestedBy string    `json:"requestedBy,omitempty"`
+}
+
+// MarkedItemsDiffResponse represents the changes of the marked items since the previous run.
+type MarkedItemsDiffResponse struct {
+	Added   []MarkedMediaItem `json:"added"`
+	Removed []MarkedMediaItem `json:"removed"`
+}
+
+// ReclaimableResponse represents the disk space a cleanup run started now would free, in bytes.
+type ReclaimableResponse struct {
+	Libraries map[string]int64 `json:"libraries"`
+	Total     int64            `json:"total"`
+}
+
+// CleanupStats represents aggregated statistics about the cleanup runs.
+type CleanupStats struct {
+	TotalRuns    int64      `json:"totalRuns"`
+	FailedRuns   int64      `json:"failedRuns"`
+	ItemsMarked  int64      `json:"itemsMarked"`
+	ItemsDeleted int64      `json:"itemsDeleted"`
+	BytesFreed   int64      `json:"bytesFreed"`
+	LastRunAt    *time.Time `json:"lastRunAt,omitempty"`
+}
+
+// NotificationPrefs represents the notification preferences of a user.
+type NotificationPrefs struct {
+	Email          string `json:"email"`
+	EmailEnabled   bool   `json:"emailEnabled"`
+	WebPushEnabled bool   `json:"webpushEnabled"`
+	Digest         bool   `json:"digest"`
+}
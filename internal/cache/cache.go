@@ -21,6 +21,8 @@ type PrefixedCache[T any] struct {
 	cacheType config.CacheType
 	cache     *cache.Cache[any]
 	prefix    string
+	// redis is set for redis caches, so Clear only removes the keys with the prefix instead of flushing the whole server.
+	redis *redis.Client
 }
 
 // NewPrefixedCache creates a new prefixed cache wrapper.
@@ -75,7 +77,11 @@ func (p *PrefixedCache[T]) Invalidate(ctx context.Context, options ...store.Inva
 }
 
 // Clear removes all values from the cache.
+// For redis caches only the keys with the prefix are removed.
 func (p *PrefixedCache[T]) Clear(ctx context.Context) error {
+	if p.redis != nil {
+		return deleteRedisKeys(ctx, p.redis, p.prefix+"*")
+	}
 	return p.cache.Clear(ctx)
 }
 
@@ -96,10 +102,37 @@ func newMemoryCache[T any]() *cache.Cache[T] {
 	return cache.New[T](gocacheStore)
 }
 
-func newRedisCache[T any](cfg *config.CacheConfig) *cache.Cache[T] {
-	redisClient := redis.NewClient(&redis.Options{
+// PingRedis checks whether the redis server of the cache config is reachable.
+func PingRedis(ctx context.Context, cfg *config.CacheConfig) error {
+	redisClient := newRedisClient(cfg)
+	defer redisClient.Close() //nolint:errcheck
+	return redisClient.Ping(ctx).Err()
+}
+
+func newRedisClient(cfg *config.CacheConfig) *redis.Client {
+	return redis.NewClient(&redis.Options{
 		Addr: cfg.RedisURL,
 	})
-	redisStore := redis_store.NewRedis(redisClient, store.WithExpiration(time.Hour))
+}
+
+// newRedisCache creates a redis cache whose entries expire after the configured TTL.
+func newRedisCache[T any](redisClient *redis.Client, cfg *config.CacheConfig) *cache.Cache[T] {
+	redisStore := redis_store.NewRedis(redisClient, store.WithExpiration(time.Duration(cfg.TTLSeconds)*time.Second))
 	return cache.New[T](redisStore)
 }
+
+// deleteRedisKeys deletes all keys matching the pattern.
+func deleteRedisKeys(ctx context.Context, redisClient *redis.Client, pattern string) error {
+	iter := redisClient.Scan(ctx, 0, pattern, 100).Iterator()
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("failed to scan redis keys: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return redisClient.Del(ctx, keys...).Err()
+}
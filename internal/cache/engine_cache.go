@@ -4,9 +4,9 @@ import (
 	"context"
 
 	"github.com/charmbracelet/log"
-	"github.com/eko/gocache/lib/v4/cache"
 	"github.com/eko/gocache/lib/v4/codec"
 	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/pkg/tmdb"
 	jellyfin "github.com/sj14/jellyfin-go/api"
 )
 
@@ -31,25 +31,25 @@ const (
 	SonarrTagsCachePrefix    = "sonarr-tags-"
 	RadarrItemsCachePrefix   = "radarr-items-"
 	RadarrTagsCachePrefix    = "radarr-tags-"
+	ReadarrTagsCachePrefix   = "readarr-tags-"
+	TMDBDetailsCachePrefix   = "tmdb-details-"
 )
 
 type EngineCache struct {
 	SonarrTagsCache *PrefixedCache[TagMap]
 	RadarrTagsCache *PrefixedCache[TagMap]
+	// ReadarrTagsCache holds the tags of the Readarr authors.
+	ReadarrTagsCache *PrefixedCache[TagMap]
+	// TMDBDetailsCache isn't cleared between cleanup runs, since the metadata rarely changes.
+	TMDBDetailsCache *PrefixedCache[tmdb.Details]
 }
 
 func NewEngineCache(cfg *config.CacheConfig) (*EngineCache, error) {
 	return &EngineCache{
-		SonarrTagsCache: NewPrefixedCache[TagMap](
-			newCacheInstanceByType(cfg),
-			cfg.Type,
-			SonarrTagsCachePrefix,
-		),
-		RadarrTagsCache: NewPrefixedCache[TagMap](
-			newCacheInstanceByType(cfg),
-			cfg.Type,
-			RadarrTagsCachePrefix,
-		),
+		SonarrTagsCache:  newPrefixedCacheByType[TagMap](cfg, SonarrTagsCachePrefix),
+		RadarrTagsCache:  newPrefixedCacheByType[TagMap](cfg, RadarrTagsCachePrefix),
+		ReadarrTagsCache: newPrefixedCacheByType[TagMap](cfg, ReadarrTagsCachePrefix),
+		TMDBDetailsCache: newPrefixedCacheByType[tmdb.Details](cfg, TMDBDetailsCachePrefix),
 	}, nil
 }
 
@@ -57,6 +57,7 @@ func (e *EngineCache) ClearAll(ctx context.Context) {
 	errs := []error{
 		e.SonarrTagsCache.Clear(ctx),
 		e.RadarrTagsCache.Clear(ctx),
+		e.ReadarrTagsCache.Clear(ctx),
 	}
 	for _, err := range errs {
 		if err != nil {
@@ -65,14 +66,17 @@ func (e *EngineCache) ClearAll(ctx context.Context) {
 	}
 }
 
-func newCacheInstanceByType(cfg *config.CacheConfig) *cache.Cache[any] {
+// newPrefixedCacheByType creates a prefixed cache of the configured type.
+// Redis keys are additionally namespaced with the configured redis prefix.
+func newPrefixedCacheByType[T any](cfg *config.CacheConfig, prefix string) *PrefixedCache[T] {
 	switch cfg.Type {
-	case config.CacheTypeMemory:
-		return newMemoryCache[any]()
 	case config.CacheTypeRedis:
-		return newRedisCache[any](cfg)
+		redisClient := newRedisClient(cfg)
+		c := NewPrefixedCache[T](newRedisCache[any](redisClient, cfg), cfg.Type, cfg.RedisPrefix+prefix)
+		c.redis = redisClient
+		return c
 	default:
-		return newMemoryCache[any]()
+		return NewPrefixedCache[T](newMemoryCache[any](), cfg.Type, prefix)
 	}
 }
 
@@ -91,5 +95,13 @@ func (e *EngineCache) GetStats() []*Stats {
 			Stats:     e.RadarrTagsCache.GetStats(),
 			CacheName: "radarr-tags",
 		},
+		{
+			Stats:     e.ReadarrTagsCache.GetStats(),
+			CacheName: "readarr-tags",
+		},
+		{
+			Stats:     e.TMDBDetailsCache.GetStats(),
+			CacheName: "tmdb-details",
+		},
 	}
 }
@@ -0,0 +1,25 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPrefixedCacheByType(t *testing.T) {
+	redisCache := newPrefixedCacheByType[TagMap](&config.CacheConfig{
+		Type:        config.CacheTypeRedis,
+		RedisURL:    "localhost:6379",
+		RedisPrefix: "jellysweep:",
+	}, SonarrTagsCachePrefix)
+	assert.Equal(t, "jellysweep:sonarr-tags-", redisCache.prefix)
+	assert.NotNil(t, redisCache.redis)
+
+	memoryCache := newPrefixedCacheByType[TagMap](&config.CacheConfig{
+		Type:        config.CacheTypeMemory,
+		RedisPrefix: "jellysweep:",
+	}, SonarrTagsCachePrefix)
+	assert.Equal(t, "sonarr-tags-", memoryCache.prefix)
+	assert.Nil(t, memoryCache.redis)
+}
@@ -3,16 +3,20 @@ package cache
 import (
 	"context"
 	"crypto/md5" //nolint:gosec
+	"errors"
 	"fmt"
 	"image"
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/disintegration/imaging"
+	"github.com/jon4hz/jellysweep/internal/config"
 	"github.com/jon4hz/jellysweep/internal/database"
 )
 
@@ -20,28 +24,68 @@ type ImageCache struct {
 	cacheDir  string
 	client    *http.Client
 	db        database.MediaDB
-	maxWidth  int // Maximum width for scaled images
-	maxHeight int // Maximum height for scaled images
-	quality   int // JPEG quality (1-100)
+	maxWidth  int           // Maximum width for scaled images
+	maxHeight int           // Maximum height for scaled images
+	quality   int           // JPEG quality (1-100)
+	ttl       time.Duration // Age after which an image is downloaded again (0 = never)
+	maxSize   int64         // Maximum total size of the cache in bytes (0 = unlimited)
+	maxAge    time.Duration // Max age of the served images in the browser cache (0 = always revalidate)
+
+	// mu guards the size and the access times. It's also held while images are evicted or opened to be served,
+	// so an image isn't removed while it's being opened.
+	mu         sync.Mutex
+	size       int64                // Current total size of the cache in bytes
+	lastAccess map[string]time.Time // Last access of the cached files, used for LRU eviction
+}
+
+// ImageCacheStats holds statistics about the image cache.
+type ImageCacheStats struct {
+	Path         string `json:"path"`
+	Files        int    `json:"files"`
+	SizeBytes    int64  `json:"sizeBytes"`
+	MaxSizeBytes int64  `json:"maxSizeBytes"`
+	TTLDays      int    `json:"ttlDays"`
+}
+
+// imageCacheEntry is a file in the image cache.
+type imageCacheEntry struct {
+	name       string
+	size       int64
+	modTime    time.Time
+	lastAccess time.Time
 }
 
 // NewImageCache creates a new image cache manager with scaling options.
-func NewImageCache(cacheDir string, db database.MediaDB) *ImageCache {
+func NewImageCache(cfg *config.ImageCacheConfig, db database.MediaDB) *ImageCache {
 	// Create cache directory if it doesn't exist
-	if err := os.MkdirAll(cacheDir, 0o755); err != nil { //nolint:gosec
+	if err := os.MkdirAll(cfg.Path, 0o755); err != nil { //nolint:gosec
 		log.Error("failed to create cache directory", "error", err)
 	}
 
-	return &ImageCache{
-		cacheDir:  cacheDir,
+	ic := &ImageCache{
+		cacheDir:  cfg.Path,
 		maxWidth:  340, // Default max width: 340px
 		maxHeight: 500, // Default max height: 500px
 		quality:   85,  // Default JPEG quality: 85%
+		ttl:       time.Duration(cfg.TTLDays) * 24 * time.Hour,
+		maxSize:   int64(cfg.MaxSizeMB) * 1024 * 1024,
+		maxAge:    time.Duration(cfg.MaxAgeSeconds) * time.Second,
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		db: db,
+		db:         db,
+		lastAccess: make(map[string]time.Time),
+	}
+
+	entries, err := ic.entries()
+	if err != nil {
+		log.Error("failed to read image cache directory", "error", err)
+	}
+	for _, entry := range entries {
+		ic.size += entry.size
 	}
+
+	return ic
 }
 
 // getCacheKey generates a cache key from the image URL.
@@ -69,10 +113,14 @@ func (ic *ImageCache) GetCachedImagePath(ctx context.Context, imageURL string) (
 
 	cacheFilePath := ic.getCacheFilePath(imageURL)
 
-	// Check if file already exists
-	if _, err := os.Stat(cacheFilePath); err == nil {
-		log.Debug("using cached image", "path", cacheFilePath)
-		return cacheFilePath, nil
+	// Check if file already exists and isn't expired yet
+	if info, err := os.Stat(cacheFilePath); err == nil {
+		if !ic.expired(info.ModTime()) {
+			log.Debug("using cached image", "path", cacheFilePath)
+			ic.touch(info.Name())
+			return cacheFilePath, nil
+		}
+		log.Debug("cached image expired", "path", cacheFilePath)
 	}
 
 	// Download and cache the image
@@ -147,12 +195,22 @@ func (ic *ImageCache) downloadAndCache(ctx context.Context, imageURL, cacheFileP
 		return "", fmt.Errorf("failed to save processed image: %w", err)
 	}
 
+	// Remember the size of an expired image that is replaced
+	var oldSize int64
+	if info, err := os.Stat(cacheFilePath); err == nil {
+		oldSize = info.Size()
+	}
+
 	// Move the temporary file to the final location (atomic operation)
 	err = os.Rename(tempFilePath, cacheFilePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to move temp file: %w", err)
 	}
 
+	if info, err := os.Stat(cacheFilePath); err == nil {
+		ic.added(info.Name(), info.Size()-oldSize)
+	}
+
 	if needsResize {
 		log.Info("cached and resized image", "url", imageURL, "path", cacheFilePath, "original", fmt.Sprintf("%dx%d", originalWidth, originalHeight), "cached", fmt.Sprintf("%dx%d", processedImg.Bounds().Dx(), processedImg.Bounds().Dy()))
 	} else {
@@ -200,7 +258,14 @@ func (ic *ImageCache) ServeImage(ctx context.Context, mediaID uint, w http.Respo
 	}
 
 	// Open the cached file
-	file, err := os.Open(cacheFilePath)
+	file, err := ic.open(cacheFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		// the image was evicted in the meantime, download it again
+		cacheFilePath, err = ic.downloadAndCache(ctx, media.PosterURL, cacheFilePath)
+		if err == nil {
+			file, err = ic.open(cacheFilePath)
+		}
+	}
 	if err != nil {
 		log.Error("failed to open cached image", "error", err)
 		http.Error(w, "Failed to open image", http.StatusInternalServerError)
@@ -231,27 +296,106 @@ func (ic *ImageCache) ServeImage(ctx context.Context, mediaID uint, w http.Respo
 		w.Header().Set("Content-Type", "application/octet-stream")
 	}
 
-	// Set cache headers (cache for 24 hours)
-	w.Header().Set("Cache-Control", "public, max-age=86400")
-	w.Header().Set("Last-Modified", fileInfo.ModTime().Format(http.TimeFormat))
-
-	// Check if client has cached version
-	if modifiedSince := r.Header.Get("If-Modified-Since"); modifiedSince != "" {
-		if t, err := time.Parse(http.TimeFormat, modifiedSince); err == nil {
-			if fileInfo.ModTime().Before(t.Add(1 * time.Second)) {
-				w.WriteHeader(http.StatusNotModified)
-				return nil
-			}
-		}
-	}
+	// Let browsers cache the image and revalidate it with the ETag or modification time,
+	// http.ServeContent answers conditional requests with 304 Not Modified.
+	w.Header().Set("Cache-Control", ic.cacheControl())
+	w.Header().Set("ETag", imageETag(fileInfo))
 
 	// Serve the file
 	http.ServeContent(w, r, fileInfo.Name(), fileInfo.ModTime(), file)
 	return nil
 }
 
-// Clear clears the image cache directory without removing the directory itself.
-func (ic *ImageCache) Clear(ctx context.Context) error {
+// cacheControl returns the Cache-Control header of the served images.
+func (ic *ImageCache) cacheControl() string {
+	if ic.maxAge <= 0 {
+		return "public, no-cache"
+	}
+	return fmt.Sprintf("public, max-age=%d", int(ic.maxAge.Seconds()))
+}
+
+// imageETag returns a strong ETag of a cached image file.
+// The file name is derived from the image URL, so the ETag changes with the poster as well as when it's downloaded again.
+func imageETag(info os.FileInfo) string {
+	return fmt.Sprintf(`"%s-%x-%x"`, strings.TrimSuffix(info.Name(), filepath.Ext(info.Name())), info.ModTime().UnixNano(), info.Size())
+}
+
+// expired reports whether an image cached at modTime is older than the TTL.
+func (ic *ImageCache) expired(modTime time.Time) bool {
+	return ic.ttl > 0 && time.Since(modTime) > ic.ttl
+}
+
+// open opens a cached image while holding the cache lock, so it isn't evicted at the same time.
+// Once opened, the image can be served even if it's evicted afterwards.
+func (ic *ImageCache) open(path string) (*os.File, error) {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	return os.Open(path) //nolint:gosec
+}
+
+// touch records an access to a cached file.
+func (ic *ImageCache) touch(name string) {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	ic.lastAccess[name] = time.Now()
+}
+
+// added records a newly cached file and evicts the least recently used images if the cache is too large.
+func (ic *ImageCache) added(name string, sizeDelta int64) {
+	ic.mu.Lock()
+	ic.lastAccess[name] = time.Now()
+	ic.size += sizeDelta
+	exceeded := ic.maxSize > 0 && ic.size > ic.maxSize
+	ic.mu.Unlock()
+
+	if exceeded {
+		if err := ic.Cleanup(context.Background()); err != nil {
+			log.Error("failed to enforce image cache size limit", "error", err)
+		}
+	}
+}
+
+// entries returns all files in the image cache.
+func (ic *ImageCache) entries() ([]imageCacheEntry, error) {
+	dirEntries, err := os.ReadDir(ic.cacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	entries := make([]imageCacheEntry, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			// the file was removed in the meantime
+			continue
+		}
+		lastAccess, ok := ic.lastAccess[info.Name()]
+		if !ok {
+			lastAccess = info.ModTime()
+		}
+		entries = append(entries, imageCacheEntry{
+			name:       info.Name(),
+			size:       info.Size(),
+			modTime:    info.ModTime(),
+			lastAccess: lastAccess,
+		})
+	}
+	return entries, nil
+}
+
+// Cleanup removes expired images and evicts the least recently used images until the cache fits the size limit.
+func (ic *ImageCache) Cleanup(ctx context.Context) error {
+	entries, err := ic.entries()
+	if err != nil {
+		return err
+	}
+
 	root, err := os.OpenRoot(ic.cacheDir)
 	if err != nil {
 		return err
@@ -262,30 +406,93 @@ func (ic *ImageCache) Clear(ctx context.Context) error {
 		}
 	}()
 
-	return filepath.Walk(ic.cacheDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
+	// the least recently used images are evicted first
+	slices.SortFunc(entries, func(a, b imageCacheEntry) int {
+		return a.lastAccess.Compare(b.lastAccess)
+	})
 
+	var total int64
+	for _, entry := range entries {
+		total += entry.size
+	}
+
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	var removed int
+	for _, entry := range entries {
 		select {
 		case <-ctx.Done():
-			log.Warn("Clearing image cache interrupted by context cancellation")
+			log.Warn("Cleaning image cache interrupted by context cancellation")
+			ic.size = total
 			return ctx.Err()
 		default:
-			// Continue processing
 		}
 
-		if !info.IsDir() {
-			relPath, relErr := filepath.Rel(ic.cacheDir, path)
-			if relErr != nil {
-				return relErr
-			}
-			log.Debug("clearing cached image", "path", path)
-			return root.Remove(relPath)
+		overLimit := ic.maxSize > 0 && total > ic.maxSize
+		if !overLimit && !ic.expired(entry.modTime) {
+			continue
 		}
 
-		return nil
-	})
+		// check the image again, it may have been replaced, removed or used since the directory was read
+		info, err := root.Stat(entry.name)
+		if os.IsNotExist(err) {
+			total -= entry.size
+			delete(ic.lastAccess, entry.name)
+			continue
+		}
+		if err != nil {
+			log.Warn("failed to check cached image, keeping it", "name", entry.name, "error", err)
+			continue
+		}
+		total += info.Size() - entry.size
+		overLimit = ic.maxSize > 0 && total > ic.maxSize
+		expired := ic.expired(info.ModTime())
+		if !expired && (!overLimit || ic.lastAccess[entry.name].After(entry.lastAccess)) {
+			continue
+		}
+
+		log.Debug("evicting cached image", "name", entry.name, "expired", expired)
+		if err := root.Remove(entry.name); err != nil && !os.IsNotExist(err) {
+			ic.size = total
+			return err
+		}
+		total -= info.Size()
+		removed++
+		delete(ic.lastAccess, entry.name)
+	}
+
+	ic.size = total
+
+	if removed > 0 {
+		log.Info("cleaned image cache", "removed", removed, "size", total)
+	}
+	return nil
+}
+
+// Stats returns the current statistics of the image cache.
+func (ic *ImageCache) Stats() (*ImageCacheStats, error) {
+	entries, err := ic.entries()
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for _, entry := range entries {
+		total += entry.size
+	}
+
+	ic.mu.Lock()
+	ic.size = total
+	ic.mu.Unlock()
+
+	return &ImageCacheStats{
+		Path:         ic.cacheDir,
+		Files:        len(entries),
+		SizeBytes:    total,
+		MaxSizeBytes: ic.maxSize,
+		TTLDays:      int(ic.ttl / (24 * time.Hour)),
+	}, nil
 }
 
 // calculateScaledDimensions calculates new dimensions while maintaining aspect ratio.
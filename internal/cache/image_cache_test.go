@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMediaDB struct {
+	database.MediaDB
+	media database.Media
+}
+
+func (f *fakeMediaDB) GetMediaItemByID(context.Context, uint) (*database.Media, error) {
+	return &f.media, nil
+}
+
+func TestServeImageCacheHeaders(t *testing.T) {
+	posterURL := "http://radarr.local/poster.jpg"
+	db := &fakeMediaDB{media: database.Media{PosterURL: posterURL}}
+	ic := NewImageCache(&config.ImageCacheConfig{Path: t.TempDir(), MaxAgeSeconds: 3600}, db)
+	// the image is already cached, so it isn't downloaded
+	require.NoError(t, os.WriteFile(ic.getCacheFilePath(posterURL), []byte("poster"), 0o600))
+
+	serve := func(header, value string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/images/cache?id=1", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		rec := httptest.NewRecorder()
+		require.NoError(t, ic.ServeImage(context.Background(), 1, rec, req))
+		return rec
+	}
+
+	rec := serve("", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "poster", rec.Body.String())
+	assert.Equal(t, "public, max-age=3600", rec.Header().Get("Cache-Control"))
+	etag := rec.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+	lastModified := rec.Header().Get("Last-Modified")
+	assert.NotEmpty(t, lastModified)
+
+	rec = serve("If-None-Match", etag)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	rec = serve("If-None-Match", `"outdated"`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve("If-Modified-Since", lastModified)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+}
+
+func TestImageCacheControl(t *testing.T) {
+	ic := &ImageCache{}
+	assert.Equal(t, "public, no-cache", ic.cacheControl())
+}
+
+func TestCleanupEvictsLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"old.jpg", "used.jpg", "new.jpg"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("12345"), 0o600))
+	}
+	ic := NewImageCache(&config.ImageCacheConfig{Path: dir}, &fakeMediaDB{})
+	ic.maxSize = 10
+	now := time.Now()
+	ic.lastAccess = map[string]time.Time{
+		"old.jpg":  now.Add(-2 * time.Hour),
+		"used.jpg": now.Add(-time.Hour),
+		"new.jpg":  now,
+	}
+
+	require.NoError(t, ic.Cleanup(context.Background()))
+
+	assert.NoFileExists(t, filepath.Join(dir, "old.jpg"))
+	assert.FileExists(t, filepath.Join(dir, "used.jpg"))
+	assert.FileExists(t, filepath.Join(dir, "new.jpg"))
+	assert.Equal(t, int64(10), ic.size)
+	assert.NotContains(t, ic.lastAccess, "old.jpg")
+}
@@ -2,17 +2,33 @@ package config
 
 import (
 	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/jon4hz/jellysweep/internal/logging"
+	"github.com/jon4hz/jellysweep/internal/notify/email/emailtemplate"
+	"github.com/jon4hz/jellysweep/internal/notify/webhook/webhooktemplate"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
 var v = viper.New()
 
+// tagPrefixRegexp matches tag prefixes accepted by Sonarr and Radarr.
+var tagPrefixRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// oidcProviderIDRegexp matches the IDs of the OIDC providers, which are used in their login and callback routes.
+var oidcProviderIDRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
 // MustBindPFlag binds a cobra persistent flag to a viper key.
 func MustBindPFlag(key string, flag *pflag.Flag) {
 	if err := v.BindPFlag(key, flag); err != nil {
@@ -20,7 +36,10 @@ func MustBindPFlag(key string, flag *pflag.Flag) {
 	}
 }
 
-const defaultTimeout = 30 // seconds
+const (
+	defaultTimeout     = 30 // seconds
+	defaultConcurrency = 4
+)
 
 // TimeoutDuration returns the configured timeout as a time.Duration.
 // If the timeout is 0, it returns the default of 30 seconds.
@@ -48,28 +67,141 @@ const (
 type CleanupMode string
 
 const (
-	CleanupModeAll          CleanupMode = "all"
-	CleanupModeKeepEpisodes CleanupMode = "keep_episodes"
-	CleanupModeKeepSeasons  CleanupMode = "keep_seasons"
+	CleanupModeAll                CleanupMode = "all"
+	CleanupModeKeepEpisodes       CleanupMode = "keep_episodes"
+	CleanupModeKeepSeasons        CleanupMode = "keep_seasons"
+	CleanupModeKeepLatestEpisodes CleanupMode = "keep_latest_episodes"
+)
+
+// FallbackAgeSource selects the date the age filters use if content has no import history or Jellyseerr request.
+type FallbackAgeSource string
+
+const (
+	// FallbackAgeSourceNone treats content without a known date as old enough.
+	FallbackAgeSourceNone FallbackAgeSource = ""
+	// FallbackAgeSourceAdded uses the date the item was added to Sonarr/Radarr/Readarr.
+	FallbackAgeSourceAdded FallbackAgeSource = "added"
+	// FallbackAgeSourceRelease uses the first day of the release year.
+	FallbackAgeSourceRelease FallbackAgeSource = "release"
+)
+
+// LibraryMediaType restricts a library to a single type of media.
+type LibraryMediaType string
+
+const (
+	// LibraryMediaTypeAny accepts all media found in the library.
+	LibraryMediaTypeAny LibraryMediaType = ""
+	// LibraryMediaTypeMovie only accepts movies from Radarr.
+	LibraryMediaTypeMovie LibraryMediaType = "movie"
+	// LibraryMediaTypeTV only accepts series from Sonarr.
+	LibraryMediaTypeTV LibraryMediaType = "tv"
+	// LibraryMediaTypeBook only accepts books from Readarr.
+	LibraryMediaTypeBook LibraryMediaType = "book"
+)
+
+// StatsFailMode selects how a cleanup run behaves if the stats backend can't be reached.
+type StatsFailMode string
+
+const (
+	// StatsFailModeSkipDeletions aborts the run without marking or deleting anything.
+	StatsFailModeSkipDeletions StatsFailMode = "skip_deletions"
+	// StatsFailModeIgnoreStreamFilter marks and deletes media without the stream filter.
+	StatsFailModeIgnoreStreamFilter StatsFailMode = "ignore_stream_filter"
+	// StatsFailModeContinue runs the cleanup as usual and lets the stream filter fail per item.
+	StatsFailModeContinue StatsFailMode = "continue"
+)
+
+// ScanOrder selects the order in which media items are processed if a run is limited by MaxItemsPerRun.
+type ScanOrder string
+
+const (
+	// ScanOrderAdded processes the items that were added to the arrs the longest time ago first.
+	ScanOrderAdded ScanOrder = "added"
+	// ScanOrderSize processes the largest items first.
+	ScanOrderSize ScanOrder = "size"
+)
+
+// CollectionAtomicMode selects how the movies of a collection are marked if collections are treated atomically.
+type CollectionAtomicMode string
+
+const (
+	// CollectionAtomicModeAllOrNone only marks the movies of a collection once every movie of it is eligible for deletion.
+	CollectionAtomicModeAllOrNone CollectionAtomicMode = "all_or_none"
+)
+
+// LogFormat selects the output format of the logs.
+type LogFormat string
+
+const (
+	// LogFormatText writes human readable log lines.
+	LogFormatText LogFormat = "text"
+	// LogFormatJSON writes one JSON object per log line, e.g. for ingestion into Loki.
+	LogFormatJSON LogFormat = "json"
 )
 
 // Config holds the configuration for the Jellysweep server and its dependencies.
 type Config struct {
 	// LogLevel sets the log verbosity. Options: "debug", "info", "warn", "error". Defaults to "info".
+	// It is overridden by Log.Level if that is set.
 	LogLevel string `yaml:"log_level" mapstructure:"log_level"`
+	// Log holds the configuration of the log output.
+	Log *LogConfig `yaml:"log" mapstructure:"log"`
 	// Listen is the address the Jellysweep server will listen on.
 	Listen string `yaml:"listen" mapstructure:"listen"`
+	// ReadinessTimeout is the timeout in seconds for checking a single dependency in the readiness endpoint.
+	ReadinessTimeout int `yaml:"readiness_timeout" mapstructure:"readiness_timeout"`
 	// CleanupSchedule is the cron schedule for the cleanup job (e.g., "0 */12 * * *" for every 12 hours).
+	// An optional leading seconds field is supported (e.g., "30 0 */12 * * *").
 	CleanupSchedule string `yaml:"cleanup_schedule" mapstructure:"cleanup_schedule"`
+	// CleanupScheduleJitterSeconds delays every scheduled cleanup run by a random duration of up to this many seconds,
+	// so it doesn't start at the same time as other jobs on the cron boundary. Zero starts the runs on time.
+	CleanupScheduleJitterSeconds int `yaml:"cleanup_schedule_jitter_seconds" mapstructure:"cleanup_schedule_jitter_seconds"`
+	// Timezone is the IANA timezone name used to evaluate the schedules (e.g., "Europe/Zurich").
+	// Defaults to the local timezone of the system.
+	Timezone string `yaml:"timezone" mapstructure:"timezone"`
 	// Libraries is a map of libraries to their cleanup configurations.
 	Libraries map[string]*CleanupConfig `yaml:"libraries" mapstructure:"libraries"`
 	// DryRun indicates whether the cleanup job should run in dry-run mode.
 	DryRun bool `yaml:"dry_run" mapstructure:"dry_run"`
-	// CleanupMode specifies how to clean up TV series. Options: "all", "keep_episodes", "keep_seasons"
+	// DryRunReportPath is the path of the report file written at the end of each dry run.
+	// The format is determined by the file extension, supported are ".json" and ".csv".
+	DryRunReportPath string `yaml:"dry_run_report_path" mapstructure:"dry_run_report_path"`
+	// TagPrefix is the base prefix of all tags jellysweep creates in Sonarr and Radarr (e.g. "jellysweep-ignore").
+	// Use different prefixes to run multiple instances against the same servers.
+	TagPrefix string `yaml:"tag_prefix" mapstructure:"tag_prefix"`
+	// CleanupMode specifies how to clean up TV series. Options: "all", "keep_episodes", "keep_seasons", "keep_latest_episodes"
 	// See engine.CleanupMode* constants for valid values.
 	CleanupMode CleanupMode `yaml:"cleanup_mode" mapstructure:"cleanup_mode"`
-	// KeepCount specifies how many episodes or seasons to keep when using "keep_episodes" or "keep_seasons" mode
+	// KeepCount specifies how many episodes or seasons to keep when using "keep_episodes", "keep_seasons" or "keep_latest_episodes" mode
 	KeepCount int `yaml:"keep_count" mapstructure:"keep_count"`
+	// DeleteEmptySeriesAfterCleanup deletes the whole series from Sonarr if no episode files are left after a selective cleanup.
+	// Series with monitored episodes that haven't aired yet are kept.
+	DeleteEmptySeriesAfterCleanup bool `yaml:"delete_empty_series_after_cleanup" mapstructure:"delete_empty_series_after_cleanup"`
+	// MinItemsPerLibrary is the minimum number of items a library keeps during a cleanup run.
+	// Deletions that would drop a library below this count are deferred to a later run. 0 disables the safeguard.
+	MinItemsPerLibrary int `yaml:"min_items_per_library" mapstructure:"min_items_per_library"`
+	// MinMarkToDeleteHours is the minimum time in hours between marking an item and deleting it, regardless of its deletion date.
+	// It guarantees at least one chance to intervene before anything is deleted. 0 disables the cool-down.
+	MinMarkToDeleteHours int `yaml:"min_mark_to_delete_hours" mapstructure:"min_mark_to_delete_hours"`
+	// MaxItemsPerRun limits how many media items a cleanup run checks and deletes.
+	// The next run continues where the previous one stopped, so a large backlog is processed over several runs. 0 disables the limit.
+	MaxItemsPerRun int `yaml:"max_items_per_run" mapstructure:"max_items_per_run"`
+	// OrderBy is the order in which the media items are processed if MaxItemsPerRun is set.
+	OrderBy ScanOrder `yaml:"order_by" mapstructure:"order_by"`
+	// TreatCollectionsAtomically keeps or deletes the movies of a Radarr collection (e.g. a trilogy) together.
+	TreatCollectionsAtomically bool `yaml:"treat_collections_atomically" mapstructure:"treat_collections_atomically"`
+	// AtomicMode decides when the movies of a collection are marked if TreatCollectionsAtomically is set. Options: "all_or_none"
+	AtomicMode CollectionAtomicMode `yaml:"atomic_mode" mapstructure:"atomic_mode"`
+	// SkipIfDownloading excludes items with a download or import in progress in the Sonarr/Radarr queue,
+	// so files aren't deleted while the arr is upgrading them.
+	SkipIfDownloading bool `yaml:"skip_if_downloading" mapstructure:"skip_if_downloading"`
+	// Concurrency is the maximum number of parallel requests to Sonarr and the stats services for per-item lookups.
+	Concurrency int `yaml:"concurrency" mapstructure:"concurrency"`
+	// PathMappings rewrites the library folder paths reported by the media server before their disk usage is checked.
+	// Use it if the media server runs in a container and sees other paths than jellysweep.
+	PathMappings []PathMapping `yaml:"path_mappings" mapstructure:"path_mappings"`
+	// LibraryPathMap assigns arr items to a library by their path if the media server reports no library for them.
+	LibraryPathMap []LibraryPath `yaml:"library_path_map" mapstructure:"library_path_map"`
 	// Auth holds the authentication configuration for the Jellysweep server.
 	Auth *AuthConfig `yaml:"auth" mapstructure:"auth"`
 	// Database holds the database configuration.
@@ -92,47 +224,112 @@ type Config struct {
 	Ntfy *NtfyConfig `yaml:"ntfy" mapstructure:"ntfy"`
 	// WebPush holds the webpush notification configuration.
 	WebPush *WebPushConfig `yaml:"webpush" mapstructure:"webpush"`
+	// Slack holds the slack notification configuration.
+	Slack *SlackConfig `yaml:"slack" mapstructure:"slack"`
+	// Gotify holds the gotify notification configuration.
+	Gotify *GotifyConfig `yaml:"gotify" mapstructure:"gotify"`
+	// Matrix holds the matrix notification configuration.
+	Matrix *MatrixConfig `yaml:"matrix" mapstructure:"matrix"`
+	// Apprise holds the apprise API notification configuration.
+	Apprise *AppriseConfig `yaml:"apprise" mapstructure:"apprise"`
+	// Pushover holds the pushover notification configuration.
+	Pushover *PushoverConfig `yaml:"pushover" mapstructure:"pushover"`
+	// Webhook holds the generic webhook notification configuration.
+	Webhook *WebhookConfig `yaml:"webhook" mapstructure:"webhook"`
+	// Hooks holds the configuration for external hooks.
+	Hooks *HooksConfig `yaml:"hooks" mapstructure:"hooks"`
+	// DeletionRetry holds the configuration for retrying failed deletions.
+	DeletionRetry *DeletionRetryConfig `yaml:"deletion_retry" mapstructure:"deletion_retry"`
 	// ServerURL is the base URL of the Jellysweep server.
 	ServerURL string `yaml:"server_url" mapstructure:"server_url"`
 	// Cache holds the cache engine configuration.
 	Cache *CacheConfig `yaml:"cache" mapstructure:"cache"`
+	// Proxy holds the proxy for outbound requests. The proxy environment variables are used if it's not set.
+	Proxy *ProxyConfig `yaml:"proxy" mapstructure:"proxy"`
+	// Stats holds the behavior of the cleanup if Jellystat or Streamystats is unreachable.
+	Stats *StatsConfig `yaml:"stats" mapstructure:"stats"`
+	// Retry holds the retries of requests to Sonarr, Radarr, Readarr and Jellyseerr while they are overloaded.
+	Retry *RetryConfig `yaml:"retry" mapstructure:"retry"`
+	// ImageCache holds the configuration for the poster image cache.
+	ImageCache *ImageCacheConfig `yaml:"image_cache" mapstructure:"image_cache"`
+	// DefaultPosterURL is shown in the UI and notifications for media without a poster in the arrs or TMDB.
+	DefaultPosterURL string `yaml:"default_poster_url" mapstructure:"default_poster_url"`
 	// LeavingCollectionsEnabled controls whether "Leaving Soon" collections are created in Jellyfin.
 	LeavingCollectionsEnabled bool `yaml:"leaving_collections_enabled" mapstructure:"leaving_collections_enabled"`
 	// Name of the "Leaving Movies" collection in Jellyfin.
 	LeavingCollectionsMovieName string `yaml:"leaving_collections_movie_name" mapstructure:"leaving_collections_movie_name"`
 	// Name of the "Leaving TV Shows" collection in Jellyfin.
 	LeavingCollectionsTVName string `yaml:"leaving_collections_tv_name" mapstructure:"leaving_collections_tv_name"`
+	// LeavingCollectionsWindowDays limits the leaving collections to items that are deleted within this many days.
+	// 0 includes all items marked for deletion.
+	LeavingCollectionsWindowDays int `yaml:"leaving_collections_window_days" mapstructure:"leaving_collections_window_days"`
 
 	// Jellyseerr holds the configuration for the Jellyseerr server.
 	Jellyseerr *JellyseerrConfig `yaml:"jellyseerr" mapstructure:"jellyseerr"`
+	// ResyncJellyseerrOnKeep marks the media as available in Jellyseerr again when a keep request is approved.
+	ResyncJellyseerrOnKeep bool `yaml:"resync_jellyseerr_on_keep" mapstructure:"resync_jellyseerr_on_keep"`
+	// DeleteJellyseerrRequestOnCleanup removes the media and its requests from Jellyseerr after the media was deleted.
+	DeleteJellyseerrRequestOnCleanup bool `yaml:"delete_jellyseerr_request_on_cleanup" mapstructure:"delete_jellyseerr_request_on_cleanup"`
+	// ProtectRequesters is a list of requester emails whose requested media is never deleted.
+	// It applies to all libraries, additionally to the per-library filter configuration.
+	ProtectRequesters []string `yaml:"protect_requesters" mapstructure:"protect_requesters"`
+	// AlwaysEligibleRequesters is a list of requester emails whose requested media skips the age and stream thresholds.
+	// It applies to all libraries, additionally to the per-library filter configuration.
+	AlwaysEligibleRequesters []string `yaml:"always_eligible_requesters" mapstructure:"always_eligible_requesters"`
+	// KeepExpiryReminderDays reminds requesters this many days before the protection of their kept media expires.
+	// 0 disables the reminders.
+	KeepExpiryReminderDays int `yaml:"keep_expiry_reminder_days" mapstructure:"keep_expiry_reminder_days"`
+	// DeleteImmediatelyOnDeny schedules media for deletion in the next cleanup run once its keep request is denied
+	// or it's marked for deletion by an admin, instead of waiting for the cleanup delay.
+	DeleteImmediatelyOnDeny bool `yaml:"delete_immediately_on_deny" mapstructure:"delete_immediately_on_deny"`
+	// AutoApproveLibraries is a list of library names in which keep requests are approved automatically,
+	// regardless of the auto-approval permission of the requesting user.
+	AutoApproveLibraries []string `yaml:"auto_approve_libraries" mapstructure:"auto_approve_libraries"`
 	// Sonarr holds the configuration for the Sonarr server.
 	Sonarr *SonarrConfig `yaml:"sonarr" mapstructure:"sonarr"`
 	// Radarr holds the configuration for the Radarr server.
 	Radarr *RadarrConfig `yaml:"radarr" mapstructure:"radarr"`
+	// Readarr holds the configuration for the Readarr server.
+	Readarr *ReadarrConfig `yaml:"readarr" mapstructure:"readarr"`
 	// Jellystat holds the configuration for the Jellystat server.
 	Jellystat *JellystatConfig `yaml:"jellystat" mapstructure:"jellystat"`
 	// Gravatar holds the configuration for Gravatar profile pictures.
 	Gravatar *GravatarConfig `yaml:"gravatar" mapstructure:"gravatar"`
 	// Jellyfin holds the configuration for the Jellyfin server.
 	Jellyfin *JellyfinConfig `yaml:"jellyfin" mapstructure:"jellyfin"`
+	// Emby holds the configuration for the Emby server.
+	// It can be used as an alternative to the Jellyfin server.
+	Emby *EmbyConfig `yaml:"emby" mapstructure:"emby"`
+	// Plex holds the configuration for the Plex Media Server.
+	// It can be used as an alternative to the Jellyfin server.
+	Plex *PlexConfig `yaml:"plex" mapstructure:"plex"`
 	// Streamystats holds the configuration for the Streamystats server.
 	Streamystats *StreamystatsConfig `yaml:"streamystats" mapstructure:"streamystats"`
 	// Tunarr holds the configuration for the Tunarr server.
 	Tunarr *TunarrConfig `yaml:"tunarr" mapstructure:"tunarr"`
+	// TMDB holds the configuration for the TMDB metadata enrichment of notifications.
+	TMDB *TMDBConfig `yaml:"tmdb" mapstructure:"tmdb"`
 }
 
 // AuthConfig holds the authentication configuration for the Jellysweep server.
 type AuthConfig struct {
 	// OIDC holds the OpenID Connect configuration.
 	OIDC *OIDCConfig `yaml:"oidc" mapstructure:"oidc"`
+	// OIDCProviders holds additional named OpenID Connect providers users can choose from, each with its own login route.
+	OIDCProviders []*OIDCConfig `yaml:"oidc_providers" mapstructure:"oidc_providers"`
 	// Jellyfin holds the Jellyfin authentication configuration.
 	Jellyfin *JellyfinAuthConfig `yaml:"jellyfin" mapstructure:"jellyfin"`
+	// LDAP holds the LDAP/Active Directory authentication configuration.
+	LDAP *LDAPConfig `yaml:"ldap" mapstructure:"ldap"`
 }
 
 // OIDCConfig holds the OpenID Connect configuration for the Jellysweep server.
 type OIDCConfig struct {
 	// Enabled indicates whether OIDC authentication is enabled.
 	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// ID identifies a provider of the oidc_providers list in its login and callback routes, e.g. /auth/oidc/<id>/login.
+	// It's required for the providers in oidc_providers. The single provider configured as oidc uses /auth/oidc/login without an ID.
+	ID string `yaml:"id" mapstructure:"id"`
 	// Name is the display name for the OIDC provider.
 	Name string `yaml:"name" mapstructure:"name"`
 	// Issuer is the OIDC issuer URL.
@@ -161,6 +358,30 @@ type JellyfinAuthConfig struct {
 	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
 }
 
+// LDAPConfig holds the LDAP/Active Directory authentication configuration for the Jellysweep server.
+type LDAPConfig struct {
+	// Enabled indicates whether LDAP authentication is enabled.
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// URL is the LDAP server URL (e.g. ldap://ldap.example.com:389 or ldaps://ldap.example.com:636).
+	URL string `yaml:"url" mapstructure:"url"`
+	// BindDN is the DN of the service account used to search for users.
+	// If empty, the search is performed with an anonymous bind.
+	BindDN string `yaml:"bind_dn" mapstructure:"bind_dn"`
+	// BindPassword is the password of the service account.
+	BindPassword string `yaml:"bind_password" mapstructure:"bind_password"`
+	// BaseDN is the base DN used when searching for users.
+	BaseDN string `yaml:"base_dn" mapstructure:"base_dn"`
+	// UserFilter is the LDAP filter used to find a user. %s is replaced with the escaped username.
+	UserFilter string `yaml:"user_filter" mapstructure:"user_filter"`
+	// AdminGroup is the group (DN or CN) whose members have admin privileges.
+	AdminGroup string `yaml:"admin_group" mapstructure:"admin_group"`
+	// AutoApproveGroup is the group (DN or CN) whose members get automatic approval for keep requests.
+	// This setting overrides the database value for auto-approval permission on each login.
+	AutoApproveGroup string `yaml:"auto_approve_group" mapstructure:"auto_approve_group"`
+	// Timeout is the connection timeout in seconds for LDAP requests.
+	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+}
+
 // DatabaseConfig holds the database configuration.
 type DatabaseConfig struct {
 	// Type is the database backend to use. Options: "sqlite", "postgres".
@@ -179,6 +400,9 @@ type DatabaseConfig struct {
 	Password string `yaml:"password" mapstructure:"password"`
 	// SSLMode is the PostgreSQL sslmode connection option.
 	SSLMode string `yaml:"ssl_mode" mapstructure:"ssl_mode"`
+	// AutoMigrate runs the database migrations on startup.
+	// If disabled, the migrate command has to be run before starting a new version.
+	AutoMigrate bool `yaml:"auto_migrate" mapstructure:"auto_migrate"`
 }
 
 // EmailConfig holds the email notification configuration.
@@ -203,6 +427,33 @@ type EmailConfig struct {
 	UseSSL bool `yaml:"use_ssl" mapstructure:"use_ssl"`
 	// InsecureSkipVerify indicates whether to skip TLS certificate verification.
 	InsecureSkipVerify bool `yaml:"insecure_skip_verify" mapstructure:"insecure_skip_verify"`
+	// MaxRetries is the number of times a failed email is retried with exponential backoff.
+	MaxRetries int `yaml:"max_retries" mapstructure:"max_retries"`
+	// TimeoutSeconds is the timeout for connecting to the SMTP server and sending a single email.
+	TimeoutSeconds int `yaml:"timeout_seconds" mapstructure:"timeout_seconds"`
+	// TemplatePath is the path to a custom Go html/template file for the cleanup notification.
+	// The embedded default template is used if it's empty.
+	TemplatePath string `yaml:"template_path" mapstructure:"template_path"`
+	// Locale is the language used to format dates in the emails, e.g. "de".
+	Locale string `yaml:"locale" mapstructure:"locale"`
+	// MaxItemsPerEmail splits the cleanup notification of a user into multiple emails with at most this many items.
+	// Zero sends all items in a single email.
+	MaxItemsPerEmail int `yaml:"max_items_per_email" mapstructure:"max_items_per_email"`
+	// BatchAboveTotal sends a single summary email to the admin instead of the user notifications
+	// if more items than this were marked in one run. Zero disables the summary.
+	BatchAboveTotal int `yaml:"batch_above_total" mapstructure:"batch_above_total"`
+	// AdminEmail receives the summary email, the from email is used if it's empty.
+	AdminEmail string `yaml:"admin_email" mapstructure:"admin_email"`
+	// Events limits the notifications to these events. All events are sent if it's empty.
+	Events NotificationEvents `yaml:"events" mapstructure:"events"`
+}
+
+// GetAdminEmail returns the recipient of the admin summary email.
+func (c *EmailConfig) GetAdminEmail() string {
+	if c.AdminEmail != "" {
+		return c.AdminEmail
+	}
+	return c.FromEmail
 }
 
 // NtfyConfig holds the ntfy notification configuration.
@@ -221,6 +472,8 @@ type NtfyConfig struct {
 	Token string `yaml:"token" mapstructure:"token"`
 	// Timeout is the HTTP client timeout in seconds.
 	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	// Events limits the notifications to these events. All events are sent if it's empty.
+	Events NotificationEvents `yaml:"events" mapstructure:"events"`
 }
 
 // WebPushConfig holds the webpush notification configuration.
@@ -235,6 +488,134 @@ type WebPushConfig struct {
 	PrivateKey string `yaml:"private_key" mapstructure:"private_key"`
 	// Timeout is the HTTP client timeout in seconds.
 	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	// Events limits the notifications to these events. All events are sent if it's empty.
+	Events NotificationEvents `yaml:"events" mapstructure:"events"`
+}
+
+// SlackConfig holds the slack notification configuration.
+type SlackConfig struct {
+	// Enabled indicates whether slack notifications are enabled.
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// WebhookURL is the incoming webhook URL of the slack app.
+	WebhookURL string `yaml:"webhook_url" mapstructure:"webhook_url"`
+	// Channel optionally overrides the default channel of the webhook.
+	Channel string `yaml:"channel" mapstructure:"channel"`
+	// Timeout is the HTTP client timeout in seconds.
+	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	// Events limits the notifications to these events. All events are sent if it's empty.
+	Events NotificationEvents `yaml:"events" mapstructure:"events"`
+}
+
+// GotifyConfig holds the gotify notification configuration.
+type GotifyConfig struct {
+	// Enabled indicates whether gotify notifications are enabled.
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// ServerURL is the URL of the gotify server.
+	ServerURL string `yaml:"server_url" mapstructure:"server_url"`
+	// Token is the gotify application token.
+	Token string `yaml:"token" mapstructure:"token"`
+	// Priority is the gotify message priority (0-10).
+	Priority int `yaml:"priority" mapstructure:"priority"`
+	// Timeout is the HTTP client timeout in seconds.
+	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	// Events limits the notifications to these events. All events are sent if it's empty.
+	Events NotificationEvents `yaml:"events" mapstructure:"events"`
+}
+
+// MatrixConfig holds the matrix notification configuration.
+type MatrixConfig struct {
+	// Enabled indicates whether matrix notifications are enabled.
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// HomeserverURL is the URL of the matrix homeserver.
+	HomeserverURL string `yaml:"homeserver_url" mapstructure:"homeserver_url"`
+	// AccessToken is the access token of the matrix user sending the messages.
+	AccessToken string `yaml:"access_token" mapstructure:"access_token"`
+	// RoomID is the ID of the room the messages are sent to (e.g. !abc123:example.com).
+	RoomID string `yaml:"room_id" mapstructure:"room_id"`
+	// Timeout is the HTTP client timeout in seconds.
+	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	// Events limits the notifications to these events. All events are sent if it's empty.
+	Events NotificationEvents `yaml:"events" mapstructure:"events"`
+}
+
+// AppriseConfig holds the apprise API notification configuration.
+type AppriseConfig struct {
+	// Enabled indicates whether apprise notifications are enabled.
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// ServerURL is the URL of the apprise API server.
+	ServerURL string `yaml:"server_url" mapstructure:"server_url"`
+	// URLs are the apprise URLs to notify (e.g. discord://webhook_id/webhook_token).
+	URLs []string `yaml:"urls" mapstructure:"urls"`
+	// ConfigKey is the key of a configuration stored in the apprise API server. It's used instead of URLs if set.
+	ConfigKey string `yaml:"config_key" mapstructure:"config_key"`
+	// Timeout is the HTTP client timeout in seconds.
+	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	// Events limits the notifications to these events. All events are sent if it's empty.
+	Events NotificationEvents `yaml:"events" mapstructure:"events"`
+}
+
+// PushoverConfig holds the pushover notification configuration.
+type PushoverConfig struct {
+	// Enabled indicates whether pushover notifications are enabled.
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// Token is the API token of the pushover application.
+	Token string `yaml:"token" mapstructure:"token"`
+	// UserKey is the key of the pushover user or group receiving the messages.
+	UserKey string `yaml:"user_key" mapstructure:"user_key"`
+	// Device optionally limits the messages to a single device of the user.
+	Device string `yaml:"device" mapstructure:"device"`
+	// Timeout is the HTTP client timeout in seconds.
+	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	// Events limits the notifications to these events. All events are sent if it's empty.
+	Events NotificationEvents `yaml:"events" mapstructure:"events"`
+}
+
+// WebhookConfig holds the generic webhook notification configuration.
+type WebhookConfig struct {
+	// Enabled indicates whether webhook notifications are enabled.
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// URL is the endpoint receiving the notifications.
+	URL string `yaml:"url" mapstructure:"url"`
+	// Method is the HTTP method of the requests. Options: "POST", "PUT", "PATCH"
+	Method string `yaml:"method" mapstructure:"method"`
+	// Headers are added to every request, e.g. for authentication.
+	Headers map[string]string `yaml:"headers" mapstructure:"headers"`
+	// Template is a Go template rendering the JSON payload of an event. The event is sent as JSON if it's empty.
+	Template string `yaml:"template" mapstructure:"template"`
+	// Retries is the number of times a failed request is retried.
+	Retries int `yaml:"retries" mapstructure:"retries"`
+	// Timeout is the HTTP client timeout in seconds.
+	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	// Events limits the notifications to these events. All events are sent if it's empty.
+	Events NotificationEvents `yaml:"events" mapstructure:"events"`
+}
+
+// StatsConfig holds the behavior of the cleanup if the stats backend is unreachable.
+type StatsConfig struct {
+	// FailMode selects what happens if Jellystat or Streamystats can't be reached at the start of the cleanup.
+	FailMode StatsFailMode `yaml:"fail_mode" mapstructure:"fail_mode"`
+}
+
+// DeletionRetryConfig holds the configuration for retrying failed deletions.
+type DeletionRetryConfig struct {
+	// MaxAttempts is the number of failed attempts after which a deletion is given up and the admins are notified.
+	MaxAttempts int `yaml:"max_attempts" mapstructure:"max_attempts"`
+	// InitialDelay is the delay in minutes before the first retry. It doubles after every failed attempt.
+	InitialDelay int `yaml:"initial_delay" mapstructure:"initial_delay"`
+	// MaxDelay is the maximum delay in minutes between two retries.
+	MaxDelay int `yaml:"max_delay" mapstructure:"max_delay"`
+}
+
+// HooksConfig holds the configuration for external hooks.
+type HooksConfig struct {
+	// PreDeleteCommand is a shell command executed before a media item is deleted.
+	// The item's metadata is passed as JSON over stdin. A non-zero exit code skips the deletion.
+	PreDeleteCommand string `yaml:"pre_delete_command" mapstructure:"pre_delete_command"`
+	// PreDeleteWebhookURL is called with a POST request before a media item is deleted.
+	// The item's metadata is sent as JSON body. A non-2xx response skips the deletion.
+	PreDeleteWebhookURL string `yaml:"pre_delete_webhook_url" mapstructure:"pre_delete_webhook_url"`
+	// Timeout is the timeout in seconds for each hook invocation.
+	Timeout int `yaml:"timeout" mapstructure:"timeout"`
 }
 
 // CleanupConfig holds the configuration for the cleanup job.
@@ -245,6 +626,9 @@ type CleanupConfig struct {
 	CleanupDelay int `yaml:"cleanup_delay" mapstructure:"cleanup_delay"`
 	// DiskUsageThresholds is a list of disk usage thresholds for cleanup.
 	DiskUsageThresholds []DiskUsageThreshold `yaml:"disk_usage_thresholds" mapstructure:"disk_usage_thresholds"`
+	// RuntimeRules override the content age and last stream thresholds for items up to a runtime,
+	// e.g. to sweep short documentaries sooner than feature films in the same library.
+	RuntimeRules []RuntimeRule `yaml:"runtime_rules" mapstructure:"runtime_rules"`
 	// ProtectionPeriod is the number of days to protect requested media from cleanup.
 	ProtectionPeriod int `yaml:"protection_period" mapstructure:"protection_period"`
 	// ContentAgeThreshold is the minimum age in days for content (since it was first imported) to be eligible for cleanup.
@@ -261,27 +645,96 @@ type CleanupConfig struct {
 	ExcludeTags []string `yaml:"exclude_tags" mapstructure:"exclude_tags"`
 	// Filter is the configuration for all available filters.
 	Filter FilterConfig `yaml:"filter" mapstructure:"filter"`
+	// MediaType restricts the library to movies, series or books. Items of other types found in a library
+	// with the same name are ignored. By default all types are accepted.
+	MediaType LibraryMediaType `yaml:"media_type" mapstructure:"media_type"`
 }
 
 type FilterConfig struct {
 	// ContentAgeThreshold is the minimum age in days for content (since it was first imported) to be eligible for cleanup.
+	// The age is the time since the first import found in the Sonarr/Radarr/Readarr history after the last deletion,
+	// not the time since the release, so an old movie that was just downloaded is still new.
 	ContentAgeThreshold int `yaml:"content_age_threshold" mapstructure:"content_age_threshold"`
+	// NewlyAddedGraceDays protects content which was added to Sonarr/Radarr/Readarr in the last N days.
+	// Unlike ContentAgeThreshold it uses the added date of the arr item and no history, so it also protects
+	// items whose import history is missing or older than the item itself, e.g. after re-adding it.
+	NewlyAddedGraceDays int `yaml:"newly_added_grace_days" mapstructure:"newly_added_grace_days"`
+	// RequestAgeThreshold is the minimum age in days of the Jellyseerr request for content to be eligible for cleanup.
+	// Content without a known request is always old enough, unless FallbackAgeSource is set.
+	RequestAgeThreshold int `yaml:"request_age_threshold" mapstructure:"request_age_threshold"`
+	// FallbackAgeSource is the date used by the content and request age thresholds if the import history or the
+	// Jellyseerr request is missing, e.g. for manually imported content. Options: "added", "release".
+	// By default such content is always old enough.
+	FallbackAgeSource FallbackAgeSource `yaml:"fallback_age_source" mapstructure:"fallback_age_source"`
 	// LastStreamThreshold is the minimum time in days since the last stream for content to be eligible for cleanup.
 	LastStreamThreshold int `yaml:"last_stream_threshold" mapstructure:"last_stream_threshold"`
 	// ContentSizeThreshold is the minimum size in bytes for content to be eligible for cleanup.
 	ContentSizeThreshold int64 `yaml:"content_size_threshold" mapstructure:"content_size_threshold"`
+	// ContentSizeThresholdPercent is the minimum size in percent of the library's total size for content to be eligible for cleanup.
+	// If ContentSizeThreshold is set as well, the larger of both thresholds is used.
+	ContentSizeThresholdPercent float64 `yaml:"content_size_threshold_percent" mapstructure:"content_size_threshold_percent"`
 	// ExcludeTags is a list of tags to exclude from deletion.
 	ExcludeTags []string `yaml:"exclude_tags" mapstructure:"exclude_tags"`
 	// TunarrEnabled enables the Tunarr filter for this library to protect items used in Tunarr channels.
 	TunarrEnabled bool `yaml:"tunarr_enabled" mapstructure:"tunarr_enabled"`
+	// ProtectCollections is a list of Jellyfin collection names. Items in any of these collections are excluded from deletion.
+	ProtectCollections []string `yaml:"protect_collections" mapstructure:"protect_collections"`
+	// ProtectIfExternalSubtitles excludes items that have external (non-embedded) subtitle files in Sonarr/Radarr.
+	ProtectIfExternalSubtitles bool `yaml:"protect_if_external_subtitles" mapstructure:"protect_if_external_subtitles"`
+	// ProtectFavorites excludes items that at least one media server user marked as favorite.
+	// Plex has no favorites, so it can't be enabled with Plex.
+	ProtectFavorites bool `yaml:"protect_favorites" mapstructure:"protect_favorites"`
+	// ProtectIfWatchedByAnyUser uses the latest play of any single user for the last stream threshold,
+	// so items watched by users with restricted library access are protected even if the global stats miss it.
+	ProtectIfWatchedByAnyUser bool `yaml:"protect_if_watched_by_any_user" mapstructure:"protect_if_watched_by_any_user"`
+	// ProtectIfInProgressByAnyUser excludes items that any user started but didn't finish, regardless of the last play.
+	// The resume positions are read from the Jellyfin or Emby user data, so it can't be enabled with Plex.
+	ProtectIfInProgressByAnyUser bool `yaml:"protect_if_in_progress_by_any_user" mapstructure:"protect_if_in_progress_by_any_user"`
+	// MinHistoricalPlayCountProtect protects items that were played at least this many times in total,
+	// even if their last play is outside the last stream threshold. 0 disables it.
+	MinHistoricalPlayCountProtect int `yaml:"min_historical_play_count_protect" mapstructure:"min_historical_play_count_protect"`
+	// ProtectRequesters is a list of requester emails whose requested media is excluded from deletion.
+	ProtectRequesters []string `yaml:"protect_requesters" mapstructure:"protect_requesters"`
+	// AlwaysEligibleRequesters is a list of requester emails whose requested media skips the age and stream thresholds.
+	AlwaysEligibleRequesters []string `yaml:"always_eligible_requesters" mapstructure:"always_eligible_requesters"`
+	// ProtectIfRequestedByMultiple excludes items requested by at least this many distinct Jellyseerr users. 0 disables it.
+	ProtectIfRequestedByMultiple int `yaml:"protect_if_requested_by_multiple" mapstructure:"protect_if_requested_by_multiple"`
+	// OnlyCleanupEndedSeries excludes TV series which Sonarr doesn't consider ended, e.g. continuing or upcoming series.
+	OnlyCleanupEndedSeries bool `yaml:"only_cleanup_ended_series" mapstructure:"only_cleanup_ended_series"`
+	// SkipUnmonitored excludes items which aren't monitored in Sonarr/Radarr/Readarr.
+	SkipUnmonitored bool `yaml:"skip_unmonitored" mapstructure:"skip_unmonitored"`
 }
 
 // DiskUsageThreshold holds the disk usage thresholds for cleanup.
 type DiskUsageThreshold struct {
 	// UsagePercent is the disk usage percentage threshold.
 	UsagePercent float64 `yaml:"usage_percent" mapstructure:"usage_percent"`
+	// MinFreeSpaceBytes reaches the threshold once the free space of the disk drops below this many bytes,
+	// additionally to the usage percent. Set only this and no usage percent to react to the free space alone.
+	MinFreeSpaceBytes uint64 `yaml:"min_free_space_bytes" mapstructure:"min_free_space_bytes"`
 	// MaxCleanupDelay is the cleanup delay in days when this threshold is reached.
 	MaxCleanupDelay int `yaml:"max_cleanup_delay" mapstructure:"max_cleanup_delay"`
+	// TargetUsagePercent is the disk usage percentage the cleanup should get back to once this threshold is reached.
+	// If set, only the largest items needed to reach the target are deleted instead of all eligible items.
+	TargetUsagePercent float64 `yaml:"target_usage_percent" mapstructure:"target_usage_percent"`
+}
+
+// RuntimeRule overrides thresholds of a library for items with a runtime of at most MaxRuntimeMinutes.
+type RuntimeRule struct {
+	// MaxRuntimeMinutes is the longest runtime in minutes the rule applies to. For series the episode runtime is used.
+	MaxRuntimeMinutes int `yaml:"max_runtime_minutes" mapstructure:"max_runtime_minutes"`
+	// ContentAgeThreshold overrides the content age threshold of the library, 0 keeps the library threshold.
+	ContentAgeThreshold int `yaml:"content_age_threshold" mapstructure:"content_age_threshold"`
+	// LastStreamThreshold overrides the last stream threshold of the library, 0 keeps the library threshold.
+	LastStreamThreshold int `yaml:"last_stream_threshold" mapstructure:"last_stream_threshold"`
+}
+
+// PathMapping rewrites a path prefix of the media server to the path prefix visible to jellysweep.
+type PathMapping struct {
+	// From is the path prefix as reported by the media server (e.g. "/media").
+	From string `yaml:"from" mapstructure:"from"`
+	// To is the path prefix the From prefix is replaced with (e.g. "/mnt/storage").
+	To string `yaml:"to" mapstructure:"to"`
 }
 
 // CacheConfig holds the configuration for the cache engine.
@@ -290,6 +743,24 @@ type CacheConfig struct {
 	Type CacheType `yaml:"type" mapstructure:"type"`
 	// RedisURL is the URL for the Redis cache if using Redis.
 	RedisURL string `yaml:"redis_url" mapstructure:"redis_url"`
+	// RedisPrefix is prepended to all Redis keys, so the Redis server can be shared with other applications.
+	RedisPrefix string `yaml:"redis_prefix" mapstructure:"redis_prefix"`
+	// TTLSeconds is the expiration of the Redis entries in seconds. 0 disables the expiration.
+	TTLSeconds int `yaml:"ttl_seconds" mapstructure:"ttl_seconds"`
+}
+
+// ImageCacheConfig holds the configuration for the poster image cache.
+type ImageCacheConfig struct {
+	// Path is the directory the cached images are stored in.
+	Path string `yaml:"path" mapstructure:"path"`
+	// TTLDays is the number of days after which a cached image is downloaded again. 0 disables the expiration.
+	TTLDays int `yaml:"ttl_days" mapstructure:"ttl_days"`
+	// MaxSizeMB is the maximum total size of the cache in megabytes. The least recently used images are evicted first.
+	// 0 disables the size limit.
+	MaxSizeMB int `yaml:"max_size_mb" mapstructure:"max_size_mb"`
+	// MaxAgeSeconds is how long browsers may use a served image without asking again.
+	// 0 makes browsers revalidate the image on every request.
+	MaxAgeSeconds int `yaml:"max_age_seconds" mapstructure:"max_age_seconds"`
 }
 
 // JellyseerrConfig holds the configuration for the Jellyseerr server.
@@ -300,6 +771,16 @@ type JellyseerrConfig struct {
 	APIKey string `yaml:"api_key" mapstructure:"api_key"`
 	// Timeout is the HTTP client timeout in seconds.
 	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	// Proxy is the proxy URL for this service, overriding the global proxy config.
+	Proxy string `yaml:"proxy" mapstructure:"proxy"`
+}
+
+// TMDBConfig holds the configuration for The Movie Database (TMDB) API.
+type TMDBConfig struct {
+	// APIKey is the TMDB API key (v3 auth).
+	APIKey string `yaml:"api_key" mapstructure:"api_key"`
+	// Timeout is the HTTP client timeout in seconds.
+	Timeout int `yaml:"timeout" mapstructure:"timeout"`
 }
 
 // SonarrConfig holds the configuration for the Sonarr server.
@@ -310,6 +791,8 @@ type SonarrConfig struct {
 	APIKey string `yaml:"api_key" mapstructure:"api_key"`
 	// Timeout is the HTTP client timeout in seconds.
 	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	// Proxy is the proxy URL for this service, overriding the global proxy config.
+	Proxy string `yaml:"proxy" mapstructure:"proxy"`
 }
 
 // RadarrConfig holds the configuration for the Radarr server.
@@ -320,6 +803,20 @@ type RadarrConfig struct {
 	APIKey string `yaml:"api_key" mapstructure:"api_key"`
 	// Timeout is the HTTP client timeout in seconds.
 	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	// Proxy is the proxy URL for this service, overriding the global proxy config.
+	Proxy string `yaml:"proxy" mapstructure:"proxy"`
+}
+
+// ReadarrConfig holds the configuration for the Readarr server.
+type ReadarrConfig struct {
+	// URL is the base URL of the Readarr server.
+	URL string `yaml:"url" mapstructure:"url"`
+	// APIKey is the API key for the Readarr server.
+	APIKey string `yaml:"api_key" mapstructure:"api_key"`
+	// Timeout is the HTTP client timeout in seconds.
+	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	// Proxy is the proxy URL for this service, overriding the global proxy config.
+	Proxy string `yaml:"proxy" mapstructure:"proxy"`
 }
 
 // JellystatConfig holds the configuration for the Jellystat server.
@@ -330,6 +827,8 @@ type JellystatConfig struct {
 	APIKey string `yaml:"api_key" mapstructure:"api_key"`
 	// Timeout is the HTTP client timeout in seconds.
 	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	// Proxy is the proxy URL for this service, overriding the global proxy config.
+	Proxy string `yaml:"proxy" mapstructure:"proxy"`
 }
 
 // StreamystatsConfig holds the configuration for the Streamystats server.
@@ -338,8 +837,26 @@ type StreamystatsConfig struct {
 	URL string `yaml:"url" mapstructure:"url"`
 	// ServerID is the Jellyfin server ID.
 	ServerID int `yaml:"server_id" mapstructure:"server_id"`
+	// ServerIDs are the IDs of multiple Jellyfin servers, e.g. of a cluster, whose play data is combined.
+	ServerIDs []int `yaml:"server_ids" mapstructure:"server_ids"`
 	// Timeout is the HTTP client timeout in seconds.
 	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	// Proxy is the proxy URL for this service, overriding the global proxy config.
+	Proxy string `yaml:"proxy" mapstructure:"proxy"`
+}
+
+// GetServerIDs returns the configured server IDs, the single server ID first.
+func (c *StreamystatsConfig) GetServerIDs() []int {
+	var ids []int
+	if c.ServerID != 0 {
+		ids = append(ids, c.ServerID)
+	}
+	for _, id := range c.ServerIDs {
+		if id != 0 && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
 }
 
 // TunarrConfig holds the configuration for the Tunarr server.
@@ -348,6 +865,33 @@ type TunarrConfig struct {
 	URL string `yaml:"url" mapstructure:"url"`
 	// Timeout is the HTTP client timeout in seconds.
 	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	// ProtectAllLibraries protects items used in Tunarr channels in every library, regardless of the per-library tunarr_enabled setting.
+	ProtectAllLibraries bool `yaml:"protect_all_libraries" mapstructure:"protect_all_libraries"`
+	// ChannelNamePatterns limits the protection to channels whose name matches one of these case-insensitive glob patterns.
+	// Items of all channels are protected if empty.
+	ChannelNamePatterns []string `yaml:"channel_name_patterns" mapstructure:"channel_name_patterns"`
+}
+
+// MatchesChannel reports whether the channel name matches one of the channel name patterns.
+// All channels match if no patterns are configured.
+func (c *TunarrConfig) MatchesChannel(name string) bool {
+	if len(c.ChannelNamePatterns) == 0 {
+		return true
+	}
+	for _, pattern := range c.ChannelNamePatterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(name)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// LogConfig holds the configuration of the log output.
+type LogConfig struct {
+	// Level sets the log verbosity. Options: "debug", "info", "warn", "error". Falls back to LogLevel if empty.
+	Level string `yaml:"level" mapstructure:"level"`
+	// Format is the output format of the logs. Options: "text", "json". Defaults to "text".
+	Format LogFormat `yaml:"format" mapstructure:"format"`
 }
 
 // JellyfinConfig holds the configuration for the Jellyfin server.
@@ -358,6 +902,48 @@ type JellyfinConfig struct {
 	APIKey string `yaml:"api_key" mapstructure:"api_key"`
 	// Timeout is the HTTP client timeout in seconds.
 	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	// Proxy is the proxy URL for this service, overriding the global proxy config.
+	Proxy string `yaml:"proxy" mapstructure:"proxy"`
+	// IncludeLibraries limits the scanned Jellyfin libraries to these libraries, empty scans all libraries.
+	IncludeLibraries []string `yaml:"include_libraries" mapstructure:"include_libraries"`
+	// ExcludeLibraries are Jellyfin libraries that are never scanned, even if they are included.
+	ExcludeLibraries []string `yaml:"exclude_libraries" mapstructure:"exclude_libraries"`
+}
+
+// IsLibraryScanned reports whether the Jellyfin library passes the include and exclude lists.
+// The exclude list takes precedence over the include list.
+func (c *JellyfinConfig) IsLibraryScanned(name string) bool {
+	if c == nil {
+		return true
+	}
+	if containsFold(c.ExcludeLibraries, name) {
+		return false
+	}
+	return len(c.IncludeLibraries) == 0 || containsFold(c.IncludeLibraries, name)
+}
+
+// EmbyConfig holds the configuration for the Emby server.
+type EmbyConfig struct {
+	// URL is the base URL of the Emby server.
+	URL string `yaml:"url" mapstructure:"url"`
+	// APIKey is the API key for the Emby server.
+	APIKey string `yaml:"api_key" mapstructure:"api_key"`
+	// Timeout is the HTTP client timeout in seconds.
+	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	// Proxy is the proxy URL for this service, overriding the global proxy config.
+	Proxy string `yaml:"proxy" mapstructure:"proxy"`
+}
+
+// PlexConfig holds the configuration for the Plex Media Server.
+type PlexConfig struct {
+	// URL is the base URL of the Plex server.
+	URL string `yaml:"url" mapstructure:"url"`
+	// Token is the X-Plex-Token used to authenticate against the Plex server.
+	Token string `yaml:"token" mapstructure:"token"`
+	// Timeout is the HTTP client timeout in seconds.
+	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	// Proxy is the proxy URL for this service, overriding the global proxy config.
+	Proxy string `yaml:"proxy" mapstructure:"proxy"`
 }
 
 // GravatarConfig holds the configuration for Gravatar profile pictures.
@@ -378,6 +964,51 @@ type GravatarConfig struct {
 // If path is empty, it will use default search paths for config files.
 // If no config file is found, it will generate a default one in the current directory.
 func Load(path string) (*Config, error) {
+	c, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Warn about deprecated configuration options
+	warnDeprecatedConfig(c)
+
+	// Validate required configs
+	if err := validateConfig(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Report is the result of checking a configuration with Check.
+type Report struct {
+	// ConfigFile is the path of the used config file, empty if only defaults and environment variables were used.
+	ConfigFile string
+	// Deprecations lists the deprecated options in use.
+	Deprecations []Deprecation
+	// ThresholdWarnings lists disk usage thresholds that are probably misordered.
+	ThresholdWarnings []ThresholdWarning
+	// Err is the validation error, nil if the configuration is valid.
+	Err error
+}
+
+// Check loads the configuration like Load, but reports the deprecated options and the validation error
+// instead of logging or returning them. An error is only returned if the config file can't be read.
+func Check(path string) (*Report, error) {
+	c, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Report{
+		ConfigFile:        v.ConfigFileUsed(),
+		Deprecations:      deprecations(c),
+		ThresholdWarnings: thresholdWarnings(c),
+		Err:               validateConfig(c),
+	}, nil
+}
+
+// load reads and sanitizes the configuration without validating it.
+func load(path string) (*Config, error) {
 	// bind some weirdly unsupported nested env vars
 	bindNestedEnv(v)
 
@@ -412,6 +1043,12 @@ func Load(path string) (*Config, error) {
 		configFileFound = true
 	}
 
+	// Apply the log settings before anything else is logged, so the debug lines below respect the configured level.
+	applyLogConfig(&Config{
+		LogLevel: v.GetString("log_level"),
+		Log:      &LogConfig{Level: v.GetString("log.level"), Format: LogFormat(v.GetString("log.format"))},
+	})
+
 	// Print info about config file usage
 	if configFileFound {
 		log.Debug("Using config file", "file", v.ConfigFileUsed())
@@ -423,33 +1060,65 @@ func Load(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
-	// Apply the resolved log level.
-	logging.SetLevel(c.LogLevel)
+	// Replace ${file:...} and ${env:...} references with the referenced secrets
+	if err := resolveSecretRefs(&c); err != nil {
+		return nil, err
+	}
+
+	// Apply the resolved log settings.
+	applyLogConfig(&c)
 
 	// Sanitize config values
 	sanitizeConfig(&c)
 
-	// Warn about deprecated configuration options
-	warnDeprecatedConfig(&c)
+	_, jellyfinAuthFromEnv := os.LookupEnv("JELLYSWEEP_AUTH_JELLYFIN_ENABLED")
+	applyJellyfinAuthDefault(&c, v.InConfig("auth.jellyfin.enabled") || jellyfinAuthFromEnv)
 
-	// Validate required configs
-	if err := validateConfig(&c); err != nil {
-		return nil, err
+	return &c, nil
+}
+
+// applyJellyfinAuthDefault disables the Jellyfin auth if it's only enabled by default and Jellyfin isn't the media server,
+// e.g. with Emby or Plex. An explicitly enabled Jellyfin auth is kept, so the validation still requires the jellyfin section.
+func applyJellyfinAuthDefault(c *Config, explicit bool) {
+	if explicit || c.Jellyfin != nil || c.Auth == nil || c.Auth.Jellyfin == nil {
+		return
 	}
+	c.Auth.Jellyfin.Enabled = false
+}
 
-	return &c, nil
+// applyLogConfig applies the log level and format of the configuration to the shared logger.
+func applyLogConfig(c *Config) {
+	logging.SetLevel(c.GetLogLevel())
+	logging.SetFormat(string(c.GetLogFormat()))
 }
 
 // setDefaults sets default values for the configuration.
 func setDefaults(v *viper.Viper) {
 	// Jellysweep defaults
 	v.SetDefault("log_level", "info")
+	v.SetDefault("log.level", "")
+	v.SetDefault("log.format", LogFormatText)
 	v.SetDefault("listen", "0.0.0.0:3002")
+	v.SetDefault("readiness_timeout", 5)
 	v.SetDefault("cleanup_schedule", "0 */12 * * *") // Every 12 hours
 	v.SetDefault("cleanup_mode", "all")              // Default to cleaning up everything
 	v.SetDefault("keep_count", 1)                    // Default to keeping 1 episode/season if mode is not "all"
+	v.SetDefault("delete_empty_series_after_cleanup", false)
+	v.SetDefault("cleanup_schedule_jitter_seconds", 0)
+	v.SetDefault("min_items_per_library", 0)
+	v.SetDefault("min_mark_to_delete_hours", 0)
+	v.SetDefault("max_items_per_run", 0)
+	v.SetDefault("order_by", ScanOrderAdded)
+	v.SetDefault("treat_collections_atomically", false)
+	v.SetDefault("atomic_mode", CollectionAtomicModeAllOrNone)
+	v.SetDefault("skip_if_downloading", true)
+	v.SetDefault("concurrency", defaultConcurrency)
+	v.SetDefault("timezone", "")
 	v.SetDefault("dry_run", true)
+	v.SetDefault("dry_run_report_path", "")
+	v.SetDefault("tag_prefix", "jellysweep")
 	v.SetDefault("server_url", "http://localhost:3002")
+	v.SetDefault("default_poster_url", "")
 	v.SetDefault("session_max_age", 172800) // 48 hour
 	v.SetDefault("session_key", "")
 	v.SetDefault("secure_cookies", true)
@@ -467,6 +1136,15 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("auth.oidc.auto_approve_group", "")
 	v.SetDefault("auth.oidc.timeout", 30)
 	v.SetDefault("auth.jellyfin.enabled", true)
+	v.SetDefault("auth.ldap.enabled", false)
+	v.SetDefault("auth.ldap.url", "")
+	v.SetDefault("auth.ldap.bind_dn", "")
+	v.SetDefault("auth.ldap.bind_password", "")
+	v.SetDefault("auth.ldap.base_dn", "")
+	v.SetDefault("auth.ldap.user_filter", "(uid=%s)")
+	v.SetDefault("auth.ldap.admin_group", "")
+	v.SetDefault("auth.ldap.auto_approve_group", "")
+	v.SetDefault("auth.ldap.timeout", 30)
 
 	// Database defaults
 	v.SetDefault("database.type", DatabaseTypeSQLite)
@@ -477,15 +1155,40 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("database.user", "")
 	v.SetDefault("database.password", "")
 	v.SetDefault("database.ssl_mode", "disable")
+	v.SetDefault("database.auto_migrate", true)
 
 	// Cache defaults
 	v.SetDefault("cache.type", CacheTypeMemory) // Default to in-memory
 	v.SetDefault("cache.redis_url", "")
+	v.SetDefault("cache.redis_prefix", "jellysweep:")
+	v.SetDefault("cache.ttl_seconds", 3600)
+
+	// Retry defaults
+	v.SetDefault("retry.max_retries", 3)
+	v.SetDefault("retry.base_delay_ms", 500)
+
+	// Stats defaults
+	v.SetDefault("stats.fail_mode", StatsFailModeSkipDeletions)
+
+	// Image cache defaults
+	v.SetDefault("image_cache.path", "./data/cache/images")
+	v.SetDefault("image_cache.ttl_days", 7)
+	v.SetDefault("image_cache.max_size_mb", 0)
+	v.SetDefault("image_cache.max_age_seconds", 86400)
 
 	// Leaving collections default
 	v.SetDefault("enable_leaving_collections", false)
 	v.SetDefault("leaving_collections_movie_name", "Leaving Movies")
 	v.SetDefault("leaving_collections_tv_name", "Leaving TV Shows")
+	v.SetDefault("leaving_collections_window_days", 0)
+	v.SetDefault("keep_expiry_reminder_days", 0)
+	v.SetDefault("delete_immediately_on_deny", false)
+	v.SetDefault("protect_requesters", []string{})
+	v.SetDefault("always_eligible_requesters", []string{})
+	v.SetDefault("auto_approve_libraries", []string{})
+
+	v.SetDefault("resync_jellyseerr_on_keep", false)
+	v.SetDefault("delete_jellyseerr_request_on_cleanup", false)
 
 	// Email defaults
 	v.SetDefault("email.enabled", false)
@@ -497,6 +1200,14 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("email.use_tls", true)
 	v.SetDefault("email.use_ssl", false)
 	v.SetDefault("email.insecure_skip_verify", false)
+	v.SetDefault("email.max_retries", 3)
+	v.SetDefault("email.timeout_seconds", 10)
+	v.SetDefault("email.template_path", "")
+	v.SetDefault("email.locale", "en")
+	v.SetDefault("email.max_items_per_email", 0)
+	v.SetDefault("email.batch_above_total", 0)
+	v.SetDefault("email.admin_email", "")
+	v.SetDefault("email.events", []string{})
 
 	// Ntfy defaults
 	v.SetDefault("ntfy.enabled", false)
@@ -506,6 +1217,7 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("ntfy.password", "")
 	v.SetDefault("ntfy.token", "")
 	v.SetDefault("ntfy.timeout", 30)
+	v.SetDefault("ntfy.events", []string{})
 
 	// Gravatar defaults
 	v.SetDefault("gravatar.enabled", false)
@@ -519,6 +1231,66 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("webpush.public_key", "")
 	v.SetDefault("webpush.private_key", "")
 	v.SetDefault("webpush.timeout", 30)
+	v.SetDefault("webpush.events", []string{})
+
+	// Slack defaults
+	v.SetDefault("slack.enabled", false)
+	v.SetDefault("slack.webhook_url", "")
+	v.SetDefault("slack.channel", "")
+	v.SetDefault("slack.timeout", 30)
+	v.SetDefault("slack.events", []string{})
+
+	// Hooks defaults
+	v.SetDefault("hooks.pre_delete_command", "")
+	v.SetDefault("hooks.pre_delete_webhook_url", "")
+	v.SetDefault("hooks.timeout", 30)
+
+	// Deletion retry defaults
+	v.SetDefault("deletion_retry.max_attempts", 5)
+	v.SetDefault("deletion_retry.initial_delay", 30)
+	v.SetDefault("deletion_retry.max_delay", 1440)
+
+	// Gotify defaults
+	v.SetDefault("gotify.enabled", false)
+	v.SetDefault("gotify.server_url", "")
+	v.SetDefault("gotify.token", "")
+	v.SetDefault("gotify.priority", 5)
+	v.SetDefault("gotify.timeout", 30)
+	v.SetDefault("gotify.events", []string{})
+
+	// Matrix defaults
+	v.SetDefault("matrix.enabled", false)
+	v.SetDefault("matrix.homeserver_url", "")
+	v.SetDefault("matrix.access_token", "")
+	v.SetDefault("matrix.room_id", "")
+	v.SetDefault("matrix.timeout", 30)
+	v.SetDefault("matrix.events", []string{})
+
+	// Apprise defaults
+	v.SetDefault("apprise.enabled", false)
+	v.SetDefault("apprise.server_url", "")
+	v.SetDefault("apprise.urls", []string{})
+	v.SetDefault("apprise.config_key", "")
+	v.SetDefault("apprise.timeout", 30)
+	v.SetDefault("apprise.events", []string{})
+
+	// Pushover defaults
+	v.SetDefault("pushover.enabled", false)
+	v.SetDefault("pushover.token", "")
+	v.SetDefault("pushover.user_key", "")
+	v.SetDefault("pushover.device", "")
+	v.SetDefault("pushover.timeout", 30)
+	v.SetDefault("pushover.events", []string{})
+
+	// Webhook defaults
+	v.SetDefault("webhook.enabled", false)
+	v.SetDefault("webhook.url", "")
+	v.SetDefault("webhook.method", http.MethodPost)
+	v.SetDefault("webhook.headers", map[string]string{})
+	v.SetDefault("webhook.template", "")
+	v.SetDefault("webhook.retries", 0)
+	v.SetDefault("webhook.timeout", 30)
+	v.SetDefault("webhook.events", []string{})
 }
 
 // the auto env function from viper only works for nested structs, if the struct to which a value binds isn't nil.
@@ -529,35 +1301,72 @@ func bindNestedEnv(v *viper.Viper) {
 	v.MustBindEnv("jellyseerr.url", "JELLYSWEEP_JELLYSEERR_URL")
 	v.MustBindEnv("jellyseerr.api_key", "JELLYSWEEP_JELLYSEERR_API_KEY")
 	v.MustBindEnv("jellyseerr.timeout", "JELLYSWEEP_JELLYSEERR_TIMEOUT")
+	v.MustBindEnv("jellyseerr.proxy", "JELLYSWEEP_JELLYSEERR_PROXY")
 
 	// Sonarr
 	v.MustBindEnv("sonarr.url", "JELLYSWEEP_SONARR_URL")
 	v.MustBindEnv("sonarr.api_key", "JELLYSWEEP_SONARR_API_KEY")
 	v.MustBindEnv("sonarr.timeout", "JELLYSWEEP_SONARR_TIMEOUT")
+	v.MustBindEnv("sonarr.proxy", "JELLYSWEEP_SONARR_PROXY")
 
 	// Radarr
 	v.MustBindEnv("radarr.url", "JELLYSWEEP_RADARR_URL")
 	v.MustBindEnv("radarr.api_key", "JELLYSWEEP_RADARR_API_KEY")
 	v.MustBindEnv("radarr.timeout", "JELLYSWEEP_RADARR_TIMEOUT")
+	v.MustBindEnv("radarr.proxy", "JELLYSWEEP_RADARR_PROXY")
+
+	// Readarr
+	v.MustBindEnv("readarr.url", "JELLYSWEEP_READARR_URL")
+	v.MustBindEnv("readarr.api_key", "JELLYSWEEP_READARR_API_KEY")
+	v.MustBindEnv("readarr.timeout", "JELLYSWEEP_READARR_TIMEOUT")
+	v.MustBindEnv("readarr.proxy", "JELLYSWEEP_READARR_PROXY")
 
 	// Jellystat
 	v.MustBindEnv("jellystat.url", "JELLYSWEEP_JELLYSTAT_URL")
 	v.MustBindEnv("jellystat.api_key", "JELLYSWEEP_JELLYSTAT_API_KEY")
 	v.MustBindEnv("jellystat.timeout", "JELLYSWEEP_JELLYSTAT_TIMEOUT")
+	v.MustBindEnv("jellystat.proxy", "JELLYSWEEP_JELLYSTAT_PROXY")
 
 	// Streamystats
 	v.MustBindEnv("streamystats.url", "JELLYSWEEP_STREAMYSTATS_URL")
 	v.MustBindEnv("streamystats.server_id", "JELLYSWEEP_STREAMYSTATS_SERVER_ID")
+	v.MustBindEnv("streamystats.server_ids", "JELLYSWEEP_STREAMYSTATS_SERVER_IDS")
 	v.MustBindEnv("streamystats.timeout", "JELLYSWEEP_STREAMYSTATS_TIMEOUT")
+	v.MustBindEnv("streamystats.proxy", "JELLYSWEEP_STREAMYSTATS_PROXY")
+
+	// Proxy
+	v.MustBindEnv("proxy.http_proxy", "JELLYSWEEP_PROXY_HTTP_PROXY")
+	v.MustBindEnv("proxy.https_proxy", "JELLYSWEEP_PROXY_HTTPS_PROXY")
+	v.MustBindEnv("proxy.no_proxy", "JELLYSWEEP_PROXY_NO_PROXY")
 
 	// Tunarr
 	v.MustBindEnv("tunarr.url", "JELLYSWEEP_TUNARR_URL")
 	v.MustBindEnv("tunarr.timeout", "JELLYSWEEP_TUNARR_TIMEOUT")
+	v.MustBindEnv("tunarr.protect_all_libraries", "JELLYSWEEP_TUNARR_PROTECT_ALL_LIBRARIES")
 
 	// Jellyfin
 	v.MustBindEnv("jellyfin.url", "JELLYSWEEP_JELLYFIN_URL")
 	v.MustBindEnv("jellyfin.api_key", "JELLYSWEEP_JELLYFIN_API_KEY")
 	v.MustBindEnv("jellyfin.timeout", "JELLYSWEEP_JELLYFIN_TIMEOUT")
+	v.MustBindEnv("jellyfin.proxy", "JELLYSWEEP_JELLYFIN_PROXY")
+	v.MustBindEnv("jellyfin.include_libraries", "JELLYSWEEP_JELLYFIN_INCLUDE_LIBRARIES")
+	v.MustBindEnv("jellyfin.exclude_libraries", "JELLYSWEEP_JELLYFIN_EXCLUDE_LIBRARIES")
+
+	// Emby
+	v.MustBindEnv("emby.url", "JELLYSWEEP_EMBY_URL")
+	v.MustBindEnv("emby.api_key", "JELLYSWEEP_EMBY_API_KEY")
+	v.MustBindEnv("emby.timeout", "JELLYSWEEP_EMBY_TIMEOUT")
+	v.MustBindEnv("emby.proxy", "JELLYSWEEP_EMBY_PROXY")
+
+	// Plex
+	v.MustBindEnv("plex.url", "JELLYSWEEP_PLEX_URL")
+	v.MustBindEnv("plex.token", "JELLYSWEEP_PLEX_TOKEN")
+	v.MustBindEnv("plex.timeout", "JELLYSWEEP_PLEX_TIMEOUT")
+	v.MustBindEnv("plex.proxy", "JELLYSWEEP_PLEX_PROXY")
+
+	// TMDB
+	v.MustBindEnv("tmdb.api_key", "JELLYSWEEP_TMDB_API_KEY")
+	v.MustBindEnv("tmdb.timeout", "JELLYSWEEP_TMDB_TIMEOUT")
 
 	// Database
 	v.MustBindEnv("database.type", "JELLYSWEEP_DATABASE_TYPE")
@@ -568,6 +1377,7 @@ func bindNestedEnv(v *viper.Viper) {
 	v.MustBindEnv("database.user", "JELLYSWEEP_DATABASE_USER")
 	v.MustBindEnv("database.password", "JELLYSWEEP_DATABASE_PASSWORD")
 	v.MustBindEnv("database.ssl_mode", "JELLYSWEEP_DATABASE_SSL_MODE")
+	v.MustBindEnv("database.auto_migrate", "JELLYSWEEP_DATABASE_AUTO_MIGRATE")
 }
 
 // validateConfig validates the configuration.
@@ -580,10 +1390,19 @@ func validateConfig(c *Config) error {
 	if c.CleanupSchedule == "" {
 		return fmt.Errorf("cleanup schedule is required")
 	}
-	// Basic validation for cron format (5 fields)
+	// Basic validation for cron format (5 fields, or 6 with seconds)
 	cronFields := strings.Fields(c.CleanupSchedule)
-	if len(cronFields) != 5 {
-		return fmt.Errorf("cleanup schedule must be a valid cron expression with 5 fields (minute hour day month weekday)")
+	if len(cronFields) != 5 && len(cronFields) != 6 {
+		return fmt.Errorf("cleanup schedule must be a valid cron expression with 5 fields (minute hour day month weekday) or 6 fields with leading seconds")
+	}
+	if c.CleanupScheduleJitterSeconds < 0 {
+		return fmt.Errorf("cleanup schedule jitter seconds must not be negative")
+	}
+
+	if c.Timezone != "" {
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", c.Timezone, err)
+		}
 	}
 
 	if c.CleanupMode == "" {
@@ -591,21 +1410,150 @@ func validateConfig(c *Config) error {
 	}
 
 	switch c.CleanupMode {
-	case CleanupModeAll, CleanupModeKeepEpisodes, CleanupModeKeepSeasons:
+	case CleanupModeAll, CleanupModeKeepEpisodes, CleanupModeKeepSeasons, CleanupModeKeepLatestEpisodes:
 		// valid
 	default:
 		return fmt.Errorf(
-			"invalid cleanup mode %q: must be one of %q, %q, %q",
+			"invalid cleanup mode %q: must be one of %q, %q, %q, %q",
 			c.CleanupMode,
 			CleanupModeAll,
 			CleanupModeKeepEpisodes,
 			CleanupModeKeepSeasons,
+			CleanupModeKeepLatestEpisodes,
 		)
 	}
 
-	if c.CleanupMode == CleanupModeKeepEpisodes || c.CleanupMode == CleanupModeKeepSeasons {
+	if c.CleanupMode != CleanupModeAll {
 		if c.KeepCount <= 0 {
-			return fmt.Errorf("keep count must be greater than 0 when using keep_episodes or keep_seasons mode")
+			return fmt.Errorf("keep count must be greater than 0 when using keep_episodes, keep_seasons or keep_latest_episodes mode")
+		}
+	}
+
+	if !tagPrefixRegexp.MatchString(c.TagPrefix) {
+		return fmt.Errorf("tag_prefix must only contain lowercase letters, digits and dashes")
+	}
+
+	if c.DryRunReportPath != "" {
+		switch strings.ToLower(filepath.Ext(c.DryRunReportPath)) {
+		case ".json", ".csv":
+			// valid
+		default:
+			return fmt.Errorf("dry run report path must end with .json or .csv")
+		}
+	}
+
+	if c.LeavingCollectionsWindowDays < 0 {
+		return fmt.Errorf("leaving collections window days must not be negative")
+	}
+
+	if c.DefaultPosterURL != "" {
+		if u, err := url.Parse(c.DefaultPosterURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("default poster URL must be an absolute http(s) URL")
+		}
+	}
+
+	if c.KeepExpiryReminderDays < 0 {
+		return fmt.Errorf("keep expiry reminder days must not be negative")
+	}
+
+	if c.MinItemsPerLibrary < 0 {
+		return fmt.Errorf("min items per library must not be negative")
+	}
+
+	if c.MaxItemsPerRun < 0 {
+		return fmt.Errorf("max items per run must not be negative")
+	}
+
+	switch c.OrderBy {
+	case "", ScanOrderAdded, ScanOrderSize:
+	default:
+		return fmt.Errorf("invalid order by %q", c.OrderBy)
+	}
+
+	switch c.AtomicMode {
+	case "", CollectionAtomicModeAllOrNone:
+	default:
+		return fmt.Errorf("invalid atomic mode %q", c.AtomicMode)
+	}
+
+	if c.Log != nil {
+		switch c.Log.Format {
+		case "", LogFormatText, LogFormatJSON:
+		default:
+			return fmt.Errorf("invalid log format %q", c.Log.Format)
+		}
+	}
+
+	if c.MinMarkToDeleteHours < 0 {
+		return fmt.Errorf("min mark to delete hours must not be negative")
+	}
+
+	if c.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+
+	if c.DeletionRetry == nil {
+		return fmt.Errorf("missing deletion retry config")
+	}
+	if c.DeletionRetry.MaxAttempts < 1 {
+		return fmt.Errorf("deletion retry max attempts must be at least 1")
+	}
+	if c.DeletionRetry.InitialDelay < 1 {
+		return fmt.Errorf("deletion retry initial delay must be at least 1 minute")
+	}
+	if c.DeletionRetry.MaxDelay < c.DeletionRetry.InitialDelay {
+		return fmt.Errorf("deletion retry max delay must not be smaller than the initial delay")
+	}
+
+	for _, mapping := range c.PathMappings {
+		if mapping.From == "" || mapping.To == "" {
+			return fmt.Errorf("path mappings require both from and to")
+		}
+	}
+	if err := validateLibraryPathMap(c); err != nil {
+		return err
+	}
+
+	for libraryName, libraryConfig := range c.Libraries {
+		if libraryConfig == nil {
+			continue
+		}
+		if percent := libraryConfig.Filter.ContentSizeThresholdPercent; percent < 0 || percent >= 100 {
+			return fmt.Errorf("content size threshold percent of library %s must be between 0 and 100", libraryName)
+		}
+		if libraryConfig.Filter.NewlyAddedGraceDays < 0 {
+			return fmt.Errorf("newly added grace days of library %s must not be negative", libraryName)
+		}
+		if libraryConfig.Filter.RequestAgeThreshold < 0 {
+			return fmt.Errorf("request age threshold of library %s must not be negative", libraryName)
+		}
+		if libraryConfig.Filter.MinHistoricalPlayCountProtect < 0 {
+			return fmt.Errorf("min historical play count protect of library %s must not be negative", libraryName)
+		}
+		if n := libraryConfig.Filter.ProtectIfRequestedByMultiple; n < 0 || n == 1 {
+			return fmt.Errorf("protect if requested by multiple of library %s must be 0 or at least 2", libraryName)
+		}
+		if c.Plex != nil && libraryConfig.Filter.ProtectFavorites {
+			return fmt.Errorf("protect favorites of library %s isn't supported with plex", libraryName)
+		}
+		if c.Plex != nil && libraryConfig.Filter.ProtectIfInProgressByAnyUser {
+			return fmt.Errorf("protect if in progress by any user of library %s isn't supported with plex", libraryName)
+		}
+		switch libraryConfig.Filter.FallbackAgeSource {
+		case FallbackAgeSourceNone, FallbackAgeSourceAdded, FallbackAgeSourceRelease:
+		default:
+			return fmt.Errorf("invalid fallback age source of library %s: %s", libraryName, libraryConfig.Filter.FallbackAgeSource)
+		}
+		switch libraryConfig.MediaType {
+		case LibraryMediaTypeAny, LibraryMediaTypeMovie, LibraryMediaTypeTV, LibraryMediaTypeBook:
+		default:
+			return fmt.Errorf("invalid media type of library %s: %s", libraryName, libraryConfig.MediaType)
+		}
+		if err := validateDiskUsageThresholds(libraryName, libraryConfig.DiskUsageThresholds); err != nil {
+			return err
+		}
+		if err := validateRuntimeRules(libraryName, libraryConfig.RuntimeRules); err != nil {
+			return err
 		}
 	}
 
@@ -613,6 +1561,10 @@ func validateConfig(c *Config) error {
 		return fmt.Errorf("session key is required")
 	}
 
+	if c.ReadinessTimeout < 1 {
+		return fmt.Errorf("readiness timeout must be at least 1 second")
+	}
+
 	if c.Database == nil {
 		return fmt.Errorf("missing database config")
 	}
@@ -664,24 +1616,24 @@ func validateConfig(c *Config) error {
 		return fmt.Errorf("missing auth config")
 	}
 
-	authEnabled := false
-	if c.Auth.OIDC != nil && c.Auth.OIDC.Enabled {
-		authEnabled = true
-		if c.Auth.OIDC.Issuer == "" {
-			return fmt.Errorf("OIDC issuer is required when OIDC is enabled")
-		}
-		if c.Auth.OIDC.ClientID == "" {
-			return fmt.Errorf("OIDC client ID is required when OIDC is enabled")
-		}
-		if c.Auth.OIDC.ClientSecret == "" {
-			return fmt.Errorf("OIDC client secret is required when OIDC is enabled")
-		}
-		if c.Auth.OIDC.RedirectURL == "" {
-			return fmt.Errorf("OIDC redirect URL is required when OIDC is enabled")
-		}
-		if c.Auth.OIDC.AdminGroup == "" {
-			return fmt.Errorf("OIDC admin group is required when OIDC is enabled")
-		}
+	if err := validateOIDCProviders(c.Auth); err != nil {
+		return err
+	}
+
+	if c.ImageCache == nil {
+		return fmt.Errorf("missing image cache config")
+	}
+	if c.ImageCache.Path == "" {
+		return fmt.Errorf("image cache path is required")
+	}
+	if c.ImageCache.TTLDays < 0 {
+		return fmt.Errorf("image cache TTL must not be negative")
+	}
+	if c.ImageCache.MaxSizeMB < 0 {
+		return fmt.Errorf("image cache max size must not be negative")
+	}
+	if c.ImageCache.MaxAgeSeconds < 0 {
+		return fmt.Errorf("image cache max age must not be negative")
 	}
 
 	if c.Cache != nil {
@@ -691,30 +1643,88 @@ func validateConfig(c *Config) error {
 		if c.Cache.Type == CacheTypeRedis && c.Cache.RedisURL == "" {
 			return fmt.Errorf("Redis URL is required when Redis cache is enabled") //nolint:staticcheck
 		}
+		if c.Cache.TTLSeconds < 0 {
+			return fmt.Errorf("cache TTL must not be negative")
+		}
 	} else {
 		c.Cache = &CacheConfig{
 			Type: CacheTypeMemory, // Default to in-memory cache if not enabled
 		}
 	}
 
-	if c.Jellyfin == nil {
-		return fmt.Errorf("missing jellyfin config")
+	if c.Retry != nil {
+		if c.Retry.MaxRetries < 0 {
+			return fmt.Errorf("retry max retries must not be negative")
+		}
+		if c.Retry.BaseDelayMS < 0 {
+			return fmt.Errorf("retry base delay must not be negative")
+		}
 	}
-	if c.Jellyfin.URL == "" {
-		return fmt.Errorf("jellyfin URL is required")
+
+	if c.Stats != nil {
+		switch c.Stats.FailMode {
+		case StatsFailModeSkipDeletions, StatsFailModeIgnoreStreamFilter, StatsFailModeContinue:
+		default:
+			return fmt.Errorf("invalid stats fail mode %q", c.Stats.FailMode)
+		}
 	}
-	if c.Jellyfin.APIKey == "" {
-		return fmt.Errorf("jellyfin API key is required")
+
+	var mediaServers int
+	for _, configured := range []bool{c.Jellyfin != nil, c.Emby != nil, c.Plex != nil} {
+		if configured {
+			mediaServers++
+		}
+	}
+	if mediaServers == 0 {
+		return fmt.Errorf("one of jellyfin, emby or plex config must be provided")
+	}
+	if mediaServers > 1 {
+		return fmt.Errorf("only one of jellyfin, emby or plex can be configured at a time")
+	}
+	if c.Jellyfin != nil {
+		if c.Jellyfin.URL == "" {
+			return fmt.Errorf("jellyfin URL is required")
+		}
+		if c.Jellyfin.APIKey == "" {
+			return fmt.Errorf("jellyfin API key is required")
+		}
+	}
+	if c.Emby != nil {
+		if c.Emby.URL == "" {
+			return fmt.Errorf("emby URL is required when emby is configured")
+		}
+		if c.Emby.APIKey == "" {
+			return fmt.Errorf("emby API key is required when emby is configured")
+		}
+	}
+	if c.Plex != nil {
+		if c.Plex.URL == "" {
+			return fmt.Errorf("plex URL is required when plex is configured")
+		}
+		if c.Plex.Token == "" {
+			return fmt.Errorf("plex token is required when plex is configured")
+		}
 	}
 
 	if c.Auth.Jellyfin != nil && c.Auth.Jellyfin.Enabled {
-		authEnabled = true
-		if c.Jellyfin.URL == "" {
+		if c.Jellyfin == nil || c.Jellyfin.URL == "" {
 			return fmt.Errorf("Jellyfin URL is required when Jellyfin auth is enabled") //nolint:staticcheck
 		}
 	}
 
-	if !authEnabled {
+	if c.Auth.LDAP != nil && c.Auth.LDAP.Enabled {
+		if c.Auth.LDAP.URL == "" {
+			return fmt.Errorf("LDAP URL is required when LDAP is enabled")
+		}
+		if c.Auth.LDAP.BaseDN == "" {
+			return fmt.Errorf("LDAP base DN is required when LDAP is enabled")
+		}
+		if !strings.Contains(c.Auth.LDAP.UserFilter, "%s") {
+			return fmt.Errorf("LDAP user filter must contain %%s as the username placeholder")
+		}
+	}
+
+	if !c.Auth.IsAuthenticationEnabled() {
 		return fmt.Errorf("at least one authentication method must be enabled")
 	}
 
@@ -727,8 +1737,12 @@ func validateConfig(c *Config) error {
 		}
 	}
 
-	if c.Sonarr == nil && c.Radarr == nil {
-		return fmt.Errorf("either sonarr or radarr config must be provided")
+	if c.TMDB != nil && c.TMDB.APIKey == "" {
+		return fmt.Errorf("tmdb API key is required")
+	}
+
+	if c.Sonarr == nil && c.Radarr == nil && c.Readarr == nil {
+		return fmt.Errorf("either sonarr, radarr or readarr config must be provided")
 	}
 
 	if c.Sonarr != nil {
@@ -749,11 +1763,39 @@ func validateConfig(c *Config) error {
 		}
 	}
 
+	if c.Readarr != nil {
+		if c.Readarr.URL == "" {
+			return fmt.Errorf("readarr URL is required when readarr is configured")
+		}
+		if c.Readarr.APIKey == "" {
+			return fmt.Errorf("readarr API key is required when readarr is configured")
+		}
+	}
+
+	if c.Proxy != nil {
+		if err := validateProxyURL("http", c.Proxy.HTTPProxy); err != nil {
+			return err
+		}
+		if err := validateProxyURL("https", c.Proxy.HTTPSProxy); err != nil {
+			return err
+		}
+	}
+
+	for name, proxyURL := range c.serviceProxies() {
+		if err := validateProxyURL(name, proxyURL); err != nil {
+			return err
+		}
+	}
+
 	if c.Jellystat != nil && c.Streamystats != nil {
 		return fmt.Errorf("only one of jellystat or streamystats can be configured at a time")
 	}
 
-	if c.Jellystat == nil && c.Streamystats == nil {
+	if c.Plex != nil && c.Jellystat != nil {
+		return fmt.Errorf("jellystat only knows jellyfin items and can't be used with plex, the plex play history is used instead")
+	}
+
+	if c.Jellystat == nil && c.Streamystats == nil && c.Plex == nil {
 		return fmt.Errorf("either jellystat or streamystats config must be provided")
 	}
 
@@ -770,8 +1812,11 @@ func validateConfig(c *Config) error {
 		if c.Streamystats.URL == "" {
 			return fmt.Errorf("streamystats URL is required when streamystats is configured")
 		}
-		if c.Streamystats.ServerID == 0 {
-			return fmt.Errorf("streamystats server ID is required when streamystats is configured")
+		if len(c.Streamystats.GetServerIDs()) == 0 {
+			return fmt.Errorf("streamystats server_id or server_ids is required when streamystats is configured")
+		}
+		if c.Jellyfin == nil {
+			return fmt.Errorf("streamystats requires a jellyfin config")
 		}
 	}
 
@@ -779,6 +1824,11 @@ func validateConfig(c *Config) error {
 		if c.Tunarr.URL == "" {
 			return fmt.Errorf("tunarr URL is required when tunarr is configured")
 		}
+		for _, pattern := range c.Tunarr.ChannelNamePatterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid tunarr channel name pattern %q: %w", pattern, err)
+			}
+		}
 	}
 
 	if c.Email != nil && c.Email.Enabled {
@@ -788,6 +1838,23 @@ func validateConfig(c *Config) error {
 		if c.Email.FromEmail == "" {
 			return fmt.Errorf("from email is required when email notifications are enabled")
 		}
+		if c.Email.MaxRetries < 0 {
+			return fmt.Errorf("email max retries must not be negative")
+		}
+		if c.Email.MaxItemsPerEmail < 0 {
+			return fmt.Errorf("email max items per email must not be negative")
+		}
+		if c.Email.BatchAboveTotal < 0 {
+			return fmt.Errorf("email batch above total must not be negative")
+		}
+		if !emailtemplate.ValidLocale(c.Email.Locale) {
+			return fmt.Errorf("unsupported email locale %q, supported locales are: %s", c.Email.Locale, strings.Join(emailtemplate.Locales(), ", "))
+		}
+		if c.Email.TemplatePath != "" {
+			if _, err := emailtemplate.ParseFile(c.Email.TemplatePath, c.Email.Locale); err != nil {
+				return err
+			}
+		}
 	}
 
 	if c.Ntfy != nil && c.Ntfy.Enabled {
@@ -805,6 +1872,105 @@ func validateConfig(c *Config) error {
 		}
 	}
 
+	if c.Slack != nil && c.Slack.Enabled {
+		if c.Slack.WebhookURL == "" {
+			return fmt.Errorf("slack webhook URL is required when slack notifications are enabled")
+		}
+	}
+
+	if c.Gotify != nil && c.Gotify.Enabled {
+		if c.Gotify.ServerURL == "" {
+			return fmt.Errorf("gotify server URL is required when gotify notifications are enabled")
+		}
+		if c.Gotify.Token == "" {
+			return fmt.Errorf("gotify token is required when gotify notifications are enabled")
+		}
+		if c.Gotify.Priority < 0 || c.Gotify.Priority > 10 {
+			return fmt.Errorf("gotify priority must be between 0 and 10")
+		}
+	}
+
+	if c.Matrix != nil && c.Matrix.Enabled {
+		if c.Matrix.HomeserverURL == "" {
+			return fmt.Errorf("matrix homeserver URL is required when matrix notifications are enabled")
+		}
+		if c.Matrix.AccessToken == "" {
+			return fmt.Errorf("matrix access token is required when matrix notifications are enabled")
+		}
+		if c.Matrix.RoomID == "" {
+			return fmt.Errorf("matrix room ID is required when matrix notifications are enabled")
+		}
+	}
+
+	if c.Apprise != nil && c.Apprise.Enabled {
+		if c.Apprise.ServerURL == "" {
+			return fmt.Errorf("apprise server URL is required when apprise notifications are enabled")
+		}
+		if len(c.Apprise.URLs) == 0 && c.Apprise.ConfigKey == "" {
+			return fmt.Errorf("either apprise urls or config key is required when apprise notifications are enabled")
+		}
+	}
+
+	if c.Pushover != nil && c.Pushover.Enabled {
+		if c.Pushover.Token == "" {
+			return fmt.Errorf("pushover token is required when pushover notifications are enabled")
+		}
+		if c.Pushover.UserKey == "" {
+			return fmt.Errorf("pushover user key is required when pushover notifications are enabled")
+		}
+	}
+
+	if c.Webhook != nil && c.Webhook.Enabled {
+		if c.Webhook.URL == "" {
+			return fmt.Errorf("webhook url is required when webhook notifications are enabled")
+		}
+		switch c.Webhook.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+		default:
+			return fmt.Errorf("invalid webhook method: %s, must be one of: POST, PUT, PATCH", c.Webhook.Method)
+		}
+		if c.Webhook.Retries < 0 {
+			return fmt.Errorf("webhook retries must not be negative")
+		}
+		if _, err := webhooktemplate.Parse(c.Webhook.Template); err != nil {
+			return err
+		}
+	}
+
+	notifierEvents := map[string]NotificationEvents{}
+	if c.Email != nil {
+		notifierEvents["email"] = c.Email.Events
+	}
+	if c.Ntfy != nil {
+		notifierEvents["ntfy"] = c.Ntfy.Events
+	}
+	if c.WebPush != nil {
+		notifierEvents["webpush"] = c.WebPush.Events
+	}
+	if c.Slack != nil {
+		notifierEvents["slack"] = c.Slack.Events
+	}
+	if c.Gotify != nil {
+		notifierEvents["gotify"] = c.Gotify.Events
+	}
+	if c.Matrix != nil {
+		notifierEvents["matrix"] = c.Matrix.Events
+	}
+	if c.Apprise != nil {
+		notifierEvents["apprise"] = c.Apprise.Events
+	}
+	if c.Pushover != nil {
+		notifierEvents["pushover"] = c.Pushover.Events
+	}
+	if c.Webhook != nil {
+		notifierEvents["webhook"] = c.Webhook.Events
+	}
+	for _, notifier := range slices.Sorted(maps.Keys(notifierEvents)) {
+		if err := validateNotificationEvents(notifier, notifierEvents[notifier]); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -820,6 +1986,14 @@ func sanitizeConfig(c *Config) {
 		c.Jellyfin.URL = urlSanitize(c.Jellyfin.URL)
 	}
 
+	if c.Emby != nil {
+		c.Emby.URL = urlSanitize(c.Emby.URL)
+	}
+
+	if c.Plex != nil {
+		c.Plex.URL = urlSanitize(c.Plex.URL)
+	}
+
 	if c.Jellyseerr != nil {
 		c.Jellyseerr.URL = urlSanitize(c.Jellyseerr.URL)
 	}
@@ -832,6 +2006,10 @@ func sanitizeConfig(c *Config) {
 		c.Radarr.URL = urlSanitize(c.Radarr.URL)
 	}
 
+	if c.Readarr != nil {
+		c.Readarr.URL = urlSanitize(c.Readarr.URL)
+	}
+
 	if c.Jellystat != nil {
 		c.Jellystat.URL = urlSanitize(c.Jellystat.URL)
 	}
@@ -844,6 +2022,17 @@ func sanitizeConfig(c *Config) {
 		c.Tunarr.URL = urlSanitize(c.Tunarr.URL)
 	}
 
+	if c.Gotify != nil {
+		c.Gotify.ServerURL = urlSanitize(c.Gotify.ServerURL)
+	}
+
+	if c.Matrix != nil {
+		c.Matrix.HomeserverURL = urlSanitize(c.Matrix.HomeserverURL)
+	}
+	if c.Apprise != nil {
+		c.Apprise.ServerURL = urlSanitize(c.Apprise.ServerURL)
+	}
+
 	if c.ServerURL != "" {
 		c.ServerURL = urlSanitize(c.ServerURL)
 	}
@@ -853,37 +2042,66 @@ func urlSanitize(url string) string {
 	return strings.TrimSuffix(strings.TrimSpace(url), "/")
 }
 
-// warnDeprecatedConfig logs warnings for any deprecated configuration options that are in use.
-func warnDeprecatedConfig(c *Config) {
+// Deprecation describes a deprecated configuration option of a library that is in use.
+type Deprecation struct {
+	Library     string
+	Option      string
+	Replacement string
+}
+
+// String returns a human readable description of the deprecation.
+func (d Deprecation) String() string {
+	return fmt.Sprintf("'%s' is deprecated, please use '%s' instead", d.Option, d.Replacement)
+}
+
+// deprecations returns the deprecated configuration options that are in use, sorted by library.
+func deprecations(c *Config) []Deprecation {
 	if c == nil || c.Libraries == nil {
-		return
+		return nil
 	}
 
-	for libraryName, libraryConfig := range c.Libraries {
+	var result []Deprecation
+	for _, libraryName := range slices.Sorted(maps.Keys(c.Libraries)) {
+		libraryConfig := c.Libraries[libraryName]
 		if libraryConfig == nil {
 			continue
 		}
 
 		// Check for deprecated ContentAgeThreshold
 		if libraryConfig.ContentAgeThreshold > 0 {
-			log.Warn("'content_age_threshold' is deprecated, please use 'filter.content_age_threshold' instead", "library", libraryName)
+			result = append(result, Deprecation{libraryName, "content_age_threshold", "filter.content_age_threshold"})
 		}
 
 		// Check for deprecated LastStreamThreshold
 		if libraryConfig.LastStreamThreshold > 0 {
-			log.Warn("'last_stream_threshold' is deprecated, please use 'filter.last_stream_threshold' instead", "library", libraryName)
+			result = append(result, Deprecation{libraryName, "last_stream_threshold", "filter.last_stream_threshold"})
 		}
 
 		// Check for deprecated ContentSizeThreshold
 		if libraryConfig.ContentSizeThreshold > 0 {
-			log.Warn("'content_size_threshold' is deprecated, please use 'filter.content_size_threshold' instead", "library", libraryName)
+			result = append(result, Deprecation{libraryName, "content_size_threshold", "filter.content_size_threshold"})
 		}
 
 		// Check for deprecated ExcludeTags
 		if len(libraryConfig.ExcludeTags) > 0 {
-			log.Warn("'exclude_tags' is deprecated, please use 'filter.exclude_tags' instead", "library", libraryName)
+			result = append(result, Deprecation{libraryName, "exclude_tags", "filter.exclude_tags"})
 		}
 	}
+	return result
+}
+
+// warnDeprecatedConfig logs warnings for any deprecated configuration options that are in use,
+// for probably misordered disk usage thresholds and for email notifications that can't be sent without Jellyseerr.
+func warnDeprecatedConfig(c *Config) {
+	for _, d := range deprecations(c) {
+		log.Warn(d.String(), "library", d.Library)
+	}
+	for _, w := range thresholdWarnings(c) {
+		log.Warn(w.String(), "library", w.Library)
+	}
+	if c.Jellyseerr == nil && c.Email != nil && c.Email.Enabled {
+		log.Warn("Jellyseerr isn't configured, users aren't emailed about their media being marked for deletion")
+	}
 }
 
 // GetLibraryConfig returns the cleanup configuration for a specific library.
@@ -904,6 +2122,28 @@ func (c *Config) GetLibraryConfig(libraryName string) *CleanupConfig {
 	return nil
 }
 
+// GetLogLevel returns the log level, preferring log.level over the top-level log_level.
+func (c *Config) GetLogLevel() string {
+	if c == nil {
+		return "info"
+	}
+	if c.Log != nil && c.Log.Level != "" {
+		return c.Log.Level
+	}
+	if c.LogLevel == "" {
+		return "info"
+	}
+	return c.LogLevel
+}
+
+// GetLogFormat returns the log format with proper defaults.
+func (c *Config) GetLogFormat() LogFormat {
+	if c == nil || c.Log == nil || c.Log.Format == "" {
+		return LogFormatText
+	}
+	return c.Log.Format
+}
+
 // GetCleanupMode returns the cleanup mode with proper defaults.
 func (c *Config) GetCleanupMode() CleanupMode {
 	if c == nil || c.CleanupMode == "" {
@@ -920,6 +2160,14 @@ func (c *Config) GetKeepCount() int {
 	return c.KeepCount
 }
 
+// GetConcurrency returns the maximum number of parallel requests for per-item lookups.
+func (c *Config) GetConcurrency() int {
+	if c == nil || c.Concurrency < 1 {
+		return defaultConcurrency
+	}
+	return c.Concurrency
+}
+
 // GetContentAgeThreshold returns the content age threshold with proper defaults.
 // It first checks the new Filter.ContentAgeThreshold field, and falls back to the
 // deprecated ContentAgeThreshold field if the new field is not set.
@@ -999,3 +2247,196 @@ func (c *CleanupConfig) GetExcludeTags() []string {
 	// Default value
 	return []string{} // Default to empty list
 }
+
+// CronWithSeconds reports whether the cleanup schedule includes a leading seconds field.
+func (c *Config) CronWithSeconds() bool {
+	return len(strings.Fields(c.CleanupSchedule)) == 6
+}
+
+// Location returns the configured timezone, or the local timezone if none is set.
+func (c *Config) Location() *time.Location {
+	if c.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// EnabledOIDCProviders returns the enabled OIDC providers, the single oidc provider first.
+func (a *AuthConfig) EnabledOIDCProviders() []*OIDCConfig {
+	if a == nil {
+		return nil
+	}
+	var providers []*OIDCConfig
+	if a.OIDC != nil && a.OIDC.Enabled {
+		providers = append(providers, a.OIDC)
+	}
+	for _, provider := range a.OIDCProviders {
+		if provider != nil && provider.Enabled {
+			providers = append(providers, provider)
+		}
+	}
+	return providers
+}
+
+// LoginPath returns the path of the login route of the provider.
+func (o *OIDCConfig) LoginPath() string {
+	if o.ID == "" {
+		return "/auth/oidc/login"
+	}
+	return "/auth/oidc/" + o.ID + "/login"
+}
+
+// DisplayName returns the name shown on the login page, the ID if no name is configured.
+func (o *OIDCConfig) DisplayName() string {
+	if o.Name != "" {
+		return o.Name
+	}
+	if o.ID != "" {
+		return o.ID
+	}
+	return "OIDC"
+}
+
+// validateOIDCProviders checks the IDs of the OIDC providers and the required fields of every enabled provider.
+func validateOIDCProviders(a *AuthConfig) error {
+	providerIDs := make(map[string]bool, len(a.OIDCProviders)+1)
+	if a.OIDC != nil && a.OIDC.ID != "" {
+		if !oidcProviderIDRegexp.MatchString(a.OIDC.ID) {
+			return fmt.Errorf("invalid OIDC id %q, only lowercase letters, digits and dashes are allowed", a.OIDC.ID)
+		}
+		providerIDs[a.OIDC.ID] = true
+	}
+	for i, provider := range a.OIDCProviders {
+		if provider == nil {
+			return fmt.Errorf("OIDC provider %d is empty", i)
+		}
+		if !oidcProviderIDRegexp.MatchString(provider.ID) {
+			return fmt.Errorf("OIDC provider %d: invalid id %q, only lowercase letters, digits and dashes are allowed", i, provider.ID)
+		}
+		if providerIDs[provider.ID] {
+			return fmt.Errorf("OIDC provider id %q is used more than once", provider.ID)
+		}
+		providerIDs[provider.ID] = true
+	}
+
+	for _, provider := range a.EnabledOIDCProviders() {
+		if err := validateOIDCConfig(provider); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateOIDCConfig checks the required fields of an enabled OIDC provider.
+func validateOIDCConfig(o *OIDCConfig) error {
+	name := "OIDC"
+	if o.ID != "" {
+		name = fmt.Sprintf("OIDC provider %q", o.ID)
+	}
+	if o.Issuer == "" {
+		return fmt.Errorf("%s issuer is required when OIDC is enabled", name)
+	}
+	if o.ClientID == "" {
+		return fmt.Errorf("%s client ID is required when OIDC is enabled", name)
+	}
+	if o.ClientSecret == "" {
+		return fmt.Errorf("%s client secret is required when OIDC is enabled", name)
+	}
+	if o.RedirectURL == "" {
+		return fmt.Errorf("%s redirect URL is required when OIDC is enabled", name)
+	}
+	if o.AdminGroup == "" {
+		return fmt.Errorf("%s admin group is required when OIDC is enabled", name)
+	}
+	return nil
+}
+
+// IsAuthenticationEnabled reports whether at least one authentication method is enabled.
+func (a *AuthConfig) IsAuthenticationEnabled() bool {
+	if a == nil {
+		return false
+	}
+	return len(a.EnabledOIDCProviders()) > 0 ||
+		(a.Jellyfin != nil && a.Jellyfin.Enabled) ||
+		(a.LDAP != nil && a.LDAP.Enabled)
+}
+
+// GetStatsFailMode returns the configured stats fail mode, skip_deletions if none is configured.
+func (c *Config) GetStatsFailMode() StatsFailMode {
+	if c.Stats == nil || c.Stats.FailMode == "" {
+		return StatsFailModeSkipDeletions
+	}
+	return c.Stats.FailMode
+}
+
+// HasRequesterRules reports whether any requester is protected or always eligible, globally or in any library,
+// or any library has a request age threshold or protects media with multiple requesters. The filters then need the request info of every item.
+func (c *Config) HasRequesterRules() bool {
+	if len(c.ProtectRequesters) > 0 || len(c.AlwaysEligibleRequesters) > 0 {
+		return true
+	}
+	for _, libraryConfig := range c.Libraries {
+		if libraryConfig == nil {
+			continue
+		}
+		if len(libraryConfig.Filter.ProtectRequesters) > 0 || len(libraryConfig.Filter.AlwaysEligibleRequesters) > 0 ||
+			libraryConfig.Filter.RequestAgeThreshold > 0 || libraryConfig.Filter.ProtectIfRequestedByMultiple > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsProtectedRequester reports whether media of the given library requested by requester must never be deleted.
+// An unknown (empty) requester is never protected.
+func (c *Config) IsProtectedRequester(libraryName, requester string) bool {
+	if requester == "" {
+		return false
+	}
+	if containsFold(c.ProtectRequesters, requester) {
+		return true
+	}
+	libraryConfig := c.GetLibraryConfig(libraryName)
+	return libraryConfig != nil && containsFold(libraryConfig.Filter.ProtectRequesters, requester)
+}
+
+// IsProtectedByRequesterCount reports whether media of the given library with this many distinct requesters must not be deleted.
+func (c *Config) IsProtectedByRequesterCount(libraryName string, requesters int) bool {
+	libraryConfig := c.GetLibraryConfig(libraryName)
+	if libraryConfig == nil || libraryConfig.Filter.ProtectIfRequestedByMultiple <= 0 {
+		return false
+	}
+	return requesters >= libraryConfig.Filter.ProtectIfRequestedByMultiple
+}
+
+// IsAlwaysEligibleRequester reports whether media of the given library requested by requester skips the age and stream thresholds.
+// An unknown (empty) requester is never always eligible, and protection takes precedence.
+func (c *Config) IsAlwaysEligibleRequester(libraryName, requester string) bool {
+	if requester == "" || c.IsProtectedRequester(libraryName, requester) {
+		return false
+	}
+	if containsFold(c.AlwaysEligibleRequesters, requester) {
+		return true
+	}
+	libraryConfig := c.GetLibraryConfig(libraryName)
+	return libraryConfig != nil && containsFold(libraryConfig.Filter.AlwaysEligibleRequesters, requester)
+}
+
+// IsAutoApproveLibrary reports whether keep requests for media of the given library are approved automatically.
+func (c *Config) IsAutoApproveLibrary(libraryName string) bool {
+	return libraryName != "" && containsFold(c.AutoApproveLibraries, libraryName)
+}
+
+// containsFold reports whether list contains s, ignoring case.
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(strings.TrimSpace(item), s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validOIDCConfig(id string) *OIDCConfig {
+	return &OIDCConfig{
+		Enabled:      true,
+		ID:           id,
+		Issuer:       "https://sso.example.com",
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		RedirectURL:  "https://jellysweep.example.com/auth/oidc/" + id + "/callback",
+		AdminGroup:   "admins",
+	}
+}
+
+func TestValidateOIDCProviders(t *testing.T) {
+	missingSecret := validOIDCConfig("google")
+	missingSecret.ClientSecret = ""
+	disabled := &OIDCConfig{ID: "disabled"}
+
+	tests := []struct {
+		name    string
+		auth    *AuthConfig
+		wantErr string
+	}{
+		{name: "single provider", auth: &AuthConfig{OIDC: validOIDCConfig("")}},
+		{name: "single and listed providers", auth: &AuthConfig{
+			OIDC:          validOIDCConfig(""),
+			OIDCProviders: []*OIDCConfig{validOIDCConfig("google"), disabled},
+		}},
+		{
+			name:    "listed provider without id",
+			auth:    &AuthConfig{OIDCProviders: []*OIDCConfig{validOIDCConfig("")}},
+			wantErr: "invalid id",
+		},
+		{
+			name:    "duplicate id",
+			auth:    &AuthConfig{OIDCProviders: []*OIDCConfig{validOIDCConfig("google"), validOIDCConfig("google")}},
+			wantErr: "used more than once",
+		},
+		{
+			name:    "every provider is validated",
+			auth:    &AuthConfig{OIDC: validOIDCConfig(""), OIDCProviders: []*OIDCConfig{missingSecret}},
+			wantErr: `OIDC provider "google" client secret is required`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateOIDCProviders(tt.auth)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestIsAuthenticationEnabledWithOIDCProviders(t *testing.T) {
+	auth := &AuthConfig{OIDCProviders: []*OIDCConfig{{ID: "google"}}}
+	assert.False(t, auth.IsAuthenticationEnabled())
+
+	auth.OIDCProviders = append(auth.OIDCProviders, validOIDCConfig("authentik"))
+	assert.True(t, auth.IsAuthenticationEnabled())
+	assert.Equal(t, []*OIDCConfig{auth.OIDCProviders[1]}, auth.EnabledOIDCProviders())
+	assert.Equal(t, "/auth/oidc/authentik/login", auth.OIDCProviders[1].LoginPath())
+}
+
+func TestLogSettings(t *testing.T) {
+	c := &Config{LogLevel: "warn"}
+	assert.Equal(t, "warn", c.GetLogLevel())
+	assert.Equal(t, LogFormatText, c.GetLogFormat())
+
+	c.Log = &LogConfig{Level: "debug", Format: LogFormatJSON}
+	assert.Equal(t, "debug", c.GetLogLevel(), "log.level wins over log_level")
+	assert.Equal(t, LogFormatJSON, c.GetLogFormat())
+
+	c.Log.Level = ""
+	assert.Equal(t, "warn", c.GetLogLevel())
+	assert.Equal(t, "info", (&Config{}).GetLogLevel())
+}
+
+func TestApplyJellyfinAuthDefault(t *testing.T) {
+	c := &Config{Emby: &EmbyConfig{URL: "http://emby:8096", APIKey: "key"}, Auth: &AuthConfig{Jellyfin: &JellyfinAuthConfig{Enabled: true}}}
+	applyJellyfinAuthDefault(c, false)
+	assert.False(t, c.Auth.Jellyfin.Enabled, "the default doesn't apply without a jellyfin section")
+
+	c.Auth.Jellyfin.Enabled = true
+	applyJellyfinAuthDefault(c, true)
+	assert.True(t, c.Auth.Jellyfin.Enabled, "an explicitly enabled Jellyfin auth is kept")
+
+	c = &Config{Jellyfin: &JellyfinConfig{URL: "http://jellyfin:8096"}, Auth: &AuthConfig{Jellyfin: &JellyfinAuthConfig{Enabled: true}}}
+	applyJellyfinAuthDefault(c, false)
+	assert.True(t, c.Auth.Jellyfin.Enabled)
+}
+
+func TestLoadEmbyOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`session_key: secret
+libraries:
+  Movies:
+    enabled: true
+emby:
+  url: http://emby:8096
+  api_key: emby-key
+radarr:
+  url: http://radarr:7878
+  api_key: radarr-key
+jellystat:
+  url: http://jellystat:3000
+  api_key: jellystat-key
+auth:
+  ldap:
+    enabled: true
+    url: ldap://ldap:389
+    base_dn: dc=example,dc=com
+`), 0o600))
+
+	c, err := Load(path)
+	require.NoError(t, err, "the Jellyfin auth default doesn't require a jellyfin section")
+	assert.False(t, c.Auth.Jellyfin.Enabled)
+}
+
+func TestLoadPlexRejectsUnsupportedFilters(t *testing.T) {
+	for _, option := range []string{"protect_favorites", "protect_if_in_progress_by_any_user"} {
+		t.Run(option, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yml")
+			require.NoError(t, os.WriteFile(path, []byte(`session_key: secret
+libraries:
+  Movies:
+    enabled: true
+    filter:
+      `+option+`: true
+plex:
+  url: http://plex:32400
+  token: plex-token
+radarr:
+  url: http://radarr:7878
+  api_key: radarr-key
+auth:
+  ldap:
+    enabled: true
+    url: ldap://ldap:389
+    base_dn: dc=example,dc=com
+`), 0o600))
+
+			_, err := Load(path)
+			require.ErrorContains(t, err, "isn't supported with plex")
+		})
+	}
+}
+
+func TestLoadPlexStats(t *testing.T) {
+	const base = `session_key: secret
+libraries:
+  Movies:
+    enabled: true
+plex:
+  url: http://plex:32400
+  token: plex-token
+radarr:
+  url: http://radarr:7878
+  api_key: radarr-key
+auth:
+  ldap:
+    enabled: true
+    url: ldap://ldap:389
+    base_dn: dc=example,dc=com
+`
+
+	t.Run("play history without stats backend", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yml")
+		require.NoError(t, os.WriteFile(path, []byte(base), 0o600))
+
+		_, err := Load(path)
+		require.NoError(t, err)
+	})
+
+	t.Run("jellystat is rejected", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yml")
+		require.NoError(t, os.WriteFile(path, []byte(base+`jellystat:
+  url: http://jellystat:3000
+  api_key: jellystat-key
+`), 0o600))
+
+		_, err := Load(path)
+		require.ErrorContains(t, err, "can't be used with plex")
+	})
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LibraryPath assigns the items below a root folder of the arrs to a library.
+type LibraryPath struct {
+	// Path is the path prefix as reported by Sonarr, Radarr or Readarr (e.g. "/data/anime").
+	Path string `yaml:"path" mapstructure:"path"`
+	// Library is the name of the library the items below the path belong to (e.g. "Anime").
+	Library string `yaml:"library" mapstructure:"library"`
+}
+
+// validateLibraryPathMap checks that every entry of the library path map has a path and a configured library.
+func validateLibraryPathMap(c *Config) error {
+	for _, libraryPath := range c.LibraryPathMap {
+		if libraryPath.Path == "" || libraryPath.Library == "" {
+			return fmt.Errorf("library path map requires both path and library")
+		}
+		if c.GetLibraryConfig(libraryPath.Library) == nil {
+			return fmt.Errorf("library path map references unknown library %s", libraryPath.Library)
+		}
+	}
+	return nil
+}
+
+// LibraryForPath returns the library of the longest path prefix in the library path map that matches the path of an arr item.
+// Prefixes only match whole path segments and trailing slashes are ignored. It returns an empty string if no prefix matches.
+func (c *Config) LibraryForPath(path string) string {
+	var (
+		library  string
+		bestPath string
+	)
+	for _, libraryPath := range c.LibraryPathMap {
+		prefix := strings.TrimRight(libraryPath.Path, "/")
+		if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+			continue
+		}
+		if library == "" || len(prefix) > len(bestPath) {
+			library, bestPath = libraryPath.Library, prefix
+		}
+	}
+	return library
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLibraryForPath(t *testing.T) {
+	cfg := &Config{
+		LibraryPathMap: []LibraryPath{
+			{Path: "/data", Library: "Movies"},
+			{Path: "/data/anime/", Library: "Anime"},
+			{Path: "/data/anime/movies", Library: "Anime Movies"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "shortest prefix", path: "/data/movies/Heat (1995)", want: "Movies"},
+		{name: "longest prefix wins", path: "/data/anime/Cowboy Bebop", want: "Anime"},
+		{name: "longest prefix wins regardless of order", path: "/data/anime/movies/Akira (1988)", want: "Anime Movies"},
+		{name: "exact match", path: "/data/anime", want: "Anime"},
+		{name: "partial segment does not match", path: "/data/animes/Trigun", want: "Movies"},
+		{name: "no match", path: "/media/tv/Lost", want: ""},
+		{name: "empty path", path: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, cfg.LibraryForPath(tt.path))
+		})
+	}
+
+	assert.Empty(t, (&Config{}).LibraryForPath("/data/anime/Cowboy Bebop"), "without a path map no library is found")
+}
+
+func TestValidateLibraryPathMap(t *testing.T) {
+	cfg := &Config{Libraries: map[string]*CleanupConfig{"anime": {Enabled: true}}}
+	assert.NoError(t, validateLibraryPathMap(cfg))
+
+	cfg.LibraryPathMap = []LibraryPath{{Path: "/data/anime", Library: "Anime"}}
+	assert.NoError(t, validateLibraryPathMap(cfg))
+
+	cfg.LibraryPathMap = []LibraryPath{{Path: "/data/anime"}}
+	assert.ErrorContains(t, validateLibraryPathMap(cfg), "requires both path and library")
+
+	cfg.LibraryPathMap = []LibraryPath{{Path: "/data/movies", Library: "Movies"}}
+	assert.ErrorContains(t, validateLibraryPathMap(cfg), "unknown library Movies")
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+)
+
+// NotificationEvent is an event a notifier can be notified about.
+type NotificationEvent string
+
+const (
+	// NotificationEventKeepRequest is sent to the admins if a keep request needs a manual approval.
+	NotificationEventKeepRequest NotificationEvent = "keep_request"
+	// NotificationEventKeepRequestDecision is sent to the requester if their keep request was approved or declined.
+	NotificationEventKeepRequestDecision NotificationEvent = "keep_request_decision"
+	// NotificationEventDeletionSummary is sent after a cleanup run marked media for deletion.
+	NotificationEventDeletionSummary NotificationEvent = "deletion_summary"
+	// NotificationEventDeletionCompleted is sent after marked media was deleted.
+	NotificationEventDeletionCompleted NotificationEvent = "deletion_completed"
+	// NotificationEventDeletionFailed is sent to the admins if the deletion of an item was given up.
+	NotificationEventDeletionFailed NotificationEvent = "deletion_failed"
+	// NotificationEventKeepExpiryReminder is sent to the requester before the protection of their media expires.
+	NotificationEventKeepExpiryReminder NotificationEvent = "keep_expiry_reminder"
+	// NotificationEventKeptMediaDeleted is sent to the former requester if their media was deleted after the protection expired.
+	NotificationEventKeptMediaDeleted NotificationEvent = "kept_media_deleted"
+)
+
+// notificationEvents are all known notification events.
+var notificationEvents = []NotificationEvent{
+	NotificationEventKeepRequest,
+	NotificationEventKeepRequestDecision,
+	NotificationEventDeletionSummary,
+	NotificationEventDeletionCompleted,
+	NotificationEventDeletionFailed,
+	NotificationEventKeepExpiryReminder,
+	NotificationEventKeptMediaDeleted,
+}
+
+// NotificationEvents are the events a notifier is notified about. An empty list includes all events.
+type NotificationEvents []NotificationEvent
+
+// Includes reports whether the notifier is notified about the event.
+func (e NotificationEvents) Includes(event NotificationEvent) bool {
+	return len(e) == 0 || slices.Contains(e, event)
+}
+
+// validateNotificationEvents checks that the events of a notifier are known.
+func validateNotificationEvents(notifier string, events NotificationEvents) error {
+	for _, event := range events {
+		if !slices.Contains(notificationEvents, event) {
+			return fmt.Errorf("invalid %s notification event: %s, must be one of: %v", notifier, event, notificationEvents)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationEventsIncludes(t *testing.T) {
+	var all NotificationEvents
+	assert.True(t, all.Includes(NotificationEventKeepRequest), "an empty list includes all events")
+	assert.True(t, all.Includes(NotificationEventDeletionFailed))
+
+	events := NotificationEvents{NotificationEventDeletionSummary, NotificationEventDeletionCompleted}
+	assert.True(t, events.Includes(NotificationEventDeletionSummary))
+	assert.True(t, events.Includes(NotificationEventDeletionCompleted))
+	assert.False(t, events.Includes(NotificationEventKeepRequest))
+}
+
+func TestValidateNotificationEvents(t *testing.T) {
+	assert.NoError(t, validateNotificationEvents("ntfy", nil))
+	assert.NoError(t, validateNotificationEvents("ntfy", NotificationEvents{NotificationEventKeepRequest}))
+	assert.ErrorContains(t, validateNotificationEvents("ntfy", NotificationEvents{"keep-request"}), "invalid ntfy notification event: keep-request")
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ProxyConfig holds the proxy used for outbound requests to the media servers, arrs, Jellyseerr and the stats services.
+type ProxyConfig struct {
+	// HTTPProxy is the proxy URL for plain HTTP requests. http, https and socks5 proxies are supported.
+	HTTPProxy string `yaml:"http_proxy" mapstructure:"http_proxy"`
+	// HTTPSProxy is the proxy URL for HTTPS requests.
+	HTTPSProxy string `yaml:"https_proxy" mapstructure:"https_proxy"`
+	// NoProxy is a comma-separated list of hosts, domains and CIDRs that are requested directly.
+	NoProxy string `yaml:"no_proxy" mapstructure:"no_proxy"`
+}
+
+// isSet reports whether any proxy is configured.
+func (p *ProxyConfig) isSet() bool {
+	return p != nil && (p.HTTPProxy != "" || p.HTTPSProxy != "")
+}
+
+// NewHTTPClient returns an HTTP client with the given timeout that sends its requests through the proxy of a service.
+// serviceProxy overrides the global proxy config. Without either, the proxy environment variables are used.
+func NewHTTPClient(timeout int, proxy *ProxyConfig, serviceProxy string) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxyFunc(proxy, serviceProxy)
+	return &http.Client{
+		Timeout:   TimeoutDuration(timeout),
+		Transport: transport,
+	}
+}
+
+// proxyFunc returns the function that selects the proxy of a request.
+func proxyFunc(proxy *ProxyConfig, serviceProxy string) func(*http.Request) (*url.URL, error) {
+	if serviceProxy != "" {
+		u, err := url.Parse(serviceProxy)
+		if err != nil {
+			// the proxy URL is validated when the config is loaded
+			return func(*http.Request) (*url.URL, error) { return nil, fmt.Errorf("invalid proxy URL: %w", err) }
+		}
+		return http.ProxyURL(u)
+	}
+	if proxy.isSet() {
+		cfg := httpproxy.Config{
+			HTTPProxy:  proxy.HTTPProxy,
+			HTTPSProxy: proxy.HTTPSProxy,
+			NoProxy:    proxy.NoProxy,
+		}
+		fn := cfg.ProxyFunc()
+		return func(req *http.Request) (*url.URL, error) {
+			return fn(req.URL)
+		}
+	}
+	return http.ProxyFromEnvironment
+}
+
+// validateProxyURL checks that a proxy URL has a supported scheme and a host.
+func validateProxyURL(name, proxyURL string) error {
+	if proxyURL == "" {
+		return nil
+	}
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return fmt.Errorf("invalid %s proxy URL: %w", name, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return fmt.Errorf("%s proxy URL must use the http, https, socks5 or socks5h scheme", name)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%s proxy URL must contain a host", name)
+	}
+	return nil
+}
+
+// serviceProxies returns the proxy URLs of the configured services by their name.
+func (c *Config) serviceProxies() map[string]string {
+	proxies := make(map[string]string)
+	if c.Jellyfin != nil {
+		proxies["jellyfin"] = c.Jellyfin.Proxy
+	}
+	if c.Emby != nil {
+		proxies["emby"] = c.Emby.Proxy
+	}
+	if c.Plex != nil {
+		proxies["plex"] = c.Plex.Proxy
+	}
+	if c.Jellyseerr != nil {
+		proxies["jellyseerr"] = c.Jellyseerr.Proxy
+	}
+	if c.Sonarr != nil {
+		proxies["sonarr"] = c.Sonarr.Proxy
+	}
+	if c.Radarr != nil {
+		proxies["radarr"] = c.Radarr.Proxy
+	}
+	if c.Readarr != nil {
+		proxies["readarr"] = c.Readarr.Proxy
+	}
+	if c.Jellystat != nil {
+		proxies["jellystat"] = c.Jellystat.Proxy
+	}
+	if c.Streamystats != nil {
+		proxies["streamystats"] = c.Streamystats.Proxy
+	}
+	return proxies
+}
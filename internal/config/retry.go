@@ -0,0 +1,99 @@
+package config
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// maxRetryDelay caps the delay between two attempts, including delays requested by Retry-After.
+const maxRetryDelay = time.Minute
+
+// RetryConfig holds the retries of idempotent requests that were rejected because the server is overloaded.
+type RetryConfig struct {
+	// MaxRetries is the maximum number of retries of a single request. 0 disables the retries.
+	MaxRetries int `yaml:"max_retries" mapstructure:"max_retries"`
+	// BaseDelayMS is the delay before the first retry in milliseconds, it's doubled for every further retry.
+	// A Retry-After header sent by the server takes precedence.
+	BaseDelayMS int `yaml:"base_delay_ms" mapstructure:"base_delay_ms"`
+}
+
+// NewRetryHTTPClient returns an HTTP client like NewHTTPClient that retries idempotent requests
+// answered with 429 Too Many Requests or 503 Service Unavailable.
+func NewRetryHTTPClient(timeout int, proxy *ProxyConfig, serviceProxy string, retry *RetryConfig) *http.Client {
+	client := NewHTTPClient(timeout, proxy, serviceProxy)
+	client.Transport = NewRetryTransport(client.Transport, retry)
+	return client
+}
+
+// NewRetryTransport wraps the transport with retries of idempotent requests.
+// The transport is returned as is if retries are disabled.
+func NewRetryTransport(next http.RoundTripper, retry *RetryConfig) http.RoundTripper {
+	if retry == nil || retry.MaxRetries <= 0 {
+		return next
+	}
+	return &retryTransport{
+		next:       next,
+		maxRetries: retry.MaxRetries,
+		baseDelay:  time.Duration(retry.BaseDelayMS) * time.Millisecond,
+	}
+}
+
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	baseDelay  time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// only requests without a body can be sent again safely, DELETE and PUT are never retried
+	if (req.Method != http.MethodGet && req.Method != http.MethodHead) || (req.Body != nil && req.Body != http.NoBody) {
+		return t.next.RoundTrip(req)
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req.Clone(req.Context()))
+		if err != nil || attempt >= t.maxRetries || !retryableStatus(resp.StatusCode) {
+			return resp, err
+		}
+
+		delay := t.delay(attempt, resp.Header.Get("Retry-After"), time.Now())
+		log.Debug("retrying request", "method", req.Method, "url", req.URL.Redacted(), "status", resp.StatusCode, "attempt", attempt+1, "delay", delay)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// delay returns the time to wait before the next attempt.
+// A valid Retry-After header is used, otherwise the base delay is doubled with every attempt.
+func (t *retryTransport) delay(attempt int, retryAfter string, now time.Time) time.Duration {
+	delay := t.baseDelay << attempt
+	if retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			delay = time.Duration(seconds) * time.Second
+		} else if date, err := http.ParseTime(retryAfter); err == nil {
+			delay = max(date.Sub(now), 0)
+		}
+	}
+	if delay < 0 || delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+// retryableStatus reports whether the status code indicates a temporarily overloaded server.
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable
+}
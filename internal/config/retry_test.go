@@ -0,0 +1,77 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryHTTPClient(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewRetryHTTPClient(5, nil, "", &RetryConfig{MaxRetries: 3, BaseDelayMS: 1})
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close() //nolint: errcheck
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRetryHTTPClientSkipsNonIdempotentRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewRetryHTTPClient(5, nil, "", &RetryConfig{MaxRetries: 3, BaseDelayMS: 1})
+
+	req, err := http.NewRequest(http.MethodDelete, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close() //nolint: errcheck
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// GETs give up after the configured retries
+	calls.Store(0)
+	resp, err = client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close() //nolint: errcheck
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestRetryDelay(t *testing.T) {
+	transport := &retryTransport{baseDelay: 100 * time.Millisecond}
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 100*time.Millisecond, transport.delay(0, "", now))
+	assert.Equal(t, 400*time.Millisecond, transport.delay(2, "", now))
+	assert.Equal(t, 3*time.Second, transport.delay(0, "3", now))
+	assert.Equal(t, 10*time.Second, transport.delay(0, now.Add(10*time.Second).Format(http.TimeFormat), now))
+	assert.Equal(t, 200*time.Millisecond, transport.delay(1, "soon", now))
+	assert.Equal(t, maxRetryDelay, transport.delay(0, "3600", now))
+}
+
+func TestNewRetryTransportDisabled(t *testing.T) {
+	assert.Equal(t, http.DefaultTransport, NewRetryTransport(http.DefaultTransport, nil))
+	assert.Equal(t, http.DefaultTransport, NewRetryTransport(http.DefaultTransport, &RetryConfig{}))
+}
@@ -0,0 +1,54 @@
+package config
+
+import "fmt"
+
+// validateRuntimeRules checks the runtime rules of a library.
+func validateRuntimeRules(libraryName string, rules []RuntimeRule) error {
+	seen := make(map[int]struct{}, len(rules))
+	for _, rule := range rules {
+		if rule.MaxRuntimeMinutes <= 0 {
+			return fmt.Errorf("max runtime minutes of the runtime rules of library %s must be positive", libraryName)
+		}
+		if _, ok := seen[rule.MaxRuntimeMinutes]; ok {
+			return fmt.Errorf("library %s has multiple runtime rules for %d minutes", libraryName, rule.MaxRuntimeMinutes)
+		}
+		seen[rule.MaxRuntimeMinutes] = struct{}{}
+		if rule.ContentAgeThreshold < 0 || rule.LastStreamThreshold < 0 {
+			return fmt.Errorf("thresholds of the runtime rules of library %s must not be negative", libraryName)
+		}
+	}
+	return nil
+}
+
+// runtimeRule returns the rule with the lowest max runtime that still covers the runtime, nil if none matches.
+// Items with an unknown runtime (0) never match.
+func (c *CleanupConfig) runtimeRule(runtimeMinutes int) *RuntimeRule {
+	if c == nil || runtimeMinutes <= 0 {
+		return nil
+	}
+	var match *RuntimeRule
+	for i, rule := range c.RuntimeRules {
+		if runtimeMinutes <= rule.MaxRuntimeMinutes && (match == nil || rule.MaxRuntimeMinutes < match.MaxRuntimeMinutes) {
+			match = &c.RuntimeRules[i]
+		}
+	}
+	return match
+}
+
+// GetContentAgeThresholdForRuntime returns the content age threshold for an item with the given runtime in minutes,
+// falling back to the threshold of the library if no runtime rule overrides it.
+func (c *CleanupConfig) GetContentAgeThresholdForRuntime(runtimeMinutes int) int {
+	if rule := c.runtimeRule(runtimeMinutes); rule != nil && rule.ContentAgeThreshold > 0 {
+		return rule.ContentAgeThreshold
+	}
+	return c.GetContentAgeThreshold()
+}
+
+// GetLastStreamThresholdForRuntime returns the last stream threshold for an item with the given runtime in minutes,
+// falling back to the threshold of the library if no runtime rule overrides it.
+func (c *CleanupConfig) GetLastStreamThresholdForRuntime(runtimeMinutes int) int {
+	if rule := c.runtimeRule(runtimeMinutes); rule != nil && rule.LastStreamThreshold > 0 {
+		return rule.LastStreamThreshold
+	}
+	return c.GetLastStreamThreshold()
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuntimeRules(t *testing.T) {
+	cfg := &CleanupConfig{
+		Filter: FilterConfig{ContentAgeThreshold: 60, LastStreamThreshold: 90},
+		RuntimeRules: []RuntimeRule{
+			{MaxRuntimeMinutes: 60, ContentAgeThreshold: 14, LastStreamThreshold: 30},
+			{MaxRuntimeMinutes: 30, ContentAgeThreshold: 7},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		runtime        int
+		wantAge        int
+		wantLastStream int
+	}{
+		{name: "unknown runtime", runtime: 0, wantAge: 60, wantLastStream: 90},
+		{name: "shortest matching rule", runtime: 25, wantAge: 7, wantLastStream: 90},
+		{name: "upper bound is inclusive", runtime: 60, wantAge: 14, wantLastStream: 30},
+		{name: "no matching rule", runtime: 120, wantAge: 60, wantLastStream: 90},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantAge, cfg.GetContentAgeThresholdForRuntime(tt.runtime))
+			assert.Equal(t, tt.wantLastStream, cfg.GetLastStreamThresholdForRuntime(tt.runtime))
+		})
+	}
+}
+
+func TestValidateRuntimeRules(t *testing.T) {
+	assert.NoError(t, validateRuntimeRules("Movies", []RuntimeRule{{MaxRuntimeMinutes: 45, ContentAgeThreshold: 7}}))
+	assert.ErrorContains(t, validateRuntimeRules("Movies", []RuntimeRule{{ContentAgeThreshold: 7}}), "must be positive")
+	assert.ErrorContains(t, validateRuntimeRules("Movies", []RuntimeRule{{MaxRuntimeMinutes: 45}, {MaxRuntimeMinutes: 45}}), "multiple runtime rules")
+	assert.ErrorContains(t, validateRuntimeRules("Movies", []RuntimeRule{{MaxRuntimeMinutes: 45, LastStreamThreshold: -1}}), "must not be negative")
+}
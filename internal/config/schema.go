@@ -0,0 +1,99 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// schemaID is the JSON schema draft the generated schema conforms to.
+const schemaID = "https://json-schema.org/draft/2020-12/schema"
+
+// schemaEnums holds the allowed values of the string based config types.
+var schemaEnums = map[reflect.Type][]string{
+	reflect.TypeFor[CacheType]():         {string(CacheTypeMemory), string(CacheTypeRedis)},
+	reflect.TypeFor[DatabaseType]():      {string(DatabaseTypeSQLite), string(DatabaseTypePostgres)},
+	reflect.TypeFor[FallbackAgeSource](): {string(FallbackAgeSourceAdded), string(FallbackAgeSourceRelease)},
+	reflect.TypeFor[LibraryMediaType]():  {string(LibraryMediaTypeMovie), string(LibraryMediaTypeTV), string(LibraryMediaTypeBook)},
+	reflect.TypeFor[LogFormat]():         {string(LogFormatText), string(LogFormatJSON)},
+	reflect.TypeFor[ScanOrder]():         {string(ScanOrderAdded), string(ScanOrderSize)},
+	reflect.TypeFor[StatsFailMode](): {
+		string(StatsFailModeSkipDeletions),
+		string(StatsFailModeIgnoreStreamFilter),
+		string(StatsFailModeContinue),
+	},
+	reflect.TypeFor[CollectionAtomicMode](): {
+		string(CollectionAtomicModeAllOrNone),
+	},
+	reflect.TypeFor[NotificationEvent](): {
+		string(NotificationEventKeepRequest),
+		string(NotificationEventKeepRequestDecision),
+		string(NotificationEventDeletionSummary),
+		string(NotificationEventDeletionCompleted),
+		string(NotificationEventDeletionFailed),
+		string(NotificationEventKeepExpiryReminder),
+		string(NotificationEventKeptMediaDeleted),
+	},
+	reflect.TypeFor[CleanupMode](): {
+		string(CleanupModeAll),
+		string(CleanupModeKeepEpisodes),
+		string(CleanupModeKeepSeasons),
+		string(CleanupModeKeepLatestEpisodes),
+	},
+}
+
+// JSONSchema generates a JSON schema of the config file from the yaml tags of the Config struct.
+// Editors can use it to offer autocompletion and validation for the config file.
+func JSONSchema() ([]byte, error) {
+	schema := schemaFor(reflect.TypeFor[Config]())
+	schema["$schema"] = schemaID
+	schema["title"] = "Jellysweep configuration"
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// schemaFor returns the JSON schema of the given type.
+func schemaFor(t reflect.Type) map[string]any {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if enum, ok := schemaEnums[t]; ok {
+		return map[string]any{"type": "string", "enum": enum}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem())}
+	case reflect.Struct:
+		properties := make(map[string]any, t.NumField())
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			properties[name] = schemaFor(field.Type)
+		}
+		return map[string]any{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+	default:
+		return map[string]any{}
+	}
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchema(t *testing.T) {
+	data, err := JSONSchema()
+	require.NoError(t, err)
+
+	var schema struct {
+		Properties map[string]struct {
+			Type                 string   `json:"type"`
+			Enum                 []string `json:"enum"`
+			AdditionalProperties any      `json:"additionalProperties"`
+		} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	assert.Equal(t, "string", schema.Properties["cleanup_schedule"].Type)
+	assert.Equal(t, "boolean", schema.Properties["dry_run"].Type)
+	assert.Equal(t, []string{"all", "keep_episodes", "keep_seasons", "keep_latest_episodes"}, schema.Properties["cleanup_mode"].Enum)
+
+	libraries := schema.Properties["libraries"]
+	assert.Equal(t, "object", libraries.Type)
+	require.IsType(t, map[string]any{}, libraries.AdditionalProperties)
+	assert.Contains(t, libraries.AdditionalProperties.(map[string]any)["properties"], "filter")
+}
+
+func TestDeprecations(t *testing.T) {
+	c := &Config{Libraries: map[string]*CleanupConfig{
+		"TV Shows": {LastStreamThreshold: 30},
+		"Movies":   {ContentAgeThreshold: 30, ExcludeTags: []string{"keep"}},
+		"Anime":    nil,
+	}}
+
+	assert.Equal(t, []Deprecation{
+		{Library: "Movies", Option: "content_age_threshold", Replacement: "filter.content_age_threshold"},
+		{Library: "Movies", Option: "exclude_tags", Replacement: "filter.exclude_tags"},
+		{Library: "TV Shows", Option: "last_stream_threshold", Replacement: "filter.last_stream_threshold"},
+	}, deprecations(c))
+}
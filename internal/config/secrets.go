@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// secretRefRegexp matches a string value that references a secret, e.g. ${file:/run/secrets/api_key} or ${env:SONARR_API_KEY}.
+var secretRefRegexp = regexp.MustCompile(`^\$\{(file|env):([^}]+)\}$`)
+
+// resolveSecretRefs replaces all string values of the config that reference a file or an environment variable
+// with the referenced content, so secrets like API keys don't have to be stored in the config file.
+func resolveSecretRefs(c *Config) error {
+	return resolveRefs(reflect.ValueOf(c).Elem(), "")
+}
+
+// resolveRefs walks the value and resolves the secret references of all strings in it.
+// path is the yaml path of the value, used in errors.
+func resolveRefs(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return resolveRefs(v.Elem(), path)
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			if path != "" {
+				name = path + "." + name
+			}
+			if err := resolveRefs(v.Field(i), name); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if err := resolveRefs(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			// map values aren't addressable, so they are resolved on a copy
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(key))
+			if err := resolveRefs(elem, fmt.Sprintf("%s.%v", path, key)); err != nil {
+				return err
+			}
+			v.SetMapIndex(key, elem)
+		}
+	case reflect.String:
+		if !v.CanSet() {
+			return nil
+		}
+		resolved, err := resolveSecretRef(v.String())
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", path, err)
+		}
+		v.SetString(resolved)
+	}
+	return nil
+}
+
+// resolveSecretRef returns the referenced secret if value is a reference, otherwise value itself.
+// Trailing newlines of referenced files are removed.
+func resolveSecretRef(value string) (string, error) {
+	match := secretRefRegexp.FindStringSubmatch(value)
+	if match == nil {
+		return value, nil
+	}
+
+	kind, ref := match[1], match[2]
+	switch kind {
+	case "file":
+		content, err := os.ReadFile(ref)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %w", err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	default:
+		secret, ok := os.LookupEnv(ref)
+		if !ok {
+			return "", fmt.Errorf("environment variable %q is not set", ref)
+		}
+		return secret, nil
+	}
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSecretRefsFile(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "sonarr_api_key")
+	require.NoError(t, os.WriteFile(secretFile, []byte("file-secret\n"), 0o600))
+
+	c := &Config{
+		Sonarr: &SonarrConfig{URL: "http://sonarr:8989", APIKey: "${file:" + secretFile + "}"},
+		Radarr: &RadarrConfig{APIKey: "plain-secret"},
+	}
+	require.NoError(t, resolveSecretRefs(c))
+
+	assert.Equal(t, "file-secret", c.Sonarr.APIKey)
+	assert.Equal(t, "http://sonarr:8989", c.Sonarr.URL)
+	assert.Equal(t, "plain-secret", c.Radarr.APIKey)
+}
+
+func TestResolveSecretRefsEnv(t *testing.T) {
+	t.Setenv("TEST_SMTP_PASSWORD", "env-secret")
+	t.Setenv("TEST_PROTECTED_REQUESTER", "requester@example.com")
+
+	c := &Config{
+		Email:     &EmailConfig{Password: "${env:TEST_SMTP_PASSWORD}"},
+		Libraries: map[string]*CleanupConfig{"Movies": {Filter: FilterConfig{ProtectRequesters: []string{"${env:TEST_PROTECTED_REQUESTER}"}}}},
+	}
+	require.NoError(t, resolveSecretRefs(c))
+
+	assert.Equal(t, "env-secret", c.Email.Password)
+	assert.Equal(t, []string{"requester@example.com"}, c.Libraries["Movies"].Filter.ProtectRequesters)
+}
+
+func TestResolveSecretRefsErrors(t *testing.T) {
+	missingFile := filepath.Join(t.TempDir(), "missing")
+
+	err := resolveSecretRefs(&Config{Jellyseerr: &JellyseerrConfig{APIKey: "${file:" + missingFile + "}"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "jellyseerr.api_key")
+
+	err = resolveSecretRefs(&Config{Auth: &AuthConfig{OIDC: &OIDCConfig{ClientSecret: "${env:TEST_UNSET_SECRET}"}}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "auth.oidc.client_secret")
+	assert.Contains(t, err.Error(), "TEST_UNSET_SECRET")
+}
+
+func TestResolveSecretRef(t *testing.T) {
+	for _, value := range []string{"", "plain", "${vault:secret}", "prefix ${env:HOME}", "$HOME"} {
+		resolved, err := resolveSecretRef(value)
+		require.NoError(t, err)
+		assert.Equal(t, value, resolved)
+	}
+}
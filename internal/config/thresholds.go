@@ -0,0 +1,86 @@
+package config
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ThresholdWarning describes a disk usage threshold of a library whose cleanup delay is longer than
+// the delay of a threshold with a lower usage percent, which is almost always a mistake.
+type ThresholdWarning struct {
+	Library string
+	// Higher is the threshold with the higher usage percent but the longer delay.
+	Higher DiskUsageThreshold
+	// Lower is the threshold with the lower usage percent but the shorter delay.
+	Lower DiskUsageThreshold
+}
+
+// String returns a human readable description of the warning.
+func (w ThresholdWarning) String() string {
+	return fmt.Sprintf("disk usage threshold at %.1f%% has a longer cleanup delay (%d days) than the threshold at %.1f%% (%d days)",
+		w.Higher.UsagePercent, w.Higher.MaxCleanupDelay, w.Lower.UsagePercent, w.Lower.MaxCleanupDelay)
+}
+
+// validateDiskUsageThresholds checks the disk usage thresholds of a library.
+// A usage percent of 0 is only allowed for thresholds that only define a minimum free space.
+func validateDiskUsageThresholds(libraryName string, thresholds []DiskUsageThreshold) error {
+	seen := make(map[float64]struct{}, len(thresholds))
+	for _, threshold := range thresholds {
+		if threshold.UsagePercent == 0 {
+			if threshold.MinFreeSpaceBytes == 0 {
+				return fmt.Errorf("disk usage thresholds of library %s need a usage percent or a minimum free space", libraryName)
+			}
+		} else {
+			if threshold.UsagePercent < 0 || threshold.UsagePercent > 100 {
+				return fmt.Errorf("usage percent of library %s must be between 0 and 100, got %.1f", libraryName, threshold.UsagePercent)
+			}
+			if _, ok := seen[threshold.UsagePercent]; ok {
+				return fmt.Errorf("library %s has multiple disk usage thresholds at %.1f%%", libraryName, threshold.UsagePercent)
+			}
+			seen[threshold.UsagePercent] = struct{}{}
+		}
+		if threshold.MaxCleanupDelay < 0 {
+			return fmt.Errorf("max cleanup delay of library %s must not be negative", libraryName)
+		}
+		if threshold.TargetUsagePercent != 0 && (threshold.TargetUsagePercent < 0 || threshold.TargetUsagePercent >= threshold.UsagePercent) {
+			return fmt.Errorf("target usage percent of library %s must be between 0 and the usage percent of its threshold (%.1f)", libraryName, threshold.UsagePercent)
+		}
+	}
+	return nil
+}
+
+// thresholdWarnings returns the disk usage thresholds whose cleanup delay is longer than the delay of
+// the next lower usage percent, sorted by library. Floor-only thresholds are not compared.
+func thresholdWarnings(c *Config) []ThresholdWarning {
+	if c == nil || c.Libraries == nil {
+		return nil
+	}
+
+	var result []ThresholdWarning
+	for _, libraryName := range slices.Sorted(maps.Keys(c.Libraries)) {
+		libraryConfig := c.Libraries[libraryName]
+		if libraryConfig == nil {
+			continue
+		}
+
+		var thresholds []DiskUsageThreshold
+		for _, threshold := range libraryConfig.DiskUsageThresholds {
+			if threshold.UsagePercent > 0 {
+				thresholds = append(thresholds, threshold)
+			}
+		}
+		slices.SortStableFunc(thresholds, func(a, b DiskUsageThreshold) int {
+			return cmp.Compare(a.UsagePercent, b.UsagePercent)
+		})
+
+		for i := 1; i < len(thresholds); i++ {
+			lower, higher := thresholds[i-1], thresholds[i]
+			if higher.UsagePercent > lower.UsagePercent && higher.MaxCleanupDelay > lower.MaxCleanupDelay {
+				result = append(result, ThresholdWarning{Library: libraryName, Higher: higher, Lower: lower})
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDiskUsageThresholds(t *testing.T) {
+	tests := []struct {
+		name       string
+		thresholds []DiskUsageThreshold
+		wantErr    string
+	}{
+		{
+			name: "valid thresholds",
+			thresholds: []DiskUsageThreshold{
+				{UsagePercent: 80, MaxCleanupDelay: 14},
+				{UsagePercent: 95, MaxCleanupDelay: 0, TargetUsagePercent: 85},
+			},
+		},
+		{
+			name:       "floor only threshold",
+			thresholds: []DiskUsageThreshold{{MinFreeSpaceBytes: 1 << 30, MaxCleanupDelay: 1}},
+		},
+		{
+			name:       "usage percent of 100",
+			thresholds: []DiskUsageThreshold{{UsagePercent: 100}},
+		},
+		{
+			name:       "neither usage percent nor floor",
+			thresholds: []DiskUsageThreshold{{MaxCleanupDelay: 7}},
+			wantErr:    "need a usage percent or a minimum free space",
+		},
+		{
+			name:       "negative usage percent",
+			thresholds: []DiskUsageThreshold{{UsagePercent: -5, MinFreeSpaceBytes: 1}},
+			wantErr:    "must be between 0 and 100",
+		},
+		{
+			name:       "usage percent above 100",
+			thresholds: []DiskUsageThreshold{{UsagePercent: 120}},
+			wantErr:    "must be between 0 and 100",
+		},
+		{
+			name:       "negative delay",
+			thresholds: []DiskUsageThreshold{{UsagePercent: 90, MaxCleanupDelay: -1}},
+			wantErr:    "must not be negative",
+		},
+		{
+			name: "duplicate usage percent",
+			thresholds: []DiskUsageThreshold{
+				{UsagePercent: 90, MaxCleanupDelay: 7},
+				{UsagePercent: 90, MaxCleanupDelay: 3},
+			},
+			wantErr: "multiple disk usage thresholds at 90.0%",
+		},
+		{
+			name: "multiple floor only thresholds",
+			thresholds: []DiskUsageThreshold{
+				{MinFreeSpaceBytes: 1 << 30, MaxCleanupDelay: 7},
+				{MinFreeSpaceBytes: 1 << 20, MaxCleanupDelay: 0},
+			},
+		},
+		{
+			name:       "target above usage percent",
+			thresholds: []DiskUsageThreshold{{UsagePercent: 80, TargetUsagePercent: 90}},
+			wantErr:    "target usage percent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDiskUsageThresholds("Movies", tt.thresholds)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestThresholdWarnings(t *testing.T) {
+	tests := []struct {
+		name       string
+		thresholds []DiskUsageThreshold
+		want       []ThresholdWarning
+	}{
+		{
+			name: "ordered thresholds",
+			thresholds: []DiskUsageThreshold{
+				{UsagePercent: 95, MaxCleanupDelay: 1},
+				{UsagePercent: 80, MaxCleanupDelay: 14},
+				{UsagePercent: 90, MaxCleanupDelay: 7},
+			},
+		},
+		{
+			name: "equal delays",
+			thresholds: []DiskUsageThreshold{
+				{UsagePercent: 80, MaxCleanupDelay: 7},
+				{UsagePercent: 90, MaxCleanupDelay: 7},
+			},
+		},
+		{
+			name: "higher usage with longer delay",
+			thresholds: []DiskUsageThreshold{
+				{UsagePercent: 80, MaxCleanupDelay: 3},
+				{UsagePercent: 90, MaxCleanupDelay: 14},
+			},
+			want: []ThresholdWarning{{
+				Library: "Movies",
+				Higher:  DiskUsageThreshold{UsagePercent: 90, MaxCleanupDelay: 14},
+				Lower:   DiskUsageThreshold{UsagePercent: 80, MaxCleanupDelay: 3},
+			}},
+		},
+		{
+			name: "floor only thresholds are ignored",
+			thresholds: []DiskUsageThreshold{
+				{MinFreeSpaceBytes: 1 << 30, MaxCleanupDelay: 30},
+				{UsagePercent: 90, MaxCleanupDelay: 7},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{Libraries: map[string]*CleanupConfig{
+				"Movies": {DiskUsageThresholds: tt.thresholds},
+				"Anime":  nil,
+			}}
+			assert.Equal(t, tt.want, thresholdWarnings(c))
+		})
+	}
+}
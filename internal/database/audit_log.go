@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"gorm.io/gorm"
+)
+
+// AuditAction represents an action performed by an admin.
+type AuditAction string
+
+const (
+	// AuditActionKeepRequestApproved indicates an admin approved a keep request.
+	AuditActionKeepRequestApproved AuditAction = "keep_request_approved"
+	// AuditActionKeepRequestDenied indicates an admin denied a keep request.
+	AuditActionKeepRequestDenied AuditAction = "keep_request_denied"
+	// AuditActionKeepForever indicates an admin added the ignore tag to keep a media item forever.
+	AuditActionKeepForever AuditAction = "keep_forever"
+	// AuditActionMediaIgnored indicates an admin permanently ignored a media item.
+	AuditActionMediaIgnored AuditAction = "media_ignored"
+	// AuditActionMediaUnignored indicates an admin removed the permanent ignore of a media item.
+	AuditActionMediaUnignored AuditAction = "media_unignored"
+	// AuditActionJobTriggered indicates an admin manually triggered a scheduler job.
+	AuditActionJobTriggered AuditAction = "job_triggered"
+	// AuditActionSchedulerPaused indicates an admin paused the scheduler.
+	AuditActionSchedulerPaused AuditAction = "scheduler_paused"
+	// AuditActionSchedulerResumed indicates an admin resumed the scheduler.
+	AuditActionSchedulerResumed AuditAction = "scheduler_resumed"
+	// AuditActionOrphanedTagsCleaned indicates an admin removed the orphaned jellysweep tags from the arrs.
+	AuditActionOrphanedTagsCleaned AuditAction = "orphaned_tags_cleaned"
+	// AuditActionExpiredForceDeleted indicates an admin deleted all media items whose deletion date has passed.
+	AuditActionExpiredForceDeleted AuditAction = "expired_force_deleted"
+	// AuditActionMaintenanceEnabled indicates an admin enabled the maintenance mode.
+	AuditActionMaintenanceEnabled AuditAction = "maintenance_enabled"
+	// AuditActionMaintenanceDisabled indicates an admin disabled the maintenance mode.
+	AuditActionMaintenanceDisabled AuditAction = "maintenance_disabled"
+	// AuditActionMediaProtected indicates an admin protected a media item.
+	AuditActionMediaProtected AuditAction = "media_protected"
+	// AuditActionMediaUnkeepable indicates an admin marked a media item as unkeepable.
+	AuditActionMediaUnkeepable AuditAction = "media_unkeepable"
+	// AuditActionJobEnabled indicates an admin enabled a scheduler job.
+	AuditActionJobEnabled AuditAction = "job_enabled"
+	// AuditActionJobDisabled indicates an admin disabled a scheduler job.
+	AuditActionJobDisabled AuditAction = "job_disabled"
+	// AuditActionCacheCleared indicates an admin cleared the engine cache.
+	AuditActionCacheCleared AuditAction = "cache_cleared"
+	// AuditActionUserPermissionsUpdated indicates an admin changed the permissions of a user.
+	AuditActionUserPermissionsUpdated AuditAction = "user_permissions_updated"
+	// AuditActionDeletionFailureReset indicates an admin reset the deletion failure of a media item, so its deletion is retried.
+	AuditActionDeletionFailureReset AuditAction = "deletion_failure_reset"
+)
+
+// AuditLogEntry records an action performed by an admin.
+type AuditLogEntry struct {
+	gorm.Model
+	// Actor is the username of the admin who performed the action.
+	Actor string `gorm:"not null;index"`
+	// Action is the performed action.
+	Action AuditAction `gorm:"not null;index"`
+	// MediaID references the affected media item, if any.
+	MediaID *uint  `gorm:"index"`
+	Media   *Media `gorm:"constraint:OnDelete:SET NULL;"`
+	// Target identifies the affected object if it isn't a media item, e.g. the job ID.
+	Target string
+	// EventTime is the time the action was performed.
+	EventTime time.Time `gorm:"not null;index"`
+}
+
+// AuditLogDB defines the interface for audit log database operations.
+type AuditLogDB interface {
+	CreateAuditLogEntry(ctx context.Context, entry AuditLogEntry) error
+	GetAuditLogEntries(ctx context.Context, limit, offset int, since time.Time) ([]AuditLogEntry, int64, error)
+}
+
+// CreateAuditLogEntry appends an entry to the audit log.
+func (c *Client) CreateAuditLogEntry(ctx context.Context, entry AuditLogEntry) error {
+	if entry.EventTime.IsZero() {
+		entry.EventTime = time.Now()
+	}
+
+	if err := c.db.WithContext(ctx).Create(&entry).Error; err != nil {
+		log.Error("failed to create audit log entry", "error", err)
+		return err
+	}
+	return nil
+}
+
+// GetAuditLogEntries retrieves the paginated audit log entries since the given time, newest first.
+// The affected media items are preloaded, including soft-deleted ones.
+func (c *Client) GetAuditLogEntries(ctx context.Context, limit, offset int, since time.Time) ([]AuditLogEntry, int64, error) {
+	query := c.db.WithContext(ctx).Model(&AuditLogEntry{}).
+		Where("event_time >= ?", since)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		log.Error("failed to count audit log entries", "error", err)
+		return nil, 0, err
+	}
+
+	var entries []AuditLogEntry
+	result := query.
+		Preload("Media", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped() // Include soft-deleted media items
+		}).
+		Order("event_time DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&entries)
+	if result.Error != nil && result.Error != gorm.ErrRecordNotFound {
+		log.Error("failed to get audit log entries", "error", result.Error)
+		return nil, 0, result.Error
+	}
+	return entries, total, nil
+}
@@ -0,0 +1,261 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"gorm.io/gorm"
+)
+
+// CleanupRunStatus represents the status of a cleanup run or one of its steps.
+type CleanupRunStatus string
+
+const (
+	// CleanupRunStatusRunning indicates the run or step is still in progress.
+	CleanupRunStatusRunning CleanupRunStatus = "running"
+	// CleanupRunStatusCompleted indicates the run or step completed successfully.
+	CleanupRunStatusCompleted CleanupRunStatus = "completed"
+	// CleanupRunStatusFailed indicates the run or step failed.
+	CleanupRunStatusFailed CleanupRunStatus = "failed"
+)
+
+// CleanupRun represents a single execution of the cleanup job.
+type CleanupRun struct {
+	gorm.Model
+	StartTime    time.Time        `gorm:"not null;index"`
+	EndTime      *time.Time       `gorm:"index"`
+	Status       CleanupRunStatus `gorm:"not null;index"`
+	Error        string
+	DryRun       bool
+	ItemsMarked  int
+	ItemsDeleted int
+	BytesFreed   int64
+	Steps        []CleanupRunStep `gorm:"constraint:OnDelete:CASCADE;"`
+}
+
+// CleanupRunStep represents a single step of a cleanup run.
+type CleanupRunStep struct {
+	gorm.Model
+	CleanupRunID   uint      `gorm:"not null;index"`
+	Name           string    `gorm:"not null"`
+	StartTime      time.Time `gorm:"not null"`
+	EndTime        *time.Time
+	Status         CleanupRunStatus `gorm:"not null"`
+	Error          string
+	ItemsProcessed int
+}
+
+// CleanupStats holds aggregated statistics about the cleanup runs.
+type CleanupStats struct {
+	TotalRuns    int64
+	FailedRuns   int64
+	ItemsMarked  int64
+	ItemsDeleted int64
+	BytesFreed   int64
+	LastRunAt    *time.Time `gorm:"-"`
+}
+
+// CleanupRunDB defines the interface for cleanup run related database operations.
+type CleanupRunDB interface {
+	CreateCleanupRun(ctx context.Context, dryRun bool) (*CleanupRun, error)
+	CompleteCleanupRun(ctx context.Context, run *CleanupRun, runErr error) error
+	StartCleanupStep(ctx context.Context, runID uint, name string) (*CleanupRunStep, error)
+	CompleteCleanupStep(ctx context.Context, step *CleanupRunStep, itemsProcessed int, stepErr error) error
+	GetCleanupRunHistory(ctx context.Context, limit, offset int, since time.Time) ([]CleanupRun, int64, error)
+	GetCleanupRun(ctx context.Context, id uint) (*CleanupRun, error)
+	GetCleanupRunSteps(ctx context.Context, runID uint) ([]CleanupRunStep, error)
+	GetMediaDeletionHistory(ctx context.Context, limit, offset int, since time.Time) ([]Media, int64, error)
+	GetCleanupStats(ctx context.Context, since time.Time) (*CleanupStats, error)
+}
+
+// CreateCleanupRun creates a new cleanup run in the running state.
+func (c *Client) CreateCleanupRun(ctx context.Context, dryRun bool) (*CleanupRun, error) {
+	run := &CleanupRun{
+		StartTime: time.Now(),
+		Status:    CleanupRunStatusRunning,
+		DryRun:    dryRun,
+	}
+	if err := c.db.WithContext(ctx).Create(run).Error; err != nil {
+		log.Error("failed to create cleanup run", "error", err)
+		return nil, err
+	}
+	return run, nil
+}
+
+// CompleteCleanupRun marks the cleanup run as completed or failed and stores its counters.
+func (c *Client) CompleteCleanupRun(ctx context.Context, run *CleanupRun, runErr error) error {
+	now := time.Now()
+	run.EndTime = &now
+	run.Status = CleanupRunStatusCompleted
+	if runErr != nil {
+		run.Status = CleanupRunStatusFailed
+		run.Error = runErr.Error()
+	}
+
+	err := c.db.WithContext(ctx).Model(&CleanupRun{}).
+		Where("id = ?", run.ID).
+		Updates(map[string]any{
+			"end_time":      run.EndTime,
+			"status":        run.Status,
+			"error":         run.Error,
+			"items_marked":  run.ItemsMarked,
+			"items_deleted": run.ItemsDeleted,
+			"bytes_freed":   run.BytesFreed,
+		}).Error
+	if err != nil {
+		log.Error("failed to complete cleanup run", "error", err)
+		return err
+	}
+	return nil
+}
+
+// StartCleanupStep creates a new running step for the given cleanup run.
+func (c *Client) StartCleanupStep(ctx context.Context, runID uint, name string) (*CleanupRunStep, error) {
+	step := &CleanupRunStep{
+		CleanupRunID: runID,
+		Name:         name,
+		StartTime:    time.Now(),
+		Status:       CleanupRunStatusRunning,
+	}
+	if err := c.db.WithContext(ctx).Create(step).Error; err != nil {
+		log.Error("failed to create cleanup run step", "error", err)
+		return nil, err
+	}
+	return step, nil
+}
+
+// CompleteCleanupStep marks the step as completed or failed.
+func (c *Client) CompleteCleanupStep(ctx context.Context, step *CleanupRunStep, itemsProcessed int, stepErr error) error {
+	now := time.Now()
+	step.EndTime = &now
+	step.ItemsProcessed = itemsProcessed
+	step.Status = CleanupRunStatusCompleted
+	if stepErr != nil {
+		step.Status = CleanupRunStatusFailed
+		step.Error = stepErr.Error()
+	}
+
+	err := c.db.WithContext(ctx).Model(&CleanupRunStep{}).
+		Where("id = ?", step.ID).
+		Updates(map[string]any{
+			"end_time":        step.EndTime,
+			"status":          step.Status,
+			"error":           step.Error,
+			"items_processed": step.ItemsProcessed,
+		}).Error
+	if err != nil {
+		log.Error("failed to complete cleanup run step", "error", err)
+		return err
+	}
+	return nil
+}
+
+// GetCleanupRunHistory retrieves the cleanup runs started after since, newest first.
+func (c *Client) GetCleanupRunHistory(ctx context.Context, limit, offset int, since time.Time) ([]CleanupRun, int64, error) {
+	query := c.db.WithContext(ctx).Model(&CleanupRun{}).
+		Where("start_time >= ?", since)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		log.Error("failed to count cleanup runs", "error", err)
+		return nil, 0, err
+	}
+
+	var runs []CleanupRun
+	result := query.
+		Order("start_time DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&runs)
+	if result.Error != nil && result.Error != gorm.ErrRecordNotFound {
+		log.Error("failed to get cleanup runs", "error", result.Error)
+		return nil, 0, result.Error
+	}
+	return runs, total, nil
+}
+
+// GetCleanupRun retrieves a single cleanup run.
+func (c *Client) GetCleanupRun(ctx context.Context, id uint) (*CleanupRun, error) {
+	var run CleanupRun
+	result := c.db.WithContext(ctx).First(&run, id)
+	if result.Error != nil {
+		log.Error("failed to get cleanup run", "error", result.Error)
+		return nil, result.Error
+	}
+	return &run, nil
+}
+
+// GetCleanupRunSteps retrieves all steps of a cleanup run in execution order.
+func (c *Client) GetCleanupRunSteps(ctx context.Context, runID uint) ([]CleanupRunStep, error) {
+	var steps []CleanupRunStep
+	result := c.db.WithContext(ctx).
+		Where("cleanup_run_id = ?", runID).
+		Order("start_time ASC").
+		Find(&steps)
+	if result.Error != nil && result.Error != gorm.ErrRecordNotFound {
+		log.Error("failed to get cleanup run steps", "error", result.Error)
+		return nil, result.Error
+	}
+	return steps, nil
+}
+
+// GetMediaDeletionHistory retrieves the media items deleted from disk after since, newest first.
+func (c *Client) GetMediaDeletionHistory(ctx context.Context, limit, offset int, since time.Time) ([]Media, int64, error) {
+	query := c.db.WithContext(ctx).Unscoped().Model(&Media{}).
+		Where("deleted_at IS NOT NULL AND deleted_at >= ? AND db_delete_reason = ?", since, DBDeleteReasonDefault)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		log.Error("failed to count deleted media", "error", err)
+		return nil, 0, err
+	}
+
+	var mediaItems []Media
+	result := query.
+		Order("deleted_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&mediaItems)
+	if result.Error != nil && result.Error != gorm.ErrRecordNotFound {
+		log.Error("failed to get deleted media", "error", result.Error)
+		return nil, 0, result.Error
+	}
+	return mediaItems, total, nil
+}
+
+// GetCleanupStats aggregates the statistics of all cleanup runs started after since.
+func (c *Client) GetCleanupStats(ctx context.Context, since time.Time) (*CleanupStats, error) {
+	var stats CleanupStats
+	err := c.db.WithContext(ctx).Model(&CleanupRun{}).
+		Select(
+			"COUNT(*) AS total_runs, "+
+				"COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0) AS failed_runs, "+
+				"COALESCE(SUM(items_marked), 0) AS items_marked, "+
+				"COALESCE(SUM(items_deleted), 0) AS items_deleted, "+
+				"COALESCE(SUM(bytes_freed), 0) AS bytes_freed",
+			CleanupRunStatusFailed,
+		).
+		Where("start_time >= ?", since).
+		Scan(&stats).Error
+	if err != nil {
+		log.Error("failed to get cleanup stats", "error", err)
+		return nil, err
+	}
+
+	var lastRun CleanupRun
+	result := c.db.WithContext(ctx).
+		Where("start_time >= ?", since).
+		Order("start_time DESC").
+		Limit(1).
+		Find(&lastRun)
+	if result.Error != nil {
+		log.Error("failed to get last cleanup run", "error", result.Error)
+		return nil, result.Error
+	}
+	if result.RowsAffected > 0 {
+		stats.LastRunAt = &lastRun.StartTime
+	}
+
+	return &stats, nil
+}
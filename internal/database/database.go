@@ -20,33 +20,35 @@ type Client struct {
 }
 
 // New creates a new database connection and performs migrations.
+// If automatic migrations are disabled, it fails if the schema is outdated instead.
+// It also reports whether the tags of the tag based versions still have to be migrated to the database.
 func New(cfg *config.DatabaseConfig) (*Client, bool, error) {
-	dialector, err := dialectorForConfig(cfg)
+	db, err := open(cfg)
 	if err != nil {
 		return nil, false, err
 	}
 
-	db, err := gorm.Open(dialector, &gorm.Config{})
-	if err != nil {
-		return nil, false, fmt.Errorf("failed to connect database: %w", err)
+	if cfg.AutoMigrate {
+		if err := migrate(db); err != nil {
+			return nil, false, err
+		}
+	} else {
+		statuses, err := schemaStatus(db)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, status := range statuses {
+			if status.Pending() {
+				return nil, false, fmt.Errorf("database schema is outdated (table %s), run the migrate command first", status.Table)
+			}
+		}
 	}
 
-	isNew := isNewDatabase(db)
-
-	if err := db.AutoMigrate(
-		&Media{},
-		&DiskUsageDeletePolicy{},
-		&Request{},
-		&User{},
-		&UserSettings{},
-		&UserPermissions{},
-		&EmailSettings{},
-		&HistoryEvent{},
-	); err != nil {
-		return nil, false, fmt.Errorf("failed to migrate database: %w", err)
+	tagMigration, err := tagMigrationPending(db)
+	if err != nil {
+		return nil, false, err
 	}
-
-	return &Client{db: db}, isNew, nil
+	return &Client{db: db}, tagMigration, nil
 }
 
 func dialectorForConfig(cfg *config.DatabaseConfig) (gorm.Dialector, error) {
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DeletionEstimate stores the projected deletion date of a media item marked for deletion.
+type DeletionEstimate struct {
+	gorm.Model
+	MediaID     uint   `gorm:"not null;uniqueIndex"`
+	Media       *Media `gorm:"constraint:OnDelete:CASCADE;"`
+	LibraryName string
+	Title       string
+	Year        int32
+	MediaType   MediaType
+	FileSize    int64
+	// EstimatedDeleteAt is the projected date the media item gets deleted.
+	EstimatedDeleteAt time.Time `gorm:"not null;index"`
+}
+
+// DeletionEstimateDB defines the interface for deletion estimate database operations.
+type DeletionEstimateDB interface {
+	UpsertDeletionEstimates(ctx context.Context, estimates []DeletionEstimate) error
+	GetDeletionEstimates(ctx context.Context, limit, offset int, since time.Time) ([]DeletionEstimate, int64, error)
+}
+
+// UpsertDeletionEstimates creates or updates the given estimates and removes the estimates of all other media items.
+func (c *Client) UpsertDeletionEstimates(ctx context.Context, estimates []DeletionEstimate) error {
+	err := c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		mediaIDs := make([]uint, 0, len(estimates))
+		for _, estimate := range estimates {
+			mediaIDs = append(mediaIDs, estimate.MediaID)
+		}
+
+		stale := tx.Unscoped()
+		if len(mediaIDs) > 0 {
+			stale = stale.Where("media_id NOT IN ?", mediaIDs)
+		} else {
+			stale = stale.Where("1 = 1")
+		}
+		if err := stale.Delete(&DeletionEstimate{}).Error; err != nil {
+			return err
+		}
+
+		if len(estimates) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "media_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"updated_at", "library_name", "title", "year", "media_type", "file_size", "estimated_delete_at",
+			}),
+		}).Create(&estimates).Error
+	})
+	if err != nil {
+		log.Error("failed to upsert deletion estimates", "error", err)
+	}
+	return err
+}
+
+// GetDeletionEstimates retrieves the paginated estimates with a projected deletion date after the given time, soonest first.
+func (c *Client) GetDeletionEstimates(ctx context.Context, limit, offset int, since time.Time) ([]DeletionEstimate, int64, error) {
+	query := c.db.WithContext(ctx).Model(&DeletionEstimate{}).
+		Where("estimated_delete_at >= ?", since)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		log.Error("failed to count deletion estimates", "error", err)
+		return nil, 0, err
+	}
+
+	var estimates []DeletionEstimate
+	result := query.
+		Order("estimated_delete_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&estimates)
+	if result.Error != nil && result.Error != gorm.ErrRecordNotFound {
+		log.Error("failed to get deletion estimates", "error", result.Error)
+		return nil, 0, result.Error
+	}
+	return estimates, total, nil
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"gorm.io/gorm"
+)
+
+// DeletionFailure records a failed deletion of a media item so it can be retried with backoff.
+type DeletionFailure struct {
+	gorm.Model
+	MediaID uint  `gorm:"not null;uniqueIndex"`
+	Media   Media `gorm:"constraint:OnDelete:CASCADE;"`
+	// Error is the error message of the last failed attempt.
+	Error string
+	// Attempts is the number of failed deletion attempts.
+	Attempts int `gorm:"not null"`
+	// LastAttemptAt is the time of the last failed attempt.
+	LastAttemptAt time.Time `gorm:"not null"`
+	// NextRetryAt is the time when the deletion is retried next.
+	NextRetryAt time.Time `gorm:"not null;index"`
+	// GaveUp indicates that the maximum number of attempts was reached and the deletion isn't retried anymore.
+	GaveUp bool `gorm:"not null;index"`
+}
+
+// DeletionFailureDB defines the interface for deletion failure database operations.
+type DeletionFailureDB interface {
+	GetDeletionFailure(ctx context.Context, mediaID uint) (*DeletionFailure, error)
+	GetDeletionFailures(ctx context.Context) ([]DeletionFailure, error)
+	GetDueDeletionFailures(ctx context.Context, asOf time.Time) ([]DeletionFailure, error)
+	SaveDeletionFailure(ctx context.Context, failure *DeletionFailure) error
+	DeleteDeletionFailure(ctx context.Context, mediaID uint) error
+}
+
+// GetDeletionFailure returns the deletion failure of a media item.
+// If the media item has no recorded failure, a new unsaved record is returned.
+func (c *Client) GetDeletionFailure(ctx context.Context, mediaID uint) (*DeletionFailure, error) {
+	var failure DeletionFailure
+	if err := c.db.WithContext(ctx).Where("media_id = ?", mediaID).First(&failure).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &DeletionFailure{MediaID: mediaID}, nil
+		}
+		log.Error("failed to get deletion failure", "error", err)
+		return nil, err
+	}
+	return &failure, nil
+}
+
+// GetDeletionFailures returns all recorded deletion failures, including the ones that were given up.
+func (c *Client) GetDeletionFailures(ctx context.Context) ([]DeletionFailure, error) {
+	var failures []DeletionFailure
+	if err := c.db.WithContext(ctx).Find(&failures).Error; err != nil {
+		log.Error("failed to get deletion failures", "error", err)
+		return nil, err
+	}
+	return failures, nil
+}
+
+// GetDueDeletionFailures returns the deletion failures that should be retried at asOf.
+// The media items are preloaded.
+func (c *Client) GetDueDeletionFailures(ctx context.Context, asOf time.Time) ([]DeletionFailure, error) {
+	var failures []DeletionFailure
+	result := c.db.WithContext(ctx).
+		Preload("Media").
+		Where("gave_up = ? AND next_retry_at <= ?", false, asOf).
+		Order("next_retry_at ASC").
+		Find(&failures)
+	if result.Error != nil {
+		log.Error("failed to get due deletion failures", "error", result.Error)
+		return nil, result.Error
+	}
+	return failures, nil
+}
+
+// SaveDeletionFailure creates or updates a deletion failure.
+func (c *Client) SaveDeletionFailure(ctx context.Context, failure *DeletionFailure) error {
+	if err := c.db.WithContext(ctx).Omit("Media").Save(failure).Error; err != nil {
+		log.Error("failed to save deletion failure", "error", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteDeletionFailure removes the deletion failure of a media item.
+func (c *Client) DeleteDeletionFailure(ctx context.Context, mediaID uint) error {
+	if err := c.db.WithContext(ctx).Unscoped().Where("media_id = ?", mediaID).Delete(&DeletionFailure{}).Error; err != nil {
+		log.Error("failed to delete deletion failure", "error", err)
+		return err
+	}
+	return nil
+}
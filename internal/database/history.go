@@ -30,6 +30,8 @@ const (
 	HistoryEventAdminUnkeep HistoryEventType = "admin_unkeep"
 	// HistoryEventDeleted indicates a media item was deleted.
 	HistoryEventDeleted HistoryEventType = "deleted"
+	// HistoryEventForceDeleted indicates a media item was deleted by an admin flushing all expired items.
+	HistoryEventForceDeleted HistoryEventType = "force_deleted"
 	// HistoryEventRequestCreated indicates a keep request was created.
 	HistoryEventRequestCreated HistoryEventType = "request_created"
 	// HistoryEventRequestApproved indicates a keep request was approved.
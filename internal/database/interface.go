@@ -12,7 +12,7 @@ const (
 	SortOrderDesc SortOrder = "desc"
 )
 
-// MediaType represents the type of media, either TV show or Movie.
+// MediaType represents the type of media, either TV show, Movie or Book.
 type MediaType string
 
 const (
@@ -20,6 +20,8 @@ const (
 	MediaTypeTV MediaType = "tv"
 	// MediaTypeMovie represents Movies.
 	MediaTypeMovie MediaType = "movie"
+	// MediaTypeBook represents ebooks and audiobooks.
+	MediaTypeBook MediaType = "book"
 )
 
 // DBDeleteReason represents the reason why a media item was deleted from the database.
@@ -36,6 +38,10 @@ const (
 	DBDeleteReasonProtectionExpired DBDeleteReason = "protection_expired"
 	// DBDeleteReasonMissingInJellyfin indicates the media was deleted in the database only because it was missing in Jellyfin.
 	DBDeleteReasonMissingInJellyfin DBDeleteReason = "missing_in_jellyfin"
+	// DBDeleteReasonReevaluated indicates the media was deleted in the database only because it no longer qualified for deletion after a reevaluation.
+	DBDeleteReasonReevaluated DBDeleteReason = "reevaluated"
+	// DBDeleteReasonIgnored indicates the media was deleted in the database only because it was permanently ignored.
+	DBDeleteReasonIgnored DBDeleteReason = "ignored"
 )
 
 // DB defines the interface for database operations.
@@ -44,6 +50,16 @@ type DB interface {
 	MediaDB
 	RequestDB
 	HistoryDB
+	CleanupRunDB
+	NotificationPrefsDB
+	DeletionFailureDB
+	SchedulerStateDB
+	MaintenanceStateDB
+	AuditLogDB
+	DeletionEstimateDB
+	StateDB
+	ScanCursorDB
+	TagMigrationDB
 }
 
 // MediaDB defines the interface for media-related database operations.
@@ -51,20 +67,28 @@ type MediaDB interface {
 	CreateMediaItems(ctx context.Context, items []Media) error
 	GetMediaItemByID(ctx context.Context, id uint) (*Media, error)
 	GetMediaItems(ctx context.Context, includeProtected bool) ([]Media, error)
+	GetMediaItemsByJellyfinID(ctx context.Context, jellyfinID string) ([]Media, error)
 	GetMediaItemsByMediaType(ctx context.Context, mediaType MediaType) ([]Media, error)
 	GetMediaWithPendingRequest(ctx context.Context) ([]Media, error)
 	GetMediaExpiredProtection(ctx context.Context, asOf time.Time) ([]Media, error)
+	GetMediaProtectionExpiringBetween(ctx context.Context, from, to time.Time) ([]Media, error)
+	GetLatestExpiredProtection(ctx context.Context, jellyfinID string) (*Media, error)
 	GetDeletedMediaByTMDBID(ctx context.Context, tmdbID int32) ([]Media, error)
 	GetDeletedMediaByTVDBID(ctx context.Context, tvdbID int32) ([]Media, error)
-	SetMediaProtectedUntil(ctx context.Context, mediaID uint, protectedUntil *time.Time) error
+	SetMediaProtectedUntil(ctx context.Context, mediaID uint, protectedUntil *time.Time, keptSeasons Seasons) error
 	MarkMediaAsUnkeepable(ctx context.Context, mediaID uint) error
+	SetMediaDefaultDeleteAt(ctx context.Context, mediaID uint, deleteAt time.Time) error
+	MarkProtectionReminderSent(ctx context.Context, mediaID uint) error
+	SetMediaIgnored(ctx context.Context, mediaID uint, ignored bool) (*Media, error)
+	GetIgnoredMedia(ctx context.Context) ([]Media, error)
 	DeleteMediaItem(ctx context.Context, media *Media) error
 }
 
 // RequestDB defines the interface for request-related database operations.
 type RequestDB interface {
-	CreateRequest(ctx context.Context, mediaID uint, userID uint) (*Request, error)
-	UpdateRequestStatus(ctx context.Context, requestID uint, status RequestStatus) error
+	CreateRequest(ctx context.Context, mediaID uint, userID uint, keptSeasons Seasons) (*Request, error)
+	UpdateRequestStatus(ctx context.Context, requestID uint, expected, status RequestStatus) error
+	ApplyKeepRequestDecisions(ctx context.Context, decisions []KeepRequestDecision) ([]uint, error)
 }
 
 // UserDB defines the interface for user-related database operations.
@@ -0,0 +1,49 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/charmbracelet/log"
+	"gorm.io/gorm"
+)
+
+// maintenanceStateID is the ID of the single maintenance state row.
+const maintenanceStateID = 1
+
+// MaintenanceState holds the persisted maintenance mode.
+type MaintenanceState struct {
+	gorm.Model
+	// Enabled indicates that keep requests, manual triggers and scheduled jobs are rejected.
+	Enabled bool `gorm:"not null"`
+}
+
+// MaintenanceStateDB defines the interface for maintenance state database operations.
+type MaintenanceStateDB interface {
+	GetMaintenanceMode(ctx context.Context) (bool, error)
+	SetMaintenanceMode(ctx context.Context, enabled bool) error
+}
+
+// GetMaintenanceMode returns whether the maintenance mode is enabled.
+func (c *Client) GetMaintenanceMode(ctx context.Context) (bool, error) {
+	var state MaintenanceState
+	if err := c.db.WithContext(ctx).First(&state, maintenanceStateID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		log.Error("failed to get maintenance state", "error", err)
+		return false, err
+	}
+	return state.Enabled, nil
+}
+
+// SetMaintenanceMode stores whether the maintenance mode is enabled.
+func (c *Client) SetMaintenanceMode(ctx context.Context, enabled bool) error {
+	state := MaintenanceState{Enabled: enabled}
+	state.ID = maintenanceStateID
+	if err := c.db.WithContext(ctx).Save(&state).Error; err != nil {
+		log.Error("failed to set maintenance state", "error", err)
+		return err
+	}
+	return nil
+}
@@ -12,14 +12,16 @@ import (
 // DiskUsageDeletePolicy represents the disk usage policy for media deletion.
 type DiskUsageDeletePolicy struct {
 	gorm.Model
-	MediaID    uint      `gorm:"not null;index"`
-	Threshold  float64   `gorm:"not null"` // Disk usage threshold percentage
-	DeleteDate time.Time `gorm:"not null"` // Date when media should be deleted if threshold is exceeded
+	MediaID           uint      `gorm:"not null;index"`
+	Threshold         float64   `gorm:"not null"` // Disk usage threshold percentage
+	MinFreeSpaceBytes uint64    // Free space floor of the threshold, 0 if it only uses the percentage
+	DeleteDate        time.Time `gorm:"not null"` // Date when media should be deleted if threshold is exceeded
 }
 
 // Media represents a media item in the database.
 type Media struct {
 	gorm.Model
+	// JellyfinID is the item ID in the configured media server, i.e. the Emby item ID or the Plex rating key if those are used instead of Jellyfin.
 	JellyfinID      string `gorm:"not null;uniqueIndex:idx_media_arr"`
 	LibraryName     string
 	ArrID           int32 `gorm:"not null;uniqueIndex:idx_media_arr"` // Sonarr or Radarr ID
@@ -28,12 +30,26 @@ type Media struct {
 	TvdbId          *int32 `gorm:"index"`
 	Year            int32
 	FileSize        int64
+	RuntimeMinutes  int
+	ArrAddedAt      time.Time // date the media was added to Sonarr, Radarr or Readarr, zero if unknown
+	CollectionID    int32     // TMDB ID of the Radarr collection of a movie, 0 if it isn't part of a collection
+	Path            string
 	PosterURL       string
 	MediaType       MediaType `gorm:"not null;uniqueIndex:idx_media_arr"`
 	RequestedBy     string
 	DefaultDeleteAt time.Time `gorm:"not null;index;uniqueIndex:idx_media_arr"`
 	ProtectedUntil  *time.Time
-	Unkeepable      bool
+	// KeptSeasons limits the protection of a TV series to these seasons, empty protects the whole series.
+	KeptSeasons Seasons
+	Unkeepable  bool
+	// ProtectionReminderSent is set once the requester was reminded that the protection expires soon.
+	ProtectionReminderSent bool `gorm:"not null;default:false"`
+	// Ignored permanently excludes the media from cleanup, independent of the arr ignore tag.
+	Ignored bool `gorm:"not null;default:false;index"`
+	// ForceDelete is set for media tagged for immediate deletion in the arrs, recent plays don't remove it from the database.
+	ForceDelete bool `gorm:"not null;default:false"`
+	// DeletionReason explains why the media was marked for deletion, e.g. "added 120 days ago, never played".
+	DeletionReason string
 	// Reason why this item was deleted from the database.
 	DBDeleteReason          DBDeleteReason
 	DiskUsageDeletePolicies []DiskUsageDeletePolicy `gorm:"constraint:OnDelete:CASCADE;"`
@@ -81,6 +97,19 @@ func (c *Client) GetMediaItems(ctx context.Context, includeProtected bool) ([]Me
 	return mediaItems, nil
 }
 
+// GetMediaItemsByJellyfinID retrieves all media items with the given Jellyfin ID.
+func (c *Client) GetMediaItemsByJellyfinID(ctx context.Context, jellyfinID string) ([]Media, error) {
+	var mediaItems []Media
+	result := c.db.WithContext(ctx).
+		Where("jellyfin_id = ?", jellyfinID).
+		Find(&mediaItems)
+	if result.Error != nil && result.Error != gorm.ErrRecordNotFound {
+		log.Error("failed to get media items by jellyfin ID", "error", result.Error)
+		return nil, result.Error
+	}
+	return mediaItems, nil
+}
+
 func (c *Client) GetMediaItemsByMediaType(ctx context.Context, mediaType MediaType) ([]Media, error) {
 	var mediaItems []Media
 	result := c.db.WithContext(ctx).
@@ -120,6 +149,44 @@ func (c *Client) GetMediaExpiredProtection(ctx context.Context, asOf time.Time)
 	return mediaItems, nil
 }
 
+// GetLatestExpiredProtection returns the most recently removed media item with the given Jellyfin ID
+// whose protection expired, including its keep request. It returns nil if there is no such item.
+func (c *Client) GetLatestExpiredProtection(ctx context.Context, jellyfinID string) (*Media, error) {
+	var mediaItems []Media
+	result := c.db.WithContext(ctx).
+		Unscoped().
+		Preload("Request", func(tx *gorm.DB) *gorm.DB { return tx.Unscoped() }).
+		Preload("Request.User", func(tx *gorm.DB) *gorm.DB { return tx.Unscoped() }).
+		Where("jellyfin_id = ? AND db_delete_reason = ? AND deleted_at IS NOT NULL", jellyfinID, DBDeleteReasonProtectionExpired).
+		Order("deleted_at DESC").
+		Limit(1).
+		Find(&mediaItems)
+	if result.Error != nil {
+		log.Error("failed to get media item with expired protection", "error", result.Error)
+		return nil, result.Error
+	}
+	if len(mediaItems) == 0 {
+		return nil, nil
+	}
+	return &mediaItems[0], nil
+}
+
+// GetMediaProtectionExpiringBetween returns all media items whose protection expires in the given window
+// and whose requester wasn't reminded yet.
+func (c *Client) GetMediaProtectionExpiringBetween(ctx context.Context, from, to time.Time) ([]Media, error) {
+	var mediaItems []Media
+	result := c.db.WithContext(ctx).
+		Preload("Request").
+		Preload("Request.User").
+		Where("protected_until > ? AND protected_until <= ? AND protection_reminder_sent = ?", from, to, false).
+		Find(&mediaItems)
+	if result.Error != nil && result.Error != gorm.ErrRecordNotFound {
+		log.Error("failed to get media items with expiring protection", "error", result.Error)
+		return nil, result.Error
+	}
+	return mediaItems, nil
+}
+
 func (c *Client) GetDeletedMediaByTMDBID(ctx context.Context, tmdbID int32) ([]Media, error) {
 	var mediaItems []Media
 	result := c.db.WithContext(ctx).
@@ -146,10 +213,12 @@ func (c *Client) GetDeletedMediaByTVDBID(ctx context.Context, tvdbID int32) ([]M
 	return mediaItems, nil
 }
 
-func (c *Client) SetMediaProtectedUntil(ctx context.Context, mediaID uint, protectedUntil *time.Time) error {
+// SetMediaProtectedUntil protects the media until the given time.
+// keptSeasons limits the protection of a TV series to these seasons, nil protects the whole media.
+func (c *Client) SetMediaProtectedUntil(ctx context.Context, mediaID uint, protectedUntil *time.Time, keptSeasons Seasons) error {
 	result := c.db.WithContext(ctx).Model(&Media{}).
 		Where("id = ?", mediaID).
-		Updates(Media{ProtectedUntil: protectedUntil, Unkeepable: false})
+		Updates(map[string]any{"protected_until": protectedUntil, "unkeepable": false, "protection_reminder_sent": false, "kept_seasons": keptSeasons})
 	if result.Error != nil {
 		log.Error("failed to set media protected until", "error", result.Error)
 		return result.Error
@@ -157,6 +226,30 @@ func (c *Client) SetMediaProtectedUntil(ctx context.Context, mediaID uint, prote
 	return nil
 }
 
+// MarkProtectionReminderSent records that the requester was reminded about the expiring protection.
+func (c *Client) MarkProtectionReminderSent(ctx context.Context, mediaID uint) error {
+	result := c.db.WithContext(ctx).Model(&Media{}).
+		Where("id = ?", mediaID).
+		Update("protection_reminder_sent", true)
+	if result.Error != nil {
+		log.Error("failed to mark protection reminder as sent", "error", result.Error)
+		return result.Error
+	}
+	return nil
+}
+
+// SetMediaDefaultDeleteAt moves the default deletion date of a media item.
+func (c *Client) SetMediaDefaultDeleteAt(ctx context.Context, mediaID uint, deleteAt time.Time) error {
+	result := c.db.WithContext(ctx).Model(&Media{}).
+		Where("id = ?", mediaID).
+		Update("default_delete_at", deleteAt)
+	if result.Error != nil {
+		log.Error("failed to set media default delete date", "error", result.Error)
+		return result.Error
+	}
+	return nil
+}
+
 func (c *Client) MarkMediaAsUnkeepable(ctx context.Context, mediaID uint) error {
 	result := c.db.WithContext(ctx).Model(&Media{}).
 		Where("id = ?", mediaID).
@@ -184,3 +277,37 @@ func (c *Client) DeleteMediaItem(ctx context.Context, media *Media) error {
 	}
 	return nil
 }
+
+// SetMediaIgnored sets the permanent-ignore flag for the media item and all other
+// rows (including soft-deleted ones) that reference the same arr item.
+func (c *Client) SetMediaIgnored(ctx context.Context, mediaID uint, ignored bool) (*Media, error) {
+	var media Media
+	if err := c.db.WithContext(ctx).Unscoped().First(&media, mediaID).Error; err != nil {
+		log.Error("failed to get media item by ID", "error", err)
+		return nil, err
+	}
+
+	result := c.db.WithContext(ctx).Unscoped().Model(&Media{}).
+		Where("arr_id = ? AND media_type = ?", media.ArrID, media.MediaType).
+		Update("ignored", ignored)
+	if result.Error != nil {
+		log.Error("failed to set media ignored", "error", result.Error)
+		return nil, result.Error
+	}
+	media.Ignored = ignored
+	return &media, nil
+}
+
+// GetIgnoredMedia retrieves all media items that are permanently ignored, including soft-deleted ones.
+func (c *Client) GetIgnoredMedia(ctx context.Context) ([]Media, error) {
+	var mediaItems []Media
+	result := c.db.WithContext(ctx).
+		Unscoped().
+		Where("ignored = ?", true).
+		Find(&mediaItems)
+	if result.Error != nil && result.Error != gorm.ErrRecordNotFound {
+		log.Error("failed to get ignored media items", "error", result.Error)
+		return nil, result.Error
+	}
+	return mediaItems, nil
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"gorm.io/gorm"
+)
+
+// schemaModels are the models whose tables are created and updated by the migrations.
+var schemaModels = []any{
+	&Media{},
+	&DiskUsageDeletePolicy{},
+	&Request{},
+	&User{},
+	&UserSettings{},
+	&UserPermissions{},
+	&EmailSettings{},
+	&HistoryEvent{},
+	&CleanupRun{},
+	&CleanupRunStep{},
+	&UserNotificationPrefs{},
+	&DeletionFailure{},
+	&SchedulerState{},
+	&MaintenanceState{},
+	&AuditLogEntry{},
+	&DeletionEstimate{},
+	&ScanCursor{},
+	&TagMigrationState{},
+}
+
+// TableStatus describes whether the table of a model matches the current schema.
+type TableStatus struct {
+	// Table is the name of the table.
+	Table string
+	// Exists is false if the table still has to be created.
+	Exists bool
+	// MissingColumns are the columns that still have to be added.
+	MissingColumns []string
+}
+
+// Pending reports whether the table needs a migration.
+func (s TableStatus) Pending() bool {
+	return !s.Exists || len(s.MissingColumns) > 0
+}
+
+// Migrate creates or updates the tables of the database without starting anything else.
+func Migrate(cfg *config.DatabaseConfig) ([]TableStatus, error) {
+	db, err := open(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer closeDB(db)
+
+	if err := migrate(db); err != nil {
+		return nil, err
+	}
+	return schemaStatus(db)
+}
+
+// MigrationStatus reports the tables and columns the migrations would create, without changing the database.
+func MigrationStatus(cfg *config.DatabaseConfig) ([]TableStatus, error) {
+	db, err := open(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer closeDB(db)
+
+	return schemaStatus(db)
+}
+
+func open(cfg *config.DatabaseConfig) (*gorm.DB, error) {
+	dialector, err := dialectorForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect database: %w", err)
+	}
+	return db, nil
+}
+
+func closeDB(db *gorm.DB) {
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.Close() //nolint:errcheck,gosec
+	}
+}
+
+func migrate(db *gorm.DB) error {
+	isNew := isNewDatabase(db)
+	if err := db.AutoMigrate(schemaModels...); err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+	// the tags of the tag based versions are migrated by the first cleanup run
+	if isNew {
+		return markTagMigrationPending(db)
+	}
+	return nil
+}
+
+// schemaStatus compares the tables and columns of the database with the models.
+func schemaStatus(db *gorm.DB) ([]TableStatus, error) {
+	migrator := db.Migrator()
+	statuses := make([]TableStatus, 0, len(schemaModels))
+	for _, model := range schemaModels {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+
+		status := TableStatus{Table: stmt.Schema.Table, Exists: migrator.HasTable(model)}
+		if status.Exists {
+			for _, field := range stmt.Schema.Fields {
+				if field.DBName == "" || field.IgnoreMigration {
+					continue
+				}
+				if !migrator.HasColumn(model, field.DBName) {
+					status.MissingColumns = append(status.MissingColumns, field.DBName)
+				}
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
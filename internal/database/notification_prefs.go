@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/charmbracelet/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserNotificationPrefs holds the notification preferences of a user.
+// Users without a row get DefaultUserNotificationPrefs, which matches the behavior before preferences existed.
+type UserNotificationPrefs struct {
+	gorm.Model
+	UserID uint `gorm:"uniqueIndex;not null"`
+	// Email is the address the user receives cleanup notifications at.
+	// It is used to match the requester email reported by Jellyseerr.
+	Email string `gorm:"index"`
+	// EmailEnabled controls whether the user receives cleanup emails.
+	EmailEnabled bool `gorm:"not null"`
+	// WebPushEnabled controls whether the user receives web push notifications.
+	WebPushEnabled bool `gorm:"not null"`
+	// Digest sends all items of a cleanup run in a single email instead of one email per item.
+	Digest bool `gorm:"not null"`
+}
+
+// DefaultUserNotificationPrefs returns the preferences used for users that haven't set any.
+func DefaultUserNotificationPrefs(userID uint) *UserNotificationPrefs {
+	return &UserNotificationPrefs{
+		UserID:         userID,
+		EmailEnabled:   true,
+		WebPushEnabled: true,
+		Digest:         true,
+	}
+}
+
+// NotificationPrefsDB defines the interface for notification preference database operations.
+type NotificationPrefsDB interface {
+	GetUserNotificationPrefs(ctx context.Context, userID uint) (*UserNotificationPrefs, error)
+	GetUserNotificationPrefsByEmail(ctx context.Context, email string) (*UserNotificationPrefs, error)
+	SetUserNotificationPrefs(ctx context.Context, prefs *UserNotificationPrefs) error
+}
+
+// GetUserNotificationPrefs returns the notification preferences of a user.
+// If the user hasn't stored any preferences, the defaults are returned.
+func (c *Client) GetUserNotificationPrefs(ctx context.Context, userID uint) (*UserNotificationPrefs, error) {
+	var prefs UserNotificationPrefs
+	if err := c.db.WithContext(ctx).Where("user_id = ?", userID).First(&prefs).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return DefaultUserNotificationPrefs(userID), nil
+		}
+		log.Error("failed to get user notification preferences", "error", err)
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+// GetUserNotificationPrefsByEmail returns the notification preferences stored for an email address.
+// If no user stored preferences for the address, the defaults are returned.
+func (c *Client) GetUserNotificationPrefsByEmail(ctx context.Context, email string) (*UserNotificationPrefs, error) {
+	var prefs UserNotificationPrefs
+	if err := c.db.WithContext(ctx).Where("LOWER(email) = LOWER(?)", email).First(&prefs).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return DefaultUserNotificationPrefs(0), nil
+		}
+		log.Error("failed to get user notification preferences by email", "error", err)
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+// SetUserNotificationPrefs creates or updates the notification preferences of a user.
+func (c *Client) SetUserNotificationPrefs(ctx context.Context, prefs *UserNotificationPrefs) error {
+	if prefs.ID != 0 {
+		if err := c.db.WithContext(ctx).Save(prefs).Error; err != nil {
+			log.Error("failed to update user notification preferences", "error", err)
+			return err
+		}
+		return nil
+	}
+	if err := c.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "email", "email_enabled", "web_push_enabled", "digest"}),
+	}).Create(prefs).Error; err != nil {
+		log.Error("failed to set user notification preferences", "error", err)
+		return err
+	}
+	return nil
+}
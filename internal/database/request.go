@@ -2,11 +2,21 @@ package database
 
 import (
 	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"gorm.io/gorm"
 )
 
+// ErrRequestStatusChanged is returned if a keep request no longer has the expected status,
+// e.g. because it was processed concurrently.
+var ErrRequestStatusChanged = errors.New("request status changed")
+
 type RequestStatus string
 
 const (
@@ -16,6 +26,65 @@ const (
 	RequestStatusDenied      RequestStatus = "denied"
 )
 
+// Seasons is a list of season numbers stored as JSON.
+type Seasons []int32
+
+// Value implements driver.Valuer. An empty list is stored as NULL.
+func (s Seasons) Value() (driver.Value, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal([]int32(s))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner.
+func (s *Seasons) Scan(value any) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	default:
+		return fmt.Errorf("unsupported type for seasons: %T", value)
+	}
+	if len(b) == 0 {
+		*s = nil
+		return nil
+	}
+	return json.Unmarshal(b, (*[]int32)(s))
+}
+
+// GormDataType returns the column type used for the seasons.
+func (Seasons) GormDataType() string {
+	return "text"
+}
+
+// Contains reports whether the season is part of the list.
+func (s Seasons) Contains(season int32) bool {
+	return slices.Contains(s, season)
+}
+
+// KeepRequestDecision describes the decision about the keep request of a single media item.
+type KeepRequestDecision struct {
+	MediaID   uint
+	RequestID uint
+	// Approved protects the media until ProtectedUntil, otherwise the media is marked as unkeepable.
+	Approved       bool
+	ProtectedUntil time.Time
+	// KeptSeasons limits the protection of approved TV series to these seasons, nil protects the whole series.
+	KeptSeasons Seasons
+	// DeleteAt moves the default deletion date of denied media, if set.
+	DeleteAt *time.Time
+}
+
 // Request represents a media keep request made by a user.
 type Request struct {
 	gorm.Model
@@ -23,12 +92,15 @@ type Request struct {
 	Status  RequestStatus `gorm:"not null;default:'pending';index"`
 	UserID  uint          `gorm:"not null;index"`
 	User    User
+	// KeptSeasons are the seasons of a TV series the user asked to keep, empty for the whole media.
+	KeptSeasons Seasons
 }
 
-func (c *Client) CreateRequest(ctx context.Context, mediaID uint, userID uint) (*Request, error) {
+func (c *Client) CreateRequest(ctx context.Context, mediaID uint, userID uint, keptSeasons Seasons) (*Request, error) {
 	request := Request{
-		MediaID: mediaID,
-		UserID:  userID,
+		MediaID:     mediaID,
+		UserID:      userID,
+		KeptSeasons: keptSeasons,
 	}
 	if err := c.db.WithContext(ctx).Create(&request).Error; err != nil {
 		return nil, err
@@ -36,14 +108,55 @@ func (c *Client) CreateRequest(ctx context.Context, mediaID uint, userID uint) (
 	return &request, nil
 }
 
-func (c *Client) UpdateRequestStatus(ctx context.Context, requestID uint, status RequestStatus) error {
-	result := c.db.WithContext(ctx).Model(&Request{}).Where("id = ?", requestID).Update("status", status)
+// UpdateRequestStatus changes the status of a keep request, but only if it still has the expected status.
+// It returns ErrRequestStatusChanged if the request doesn't exist or its status changed in the meantime.
+func (c *Client) UpdateRequestStatus(ctx context.Context, requestID uint, expected, status RequestStatus) error {
+	result := c.db.WithContext(ctx).Model(&Request{}).Where("id = ? AND status = ?", requestID, expected).Update("status", status)
 	if result.Error != nil {
 		log.Error("failed to update request status", "error", result.Error)
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+		return ErrRequestStatusChanged
 	}
 	return nil
 }
+
+// ApplyKeepRequestDecisions updates multiple keep requests and the protection of their media in a single transaction.
+// Requests that were deleted or aren't pending anymore are skipped, their media IDs are returned.
+func (c *Client) ApplyKeepRequestDecisions(ctx context.Context, decisions []KeepRequestDecision) ([]uint, error) {
+	var skipped []uint
+	err := c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		skipped = nil
+		for _, decision := range decisions {
+			status := RequestStatusDenied
+			mediaUpdates := map[string]any{"unkeepable": true, "protected_until": nil}
+			if decision.Approved {
+				status = RequestStatusApproved
+				mediaUpdates = map[string]any{"unkeepable": false, "protected_until": decision.ProtectedUntil, "protection_reminder_sent": false, "kept_seasons": decision.KeptSeasons}
+			} else if decision.DeleteAt != nil {
+				mediaUpdates["default_delete_at"] = *decision.DeleteAt
+			}
+
+			result := tx.Model(&Request{}).Where("id = ? AND status = ?", decision.RequestID, RequestStatusPending).Update("status", status)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				log.Warn("skipping keep request that was deleted or processed in the meantime", "requestID", decision.RequestID, "mediaID", decision.MediaID)
+				skipped = append(skipped, decision.MediaID)
+				continue
+			}
+
+			if err := tx.Model(&Media{}).Where("id = ?", decision.MediaID).Updates(mediaUpdates).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("failed to apply keep request decisions", "error", err)
+		return nil, err
+	}
+	return skipped, nil
+}
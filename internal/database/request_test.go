@@ -0,0 +1,46 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyKeepRequestDecisionsSkipsStaleRequests(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+
+	user := User{Username: "user"}
+	require.NoError(t, c.db.Create(&user).Error)
+	pending := Media{JellyfinID: "jf-1", LibraryName: "Movies", ArrID: 1, Title: "Pending", MediaType: MediaTypeMovie}
+	processed := Media{JellyfinID: "jf-2", LibraryName: "Movies", ArrID: 2, Title: "Processed", MediaType: MediaTypeMovie}
+	require.NoError(t, c.db.Create(&pending).Error)
+	require.NoError(t, c.db.Create(&processed).Error)
+	pendingRequest, err := c.CreateRequest(ctx, pending.ID, user.ID, nil)
+	require.NoError(t, err)
+	processedRequest, err := c.CreateRequest(ctx, processed.ID, user.ID, nil)
+	require.NoError(t, err)
+	require.NoError(t, c.UpdateRequestStatus(ctx, processedRequest.ID, RequestStatusPending, RequestStatusDenied))
+
+	protectedUntil := time.Now().Add(24 * time.Hour)
+	skipped, err := c.ApplyKeepRequestDecisions(ctx, []KeepRequestDecision{
+		{MediaID: pending.ID, RequestID: pendingRequest.ID, Approved: true, ProtectedUntil: protectedUntil},
+		{MediaID: processed.ID, RequestID: processedRequest.ID, Approved: true, ProtectedUntil: protectedUntil},
+		{MediaID: 99, RequestID: 99, Approved: true, ProtectedUntil: protectedUntil},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{processed.ID, 99}, skipped)
+
+	var got Media
+	require.NoError(t, c.db.Preload("Request").First(&got, pending.ID).Error)
+	assert.Equal(t, RequestStatusApproved, got.Request.Status)
+	require.NotNil(t, got.ProtectedUntil)
+
+	var untouched Media
+	require.NoError(t, c.db.Preload("Request").First(&untouched, processed.ID).Error)
+	assert.Equal(t, RequestStatusDenied, untouched.Request.Status, "the processed request is left untouched")
+	assert.Nil(t, untouched.ProtectedUntil)
+}
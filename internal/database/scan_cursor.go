@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"gorm.io/gorm"
+)
+
+// scanCursorID is the ID of the single scan cursor row.
+const scanCursorID = 1
+
+// ScanCursor holds the position the next cleanup run continues at if the runs are limited by max_items_per_run.
+// The position is the sort key of the last checked item, so added or removed items don't shift it.
+type ScanCursor struct {
+	gorm.Model
+	// OrderBy is the scan order the position belongs to.
+	OrderBy string
+	// LastAdded is the date the last checked item was added to the arrs, used with the added order.
+	LastAdded time.Time
+	// LastSize is the size of the last checked item, used with the size order.
+	LastSize int64
+	// LastJellyfinID is the Jellyfin ID of the last checked item, it breaks ties of the sort value.
+	LastJellyfinID string
+}
+
+// ScanCursorDB defines the interface for scan cursor database operations.
+type ScanCursorDB interface {
+	GetScanCursor(ctx context.Context) (*ScanCursor, error)
+	SetScanCursor(ctx context.Context, cursor *ScanCursor) error
+	ResetScanCursor(ctx context.Context) error
+}
+
+// GetScanCursor returns the position the next cleanup run continues at, or nil if it starts at the first item.
+func (c *Client) GetScanCursor(ctx context.Context) (*ScanCursor, error) {
+	var cursor ScanCursor
+	if err := c.db.WithContext(ctx).First(&cursor, scanCursorID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		log.Error("failed to get scan cursor", "error", err)
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// SetScanCursor stores the position the next cleanup run continues at.
+func (c *Client) SetScanCursor(ctx context.Context, cursor *ScanCursor) error {
+	cursor.ID = scanCursorID
+	if err := c.db.WithContext(ctx).Save(cursor).Error; err != nil {
+		log.Error("failed to set scan cursor", "error", err)
+		return err
+	}
+	return nil
+}
+
+// ResetScanCursor removes the stored position, so the next cleanup run starts at the first item.
+func (c *Client) ResetScanCursor(ctx context.Context) error {
+	if err := c.db.WithContext(ctx).Unscoped().Delete(&ScanCursor{}, scanCursorID).Error; err != nil {
+		log.Error("failed to reset scan cursor", "error", err)
+		return err
+	}
+	return nil
+}
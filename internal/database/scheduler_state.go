@@ -0,0 +1,49 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/charmbracelet/log"
+	"gorm.io/gorm"
+)
+
+// schedulerStateID is the ID of the single scheduler state row.
+const schedulerStateID = 1
+
+// SchedulerState holds the persisted state of the job scheduler.
+type SchedulerState struct {
+	gorm.Model
+	// Paused indicates that all scheduled jobs are suspended.
+	Paused bool `gorm:"not null"`
+}
+
+// SchedulerStateDB defines the interface for scheduler state database operations.
+type SchedulerStateDB interface {
+	GetSchedulerPaused(ctx context.Context) (bool, error)
+	SetSchedulerPaused(ctx context.Context, paused bool) error
+}
+
+// GetSchedulerPaused returns whether the scheduler is paused.
+func (c *Client) GetSchedulerPaused(ctx context.Context) (bool, error) {
+	var state SchedulerState
+	if err := c.db.WithContext(ctx).First(&state, schedulerStateID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		log.Error("failed to get scheduler state", "error", err)
+		return false, err
+	}
+	return state.Paused, nil
+}
+
+// SetSchedulerPaused stores whether the scheduler is paused.
+func (c *Client) SetSchedulerPaused(ctx context.Context, paused bool) error {
+	state := SchedulerState{Paused: paused}
+	state.ID = schedulerStateID
+	if err := c.db.WithContext(ctx).Save(&state).Error; err != nil {
+		log.Error("failed to set scheduler state", "error", err)
+		return err
+	}
+	return nil
+}
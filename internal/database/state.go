@@ -0,0 +1,340 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StateSchemaVersion is the version of the state dump written by ExportState.
+// It must be increased whenever the format changes in a way older versions can't read.
+const StateSchemaVersion = 1
+
+// State is a versioned dump of the media items, their keep requests and their history.
+// Database IDs are not part of the dump, users are referenced by their username.
+type State struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	ExportedAt    time.Time    `json:"exportedAt"`
+	Media         []StateMedia `json:"media"`
+}
+
+// StateMedia is a media item in the state dump.
+// DeletedAt is set for media items that were already removed from the database.
+type StateMedia struct {
+	JellyfinID             string              `json:"jellyfinId"`
+	LibraryName            string              `json:"libraryName"`
+	ArrID                  int32               `json:"arrId"`
+	Title                  string              `json:"title"`
+	TmdbID                 *int32              `json:"tmdbId,omitempty"`
+	TvdbID                 *int32              `json:"tvdbId,omitempty"`
+	Year                   int32               `json:"year"`
+	FileSize               int64               `json:"fileSize"`
+	RuntimeMinutes         int                 `json:"runtimeMinutes,omitempty"`
+	CollectionID           int32               `json:"collectionId,omitempty"`
+	Path                   string              `json:"path,omitempty"`
+	PosterURL              string              `json:"posterUrl,omitempty"`
+	MediaType              MediaType           `json:"mediaType"`
+	RequestedBy            string              `json:"requestedBy,omitempty"`
+	DefaultDeleteAt        time.Time           `json:"defaultDeleteAt"`
+	ProtectedUntil         *time.Time          `json:"protectedUntil,omitempty"`
+	KeptSeasons            Seasons             `json:"keptSeasons,omitempty"`
+	Unkeepable             bool                `json:"unkeepable"`
+	ProtectionReminderSent bool                `json:"protectionReminderSent"`
+	Ignored                bool                `json:"ignored"`
+	ForceDelete            bool                `json:"forceDelete,omitempty"`
+	DeletionReason         string              `json:"deletionReason,omitempty"`
+	DBDeleteReason         DBDeleteReason      `json:"dbDeleteReason,omitempty"`
+	CreatedAt              time.Time           `json:"createdAt"`
+	DeletedAt              *time.Time          `json:"deletedAt,omitempty"`
+	Request                *StateRequest       `json:"request,omitempty"`
+	History                []StateHistoryEvent `json:"history,omitempty"`
+}
+
+// StateRequest is the keep request of a media item in the state dump.
+type StateRequest struct {
+	Username    string        `json:"username"`
+	Status      RequestStatus `json:"status"`
+	KeptSeasons Seasons       `json:"keptSeasons,omitempty"`
+	CreatedAt   time.Time     `json:"createdAt"`
+}
+
+// StateHistoryEvent is a history event of a media item in the state dump.
+type StateHistoryEvent struct {
+	EventType HistoryEventType `json:"eventType"`
+	Username  string           `json:"username,omitempty"`
+	EventTime time.Time        `json:"eventTime"`
+}
+
+// StateDB defines the interface for exporting and importing the database state.
+type StateDB interface {
+	ExportState(ctx context.Context, w io.Writer) error
+	ImportState(ctx context.Context, r io.Reader) error
+}
+
+// ExportState writes a JSON dump of all media items, including already deleted ones, with their keep requests and history to w.
+func (c *Client) ExportState(ctx context.Context, w io.Writer) error {
+	var mediaItems []Media
+	err := c.db.WithContext(ctx).Unscoped().
+		Preload("Request", func(tx *gorm.DB) *gorm.DB { return tx.Unscoped() }).
+		Preload("Request.User", func(tx *gorm.DB) *gorm.DB { return tx.Unscoped() }).
+		Order("id ASC").
+		Find(&mediaItems).Error
+	if err != nil {
+		log.Error("failed to get media items for export", "error", err)
+		return err
+	}
+
+	var events []HistoryEvent
+	err = c.db.WithContext(ctx).
+		Preload("User", func(tx *gorm.DB) *gorm.DB { return tx.Unscoped() }).
+		Order("event_time ASC").
+		Find(&events).Error
+	if err != nil {
+		log.Error("failed to get history events for export", "error", err)
+		return err
+	}
+
+	history := make(map[uint][]StateHistoryEvent)
+	for _, event := range events {
+		stateEvent := StateHistoryEvent{EventType: event.EventType, EventTime: event.EventTime}
+		if event.User != nil {
+			stateEvent.Username = event.User.Username
+		}
+		history[event.MediaID] = append(history[event.MediaID], stateEvent)
+	}
+
+	state := State{
+		SchemaVersion: StateSchemaVersion,
+		ExportedAt:    time.Now(),
+		Media:         make([]StateMedia, 0, len(mediaItems)),
+	}
+	for _, media := range mediaItems {
+		stateMedia := StateMedia{
+			JellyfinID:             media.JellyfinID,
+			LibraryName:            media.LibraryName,
+			ArrID:                  media.ArrID,
+			Title:                  media.Title,
+			TmdbID:                 media.TmdbId,
+			TvdbID:                 media.TvdbId,
+			Year:                   media.Year,
+			FileSize:               media.FileSize,
+			RuntimeMinutes:         media.RuntimeMinutes,
+			CollectionID:           media.CollectionID,
+			Path:                   media.Path,
+			PosterURL:              media.PosterURL,
+			MediaType:              media.MediaType,
+			RequestedBy:            media.RequestedBy,
+			DefaultDeleteAt:        media.DefaultDeleteAt,
+			ProtectedUntil:         media.ProtectedUntil,
+			KeptSeasons:            media.KeptSeasons,
+			Unkeepable:             media.Unkeepable,
+			ProtectionReminderSent: media.ProtectionReminderSent,
+			Ignored:                media.Ignored,
+			ForceDelete:            media.ForceDelete,
+			DeletionReason:         media.DeletionReason,
+			DBDeleteReason:         media.DBDeleteReason,
+			CreatedAt:              media.CreatedAt,
+			History:                history[media.ID],
+		}
+		if media.DeletedAt.Valid {
+			stateMedia.DeletedAt = &media.DeletedAt.Time
+		}
+		if media.Request.ID != 0 {
+			stateMedia.Request = &StateRequest{
+				Username:    media.Request.User.Username,
+				Status:      media.Request.Status,
+				KeptSeasons: media.Request.KeptSeasons,
+				CreatedAt:   media.Request.CreatedAt,
+			}
+		}
+		state.Media = append(state.Media, stateMedia)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(state)
+}
+
+// ImportState restores a JSON dump written by ExportState in a single transaction.
+// Media items are upserted by their arr ID, media type and library, so importing the same dump multiple times doesn't create duplicates.
+func (c *Client) ImportState(ctx context.Context, r io.Reader) error {
+	var state State
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return fmt.Errorf("failed to decode state: %w", err)
+	}
+	if state.SchemaVersion < 1 || state.SchemaVersion > StateSchemaVersion {
+		return fmt.Errorf("unsupported state schema version %d, expected at most %d", state.SchemaVersion, StateSchemaVersion)
+	}
+
+	err := c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		users := make(map[string]uint)
+		for _, stateMedia := range state.Media {
+			if stateMedia.JellyfinID == "" {
+				return fmt.Errorf("media %q has no jellyfin ID", stateMedia.Title)
+			}
+			mediaID, err := importStateMedia(tx, stateMedia)
+			if err != nil {
+				return fmt.Errorf("failed to import media %q: %w", stateMedia.Title, err)
+			}
+			if stateMedia.Request != nil {
+				if err := importStateRequest(tx, users, mediaID, stateMedia.Request); err != nil {
+					return fmt.Errorf("failed to import keep request of %q: %w", stateMedia.Title, err)
+				}
+			}
+			for _, event := range stateMedia.History {
+				if err := importStateHistoryEvent(tx, users, mediaID, event); err != nil {
+					return fmt.Errorf("failed to import history of %q: %w", stateMedia.Title, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("failed to import state", "error", err)
+	}
+	return err
+}
+
+// importStateMedia creates or updates the matching media item and returns its ID.
+// Media items are matched by their arr ID, media type and library. An active item only matches the active row,
+// a deleted one matches the deleted row that was removed at the same second.
+func importStateMedia(tx *gorm.DB, stateMedia StateMedia) (uint, error) {
+	var candidates []Media
+	query := tx.Unscoped().
+		Where("arr_id = ? AND media_type = ? AND library_name = ?", stateMedia.ArrID, stateMedia.MediaType, stateMedia.LibraryName)
+	if stateMedia.DeletedAt != nil {
+		query = query.Where("deleted_at IS NOT NULL")
+	} else {
+		query = query.Where("deleted_at IS NULL")
+	}
+	if err := query.Order("id ASC").Find(&candidates).Error; err != nil {
+		return 0, err
+	}
+
+	var media Media
+	for _, candidate := range candidates {
+		if stateMedia.DeletedAt == nil || sameSecond(candidate.DeletedAt.Time, *stateMedia.DeletedAt) {
+			media = candidate
+			break
+		}
+	}
+
+	media.JellyfinID = stateMedia.JellyfinID
+	media.LibraryName = stateMedia.LibraryName
+	media.ArrID = stateMedia.ArrID
+	media.Title = stateMedia.Title
+	media.TmdbId = stateMedia.TmdbID
+	media.TvdbId = stateMedia.TvdbID
+	media.Year = stateMedia.Year
+	media.FileSize = stateMedia.FileSize
+	media.RuntimeMinutes = stateMedia.RuntimeMinutes
+	media.CollectionID = stateMedia.CollectionID
+	media.Path = stateMedia.Path
+	media.PosterURL = stateMedia.PosterURL
+	media.MediaType = stateMedia.MediaType
+	media.RequestedBy = stateMedia.RequestedBy
+	media.DefaultDeleteAt = stateMedia.DefaultDeleteAt
+	media.ProtectedUntil = stateMedia.ProtectedUntil
+	media.KeptSeasons = stateMedia.KeptSeasons
+	media.Unkeepable = stateMedia.Unkeepable
+	media.ProtectionReminderSent = stateMedia.ProtectionReminderSent
+	media.Ignored = stateMedia.Ignored
+	media.ForceDelete = stateMedia.ForceDelete
+	media.DeletionReason = stateMedia.DeletionReason
+	media.DBDeleteReason = stateMedia.DBDeleteReason
+	if media.ID == 0 {
+		media.CreatedAt = stateMedia.CreatedAt
+	}
+	media.DeletedAt = gorm.DeletedAt{}
+	if stateMedia.DeletedAt != nil {
+		media.DeletedAt = gorm.DeletedAt{Time: *stateMedia.DeletedAt, Valid: true}
+	}
+
+	if err := tx.Unscoped().Omit(clause.Associations).Save(&media).Error; err != nil {
+		return 0, err
+	}
+	return media.ID, nil
+}
+
+// importStateRequest creates or updates the keep request of the media item.
+func importStateRequest(tx *gorm.DB, users map[string]uint, mediaID uint, stateRequest *StateRequest) error {
+	userID, err := importStateUser(tx, users, stateRequest.Username)
+	if err != nil {
+		return err
+	}
+
+	var request Request
+	if err := tx.Unscoped().Where("media_id = ?", mediaID).Limit(1).Find(&request).Error; err != nil {
+		return err
+	}
+
+	request.MediaID = mediaID
+	request.UserID = userID
+	request.Status = stateRequest.Status
+	request.KeptSeasons = stateRequest.KeptSeasons
+	request.DeletedAt = gorm.DeletedAt{}
+	if request.ID == 0 {
+		request.CreatedAt = stateRequest.CreatedAt
+	}
+	return tx.Unscoped().Omit(clause.Associations).Save(&request).Error
+}
+
+// importStateHistoryEvent creates the history event unless the media item already has an event of the same type at the same second.
+func importStateHistoryEvent(tx *gorm.DB, users map[string]uint, mediaID uint, stateEvent StateHistoryEvent) error {
+	var userID *uint
+	if stateEvent.Username != "" {
+		id, err := importStateUser(tx, users, stateEvent.Username)
+		if err != nil {
+			return err
+		}
+		userID = &id
+	}
+
+	var events []HistoryEvent
+	err := tx.Where("media_id = ? AND event_type = ?", mediaID, stateEvent.EventType).Find(&events).Error
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if sameSecond(event.EventTime, stateEvent.EventTime) {
+			return nil
+		}
+	}
+
+	return tx.Omit(clause.Associations).Create(&HistoryEvent{
+		MediaID:   mediaID,
+		EventType: stateEvent.EventType,
+		UserID:    userID,
+		EventTime: stateEvent.EventTime,
+	}).Error
+}
+
+// importStateUser returns the ID of the user with the given username, creating the user if needed.
+func importStateUser(tx *gorm.DB, users map[string]uint, username string) (uint, error) {
+	if id, ok := users[username]; ok {
+		return id, nil
+	}
+	if username == "" {
+		return 0, errors.New("missing username")
+	}
+
+	user := User{Username: username}
+	if err := tx.Where(User{Username: username}).FirstOrCreate(&user).Error; err != nil {
+		return 0, err
+	}
+	users[username] = user.ID
+	return user.ID, nil
+}
+
+// sameSecond reports whether two timestamps describe the same second.
+// The database may store timestamps with a lower precision or in another timezone than the dump.
+func sameSecond(a, b time.Time) bool {
+	return a.Truncate(time.Second).Equal(b.Truncate(time.Second))
+}
@@ -0,0 +1,152 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	c, _, err := New(&config.DatabaseConfig{
+		Type:        config.DatabaseTypeSQLite,
+		Path:        filepath.Join(t.TempDir(), "jellysweep.db"),
+		AutoMigrate: true,
+	})
+	require.NoError(t, err)
+	return c
+}
+
+// seedState creates an active and a deleted movie with the same arr ID, a keep request and history events.
+func seedState(t *testing.T, c *Client) {
+	t.Helper()
+	ctx := context.Background()
+	deleteAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	deleted := Media{JellyfinID: "jf-old", LibraryName: "Movies", ArrID: 7, Title: "Old Cut", MediaType: MediaTypeMovie, DefaultDeleteAt: deleteAt.AddDate(0, -6, 0)}
+	require.NoError(t, c.db.Create(&deleted).Error)
+	require.NoError(t, c.db.Model(&deleted).Update("db_delete_reason", DBDeleteReasonDefault).Error)
+	require.NoError(t, c.db.Delete(&deleted).Error)
+
+	active := Media{JellyfinID: "jf-new", LibraryName: "Movies", ArrID: 7, Title: "New Cut", MediaType: MediaTypeMovie, DefaultDeleteAt: deleteAt, FileSize: 1 << 30}
+	require.NoError(t, c.db.Create(&active).Error)
+	show := Media{JellyfinID: "jf-show", LibraryName: "TV Shows", ArrID: 7, Title: "Show", MediaType: MediaTypeTV, DefaultDeleteAt: deleteAt, KeptSeasons: Seasons{1, 2}}
+	require.NoError(t, c.db.Create(&show).Error)
+
+	user := User{Username: "alice"}
+	require.NoError(t, c.db.Create(&user).Error)
+	_, err := c.CreateRequest(ctx, active.ID, user.ID, nil)
+	require.NoError(t, err)
+
+	eventTime := time.Date(2026, 2, 1, 8, 30, 15, 123456789, time.UTC)
+	require.NoError(t, c.CreateHistoryEvent(ctx, HistoryEvent{MediaID: active.ID, EventType: HistoryEventPickedUp, EventTime: eventTime}))
+	require.NoError(t, c.CreateHistoryEvent(ctx, HistoryEvent{MediaID: active.ID, EventType: HistoryEventRequestCreated, UserID: &user.ID, EventTime: eventTime.Add(time.Hour)}))
+	require.NoError(t, c.CreateHistoryEvent(ctx, HistoryEvent{MediaID: deleted.ID, EventType: HistoryEventDeleted, EventTime: eventTime.Add(-time.Hour)}))
+}
+
+func exportState(t *testing.T, c *Client) State {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, c.ExportState(context.Background(), &buf))
+	var state State
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &state))
+	return state
+}
+
+func importState(t *testing.T, c *Client, state State) {
+	t.Helper()
+	data, err := json.Marshal(state)
+	require.NoError(t, err)
+	require.NoError(t, c.ImportState(context.Background(), bytes.NewReader(data)))
+}
+
+// normalizeState drops the fields that differ between databases and normalizes the timestamps for comparison.
+func normalizeState(state State) State {
+	normalize := func(t time.Time) time.Time { return t.UTC().Truncate(time.Second) }
+	state.ExportedAt = time.Time{}
+	for i := range state.Media {
+		media := &state.Media[i]
+		media.CreatedAt = normalize(media.CreatedAt)
+		media.DefaultDeleteAt = normalize(media.DefaultDeleteAt)
+		if media.DeletedAt != nil {
+			deletedAt := normalize(*media.DeletedAt)
+			media.DeletedAt = &deletedAt
+		}
+		if media.Request != nil {
+			media.Request.CreatedAt = normalize(media.Request.CreatedAt)
+		}
+		for j := range media.History {
+			media.History[j].EventTime = normalize(media.History[j].EventTime)
+		}
+	}
+	return state
+}
+
+func countRows(t *testing.T, c *Client) map[string]int64 {
+	t.Helper()
+	counts := make(map[string]int64)
+	for name, model := range map[string]any{"media": &Media{}, "requests": &Request{}, "history": &HistoryEvent{}, "users": &User{}} {
+		var count int64
+		require.NoError(t, c.db.Unscoped().Model(model).Count(&count).Error)
+		counts[name] = count
+	}
+	return counts
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	source := newTestClient(t)
+	seedState(t, source)
+	exported := exportState(t, source)
+	require.Len(t, exported.Media, 3)
+	assert.Equal(t, StateSchemaVersion, exported.SchemaVersion)
+
+	target := newTestClient(t)
+	importState(t, target, exported)
+	assert.Equal(t, normalizeState(exported), normalizeState(exportState(t, target)))
+	assert.Equal(t, countRows(t, source), countRows(t, target))
+
+	// importing the same dump again updates the existing rows
+	importState(t, target, exported)
+	assert.Equal(t, countRows(t, source), countRows(t, target))
+	assert.Equal(t, normalizeState(exported), normalizeState(exportState(t, target)))
+}
+
+func TestImportStateMatchesAcrossTimezones(t *testing.T) {
+	c := newTestClient(t)
+	seedState(t, c)
+	before := countRows(t, c)
+
+	// the same dump with all timestamps in another timezone and without sub-second precision
+	state := exportState(t, c)
+	zone := time.FixedZone("UTC+5", 5*60*60)
+	for i := range state.Media {
+		media := &state.Media[i]
+		media.Title += " (restored)"
+		if media.DeletedAt != nil {
+			deletedAt := media.DeletedAt.In(zone).Truncate(time.Second)
+			media.DeletedAt = &deletedAt
+		}
+		for j := range media.History {
+			media.History[j].EventTime = media.History[j].EventTime.In(zone).Truncate(time.Second)
+		}
+	}
+	importState(t, c, state)
+
+	assert.Equal(t, before, countRows(t, c))
+	for _, media := range exportState(t, c).Media {
+		assert.Contains(t, media.Title, "(restored)")
+	}
+}
+
+func TestImportStateUnsupportedVersion(t *testing.T) {
+	c := newTestClient(t)
+	err := c.ImportState(context.Background(), bytes.NewReader([]byte(`{"schemaVersion": 99}`)))
+	require.Error(t, err)
+}
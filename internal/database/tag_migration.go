@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"gorm.io/gorm"
+)
+
+// tagMigrationStateID is the ID of the single tag migration state row.
+const tagMigrationStateID = 1
+
+// TagMigrationState records whether the tags of the tag based versions still have to be migrated to the database.
+// It's created together with the media table, so the migration isn't lost if the schema is migrated by the migrate command.
+type TagMigrationState struct {
+	gorm.Model
+	// Pending is set until the first cleanup run migrated the tags.
+	Pending bool `gorm:"not null"`
+}
+
+// TagMigrationDB defines the interface for tag migration database operations.
+type TagMigrationDB interface {
+	CompleteTagMigration(ctx context.Context) error
+}
+
+// CompleteTagMigration records that the tags were migrated to the database.
+func (c *Client) CompleteTagMigration(ctx context.Context) error {
+	state := TagMigrationState{Pending: false}
+	state.ID = tagMigrationStateID
+	if err := c.db.WithContext(ctx).Save(&state).Error; err != nil {
+		log.Error("failed to complete tag migration", "error", err)
+		return err
+	}
+	return nil
+}
+
+// markTagMigrationPending records that the tags still have to be migrated, it's called when the media table is created.
+func markTagMigrationPending(db *gorm.DB) error {
+	state := TagMigrationState{Pending: true}
+	state.ID = tagMigrationStateID
+	if err := db.Save(&state).Error; err != nil {
+		return fmt.Errorf("failed to record pending tag migration: %w", err)
+	}
+	return nil
+}
+
+// tagMigrationPending reports whether the tags still have to be migrated to the database.
+func tagMigrationPending(db *gorm.DB) (bool, error) {
+	var state TagMigrationState
+	if err := db.First(&state, tagMigrationStateID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get tag migration state: %w", err)
+	}
+	return state.Pending, nil
+}
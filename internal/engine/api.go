@@ -2,13 +2,19 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/jon4hz/jellysweep/internal/cache"
+	"github.com/jon4hz/jellysweep/internal/config"
 	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/notify/webhook"
 	"github.com/jon4hz/jellysweep/internal/notify/webpush"
+	"github.com/jon4hz/jellysweep/pkg/jellyseerr"
+	"gorm.io/gorm"
 )
 
 // GetImageCache returns the image cache instance for API access.
@@ -22,53 +28,71 @@ func (e *Engine) GetEngineCache() *cache.EngineCache {
 }
 
 // RequestKeepMedia creates a new keep request for the specified media item in the database and sends a notification to admins.
-// If the user has auto-approval permission, the request is automatically approved.
+// If the user has auto-approval permission or the media belongs to an auto-approve library, the request is automatically approved.
+// Seasons optionally limits the request of a TV series to the given seasons, the remaining seasons are still cleaned up.
 // Returns true if the request was auto-approved, false otherwise.
-func (e *Engine) RequestKeepMedia(ctx context.Context, mediaID uint, userID uint, username string) (bool, error) {
+func (e *Engine) RequestKeepMedia(ctx context.Context, mediaID uint, userID uint, username string, seasons []int32) (bool, error) {
+	e.clientsMu.RLock()
+	defer e.clientsMu.RUnlock()
+
+	if e.MaintenanceMode() {
+		return false, ErrMaintenanceMode
+	}
+
 	// Fetch user from database to get current permissions
 	user, err := e.db.GetUserByID(ctx, userID)
 	if err != nil {
-		log.Error("failed to get user by ID", "userID", userID, "error", err)
+		log.FromContext(ctx).Error("failed to get user by ID", "userID", userID, "error", err)
 		return false, err
 	}
 
 	hasAutoApproval := user.UserPermissions.HasAutoApproval
 
 	// Parse media ID to determine if it's a Sonarr or Radarr item
-	log.Debug("Requesting to keep media", "mediaID", mediaID, "userID", userID, "hasAutoApproval", hasAutoApproval)
+	log.FromContext(ctx).Debug("Requesting to keep media", "mediaID", mediaID, "userID", userID, "hasAutoApproval", hasAutoApproval)
 
 	media, err := e.db.GetMediaItemByID(ctx, mediaID)
 	if err != nil {
-		log.Error("failed to get media item by ID", "mediaID", mediaID, "error", err)
+		log.FromContext(ctx).Error("failed to get media item by ID", "mediaID", mediaID, "error", err)
 		return false, err
 	}
 
 	if media.Unkeepable {
-		log.Warn("Media is marked as unkeepable", "mediaID", mediaID, "type", media.MediaType, "title", media.Title)
+		log.FromContext(ctx).Warn("Media is marked as unkeepable", "mediaID", mediaID, "type", media.MediaType, "title", media.Title)
 		return false, ErrUnkeepableMedia
 	}
 
 	if media.Request.ID != 0 {
-		log.Warn("Media already requested", "mediaID", mediaID, "type", media.MediaType, "title", media.Title)
+		log.FromContext(ctx).Warn("Media already requested", "mediaID", mediaID, "type", media.MediaType, "title", media.Title)
 		return false, ErrRequestAlreadyProcessed
 	}
 
-	_, err = e.db.CreateRequest(ctx, media.ID, userID)
+	if len(seasons) > 0 && media.MediaType != database.MediaTypeTV {
+		log.FromContext(ctx).Warn("Seasons can only be kept for tv series", "mediaID", mediaID, "type", media.MediaType, "title", media.Title)
+		return false, ErrSeasonsNotSupported
+	}
+
+	_, err = e.db.CreateRequest(ctx, media.ID, userID, seasons)
 	if err != nil {
-		log.Error("failed to create keep request in database", "mediaID", media.ID, "error", err)
+		log.FromContext(ctx).Error("failed to create keep request in database", "mediaID", media.ID, "error", err)
 		return false, err
 	}
 
 	// Create history event for request creation
 	if err := e.CreateRequestCreatedEvent(ctx, media, userID); err != nil {
-		log.Error("failed to create request created event", "title", media.Title, "error", err)
+		log.FromContext(ctx).Error("failed to create request created event", "title", media.Title, "error", err)
 	}
 
-	// If user has auto-approval permission, automatically approve the request
-	if hasAutoApproval {
-		log.Info("Auto-approving keep request for user with auto-approval permission", "username", username, "mediaID", mediaID, "title", media.Title)
-		if err := e.HandleKeepRequest(ctx, userID, mediaID, true); err != nil {
-			log.Error("failed to auto-approve request", "mediaID", mediaID, "error", err)
+	// If user has auto-approval permission or the library is trusted, automatically approve the request
+	autoApproveLibrary := e.cfg.IsAutoApproveLibrary(media.LibraryName)
+	if hasAutoApproval || autoApproveLibrary {
+		if hasAutoApproval {
+			log.FromContext(ctx).Info("Auto-approving keep request for user with auto-approval permission", "username", username, "mediaID", mediaID, "title", media.Title)
+		} else {
+			log.FromContext(ctx).Info("Auto-approving keep request for auto-approve library", "username", username, "mediaID", mediaID, "title", media.Title, "library", media.LibraryName)
+		}
+		if err := e.handleKeepRequest(ctx, userID, mediaID, true, nil); err != nil {
+			log.FromContext(ctx).Error("failed to auto-approve request", "mediaID", mediaID, "error", err)
 			return false, err
 		}
 
@@ -76,9 +100,45 @@ func (e *Engine) RequestKeepMedia(ctx context.Context, mediaID uint, userID uint
 	}
 
 	// Send ntfy notification to admins if the request needs manual approval
-	if e.ntfy != nil {
+	if e.ntfy != nil && e.cfg.Ntfy.Events.Includes(config.NotificationEventKeepRequest) {
 		if ntfyErr := e.ntfy.SendKeepRequest(ctx, media.Title, string(media.MediaType), username); ntfyErr != nil {
-			log.Error("failed to send ntfy keep request notification", "error", ntfyErr)
+			log.FromContext(ctx).Error("failed to send ntfy keep request notification", "error", ntfyErr)
+		}
+	}
+
+	// Send gotify notification to admins if the request needs manual approval
+	if e.gotify != nil && e.cfg.Gotify.Events.Includes(config.NotificationEventKeepRequest) {
+		if gotifyErr := e.gotify.SendKeepRequest(ctx, media.Title, string(media.MediaType), username); gotifyErr != nil {
+			log.FromContext(ctx).Error("failed to send gotify keep request notification", "error", gotifyErr)
+		}
+	}
+
+	// Send matrix notification to admins if the request needs manual approval
+	if e.matrix != nil && e.cfg.Matrix.Events.Includes(config.NotificationEventKeepRequest) {
+		if matrixErr := e.matrix.SendKeepRequest(ctx, media.Title, string(media.MediaType), username); matrixErr != nil {
+			log.FromContext(ctx).Error("failed to send matrix keep request notification", "error", matrixErr)
+		}
+	}
+
+	// Send apprise notification to admins if the request needs manual approval
+	if e.apprise != nil && e.cfg.Apprise.Events.Includes(config.NotificationEventKeepRequest) {
+		if appriseErr := e.apprise.SendKeepRequest(ctx, media.Title, string(media.MediaType), username, e.posterOrDefault(media.PosterURL)); appriseErr != nil {
+			log.FromContext(ctx).Error("failed to send apprise keep request notification", "error", appriseErr)
+		}
+	}
+
+	// Send pushover notification to admins if the request needs manual approval
+	if e.pushover != nil && e.cfg.Pushover.Events.Includes(config.NotificationEventKeepRequest) {
+		if pushoverErr := e.pushover.SendKeepRequest(ctx, media.Title, string(media.MediaType), username); pushoverErr != nil {
+			log.FromContext(ctx).Error("failed to send pushover keep request notification", "error", pushoverErr)
+		}
+	}
+
+	// Send webhook notification to admins if the request needs manual approval
+	if e.webhook != nil && e.cfg.Webhook.Events.Includes(config.NotificationEventKeepRequest) {
+		webhookItem := webhook.MediaItem{Title: media.Title, Type: string(media.MediaType), Year: media.Year, Library: media.LibraryName}
+		if webhookErr := e.webhook.SendKeepRequest(ctx, webhookItem, username); webhookErr != nil {
+			log.FromContext(ctx).Error("failed to send webhook keep request notification", "error", webhookErr)
 		}
 	}
 
@@ -86,80 +146,327 @@ func (e *Engine) RequestKeepMedia(ctx context.Context, mediaID uint, userID uint
 }
 
 // HandleKeepRequest accepts or declines a keep request for the specified media item.
-func (e *Engine) HandleKeepRequest(ctx context.Context, userID, mediaID uint, accept bool) error {
+// Seasons optionally overrides the seasons of a TV series that are protected on approval,
+// if nil the seasons of the request are used.
+func (e *Engine) HandleKeepRequest(ctx context.Context, userID, mediaID uint, accept bool, seasons []int32) error {
+	e.clientsMu.RLock()
+	defer e.clientsMu.RUnlock()
+	return e.handleKeepRequest(ctx, userID, mediaID, accept, seasons)
+}
+
+// handleKeepRequest implements HandleKeepRequest, the caller must hold the clients lock.
+func (e *Engine) handleKeepRequest(ctx context.Context, userID, mediaID uint, accept bool, seasons []int32) error {
+	if e.MaintenanceMode() {
+		return ErrMaintenanceMode
+	}
+
 	media, err := e.db.GetMediaItemByID(ctx, mediaID)
 	if err != nil {
-		log.Error("failed to get media item by ID", "mediaID", mediaID, "error", err)
+		log.FromContext(ctx).Error("failed to get media item by ID", "mediaID", mediaID, "error", err)
 		return err
 	}
 
 	if media.Request.ID == 0 {
-		log.Warn("Media has no pending keep request", "mediaID", mediaID, "type", media.MediaType, "title", media.Title)
+		log.FromContext(ctx).Warn("Media has no pending keep request", "mediaID", mediaID, "type", media.MediaType, "title", media.Title)
 		return nil
 	}
 
+	if len(seasons) > 0 && media.MediaType != database.MediaTypeTV {
+		return ErrSeasonsNotSupported
+	}
+	if seasons == nil {
+		seasons = media.Request.KeptSeasons
+	}
+
 	newStatus := database.RequestStatusDenied
 	if accept {
 		newStatus = database.RequestStatusApproved
 	}
 
-	err = e.db.UpdateRequestStatus(ctx, media.Request.ID, newStatus)
+	// only transition if no one else processed the request since it was read
+	err = e.db.UpdateRequestStatus(ctx, media.Request.ID, media.Request.Status, newStatus)
+	if errors.Is(err, database.ErrRequestStatusChanged) {
+		log.FromContext(ctx).Warn("Keep request was processed concurrently", "mediaID", mediaID, "title", media.Title)
+		return ErrRequestAlreadyProcessed
+	}
 	if err != nil {
-		log.Error("failed to update request status in database", "requestID", media.Request.ID, "error", err)
+		log.FromContext(ctx).Error("failed to update request status in database", "requestID", media.Request.ID, "error", err)
 		return err
 	}
 
 	if accept {
 		libraryConfig := e.cfg.GetLibraryConfig(media.LibraryName)
 		if libraryConfig == nil {
-			log.Error("library config not found", "library", media.LibraryName)
+			log.FromContext(ctx).Error("library config not found", "library", media.LibraryName)
 			return fmt.Errorf("library config not found for library: %s", media.LibraryName)
 		}
 
 		protectedUntil := time.Now().Add(time.Hour * 24 * time.Duration(libraryConfig.GetProtectionPeriod()))
-		err = e.db.SetMediaProtectedUntil(ctx, media.ID, &protectedUntil)
+		err = e.db.SetMediaProtectedUntil(ctx, media.ID, &protectedUntil, seasons)
 		if err != nil {
-			log.Error("failed to set media protected until in database", "mediaID", media.ID, "error", err)
+			log.FromContext(ctx).Error("failed to set media protected until in database", "mediaID", media.ID, "error", err)
 			return err
 		}
 
 		// Create history event for request approval and protection
 		if err := e.CreateRequestApprovedEvent(ctx, userID, media); err != nil {
-			log.Error("failed to create request approved event", "title", media.Title, "error", err)
+			log.FromContext(ctx).Error("failed to create request approved event", "title", media.Title, "error", err)
 		}
 
 		if err := e.CreateProtectedEvent(ctx, media); err != nil {
-			log.Error("failed to create protected event", "title", media.Title, "error", err)
+			log.FromContext(ctx).Error("failed to create protected event", "title", media.Title, "error", err)
 		}
+
+		e.resyncJellyseerr(ctx, media)
 	} else {
 		err = e.db.MarkMediaAsUnkeepable(ctx, media.ID)
 		if err != nil {
-			log.Error("failed to mark media as unkeepable in database", "mediaID", media.ID, "error", err)
+			log.FromContext(ctx).Error("failed to mark media as unkeepable in database", "mediaID", media.ID, "error", err)
+			return err
+		}
+
+		if err := e.deleteImmediatelyOnDeny(ctx, media); err != nil {
 			return err
 		}
 
 		// Create history event for request denial
 		if err := e.CreateRequestDeniedEvent(ctx, userID, media); err != nil {
-			log.Error("failed to create request denied event", "title", media.Title, "error", err)
+			log.FromContext(ctx).Error("failed to create request denied event", "title", media.Title, "error", err)
 		}
 	}
 
 	// get user who made the request
 	user, err := e.db.GetUserByID(ctx, media.Request.UserID)
 	if err != nil {
-		log.Error("failed to get user by ID", "userID", media.Request.UserID, "error", err)
+		log.FromContext(ctx).Error("failed to get user by ID", "userID", media.Request.UserID, "error", err)
 		return err
 	}
 
-	if e.webpush != nil && user.Username != "" {
-		if pushErr := e.webpush.SendKeepRequestNotification(ctx, user.Username, media.Title, string(media.MediaType), accept); pushErr != nil {
-			log.Error("failed to send webpush notification", "error", pushErr)
+	if e.webpush != nil && e.cfg.WebPush.Events.Includes(config.NotificationEventKeepRequestDecision) && user.Username != "" {
+		prefs, err := e.db.GetUserNotificationPrefs(ctx, user.ID)
+		if err != nil {
+			log.FromContext(ctx).Error("failed to get notification preferences", "userID", user.ID, "error", err)
+			return nil
+		}
+		if !prefs.WebPushEnabled {
+			log.FromContext(ctx).Debug("User opted out of webpush notifications", "username", user.Username)
+			return nil
+		}
+		if pushErr := e.webpush.SendKeepRequestNotification(ctx, user.Username, media.Title, string(media.MediaType), e.posterOrDefault(media.PosterURL), accept); pushErr != nil {
+			log.FromContext(ctx).Error("failed to send webpush notification", "error", pushErr)
 		}
 	}
 
 	return nil
 }
 
+// KeepRequestResult is the outcome of a single keep request processed by HandleKeepRequests.
+type KeepRequestResult struct {
+	MediaID uint   `json:"mediaId"`
+	Title   string `json:"title,omitempty"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// HandleKeepRequests accepts or declines the keep requests of multiple media items in a single transaction.
+// Items that can't be processed are reported in the results, the remaining items are still processed.
+// Each requester receives a single summarized notification.
+func (e *Engine) HandleKeepRequests(ctx context.Context, userID uint, mediaIDs []uint, accept bool) ([]KeepRequestResult, error) {
+	e.clientsMu.RLock()
+	defer e.clientsMu.RUnlock()
+
+	if e.MaintenanceMode() {
+		return nil, ErrMaintenanceMode
+	}
+	if len(mediaIDs) == 0 {
+		return nil, errors.New("no media IDs provided")
+	}
+
+	results := make([]KeepRequestResult, 0, len(mediaIDs))
+	decisions := make([]database.KeepRequestDecision, 0, len(mediaIDs))
+	mediaItems := make([]*database.Media, 0, len(mediaIDs))
+	seen := make(map[uint]bool, len(mediaIDs))
+
+	for _, mediaID := range mediaIDs {
+		if seen[mediaID] {
+			continue
+		}
+		seen[mediaID] = true
+
+		media, err := e.db.GetMediaItemByID(ctx, mediaID)
+		if err != nil {
+			results = append(results, KeepRequestResult{MediaID: mediaID, Error: err.Error()})
+			continue
+		}
+
+		result := KeepRequestResult{MediaID: mediaID, Title: media.Title}
+		switch {
+		case media.Request.ID == 0:
+			err = ErrNoKeepRequest
+		case media.Unkeepable:
+			err = ErrUnkeepableMedia
+		case media.Request.Status != database.RequestStatusPending:
+			err = ErrRequestAlreadyProcessed
+		}
+
+		decision := database.KeepRequestDecision{
+			MediaID:   media.ID,
+			RequestID: media.Request.ID,
+			Approved:  accept,
+		}
+		if err == nil && accept {
+			libraryConfig := e.cfg.GetLibraryConfig(media.LibraryName)
+			if libraryConfig == nil {
+				err = fmt.Errorf("library config not found for library: %s", media.LibraryName)
+			} else {
+				decision.ProtectedUntil = time.Now().Add(time.Hour * 24 * time.Duration(libraryConfig.GetProtectionPeriod()))
+				decision.KeptSeasons = media.Request.KeptSeasons
+			}
+		}
+		if !accept && e.cfg.DeleteImmediatelyOnDeny {
+			deleteAt := time.Now()
+			decision.DeleteAt = &deleteAt
+		}
+		if err != nil {
+			log.FromContext(ctx).Warn("Skipping keep request", "mediaID", mediaID, "title", media.Title, "error", err)
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		decisions = append(decisions, decision)
+		mediaItems = append(mediaItems, media)
+		results = append(results, result)
+	}
+
+	if len(decisions) == 0 {
+		return results, nil
+	}
+
+	skipped, err := e.db.ApplyKeepRequestDecisions(ctx, decisions)
+	if err != nil {
+		log.FromContext(ctx).Error("failed to apply keep request decisions", "count", len(decisions), "error", err)
+		return nil, err
+	}
+
+	// key: requester user ID, value: titles of the processed media
+	titlesByUser := make(map[uint][]string)
+	processed := 0
+	for _, media := range mediaItems {
+		stale := slices.Contains(skipped, media.ID)
+		for i := range results {
+			if results[i].MediaID != media.ID {
+				continue
+			}
+			if stale {
+				results[i].Error = ErrRequestAlreadyProcessed.Error()
+			} else {
+				results[i].Success = true
+			}
+		}
+		if stale {
+			continue
+		}
+		processed++
+
+		if accept {
+			if err := e.CreateRequestApprovedEvent(ctx, userID, media); err != nil {
+				log.FromContext(ctx).Error("failed to create request approved event", "title", media.Title, "error", err)
+			}
+			if err := e.CreateProtectedEvent(ctx, media); err != nil {
+				log.FromContext(ctx).Error("failed to create protected event", "title", media.Title, "error", err)
+			}
+			e.resyncJellyseerr(ctx, media)
+		} else if err := e.CreateRequestDeniedEvent(ctx, userID, media); err != nil {
+			log.FromContext(ctx).Error("failed to create request denied event", "title", media.Title, "error", err)
+		}
+
+		titlesByUser[media.Request.UserID] = append(titlesByUser[media.Request.UserID], media.Title)
+	}
+
+	log.FromContext(ctx).Info("Processed keep requests", "count", processed, "skipped", len(skipped), "approved", accept)
+
+	e.sendKeepRequestsSummary(ctx, titlesByUser, accept)
+
+	return results, nil
+}
+
+// sendKeepRequestsSummary sends each requester a single notification about their processed keep requests.
+func (e *Engine) sendKeepRequestsSummary(ctx context.Context, titlesByUser map[uint][]string, accept bool) {
+	if e.webpush == nil || !e.cfg.WebPush.Events.Includes(config.NotificationEventKeepRequestDecision) {
+		return
+	}
+
+	for requesterID, titles := range titlesByUser {
+		user, err := e.db.GetUserByID(ctx, requesterID)
+		if err != nil {
+			log.FromContext(ctx).Error("failed to get user by ID", "userID", requesterID, "error", err)
+			continue
+		}
+		if user.Username == "" {
+			continue
+		}
+
+		prefs, err := e.db.GetUserNotificationPrefs(ctx, user.ID)
+		if err != nil {
+			log.FromContext(ctx).Error("failed to get notification preferences", "userID", user.ID, "error", err)
+			continue
+		}
+		if !prefs.WebPushEnabled {
+			log.FromContext(ctx).Debug("User opted out of webpush notifications", "username", user.Username)
+			continue
+		}
+
+		if err := e.webpush.SendKeepRequestsSummaryNotification(ctx, user.Username, titles, accept); err != nil {
+			log.FromContext(ctx).Error("failed to send webpush notification", "error", err)
+		}
+	}
+}
+
+// resyncJellyseerr marks kept media as available in Jellyseerr again, so it doesn't show up as deletable there.
+// Errors are only logged since the keep request was already approved.
+func (e *Engine) resyncJellyseerr(ctx context.Context, media *database.Media) {
+	if e.jellyseerr == nil || !e.cfg.ResyncJellyseerrOnKeep {
+		return
+	}
+	if media.TmdbId == nil {
+		log.FromContext(ctx).Debug("Media has no TMDB ID, skipping jellyseerr resync", "title", media.Title)
+		return
+	}
+
+	if err := e.jellyseerr.UpdateMediaStatus(ctx, *media.TmdbId, string(media.MediaType), jellyseerr.MediaStatusAvailable); err != nil {
+		if errors.Is(err, jellyseerr.ErrMediaNotFound) {
+			log.FromContext(ctx).Debug("Media not found in jellyseerr, skipping resync", "title", media.Title)
+			return
+		}
+		log.FromContext(ctx).Error("failed to resync media in jellyseerr", "title", media.Title, "error", err)
+		return
+	}
+	log.FromContext(ctx).Info("Marked kept media as available in jellyseerr", "title", media.Title)
+}
+
+// deleteJellyseerrRequest removes a deleted media item and its requests from Jellyseerr.
+// Errors are only logged since the media itself was already deleted.
+func (e *Engine) deleteJellyseerrRequest(ctx context.Context, media database.Media) {
+	if e.jellyseerr == nil || !e.cfg.DeleteJellyseerrRequestOnCleanup {
+		return
+	}
+	if media.TmdbId == nil || *media.TmdbId == 0 {
+		log.FromContext(ctx).Debug("Media has no TMDB ID, skipping jellyseerr request cleanup", "title", media.Title)
+		return
+	}
+
+	if err := e.jellyseerr.DeleteMediaRequest(ctx, *media.TmdbId, string(media.MediaType)); err != nil {
+		if errors.Is(err, jellyseerr.ErrMediaNotFound) {
+			log.FromContext(ctx).Debug("Media not found in jellyseerr, skipping request cleanup", "title", media.Title)
+			return
+		}
+		log.FromContext(ctx).Error("failed to delete jellyseerr request", "title", media.Title, "error", err)
+		return
+	}
+	log.FromContext(ctx).Info("Deleted jellyseerr request of deleted media", "title", media.Title)
+}
+
 // GetWebPushClient returns the webpush client.
 func (e *Engine) GetWebPushClient() *webpush.Client {
 	return e.webpush
@@ -170,26 +477,65 @@ func (e *Engine) addIgnoreTag(ctx context.Context, media *database.Media) error
 	switch media.MediaType {
 	case database.MediaTypeMovie:
 		if e.radarr == nil {
-			log.Warn("Radarr client not available, cannot add ignore tag", "mediaID", media.ID, "title", media.Title)
+			log.FromContext(ctx).Warn("Radarr client not available, cannot add ignore tag", "mediaID", media.ID, "title", media.Title)
 			return fmt.Errorf("radarr client not available")
 		}
 		if err := e.radarr.ResetAllTagsAndAddIgnore(ctx, media.ArrID); err != nil {
-			log.Error("Failed to add ignore tag in radarr", "mediaID", media.ID, "title", media.Title, "error", err)
+			log.FromContext(ctx).Error("Failed to add ignore tag in radarr", "mediaID", media.ID, "title", media.Title, "error", err)
 			return err
 		}
 	case database.MediaTypeTV:
 		if e.sonarr == nil {
-			log.Warn("Sonarr client not available, cannot add ignore tag", "mediaID", media.ID, "title", media.Title)
+			log.FromContext(ctx).Warn("Sonarr client not available, cannot add ignore tag", "mediaID", media.ID, "title", media.Title)
 			return fmt.Errorf("sonarr client not available")
 		}
 		if err := e.sonarr.ResetAllTagsAndAddIgnore(ctx, media.ArrID); err != nil {
-			log.Error("Failed to add ignore tag in sonarr", "mediaID", media.ID, "title", media.Title, "error", err)
+			log.FromContext(ctx).Error("Failed to add ignore tag in sonarr", "mediaID", media.ID, "title", media.Title, "error", err)
+			return err
+		}
+	case database.MediaTypeBook:
+		if e.readarr == nil {
+			log.FromContext(ctx).Warn("Readarr client not available, cannot add ignore tag", "mediaID", media.ID, "title", media.Title)
+			return fmt.Errorf("readarr client not available")
+		}
+		if err := e.readarr.ResetAllTagsAndAddIgnore(ctx, media.ArrID); err != nil {
+			log.FromContext(ctx).Error("Failed to add ignore tag in readarr", "mediaID", media.ID, "title", media.Title, "error", err)
 			return err
 		}
 	default:
 		return fmt.Errorf("unsupported media type: %s", media.MediaType)
 	}
 
+	if _, err := e.db.SetMediaIgnored(ctx, media.ID, true); err != nil {
+		log.FromContext(ctx).Error("Failed to set ignored flag", "mediaID", media.ID, "title", media.Title, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// SetMediaIgnored sets the permanent-ignore flag of a media item.
+// Ignored media is never picked up for deletion again, independent of the arr ignore tag.
+// If the media is currently marked for deletion, it is removed from the database.
+func (e *Engine) SetMediaIgnored(ctx context.Context, mediaID uint, ignored bool) error {
+	if e.MaintenanceMode() {
+		return ErrMaintenanceMode
+	}
+
+	media, err := e.db.SetMediaIgnored(ctx, mediaID, ignored)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	if ignored && !media.DeletedAt.Valid {
+		media.DBDeleteReason = database.DBDeleteReasonIgnored
+		if err := e.db.DeleteMediaItem(ctx, media); err != nil {
+			log.FromContext(ctx).Error("Failed to delete media item", "mediaID", mediaID, "error", err)
+			return fmt.Errorf("database error: %w", err)
+		}
+	}
+
+	log.FromContext(ctx).Info("Updated permanent ignore", "mediaID", mediaID, "title", media.Title, "ignored", ignored)
 	return nil
 }
 
@@ -210,26 +556,30 @@ func (e *Engine) GetMediaItemsByMediaType(ctx context.Context, mediaType databas
 
 // MarkMediaAsProtected marks a media item as protected for the configured duration.
 func (e *Engine) MarkMediaAsProtected(ctx context.Context, mediaID uint, adminID uint) error {
+	if e.MaintenanceMode() {
+		return ErrMaintenanceMode
+	}
+
 	media, err := e.db.GetMediaItemByID(ctx, mediaID)
 	if err != nil {
-		log.Error("Failed to get media item by ID", "mediaID", mediaID, "error", err)
+		log.FromContext(ctx).Error("Failed to get media item by ID", "mediaID", mediaID, "error", err)
 		return fmt.Errorf("database error: %w", err)
 	}
 
 	libraryConfig := e.cfg.GetLibraryConfig(media.LibraryName)
 	if libraryConfig == nil {
-		log.Error("No library configuration found", "library", media.LibraryName)
+		log.FromContext(ctx).Error("No library configuration found", "library", media.LibraryName)
 		return fmt.Errorf("no library configuration found")
 	}
 
 	protectedUntil := time.Now().Add(time.Hour * 24 * time.Duration(libraryConfig.GetProtectionPeriod()))
-	if err := e.db.SetMediaProtectedUntil(ctx, media.ID, &protectedUntil); err != nil {
-		log.Error("Failed to set media protected until", "mediaID", mediaID, "error", err)
+	if err := e.db.SetMediaProtectedUntil(ctx, media.ID, &protectedUntil, nil); err != nil {
+		log.FromContext(ctx).Error("Failed to set media protected until", "mediaID", mediaID, "error", err)
 		return fmt.Errorf("failed to set media protected until: %w", err)
 	}
 
 	if err := e.CreateAdminKeepEvent(ctx, adminID, media); err != nil {
-		log.Error("Failed to create admin keep event", "mediaID", mediaID, "error", err)
+		log.FromContext(ctx).Error("Failed to create admin keep event", "mediaID", mediaID, "error", err)
 		return fmt.Errorf("failed to create admin keep event: %w", err)
 	}
 
@@ -238,19 +588,33 @@ func (e *Engine) MarkMediaAsProtected(ctx context.Context, mediaID uint, adminID
 
 // MarkMediaAsUnkeepable marks a media item as unkeepable and denies all keep requests.
 func (e *Engine) MarkMediaAsUnkeepable(ctx context.Context, mediaID uint, adminID uint) error {
+	if e.MaintenanceMode() {
+		return ErrMaintenanceMode
+	}
+
 	media, err := e.db.GetMediaItemByID(ctx, mediaID)
 	if err != nil {
-		log.Error("Failed to get media item by ID", "mediaID", mediaID, "error", err)
+		log.FromContext(ctx).Error("Failed to get media item by ID", "mediaID", mediaID, "error", err)
 		return fmt.Errorf("database error: %w", err)
 	}
 
 	if err := e.db.MarkMediaAsUnkeepable(ctx, media.ID); err != nil {
-		log.Error("Failed to mark media as unkeepable", "mediaID", mediaID, "error", err)
+		log.FromContext(ctx).Error("Failed to mark media as unkeepable", "mediaID", mediaID, "error", err)
 		return fmt.Errorf("failed to mark media as unkeepable: %w", err)
 	}
 
+	if err := e.deleteImmediatelyOnDeny(ctx, media); err != nil {
+		return err
+	}
+
+	// marking the item again also retries a deletion that was given up
+	if err := e.db.DeleteDeletionFailure(ctx, media.ID); err != nil {
+		log.FromContext(ctx).Error("Failed to reset deletion failure", "mediaID", mediaID, "error", err)
+		return fmt.Errorf("database error: %w", err)
+	}
+
 	if err := e.CreateAdminUnkeepEvent(ctx, adminID, media); err != nil {
-		log.Error("Failed to create admin unkeep event", "mediaID", mediaID, "error", err)
+		log.FromContext(ctx).Error("Failed to create admin unkeep event", "mediaID", mediaID, "error", err)
 		return fmt.Errorf("failed to create admin unkeep event: %w", err)
 	}
 
@@ -259,25 +623,32 @@ func (e *Engine) MarkMediaAsUnkeepable(ctx context.Context, mediaID uint, adminI
 
 // MarkMediaAsKeepForever removes the media item from the database and adds an ignore tag.
 func (e *Engine) MarkMediaAsKeepForever(ctx context.Context, mediaID uint, adminID uint) error {
+	if e.MaintenanceMode() {
+		return ErrMaintenanceMode
+	}
+
+	e.clientsMu.RLock()
+	defer e.clientsMu.RUnlock()
+
 	media, err := e.db.GetMediaItemByID(ctx, mediaID)
 	if err != nil {
-		log.Error("Failed to get media item by ID", "mediaID", mediaID, "error", err)
+		log.FromContext(ctx).Error("Failed to get media item by ID", "mediaID", mediaID, "error", err)
 		return fmt.Errorf("database error: %w", err)
 	}
 
 	if err := e.addIgnoreTag(ctx, media); err != nil {
-		log.Error("Failed to add ignore tag", "mediaID", mediaID, "error", err)
+		log.FromContext(ctx).Error("Failed to add ignore tag", "mediaID", mediaID, "error", err)
 		return fmt.Errorf("engine error: %w", err)
 	}
 
 	media.DBDeleteReason = database.DBDeleteReasonKeepForever
 	if err := e.db.DeleteMediaItem(ctx, media); err != nil {
-		log.Error("Failed to delete media item", "mediaID", mediaID, "error", err)
+		log.FromContext(ctx).Error("Failed to delete media item", "mediaID", mediaID, "error", err)
 		return fmt.Errorf("database error: %w", err)
 	}
 
 	if err := e.CreateKeepForeverEvent(ctx, adminID, media); err != nil {
-		log.Error("Failed to create keep forever event", "mediaID", mediaID, "error", err)
+		log.FromContext(ctx).Error("Failed to create keep forever event", "mediaID", mediaID, "error", err)
 		return fmt.Errorf("database error: %w", err)
 	}
 
@@ -304,3 +675,56 @@ func (e *Engine) GetAllUsers(ctx context.Context) ([]database.User, error) {
 func (e *Engine) UpdateUserAutoApproval(ctx context.Context, userID uint, hasAutoApproval bool) error {
 	return e.db.UpdateUserAutoApproval(ctx, userID, hasAutoApproval)
 }
+
+// GetCleanupRunHistory returns the cleanup runs started after since.
+func (e *Engine) GetCleanupRunHistory(ctx context.Context, limit, offset int, since time.Time) ([]database.CleanupRun, int64, error) {
+	return e.db.GetCleanupRunHistory(ctx, limit, offset, since)
+}
+
+// GetCleanupRun returns a single cleanup run.
+func (e *Engine) GetCleanupRun(ctx context.Context, id uint) (*database.CleanupRun, error) {
+	run, err := e.db.GetCleanupRun(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCleanupRunNotFound
+	}
+	return run, err
+}
+
+// GetCleanupRunSteps returns the steps of a cleanup run.
+func (e *Engine) GetCleanupRunSteps(ctx context.Context, runID uint) ([]database.CleanupRunStep, error) {
+	return e.db.GetCleanupRunSteps(ctx, runID)
+}
+
+// GetMediaDeletionHistory returns the media items deleted after since.
+func (e *Engine) GetMediaDeletionHistory(ctx context.Context, limit, offset int, since time.Time) ([]database.Media, int64, error) {
+	return e.db.GetMediaDeletionHistory(ctx, limit, offset, since)
+}
+
+// GetCleanupStats returns aggregated statistics of the cleanup runs started after since.
+func (e *Engine) GetCleanupStats(ctx context.Context, since time.Time) (*database.CleanupStats, error) {
+	return e.db.GetCleanupStats(ctx, since)
+}
+
+// GetUserNotificationPrefs returns the notification preferences of a user.
+func (e *Engine) GetUserNotificationPrefs(ctx context.Context, userID uint) (*database.UserNotificationPrefs, error) {
+	return e.db.GetUserNotificationPrefs(ctx, userID)
+}
+
+// SetUserNotificationPrefs stores the notification preferences of a user.
+func (e *Engine) SetUserNotificationPrefs(ctx context.Context, prefs *database.UserNotificationPrefs) error {
+	return e.db.SetUserNotificationPrefs(ctx, prefs)
+}
+
+// deleteImmediatelyOnDeny moves the default deletion date of denied media to now if delete_immediately_on_deny is set,
+// so the next cleanup run deletes it instead of waiting for the cleanup delay.
+func (e *Engine) deleteImmediatelyOnDeny(ctx context.Context, media *database.Media) error {
+	if !e.cfg.DeleteImmediatelyOnDeny {
+		return nil
+	}
+	if err := e.db.SetMediaDefaultDeleteAt(ctx, media.ID, time.Now()); err != nil {
+		log.FromContext(ctx).Error("failed to schedule immediate deletion", "mediaID", media.ID, "error", err)
+		return fmt.Errorf("failed to schedule immediate deletion: %w", err)
+	}
+	log.FromContext(ctx).Info("Scheduled denied media for deletion in the next cleanup run", "title", media.Title)
+	return nil
+}
@@ -8,22 +8,36 @@ import (
 	"github.com/devopsarr/radarr-go/radarr"
 	"github.com/devopsarr/sonarr-go/sonarr"
 	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/pkg/readarr"
 	jellyfin "github.com/sj14/jellyfin-go/api"
 )
 
 type MediaItem struct {
+	// JellyfinID is the item ID in the configured media server, i.e. the Emby item ID or the Plex rating key if those are used instead of Jellyfin.
 	JellyfinID     string
 	LibraryName    string // Jellyfin library name this item belongs to
 	SeriesResource sonarr.SeriesResource
 	MovieResource  radarr.MovieResource
+	BookResource   readarr.Book
 	Title          string
 	TmdbId         int32
 	TvdbId         int32
 	Year           int32
+	FileSize       int64 // Size on disk in bytes
 	Tags           []string
 	MediaType      models.MediaType
 	// User information for the person who requested this media
 	RequestedBy string // User email or username
+	// Requesters are all distinct Jellyseerr users that requested this media
+	Requesters []string
+	// RequestedAt is the time of the Jellyseerr request, nil if unknown
+	RequestedAt *time.Time
+	// Metadata from TMDB, only populated if TMDB is configured
+	Overview  string
+	Genres    []string
+	PosterURL string
+	// DeletionReasons are added by the filters that found the item eligible for deletion, e.g. "never played"
+	DeletionReasons []string
 }
 
 type Arrer interface {
@@ -38,6 +52,34 @@ type Arrer interface {
 
 	// History methods for getting import dates
 	GetItemAddedDate(ctx context.Context, itemID int32, since time.Time) (*time.Time, error)
+
+	// HasExternalSubtitles reports whether the item has external (non-embedded) subtitle files.
+	HasExternalSubtitles(ctx context.Context, itemID int32) (bool, error)
+
+	// GetDownloadingIDs returns the IDs of the items with a download or import in progress in the queue.
+	GetDownloadingIDs(ctx context.Context) (map[int32]bool, error)
+}
+
+// TaggedItem is an arr item carrying jellysweep tags.
+type TaggedItem struct {
+	ID    int32
+	Title string
+	Tags  []string
+}
+
+// TagCleaner is implemented by arrs that tag single media items, so stray jellysweep tags can be found and removed per item.
+type TagCleaner interface {
+	// GetTaggedItems returns all items with jellysweep tags other than the ignore tag.
+	GetTaggedItems(ctx context.Context) ([]TaggedItem, error)
+	// RemoveJellysweepTags removes the jellysweep tags from a single item, keeping the ignore tag.
+	RemoveJellysweepTags(ctx context.Context, id int32) error
+}
+
+// SeasonDeleter is implemented by arrs that can delete single seasons of a series.
+type SeasonDeleter interface {
+	// DeleteSeasonsExcept deletes the files of all seasons except the kept ones and unmonitors them.
+	// Specials (season 0) are always kept. It returns the size of the deleted files in bytes.
+	DeleteSeasonsExcept(ctx context.Context, seriesID int32, title string, keep []int32) (int64, error)
 }
 
 type JellyfinItem struct {
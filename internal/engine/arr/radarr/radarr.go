@@ -3,7 +3,6 @@ package radarr
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"slices"
 	"strconv"
 	"strings"
@@ -22,7 +21,10 @@ import (
 	jellyfin "github.com/sj14/jellyfin-go/api"
 )
 
-var _ arr.Arrer = (*Radarr)(nil)
+var (
+	_ arr.Arrer      = (*Radarr)(nil)
+	_ arr.TagCleaner = (*Radarr)(nil)
+)
 
 type Radarr struct {
 	client    *radarrAPI.APIClient
@@ -51,7 +53,7 @@ func NewRadarr(cfg *config.Config, stats stats.Statser, tagsCache *cache.Prefixe
 			URL: cfg.Radarr.URL,
 		},
 	}
-	rcfg.HTTPClient = &http.Client{Timeout: config.TimeoutDuration(cfg.Radarr.Timeout)}
+	rcfg.HTTPClient = config.NewRetryHTTPClient(cfg.Radarr.Timeout, cfg.Proxy, cfg.Radarr.Proxy, cfg.Retry)
 	rcfg.UserAgent = fmt.Sprintf("Jellysweep/%s", version.Version)
 	client := radarrAPI.NewAPIClient(rcfg)
 
@@ -91,12 +93,6 @@ func (r *Radarr) GetItems(ctx context.Context, jellyfinItems []arr.JellyfinItem)
 
 	mediaItems := make([]arr.MediaItem, 0)
 	for _, jf := range jellyfinItems {
-		libraryName := jf.ParentLibraryName
-		if libraryName == "" {
-			log.Error("Library name is empty for Jellyfin item, skipping", "item_id", jf.GetId(), "item_name", jf.GetName())
-			continue
-		}
-
 		if jf.GetType() != jellyfin.BASEITEMKIND_MOVIE {
 			continue
 		}
@@ -135,6 +131,15 @@ func (r *Radarr) GetItems(ctx context.Context, jellyfinItems []arr.JellyfinItem)
 			continue
 		}
 
+		libraryName := jf.ParentLibraryName
+		if libraryName == "" {
+			libraryName = r.cfg.LibraryForPath(mr.GetPath())
+		}
+		if libraryName == "" {
+			log.Error("Library name is empty for Jellyfin item, skipping", "item_id", jf.GetId(), "item_name", jf.GetName())
+			continue
+		}
+
 		mediaItems = append(mediaItems, arr.MediaItem{
 			JellyfinID:    jf.GetId(),
 			LibraryName:   libraryName,
@@ -300,6 +305,67 @@ func (r *Radarr) ResetTags(ctx context.Context, additionalTags []string) error {
 	return nil
 }
 
+// GetTaggedItems returns all movies with jellysweep tags other than the ignore tag.
+func (r *Radarr) GetTaggedItems(ctx context.Context) ([]arr.TaggedItem, error) {
+	movies, err := r.getItems(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list radarr movies: %w", err)
+	}
+
+	tagMap, err := r.getTags(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Radarr tags: %w", err)
+	}
+
+	var items []arr.TaggedItem
+	for _, m := range movies {
+		var jellysweepTags []string
+		for _, id := range m.GetTags() {
+			if name := tagMap[id]; tags.IsJellysweepTagWithoutIgnore(name) {
+				jellysweepTags = append(jellysweepTags, name)
+			}
+		}
+		if len(jellysweepTags) > 0 {
+			items = append(items, arr.TaggedItem{ID: m.GetId(), Title: m.GetTitle(), Tags: jellysweepTags})
+		}
+	}
+	return items, nil
+}
+
+// RemoveJellysweepTags removes the jellysweep tags from a single movie, keeping the ignore tag.
+func (r *Radarr) RemoveJellysweepTags(ctx context.Context, id int32) error {
+	movie, getResp, err := r.client.MovieAPI.GetMovieById(r.radarrAuthCtx(ctx), id).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to get radarr movie: %w", err)
+	}
+	defer getResp.Body.Close() //nolint: errcheck
+
+	tagMap, err := r.getTags(ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to get radarr tags: %w", err)
+	}
+
+	newTags := make([]int32, 0)
+	for _, tid := range movie.GetTags() {
+		if tags.IsJellysweepTagWithoutIgnore(tagMap[tid]) {
+			continue
+		}
+		newTags = append(newTags, tid)
+	}
+
+	movie.Tags = newTags
+	_, resp, err := r.client.MovieAPI.UpdateMovie(r.radarrAuthCtx(ctx), fmt.Sprintf("%d", id)).
+		MovieResource(*movie).
+		Execute()
+	if err != nil {
+		return fmt.Errorf("failed to update radarr movie: %w", err)
+	}
+	defer resp.Body.Close() //nolint: errcheck
+
+	log.Info("removed jellysweep tags from Radarr movie", "title", movie.GetTitle())
+	return nil
+}
+
 func (r *Radarr) CleanupAllTags(ctx context.Context, additionalTags []string) error {
 	tagsList, resp, err := r.client.TagDetailsAPI.ListTagDetail(r.radarrAuthCtx(ctx)).Execute()
 	if err != nil {
@@ -445,3 +511,76 @@ func (r *Radarr) GetItemAddedDate(ctx context.Context, movieID int32, since time
 
 	return earliestTime, nil
 }
+
+// HasExternalSubtitles reports whether the movie has external subtitle files next to its media file.
+func (r *Radarr) HasExternalSubtitles(ctx context.Context, movieID int32) (bool, error) {
+	extraFiles, resp, err := r.client.ExtraFileAPI.ListExtraFile(r.radarrAuthCtx(ctx)).
+		MovieId(movieID).
+		Execute()
+	if err != nil {
+		return false, fmt.Errorf("failed to list radarr extra files: %w", err)
+	}
+	defer resp.Body.Close() //nolint: errcheck
+
+	for _, extraFile := range extraFiles {
+		if extraFile.GetType() == radarrAPI.EXTRAFILETYPE_SUBTITLE {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetDownloadingIDs returns the IDs of the movies with a download or import in progress in the Radarr queue.
+func (r *Radarr) GetDownloadingIDs(ctx context.Context) (map[int32]bool, error) {
+	ids := make(map[int32]bool)
+	page := int32(1)
+	pageSize := int32(250)
+	var seen int
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		queue, resp, err := r.client.QueueAPI.GetQueue(r.radarrAuthCtx(ctx)).
+			Page(page).
+			PageSize(pageSize).
+			Execute()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get radarr queue: %w", err)
+		}
+		_ = resp.Body.Close()
+
+		records := queue.GetRecords()
+		for _, record := range records {
+			switch record.GetTrackedDownloadState() {
+			case radarrAPI.TRACKEDDOWNLOADSTATE_DOWNLOADING,
+				radarrAPI.TRACKEDDOWNLOADSTATE_IMPORT_PENDING,
+				radarrAPI.TRACKEDDOWNLOADSTATE_IMPORTING:
+				if id := record.GetMovieId(); id != 0 {
+					ids[id] = true
+				}
+			}
+		}
+
+		seen += len(records)
+		if len(records) == 0 || seen >= int(queue.GetTotalRecords()) {
+			break
+		}
+		page++
+	}
+
+	return ids, nil
+}
+
+// Ping checks whether Radarr is reachable by requesting its system status.
+func (r *Radarr) Ping(ctx context.Context) error {
+	_, resp, err := r.client.SystemAPI.GetSystemStatus(r.radarrAuthCtx(ctx)).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to get radarr system status: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	return nil
+}
@@ -0,0 +1,383 @@
+package readarr
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/cache"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/tags"
+	readarrAPI "github.com/jon4hz/jellysweep/pkg/readarr"
+	"github.com/samber/lo"
+	jellyfin "github.com/sj14/jellyfin-go/api"
+)
+
+var _ arr.Arrer = (*Readarr)(nil)
+
+// Readarr implements arr.Arrer for books managed by Readarr.
+// Readarr only supports tags on authors, so the tags of a book are the tags of its author.
+type Readarr struct {
+	client    *readarrAPI.Client
+	cfg       *config.Config
+	tagsCache *cache.PrefixedCache[cache.TagMap]
+}
+
+func NewReadarr(cfg *config.Config, tagsCache *cache.PrefixedCache[cache.TagMap]) *Readarr {
+	return &Readarr{
+		client:    readarrAPI.New(cfg.Readarr, cfg.Proxy, cfg.Retry),
+		cfg:       cfg,
+		tagsCache: tagsCache,
+	}
+}
+
+// bookKey returns the key used to match books by title and release year.
+func bookKey(title string, year int32) string {
+	return fmt.Sprintf("%s|%d", strings.ToLower(strings.TrimSpace(title)), year)
+}
+
+// GetItems merges Jellyfin books and audiobooks with Readarr books into library-grouped MediaItems.
+func (r *Readarr) GetItems(ctx context.Context, jellyfinItems []arr.JellyfinItem) ([]arr.MediaItem, error) {
+	tagMap, err := r.getTags(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Readarr tags: %w", err)
+	}
+
+	books, err := r.client.ListBooks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Readarr books: %w", err)
+	}
+
+	authors, err := r.client.ListAuthors(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Readarr authors: %w", err)
+	}
+	authorTags := make(map[int32][]int32, len(authors))
+	authorPaths := make(map[int32]string, len(authors))
+	for _, a := range authors {
+		authorTags[a.ID] = a.Tags
+		authorPaths[a.ID] = a.Path
+	}
+
+	// Index books by title+year (primary) and title (fallback).
+	// Books without files are skipped, there is nothing to clean up.
+	byTitleYear := make(map[string]readarrAPI.Book)
+	byTitle := make(map[string]readarrAPI.Book)
+	for _, b := range books {
+		if b.Statistics == nil || b.Statistics.BookFileCount == 0 {
+			continue
+		}
+		byTitleYear[bookKey(b.Title, b.Year())] = b
+		byTitle[strings.ToLower(strings.TrimSpace(b.Title))] = b
+	}
+
+	mediaItems := make([]arr.MediaItem, 0)
+	for _, jf := range jellyfinItems {
+		if jf.GetType() != jellyfin.BASEITEMKIND_BOOK && jf.GetType() != jellyfin.BASEITEMKIND_AUDIO_BOOK {
+			continue
+		}
+
+		book, matched := byTitleYear[bookKey(jf.GetName(), jf.GetProductionYear())]
+		if matched {
+			log.Debug("Matched Readarr book by title+year", "title", jf.GetName(), "year", jf.GetProductionYear())
+		} else if book, matched = byTitle[strings.ToLower(strings.TrimSpace(jf.GetName()))]; matched {
+			log.Debug("Matched Readarr book by title", "title", jf.GetName())
+		}
+
+		if !matched {
+			log.Warn("No matching Readarr book found for Jellyfin item, skipping", "title", jf.GetName(), "year", jf.GetProductionYear())
+			continue
+		}
+
+		libraryName := jf.ParentLibraryName
+		if libraryName == "" {
+			libraryName = r.cfg.LibraryForPath(authorPaths[book.AuthorID])
+		}
+		if libraryName == "" {
+			log.Error("Library name is empty for Jellyfin item, skipping", "item_id", jf.GetId(), "item_name", jf.GetName())
+			continue
+		}
+
+		mediaItems = append(mediaItems, arr.MediaItem{
+			JellyfinID:   jf.GetId(),
+			LibraryName:  libraryName,
+			BookResource: book,
+			Title:        book.Title,
+			Year:         book.Year(),
+			FileSize:     book.SizeOnDisk(),
+			Tags:         lo.Map(authorTags[book.AuthorID], func(tag int32, _ int) string { return tagMap[tag] }),
+			MediaType:    models.MediaTypeBook,
+		})
+	}
+
+	log.Info("Merged jellyfin items with readarr books", "mediaCount", len(mediaItems), "jellyfinCount", len(jellyfinItems))
+	return mediaItems, nil
+}
+
+func (r *Readarr) getTags(ctx context.Context, forceRefresh bool) (cache.TagMap, error) {
+	if forceRefresh {
+		if err := r.tagsCache.Clear(ctx); err != nil {
+			log.Debug("Failed to clear Readarr tags cache, fetching from API", "error", err)
+		}
+	}
+
+	cachedTags, err := r.tagsCache.Get(ctx, "all")
+	if err != nil {
+		log.Debug("Failed to get Readarr tags from cache, fetching from API", "error", err)
+	}
+	if len(cachedTags) != 0 && !forceRefresh {
+		return cachedTags, nil
+	}
+
+	tagList, err := r.client.ListTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tagMap := make(cache.TagMap)
+	for _, t := range tagList {
+		tagMap[t.ID] = t.Label
+	}
+	if err := r.tagsCache.Set(ctx, "all", tagMap); err != nil {
+		log.Warn("failed to cache Readarr tags", "error", err)
+	}
+
+	return tagMap, nil
+}
+
+// ensureTag returns the ID of the tag with the given label, creating it if it doesn't exist.
+func (r *Readarr) ensureTag(ctx context.Context, label string) (int32, error) {
+	tagMap, err := r.getTags(ctx, false)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get readarr tags: %w", err)
+	}
+
+	for id, tag := range tagMap {
+		if tag == label {
+			return id, nil
+		}
+	}
+
+	newTag, err := r.client.CreateTag(ctx, label)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create Readarr tag %s: %w", label, err)
+	}
+	log.Info("created Readarr tag", "label", label)
+
+	tagMap[newTag.ID] = newTag.Label
+	if err := r.tagsCache.Set(ctx, "all", tagMap); err != nil {
+		log.Warn("failed to cache new Readarr tag", "label", label, "error", err)
+	}
+	return newTag.ID, nil
+}
+
+func (r *Readarr) DeleteMedia(ctx context.Context, bookID int32, title string) error {
+	if r.cfg.DryRun {
+		log.Info("dry run: would delete Readarr book", "title", title)
+		return nil
+	}
+
+	if err := r.client.DeleteBook(ctx, bookID); err != nil {
+		return fmt.Errorf("failed to delete Readarr book %s: %w", title, err)
+	}
+
+	log.Info("deleted Readarr book", "title", title)
+	return nil
+}
+
+func (r *Readarr) ResetTags(ctx context.Context, additionalTags []string) error {
+	authors, err := r.client.ListAuthors(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list readarr authors: %w", err)
+	}
+
+	tagMap, err := r.getTags(ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to get Readarr tags: %w", err)
+	}
+
+	updated := 0
+	for _, a := range authors {
+		hasJellysweepTags := false
+		newTags := make([]int32, 0)
+
+		for _, id := range a.Tags {
+			name := tagMap[id]
+			if tags.IsJellysweepOrAdditionalTag(name, additionalTags) {
+				hasJellysweepTags = true
+				log.Debug("removing jellysweep tag from Readarr author", "tag", name, "author", a.AuthorName)
+			} else {
+				newTags = append(newTags, id)
+			}
+		}
+
+		if hasJellysweepTags {
+			if err := r.client.SetAuthorTags(ctx, a.ID, newTags); err != nil {
+				log.Error("failed to update Readarr author", "author", a.AuthorName, "error", err)
+				continue
+			}
+			log.Info("removed jellysweep tags from Readarr author", "author", a.AuthorName)
+			updated++
+		}
+	}
+
+	log.Info("updated Readarr authors", "count", updated)
+	return nil
+}
+
+func (r *Readarr) CleanupAllTags(ctx context.Context, additionalTags []string) error {
+	tagList, err := r.client.ListTags(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list Readarr tags: %w", err)
+	}
+
+	deleted := 0
+	for _, t := range tagList {
+		if tags.IsJellysweepOrAdditionalTag(t.Label, additionalTags) {
+			if err := r.client.DeleteTag(ctx, t.ID); err != nil {
+				log.Error("failed to delete Readarr tag", "tag", t.Label, "error", err)
+				continue
+			}
+			log.Info("deleted Readarr tag", "tag", t.Label)
+			deleted++
+		}
+	}
+
+	if deleted > 0 {
+		if err := r.tagsCache.Clear(ctx); err != nil {
+			log.Warn("failed to clear Readarr tags cache", "error", err)
+		}
+	}
+
+	log.Info("deleted Readarr tags", "count", deleted)
+	return nil
+}
+
+// ResetAllTagsAndAddIgnore replaces the jellysweep tags of the book's author with the ignore tag.
+// Since Readarr tags authors, this protects all books of the author.
+func (r *Readarr) ResetAllTagsAndAddIgnore(ctx context.Context, bookID int32) error {
+	book, err := r.client.GetBook(ctx, bookID)
+	if err != nil {
+		return fmt.Errorf("failed to get readarr book: %w", err)
+	}
+
+	authors, err := r.client.ListAuthors(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list readarr authors: %w", err)
+	}
+	author, found := lo.Find(authors, func(a readarrAPI.Author) bool { return a.ID == book.AuthorID })
+	if !found {
+		return fmt.Errorf("readarr author %d of book %s not found", book.AuthorID, book.Title)
+	}
+
+	ignoreID, err := r.ensureTag(ctx, tags.JellysweepIgnoreTag)
+	if err != nil {
+		return fmt.Errorf("failed to create ignore tag: %w", err)
+	}
+
+	tagMap, err := r.getTags(ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to get readarr tags: %w", err)
+	}
+
+	newTags := make([]int32, 0)
+	for _, tid := range author.Tags {
+		name := tagMap[tid]
+		if tags.IsJellysweepTag(name) {
+			log.Debug("removing jellysweep tag from Readarr author", "tag", name, "author", author.AuthorName)
+		} else {
+			newTags = append(newTags, tid)
+		}
+	}
+
+	if !slices.Contains(newTags, ignoreID) {
+		newTags = append(newTags, ignoreID)
+	}
+
+	if err := r.client.SetAuthorTags(ctx, author.ID, newTags); err != nil {
+		return fmt.Errorf("failed to update readarr author: %w", err)
+	}
+
+	log.Info("removed all jellysweep tags and added ignore tag to Readarr author", "author", author.AuthorName, "book", book.Title)
+	return nil
+}
+
+// GetItemAddedDate retrieves the first date when a book was imported.
+func (r *Readarr) GetItemAddedDate(ctx context.Context, bookID int32, since time.Time) (*time.Time, error) {
+	var allHistory []readarrAPI.HistoryRecord
+	page := int32(1)
+	pageSize := int32(250)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		historyPage, err := r.client.GetHistory(ctx, bookID, page, pageSize)
+		if err != nil {
+			log.Warn("failed to get Readarr history for book", "bookID", bookID, "error", err)
+			return nil, err
+		}
+
+		if len(historyPage.Records) == 0 {
+			break
+		}
+
+		allHistory = append(allHistory, historyPage.Records...)
+
+		// Check if we have more pages
+		if len(allHistory) >= int(historyPage.TotalRecords) {
+			break
+		}
+
+		// or if the last record is older than 'since'
+		if historyPage.Records[len(historyPage.Records)-1].Date.Before(since) {
+			break
+		}
+
+		page++
+	}
+
+	// Find the earliest import event that is after 'since'
+	var earliestTime *time.Time
+	for _, record := range allHistory {
+		if record.BookID != bookID {
+			continue
+		}
+		if record.EventType == readarrAPI.EventTypeBookFileImported ||
+			record.EventType == readarrAPI.EventTypeDownloadImported {
+			recordTime := record.Date
+			if earliestTime == nil || (recordTime.Before(*earliestTime) && recordTime.After(since)) {
+				earliestTime = &recordTime
+			}
+		}
+	}
+
+	if earliestTime != nil {
+		log.Debug("Readarr book first imported", "bookID", bookID, "importedAt", earliestTime.Format(time.RFC3339))
+	}
+
+	return earliestTime, nil
+}
+
+// HasExternalSubtitles always returns false, books don't have subtitles.
+func (r *Readarr) HasExternalSubtitles(context.Context, int32) (bool, error) {
+	return false, nil
+}
+
+// GetDownloadingIDs always returns no IDs, the Readarr client doesn't support the queue.
+func (r *Readarr) GetDownloadingIDs(context.Context) (map[int32]bool, error) {
+	return nil, nil
+}
+
+// Ping checks whether Readarr is reachable by requesting its system status.
+func (r *Readarr) Ping(ctx context.Context) error {
+	return r.client.GetSystemStatus(ctx)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/log"
 	sonarrAPI "github.com/devopsarr/sonarr-go/sonarr"
 	"github.com/jon4hz/jellysweep/internal/config"
+	"golang.org/x/sync/errgroup"
 )
 
 func (s *Sonarr) DeleteMedia(ctx context.Context, seriesID int32, title string) error {
@@ -35,18 +36,30 @@ func (s *Sonarr) DeleteMedia(ctx context.Context, seriesID int32, title string)
 		defer resp.Body.Close() //nolint: errcheck
 		deletionDescription = "entire series"
 
-	case config.CleanupModeKeepEpisodes, config.CleanupModeKeepSeasons:
-		// Get episode files to keep
-		filesToKeep, err := s.getEpisodeFilesToKeep(ctx, seriesID, title, cleanupMode, keepCount)
-		if err != nil {
-			log.Error("failed to determine episode files to keep", "title", title, "error", err)
+	case config.CleanupModeKeepEpisodes, config.CleanupModeKeepSeasons, config.CleanupModeKeepLatestEpisodes:
+		// Get the episode files to keep and all episode files of the series in parallel
+		var (
+			filesToKeep     []int32
+			allEpisodeFiles []sonarrAPI.EpisodeFileResource
+		)
+		g, gctx := errgroup.WithContext(ctx)
+		g.Go(func() error {
+			var err error
+			filesToKeep, err = s.getEpisodeFilesToKeep(gctx, seriesID, title, cleanupMode, keepCount)
+			if err != nil {
+				log.Error("failed to determine episode files to keep", "title", title, "error", err)
+			}
 			return err
-		}
-
-		// Get all episode files for the series
-		allEpisodeFiles, err := s.getEpisodeFiles(ctx, seriesID)
-		if err != nil {
-			log.Error("failed to get episode files", "title", title, "error", err)
+		})
+		g.Go(func() error {
+			var err error
+			allEpisodeFiles, err = s.getEpisodeFiles(gctx, seriesID)
+			if err != nil {
+				log.Error("failed to get episode files", "title", title, "error", err)
+			}
+			return err
+		})
+		if err := g.Wait(); err != nil {
 			return err
 		}
 
@@ -73,9 +86,23 @@ func (s *Sonarr) DeleteMedia(ctx context.Context, seriesID int32, title string)
 				// continue with execution even when unmonitoring fails
 			}
 
-			if cleanupMode == config.CleanupModeKeepEpisodes {
+			if s.cfg.DeleteEmptySeriesAfterCleanup {
+				deleted, err := s.deleteSeriesIfEmpty(ctx, seriesID, title)
+				if err != nil {
+					log.Warn("failed to delete empty series", "title", title, "error", err)
+					// the episode files are already deleted, so the cleanup still succeeded
+				} else if deleted {
+					log.Info("deleted from Sonarr series", "title", title, "description", "entire series (no episode files left)")
+					return nil
+				}
+			}
+
+			switch cleanupMode { //nolint: exhaustive
+			case config.CleanupModeKeepEpisodes:
 				deletionDescription = fmt.Sprintf("all but first %d episodes (and unmonitored deleted episodes)", keepCount)
-			} else {
+			case config.CleanupModeKeepLatestEpisodes:
+				deletionDescription = fmt.Sprintf("all but latest %d episodes (and unmonitored deleted episodes)", keepCount)
+			default:
 				deletionDescription = fmt.Sprintf("all but first %d seasons (and unmonitored deleted episodes)", keepCount)
 			}
 		} else {
@@ -101,6 +128,39 @@ func (s *Sonarr) DeleteMedia(ctx context.Context, seriesID int32, title string)
 	return nil
 }
 
+// deleteSeriesIfEmpty deletes the series if it has no episode files left.
+// Series with monitored episodes that haven't aired yet are kept, so Sonarr can still grab them.
+func (s *Sonarr) deleteSeriesIfEmpty(ctx context.Context, seriesID int32, title string) (bool, error) {
+	episodeFiles, err := s.getEpisodeFiles(ctx, seriesID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get episode files for series %s: %w", title, err)
+	}
+	if len(episodeFiles) > 0 {
+		return false, nil
+	}
+
+	episodes, err := s.getEpisodes(ctx, seriesID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get episodes for series %s: %w", title, err)
+	}
+	now := time.Now().UTC()
+	for _, episode := range episodes {
+		if episode.GetMonitored() && !episodeAlreadyAired(episode, now) {
+			log.Info("keeping empty series with monitored unaired episodes", "title", title)
+			return false, nil
+		}
+	}
+
+	resp, err := s.client.SeriesAPI.DeleteSeries(s.sonarrAuthCtx(ctx), seriesID).
+		DeleteFiles(true).
+		Execute()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete Sonarr series %s: %w", title, err)
+	}
+	defer resp.Body.Close() //nolint: errcheck
+	return true, nil
+}
+
 // getEpisodeFilesToKeep determines which episode files to keep based on cleanup mode.
 func (s *Sonarr) getEpisodeFilesToKeep(ctx context.Context, seriesID int32, title string, cleanupMode config.CleanupMode, keepCount int) ([]int32, error) {
 	if cleanupMode == config.CleanupModeAll {
@@ -158,6 +218,32 @@ func (s *Sonarr) getEpisodeFilesToKeep(ctx context.Context, seriesID int32, titl
 			}
 		}
 
+	case config.CleanupModeKeepLatestEpisodes:
+		// Keep the latest N episodes (by season and episode number), excluding Season 0 (specials)
+		// Always keep all special episodes (Season 0)
+		var regularEpisodes []sonarrAPI.EpisodeResource
+		for _, episode := range episodes {
+			if episode.GetSeasonNumber() != 0 {
+				regularEpisodes = append(regularEpisodes, episode)
+			} else if episode.HasFile != nil && *episode.HasFile && episode.HasEpisodeFileId() {
+				filesToKeep = append(filesToKeep, episode.GetEpisodeFileId())
+			}
+		}
+
+		slices.SortFunc(regularEpisodes, compareEpisodesDesc)
+
+		// Keep files for the latest keepCount regular episodes (by episode order)
+		keptEpisodes := 0
+		for _, episode := range regularEpisodes {
+			if keptEpisodes >= keepCount {
+				break
+			}
+			if episode.HasFile != nil && *episode.HasFile && episode.HasEpisodeFileId() {
+				filesToKeep = append(filesToKeep, episode.GetEpisodeFileId())
+				keptEpisodes++
+			}
+		}
+
 	case config.CleanupModeKeepSeasons:
 		// Keep the first N lowest-numbered seasons (typically the earliest seasons), excluding Season 0 (specials)
 		// Group episodes by season, separating specials from regular seasons
@@ -239,21 +325,26 @@ func (s *Sonarr) getEpisodeFiles(ctx context.Context, seriesID int32) ([]sonarrA
 	return episodeFiles, nil
 }
 
-// deleteEpisodeFiles deletes specific episode files from Sonarr.
+// deleteEpisodeFiles deletes specific episode files from Sonarr with up to the configured concurrency in parallel.
 func (s *Sonarr) deleteEpisodeFiles(ctx context.Context, episodeFileIDs []int32) error {
 	if s.client == nil {
 		return fmt.Errorf("sonarr client not available")
 	}
 
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(s.cfg.GetConcurrency())
 	for _, fileID := range episodeFileIDs {
-		resp, err := s.client.EpisodeFileAPI.DeleteEpisodeFile(s.sonarrAuthCtx(ctx), fileID).Execute()
-		if err != nil {
-			return fmt.Errorf("failed to delete episode file %d: %w", fileID, err)
-		}
-		defer resp.Body.Close() //nolint: errcheck
+		g.Go(func() error {
+			resp, err := s.client.EpisodeFileAPI.DeleteEpisodeFile(s.sonarrAuthCtx(ctx), fileID).Execute()
+			if err != nil {
+				return fmt.Errorf("failed to delete episode file %d: %w", fileID, err)
+			}
+			_ = resp.Body.Close()
+			return nil
+		})
 	}
 
-	return nil
+	return g.Wait()
 }
 
 // unmonitorDeletedEpisodes unmonitors episodes that were deleted to prevent Sonarr from redownloading them.
@@ -295,6 +386,32 @@ func (s *Sonarr) unmonitorDeletedEpisodes(ctx context.Context, seriesID int32, t
 			}
 		}
 
+	case config.CleanupModeKeepLatestEpisodes:
+		// Unmonitor aired episodes older than the latest N regular episodes with files (excluding Season 0 specials).
+		// Newer episodes without a file stay monitored so Sonarr keeps grabbing new releases.
+		var regularEpisodes []sonarrAPI.EpisodeResource
+		for _, episode := range episodes {
+			if episode.GetSeasonNumber() != 0 {
+				regularEpisodes = append(regularEpisodes, episode)
+			}
+		}
+
+		slices.SortFunc(regularEpisodes, compareEpisodesDesc)
+
+		keptEpisodes := 0
+		now := time.Now().UTC()
+		for _, episode := range regularEpisodes {
+			if keptEpisodes < keepCount {
+				if episode.HasFile != nil && *episode.HasFile {
+					keptEpisodes++
+				}
+				continue
+			}
+			if episodeAlreadyAired(episode, now) {
+				episodesToUnmonitor = append(episodesToUnmonitor, episode.GetId())
+			}
+		}
+
 	case config.CleanupModeKeepSeasons:
 		// Unmonitor episodes from seasons that are not in the first N lowest-numbered regular seasons (excluding Season 0)
 		// Group episodes by season, separating specials from regular seasons
@@ -365,3 +482,82 @@ func episodeAlreadyAired(episode sonarrAPI.EpisodeResource, now time.Time) bool
 	// An episode is considered aired if it has a non-zero air date
 	return !episode.GetAirDateUtc().IsZero() && episode.GetAirDateUtc().Before(now)
 }
+
+// compareEpisodesDesc sorts episodes by season number descending, then by episode number descending (latest episodes first).
+func compareEpisodesDesc(a, b sonarrAPI.EpisodeResource) int {
+	if a.GetSeasonNumber() != b.GetSeasonNumber() {
+		return int(b.GetSeasonNumber() - a.GetSeasonNumber())
+	}
+	return int(b.GetEpisodeNumber() - a.GetEpisodeNumber())
+}
+
+// DeleteSeasonsExcept deletes the episode files of all regular seasons except the kept ones and unmonitors their aired episodes.
+// Specials (season 0) are always kept. It returns the size of the deleted files in bytes.
+func (s *Sonarr) DeleteSeasonsExcept(ctx context.Context, seriesID int32, title string, keep []int32) (int64, error) {
+	if s.cfg.DryRun {
+		log.Info("dry run: would delete Sonarr seasons", "title", title, "keptSeasons", keep)
+		return 0, nil
+	}
+
+	episodes, err := s.getEpisodes(ctx, seriesID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get episodes for series %s: %w", title, err)
+	}
+
+	filesToDelete, episodesToUnmonitor := unkeptSeasonEpisodes(episodes, keep, time.Now().UTC())
+	if len(filesToDelete) == 0 {
+		log.Debug("no episode files to delete outside of the kept seasons", "title", title, "keptSeasons", keep)
+		return 0, nil
+	}
+
+	var size int64
+	episodeFiles, err := s.getEpisodeFiles(ctx, seriesID)
+	if err != nil {
+		log.Warn("failed to get episode file sizes", "title", title, "error", err)
+	}
+	for _, file := range episodeFiles {
+		if slices.Contains(filesToDelete, file.GetId()) {
+			size += file.GetSize()
+		}
+	}
+
+	if err := s.deleteEpisodeFiles(ctx, filesToDelete); err != nil {
+		log.Error("failed to delete episode files", "title", title, "error", err)
+		return 0, err
+	}
+
+	if len(episodesToUnmonitor) > 0 {
+		resource := sonarrAPI.NewEpisodesMonitoredResource()
+		resource.SetEpisodeIds(episodesToUnmonitor)
+		resource.SetMonitored(false)
+
+		resp, err := s.client.EpisodeAPI.PutEpisodeMonitor(s.sonarrAuthCtx(ctx)).
+			EpisodesMonitoredResource(*resource).
+			Execute()
+		if err != nil {
+			log.Warn("failed to unmonitor deleted episodes", "title", title, "error", err)
+		} else {
+			_ = resp.Body.Close()
+		}
+	}
+
+	log.Info("deleted seasons not kept from Sonarr series", "title", title, "keptSeasons", keep, "files", len(filesToDelete))
+	return size, nil
+}
+
+// unkeptSeasonEpisodes returns the episode files to delete and the aired episodes to unmonitor for all regular seasons which aren't kept.
+func unkeptSeasonEpisodes(episodes []sonarrAPI.EpisodeResource, keep []int32, now time.Time) (files, unmonitor []int32) {
+	for _, episode := range episodes {
+		seasonNum := episode.GetSeasonNumber()
+		if seasonNum == 0 || slices.Contains(keep, seasonNum) {
+			continue
+		}
+		if episode.HasFile != nil && *episode.HasFile && episode.HasEpisodeFileId() && !slices.Contains(files, episode.GetEpisodeFileId()) {
+			files = append(files, episode.GetEpisodeFileId())
+		}
+		if episodeAlreadyAired(episode, now) {
+			unmonitor = append(unmonitor, episode.GetId())
+		}
+	}
+	return files, unmonitor
+}
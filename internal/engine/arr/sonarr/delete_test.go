@@ -0,0 +1,277 @@
+package sonarr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sonarrAPI "github.com/devopsarr/sonarr-go/sonarr"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEpisode struct {
+	Season, Episode int32
+	FileID          int32 // 0 if the episode has no file
+	Size            int64
+	Monitored       bool
+	AirDate         time.Time
+}
+
+// fakeSonarr serves the episodes and episode files of a single series and records deletions.
+type fakeSonarr struct {
+	mu            sync.Mutex
+	episodes      []fakeEpisode
+	seriesDeleted bool
+}
+
+func (f *fakeSonarr) handler(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v3/episode":
+		episodes := make([]map[string]any, 0, len(f.episodes))
+		for i, ep := range f.episodes {
+			episodes = append(episodes, map[string]any{
+				"id": i + 1, "seasonNumber": ep.Season, "episodeNumber": ep.Episode, "monitored": ep.Monitored,
+				"hasFile": ep.FileID != 0, "episodeFileId": ep.FileID, "airDateUtc": ep.AirDate,
+			})
+		}
+		_ = json.NewEncoder(w).Encode(episodes)
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v3/episodefile":
+		files := make([]map[string]any, 0)
+		for _, ep := range f.episodes {
+			if ep.FileID != 0 {
+				files = append(files, map[string]any{"id": ep.FileID, "seasonNumber": ep.Season, "size": ep.Size})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(files)
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/v3/episodefile/"):
+		id, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/v3/episodefile/"))
+		for i := range f.episodes {
+			if f.episodes[i].FileID == int32(id) {
+				f.episodes[i].FileID = 0
+			}
+		}
+	case r.Method == http.MethodPut && r.URL.Path == "/api/v3/episode/monitor":
+		var body struct {
+			EpisodeIDs []int `json:"episodeIds"`
+			Monitored  bool  `json:"monitored"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		for _, id := range body.EpisodeIDs {
+			f.episodes[id-1].Monitored = body.Monitored
+		}
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodDelete && r.URL.Path == "/api/v3/series/1":
+		f.seriesDeleted = true
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestSonarr(t *testing.T, f *fakeSonarr, deleteEmptySeries bool) *Sonarr {
+	t.Helper()
+	s := newTestSonarrWithMode(t, f, config.CleanupModeKeepSeasons, 1)
+	s.cfg.DeleteEmptySeriesAfterCleanup = deleteEmptySeries
+	return s
+}
+
+func newTestSonarrWithMode(t *testing.T, f *fakeSonarr, cleanupMode config.CleanupMode, keepCount int) *Sonarr {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(f.handler))
+	t.Cleanup(server.Close)
+
+	return NewSonarr(&config.Config{
+		Sonarr:      &config.SonarrConfig{URL: server.URL, APIKey: "key"},
+		CleanupMode: cleanupMode,
+		KeepCount:   keepCount,
+	}, nil, nil)
+}
+
+func TestDeleteMediaDeletesEmptySeries(t *testing.T) {
+	aired := time.Now().Add(-30 * 24 * time.Hour)
+
+	// The kept first season has no files, so deleting the files of the second season empties the series.
+	newSeries := func() *fakeSonarr {
+		return &fakeSonarr{episodes: []fakeEpisode{
+			{Season: 1, Episode: 1, Monitored: true, AirDate: aired},
+			{Season: 2, Episode: 1, FileID: 10, Monitored: true, AirDate: aired},
+			{Season: 2, Episode: 2, FileID: 11, Monitored: true, AirDate: aired},
+		}}
+	}
+
+	t.Run("empty series", func(t *testing.T) {
+		f := newSeries()
+		require.NoError(t, newTestSonarr(t, f, true).DeleteMedia(context.Background(), 1, "Show"))
+		assert.True(t, f.seriesDeleted, "the series has no episode files left")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		f := newSeries()
+		require.NoError(t, newTestSonarr(t, f, false).DeleteMedia(context.Background(), 1, "Show"))
+		assert.False(t, f.seriesDeleted)
+	})
+
+	t.Run("files left", func(t *testing.T) {
+		f := newSeries()
+		f.episodes[0].FileID = 9
+		require.NoError(t, newTestSonarr(t, f, true).DeleteMedia(context.Background(), 1, "Show"))
+		assert.False(t, f.seriesDeleted)
+	})
+
+	t.Run("monitored unaired episodes", func(t *testing.T) {
+		f := newSeries()
+		f.episodes = append(f.episodes, fakeEpisode{Season: 1, Episode: 2, Monitored: true, AirDate: time.Now().Add(7 * 24 * time.Hour)})
+		require.NoError(t, newTestSonarr(t, f, true).DeleteMedia(context.Background(), 1, "Show"))
+		assert.False(t, f.seriesDeleted, "series with monitored unaired episodes are kept")
+	})
+}
+
+func TestDeleteSeasonsExcept(t *testing.T) {
+	aired := time.Now().Add(-30 * 24 * time.Hour)
+	upcoming := time.Now().Add(7 * 24 * time.Hour)
+	f := &fakeSonarr{episodes: []fakeEpisode{
+		{Season: 0, Episode: 1, FileID: 1, Size: 1, Monitored: true, AirDate: aired},
+		{Season: 1, Episode: 1, FileID: 10, Size: 100, Monitored: true, AirDate: aired},
+		{Season: 2, Episode: 1, FileID: 20, Size: 200, Monitored: true, AirDate: aired},
+		{Season: 2, Episode: 2, FileID: 21, Size: 210, Monitored: true, AirDate: aired},
+		{Season: 3, Episode: 1, Monitored: true, AirDate: upcoming},
+	}}
+
+	size, err := newTestSonarr(t, f, true).DeleteSeasonsExcept(context.Background(), 1, "Show", []int32{1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(410), size, "only the files of the seasons which aren't kept are deleted")
+
+	assert.Equal(t, int32(1), f.episodes[0].FileID, "specials are always kept")
+	assert.Equal(t, int32(10), f.episodes[1].FileID)
+	assert.Zero(t, f.episodes[2].FileID)
+	assert.Zero(t, f.episodes[3].FileID)
+
+	assert.True(t, f.episodes[1].Monitored)
+	assert.False(t, f.episodes[2].Monitored, "aired episodes of deleted seasons are unmonitored")
+	assert.False(t, f.episodes[3].Monitored)
+	assert.True(t, f.episodes[4].Monitored, "upcoming episodes stay monitored")
+	assert.False(t, f.seriesDeleted, "the series itself is never deleted")
+}
+
+func TestDeleteSeasonsExceptNothingToDelete(t *testing.T) {
+	aired := time.Now().Add(-30 * 24 * time.Hour)
+	f := &fakeSonarr{episodes: []fakeEpisode{
+		{Season: 1, Episode: 1, FileID: 10, Size: 100, Monitored: true, AirDate: aired},
+		{Season: 2, Episode: 1, Monitored: true, AirDate: aired},
+	}}
+
+	size, err := newTestSonarr(t, f, true).DeleteSeasonsExcept(context.Background(), 1, "Show", []int32{1})
+	require.NoError(t, err)
+	assert.Zero(t, size)
+	assert.True(t, f.episodes[1].Monitored, "nothing is unmonitored without files to delete")
+}
+
+func TestUnkeptSeasonEpisodes(t *testing.T) {
+	now := time.Now()
+	episode := func(id, season, fileID int32, airDate time.Time) sonarrAPI.EpisodeResource {
+		ep := sonarrAPI.EpisodeResource{}
+		ep.SetId(id)
+		ep.SetSeasonNumber(season)
+		ep.SetHasFile(fileID != 0)
+		if fileID != 0 {
+			ep.SetEpisodeFileId(fileID)
+		}
+		ep.SetAirDateUtc(airDate)
+		return ep
+	}
+
+	files, unmonitor := unkeptSeasonEpisodes([]sonarrAPI.EpisodeResource{
+		episode(1, 0, 100, now.Add(-time.Hour)),
+		episode(2, 1, 101, now.Add(-time.Hour)),
+		episode(3, 2, 102, now.Add(-time.Hour)),
+		episode(4, 2, 102, now.Add(-time.Hour)),
+		episode(5, 3, 0, now.Add(-time.Hour)),
+		episode(6, 3, 0, now.Add(time.Hour)),
+	}, []int32{1}, now)
+
+	assert.Equal(t, []int32{102}, files, "multi-episode files are only deleted once")
+	assert.Equal(t, []int32{3, 4, 5}, unmonitor, "only aired episodes are unmonitored")
+}
+
+func TestDeleteMediaKeepLatestEpisodes(t *testing.T) {
+	aired := time.Now().Add(-30 * 24 * time.Hour)
+	upcoming := time.Now().Add(7 * 24 * time.Hour)
+	f := &fakeSonarr{episodes: []fakeEpisode{
+		{Season: 0, Episode: 1, FileID: 1, Monitored: true, AirDate: aired},
+		{Season: 1, Episode: 1, FileID: 11, Monitored: true, AirDate: aired},
+		{Season: 1, Episode: 2, FileID: 12, Monitored: true, AirDate: aired},
+		{Season: 1, Episode: 3, FileID: 13, Monitored: true, AirDate: aired},
+		{Season: 2, Episode: 1, FileID: 21, Monitored: true, AirDate: aired},
+		{Season: 2, Episode: 2, FileID: 22, Monitored: true, AirDate: aired},
+		{Season: 2, Episode: 3, Monitored: true, AirDate: aired},
+		{Season: 2, Episode: 4, Monitored: true, AirDate: upcoming},
+	}}
+
+	require.NoError(t, newTestSonarrWithMode(t, f, config.CleanupModeKeepLatestEpisodes, 2).DeleteMedia(context.Background(), 1, "Show"))
+
+	files := make(map[[2]int32]int32)
+	monitored := make(map[[2]int32]bool)
+	for _, ep := range f.episodes {
+		files[[2]int32{ep.Season, ep.Episode}] = ep.FileID
+		monitored[[2]int32{ep.Season, ep.Episode}] = ep.Monitored
+	}
+
+	assert.Equal(t, int32(1), files[[2]int32{0, 1}], "specials are always kept")
+	assert.Zero(t, files[[2]int32{1, 1}])
+	assert.Zero(t, files[[2]int32{1, 2}])
+	assert.Zero(t, files[[2]int32{1, 3}])
+	assert.Equal(t, int32(21), files[[2]int32{2, 1}], "the latest two episodes with a file are kept")
+	assert.Equal(t, int32(22), files[[2]int32{2, 2}])
+
+	assert.True(t, monitored[[2]int32{0, 1}])
+	assert.False(t, monitored[[2]int32{1, 1}], "older aired episodes are unmonitored")
+	assert.False(t, monitored[[2]int32{1, 2}])
+	assert.False(t, monitored[[2]int32{1, 3}])
+	assert.True(t, monitored[[2]int32{2, 1}])
+	assert.True(t, monitored[[2]int32{2, 2}])
+	assert.True(t, monitored[[2]int32{2, 3}], "newer episodes without a file stay monitored so they are still grabbed")
+	assert.True(t, monitored[[2]int32{2, 4}])
+	assert.False(t, f.seriesDeleted)
+}
+
+func TestDeleteMediaKeepLatestEpisodesKeepsAll(t *testing.T) {
+	aired := time.Now().Add(-30 * 24 * time.Hour)
+	f := &fakeSonarr{episodes: []fakeEpisode{
+		{Season: 1, Episode: 1, FileID: 11, Monitored: true, AirDate: aired},
+		{Season: 1, Episode: 2, FileID: 12, Monitored: true, AirDate: aired},
+	}}
+
+	require.NoError(t, newTestSonarrWithMode(t, f, config.CleanupModeKeepLatestEpisodes, 3).DeleteMedia(context.Background(), 1, "Show"))
+	for _, ep := range f.episodes {
+		assert.NotZero(t, ep.FileID, "fewer episodes than the keep count are all kept")
+		assert.True(t, ep.Monitored)
+	}
+}
+
+func TestGetEpisodeFilesToKeepLatestEpisodes(t *testing.T) {
+	aired := time.Now().Add(-30 * 24 * time.Hour)
+	f := &fakeSonarr{episodes: []fakeEpisode{
+		{Season: 0, Episode: 1, FileID: 1, AirDate: aired},
+		{Season: 1, Episode: 10, FileID: 110, AirDate: aired},
+		{Season: 2, Episode: 1, FileID: 201, AirDate: aired},
+		{Season: 1, Episode: 9, FileID: 109, AirDate: aired},
+		{Season: 2, Episode: 2, AirDate: aired},
+	}}
+
+	s := newTestSonarrWithMode(t, f, config.CleanupModeKeepLatestEpisodes, 2)
+	files, err := s.getEpisodeFilesToKeep(context.Background(), 1, "Show", config.CleanupModeKeepLatestEpisodes, 2)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int32{1, 201, 110}, files, "episodes are ordered by season before episode number and episodes without a file don't count")
+}
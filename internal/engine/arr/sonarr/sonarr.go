@@ -2,6 +2,7 @@ package sonarr
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"slices"
@@ -22,7 +23,10 @@ import (
 	jellyfin "github.com/sj14/jellyfin-go/api"
 )
 
-var _ arr.Arrer = (*Sonarr)(nil)
+var (
+	_ arr.Arrer      = (*Sonarr)(nil)
+	_ arr.TagCleaner = (*Sonarr)(nil)
+)
 
 type Sonarr struct {
 	client    *sonarrAPI.APIClient
@@ -51,7 +55,7 @@ func NewSonarr(cfg *config.Config, stats stats.Statser, tagsCache *cache.Prefixe
 			URL: cfg.Sonarr.URL,
 		},
 	}
-	scfg.HTTPClient = &http.Client{Timeout: config.TimeoutDuration(cfg.Sonarr.Timeout)}
+	scfg.HTTPClient = config.NewRetryHTTPClient(cfg.Sonarr.Timeout, cfg.Proxy, cfg.Sonarr.Proxy, cfg.Retry)
 	scfg.UserAgent = fmt.Sprintf("Jellysweep/%s", version.Version)
 	client := sonarrAPI.NewAPIClient(scfg)
 
@@ -95,11 +99,6 @@ func (s *Sonarr) GetItems(ctx context.Context, jellyfinItems []arr.JellyfinItem)
 
 	mediaItems := make([]arr.MediaItem, 0)
 	for _, jf := range jellyfinItems {
-		libraryName := jf.ParentLibraryName
-		if libraryName == "" {
-			log.Error("Library name is empty for Jellyfin item, skipping", "item_id", jf.GetId(), "item_name", jf.GetName())
-			continue
-		}
 		if jf.GetType() != jellyfin.BASEITEMKIND_SERIES {
 			continue
 		}
@@ -155,6 +154,15 @@ func (s *Sonarr) GetItems(ctx context.Context, jellyfinItems []arr.JellyfinItem)
 			continue
 		}
 
+		libraryName := jf.ParentLibraryName
+		if libraryName == "" {
+			libraryName = s.cfg.LibraryForPath(sr.GetPath())
+		}
+		if libraryName == "" {
+			log.Error("Library name is empty for Jellyfin item, skipping", "item_id", jf.GetId(), "item_name", jf.GetName())
+			continue
+		}
+
 		mediaItems = append(mediaItems, arr.MediaItem{
 			JellyfinID:     jf.GetId(),
 			LibraryName:    libraryName,
@@ -306,6 +314,67 @@ func (s *Sonarr) ResetTags(ctx context.Context, additionalTags []string) error {
 	return nil
 }
 
+// GetTaggedItems returns all series with jellysweep tags other than the ignore tag.
+func (s *Sonarr) GetTaggedItems(ctx context.Context) ([]arr.TaggedItem, error) {
+	series, err := s.getItems(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sonarr series: %w", err)
+	}
+
+	tagMap, err := s.getTags(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sonarr tags: %w", err)
+	}
+
+	var items []arr.TaggedItem
+	for _, serie := range series {
+		var jellysweepTags []string
+		for _, tagID := range serie.GetTags() {
+			if name := tagMap[tagID]; tags.IsJellysweepTagWithoutIgnore(name) {
+				jellysweepTags = append(jellysweepTags, name)
+			}
+		}
+		if len(jellysweepTags) > 0 {
+			items = append(items, arr.TaggedItem{ID: serie.GetId(), Title: serie.GetTitle(), Tags: jellysweepTags})
+		}
+	}
+	return items, nil
+}
+
+// RemoveJellysweepTags removes the jellysweep tags from a single series, keeping the ignore tag.
+func (s *Sonarr) RemoveJellysweepTags(ctx context.Context, id int32) error {
+	series, getResp, err := s.client.SeriesAPI.GetSeriesById(s.sonarrAuthCtx(ctx), id).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to get sonarr series: %w", err)
+	}
+	defer getResp.Body.Close() //nolint: errcheck
+
+	tagMap, err := s.getTags(ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to get sonarr tags: %w", err)
+	}
+
+	newTags := make([]int32, 0)
+	for _, tid := range series.GetTags() {
+		if tags.IsJellysweepTagWithoutIgnore(tagMap[tid]) {
+			continue
+		}
+		newTags = append(newTags, tid)
+	}
+
+	series.Tags = newTags
+	_, resp, err := s.client.SeriesAPI.UpdateSeries(s.sonarrAuthCtx(ctx), fmt.Sprintf("%d", id)).
+		SeriesResource(*series).
+		Execute()
+	if err != nil {
+		return fmt.Errorf("failed to update sonarr series: %w", err)
+	}
+	defer resp.Body.Close() //nolint: errcheck
+
+	log.Info("removed jellysweep tags from Sonarr series", "title", series.GetTitle())
+	return nil
+}
+
 // CleanupAllTags deletes all unused jellysweep tags from Sonarr.
 func (s *Sonarr) CleanupAllTags(ctx context.Context, additionalTags []string) error {
 	tagsList, resp, err := s.client.TagDetailsAPI.ListTagDetail(s.sonarrAuthCtx(ctx)).Execute()
@@ -453,3 +522,92 @@ func (s *Sonarr) GetItemAddedDate(ctx context.Context, seriesID int32, since tim
 
 	return earliestTime, nil
 }
+
+// sonarrExtraFile is the subset of the Sonarr extra file resource needed to detect subtitles.
+// The generated Sonarr client doesn't expose the extra file endpoint.
+type sonarrExtraFile struct {
+	Type string `json:"type"`
+}
+
+// HasExternalSubtitles reports whether any episode of the series has external subtitle files.
+func (s *Sonarr) HasExternalSubtitles(ctx context.Context, seriesID int32) (bool, error) {
+	endpoint := fmt.Sprintf("%s/api/v3/extrafile?seriesId=%d", s.cfg.Sonarr.URL, seriesID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create sonarr extra file request: %w", err)
+	}
+	req.Header.Set("X-Api-Key", s.cfg.Sonarr.APIKey)
+	req.Header.Set("User-Agent", s.client.GetConfig().UserAgent)
+
+	resp, err := s.client.GetConfig().HTTPClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to list sonarr extra files: %w", err)
+	}
+	defer resp.Body.Close() //nolint: errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("failed to list sonarr extra files: unexpected status %d", resp.StatusCode)
+	}
+
+	var extraFiles []sonarrExtraFile
+	if err := json.NewDecoder(resp.Body).Decode(&extraFiles); err != nil {
+		return false, fmt.Errorf("failed to decode sonarr extra files: %w", err)
+	}
+
+	return slices.ContainsFunc(extraFiles, func(f sonarrExtraFile) bool { return f.Type == "subtitle" }), nil
+}
+
+// GetDownloadingIDs returns the IDs of the series with a download or import in progress in the Sonarr queue.
+func (s *Sonarr) GetDownloadingIDs(ctx context.Context) (map[int32]bool, error) {
+	ids := make(map[int32]bool)
+	page := int32(1)
+	pageSize := int32(250)
+	var seen int
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		queue, resp, err := s.client.QueueAPI.GetQueue(s.sonarrAuthCtx(ctx)).
+			Page(page).
+			PageSize(pageSize).
+			Execute()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sonarr queue: %w", err)
+		}
+		_ = resp.Body.Close()
+
+		records := queue.GetRecords()
+		for _, record := range records {
+			switch record.GetTrackedDownloadState() {
+			case sonarrAPI.TRACKEDDOWNLOADSTATE_DOWNLOADING,
+				sonarrAPI.TRACKEDDOWNLOADSTATE_IMPORT_PENDING,
+				sonarrAPI.TRACKEDDOWNLOADSTATE_IMPORTING:
+				if id := record.GetSeriesId(); id != 0 {
+					ids[id] = true
+				}
+			}
+		}
+
+		seen += len(records)
+		if len(records) == 0 || seen >= int(queue.GetTotalRecords()) {
+			break
+		}
+		page++
+	}
+
+	return ids, nil
+}
+
+// Ping checks whether Sonarr is reachable by requesting its system status.
+func (s *Sonarr) Ping(ctx context.Context) error {
+	_, resp, err := s.client.SystemAPI.GetSystemStatus(s.sonarrAuthCtx(ctx)).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to get sonarr system status: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	return nil
+}
@@ -0,0 +1,28 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/database"
+)
+
+// RecordAuditEntry appends an action performed by an admin to the audit log.
+// mediaID and target are optional. Failures are logged but don't fail the action itself.
+func (e *Engine) RecordAuditEntry(ctx context.Context, actor string, action database.AuditAction, mediaID *uint, target string) {
+	entry := database.AuditLogEntry{
+		Actor:   actor,
+		Action:  action,
+		MediaID: mediaID,
+		Target:  target,
+	}
+	if err := e.db.CreateAuditLogEntry(ctx, entry); err != nil {
+		log.FromContext(ctx).Error("failed to record audit log entry", "actor", actor, "action", action, "error", err)
+	}
+}
+
+// GetAuditLog returns the paginated audit log.
+func (e *Engine) GetAuditLog(ctx context.Context, limit, offset int, since time.Time) ([]database.AuditLogEntry, int64, error) {
+	return e.db.GetAuditLogEntries(ctx, limit, offset, since)
+}
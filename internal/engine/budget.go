@@ -0,0 +1,160 @@
+package engine
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/filter"
+)
+
+// scanKey is the position of a media item in the scan order.
+type scanKey struct {
+	added      time.Time
+	size       int64
+	jellyfinID string
+}
+
+// scanKeyOf returns the scan key of the media item.
+func scanKeyOf(item arr.MediaItem) scanKey {
+	size, _ := filter.ItemSize(item)
+	return scanKey{added: filter.ArrAddedDate(item), size: size, jellyfinID: item.JellyfinID}
+}
+
+// scanKeyOfCursor returns the scan key of the last item checked by the previous run.
+func scanKeyOfCursor(cursor *database.ScanCursor) scanKey {
+	return scanKey{added: cursor.LastAdded, size: cursor.LastSize, jellyfinID: cursor.LastJellyfinID}
+}
+
+// compareScanKeys compares two scan keys in the configured scan order.
+// Ties are broken by the Jellyfin ID, so the order is the same in every run.
+func compareScanKeys(a, b scanKey, order config.ScanOrder) int {
+	var c int
+	switch order {
+	case config.ScanOrderSize:
+		c = cmp.Compare(b.size, a.size)
+	default:
+		c = a.added.Compare(b.added)
+	}
+	if c != 0 {
+		return c
+	}
+	return cmp.Compare(a.jellyfinID, b.jellyfinID)
+}
+
+// sortForScan sorts the media items by the configured scan order.
+func sortForScan(mediaItems []arr.MediaItem, order config.ScanOrder) {
+	slices.SortStableFunc(mediaItems, func(a, b arr.MediaItem) int {
+		return compareScanKeys(scanKeyOf(a), scanKeyOf(b), order)
+	})
+}
+
+// scanBudgetFilter limits a run to the next MaxItemsPerRun media items after the persisted scan cursor.
+// It runs right after the size filter, so the library totals are computed from all items.
+type scanBudgetFilter struct {
+	cfg *config.Config
+	db  database.ScanCursorDB
+	// preview leaves the cursor untouched, e.g. to estimate the next run.
+	preview bool
+}
+
+var _ filter.Filterer = (*scanBudgetFilter)(nil)
+
+// newScanBudgetFilter creates the filter that applies the scan budget of the run.
+func newScanBudgetFilter(cfg *config.Config, db database.ScanCursorDB) *scanBudgetFilter {
+	return &scanBudgetFilter{
+		cfg: cfg,
+		db:  db,
+	}
+}
+
+// previewFilter returns a copy of the filter that never moves the cursor.
+func (f *scanBudgetFilter) previewFilter() *scanBudgetFilter {
+	return &scanBudgetFilter{
+		cfg:     f.cfg,
+		db:      f.db,
+		preview: true,
+	}
+}
+
+// String returns the name of the filter.
+func (f *scanBudgetFilter) String() string { return "Scan Budget" }
+
+// Apply returns the next slice of at most MaxItemsPerRun media items, starting behind the item the previous run stopped at.
+// The cursor is moved to the last returned item and starts over once the end of the list is reached.
+// Without a budget all items are returned.
+func (f *scanBudgetFilter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.MediaItem, error) {
+	budget := f.cfg.MaxItemsPerRun
+	if budget <= 0 || len(mediaItems) <= budget {
+		return mediaItems, nil
+	}
+
+	mediaItems = slices.Clone(mediaItems)
+	sortForScan(mediaItems, f.cfg.OrderBy)
+
+	start := 0
+	cursor, err := f.db.GetScanCursor(ctx)
+	if err != nil {
+		log.FromContext(ctx).Warn("Failed to get scan cursor, starting at the first item", "error", err)
+	} else if cursor != nil && cursor.OrderBy == string(f.cfg.OrderBy) {
+		last := scanKeyOfCursor(cursor)
+		start = slices.IndexFunc(mediaItems, func(item arr.MediaItem) bool {
+			return compareScanKeys(scanKeyOf(item), last, f.cfg.OrderBy) > 0
+		})
+		if start < 0 {
+			start = 0
+		}
+	}
+
+	end := min(start+budget, len(mediaItems))
+	if f.preview {
+		return mediaItems[start:end], nil
+	}
+	if end == len(mediaItems) {
+		if err := f.db.ResetScanCursor(ctx); err != nil {
+			log.FromContext(ctx).Warn("Failed to reset scan cursor, the next run continues at the same item", "error", err)
+		}
+	} else {
+		last := scanKeyOf(mediaItems[end-1])
+		next := &database.ScanCursor{
+			OrderBy:        string(f.cfg.OrderBy),
+			LastAdded:      last.added,
+			LastSize:       last.size,
+			LastJellyfinID: last.jellyfinID,
+		}
+		if err := f.db.SetScanCursor(ctx, next); err != nil {
+			log.FromContext(ctx).Warn("Failed to store scan cursor, the next run checks the same items again", "error", err)
+		}
+	}
+
+	log.FromContext(ctx).Info("Limiting the run to a part of the media items", "from", start, "to", end, "total", len(mediaItems), "orderBy", f.cfg.OrderBy)
+	return mediaItems[start:end], nil
+}
+
+// sortForCleanup sorts the marked media items in the order they are deleted if the deletions per run are limited.
+// Largest items come first with the size order, otherwise the items that are due the longest are deleted first.
+func sortForCleanup(mediaItems []database.Media, order config.ScanOrder) {
+	slices.SortStableFunc(mediaItems, func(a, b database.Media) int {
+		var c int
+		switch order {
+		case config.ScanOrderSize:
+			c = cmp.Compare(b.FileSize, a.FileSize)
+		default:
+			c = a.DefaultDeleteAt.Compare(b.DefaultDeleteAt)
+		}
+		if c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+}
+
+// deletionBudgetReached reports whether the cleanup run already deleted MaxItemsPerRun items.
+func (e *Engine) deletionBudgetReached(deleted int) bool {
+	return e.cfg.MaxItemsPerRun > 0 && deleted >= e.cfg.MaxItemsPerRun
+}
@@ -2,109 +2,279 @@ package engine
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/jon4hz/jellysweep/internal/api/models"
 	"github.com/jon4hz/jellysweep/internal/database"
 	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/hooks"
 	jellyfin "github.com/sj14/jellyfin-go/api"
 )
 
 func (e *Engine) cleanupMedia(ctx context.Context) error {
+	e.deleteMu.Lock()
+	defer e.deleteMu.Unlock()
+
 	deletedItems := make(map[string][]arr.MediaItem)
 
+	if _, err := e.checkStats(ctx); err != nil {
+		return err
+	}
+
 	mediaItems, err := e.db.GetMediaItems(ctx, false)
 	if err != nil {
-		log.Error("failed to get media items from database", "error", err)
+		log.FromContext(ctx).Error("failed to get media items from database", "error", err)
+		return err
+	}
+
+	if e.cfg.MaxItemsPerRun > 0 {
+		sortForCleanup(mediaItems, e.cfg.OrderBy)
+	}
+
+	if err := e.policy.Prepare(ctx, mediaItems); err != nil {
+		log.FromContext(ctx).Error("failed to prepare deletion policies", "error", err)
+	}
+
+	failedItems, err := e.getDeletionFailureIDs(ctx)
+	if err != nil {
+		log.FromContext(ctx).Error("failed to get deletion failures", "error", err)
+	}
+
+	protectedCollections, err := e.protectedCollections(ctx)
+	if err != nil {
+		log.FromContext(ctx).Error("failed to get protected collections", "error", err)
 		return err
 	}
 
+	remaining := maps.Clone(e.data.libraryItemCounts)
+	var spared []string
+	var attempted int
+
 	for _, item := range mediaItems {
+		// items with a failed deletion are handled by the deletion retry job
+		if failedItems[item.ID] {
+			log.FromContext(ctx).Debug("skipping media item with failed deletion, handled by retry job", "title", item.Title)
+			continue
+		}
+
 		// since the deletion policies were already set during the scaning phase, we can just use the existing policy engine.
 		if ok, err := e.policy.ShouldTriggerDeletion(ctx, item); err != nil {
-			log.Error("failed to check deletion policy for media item", "title", item.Title, "error", err)
+			log.FromContext(ctx).Error("failed to check deletion policy for media item", "title", item.Title, "error", err)
 			continue
 		} else if !ok {
-			log.Info("skipping deletion for media item, no policies triggered", "title", item.Title)
+			log.FromContext(ctx).Info("skipping deletion for media item, no policies triggered", "title", item.Title)
 			continue
 		}
 
-		if e.cfg.DryRun {
-			log.Info("[Dry Run] Would delete media item", "title", item.Title, "library", item.LibraryName)
+		if protectedCollections[item.CollectionID] {
+			log.FromContext(ctx).Info("skipping deletion for media item, another movie of its collection is protected", "title", item.Title)
 			continue
 		}
 
-		switch item.MediaType {
-		case database.MediaTypeTV:
-			if e.sonarr == nil {
-				log.Warn("Sonarr client not configured, cannot delete TV show", "title", item.Title)
-				continue
-			}
-			if err := e.sonarr.DeleteMedia(ctx, item.ArrID, item.Title); err != nil {
-				log.Error("failed to delete Sonarr media", "title", item.Title, "error", err)
-				continue
-			}
-
-			// Also remove from Jellyfin according to cleanup mode
-			if err := e.removeJellyfinItem(ctx, item); err != nil {
-				log.Error("failed to remove Jellyfin item", "title", item.Title, "error", err)
-				// Continue even if Jellyfin removal fails, as Sonarr deletion succeeded
-			}
-
-			deletedItems["TV Shows"] = append(deletedItems["TV Shows"], arr.MediaItem{
-				Title:     item.Title,
-				Year:      item.Year,
-				MediaType: models.MediaTypeTV,
-			})
-
-		case database.MediaTypeMovie:
-			if e.radarr == nil {
-				log.Warn("Radarr client not configured, cannot delete movie", "title", item.Title)
-				continue
-			}
-			if err := e.radarr.DeleteMedia(ctx, item.ArrID, item.Title); err != nil {
-				log.Error("failed to delete Radarr media", "title", item.Title, "error", err)
-				continue
-			}
-
-			// Also remove from Jellyfin (always entire movie)
-			if err := e.removeJellyfinItem(ctx, item); err != nil {
-				log.Error("failed to remove Jellyfin item", "title", item.Title, "error", err)
-				// Continue even if Jellyfin removal fails, as Radarr deletion succeeded
-			}
-
-			deletedItems["Movies"] = append(deletedItems["Movies"], arr.MediaItem{
-				Title:     item.Title,
-				Year:      item.Year,
-				MediaType: models.MediaTypeMovie,
-			})
-
-		default:
-			log.Error("unsupported media type for deletion", "mediaType", item.MediaType)
+		if e.inDeletionCoolDown(item, time.Now()) {
+			log.FromContext(ctx).Info("skipping deletion for media item, it was marked too recently", "title", item.Title, "markedAt", item.CreatedAt, "minMarkToDeleteHours", e.cfg.MinMarkToDeleteHours)
 			continue
 		}
-		item.DBDeleteReason = database.DBDeleteReasonDefault
 
-		if err := e.db.DeleteMediaItem(ctx, &item); err != nil {
-			log.Error("failed to delete media item from database", "title", item.Title, "error", err)
+		if e.libraryFloorReached(remaining, item.LibraryName) {
+			log.FromContext(ctx).Warn("sparing media item, library would drop below the minimum item count", "title", item.Title, "library", item.LibraryName, "minItems", e.cfg.MinItemsPerLibrary)
+			spared = append(spared, item.Title)
 			continue
 		}
 
-		if err := e.CreateDeletedEvent(ctx, &item); err != nil {
-			log.Error("failed to create deletion event", "title", item.Title, "error", err)
+		if e.deletionBudgetReached(attempted) {
+			log.FromContext(ctx).Info("deletion limit of this run reached, deferring the remaining media items to the next run", "maxItemsPerRun", e.cfg.MaxItemsPerRun)
+			break
+		}
+		attempted++
+
+		if e.cfg.DryRun {
+			log.FromContext(ctx).Info("[Dry Run] Would delete media item", "title", item.Title, "library", item.LibraryName)
+			e.addDryRunReportEntry(item, dryRunReasonDelete, time.Now())
+			remaining[item.LibraryName]--
+			continue
 		}
+
+		if e.deleteItem(ctx, item, deletedItems, database.HistoryEventDeleted) {
+			remaining[item.LibraryName]--
+		}
+	}
+
+	if len(spared) > 0 {
+		log.FromContext(ctx).Warn("deferred deletion of media items to protect the minimum library size", "count", len(spared), "items", spared)
+	}
+
+	e.cleanupUnkeptSeasons(ctx, remaining, attempted, deletedItems)
+
+	if err := e.writeDryRunReport(ctx); err != nil {
+		log.FromContext(ctx).Error("failed to write dry-run report", "error", err)
 	}
 
-	// Send completion notification if any items were deleted
-	if len(deletedItems) > 0 {
-		if err := e.sendNtfyDeletionCompletedNotification(ctx, deletedItems); err != nil {
-			log.Error("failed to send deletion completed notification", "error", err)
+	e.sendDeletionCompletedNotifications(ctx, deletedItems)
+
+	return nil
+}
+
+// libraryFloorReached reports whether deleting another item would drop the library below the configured minimum item count.
+// remaining holds the number of items left per library and is updated by the caller after each deletion.
+func (e *Engine) libraryFloorReached(remaining map[string]int, libraryName string) bool {
+	if e.cfg.MinItemsPerLibrary <= 0 {
+		return false
+	}
+	return remaining[libraryName] <= e.cfg.MinItemsPerLibrary
+}
+
+// inDeletionCoolDown reports whether the item was marked less than the configured cool-down ago.
+func (e *Engine) inDeletionCoolDown(item database.Media, now time.Time) bool {
+	if e.cfg.MinMarkToDeleteHours <= 0 {
+		return false
+	}
+	return now.Sub(item.CreatedAt) < time.Duration(e.cfg.MinMarkToDeleteHours)*time.Hour
+}
+
+// deleteItem runs the pre-delete hooks, deletes the media item and records the deletion with the given history event.
+// A failed deletion is recorded for the deletion retry job. It reports whether the item was deleted.
+func (e *Engine) deleteItem(ctx context.Context, item database.Media, deletedItems map[string][]arr.MediaItem, eventType database.HistoryEventType) bool {
+	if e.downloadInProgress(ctx, item) {
+		log.FromContext(ctx).Info("skipping deletion for media item, a download or import is in progress", "title", item.Title)
+		return false
+	}
+
+	if err := e.runPreDeleteHooks(ctx, item); err != nil {
+		log.FromContext(ctx).Error("pre-delete hook failed, skipping deletion", "title", item.Title, "error", err)
+		return false
+	}
+
+	if err := e.deleteMedia(ctx, item); err != nil {
+		if errors.Is(err, errCannotDelete) {
+			log.FromContext(ctx).Warn("cannot delete media item", "title", item.Title, "error", err)
+			return false
 		}
+		log.FromContext(ctx).Error("failed to delete media item", "title", item.Title, "error", err)
+		e.recordDeletionFailure(ctx, item, err)
+		return false
 	}
+	e.recordDeleted(item.FileSize)
+	e.deleteJellyseerrRequest(ctx, item)
+	e.finishDeletion(ctx, item, deletedItems, eventType)
+	return true
+}
 
+// downloadInProgress reports whether Sonarr/Radarr has a download or import of the media item in progress, if skip_if_downloading is enabled.
+// The queue is checked right before the deletion, since a download may have started after the item was marked.
+// If the queue can't be retrieved, the item is deleted like in the downloading filter.
+func (e *Engine) downloadInProgress(ctx context.Context, item database.Media) bool {
+	if !e.cfg.SkipIfDownloading {
+		return false
+	}
+
+	var client arr.Arrer
+	switch item.MediaType {
+	case database.MediaTypeTV:
+		client = e.sonarr
+	case database.MediaTypeMovie:
+		client = e.radarr
+	}
+	if client == nil {
+		return false
+	}
+
+	ids, err := client.GetDownloadingIDs(ctx)
+	if err != nil {
+		log.FromContext(ctx).Warn("failed to get the download queue", "title", item.Title, "error", err)
+		return false
+	}
+	return ids[item.ArrID]
+}
+
+// deleteMedia deletes the media item in Sonarr/Radarr and removes it from Jellyfin.
+// It returns errCannotDelete if the item can't be deleted because of the configuration.
+func (e *Engine) deleteMedia(ctx context.Context, item database.Media) error {
+	switch item.MediaType {
+	case database.MediaTypeTV:
+		if e.sonarr == nil {
+			return fmt.Errorf("%w: sonarr client not configured", errCannotDelete)
+		}
+		if err := e.sonarr.DeleteMedia(ctx, item.ArrID, item.Title); err != nil {
+			return fmt.Errorf("failed to delete Sonarr media: %w", err)
+		}
+
+		// Also remove from Jellyfin according to cleanup mode
+		if err := e.removeJellyfinItem(ctx, item); err != nil {
+			log.FromContext(ctx).Error("failed to remove Jellyfin item", "title", item.Title, "error", err)
+			// Continue even if Jellyfin removal fails, as Sonarr deletion succeeded
+		}
+
+	case database.MediaTypeMovie:
+		if e.radarr == nil {
+			return fmt.Errorf("%w: radarr client not configured", errCannotDelete)
+		}
+		if err := e.radarr.DeleteMedia(ctx, item.ArrID, item.Title); err != nil {
+			return fmt.Errorf("failed to delete Radarr media: %w", err)
+		}
+
+		// Also remove from Jellyfin (always entire movie)
+		if err := e.removeJellyfinItem(ctx, item); err != nil {
+			log.FromContext(ctx).Error("failed to remove Jellyfin item", "title", item.Title, "error", err)
+			// Continue even if Jellyfin removal fails, as Radarr deletion succeeded
+		}
+
+	case database.MediaTypeBook:
+		if e.readarr == nil {
+			return fmt.Errorf("%w: readarr client not configured", errCannotDelete)
+		}
+		if err := e.readarr.DeleteMedia(ctx, item.ArrID, item.Title); err != nil {
+			return fmt.Errorf("failed to delete Readarr media: %w", err)
+		}
+
+		// Also remove from Jellyfin (always the entire book)
+		if err := e.removeJellyfinItem(ctx, item); err != nil {
+			log.FromContext(ctx).Error("failed to remove Jellyfin item", "title", item.Title, "error", err)
+			// Continue even if Jellyfin removal fails, as Readarr deletion succeeded
+		}
+
+	default:
+		return fmt.Errorf("%w: unsupported media type %s", errCannotDelete, item.MediaType)
+	}
 	return nil
 }
 
+// finishDeletion removes a deleted media item from the leaving collections and the database and records the deletion
+// with the given history event. The former keep requester is notified if the item was deleted after its protection expired.
+func (e *Engine) finishDeletion(ctx context.Context, item database.Media, deletedItems map[string][]arr.MediaItem, eventType database.HistoryEventType) {
+	e.removeFromLeavingCollections(ctx, item)
+
+	mediaType := models.MediaType(item.MediaType)
+	// the library name was resolved from the media server when the item was gathered
+	deletedItems[item.LibraryName] = append(deletedItems[item.LibraryName], arr.MediaItem{
+		Title:       item.Title,
+		Year:        item.Year,
+		FileSize:    item.FileSize,
+		MediaType:   mediaType,
+		LibraryName: item.LibraryName,
+	})
+
+	item.DBDeleteReason = database.DBDeleteReasonDefault
+	if err := e.db.DeleteMediaItem(ctx, &item); err != nil {
+		log.FromContext(ctx).Error("failed to delete media item from database", "title", item.Title, "error", err)
+		return
+	}
+
+	if err := e.CreateDeletedEvent(ctx, &item, eventType); err != nil {
+		log.FromContext(ctx).Error("failed to create deletion event", "title", item.Title, "error", err)
+	}
+
+	if err := e.db.DeleteDeletionFailure(ctx, item.ID); err != nil {
+		log.FromContext(ctx).Error("failed to remove deletion failure", "title", item.Title, "error", err)
+	}
+
+	e.notifyKeptMediaDeleted(ctx, item)
+}
+
 func (e *Engine) removeJellyfinItem(ctx context.Context, item database.Media) error {
 	// Determine the Jellyfin item type based on media type
 	var itemType jellyfin.BaseItemKind
@@ -113,8 +283,10 @@ func (e *Engine) removeJellyfinItem(ctx context.Context, item database.Media) er
 		itemType = jellyfin.BASEITEMKIND_MOVIE
 	case database.MediaTypeTV:
 		itemType = jellyfin.BASEITEMKIND_SERIES
+	case database.MediaTypeBook:
+		itemType = jellyfin.BASEITEMKIND_BOOK
 	default:
-		log.Warn("unknown media type for Jellyfin cleanup", "mediaType", item.MediaType)
+		log.FromContext(ctx).Warn("unknown media type for Jellyfin cleanup", "mediaType", item.MediaType)
 		return nil
 	}
 
@@ -123,9 +295,27 @@ func (e *Engine) removeJellyfinItem(ctx context.Context, item database.Media) er
 	keepCount := e.cfg.GetKeepCount()
 
 	if err := e.jellyfin.RemoveItemWithCleanupMode(ctx, item.JellyfinID, item.Title, itemType, cleanupMode, keepCount); err != nil {
-		log.Error("failed to remove jellyfin item", "jellyfinID", item.JellyfinID, "error", err)
+		log.FromContext(ctx).Error("failed to remove jellyfin item", "jellyfinID", item.JellyfinID, "error", err)
 		return err
 	}
 
 	return nil
 }
+
+// runPreDeleteHooks invokes the configured pre-delete hooks for the media item.
+func (e *Engine) runPreDeleteHooks(ctx context.Context, item database.Media) error {
+	if e.hooks == nil {
+		return nil
+	}
+
+	return e.hooks.PreDelete(ctx, hooks.Payload{
+		Title:     item.Title,
+		Year:      item.Year,
+		MediaType: string(item.MediaType),
+		Library:   item.LibraryName,
+		Path:      item.Path,
+		TmdbID:    item.TmdbId,
+		TvdbID:    item.TvdbId,
+		Size:      item.FileSize,
+	})
+}
@@ -3,6 +3,7 @@ package engine
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/jon4hz/jellysweep/internal/database"
@@ -13,54 +14,66 @@ import (
 // There are separate collections for movies and TV shows.
 func (e *Engine) createJellyfinLeavingCollections(ctx context.Context) error {
 	if !e.cfg.LeavingCollectionsEnabled {
-		log.Debug("Leaving collections feature is disabled, skipping")
+		log.FromContext(ctx).Debug("Leaving collections feature is disabled, skipping")
 		return nil
 	}
 
-	log.Info("Creating/updating Jellyfin leaving collections")
+	log.FromContext(ctx).Info("Creating/updating Jellyfin leaving collections")
 
 	// Get all media items currently marked for deletion from the database
 	mediaItems, err := e.db.GetMediaItems(ctx, false) // Don't include protected items
 	if err != nil {
-		log.Error("Failed to get media items from database", "error", err)
+		log.FromContext(ctx).Error("Failed to get media items from database", "error", err)
 		return fmt.Errorf("failed to get media items from database: %w", err)
 	}
 
 	if len(mediaItems) == 0 {
-		log.Debug("No media items marked for deletion, skipping collection creation")
+		log.FromContext(ctx).Debug("No media items marked for deletion, skipping collection creation")
 		return nil
 	}
 
 	// Separate items by media type and collect their Jellyfin IDs
 	leavingMovies := []string{}
 	leavingTVShows := []string{}
+	var leavingMovieItems, leavingTVShowItems []database.Media
 
+	now := time.Now()
 	for _, item := range mediaItems {
+		if !e.isInLeavingWindow(item, now) {
+			log.FromContext(ctx).Debug("Item is not leaving within the configured window, skipping", "title", item.Title, "deleteAt", item.DefaultDeleteAt)
+			continue
+		}
 		switch item.MediaType {
 		case database.MediaTypeMovie:
 			leavingMovies = append(leavingMovies, item.JellyfinID)
+			leavingMovieItems = append(leavingMovieItems, item)
 		case database.MediaTypeTV:
 			leavingTVShows = append(leavingTVShows, item.JellyfinID)
+			leavingTVShowItems = append(leavingTVShowItems, item)
+		case database.MediaTypeBook:
+			// there are no leaving collections for books
 		default:
-			log.Warn("Unknown media type", "type", item.MediaType, "title", item.Title)
+			log.FromContext(ctx).Warn("Unknown media type", "type", item.MediaType, "title", item.Title)
 		}
 	}
 
 	// Create/update leaving collections if we have items
 	if len(leavingMovies) > 0 {
 		if err := e.createOrUpdateLeavingCollection(ctx, e.cfg.LeavingCollectionsMovieName, leavingMovies); err != nil {
-			log.Error("Failed to create/update leaving movies collection", "error", err)
+			log.FromContext(ctx).Error("Failed to create/update leaving movies collection", "error", err)
 			return fmt.Errorf("failed to create/update leaving movies collection: %w", err)
 		}
-		log.Info("Updated leaving movies collection", "count", len(leavingMovies))
+		e.updateLeavingCollectionOverview(ctx, e.cfg.LeavingCollectionsMovieName, leavingMovieItems)
+		log.FromContext(ctx).Info("Updated leaving movies collection", "count", len(leavingMovies))
 	}
 
 	if len(leavingTVShows) > 0 {
 		if err := e.createOrUpdateLeavingCollection(ctx, e.cfg.LeavingCollectionsTVName, leavingTVShows); err != nil {
-			log.Error("Failed to create/update leaving TV shows collection", "error", err)
+			log.FromContext(ctx).Error("Failed to create/update leaving TV shows collection", "error", err)
 			return fmt.Errorf("failed to create/update leaving TV shows collection: %w", err)
 		}
-		log.Info("Updated leaving TV shows collection", "count", len(leavingTVShows))
+		e.updateLeavingCollectionOverview(ctx, e.cfg.LeavingCollectionsTVName, leavingTVShowItems)
+		log.FromContext(ctx).Info("Updated leaving TV shows collection", "count", len(leavingTVShows))
 	}
 
 	return nil
@@ -76,12 +89,12 @@ func (e *Engine) createOrUpdateLeavingCollection(ctx context.Context, collection
 
 	if existingCollectionID != "" {
 		// Collection exists, update it to match the current item list
-		log.Debug("Found existing collection, updating items", "collection", collectionName, "id", existingCollectionID)
+		log.FromContext(ctx).Debug("Found existing collection, updating items", "collection", collectionName, "id", existingCollectionID)
 
 		// Get current items in the collection
 		currentItems, err := e.jellyfin.GetCollectionItems(ctx, existingCollectionID)
 		if err != nil {
-			log.Warn("Failed to get current collection items", "collection", collectionName, "error", err)
+			log.FromContext(ctx).Warn("Failed to get current collection items", "collection", collectionName, "error", err)
 			// Continue anyway, try to add all items
 			currentItems = make(map[string]bool)
 		}
@@ -96,16 +109,16 @@ func (e *Engine) createOrUpdateLeavingCollection(ctx context.Context, collection
 
 		// Add new items to the collection
 		if len(itemsToAdd) > 0 {
-			log.Debug("Adding new items to collection", "collection", collectionName, "count", len(itemsToAdd))
+			log.FromContext(ctx).Debug("Adding new items to collection", "collection", collectionName, "count", len(itemsToAdd))
 			if err = e.jellyfin.AddItemsToCollection(ctx, existingCollectionID, itemsToAdd); err != nil {
 				return fmt.Errorf("failed to add items to existing collection %s: %w", collectionName, err)
 			}
 		} else {
-			log.Debug("No new items to add to collection", "collection", collectionName)
+			log.FromContext(ctx).Debug("No new items to add to collection", "collection", collectionName)
 		}
 	} else {
 		// Collection doesn't exist, create it
-		log.Debug("Creating new collection", "collection", collectionName)
+		log.FromContext(ctx).Debug("Creating new collection", "collection", collectionName)
 
 		if err := e.jellyfin.CreateCollection(ctx, collectionName, itemIDs); err != nil {
 			return fmt.Errorf("failed to create collection %s: %w", collectionName, err)
@@ -118,27 +131,27 @@ func (e *Engine) createOrUpdateLeavingCollection(ctx context.Context, collection
 // removeItemsFromLeavingCollections removes items from the leaving collections if they are no longer marked for deletion.
 func (e *Engine) removeItemsFromLeavingCollections(ctx context.Context) {
 	if !e.cfg.LeavingCollectionsEnabled {
-		log.Debug("Leaving collections feature is disabled, skipping cleanup")
+		log.FromContext(ctx).Debug("Leaving collections feature is disabled, skipping cleanup")
 		return
 	}
 
-	log.Info("Cleaning up leaving collections")
+	log.FromContext(ctx).Info("Cleaning up leaving collections")
 
 	// Find leaving collections
 	moviesCollectionID, err := e.jellyfin.FindCollectionByName(ctx, e.cfg.LeavingCollectionsMovieName)
 	if err != nil {
-		log.Warn("Failed to find leaving movies collection", "error", err)
+		log.FromContext(ctx).Warn("Failed to find leaving movies collection", "error", err)
 	}
 
 	tvShowsCollectionID, err := e.jellyfin.FindCollectionByName(ctx, e.cfg.LeavingCollectionsTVName)
 	if err != nil {
-		log.Warn("Failed to find leaving TV shows collection", "error", err)
+		log.FromContext(ctx).Warn("Failed to find leaving TV shows collection", "error", err)
 	}
 
 	// Get all items currently marked for deletion from database
 	mediaItems, err := e.db.GetMediaItems(ctx, false) // Don't include protected items
 	if err != nil {
-		log.Warn("Failed to get currently marked items from database", "error", err)
+		log.FromContext(ctx).Warn("Failed to get currently marked items from database", "error", err)
 		return
 	}
 
@@ -146,7 +159,12 @@ func (e *Engine) removeItemsFromLeavingCollections(ctx context.Context) {
 	currentlyLeavingMovies := make(map[string]bool)
 	currentlyLeavingTVShows := make(map[string]bool)
 
+	now := time.Now()
 	for _, item := range mediaItems {
+		// Items that are deleted further out than the window are removed until they enter it again.
+		if !e.isInLeavingWindow(item, now) {
+			continue
+		}
 		switch item.MediaType {
 		case database.MediaTypeMovie:
 			currentlyLeavingMovies[item.JellyfinID] = true
@@ -158,18 +176,42 @@ func (e *Engine) removeItemsFromLeavingCollections(ctx context.Context) {
 	// Remove items from leaving movies collection if they're no longer marked for deletion
 	if moviesCollectionID != "" {
 		if err := e.removeItemsNotInSet(ctx, moviesCollectionID, currentlyLeavingMovies, e.cfg.LeavingCollectionsMovieName); err != nil {
-			log.Error("Failed to clean up leaving movies collection", "error", err)
+			log.FromContext(ctx).Error("Failed to clean up leaving movies collection", "error", err)
 		}
 	}
 
 	// Remove items from leaving TV shows collection if they're no longer marked for deletion
 	if tvShowsCollectionID != "" {
 		if err := e.removeItemsNotInSet(ctx, tvShowsCollectionID, currentlyLeavingTVShows, e.cfg.LeavingCollectionsTVName); err != nil {
-			log.Error("Failed to clean up leaving TV shows collection", "error", err)
+			log.FromContext(ctx).Error("Failed to clean up leaving TV shows collection", "error", err)
+		}
+	}
+}
+
+// removeFromLeavingCollections removes a deleted item from the leaving collections right away,
+// so users don't find a dead entry there until the next scan cleans up the collections.
+func (e *Engine) removeFromLeavingCollections(ctx context.Context, item database.Media) {
+	if !e.cfg.LeavingCollectionsEnabled || item.JellyfinID == "" {
+		return
+	}
+
+	for _, collectionName := range []string{e.cfg.LeavingCollectionsMovieName, e.cfg.LeavingCollectionsTVName} {
+		if err := e.jellyfin.RemoveItemFromCollection(ctx, collectionName, item.JellyfinID); err != nil {
+			log.FromContext(ctx).Warn("Failed to remove deleted item from leaving collection", "title", item.Title, "collection", collectionName, "error", err)
 		}
 	}
 }
 
+// isInLeavingWindow reports whether the item is deleted within the configured leaving collections window.
+// The default deletion date is used as projection, since disk usage based deletions can't be predicted.
+func (e *Engine) isInLeavingWindow(item database.Media, now time.Time) bool {
+	if e.cfg.LeavingCollectionsWindowDays <= 0 {
+		return true
+	}
+	windowEnd := now.Add(time.Duration(e.cfg.LeavingCollectionsWindowDays) * 24 * time.Hour)
+	return !item.DefaultDeleteAt.After(windowEnd)
+}
+
 // removeItemsNotInSet removes items from a collection if they are not in the provided set.
 func (e *Engine) removeItemsNotInSet(ctx context.Context, collectionID string, shouldKeepSet map[string]bool, collectionName string) error {
 	// Get current items in the collection
@@ -182,13 +224,13 @@ func (e *Engine) removeItemsNotInSet(ctx context.Context, collectionID string, s
 	for itemID := range currentItems {
 		if !shouldKeepSet[itemID] {
 			itemsToRemove = append(itemsToRemove, itemID)
-			log.Debug("Marking item for removal from collection", "collection", collectionName, "itemID", itemID)
+			log.FromContext(ctx).Debug("Marking item for removal from collection", "collection", collectionName, "itemID", itemID)
 		}
 	}
 
 	// Remove items that should no longer be in the collection
 	if len(itemsToRemove) > 0 {
-		log.Info("Removing items from leaving collection", "collection", collectionName, "count", len(itemsToRemove))
+		log.FromContext(ctx).Info("Removing items from leaving collection", "collection", collectionName, "count", len(itemsToRemove))
 
 		if err := e.jellyfin.RemoveItemsFromCollection(ctx, collectionID, itemsToRemove); err != nil {
 			return fmt.Errorf("failed to remove items from collection %s: %w", collectionName, err)
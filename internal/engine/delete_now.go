@@ -0,0 +1,95 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/tags"
+)
+
+// splitDeleteNowItems separates the media items tagged for immediate deletion from the others.
+// The ignore tag takes precedence, items with both tags are treated like ignored items.
+func splitDeleteNowItems(mediaItems []arr.MediaItem) (deleteNow, others []arr.MediaItem) {
+	for _, item := range mediaItems {
+		if slices.Contains(item.Tags, tags.JellysweepDeleteNowTag) && !slices.Contains(item.Tags, tags.JellysweepIgnoreTag) {
+			deleteNow = append(deleteNow, item)
+			continue
+		}
+		others = append(others, item)
+	}
+	return deleteNow, others
+}
+
+// markForDeletionNow schedules the media items tagged for immediate deletion for the next cleanup,
+// bypassing all filters. Items already in the database are moved up, unless they are protected or permanently ignored.
+func (e *Engine) markForDeletionNow(ctx context.Context, mediaItems []arr.MediaItem) error {
+	if len(mediaItems) == 0 {
+		return nil
+	}
+
+	dbItems, err := e.db.GetMediaItems(ctx, true)
+	if err != nil {
+		return fmt.Errorf("failed to get media items: %w", err)
+	}
+	ignoredItems, err := e.db.GetIgnoredMedia(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get ignored media: %w", err)
+	}
+
+	now := time.Now()
+	newItems := make([]database.Media, 0, len(mediaItems))
+	for _, item := range mediaItems {
+		dbItem := arrMediaToDBMediaItem(item)
+		sameItem := func(other database.Media) bool {
+			// the IDs of the arrs can overlap, so the media type has to match as well
+			return other.MediaType == dbItem.MediaType && other.ArrID == dbItem.ArrID
+		}
+
+		if slices.ContainsFunc(ignoredItems, sameItem) {
+			log.FromContext(ctx).Debug("Skipping permanently ignored item tagged for immediate deletion", "title", dbItem.Title)
+			continue
+		}
+
+		if i := slices.IndexFunc(dbItems, sameItem); i >= 0 {
+			existing := dbItems[i]
+			if existing.ProtectedUntil != nil && existing.ProtectedUntil.After(now) {
+				log.FromContext(ctx).Warn("Skipping protected item tagged for immediate deletion", "title", existing.Title, "protectedUntil", existing.ProtectedUntil)
+				continue
+			}
+			if existing.DefaultDeleteAt.After(now) {
+				log.FromContext(ctx).Info("Moving deletion of item tagged for immediate deletion to now", "title", existing.Title)
+				if err := e.db.SetMediaDefaultDeleteAt(ctx, existing.ID, now); err != nil {
+					log.FromContext(ctx).Error("failed to set deletion date of item tagged for immediate deletion", "title", existing.Title, "error", err)
+				}
+			}
+			continue
+		}
+
+		log.FromContext(ctx).Info("Marking item tagged for immediate deletion", "title", dbItem.Title, "library", dbItem.LibraryName)
+		dbItem.PosterURL = e.posterOrDefault(dbItem.PosterURL)
+		dbItem.DefaultDeleteAt = now
+		dbItem.ForceDelete = true
+		dbItem.Unkeepable = true
+		dbItem.DeletionReason = "tagged for immediate deletion"
+		newItems = append(newItems, dbItem)
+		e.addDryRunReportEntry(dbItem, dryRunReasonDeleteNow, now)
+	}
+
+	if len(newItems) == 0 {
+		return nil
+	}
+	if err := e.db.CreateMediaItems(ctx, newItems); err != nil {
+		return fmt.Errorf("failed to create media items tagged for immediate deletion: %w", err)
+	}
+	for i := range newItems {
+		if err := e.CreatePickedUpEvent(ctx, &newItems[i]); err != nil {
+			log.FromContext(ctx).Error("failed to create picked up event", "title", newItems[i].Title, "error", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/notify/webhook"
+)
+
+// errCannotDelete is returned if a media item can't be deleted because of the configuration.
+// Such items aren't recorded as deletion failures, since retrying them wouldn't help.
+var errCannotDelete = errors.New("media item cannot be deleted")
+
+// ErrNoDeletionFailure is returned if a deletion failure should be reset, but none is recorded for the media item.
+var ErrNoDeletionFailure = errors.New("no deletion failure recorded")
+
+// getDeletionFailureIDs returns the IDs of all media items with a recorded deletion failure.
+func (e *Engine) getDeletionFailureIDs(ctx context.Context) (map[uint]bool, error) {
+	failures, err := e.db.GetDeletionFailures(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[uint]bool, len(failures))
+	for _, failure := range failures {
+		ids[failure.MediaID] = true
+	}
+	return ids, nil
+}
+
+// ResetDeletionFailure removes the recorded deletion failure of a media item, including a given up one.
+// The item is then deleted again by the next cleanup run or forced deletion once its deletion date has passed.
+func (e *Engine) ResetDeletionFailure(ctx context.Context, mediaID uint) error {
+	if e.MaintenanceMode() {
+		return ErrMaintenanceMode
+	}
+
+	failure, err := e.db.GetDeletionFailure(ctx, mediaID)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if failure.Attempts == 0 {
+		return ErrNoDeletionFailure
+	}
+
+	if err := e.db.DeleteDeletionFailure(ctx, mediaID); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	log.FromContext(ctx).Info("reset deletion failure", "mediaID", mediaID, "attempts", failure.Attempts, "gaveUp", failure.GaveUp)
+	return nil
+}
+
+// retryDelay returns the delay before the next retry after the given number of failed attempts.
+func (e *Engine) retryDelay(attempts int) time.Duration {
+	initialDelay := time.Duration(e.cfg.DeletionRetry.InitialDelay) * time.Minute
+	maxDelay := time.Duration(e.cfg.DeletionRetry.MaxDelay) * time.Minute
+
+	delay := initialDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxDelay {
+			return maxDelay
+		}
+	}
+	return min(delay, maxDelay)
+}
+
+// recordDeletionFailure records a failed deletion attempt of a media item.
+// Once the maximum number of attempts is reached, the deletion is given up and the admins are notified.
+func (e *Engine) recordDeletionFailure(ctx context.Context, item database.Media, deleteErr error) {
+	failure, err := e.db.GetDeletionFailure(ctx, item.ID)
+	if err != nil {
+		log.FromContext(ctx).Error("failed to get deletion failure", "title", item.Title, "error", err)
+		return
+	}
+
+	now := time.Now()
+	failure.Attempts++
+	failure.Error = deleteErr.Error()
+	failure.LastAttemptAt = now
+	failure.NextRetryAt = now.Add(e.retryDelay(failure.Attempts))
+	failure.GaveUp = failure.Attempts >= e.cfg.DeletionRetry.MaxAttempts
+
+	if err := e.db.SaveDeletionFailure(ctx, failure); err != nil {
+		log.FromContext(ctx).Error("failed to save deletion failure", "title", item.Title, "error", err)
+		return
+	}
+
+	if !failure.GaveUp {
+		log.FromContext(ctx).Info("scheduled retry for failed deletion", "title", item.Title, "attempts", failure.Attempts, "next_retry_at", failure.NextRetryAt)
+		return
+	}
+
+	log.FromContext(ctx).Warn("giving up deletion of media item", "title", item.Title, "attempts", failure.Attempts)
+	e.notifyDeletionGaveUp(ctx, item, failure)
+}
+
+// notifyDeletionGaveUp notifies the admins about a deletion that was given up.
+func (e *Engine) notifyDeletionGaveUp(ctx context.Context, item database.Media, failure *database.DeletionFailure) {
+	if e.ntfy != nil && e.cfg.Ntfy.Events.Includes(config.NotificationEventDeletionFailed) {
+		if err := e.ntfy.SendDeletionFailed(ctx, item.Title, string(item.MediaType), failure.Error, failure.Attempts); err != nil {
+			log.FromContext(ctx).Error("failed to send ntfy deletion failed notification", "error", err)
+		}
+	}
+
+	if e.gotify != nil && e.cfg.Gotify.Events.Includes(config.NotificationEventDeletionFailed) {
+		if err := e.gotify.SendDeletionFailed(ctx, item.Title, string(item.MediaType), failure.Error, failure.Attempts); err != nil {
+			log.FromContext(ctx).Error("failed to send gotify deletion failed notification", "error", err)
+		}
+	}
+
+	if e.slack != nil && e.cfg.Slack.Events.Includes(config.NotificationEventDeletionFailed) {
+		if err := e.slack.SendDeletionFailed(ctx, item.Title, string(item.MediaType), failure.Error, failure.Attempts); err != nil {
+			log.FromContext(ctx).Error("failed to send slack deletion failed notification", "error", err)
+		}
+	}
+
+	if e.matrix != nil && e.cfg.Matrix.Events.Includes(config.NotificationEventDeletionFailed) {
+		if err := e.matrix.SendDeletionFailed(ctx, item.Title, string(item.MediaType), failure.Error, failure.Attempts); err != nil {
+			log.FromContext(ctx).Error("failed to send matrix deletion failed notification", "error", err)
+		}
+	}
+
+	if e.apprise != nil && e.cfg.Apprise.Events.Includes(config.NotificationEventDeletionFailed) {
+		if err := e.apprise.SendDeletionFailed(ctx, item.Title, string(item.MediaType), failure.Error, failure.Attempts); err != nil {
+			log.FromContext(ctx).Error("failed to send apprise deletion failed notification", "error", err)
+		}
+	}
+
+	if e.pushover != nil && e.cfg.Pushover.Events.Includes(config.NotificationEventDeletionFailed) {
+		if err := e.pushover.SendDeletionFailed(ctx, item.Title, string(item.MediaType), failure.Error, failure.Attempts); err != nil {
+			log.FromContext(ctx).Error("failed to send pushover deletion failed notification", "error", err)
+		}
+	}
+
+	if e.webhook != nil && e.cfg.Webhook.Events.Includes(config.NotificationEventDeletionFailed) {
+		webhookItem := webhook.MediaItem{Title: item.Title, Type: string(item.MediaType), Year: item.Year, Library: item.LibraryName}
+		if err := e.webhook.SendDeletionFailed(ctx, webhookItem, failure.Error, failure.Attempts); err != nil {
+			log.FromContext(ctx).Error("failed to send webhook deletion failed notification", "error", err)
+		}
+	}
+}
+
+// retryFailedDeletions retries all failed deletions that are due.
+func (e *Engine) retryFailedDeletions(ctx context.Context) error {
+	if e.cfg.DryRun {
+		log.FromContext(ctx).Debug("Dry run enabled, skipping retry of failed deletions")
+		return nil
+	}
+
+	failures, err := e.db.GetDueDeletionFailures(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("retrying failed deletions", "count", len(failures))
+
+	e.deleteMu.Lock()
+	defer e.deleteMu.Unlock()
+
+	protectedCollections, err := e.protectedCollections(ctx)
+	if err != nil {
+		log.FromContext(ctx).Error("failed to get protected collections", "error", err)
+		return err
+	}
+
+	remaining := maps.Clone(e.data.libraryItemCounts)
+	deletedItems := make(map[string][]arr.MediaItem)
+	for _, failure := range failures {
+		item := failure.Media
+		// the media item was removed in the meantime (e.g. it was protected or isn't found anymore)
+		if item.ID == 0 {
+			if err := e.db.DeleteDeletionFailure(ctx, failure.MediaID); err != nil {
+				log.FromContext(ctx).Error("failed to remove stale deletion failure", "mediaID", failure.MediaID, "error", err)
+			}
+			continue
+		}
+		if item.ProtectedUntil != nil && item.ProtectedUntil.After(time.Now()) {
+			log.FromContext(ctx).Debug("skipping retry of protected media item", "title", item.Title)
+			continue
+		}
+		if protectedCollections[item.CollectionID] {
+			log.FromContext(ctx).Info("skipping retry of media item, another movie of its collection is protected", "title", item.Title)
+			continue
+		}
+		if e.libraryFloorReached(remaining, item.LibraryName) {
+			log.FromContext(ctx).Warn("sparing media item, library would drop below the minimum item count", "title", item.Title, "library", item.LibraryName, "minItems", e.cfg.MinItemsPerLibrary)
+			continue
+		}
+
+		if !e.deleteItem(ctx, item, deletedItems, database.HistoryEventDeleted) {
+			continue
+		}
+		log.FromContext(ctx).Info("deleted media item after retry", "title", item.Title, "attempts", failure.Attempts+1)
+		remaining[item.LibraryName]--
+	}
+
+	e.sendDeletionCompletedNotifications(ctx, deletedItems)
+
+	return nil
+}
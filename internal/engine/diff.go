@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+)
+
+const (
+	// dryRunReasonNewlyMarked is used for items that are marked for the first time since the previous run.
+	dryRunReasonNewlyMarked = "newly_marked"
+	// dryRunReasonNoLongerMarked is used for items of the previous runs that wouldn't be marked anymore.
+	dryRunReasonNoLongerMarked = "no_longer_marked"
+)
+
+// MarkedItemsDiff holds the changes of the marked items compared to the items recorded by the previous runs.
+type MarkedItemsDiff struct {
+	// Added are the items that would be marked now but aren't recorded yet.
+	Added []database.Media
+	// Removed are the recorded items that wouldn't be marked anymore.
+	Removed []database.Media
+}
+
+// GetMarkedItemsDiff gathers and filters the media items like a cleanup run, without recording anything,
+// and compares them to the items already recorded in the database.
+func (e *Engine) GetMarkedItemsDiff(ctx context.Context) (*MarkedItemsDiff, error) {
+	e.clientsMu.RLock()
+	defer e.clientsMu.RUnlock()
+
+	gathered, err := e.collectMediaItems(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to gather media items: %w", err)
+	}
+	mediaItems := gathered.items
+	if e.cfg.HasRequesterRules() {
+		mediaItems = e.populateRequesterInfo(ctx, mediaItems)
+	}
+	return e.markedItemsDiff(ctx, mediaItems)
+}
+
+// markedItemsDiff filters the gathered media items and compares them to the recorded items.
+// The requester information must already be populated if requester rules are configured.
+func (e *Engine) markedItemsDiff(ctx context.Context, mediaItems []arr.MediaItem) (*MarkedItemsDiff, error) {
+	// The database filter drops all recorded items, so it can't be used to find the items that are still marked.
+	marked, err := e.diffFilters.ApplyAll(ctx, mediaItems, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter media items: %w", err)
+	}
+	return e.compareWithRecorded(ctx, marked)
+}
+
+// compareWithRecorded compares the marked items with the items recorded by the previous runs.
+func (e *Engine) compareWithRecorded(ctx context.Context, marked []arr.MediaItem) (*MarkedItemsDiff, error) {
+	recorded, err := e.db.GetMediaItems(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recorded media items: %w", err)
+	}
+	ignored, err := e.db.GetIgnoredMedia(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ignored media items: %w", err)
+	}
+	return diffMarkedItems(marked, recorded, ignored), nil
+}
+
+// diffMarkedItems compares the marked items with the recorded items by their Jellyfin ID.
+// Ignored items are never reported as added.
+func diffMarkedItems(marked []arr.MediaItem, recorded, ignored []database.Media) *MarkedItemsDiff {
+	markedIDs := make(map[string]struct{}, len(marked))
+	for _, item := range marked {
+		markedIDs[item.JellyfinID] = struct{}{}
+	}
+	knownIDs := make(map[string]struct{}, len(recorded)+len(ignored))
+	for _, item := range recorded {
+		knownIDs[item.JellyfinID] = struct{}{}
+	}
+	for _, item := range ignored {
+		knownIDs[item.JellyfinID] = struct{}{}
+	}
+
+	diff := &MarkedItemsDiff{
+		Added:   make([]database.Media, 0),
+		Removed: make([]database.Media, 0),
+	}
+	for _, item := range marked {
+		if _, ok := knownIDs[item.JellyfinID]; !ok {
+			diff.Added = append(diff.Added, arrMediaToDBMediaItem(item))
+		}
+	}
+	for _, item := range recorded {
+		if _, ok := markedIDs[item.JellyfinID]; !ok {
+			diff.Removed = append(diff.Removed, item)
+		}
+	}
+	return diff
+}
+
+// dryRunDiffEnabled reports whether the changes since the previous run are added to the dry-run report.
+func (e *Engine) dryRunDiffEnabled() bool {
+	return e.cfg.DryRun && e.cfg.DryRunReportPath != ""
+}
+
+// applyFiltersWithDryRunDiff filters the media items like a regular run and records the changes since the previous run in the dry-run report.
+// The database filter drops all recorded items and the scan budget only a part of the items, so both are applied
+// after the diff is computed from the result of the other filters.
+func (e *Engine) applyFiltersWithDryRunDiff(ctx context.Context, mediaItems []arr.MediaItem, skipStreamFilter bool) ([]arr.MediaItem, error) {
+	filters := e.diffFilters
+	if skipStreamFilter {
+		filters = e.statslessDiffFilters
+	}
+	onStep := e.recordFilterStep(ctx)
+	marked, err := filters.ApplyAll(ctx, mediaItems, onStep)
+	if err != nil {
+		return nil, err
+	}
+	e.addDryRunDiff(ctx, marked)
+	return e.recordFilters.ApplyAll(ctx, marked, onStep)
+}
+
+// addDryRunDiff records the changes of the marked items since the previous run in the dry-run report.
+func (e *Engine) addDryRunDiff(ctx context.Context, marked []arr.MediaItem) {
+	diff, err := e.compareWithRecorded(ctx, marked)
+	if err != nil {
+		log.FromContext(ctx).Error("failed to compare marked items with the previous run", "error", err)
+		return
+	}
+	for _, item := range diff.Added {
+		e.addDryRunReportEntry(item, dryRunReasonNewlyMarked, item.DefaultDeleteAt)
+	}
+	for _, item := range diff.Removed {
+		e.addDryRunReportEntry(item, dryRunReasonNoLongerMarked, item.DefaultDeleteAt)
+	}
+	log.FromContext(ctx).Info("Compared marked items with the previous run", "added", len(diff.Added), "removed", len(diff.Removed))
+}
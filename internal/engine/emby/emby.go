@@ -0,0 +1,651 @@
+package emby
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	jellyfinImpl "github.com/jon4hz/jellysweep/internal/engine/jellyfin"
+	"github.com/jon4hz/jellysweep/internal/engine/mediaserver"
+	"github.com/jon4hz/jellysweep/internal/version"
+	jellyfin "github.com/sj14/jellyfin-go/api"
+)
+
+var _ mediaserver.MediaServer = (*Client)(nil)
+
+// batchSize is the number of item IDs sent per collection request to avoid URL length limitations.
+const batchSize = 50
+
+// Client provides a high-level interface for interacting with Emby.
+type Client struct {
+	baseURL    string
+	apiKey     string
+	cfg        *config.Config
+	httpClient *http.Client
+}
+
+// item is the subset of the Emby BaseItemDto used by jellysweep.
+type item struct {
+	ID                string            `json:"Id"`
+	Name              string            `json:"Name"`
+	Type              string            `json:"Type"`
+	Path              string            `json:"Path"`
+	ParentID          string            `json:"ParentId"`
+	DateCreated       *time.Time        `json:"DateCreated"`
+	ProductionYear    int32             `json:"ProductionYear"`
+	IndexNumber       int32             `json:"IndexNumber"`
+	ParentIndexNumber int32             `json:"ParentIndexNumber"`
+	Tags              []string          `json:"Tags"`
+	TagItems          []tagItem         `json:"TagItems"`
+	ProviderIDs       map[string]string `json:"ProviderIds"`
+	SeriesID          string            `json:"SeriesId"`
+	UserData          *userData         `json:"UserData"`
+}
+
+// userData is the subset of the Emby UserItemDataDto used by jellysweep.
+type userData struct {
+	LastPlayedDate *time.Time `json:"LastPlayedDate"`
+}
+
+type tagItem struct {
+	Name string `json:"Name"`
+}
+
+type itemsResponse struct {
+	Items            []item `json:"Items"`
+	TotalRecordCount int32  `json:"TotalRecordCount"`
+}
+
+type virtualFolder struct {
+	Name      string   `json:"Name"`
+	Locations []string `json:"Locations"`
+}
+
+type user struct {
+	ID     string `json:"Id"`
+	Name   string `json:"Name"`
+	Policy struct {
+		IsDisabled bool `json:"IsDisabled"`
+	} `json:"Policy"`
+}
+
+type collectionResponse struct {
+	ID string `json:"Id"`
+}
+
+// New creates a new Emby client with the given configuration.
+func New(cfg *config.Config) *Client {
+	return &Client{
+		baseURL:    cfg.Emby.URL,
+		apiKey:     cfg.Emby.APIKey,
+		cfg:        cfg,
+		httpClient: config.NewHTTPClient(cfg.Emby.Timeout, cfg.Proxy, cfg.Emby.Proxy),
+	}
+}
+
+// do sends a request to the Emby API and decodes the JSON response into out (if not nil).
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, out any) error {
+	return c.doWithBody(ctx, method, path, query, nil, out)
+}
+
+// doWithBody is like do, but sends body (if not nil) JSON encoded as request body.
+func (c *Client) doWithBody(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reqBody = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("X-Emby-Token", c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("Jellysweep/%s", version.Version))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("emby returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// GetJellyfinItems retrieves all media items from enabled Emby libraries.
+// The items are converted to the jellyfin representation so they can be processed by the rest of the engine.
+func (c *Client) GetJellyfinItems(ctx context.Context) ([]arr.JellyfinItem, map[string][]string, error) {
+	allItems, err := c.fetchItems(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	libraryFoldersMap, err := c.GetLibraryFoldersMap(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return allItems, libraryFoldersMap, nil
+}
+
+// GetLibraryFoldersMap retrieves the mapping of library names to their folder paths.
+func (c *Client) GetLibraryFoldersMap(ctx context.Context) (map[string][]string, error) {
+	var folders []virtualFolder
+	if err := c.do(ctx, http.MethodGet, "/Library/VirtualFolders", nil, &folders); err != nil {
+		return nil, fmt.Errorf("failed to get virtual folders: %w", err)
+	}
+	if len(folders) == 0 {
+		log.Warn("No virtual folders found")
+	}
+
+	libraryFoldersMap := make(map[string][]string)
+	for _, folder := range folders {
+		libraryConfig := c.cfg.GetLibraryConfig(folder.Name)
+		if libraryConfig == nil || !libraryConfig.Enabled {
+			log.Debug("Skipping virtual folder for disabled library", "library", folder.Name)
+			continue
+		}
+		libraryFoldersMap[folder.Name] = folder.Locations
+	}
+
+	return libraryFoldersMap, nil
+}
+
+// fetchItems fetches all movies, series and books from the enabled libraries.
+func (c *Client) fetchItems(ctx context.Context) ([]arr.JellyfinItem, error) {
+	var mediaFolders itemsResponse
+	if err := c.do(ctx, http.MethodGet, "/Library/MediaFolders", nil, &mediaFolders); err != nil {
+		return nil, fmt.Errorf("failed to get media folders: %w", err)
+	}
+	if len(mediaFolders.Items) == 0 {
+		return nil, fmt.Errorf("no media folders found")
+	}
+
+	var allItems []arr.JellyfinItem
+	for _, folder := range mediaFolders.Items {
+		if folder.ID == "" || folder.Name == "" {
+			continue
+		}
+
+		libraryConfig := c.cfg.GetLibraryConfig(folder.Name)
+		if libraryConfig == nil || !libraryConfig.Enabled {
+			log.Debug("Skipping disabled library", "library", folder.Name)
+			continue
+		}
+
+		log.Info("Processing library", "library", folder.Name, "id", folder.ID)
+
+		libraryItems, err := c.getItems(ctx, folder.ID, true, "Movie,Series,Book,AudioBook")
+		if err != nil {
+			log.Error("Failed to get items from library", "library", folder.Name, "error", err)
+			continue
+		}
+
+		for _, it := range libraryItems {
+			allItems = append(allItems, arr.JellyfinItem{
+				BaseItemDto:       it.toJellyfin(),
+				ParentLibraryName: folder.Name,
+			})
+		}
+		log.Info("Retrieved all items from library", "library", folder.Name, "total", len(libraryItems))
+	}
+
+	return allItems, nil
+}
+
+// getItems retrieves all items below the parent, paginating through the results.
+func (c *Client) getItems(ctx context.Context, parentID string, recursive bool, itemTypes string) ([]item, error) {
+	var allItems []item
+
+	startIndex := 0
+	const limit = 1000
+	for {
+		query := url.Values{}
+		query.Set("ParentId", parentID)
+		query.Set("Recursive", strconv.FormatBool(recursive))
+		query.Set("StartIndex", strconv.Itoa(startIndex))
+		query.Set("Limit", strconv.Itoa(limit))
+		query.Set("Fields", "Path,DateCreated,Tags,ParentId,ProviderIds,ProductionYear")
+		if itemTypes != "" {
+			query.Set("IncludeItemTypes", itemTypes)
+		}
+
+		var resp itemsResponse
+		if err := c.do(ctx, http.MethodGet, "/Items", query, &resp); err != nil {
+			return nil, fmt.Errorf("failed to get items: %w", err)
+		}
+		if len(resp.Items) == 0 {
+			break
+		}
+
+		allItems = append(allItems, resp.Items...)
+		startIndex += len(resp.Items)
+		if startIndex >= int(resp.TotalRecordCount) {
+			break
+		}
+	}
+
+	return allItems, nil
+}
+
+// toJellyfin converts the emby item to a jellyfin BaseItemDto.
+func (i item) toJellyfin() jellyfin.BaseItemDto {
+	dto := jellyfin.BaseItemDto{}
+	dto.SetId(i.ID)
+	dto.SetName(i.Name)
+	dto.SetPath(i.Path)
+	dto.SetProductionYear(i.ProductionYear)
+	dto.SetIndexNumber(i.IndexNumber)
+	dto.SetParentIndexNumber(i.ParentIndexNumber)
+	if i.ParentID != "" {
+		dto.SetParentId(i.ParentID)
+	}
+	if i.DateCreated != nil {
+		dto.SetDateCreated(*i.DateCreated)
+	}
+	if i.ProviderIDs != nil {
+		dto.SetProviderIds(i.ProviderIDs)
+	}
+
+	tags := i.Tags
+	for _, tag := range i.TagItems {
+		if !slices.Contains(tags, tag.Name) {
+			tags = append(tags, tag.Name)
+		}
+	}
+	dto.SetTags(tags)
+
+	switch i.Type {
+	case "Movie":
+		dto.SetType(jellyfin.BASEITEMKIND_MOVIE)
+	case "Series":
+		dto.SetType(jellyfin.BASEITEMKIND_SERIES)
+	case "Season":
+		dto.SetType(jellyfin.BASEITEMKIND_SEASON)
+	case "Episode":
+		dto.SetType(jellyfin.BASEITEMKIND_EPISODE)
+	case "BoxSet":
+		dto.SetType(jellyfin.BASEITEMKIND_BOX_SET)
+	case "Book":
+		dto.SetType(jellyfin.BASEITEMKIND_BOOK)
+	case "AudioBook":
+		dto.SetType(jellyfin.BASEITEMKIND_AUDIO_BOOK)
+	}
+
+	return dto
+}
+
+// RemoveItem removes an item from Emby by its ID.
+func (c *Client) RemoveItem(ctx context.Context, itemID string) error {
+	if err := c.do(ctx, http.MethodDelete, "/Items/"+url.PathEscape(itemID), nil, nil); err != nil {
+		return fmt.Errorf("failed to remove item %s: %w", itemID, err)
+	}
+	return nil
+}
+
+// RemoveItemWithCleanupMode removes an item from Emby according to the cleanup mode.
+// For movies or "all" mode, it removes the entire item.
+// For TV series with "keep_episodes", "keep_seasons" or "keep_latest_episodes" mode, it removes specific episodes.
+func (c *Client) RemoveItemWithCleanupMode(ctx context.Context, itemID, title string, itemType jellyfin.BaseItemKind, cleanupMode config.CleanupMode, keepCount int) error {
+	if itemType == jellyfin.BASEITEMKIND_MOVIE || itemType == jellyfin.BASEITEMKIND_BOOK || cleanupMode == config.CleanupModeAll {
+		if err := c.RemoveItem(ctx, itemID); err != nil {
+			return err
+		}
+		log.Info("removed entire item from Emby", "title", title)
+		return nil
+	}
+
+	if itemType != jellyfin.BASEITEMKIND_SERIES {
+		return fmt.Errorf("unsupported item type for cleanup mode %s: %s", cleanupMode, itemType)
+	}
+
+	embyEpisodes, err := c.getItems(ctx, itemID, true, "Episode")
+	if err != nil {
+		return fmt.Errorf("failed to get episodes for series %s: %w", title, err)
+	}
+
+	episodes := make([]jellyfin.BaseItemDto, 0, len(embyEpisodes))
+	for _, ep := range embyEpisodes {
+		// Skip specials
+		if ep.ParentIndexNumber == 0 {
+			continue
+		}
+		episodes = append(episodes, ep.toJellyfin())
+	}
+
+	episodesToKeep := jellyfinImpl.FilterEpisodesToKeep(episodes, title, cleanupMode, keepCount)
+	var deleted int
+	for _, episode := range episodes {
+		if slices.Contains(episodesToKeep, episode.GetId()) {
+			continue
+		}
+		if err := c.RemoveItem(ctx, episode.GetId()); err != nil {
+			return fmt.Errorf("failed to delete episode: %w", err)
+		}
+		deleted++
+	}
+
+	log.Info("deleted episodes from Emby series", "title", title, "deleted", deleted, "kept", len(episodesToKeep))
+	return nil
+}
+
+// FindCollectionByName searches for a collection by name and returns its ID.
+func (c *Client) FindCollectionByName(ctx context.Context, name string) (string, error) {
+	query := url.Values{}
+	query.Set("IncludeItemTypes", "BoxSet")
+	query.Set("Recursive", "true")
+
+	var resp itemsResponse
+	if err := c.do(ctx, http.MethodGet, "/Items", query, &resp); err != nil {
+		return "", fmt.Errorf("failed to get collections: %w", err)
+	}
+
+	for _, it := range resp.Items {
+		if it.Name == name {
+			return it.ID, nil
+		}
+	}
+
+	return "", nil // Collection not found
+}
+
+// GetCollectionItems returns a map of item IDs currently in the collection.
+func (c *Client) GetCollectionItems(ctx context.Context, collectionID string) (map[string]bool, error) {
+	query := url.Values{}
+	query.Set("ParentId", collectionID)
+
+	var resp itemsResponse
+	if err := c.do(ctx, http.MethodGet, "/Items", query, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get collection items: %w", err)
+	}
+
+	currentItems := make(map[string]bool)
+	for _, it := range resp.Items {
+		currentItems[it.ID] = true
+	}
+
+	return currentItems, nil
+}
+
+// SetCollectionOverview sets the overview (description) of a collection.
+// Emby replaces the whole item on update, so the collection is fetched first and sent back with the new overview.
+func (c *Client) SetCollectionOverview(ctx context.Context, collectionID, overview string) error {
+	query := url.Values{}
+	query.Set("Ids", collectionID)
+	query.Set("Fields", "Overview,Genres,Tags,ProviderIds,SortName,DateCreated,PremiereDate,LockedFields,LockData")
+
+	var resp struct {
+		Items []map[string]any `json:"Items"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/Items", query, &resp); err != nil {
+		return fmt.Errorf("failed to get collection %s: %w", collectionID, err)
+	}
+	if len(resp.Items) == 0 {
+		return fmt.Errorf("collection %s not found", collectionID)
+	}
+
+	collection := resp.Items[0]
+	collection["Overview"] = overview
+	if err := c.doWithBody(ctx, http.MethodPost, "/Items/"+url.PathEscape(collectionID), nil, collection, nil); err != nil {
+		return fmt.Errorf("failed to update overview of collection %s: %w", collectionID, err)
+	}
+	return nil
+}
+
+// GetCollectionMembership returns the names of the collections each of the given items belongs to.
+func (c *Client) GetCollectionMembership(ctx context.Context, itemIDs []string) (map[string][]string, error) {
+	wanted := make(map[string]bool, len(itemIDs))
+	for _, id := range itemIDs {
+		wanted[id] = true
+	}
+
+	query := url.Values{}
+	query.Set("IncludeItemTypes", "BoxSet")
+	query.Set("Recursive", "true")
+
+	var resp itemsResponse
+	if err := c.do(ctx, http.MethodGet, "/Items", query, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get collections: %w", err)
+	}
+
+	membership := make(map[string][]string)
+	for _, collection := range resp.Items {
+		members, err := c.GetCollectionItems(ctx, collection.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get items of collection %s: %w", collection.Name, err)
+		}
+		for id := range members {
+			if wanted[id] {
+				membership[id] = append(membership[id], collection.Name)
+			}
+		}
+	}
+
+	return membership, nil
+}
+
+// GetFavoritedBy returns the names of the users who marked each of the given items as favorite.
+// The favorites are fetched once per user. Disabled users are ignored.
+func (c *Client) GetFavoritedBy(ctx context.Context, itemIDs []string) (map[string][]string, error) {
+	wanted := make(map[string]bool, len(itemIDs))
+	for _, id := range itemIDs {
+		wanted[id] = true
+	}
+
+	var users []user
+	if err := c.do(ctx, http.MethodGet, "/Users", nil, &users); err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	favoritedBy := make(map[string][]string)
+	for _, u := range users {
+		if u.Policy.IsDisabled {
+			continue
+		}
+
+		query := url.Values{}
+		query.Set("Recursive", "true")
+		query.Set("Filters", "IsFavorite")
+		query.Set("IncludeItemTypes", "Movie,Series")
+
+		var resp itemsResponse
+		if err := c.do(ctx, http.MethodGet, "/Users/"+u.ID+"/Items", query, &resp); err != nil {
+			return nil, fmt.Errorf("failed to get favorites of user %s: %w", u.Name, err)
+		}
+		for _, item := range resp.Items {
+			if wanted[item.ID] {
+				favoritedBy[item.ID] = append(favoritedBy[item.ID], u.Name)
+			}
+		}
+	}
+
+	return favoritedBy, nil
+}
+
+// GetInProgressBy returns the names of the users with a resume position in each of the given items.
+// The resumable movies and episodes are fetched once per user, episodes count for their series. Disabled users are ignored.
+func (c *Client) GetInProgressBy(ctx context.Context, itemIDs []string) (map[string][]string, error) {
+	wanted := make(map[string]bool, len(itemIDs))
+	for _, id := range itemIDs {
+		wanted[id] = true
+	}
+
+	var users []user
+	if err := c.do(ctx, http.MethodGet, "/Users", nil, &users); err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	inProgressBy := make(map[string][]string)
+	for _, u := range users {
+		if u.Policy.IsDisabled {
+			continue
+		}
+
+		query := url.Values{}
+		query.Set("Recursive", "true")
+		query.Set("Filters", "IsResumable")
+		query.Set("IncludeItemTypes", "Movie,Episode")
+
+		var resp itemsResponse
+		if err := c.do(ctx, http.MethodGet, "/Users/"+u.ID+"/Items", query, &resp); err != nil {
+			return nil, fmt.Errorf("failed to get resumable items of user %s: %w", u.Name, err)
+		}
+		for _, item := range resp.Items {
+			id := item.ID
+			if item.Type == "Episode" {
+				id = item.SeriesID
+			}
+			if wanted[id] && !slices.Contains(inProgressBy[id], u.Name) {
+				inProgressBy[id] = append(inProgressBy[id], u.Name)
+			}
+		}
+	}
+
+	return inProgressBy, nil
+}
+
+// GetLastPlayedByAnyUser returns the latest play of any user for each of the given items.
+// The played movies and episodes are fetched once per user, episodes count for their series. Disabled users are ignored.
+func (c *Client) GetLastPlayedByAnyUser(ctx context.Context, itemIDs []string) (map[string]time.Time, error) {
+	wanted := make(map[string]bool, len(itemIDs))
+	for _, id := range itemIDs {
+		wanted[id] = true
+	}
+
+	var users []user
+	if err := c.do(ctx, http.MethodGet, "/Users", nil, &users); err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	lastPlayed := make(map[string]time.Time)
+	for _, u := range users {
+		if u.Policy.IsDisabled {
+			continue
+		}
+
+		query := url.Values{}
+		query.Set("Recursive", "true")
+		query.Set("Filters", "IsPlayed")
+		query.Set("IncludeItemTypes", "Movie,Episode")
+
+		var resp itemsResponse
+		if err := c.do(ctx, http.MethodGet, "/Users/"+u.ID+"/Items", query, &resp); err != nil {
+			return nil, fmt.Errorf("failed to get played items of user %s: %w", u.Name, err)
+		}
+		for _, item := range resp.Items {
+			id := item.ID
+			if item.Type == "Episode" {
+				id = item.SeriesID
+			}
+			if !wanted[id] || item.UserData == nil || item.UserData.LastPlayedDate == nil {
+				continue
+			}
+			if played := *item.UserData.LastPlayedDate; played.After(lastPlayed[id]) {
+				lastPlayed[id] = played
+			}
+		}
+	}
+
+	return lastPlayed, nil
+}
+
+// CreateCollection creates a new collection with the given name and item IDs.
+// Items are added in batches to avoid URL length limitations.
+func (c *Client) CreateCollection(ctx context.Context, name string, itemIDs []string) error {
+	initialItems := itemIDs[:min(batchSize, len(itemIDs))]
+
+	query := url.Values{}
+	query.Set("Name", name)
+	query.Set("Ids", strings.Join(initialItems, ","))
+
+	var resp collectionResponse
+	if err := c.do(ctx, http.MethodPost, "/Collections", query, &resp); err != nil {
+		return fmt.Errorf("failed to create collection %s: %w", name, err)
+	}
+
+	if len(itemIDs) > batchSize {
+		if err := c.AddItemsToCollection(ctx, resp.ID, itemIDs[batchSize:]); err != nil {
+			return fmt.Errorf("failed to add items to collection %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// AddItemsToCollection adds items to an existing collection.
+// Items are added in batches to avoid URL length limitations.
+func (c *Client) AddItemsToCollection(ctx context.Context, collectionID string, itemIDs []string) error {
+	for batch := range slices.Chunk(itemIDs, batchSize) {
+		query := url.Values{}
+		query.Set("Ids", strings.Join(batch, ","))
+		if err := c.do(ctx, http.MethodPost, "/Collections/"+url.PathEscape(collectionID)+"/Items", query, nil); err != nil {
+			return fmt.Errorf("failed to add items to collection %s: %w", collectionID, err)
+		}
+	}
+	return nil
+}
+
+// RemoveItemsFromCollection removes items from an existing collection.
+// Items are removed in batches to avoid URL length limitations.
+func (c *Client) RemoveItemsFromCollection(ctx context.Context, collectionID string, itemIDs []string) error {
+	for batch := range slices.Chunk(itemIDs, batchSize) {
+		query := url.Values{}
+		query.Set("Ids", strings.Join(batch, ","))
+		if err := c.do(ctx, http.MethodDelete, "/Collections/"+url.PathEscape(collectionID)+"/Items", query, nil); err != nil {
+			return fmt.Errorf("failed to remove items from collection %s: %w", collectionID, err)
+		}
+	}
+	return nil
+}
+
+// RemoveItemFromCollection removes a single item from the collection with the given name.
+// It does nothing if the collection doesn't exist.
+func (c *Client) RemoveItemFromCollection(ctx context.Context, collectionName, itemID string) error {
+	collectionID, err := c.FindCollectionByName(ctx, collectionName)
+	if err != nil {
+		return err
+	}
+	if collectionID == "" {
+		return nil
+	}
+	return c.RemoveItemsFromCollection(ctx, collectionID, []string{itemID})
+}
+
+// Ping checks whether Emby is reachable by requesting its system info.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.do(ctx, http.MethodGet, "/System/Info", nil, nil)
+}
@@ -0,0 +1,216 @@
+package emby
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	jellyfin "github.com/sj14/jellyfin-go/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client talking to a test server with the given handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "key", r.Header.Get("X-Emby-Token"))
+		w.Header().Set("Content-Type", "application/json")
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return New(&config.Config{
+		Emby: &config.EmbyConfig{URL: server.URL, APIKey: "key"},
+		Libraries: map[string]*config.CleanupConfig{
+			"Movies":   {Enabled: true},
+			"Shows":    {Enabled: true},
+			"Disabled": {Enabled: false},
+		},
+	})
+}
+
+func TestGetJellyfinItems(t *testing.T) {
+	libraryItems := map[string][]map[string]any{
+		"movies-id": {
+			{"Id": "movie-1", "Name": "First Movie", "Type": "Movie", "ProductionYear": 1999, "Path": "/movies/first", "ProviderIds": map[string]string{"Tmdb": "603"}, "Tags": []string{"keep"}, "TagItems": []map[string]any{{"Name": "keep"}, {"Name": "family"}}},
+			{"Id": "movie-2", "Name": "Second Movie", "Type": "Movie"},
+		},
+		"shows-id": {
+			{"Id": "series-1", "Name": "Show", "Type": "Series"},
+		},
+		"disabled-id": {
+			{"Id": "movie-3", "Name": "Disabled Movie", "Type": "Movie"},
+		},
+	}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Library/MediaFolders":
+			_ = json.NewEncoder(w).Encode(map[string]any{"Items": []map[string]any{
+				{"Id": "movies-id", "Name": "Movies"},
+				{"Id": "shows-id", "Name": "Shows"},
+				{"Id": "disabled-id", "Name": "Disabled"},
+			}})
+		case "/Library/VirtualFolders":
+			_ = json.NewEncoder(w).Encode([]map[string]any{
+				{"Name": "Movies", "Locations": []string{"/movies"}},
+				{"Name": "Shows", "Locations": []string{"/shows", "/shows2"}},
+				{"Name": "Disabled", "Locations": []string{"/disabled"}},
+			})
+		case "/Items":
+			assert.NotEqual(t, "disabled-id", r.URL.Query().Get("ParentId"), "disabled libraries aren't fetched")
+			// serve a single item per page to cover the pagination
+			items := libraryItems[r.URL.Query().Get("ParentId")]
+			start, _ := strconv.Atoi(r.URL.Query().Get("StartIndex"))
+			page := []map[string]any{}
+			if start < len(items) {
+				page = items[start : start+1]
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"Items": page, "TotalRecordCount": len(items)})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	items, folders, err := c.GetJellyfinItems(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"Movies": {"/movies"}, "Shows": {"/shows", "/shows2"}}, folders)
+
+	libraries := make(map[string]string)
+	for _, item := range items {
+		libraries[item.GetId()] = item.ParentLibraryName
+	}
+	assert.Equal(t, map[string]string{"movie-1": "Movies", "movie-2": "Movies", "series-1": "Shows"}, libraries)
+
+	first := items[0]
+	assert.Equal(t, "First Movie", first.GetName())
+	assert.Equal(t, jellyfin.BASEITEMKIND_MOVIE, first.GetType())
+	assert.Equal(t, int32(1999), first.GetProductionYear())
+	assert.Equal(t, "/movies/first", first.GetPath())
+	assert.Equal(t, map[string]string{"Tmdb": "603"}, first.GetProviderIds())
+	assert.Equal(t, []string{"keep", "family"}, first.GetTags(), "tags and tag items are merged")
+	assert.Equal(t, jellyfin.BASEITEMKIND_SERIES, items[2].GetType())
+}
+
+// collectionRequest is a request of the collection endpoints recorded by the test server.
+type collectionRequest struct {
+	method string
+	path   string
+	ids    int
+}
+
+func newCollectionTestClient(t *testing.T) (*Client, func() []collectionRequest) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var requests []collectionRequest
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, collectionRequest{
+			method: r.Method,
+			path:   r.URL.Path,
+			ids:    len(strings.Split(r.URL.Query().Get("Ids"), ",")),
+		})
+		mu.Unlock()
+		if r.URL.Path == "/Collections" {
+			_ = json.NewEncoder(w).Encode(map[string]any{"Id": "collection"})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return c, func() []collectionRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func itemIDs(n int) []string {
+	ids := make([]string, 0, n)
+	for i := range n {
+		ids = append(ids, fmt.Sprintf("item-%d", i))
+	}
+	return ids
+}
+
+func TestCollectionBatching(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		c, requests := newCollectionTestClient(t)
+		require.NoError(t, c.CreateCollection(context.Background(), "Leaving Soon", itemIDs(120)))
+		assert.Equal(t, []collectionRequest{
+			{method: http.MethodPost, path: "/Collections", ids: 50},
+			{method: http.MethodPost, path: "/Collections/collection/Items", ids: 50},
+			{method: http.MethodPost, path: "/Collections/collection/Items", ids: 20},
+		}, requests())
+	})
+
+	t.Run("add", func(t *testing.T) {
+		c, requests := newCollectionTestClient(t)
+		require.NoError(t, c.AddItemsToCollection(context.Background(), "collection", itemIDs(50)))
+		assert.Equal(t, []collectionRequest{
+			{method: http.MethodPost, path: "/Collections/collection/Items", ids: 50},
+		}, requests())
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		c, requests := newCollectionTestClient(t)
+		require.NoError(t, c.RemoveItemsFromCollection(context.Background(), "collection", itemIDs(101)))
+		assert.Equal(t, []collectionRequest{
+			{method: http.MethodDelete, path: "/Collections/collection/Items", ids: 50},
+			{method: http.MethodDelete, path: "/Collections/collection/Items", ids: 50},
+			{method: http.MethodDelete, path: "/Collections/collection/Items", ids: 1},
+		}, requests())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "boom", http.StatusInternalServerError)
+		})
+		err := c.AddItemsToCollection(context.Background(), "collection", itemIDs(2))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "emby returned status 500")
+	})
+}
+
+func TestGetCollectionMembership(t *testing.T) {
+	collections := map[string][]string{
+		"favorites": {"movie", "series"},
+		"classics":  {"movie", "other"},
+	}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/Items" {
+			http.NotFound(w, r)
+			return
+		}
+		var items []map[string]any
+		if parentID := r.URL.Query().Get("ParentId"); parentID != "" {
+			for _, id := range collections[parentID] {
+				items = append(items, map[string]any{"Id": id, "Type": "Movie"})
+			}
+		} else {
+			assert.Equal(t, "BoxSet", r.URL.Query().Get("IncludeItemTypes"))
+			items = []map[string]any{
+				{"Id": "favorites", "Name": "Favorites", "Type": "BoxSet"},
+				{"Id": "classics", "Name": "Classics", "Type": "BoxSet"},
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"Items": items, "TotalRecordCount": len(items)})
+	})
+
+	membership, err := c.GetCollectionMembership(context.Background(), []string{"movie", "series", "missing"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"movie":  {"Favorites", "Classics"},
+		"series": {"Favorites"},
+	}, membership, "only the requested items are returned")
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/charmbracelet/log"
@@ -14,27 +17,24 @@ import (
 	"github.com/jon4hz/jellysweep/internal/config"
 	"github.com/jon4hz/jellysweep/internal/database"
 	"github.com/jon4hz/jellysweep/internal/engine/arr"
-	radarrImpl "github.com/jon4hz/jellysweep/internal/engine/arr/radarr"
-	sonarrImpl "github.com/jon4hz/jellysweep/internal/engine/arr/sonarr"
-	"github.com/jon4hz/jellysweep/internal/engine/jellyfin"
+	"github.com/jon4hz/jellysweep/internal/engine/mediaserver"
 	"github.com/jon4hz/jellysweep/internal/engine/stats"
-	"github.com/jon4hz/jellysweep/internal/engine/stats/jellystat"
-	"github.com/jon4hz/jellysweep/internal/engine/stats/streamystats"
 	"github.com/jon4hz/jellysweep/internal/filter"
-	agefilter "github.com/jon4hz/jellysweep/internal/filter/age_filter"
-	databasefilter "github.com/jon4hz/jellysweep/internal/filter/database_filter"
-	seriesfilter "github.com/jon4hz/jellysweep/internal/filter/series_filter"
-	sizefilter "github.com/jon4hz/jellysweep/internal/filter/size_filter"
-	streamfilter "github.com/jon4hz/jellysweep/internal/filter/stream_filter"
-	tagsfilter "github.com/jon4hz/jellysweep/internal/filter/tags_filter"
-	tunarrfilter "github.com/jon4hz/jellysweep/internal/filter/tunarr_filter"
+	"github.com/jon4hz/jellysweep/internal/hooks"
+	"github.com/jon4hz/jellysweep/internal/notify/apprise"
 	"github.com/jon4hz/jellysweep/internal/notify/email"
+	"github.com/jon4hz/jellysweep/internal/notify/gotify"
+	"github.com/jon4hz/jellysweep/internal/notify/matrix"
 	"github.com/jon4hz/jellysweep/internal/notify/ntfy"
+	"github.com/jon4hz/jellysweep/internal/notify/pushover"
+	"github.com/jon4hz/jellysweep/internal/notify/slack"
+	"github.com/jon4hz/jellysweep/internal/notify/webhook"
 	"github.com/jon4hz/jellysweep/internal/notify/webpush"
 	"github.com/jon4hz/jellysweep/internal/policy"
 	"github.com/jon4hz/jellysweep/internal/scheduler"
 	"github.com/jon4hz/jellysweep/internal/tags"
 	"github.com/jon4hz/jellysweep/pkg/jellyseerr"
+	"github.com/jon4hz/jellysweep/pkg/tmdb"
 	"github.com/samber/lo"
 	"golang.org/x/sync/errgroup"
 )
@@ -44,6 +44,16 @@ var (
 	ErrRequestAlreadyProcessed = errors.New("request already processed")
 	// ErrUnkeepableMedia indicates that the specified media item cannot be kept.
 	ErrUnkeepableMedia = errors.New("media cannot be kept")
+	// ErrNoKeepRequest indicates that the specified media item has no keep request.
+	ErrNoKeepRequest = errors.New("media has no keep request")
+	// ErrSeasonsNotSupported indicates that seasons were requested to be kept for media other than TV series.
+	ErrSeasonsNotSupported = errors.New("seasons can only be kept for tv series")
+	// ErrCleanupRunNotFound indicates that the specified cleanup run does not exist.
+	ErrCleanupRunNotFound = errors.New("cleanup run not found")
+	// ErrMaintenanceMode indicates that the action was rejected because the maintenance mode is enabled.
+	ErrMaintenanceMode = errors.New("maintenance in progress")
+	// ErrDeletionInProgress indicates that the action was rejected because media is being deleted by another job.
+	ErrDeletionInProgress = errors.New("deletion in progress")
 )
 
 // Engine is the main engine for Jellysweep, managing interactions with sonarr, radarr, and other services.
@@ -53,16 +63,51 @@ type Engine struct {
 	db         database.DB
 	filters    *filter.Filter
 	policy     *policy.Engine
-	jellyfin   *jellyfin.Client
+	jellyfin   mediaserver.MediaServer
 	stats      stats.Statser
 	jellyseerr *jellyseerr.Client
+	tmdb       *tmdb.Client
 	sonarr     arr.Arrer
 	radarr     arr.Arrer
+	readarr    arr.Arrer
 	email      *email.NotificationService
 	ntfy       *ntfy.Client
 	webpush    *webpush.Client
+	slack      *slack.Client
+	gotify     *gotify.Client
+	matrix     *matrix.Client
+	apprise    *apprise.Client
+	pushover   *pushover.Client
+	webhook    *webhook.Client
+	hooks      *hooks.Runner
 	scheduler  *scheduler.Scheduler
 
+	// clientsMu is held by the scheduled jobs and the API calls using the clients while they run
+	// and locked exclusively to swap the clients and their config sections on a reload.
+	clientsMu sync.RWMutex
+
+	// deleteMu is held while media is deleted, so the cleanup, the deletion retry and forced deletions never run at the same time.
+	deleteMu sync.Mutex
+
+	// maintenance is set while the maintenance mode is enabled, keep requests, manual triggers and jobs are rejected then.
+	maintenance atomic.Bool
+
+	// diffFilters are the filters without the database filter and the scan budget, used to compare the marked items between runs.
+	diffFilters *filter.Filter
+	// statslessDiffFilters are the diff filters without the stream filter.
+	statslessDiffFilters *filter.Filter
+	// recordFilters are the scan budget and the database filter, applied to the result of the diff filters in dry runs.
+	recordFilters *filter.Filter
+	// estimateFilters are the filters with a scan budget that doesn't move the cursor, used to estimate or preview the next run.
+	estimateFilters *filter.Filter
+	// statslessFilters are the filters without the stream filter, used if the stats backend is unreachable.
+	statslessFilters *filter.Filter
+
+	// ageFilter, streamFilter and inProgressFilter are used to reevaluate single items.
+	ageFilter        filter.Filterer
+	streamFilter     filter.Filterer
+	inProgressFilter filter.Filterer
+
 	imageCache *cache.ImageCache
 	cache      *cache.EngineCache // Cache for engine-specific data
 
@@ -76,73 +121,43 @@ type Engine struct {
 type data struct {
 	// userNotifications tracks which users should be notified about which media items
 	userNotifications map[string][]arr.MediaItem // key: user email, value: media items
+	// dryRunReport collects the items that would be marked or deleted during a dry run
+	dryRunReport []dryRunReportEntry
+	// run is the cleanup run currently in progress
+	run *database.CleanupRun
+	// libraryItemCounts is the number of items per library in the media server, as of the last gathering
+	libraryItemCounts map[string]int
 }
 
 // New creates a new Engine instance.
 func New(cfg *config.Config, db database.DB, initialDBMigration bool) (*Engine, error) {
+	tags.SetPrefix(cfg.TagPrefix)
+
 	// Create scheduler first
-	sched, err := scheduler.New()
+	sched, err := scheduler.New(cfg.Location())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create scheduler: %w", err)
 	}
 
-	var statsClient stats.Statser
-	if cfg.Jellystat != nil {
-		statsClient = jellystat.New(cfg.Jellystat)
-	}
-
-	if cfg.Streamystats != nil {
-		statsClient, err = streamystats.New(cfg.Streamystats, cfg.Jellyfin.APIKey)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create StreamyStats client: %w", err)
-		}
-	}
-
 	engineCache, err := cache.NewEngineCache(cfg.Cache)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create engine cache: %w", err)
 	}
 
-	// Create Jellyfin client
-	jellyfinClient := jellyfin.New(cfg)
-
-	var sonarrClient arr.Arrer
-	if cfg.Sonarr != nil {
-		sonarrClient = sonarrImpl.NewSonarr(cfg, statsClient, engineCache.SonarrTagsCache)
-	} else {
-		log.Warn("Sonarr configuration is missing, some features will be disabled")
-	}
-
-	var radarrClient arr.Arrer
-	if cfg.Radarr != nil {
-		radarrClient = radarrImpl.NewRadarr(cfg, statsClient, engineCache.RadarrTagsCache)
-	} else {
-		log.Warn("Radarr configuration is missing, some features will be disabled")
-	}
-
-	filterList := []filter.Filterer{
-		databasefilter.New(db),
-		seriesfilter.New(cfg),
-		tagsfilter.New(cfg),
-		sizefilter.New(cfg),
-		agefilter.New(cfg, db, sonarrClient, radarrClient),
-		streamfilter.New(cfg, statsClient),
-	}
-
-	if cfg.Tunarr != nil {
-		tunarrF, err := tunarrfilter.New(cfg)
-		if err != nil {
-			log.Warn("failed to create Tunarr filter", "error", err)
-		} else {
-			filterList = append(filterList, tunarrF)
-		}
+	c, err := newClients(cfg, engineCache)
+	if err != nil {
+		return nil, err
 	}
-
-	filters := filter.New(filterList...)
+	filters := newFilterSet(cfg, db, c)
 
 	var jellyseerrClient *jellyseerr.Client
 	if cfg.Jellyseerr != nil {
-		jellyseerrClient = jellyseerr.New(cfg.Jellyseerr)
+		jellyseerrClient = jellyseerr.New(cfg.Jellyseerr, cfg.Proxy, cfg.Retry)
+	}
+
+	var tmdbClient *tmdb.Client
+	if cfg.TMDB != nil {
+		tmdbClient = tmdb.New(cfg.TMDB)
 	}
 
 	// Initialize email notification service
@@ -160,28 +175,89 @@ func New(cfg *config.Config, db database.DB, initialDBMigration bool) (*Engine,
 	// Initialize webpush client
 	var webpushClient *webpush.Client
 	if cfg.WebPush != nil && cfg.WebPush.Enabled {
-		webpushClient = webpush.NewClient(cfg.WebPush)
+		webpushClient = webpush.NewClient(cfg.WebPush, cfg.ServerURL)
+	}
+
+	// Initialize slack client
+	var slackClient *slack.Client
+	if cfg.Slack != nil && cfg.Slack.Enabled {
+		slackClient = slack.NewClient(cfg.Slack)
+	}
+
+	// Initialize gotify client
+	var gotifyClient *gotify.Client
+	if cfg.Gotify != nil && cfg.Gotify.Enabled {
+		gotifyClient = gotify.NewClient(cfg.Gotify)
+	}
+
+	// Initialize matrix client
+	var matrixClient *matrix.Client
+	if cfg.Matrix != nil && cfg.Matrix.Enabled {
+		matrixClient = matrix.NewClient(cfg.Matrix)
+	}
+
+	// Initialize apprise client
+	var appriseClient *apprise.Client
+	if cfg.Apprise != nil && cfg.Apprise.Enabled {
+		appriseClient = apprise.NewClient(cfg.Apprise)
+	}
+
+	// Initialize pushover client
+	var pushoverClient *pushover.Client
+	if cfg.Pushover != nil && cfg.Pushover.Enabled {
+		pushoverClient = pushover.NewClient(cfg.Pushover)
+	}
+
+	// Initialize webhook client
+	var webhookClient *webhook.Client
+	if cfg.Webhook != nil && cfg.Webhook.Enabled {
+		webhookClient, err = webhook.NewClient(cfg.Webhook)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create webhook client: %w", err)
+		}
+	}
+
+	var hookRunner *hooks.Runner
+	if cfg.Hooks != nil && (cfg.Hooks.PreDeleteCommand != "" || cfg.Hooks.PreDeleteWebhookURL != "") {
+		hookRunner = hooks.New(cfg.Hooks)
 	}
 
 	engine := &Engine{
-		cfg:                cfg,
-		db:                 db,
-		initialDBMigration: initialDBMigration,
-		filters:            filters,
-		policy:             policy.NewEngine(),
-		jellyfin:           jellyfinClient,
-		stats:              statsClient,
-		jellyseerr:         jellyseerrClient,
-		sonarr:             sonarrClient,
-		radarr:             radarrClient,
-		email:              emailService,
-		ntfy:               ntfyClient,
-		webpush:            webpushClient,
-		scheduler:          sched,
+		cfg:                  cfg,
+		db:                   db,
+		initialDBMigration:   initialDBMigration,
+		filters:              filters.filters,
+		diffFilters:          filters.diffFilters,
+		statslessDiffFilters: filters.statslessDiffFilters,
+		recordFilters:        filters.recordFilters,
+		estimateFilters:      filters.estimateFilters,
+		statslessFilters:     filters.statslessFilters,
+		ageFilter:            filters.ageFilter,
+		streamFilter:         filters.streamFilter,
+		inProgressFilter:     filters.inProgressFilter,
+		policy:               policy.NewEngine(),
+		jellyfin:             c.jellyfin,
+		stats:                c.stats,
+		jellyseerr:           jellyseerrClient,
+		tmdb:                 tmdbClient,
+		sonarr:               c.sonarr,
+		radarr:               c.radarr,
+		readarr:              c.readarr,
+		email:                emailService,
+		ntfy:                 ntfyClient,
+		webpush:              webpushClient,
+		slack:                slackClient,
+		gotify:               gotifyClient,
+		matrix:               matrixClient,
+		apprise:              appriseClient,
+		pushover:             pushoverClient,
+		webhook:              webhookClient,
+		hooks:                hookRunner,
+		scheduler:            sched,
 		data: &data{
 			userNotifications: make(map[string][]arr.MediaItem),
 		},
-		imageCache: cache.NewImageCache("./data/cache/images", db),
+		imageCache: cache.NewImageCache(cfg.ImageCache, db),
 		cache:      engineCache,
 	}
 
@@ -190,12 +266,28 @@ func New(cfg *config.Config, db database.DB, initialDBMigration bool) (*Engine,
 		return nil, fmt.Errorf("failed to setup jobs: %w", err)
 	}
 
+	// Restore the paused state, so a paused scheduler stays paused after a restart
+	paused, err := db.GetSchedulerPaused(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduler state: %w", err)
+	}
+	if paused {
+		sched.Pause()
+	}
+
+	// Restore the maintenance mode, so the maintenance isn't interrupted by a restart
+	maintenance, err := db.GetMaintenanceMode(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance state: %w", err)
+	}
+	engine.maintenance.Store(maintenance)
+
 	return engine, nil
 }
 
 // runCleanupJob is the main cleanup job function.
 func (e *Engine) runCleanupJob(ctx context.Context) (err error) {
-	log.Info("Starting scheduled cleanup job")
+	log.FromContext(ctx).Info("Starting scheduled cleanup job")
 
 	// Clear all caches to ensure fresh data
 	e.cache.ClearAll(ctx)
@@ -203,133 +295,172 @@ func (e *Engine) runCleanupJob(ctx context.Context) (err error) {
 	if e.initialDBMigration {
 		// migrate old tag based items to database
 		if err := e.migrateTagsToDatabase(ctx); err != nil {
-			log.Error("An error occurred while migrating tags to database")
+			log.FromContext(ctx).Error("An error occurred while migrating tags to database")
 			return err
 		}
+		if err := e.db.CompleteTagMigration(ctx); err != nil {
+			log.FromContext(ctx).Error("An error occurred while recording the tag migration")
+			return err
+		}
+		e.initialDBMigration = false
 	}
 
+	ctx = e.startCleanupRun(ctx)
+	defer func() { e.completeCleanupRun(ctx, err) }()
+
+	step := e.startStep(ctx, stepRemoveProtectedExpired)
 	e.removeProtectedExpiredItems(ctx)
+	e.completeStep(ctx, step, 0, nil)
 
+	step = e.startStep(ctx, stepGatherMedia)
 	mediaItems, err := e.gatherMediaItems(ctx)
+	e.completeStep(ctx, step, len(mediaItems), err)
 	if err != nil {
-		log.Error("failed to gather media items", "error", err)
+		log.FromContext(ctx).Error("failed to gather media items", "error", err)
 		return err
 	}
-	log.Info("Media items gathered successfully")
+	log.FromContext(ctx).Info("Media items gathered successfully")
 
-	if err := e.removeItemsNotFoundAnymore(ctx, mediaItems); err != nil {
-		log.Error("An error occurred while removing items not found in Jellyfin")
+	step = e.startStep(ctx, stepRemoveNotFound)
+	notFoundErr := e.removeItemsNotFoundAnymore(ctx, mediaItems)
+	e.completeStep(ctx, step, 0, notFoundErr)
+	if notFoundErr != nil {
+		log.FromContext(ctx).Error("An error occurred while removing items not found in Jellyfin")
 	}
 
-	if err = e.markForDeletion(ctx, mediaItems); err != nil {
-		log.Error("An error occurred while marking media for deletion")
+	step = e.startStep(ctx, stepMarkForDeletion)
+	err = e.markForDeletion(ctx, mediaItems)
+	e.completeStep(ctx, step, e.itemsMarked(), err)
+	if err != nil {
+		log.FromContext(ctx).Error("An error occurred while marking media for deletion")
 	}
 
+	step = e.startStep(ctx, stepRemoveRecentlyPlayed)
 	e.removeRecentlyPlayedItems(ctx)
+	e.completeStep(ctx, step, 0, nil)
 
 	// only delete media if there was no previous error
 	if err == nil {
-		if err := e.cleanupMedia(ctx); err != nil {
-			log.Error("An error occurred while deleting media")
+		step = e.startStep(ctx, stepCleanupMedia)
+		err = e.cleanupMedia(ctx)
+		e.completeStep(ctx, step, e.itemsDeleted(), err)
+		if err != nil {
+			log.FromContext(ctx).Error("An error occurred while deleting media")
 			return err
 		}
 	}
 
-	if err := e.createJellyfinLeavingCollections(ctx); err != nil {
-		log.Error("An error occurred while creating Jellyfin leaving collections")
+	step = e.startStep(ctx, stepLeavingCollections)
+	collectionsErr := e.createJellyfinLeavingCollections(ctx)
+	if collectionsErr != nil {
+		log.FromContext(ctx).Error("An error occurred while creating Jellyfin leaving collections")
 	}
-
 	e.removeItemsFromLeavingCollections(ctx)
+	e.completeStep(ctx, step, 0, collectionsErr)
 
-	log.Info("Scheduled cleanup job completed")
+	if estimateErr := e.estimateDeletions(ctx); estimateErr != nil {
+		log.FromContext(ctx).Error("An error occurred while estimating deletions", "error", estimateErr)
+	}
+
+	log.FromContext(ctx).Info("Scheduled cleanup job completed")
 	return err
 }
 
 func (e *Engine) removeProtectedExpiredItems(ctx context.Context) {
-	log.Info("Removing media items with expired protection from database")
+	log.FromContext(ctx).Info("Removing media items with expired protection from database")
 	mediaItems, err := e.db.GetMediaExpiredProtection(ctx, time.Now())
 	if err != nil {
-		log.Error("Failed to get media items with expired protection from database", "error", err)
+		log.FromContext(ctx).Error("Failed to get media items with expired protection from database", "error", err)
 		return
 	}
 	if len(mediaItems) == 0 {
-		log.Debug("No media items with expired protection found in database")
+		log.FromContext(ctx).Debug("No media items with expired protection found in database")
 		return
 	}
 	for _, item := range mediaItems {
 		item.DBDeleteReason = database.DBDeleteReasonProtectionExpired
 
 		if err := e.db.DeleteMediaItem(ctx, &item); err != nil {
-			log.Error("Failed to remove media item with expired protection from database", "title", item.Title, "jellyfinID", item.JellyfinID, "protectedUntil", item.ProtectedUntil, "error", err)
+			log.FromContext(ctx).Error("Failed to remove media item with expired protection from database", "title", item.Title, "jellyfinID", item.JellyfinID, "protectedUntil", item.ProtectedUntil, "error", err)
 		}
 
 		// Create history event for protection expiration before deletion
 		if err := e.CreateProtectionExpiredEvent(ctx, &item); err != nil {
-			log.Error("failed to create protection expired event", "title", item.Title, "error", err)
+			log.FromContext(ctx).Error("failed to create protection expired event", "title", item.Title, "error", err)
 		}
 	}
-	log.Info("Media items with expired protection removal process completed")
+	log.FromContext(ctx).Info("Media items with expired protection removal process completed")
 }
 
 func (e *Engine) removeRecentlyPlayedItems(ctx context.Context) {
-	log.Info("Removing recently played items from database")
+	log.FromContext(ctx).Info("Removing recently played items from database")
 
 	mediaItems, err := e.db.GetMediaItems(ctx, true)
 	if err != nil {
-		log.Error("Failed to get media items from database", "error", err)
+		log.FromContext(ctx).Error("Failed to get media items from database", "error", err)
 		return
 	}
 
 	if len(mediaItems) == 0 {
-		log.Debug("No media items found in database to check for recent plays")
+		log.FromContext(ctx).Debug("No media items found in database to check for recent plays")
 		return
 	}
 
+	jellyfinIDs := make([]string, 0, len(mediaItems))
 	for _, item := range mediaItems {
-		lastPlayed, err := e.stats.GetItemLastPlayed(ctx, item.JellyfinID)
-		if err != nil {
-			log.Error("Failed to get last played time for item", "title", item.Title, "jellyfinID", item.JellyfinID, "error", err)
+		jellyfinIDs = append(jellyfinIDs, item.JellyfinID)
+	}
+
+	lastPlayedByID, err := e.stats.GetItemsLastPlayed(ctx, jellyfinIDs)
+	if err != nil {
+		log.FromContext(ctx).Error("Failed to get last played times", "error", err)
+		return
+	}
+
+	for _, item := range mediaItems {
+		if item.ForceDelete {
+			log.FromContext(ctx).Debug("Item is tagged for immediate deletion, skipping removal", "title", item.Title, "jellyfinID", item.JellyfinID)
 			continue
 		}
-
-		if lastPlayed.IsZero() {
-			log.Debug("Item has never been played, skipping removal", "title", item.Title, "jellyfinID", item.JellyfinID)
+		lastPlayed, ok := lastPlayedByID[item.JellyfinID]
+		if !ok {
+			log.FromContext(ctx).Debug("Item has never been played, skipping removal", "title", item.Title, "jellyfinID", item.JellyfinID)
 			continue
 		}
 
 		libraryConfig := e.cfg.GetLibraryConfig(item.LibraryName)
 		if libraryConfig == nil {
-			log.Warn("Library config not found", "library", item.LibraryName)
+			log.FromContext(ctx).Warn("Library config not found", "library", item.LibraryName)
 			continue
 		}
 
 		timeSinceLastPlayed := time.Since(lastPlayed)
-		thresholdDuration := time.Duration(libraryConfig.GetLastStreamThreshold()) * 24 * time.Hour
+		thresholdDuration := time.Duration(libraryConfig.GetLastStreamThresholdForRuntime(item.RuntimeMinutes)) * 24 * time.Hour
 		if timeSinceLastPlayed > thresholdDuration {
-			log.Debug("Item last played outside of threshold, skipping removal", "title", item.Title, "jellyfinID", item.JellyfinID, "lastPlayed", lastPlayed.Format(time.RFC3339))
+			log.FromContext(ctx).Debug("Item last played outside of threshold, skipping removal", "title", item.Title, "jellyfinID", item.JellyfinID, "lastPlayed", lastPlayed.Format(time.RFC3339))
 			continue
 		}
 		item.DBDeleteReason = database.DBDeleteReasonStreamed
 		// Create deletion event for streamed items
 		if err := e.CreateStreamedEvent(ctx, &item); err != nil {
-			log.Error("failed to create deletion event", "title", item.Title, "error", err)
+			log.FromContext(ctx).Error("failed to create deletion event", "title", item.Title, "error", err)
 		}
 
 		if err := e.db.DeleteMediaItem(ctx, &item); err != nil {
-			log.Error("Failed to remove recently played item from database", "title", item.Title, "jellyfinID", item.JellyfinID, "error", err)
+			log.FromContext(ctx).Error("Failed to remove recently played item from database", "title", item.Title, "jellyfinID", item.JellyfinID, "error", err)
 			continue
 		}
 	}
 
-	log.Info("Recently played items removal process completed")
+	log.FromContext(ctx).Info("Recently played items removal process completed")
 }
 
 func (e *Engine) removeItemsNotFoundAnymore(ctx context.Context, mediaItems []arr.MediaItem) error {
-	log.Info("Removing items no longer present in Jellyfin from database")
+	log.FromContext(ctx).Info("Removing items no longer present in Jellyfin from database")
 
 	dbMediaItems, err := e.db.GetMediaItems(ctx, false)
 	if err != nil {
-		log.Error("Failed to get media items from database", "error", err)
+		log.FromContext(ctx).Error("Failed to get media items from database", "error", err)
 		return err
 	}
 
@@ -340,34 +471,76 @@ func (e *Engine) removeItemsNotFoundAnymore(ctx context.Context, mediaItems []ar
 
 	for _, dbItem := range dbMediaItems {
 		if _, exists := jellyfinItemMap[dbItem.JellyfinID]; !exists {
-			log.Info("Media item no longer present in Jellyfin, removing from database", "title", dbItem.Title, "jellyfinID", dbItem.JellyfinID)
+			log.FromContext(ctx).Info("Media item no longer present in Jellyfin, removing from database", "title", dbItem.Title, "jellyfinID", dbItem.JellyfinID)
 			dbItem.DBDeleteReason = database.DBDeleteReasonMissingInJellyfin
 
 			// Create deletion event for missing items
 			if err := e.CreateNotFoundAnymoreEvent(ctx, &dbItem); err != nil {
-				log.Error("failed to create not found anymore event", "title", dbItem.Title, "error", err)
+				log.FromContext(ctx).Error("failed to create not found anymore event", "title", dbItem.Title, "error", err)
 			}
 
 			if err := e.db.DeleteMediaItem(ctx, &dbItem); err != nil {
-				log.Error("Failed to remove media item no longer present in Jellyfin from database", "title", dbItem.Title, "jellyfinID", dbItem.JellyfinID, "error", err)
+				log.FromContext(ctx).Error("Failed to remove media item no longer present in Jellyfin from database", "title", dbItem.Title, "jellyfinID", dbItem.JellyfinID, "error", err)
 				continue
 			}
 		}
 	}
 
-	log.Info("Removed items not found in Jellyfin from database successfully")
+	log.FromContext(ctx).Info("Removed items not found in Jellyfin from database successfully")
 	return nil
 }
 
 func (e *Engine) markForDeletion(ctx context.Context, mediaItems []arr.MediaItem) error {
-	mediaItems, err := e.filters.ApplyAll(ctx, mediaItems)
+	e.data.dryRunReport = nil
+
+	// Items tagged for immediate deletion bypass all filters
+	deleteNowItems, mediaItems := splitDeleteNowItems(mediaItems)
+	if err := e.markForDeletionNow(ctx, deleteNowItems); err != nil {
+		return err
+	}
+
+	gathered := mediaItems
+
+	// The requester filters need the requester of every item, otherwise it's enough to look up the marked items.
+	requesterRules := e.cfg.HasRequesterRules()
+	if requesterRules {
+		log.FromContext(ctx).Info("Populating requester information")
+		mediaItems = e.populateRequesterInfo(ctx, mediaItems)
+	}
+
+	skipStreamFilter, err := e.checkStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	if e.dryRunDiffEnabled() {
+		mediaItems, err = e.applyFiltersWithDryRunDiff(ctx, mediaItems, skipStreamFilter)
+	} else {
+		filters := e.filters
+		if skipStreamFilter {
+			filters = e.statslessFilters
+		}
+		mediaItems, err = filters.ApplyAll(ctx, mediaItems, e.recordFilterStep(ctx))
+	}
 	if err != nil {
 		return err
 	}
 
-	// Populate requester information from Jellyseerr
-	log.Info("Populating requester information")
-	mediaItems = e.populateRequesterInfo(ctx, mediaItems)
+	if e.cfg.TreatCollectionsAtomically {
+		mediaItems, err = e.keepCollectionsTogether(ctx, gathered, deleteNowItems, mediaItems)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !requesterRules {
+		// Populate requester information from Jellyseerr
+		log.FromContext(ctx).Info("Populating requester information")
+		mediaItems = e.populateRequesterInfo(ctx, mediaItems)
+	}
+
+	// Enrich the items with TMDB metadata for the notifications
+	mediaItems = e.enrichMetadata(ctx, mediaItems)
 
 	// Reset and populate user notifications for email sending
 	e.data.userNotifications = make(map[string][]arr.MediaItem)
@@ -376,42 +549,114 @@ func (e *Engine) markForDeletion(ctx context.Context, mediaItems []arr.MediaItem
 		if item.RequestedBy != "" {
 			e.data.userNotifications[item.RequestedBy] = append(e.data.userNotifications[item.RequestedBy], item)
 		}
-		log.Info("Marking media item for deletion", "name", item.Title, "library", item.LibraryName)
+		log.FromContext(ctx).Info("Marking media item for deletion", "name", item.Title, "library", item.LibraryName)
 	}
 
-	log.Info("Media items filtered successfully")
+	log.FromContext(ctx).Info("Media items filtered successfully")
+	e.recordMarked(len(mediaItems))
 
 	if len(mediaItems) == 0 {
-		log.Info("No media items marked for deletion after filtering")
+		log.FromContext(ctx).Info("No media items marked for deletion after filtering")
 		return nil
 	}
 
 	// save items to database
-	if err := e.saveMediaItemsToDatabase(mediaItems); err != nil {
-		log.Error("failed to save media items to database", "error", err)
+	if err := e.saveMediaItemsToDatabase(ctx, mediaItems); err != nil {
+		log.FromContext(ctx).Error("failed to save media items to database", "error", err)
 		return err
 	}
-	log.Info("Media items saved to database successfully")
+	log.FromContext(ctx).Info("Media items saved to database successfully")
 
 	// Send email notifications before marking for deletion
-	e.sendEmailNotifications()
+	e.sendEmailNotifications(ctx)
 
 	// Send ntfy deletion summary notification
 	if err := e.sendNtfyDeletionSummary(ctx, mediaItems); err != nil {
-		log.Error("failed to send ntfy deletion summary", "error", err)
+		log.FromContext(ctx).Error("failed to send ntfy deletion summary", "error", err)
+		// Don't return here, continue with the cleanup process
+	}
+
+	// Send gotify deletion summary notification
+	if err := e.sendGotifyDeletionSummary(ctx, mediaItems); err != nil {
+		log.FromContext(ctx).Error("failed to send gotify deletion summary", "error", err)
+		// Don't return here, continue with the cleanup process
+	}
+
+	// Send matrix deletion summary notification
+	if err := e.sendMatrixDeletionSummary(ctx, mediaItems); err != nil {
+		log.FromContext(ctx).Error("failed to send matrix deletion summary", "error", err)
+		// Don't return here, continue with the cleanup process
+	}
+
+	// Send apprise deletion summary notification
+	if err := e.sendAppriseDeletionSummary(ctx, mediaItems); err != nil {
+		log.FromContext(ctx).Error("failed to send apprise deletion summary", "error", err)
+		// Don't return here, continue with the cleanup process
+	}
+
+	// Send pushover deletion summary notification
+	if err := e.sendPushoverDeletionSummary(ctx, mediaItems); err != nil {
+		log.FromContext(ctx).Error("failed to send pushover deletion summary", "error", err)
+		// Don't return here, continue with the cleanup process
+	}
+
+	// Send webhook deletion summary notification
+	if err := e.sendWebhookDeletionSummary(ctx, mediaItems); err != nil {
+		log.FromContext(ctx).Error("failed to send webhook deletion summary", "error", err)
 		// Don't return here, continue with the cleanup process
 	}
 	return nil
 }
 
-// gatherMediaItems gathers all media items from Jellyfin, Sonarr, and Radarr.
+// gatherMediaItems gathers all media items from Jellyfin, Sonarr, Radarr and Readarr.
 // It merges them into a single collection grouped by library.
 func (e *Engine) gatherMediaItems(ctx context.Context) ([]arr.MediaItem, error) {
+	return e.gatherLibraryMediaItems(ctx, "")
+}
+
+// gatherLibraryMediaItems gathers the media items of a single library, or of all enabled libraries if libraryName is empty.
+// The library item counts and the deletion policies of the engine are updated for the cleanup.
+func (e *Engine) gatherLibraryMediaItems(ctx context.Context, libraryName string) ([]arr.MediaItem, error) {
+	gathered, err := e.collectMediaItems(ctx, libraryName)
+	if err != nil {
+		return nil, err
+	}
+
+	e.data.libraryItemCounts = gathered.libraryItemCounts
+	// Set deletion policies with freshly gathered library folders map
+	e.policy.SetPolicies(deletionPolicies(e.cfg, gathered.libraryFolders)...)
+
+	return gathered.items, nil
+}
+
+// gatheredMedia is the result of gathering the media items.
+type gatheredMedia struct {
+	items []arr.MediaItem
+	// libraryItemCounts is the number of items per library in the media server.
+	libraryItemCounts map[string]int
+	// libraryFolders are the folders of each library in the media server.
+	libraryFolders map[string][]string
+}
+
+// collectMediaItems gathers the media items like gatherLibraryMediaItems without touching the state of the engine,
+// so it's safe to use outside of the cleanup job.
+func (e *Engine) collectMediaItems(ctx context.Context, libraryName string) (*gatheredMedia, error) {
 	jellyfinItems, libraryFoldersMap, err := e.jellyfin.GetJellyfinItems(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get jellyfin items: %w", err)
 	}
 
+	// Drop items of disabled libraries before they are matched against the arrs.
+	// Items without a library are kept if the arrs can assign them to one by their path.
+	jellyfinItems = lo.Filter(jellyfinItems, func(item arr.JellyfinItem, _ int) bool {
+		if item.ParentLibraryName == "" && len(e.cfg.LibraryPathMap) > 0 {
+			return true
+		}
+		if libraryName != "" && !strings.EqualFold(item.ParentLibraryName, libraryName) {
+			return false
+		}
+		return e.isLibraryEnabled(item.ParentLibraryName)
+	})
 	var sonarrItems []arr.MediaItem
 	if e.sonarr != nil {
 		sonarrItems, err = e.sonarr.GetItems(ctx, jellyfinItems)
@@ -428,25 +673,81 @@ func (e *Engine) gatherMediaItems(ctx context.Context) ([]arr.MediaItem, error)
 		}
 	}
 
+	var readarrItems []arr.MediaItem
+	if e.readarr != nil {
+		readarrItems, err = e.readarr.GetItems(ctx, jellyfinItems)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get readarr items: %w", err)
+		}
+	}
+
 	// Merge all media items
-	mediaItems := make([]arr.MediaItem, 0, len(sonarrItems)+len(radarrItems))
+	mediaItems := make([]arr.MediaItem, 0, len(sonarrItems)+len(radarrItems)+len(readarrItems))
 	mediaItems = append(mediaItems, sonarrItems...)
 	mediaItems = append(mediaItems, radarrItems...)
+	mediaItems = append(mediaItems, readarrItems...)
+
+	// Items without a library in the media server count towards the library the arrs assigned them to by their path.
+	assignedLibraries := lo.SliceToMap(mediaItems, func(item arr.MediaItem) (string, string) {
+		return item.JellyfinID, item.LibraryName
+	})
+	libraryItemCounts := lo.CountValuesBy(jellyfinItems, func(item arr.JellyfinItem) string {
+		if item.ParentLibraryName != "" {
+			return item.ParentLibraryName
+		}
+		return assignedLibraries[item.GetId()]
+	})
+	delete(libraryItemCounts, "")
 
-	// Set deletion policies with freshly gathered library folders map
-	e.policy.SetPolicies(
-		policy.NewDefaultDelete(e.cfg),
-		policy.NewDiskUsageDelete(e.cfg, libraryFoldersMap),
-	)
+	if libraryName != "" {
+		mediaItems = lo.Filter(mediaItems, func(item arr.MediaItem, _ int) bool {
+			return strings.EqualFold(item.LibraryName, libraryName)
+		})
+	}
+	mediaItems = e.dropDisabledLibraryItems(ctx, mediaItems)
+	mediaItems = e.restrictLibraryMediaTypes(ctx, mediaItems)
 
-	return mediaItems, nil
+	return &gatheredMedia{
+		items:             mediaItems,
+		libraryItemCounts: libraryItemCounts,
+		libraryFolders:    libraryFoldersMap,
+	}, nil
+}
+
+// deletionPolicies returns the deletion policies for the given library folders.
+func deletionPolicies(cfg *config.Config, libraryFolders map[string][]string) []policy.Policy {
+	return []policy.Policy{
+		policy.NewDefaultDelete(cfg),
+		policy.NewDiskUsageDelete(cfg, libraryFolders),
+		policy.NewDiskUsageTargetDelete(cfg, libraryFolders),
+	}
+}
+
+// isLibraryEnabled reports whether the library is configured and enabled.
+func (e *Engine) isLibraryEnabled(libraryName string) bool {
+	libraryConfig := e.cfg.GetLibraryConfig(libraryName)
+	return libraryConfig != nil && libraryConfig.Enabled
+}
+
+// dropDisabledLibraryItems removes all media items that belong to a disabled library.
+func (e *Engine) dropDisabledLibraryItems(ctx context.Context, mediaItems []arr.MediaItem) []arr.MediaItem {
+	return lo.Filter(mediaItems, func(item arr.MediaItem, _ int) bool {
+		if !e.isLibraryEnabled(item.LibraryName) {
+			log.FromContext(ctx).Debug("Skipping media item from disabled library", "title", item.Title, "library", item.LibraryName)
+			return false
+		}
+		return true
+	})
 }
 
 func arrMediaToDBMediaItem(item arr.MediaItem) database.Media {
 	dbItem := database.Media{
-		JellyfinID:  item.JellyfinID,
-		LibraryName: item.LibraryName,
-		RequestedBy: item.RequestedBy,
+		JellyfinID:     item.JellyfinID,
+		LibraryName:    item.LibraryName,
+		RequestedBy:    item.RequestedBy,
+		DeletionReason: filter.DeletionReason(item),
+		RuntimeMinutes: filter.ItemRuntime(item),
+		ArrAddedAt:     filter.ArrAddedDate(item),
 	}
 
 	switch item.MediaType {
@@ -456,6 +757,7 @@ func arrMediaToDBMediaItem(item arr.MediaItem) database.Media {
 		dbItem.Title = item.SeriesResource.GetTitle()
 		dbItem.Year = item.SeriesResource.GetYear()
 		dbItem.FileSize = item.SeriesResource.Statistics.GetSizeOnDisk()
+		dbItem.Path = item.SeriesResource.GetPath()
 		dbItem.TvdbId = lo.ToPtr(item.SeriesResource.GetTvdbId())
 		dbItem.TmdbId = lo.ToPtr(item.SeriesResource.GetTmdbId())
 
@@ -471,13 +773,23 @@ func arrMediaToDBMediaItem(item arr.MediaItem) database.Media {
 		dbItem.Title = item.MovieResource.GetTitle()
 		dbItem.Year = item.MovieResource.GetYear()
 		dbItem.FileSize = item.MovieResource.Statistics.GetSizeOnDisk()
+		dbItem.Path = item.MovieResource.GetPath()
 		dbItem.TmdbId = lo.ToPtr(item.MovieResource.GetTmdbId())
+		dbItem.CollectionID = movieCollectionID(item)
 
 		for _, img := range item.MovieResource.GetImages() {
 			if img.GetCoverType() == radarrAPI.MEDIACOVERTYPES_POSTER {
 				dbItem.PosterURL = img.GetRemoteUrl()
 			}
 		}
+
+	case models.MediaTypeBook:
+		dbItem.MediaType = database.MediaTypeBook
+		dbItem.ArrID = item.BookResource.ID
+		dbItem.Title = item.BookResource.Title
+		dbItem.Year = item.BookResource.Year()
+		dbItem.FileSize = item.BookResource.SizeOnDisk()
+		dbItem.PosterURL = item.BookResource.CoverURL()
 	default:
 		return database.Media{}
 	}
@@ -485,26 +797,31 @@ func arrMediaToDBMediaItem(item arr.MediaItem) database.Media {
 	return dbItem
 }
 
-func (e *Engine) saveMediaItemsToDatabase(mediaItems []arr.MediaItem) error {
+func (e *Engine) saveMediaItemsToDatabase(ctx context.Context, mediaItems []arr.MediaItem) error {
 	dbMediaItems := make([]database.Media, 0)
 
 	for _, item := range mediaItems {
 		dbItem := arrMediaToDBMediaItem(item)
-		if err := e.policy.ApplyAll(&dbItem); err != nil {
-			log.Error("failed to apply policies to media item", "title", dbItem.Title, "error", err)
+		if dbItem.PosterURL == "" {
+			// fall back to the TMDB poster added by enrichMetadata before using the default poster
+			dbItem.PosterURL = e.posterOrDefault(item.PosterURL)
+		}
+		if err := e.policy.ApplyAll(ctx, &dbItem); err != nil {
+			log.FromContext(ctx).Error("failed to apply policies to media item", "title", dbItem.Title, "error", err)
 			continue
 		}
 		dbMediaItems = append(dbMediaItems, dbItem)
+		e.addDryRunReportEntry(dbItem, dryRunReasonMarked, dbItem.DefaultDeleteAt)
 	}
 
-	if err := e.db.CreateMediaItems(context.Background(), dbMediaItems); err != nil {
+	if err := e.db.CreateMediaItems(ctx, dbMediaItems); err != nil {
 		return fmt.Errorf("failed to create media items to database: %w", err)
 	}
 
 	// Create history events for newly picked up items
 	for i := range dbMediaItems {
-		if err := e.CreatePickedUpEvent(context.Background(), &dbMediaItems[i]); err != nil {
-			log.Error("failed to create picked up event", "title", dbMediaItems[i].Title, "error", err)
+		if err := e.CreatePickedUpEvent(ctx, &dbMediaItems[i]); err != nil {
+			log.FromContext(ctx).Error("failed to create picked up event", "title", dbMediaItems[i].Title, "error", err)
 		}
 	}
 
@@ -514,21 +831,21 @@ func (e *Engine) saveMediaItemsToDatabase(mediaItems []arr.MediaItem) error {
 // resetAllTags removes all jellysweep tags from all media in Sonarr and Radarr.
 // Legacy: also cleans up any remaining tags.
 func (e *Engine) resetAllTags(ctx context.Context, additionalTags []string) error {
-	log.Info("Resetting all jellysweep tags...")
+	log.FromContext(ctx).Info("Resetting all jellysweep tags...")
 
-	if e.sonarr == nil && e.radarr == nil {
-		return fmt.Errorf("no Sonarr or Radarr client configured, cannot reset tags")
+	if e.sonarr == nil && e.radarr == nil && e.readarr == nil {
+		return fmt.Errorf("no Sonarr, Radarr or Readarr client configured, cannot reset tags")
 	}
 
 	g, ctx := errgroup.WithContext(ctx)
 	// Reset Sonarr tags
 	if e.sonarr != nil {
 		g.Go(func() error {
-			log.Info("Removing jellysweep tags from Sonarr series...")
+			log.FromContext(ctx).Info("Removing jellysweep tags from Sonarr series...")
 			if err := e.sonarr.ResetTags(ctx, additionalTags); err != nil {
 				return fmt.Errorf("failed to reset Sonarr tags: %w", err)
 			}
-			log.Info("Cleaning up all Sonarr jellysweep tags...")
+			log.FromContext(ctx).Info("Cleaning up all Sonarr jellysweep tags...")
 			if err := e.sonarr.CleanupAllTags(ctx, additionalTags); err != nil {
 				return fmt.Errorf("failed to cleanup Sonarr tags: %w", err)
 			}
@@ -539,33 +856,48 @@ func (e *Engine) resetAllTags(ctx context.Context, additionalTags []string) erro
 	// Reset Radarr tags
 	if e.radarr != nil {
 		g.Go(func() error {
-			log.Info("Removing jellysweep tags from Radarr movies...")
+			log.FromContext(ctx).Info("Removing jellysweep tags from Radarr movies...")
 			if err := e.radarr.ResetTags(ctx, additionalTags); err != nil {
 				return fmt.Errorf("failed to reset Radarr tags: %w", err)
 			}
-			log.Info("Cleaning up all Radarr jellysweep tags...")
+			log.FromContext(ctx).Info("Cleaning up all Radarr jellysweep tags...")
 			if err := e.radarr.CleanupAllTags(ctx, additionalTags); err != nil {
 				return fmt.Errorf("failed to cleanup Radarr tags: %w", err)
 			}
 			return nil
 		})
 	}
+
+	// Reset Readarr tags
+	if e.readarr != nil {
+		g.Go(func() error {
+			log.FromContext(ctx).Info("Removing jellysweep tags from Readarr authors...")
+			if err := e.readarr.ResetTags(ctx, additionalTags); err != nil {
+				return fmt.Errorf("failed to reset Readarr tags: %w", err)
+			}
+			log.FromContext(ctx).Info("Cleaning up all Readarr jellysweep tags...")
+			if err := e.readarr.CleanupAllTags(ctx, additionalTags); err != nil {
+				return fmt.Errorf("failed to cleanup Readarr tags: %w", err)
+			}
+			return nil
+		})
+	}
 	if err := g.Wait(); err != nil {
-		log.Error(err)
+		log.FromContext(ctx).Error(err)
 		return fmt.Errorf("error while resetting tags")
 	}
 
-	log.Info("All jellysweep tags have been successfully reset!")
+	log.FromContext(ctx).Info("All jellysweep tags have been successfully reset!")
 	return nil
 }
 
 // migrateTagsToDatabase migrates existing jellysweep items to the database based on their tags in Sonarr and Radarr.
 func (e *Engine) migrateTagsToDatabase(ctx context.Context) error {
-	log.Info("Starting migration of jellysweep tags to database...")
+	log.FromContext(ctx).Info("Starting migration of jellysweep tags to database...")
 
 	jellyfinItems, _, err := e.jellyfin.GetJellyfinItems(ctx)
 	if err != nil {
-		log.Error("Failed to get jellyfin items for migration", "error", err)
+		log.FromContext(ctx).Error("Failed to get jellyfin items for migration", "error", err)
 		return err
 	}
 
@@ -573,7 +905,7 @@ func (e *Engine) migrateTagsToDatabase(ctx context.Context) error {
 	if e.sonarr != nil {
 		sonarrItems, err := e.sonarr.GetItems(ctx, jellyfinItems)
 		if err != nil {
-			log.Error("Failed to get sonarr items for migration", "error", err)
+			log.FromContext(ctx).Error("Failed to get sonarr items for migration", "error", err)
 			return err
 		}
 		legacyitems = append(legacyitems, sonarrItems...)
@@ -581,7 +913,7 @@ func (e *Engine) migrateTagsToDatabase(ctx context.Context) error {
 	if e.radarr != nil {
 		radarrItems, err := e.radarr.GetItems(ctx, jellyfinItems)
 		if err != nil {
-			log.Error("Failed to get radarr items for migration", "error", err)
+			log.FromContext(ctx).Error("Failed to get radarr items for migration", "error", err)
 			return err
 		}
 		legacyitems = append(legacyitems, radarrItems...)
@@ -621,26 +953,26 @@ func (e *Engine) migrateTagsToDatabase(ctx context.Context) error {
 
 		if mustMigrate {
 			dbItems = append(dbItems, dbItem)
-			log.Info("Migrating item to database", "title", dbItem.Title, "library", dbItem.LibraryName)
+			log.FromContext(ctx).Info("Migrating item to database", "title", dbItem.Title, "library", dbItem.LibraryName)
 		}
 	}
 
 	if len(dbItems) == 0 {
-		log.Debug("No items found for migration")
+		log.FromContext(ctx).Debug("No items found for migration")
 		return nil
 	}
 
 	if err := e.db.CreateMediaItems(ctx, dbItems); err != nil {
-		log.Error("Failed to migrate items to database", "error", err)
+		log.FromContext(ctx).Error("Failed to migrate items to database", "error", err)
 		return err
 	}
 
 	if err := e.resetAllTags(ctx, nil); err != nil {
-		log.Error("Failed to reset tags after migration", "error", err)
+		log.FromContext(ctx).Error("Failed to reset tags after migration", "error", err)
 		return err
 	}
 
-	log.Info("Migration of tags to database completed successfully")
+	log.FromContext(ctx).Info("Migration of tags to database completed successfully")
 
 	return nil
 }
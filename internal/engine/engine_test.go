@@ -0,0 +1,1717 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+	radarrAPI "github.com/devopsarr/radarr-go/radarr"
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/cache"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/engine/mediaserver"
+	"github.com/jon4hz/jellysweep/internal/engine/stats"
+	"github.com/jon4hz/jellysweep/internal/filter"
+	agefilter "github.com/jon4hz/jellysweep/internal/filter/age_filter"
+	databasefilter "github.com/jon4hz/jellysweep/internal/filter/database_filter"
+	requestagefilter "github.com/jon4hz/jellysweep/internal/filter/request_age_filter"
+	"github.com/jon4hz/jellysweep/internal/notify/email"
+	"github.com/jon4hz/jellysweep/internal/notify/webhook"
+	"github.com/jon4hz/jellysweep/internal/policy"
+	"github.com/jon4hz/jellysweep/internal/tags"
+	jellyfin "github.com/sj14/jellyfin-go/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type fakeMediaServer struct {
+	mediaserver.MediaServer
+	items []arr.JellyfinItem
+	// collections maps collection names to the item IDs they contain.
+	collections map[string][]string
+}
+
+func (f *fakeMediaServer) GetJellyfinItems(context.Context) ([]arr.JellyfinItem, map[string][]string, error) {
+	return f.items, map[string][]string{}, nil
+}
+
+func (f *fakeMediaServer) RemoveItemWithCleanupMode(context.Context, string, string, jellyfin.BaseItemKind, config.CleanupMode, int) error {
+	return nil
+}
+
+func (f *fakeMediaServer) RemoveItemFromCollection(_ context.Context, collectionName, itemID string) error {
+	if ids, ok := f.collections[collectionName]; ok {
+		f.collections[collectionName] = slices.DeleteFunc(ids, func(id string) bool { return id == itemID })
+	}
+	return nil
+}
+
+// fakeArr turns every jellyfin item it receives into a media item.
+type fakeArr struct {
+	arr.Arrer
+	mediaType models.MediaType
+	received  []arr.JellyfinItem
+	// downloading are the IDs of the items with a download in progress.
+	downloading map[int32]bool
+	// pathLibrary is the library assigned to items without a library in the media server, like the library path map does.
+	pathLibrary string
+	// deleteErr is returned by every deletion.
+	deleteErr error
+}
+
+func (f *fakeArr) DeleteMedia(context.Context, int32, string) error {
+	return f.deleteErr
+}
+
+func (f *fakeArr) GetDownloadingIDs(context.Context) (map[int32]bool, error) {
+	return f.downloading, nil
+}
+
+func (f *fakeArr) GetItems(_ context.Context, jellyfinItems []arr.JellyfinItem) ([]arr.MediaItem, error) {
+	f.received = append(f.received, jellyfinItems...)
+	items := make([]arr.MediaItem, 0, len(jellyfinItems))
+	for _, jf := range jellyfinItems {
+		libraryName := jf.ParentLibraryName
+		if libraryName == "" {
+			libraryName = f.pathLibrary
+		}
+		items = append(items, arr.MediaItem{
+			JellyfinID:  jf.GetId(),
+			LibraryName: libraryName,
+			Title:       jf.GetName(),
+			MediaType:   f.mediaType,
+		})
+	}
+	return items, nil
+}
+
+// fakeDB records the media items deleted from the database.
+type fakeDB struct {
+	database.DB
+	media   []database.Media
+	deleted []database.Media
+	// estimates are the deletion estimates of the last upsert.
+	estimates []database.DeletionEstimate
+	// scanCursor is the persisted scan cursor.
+	scanCursor *database.ScanCursor
+	// expired are the media items removed from the database after their protection expired.
+	expired []database.Media
+	// prefsLookups are the IDs of the users whose notification preferences were read.
+	prefsLookups []uint
+	// maintenance is the persisted maintenance mode.
+	maintenance bool
+	// failures are the recorded deletion failures.
+	failures []database.DeletionFailure
+	// savedFailures are the deletion failures saved after a failed attempt.
+	savedFailures []database.DeletionFailure
+	// clearedFailures are the IDs of the media items whose deletion failure was removed.
+	clearedFailures []uint
+	// remindersSent are the IDs of the media items whose keep expiry reminder was marked as sent.
+	remindersSent []uint
+}
+
+func (f *fakeDB) GetMediaProtectionExpiringBetween(context.Context, time.Time, time.Time) ([]database.Media, error) {
+	return f.media, nil
+}
+
+func (f *fakeDB) MarkProtectionReminderSent(_ context.Context, mediaID uint) error {
+	f.remindersSent = append(f.remindersSent, mediaID)
+	return nil
+}
+
+func (f *fakeDB) GetMaintenanceMode(context.Context) (bool, error) {
+	return f.maintenance, nil
+}
+
+func (f *fakeDB) SetMaintenanceMode(_ context.Context, enabled bool) error {
+	f.maintenance = enabled
+	return nil
+}
+
+func (f *fakeDB) GetLatestExpiredProtection(_ context.Context, jellyfinID string) (*database.Media, error) {
+	for i := range f.expired {
+		if f.expired[i].JellyfinID == jellyfinID {
+			return &f.expired[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeDB) GetUserNotificationPrefs(_ context.Context, userID uint) (*database.UserNotificationPrefs, error) {
+	f.prefsLookups = append(f.prefsLookups, userID)
+	return &database.UserNotificationPrefs{UserID: userID}, nil
+}
+
+func (f *fakeDB) GetScanCursor(context.Context) (*database.ScanCursor, error) {
+	return f.scanCursor, nil
+}
+
+func (f *fakeDB) SetScanCursor(_ context.Context, cursor *database.ScanCursor) error {
+	f.scanCursor = cursor
+	return nil
+}
+
+func (f *fakeDB) ResetScanCursor(context.Context) error {
+	f.scanCursor = nil
+	return nil
+}
+
+func (f *fakeDB) GetMediaItems(context.Context, bool) ([]database.Media, error) {
+	return f.media, nil
+}
+
+func (f *fakeDB) CreateMediaItems(_ context.Context, items []database.Media) error {
+	f.media = append(f.media, items...)
+	return nil
+}
+
+func (f *fakeDB) GetIgnoredMedia(context.Context) ([]database.Media, error) {
+	var ignored []database.Media
+	for _, item := range f.media {
+		if item.Ignored {
+			ignored = append(ignored, item)
+		}
+	}
+	return ignored, nil
+}
+
+func (f *fakeDB) SetMediaDefaultDeleteAt(_ context.Context, mediaID uint, deleteAt time.Time) error {
+	for i := range f.media {
+		if f.media[i].ID == mediaID {
+			f.media[i].DefaultDeleteAt = deleteAt
+		}
+	}
+	return nil
+}
+
+func (f *fakeDB) GetMediaItemsByJellyfinID(_ context.Context, jellyfinID string) ([]database.Media, error) {
+	var items []database.Media
+	for _, item := range f.media {
+		if item.JellyfinID == jellyfinID {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (f *fakeDB) GetDeletionFailures(context.Context) ([]database.DeletionFailure, error) {
+	return f.failures, nil
+}
+
+func (f *fakeDB) DeleteMediaItem(_ context.Context, media *database.Media) error {
+	f.deleted = append(f.deleted, *media)
+	return nil
+}
+
+func (f *fakeDB) CreateHistoryEvent(context.Context, database.HistoryEvent) error {
+	return nil
+}
+
+func (f *fakeDB) GetDeletionFailure(_ context.Context, mediaID uint) (*database.DeletionFailure, error) {
+	for _, failure := range f.failures {
+		if failure.MediaID == mediaID {
+			return &failure, nil
+		}
+	}
+	return &database.DeletionFailure{MediaID: mediaID}, nil
+}
+
+func (f *fakeDB) SaveDeletionFailure(_ context.Context, failure *database.DeletionFailure) error {
+	f.savedFailures = append(f.savedFailures, *failure)
+	return nil
+}
+
+func (f *fakeDB) DeleteDeletionFailure(_ context.Context, mediaID uint) error {
+	f.clearedFailures = append(f.clearedFailures, mediaID)
+	return nil
+}
+
+func (f *fakeDB) CreateCleanupRun(_ context.Context, dryRun bool) (*database.CleanupRun, error) {
+	return &database.CleanupRun{Model: gorm.Model{ID: 7}, DryRun: dryRun}, nil
+}
+
+func (f *fakeDB) GetDueDeletionFailures(context.Context, time.Time) ([]database.DeletionFailure, error) {
+	return f.failures, nil
+}
+
+func (f *fakeDB) UpsertDeletionEstimates(_ context.Context, estimates []database.DeletionEstimate) error {
+	f.estimates = estimates
+	return nil
+}
+
+// triggerPolicy marks every media item for deletion.
+type triggerPolicy struct{}
+
+func (triggerPolicy) Apply(context.Context, *database.Media) error { return nil }
+
+func (triggerPolicy) ShouldTriggerDeletion(context.Context, database.Media) (bool, error) {
+	return true, nil
+}
+
+func newJellyfinItem(id, name, library string) arr.JellyfinItem {
+	item := jellyfin.NewBaseItemDto()
+	item.SetId(id)
+	item.SetName(name)
+	return arr.JellyfinItem{BaseItemDto: *item, ParentLibraryName: library}
+}
+
+func TestGatherMediaItemsSkipsDisabledLibraries(t *testing.T) {
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"Movies":   {Enabled: true},
+			"Kids":     {Enabled: false},
+			"TV Shows": {Enabled: true},
+		},
+	}
+	radarr := &fakeArr{}
+	e := &Engine{
+		cfg:    cfg,
+		policy: policy.NewEngine(),
+		jellyfin: &fakeMediaServer{items: []arr.JellyfinItem{
+			newJellyfinItem("1", "Movie", "Movies"),
+			newJellyfinItem("2", "Kids Movie", "Kids"),
+			newJellyfinItem("3", "Show", "TV Shows"),
+			newJellyfinItem("4", "Unknown", "Unconfigured"),
+		}},
+		radarr: radarr,
+		data:   &data{},
+	}
+
+	mediaItems, err := e.gatherMediaItems(context.Background())
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(mediaItems))
+	for _, item := range mediaItems {
+		ids = append(ids, item.JellyfinID)
+	}
+	assert.Equal(t, []string{"1", "3"}, ids)
+
+	// the arrs must not be asked to match items of disabled libraries at all
+	assert.Len(t, radarr.received, 2)
+	for _, item := range radarr.received {
+		assert.NotEqual(t, "Kids", item.ParentLibraryName)
+	}
+}
+
+func TestGatherMediaItemsCountsLibrariesAssignedByPath(t *testing.T) {
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"Movies": {Enabled: true},
+			"Anime":  {Enabled: true},
+		},
+		LibraryPathMap: []config.LibraryPath{{Path: "/data/anime", Library: "Anime"}},
+	}
+	e := &Engine{
+		cfg:    cfg,
+		policy: policy.NewEngine(),
+		jellyfin: &fakeMediaServer{items: []arr.JellyfinItem{
+			newJellyfinItem("1", "Movie", "Movies"),
+			newJellyfinItem("2", "Anime 1", "Anime"),
+			newJellyfinItem("3", "Anime 2", ""),
+			newJellyfinItem("4", "Anime 3", ""),
+		}},
+		radarr: &fakeArr{mediaType: models.MediaTypeMovie, pathLibrary: "Anime"},
+		data:   &data{},
+	}
+
+	_, err := e.gatherMediaItems(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"Movies": 1, "Anime": 3}, e.data.libraryItemCounts)
+}
+
+// titleFilter drops the items with the given title.
+type titleFilter struct{ title string }
+
+func (f titleFilter) String() string { return "Title Filter" }
+
+func (f titleFilter) Apply(_ context.Context, items []arr.MediaItem) ([]arr.MediaItem, error) {
+	return slices.DeleteFunc(slices.Clone(items), func(item arr.MediaItem) bool { return item.Title == f.title }), nil
+}
+
+func TestPreviewLibrary(t *testing.T) {
+	e := &Engine{
+		cfg: &config.Config{
+			Libraries: map[string]*config.CleanupConfig{
+				"Movies": {Enabled: true},
+				"Kids":   {Enabled: true},
+				"Old":    {Enabled: false},
+			},
+		},
+		estimateFilters: filter.New(titleFilter{title: "Movie 2"}),
+		jellyfin: &fakeMediaServer{items: []arr.JellyfinItem{
+			newJellyfinItem("1", "Movie 1", "Movies"),
+			newJellyfinItem("2", "Movie 2", "Movies"),
+			newJellyfinItem("3", "Kids Movie", "Kids"),
+		}},
+		radarr: &fakeArr{mediaType: models.MediaTypeMovie},
+	}
+
+	items, err := e.PreviewLibrary(context.Background(), "movies")
+	require.NoError(t, err)
+	assert.Equal(t, []PreviewItem{
+		{Title: "Movie 1", MediaType: models.MediaTypeMovie},
+		{Title: "Movie 2", MediaType: models.MediaTypeMovie, DroppedBy: "Title Filter"},
+	}, items)
+
+	_, err = e.PreviewLibrary(context.Background(), "Old")
+	require.Error(t, err)
+}
+
+func TestRestrictLibraryMediaTypes(t *testing.T) {
+	e := &Engine{
+		cfg: &config.Config{
+			Libraries: map[string]*config.CleanupConfig{
+				"Mixed":  {Enabled: true},
+				"Anime":  {Enabled: true, MediaType: config.LibraryMediaTypeTV},
+				"Movies": {Enabled: true},
+			},
+		},
+	}
+
+	items := e.restrictLibraryMediaTypes(context.Background(), []arr.MediaItem{
+		{Title: "Show", LibraryName: "Mixed", MediaType: models.MediaTypeTV},
+		{Title: "Movie", LibraryName: "Mixed", MediaType: models.MediaTypeMovie},
+		{Title: "Anime Show", LibraryName: "Anime", MediaType: models.MediaTypeTV},
+		{Title: "Anime Movie", LibraryName: "anime", MediaType: models.MediaTypeMovie},
+		{Title: "Other Movie", LibraryName: "Movies", MediaType: models.MediaTypeMovie},
+	})
+
+	titles := make([]string, 0, len(items))
+	for _, item := range items {
+		titles = append(titles, item.Title)
+	}
+	assert.Equal(t, []string{"Show", "Movie", "Anime Show", "Other Movie"}, titles)
+
+	assert.Equal(t, map[string][]models.MediaType{
+		"Mixed":  {models.MediaTypeMovie, models.MediaTypeTV},
+		"Movies": {models.MediaTypeMovie},
+	}, libraryMediaTypes([]arr.MediaItem{
+		{LibraryName: "Mixed", MediaType: models.MediaTypeTV},
+		{LibraryName: "Mixed", MediaType: models.MediaTypeMovie},
+		{LibraryName: "Mixed", MediaType: models.MediaTypeTV},
+		{LibraryName: "Movies", MediaType: models.MediaTypeMovie},
+	}))
+}
+
+func TestDropDisabledLibraryItems(t *testing.T) {
+	e := &Engine{
+		cfg: &config.Config{
+			Libraries: map[string]*config.CleanupConfig{
+				"Movies": {Enabled: true},
+				"Kids":   {Enabled: false},
+			},
+		},
+	}
+
+	items := e.dropDisabledLibraryItems(context.Background(), []arr.MediaItem{
+		{Title: "Movie", LibraryName: "movies"},
+		{Title: "Kids Movie", LibraryName: "Kids", FileSize: 1 << 30},
+		{Title: "Orphan", LibraryName: ""},
+	})
+
+	require.Len(t, items, 1)
+	assert.Equal(t, "Movie", items[0].Title)
+}
+
+func TestSonarrLibraryNameFromMediaServer(t *testing.T) {
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"Anime":    {Enabled: true, CleanupDelay: 3},
+			"TV Shows": {Enabled: true, CleanupDelay: 30},
+		},
+	}
+	db := &fakeDB{}
+	e := &Engine{
+		cfg:    cfg,
+		db:     db,
+		policy: policy.NewEngine(),
+		jellyfin: &fakeMediaServer{items: []arr.JellyfinItem{
+			newJellyfinItem("1", "Cowboy Bebop", "Anime"),
+		}},
+		sonarr: &fakeArr{mediaType: models.MediaTypeTV},
+		data:   &data{},
+	}
+
+	mediaItems, err := e.gatherMediaItems(context.Background())
+	require.NoError(t, err)
+	require.Len(t, mediaItems, 1)
+	assert.Equal(t, "Anime", mediaItems[0].LibraryName)
+
+	// the deletion policies receive the real library name
+	dbItem := arrMediaToDBMediaItem(mediaItems[0])
+	require.NoError(t, policy.NewDefaultDelete(cfg).Apply(context.Background(), &dbItem))
+	assert.Equal(t, "Anime", dbItem.LibraryName)
+	assert.WithinDuration(t, time.Now().Add(3*24*time.Hour), dbItem.DefaultDeleteAt, time.Minute)
+
+	// deleted items are reported in their real library
+	deletedItems := make(map[string][]arr.MediaItem)
+	e.finishDeletion(context.Background(), dbItem, deletedItems, database.HistoryEventDeleted)
+	assert.Contains(t, deletedItems, "Anime")
+	assert.NotContains(t, deletedItems, "TV Shows")
+	require.Len(t, db.deleted, 1)
+	assert.Equal(t, "Anime", db.deleted[0].LibraryName)
+}
+
+func TestCleanupMediaKeepsMinItemsPerLibrary(t *testing.T) {
+	e := &Engine{
+		cfg: &config.Config{
+			DryRun:             true,
+			DryRunReportPath:   filepath.Join(t.TempDir(), "report.json"),
+			MinItemsPerLibrary: 2,
+			Libraries: map[string]*config.CleanupConfig{
+				"Movies": {Enabled: true},
+				"Anime":  {Enabled: true},
+			},
+		},
+		db: &fakeDB{media: []database.Media{
+			{Title: "Movie 1", LibraryName: "Movies"},
+			{Title: "Movie 2", LibraryName: "Movies"},
+			{Title: "Movie 3", LibraryName: "Movies"},
+			{Title: "Anime 1", LibraryName: "Anime"},
+		}},
+		policy: policy.NewEngine(),
+		jellyfin: &fakeMediaServer{items: []arr.JellyfinItem{
+			newJellyfinItem("1", "Movie 1", "Movies"),
+			newJellyfinItem("2", "Movie 2", "Movies"),
+			newJellyfinItem("3", "Movie 3", "Movies"),
+			newJellyfinItem("4", "Movie 4", "Movies"),
+			newJellyfinItem("5", "Anime 1", "Anime"),
+		}},
+		radarr: &fakeArr{mediaType: models.MediaTypeMovie},
+		data:   &data{},
+	}
+
+	_, err := e.gatherMediaItems(context.Background())
+	require.NoError(t, err)
+	// gathering sets the configured policies, replace them to mark everything
+	e.policy.SetPolicies(triggerPolicy{})
+	require.NoError(t, e.cleanupMedia(context.Background()))
+
+	// Movies has 4 items, so only 2 of them may go. Anime is already below the minimum.
+	titles := make([]string, 0, len(e.data.dryRunReport))
+	for _, entry := range e.data.dryRunReport {
+		titles = append(titles, entry.Title)
+	}
+	assert.Equal(t, []string{"Movie 1", "Movie 2"}, titles)
+}
+
+func TestCleanupMediaRespectsMinMarkToDeleteHours(t *testing.T) {
+	now := time.Now()
+	e := &Engine{
+		cfg: &config.Config{
+			DryRun:               true,
+			DryRunReportPath:     filepath.Join(t.TempDir(), "report.json"),
+			MinMarkToDeleteHours: 24,
+		},
+		db: &fakeDB{media: []database.Media{
+			{Model: gorm.Model{ID: 1, CreatedAt: now}, Title: "Fresh", LibraryName: "Movies"},
+			{Model: gorm.Model{ID: 2, CreatedAt: now.Add(-23 * time.Hour)}, Title: "Almost", LibraryName: "Movies"},
+			{Model: gorm.Model{ID: 3, CreatedAt: now.Add(-25 * time.Hour)}, Title: "Cooled Down", LibraryName: "Movies"},
+		}},
+		policy: policy.NewEngine(),
+		data:   &data{libraryItemCounts: map[string]int{"Movies": 3}},
+	}
+	// the deletion date of every item has passed
+	e.policy.SetPolicies(triggerPolicy{})
+
+	require.NoError(t, e.cleanupMedia(context.Background()))
+
+	require.Len(t, e.data.dryRunReport, 1)
+	assert.Equal(t, "Cooled Down", e.data.dryRunReport[0].Title)
+}
+
+func TestCleanupMediaRespectsMaxItemsPerRun(t *testing.T) {
+	e := &Engine{
+		cfg: &config.Config{
+			DryRun:           true,
+			DryRunReportPath: filepath.Join(t.TempDir(), "report.json"),
+			MaxItemsPerRun:   2,
+			OrderBy:          config.ScanOrderSize,
+		},
+		db: &fakeDB{media: []database.Media{
+			{Model: gorm.Model{ID: 1}, Title: "Small", LibraryName: "Movies", FileSize: 1},
+			{Model: gorm.Model{ID: 2}, Title: "Large", LibraryName: "Movies", FileSize: 3},
+			{Model: gorm.Model{ID: 3}, Title: "Medium", LibraryName: "Movies", FileSize: 2},
+		}},
+		policy: policy.NewEngine(),
+		data:   &data{libraryItemCounts: map[string]int{"Movies": 3}},
+	}
+	e.policy.SetPolicies(triggerPolicy{})
+
+	require.NoError(t, e.cleanupMedia(context.Background()))
+
+	require.Len(t, e.data.dryRunReport, 2)
+	assert.Equal(t, "Large", e.data.dryRunReport[0].Title)
+	assert.Equal(t, "Medium", e.data.dryRunReport[1].Title)
+}
+
+func TestEstimateReclaimableSize(t *testing.T) {
+	now := time.Now()
+	e := &Engine{
+		cfg: &config.Config{
+			MaxItemsPerRun:       3,
+			OrderBy:              config.ScanOrderSize,
+			MinMarkToDeleteHours: 24,
+		},
+		db: &fakeDB{},
+	}
+	policies := policy.NewEngine()
+	policies.SetPolicies(triggerPolicy{})
+
+	recorded := []database.Media{
+		{Model: gorm.Model{ID: 1, CreatedAt: now.Add(-48 * time.Hour)}, Title: "Movie", LibraryName: "Movies", FileSize: 100},
+		{Model: gorm.Model{ID: 2, CreatedAt: now.Add(-48 * time.Hour)}, Title: "Show", LibraryName: "Shows", FileSize: 300},
+		{Model: gorm.Model{ID: 3, CreatedAt: now}, Title: "Fresh", LibraryName: "Movies", FileSize: 1000},
+		{Model: gorm.Model{ID: 4, CreatedAt: now.Add(-48 * time.Hour)}, Title: "Tiny", LibraryName: "Movies", FileSize: 1},
+		{Model: gorm.Model{ID: 5, CreatedAt: now.Add(-48 * time.Hour)}, Title: "Over Limit", LibraryName: "Shows", FileSize: 0},
+	}
+	candidates := e.reclaimableCandidates(context.Background(), policies, recorded, nil, nil, now)
+
+	perLibrary, total := e.reclaimableSize(context.Background(), policies, map[string]int{"Movies": 10, "Shows": 10}, candidates)
+	assert.Equal(t, map[string]int64{"Movies": 101, "Shows": 300}, perLibrary, "items in the cool-down or beyond the run limit aren't counted")
+	assert.Equal(t, int64(401), total)
+	assert.Equal(t, "Movie", recorded[0].Title, "the recorded items aren't reordered")
+}
+
+func TestApplyScanBudget(t *testing.T) {
+	movie := func(id string, added time.Time) arr.MediaItem {
+		resource := radarrAPI.MovieResource{}
+		resource.SetAdded(added)
+		return arr.MediaItem{JellyfinID: id, MediaType: models.MediaTypeMovie, MovieResource: resource}
+	}
+	now := time.Now()
+	items := []arr.MediaItem{
+		movie("c", now.AddDate(0, 0, -1)),
+		movie("a", now.AddDate(0, 0, -3)),
+		movie("e", now.AddDate(0, 0, -5)),
+		movie("b", now.AddDate(0, 0, -2)),
+		movie("d", now.AddDate(0, 0, -4)),
+	}
+	ids := func(items []arr.MediaItem) []string {
+		result := make([]string, 0, len(items))
+		for _, item := range items {
+			result = append(result, item.JellyfinID)
+		}
+		return result
+	}
+
+	db := &fakeDB{}
+	cfg := &config.Config{MaxItemsPerRun: 2, OrderBy: config.ScanOrderAdded}
+	f := newScanBudgetFilter(cfg, db)
+	apply := func(items []arr.MediaItem) []string {
+		result, err := f.Apply(context.Background(), items)
+		require.NoError(t, err)
+		return ids(result)
+	}
+
+	assert.Equal(t, []string{"e", "d"}, apply(items))
+	require.NotNil(t, db.scanCursor)
+	assert.Equal(t, "d", db.scanCursor.LastJellyfinID)
+	assert.Equal(t, []string{"a", "b"}, apply(items))
+	assert.Equal(t, []string{"c"}, apply(items))
+	assert.Nil(t, db.scanCursor, "the cursor starts over at the end")
+	assert.Equal(t, []string{"e", "d"}, apply(items))
+
+	// removing an already checked item doesn't skip the next one
+	assert.Equal(t, []string{"a", "b"}, apply(slices.DeleteFunc(slices.Clone(items), func(item arr.MediaItem) bool {
+		return item.JellyfinID == "e"
+	})))
+
+	// items added before the cursor are checked once the scan starts over
+	items = append(items, movie("f", now.AddDate(0, 0, -6)))
+	assert.Equal(t, []string{"c"}, apply(items))
+	assert.Equal(t, []string{"f", "e"}, apply(items))
+
+	preview, err := f.previewFilter().Apply(context.Background(), items)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"d", "a"}, ids(preview))
+	assert.Equal(t, "e", db.scanCursor.LastJellyfinID, "the preview doesn't move the cursor")
+
+	cfg.MaxItemsPerRun = 0
+	assert.Equal(t, ids(items), apply(items), "without a budget all items are returned unchanged")
+}
+
+func TestCleanupMediaRemovesItemsFromLeavingCollections(t *testing.T) {
+	mediaServer := &fakeMediaServer{collections: map[string][]string{
+		"Leaving Movies":   {"1", "2"},
+		"Leaving TV Shows": {"3"},
+	}}
+	db := &fakeDB{media: []database.Media{
+		{Title: "Movie 1", LibraryName: "Movies", JellyfinID: "1", MediaType: database.MediaTypeMovie},
+	}}
+	e := &Engine{
+		cfg: &config.Config{
+			LeavingCollectionsEnabled:   true,
+			LeavingCollectionsMovieName: "Leaving Movies",
+			LeavingCollectionsTVName:    "Leaving TV Shows",
+		},
+		db:       db,
+		policy:   policy.NewEngine(),
+		jellyfin: mediaServer,
+		radarr:   &fakeArr{mediaType: models.MediaTypeMovie},
+		data:     &data{libraryItemCounts: map[string]int{"Movies": 2}},
+	}
+	e.policy.SetPolicies(triggerPolicy{})
+
+	require.NoError(t, e.cleanupMedia(context.Background()))
+
+	require.Len(t, db.deleted, 1)
+	assert.Equal(t, map[string][]string{
+		"Leaving Movies":   {"2"},
+		"Leaving TV Shows": {"3"},
+	}, mediaServer.collections)
+}
+
+// seasonArr records the seasons kept on partial deletions.
+type seasonArr struct {
+	fakeArr
+	kept map[int32][]int32
+}
+
+func (f *seasonArr) DeleteSeasonsExcept(_ context.Context, seriesID int32, _ string, keep []int32) (int64, error) {
+	f.kept[seriesID] = keep
+	return 100, nil
+}
+
+func TestCleanupUnkeptSeasons(t *testing.T) {
+	protectedUntil := time.Now().Add(24 * time.Hour)
+	expired := time.Now().Add(-time.Hour)
+	sonarr := &seasonArr{kept: make(map[int32][]int32)}
+	e := &Engine{
+		cfg: &config.Config{},
+		db: &fakeDB{media: []database.Media{
+			{ArrID: 1, Title: "Kept Seasons", MediaType: database.MediaTypeTV, ProtectedUntil: &protectedUntil, KeptSeasons: database.Seasons{1, 2}},
+			{ArrID: 2, Title: "Whole Series", MediaType: database.MediaTypeTV, ProtectedUntil: &protectedUntil},
+			{ArrID: 3, Title: "Expired", MediaType: database.MediaTypeTV, ProtectedUntil: &expired, KeptSeasons: database.Seasons{1}},
+			{ArrID: 4, Title: "Movie", MediaType: database.MediaTypeMovie, ProtectedUntil: &protectedUntil, KeptSeasons: database.Seasons{1}},
+		}},
+		policy: policy.NewEngine(),
+		sonarr: sonarr,
+		data:   &data{run: &database.CleanupRun{}},
+	}
+	e.policy.SetPolicies(triggerPolicy{})
+
+	deletedItems := make(map[string][]arr.MediaItem)
+	attempted := e.cleanupUnkeptSeasons(context.Background(), map[string]int{}, 0, deletedItems)
+
+	assert.Equal(t, map[int32][]int32{1: {1, 2}}, sonarr.kept)
+	assert.Equal(t, 1, attempted)
+	assert.Equal(t, 1, e.data.run.ItemsDeleted)
+	assert.Equal(t, int64(100), e.data.run.BytesFreed)
+	require.Len(t, deletedItems[""], 1, "the series is part of the completion notifications")
+	assert.Equal(t, "Kept Seasons", deletedItems[""][0].Title)
+}
+
+func TestCleanupUnkeptSeasonsSafeguards(t *testing.T) {
+	protectedUntil := time.Now().Add(24 * time.Hour)
+	series := func(arrID int32, markedAt time.Time) database.Media {
+		return database.Media{
+			Model: gorm.Model{CreatedAt: markedAt}, ArrID: arrID, Title: fmt.Sprintf("Series %d", arrID), LibraryName: "TV Shows",
+			MediaType: database.MediaTypeTV, ProtectedUntil: &protectedUntil, KeptSeasons: database.Seasons{1},
+		}
+	}
+	newEngine := func(cfg *config.Config, media ...database.Media) (*Engine, *seasonArr) {
+		sonarr := &seasonArr{kept: make(map[int32][]int32)}
+		e := &Engine{cfg: cfg, db: &fakeDB{media: media}, policy: policy.NewEngine(), sonarr: sonarr, data: &data{}}
+		e.policy.SetPolicies(triggerPolicy{})
+		return e, sonarr
+	}
+	old := time.Now().Add(-48 * time.Hour)
+
+	t.Run("cool-down", func(t *testing.T) {
+		e, sonarr := newEngine(&config.Config{MinMarkToDeleteHours: 24}, series(1, time.Now()), series(2, old))
+		e.cleanupUnkeptSeasons(context.Background(), map[string]int{}, 0, make(map[string][]arr.MediaItem))
+		assert.Equal(t, map[int32][]int32{2: {1}}, sonarr.kept)
+	})
+
+	t.Run("library floor", func(t *testing.T) {
+		e, sonarr := newEngine(&config.Config{MinItemsPerLibrary: 2}, series(1, old))
+		e.cleanupUnkeptSeasons(context.Background(), map[string]int{"TV Shows": 2}, 0, make(map[string][]arr.MediaItem))
+		assert.Empty(t, sonarr.kept)
+	})
+
+	t.Run("deletion budget", func(t *testing.T) {
+		e, sonarr := newEngine(&config.Config{MaxItemsPerRun: 2}, series(1, old), series(2, old))
+		attempted := e.cleanupUnkeptSeasons(context.Background(), map[string]int{}, 1, make(map[string][]arr.MediaItem))
+		assert.Equal(t, map[int32][]int32{1: {1}}, sonarr.kept, "the deletions of the whole items count against the budget")
+		assert.Equal(t, 2, attempted)
+	})
+}
+
+func TestEstimateDeletions(t *testing.T) {
+	deleteAt := time.Now().Add(24 * time.Hour)
+	protectedUntil := deleteAt.Add(7 * 24 * time.Hour)
+	expired := time.Now().Add(-time.Hour)
+	db := &fakeDB{media: []database.Media{
+		{Model: gorm.Model{ID: 1}, Title: "Marked", LibraryName: "Movies", FileSize: 10, DefaultDeleteAt: deleteAt},
+		{Model: gorm.Model{ID: 2}, Title: "Protected", LibraryName: "Movies", DefaultDeleteAt: deleteAt, ProtectedUntil: &protectedUntil},
+		{Model: gorm.Model{ID: 3}, Title: "Protection Expired", LibraryName: "Movies", DefaultDeleteAt: deleteAt, ProtectedUntil: &expired},
+		{Model: gorm.Model{ID: 4}, Title: "Ignored", LibraryName: "Movies", DefaultDeleteAt: deleteAt, Ignored: true},
+	}}
+	e := &Engine{cfg: &config.Config{}, db: db}
+
+	require.NoError(t, e.estimateDeletions(context.Background()))
+
+	require.Len(t, db.estimates, 3)
+	assert.Equal(t, database.DeletionEstimate{
+		MediaID: 1, Title: "Marked", LibraryName: "Movies", FileSize: 10, EstimatedDeleteAt: deleteAt,
+	}, db.estimates[0])
+	assert.Equal(t, protectedUntil, db.estimates[1].EstimatedDeleteAt)
+	assert.Equal(t, deleteAt, db.estimates[2].EstimatedDeleteAt)
+}
+
+func TestLibraryFloorReachedDisabled(t *testing.T) {
+	e := &Engine{cfg: &config.Config{}}
+	assert.False(t, e.libraryFloorReached(map[string]int{}, "Movies"))
+}
+
+func TestFilterStepName(t *testing.T) {
+	assert.Equal(t, "filter_tags", filterStepName("Tags Filter"))
+	assert.Equal(t, "filter_age", filterStepName("Age Filter"))
+	assert.Equal(t, "filter_custom_rule", filterStepName("Custom Rule"))
+}
+
+func TestDiffMarkedItems(t *testing.T) {
+	marked := []arr.MediaItem{
+		{JellyfinID: "kept", MediaType: models.MediaTypeMovie},
+		{JellyfinID: "new", MediaType: models.MediaTypeMovie},
+		{JellyfinID: "ignored", MediaType: models.MediaTypeMovie},
+	}
+	recorded := []database.Media{
+		{JellyfinID: "kept", Title: "Kept"},
+		{JellyfinID: "gone", Title: "Gone"},
+	}
+	ignored := []database.Media{
+		{JellyfinID: "ignored", Ignored: true},
+	}
+
+	diff := diffMarkedItems(marked, recorded, ignored)
+
+	require.Len(t, diff.Added, 1)
+	assert.Equal(t, "new", diff.Added[0].JellyfinID)
+	require.Len(t, diff.Removed, 1)
+	assert.Equal(t, "gone", diff.Removed[0].JellyfinID)
+}
+
+func TestDiffMarkedItemsEmpty(t *testing.T) {
+	diff := diffMarkedItems(nil, nil, nil)
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Removed)
+	assert.NotNil(t, diff.Added)
+	assert.NotNil(t, diff.Removed)
+}
+
+func TestApplyFiltersWithDryRunDiff(t *testing.T) {
+	movie := func(id int32, title string) arr.MediaItem {
+		resource := radarrAPI.MovieResource{}
+		resource.SetId(id)
+		resource.SetTitle(title)
+		return arr.MediaItem{JellyfinID: title, Title: title, MediaType: models.MediaTypeMovie, MovieResource: resource}
+	}
+	db := &fakeDB{media: []database.Media{
+		{JellyfinID: "recorded", Title: "recorded", MediaType: database.MediaTypeMovie, ArrID: 2},
+		{JellyfinID: "gone", Title: "gone", MediaType: database.MediaTypeMovie, ArrID: 3},
+	}}
+	cfg := &config.Config{DryRun: true, DryRunReportPath: filepath.Join(t.TempDir(), "report.json")}
+	e := &Engine{
+		cfg:           cfg,
+		db:            db,
+		diffFilters:   filter.New(titleFilter{title: "gone"}),
+		recordFilters: filter.New(newScanBudgetFilter(cfg, db), databasefilter.New(db)),
+		data:          &data{},
+	}
+
+	marked, err := e.applyFiltersWithDryRunDiff(context.Background(), []arr.MediaItem{
+		movie(1, "new"),
+		movie(2, "recorded"),
+		movie(3, "gone"),
+	}, false)
+	require.NoError(t, err)
+
+	require.Len(t, marked, 1, "recorded items are dropped after the diff")
+	assert.Equal(t, "new", marked[0].Title)
+	reasons := make(map[string]string)
+	for _, entry := range e.data.dryRunReport {
+		reasons[entry.Title] = entry.Reason
+	}
+	assert.Equal(t, map[string]string{"new": dryRunReasonNewlyMarked, "gone": dryRunReasonNoLongerMarked}, reasons)
+}
+
+func TestOrphanedItems(t *testing.T) {
+	items := []arr.TaggedItem{
+		{ID: 1, Title: "Tracked", Tags: []string{"jellysweep-delete-2025-01-01"}},
+		{ID: 2, Title: "Orphan", Tags: []string{"jellysweep-must-keep-2025-01-01"}},
+	}
+
+	reports := orphanedItems("radarr", items, map[int32]bool{1: true})
+
+	require.Len(t, reports, 1)
+	assert.Equal(t, OrphanReport{
+		Service: "radarr",
+		ArrID:   2,
+		Title:   "Orphan",
+		Tags:    []string{"jellysweep-must-keep-2025-01-01"},
+	}, reports[0])
+}
+
+// pingArr is an arr whose Ping returns err.
+type pingArr struct {
+	fakeArr
+	err error
+}
+
+func (p *pingArr) Ping(context.Context) error { return p.err }
+
+func TestCheckReadiness(t *testing.T) {
+	e := &Engine{
+		cfg:      &config.Config{ReadinessTimeout: 1},
+		jellyfin: &fakeMediaServer{}, // can't be pinged, skipped
+		sonarr:   &pingArr{},
+		radarr:   &pingArr{err: errors.New("connection refused")},
+	}
+
+	ready, statuses := e.CheckReadiness(context.Background())
+	assert.False(t, ready)
+	assert.Equal(t, []DependencyStatus{
+		{Name: "sonarr", Mandatory: true, Reachable: true},
+		{Name: "radarr", Mandatory: true, Reachable: false, Error: "connection refused"},
+	}, statuses)
+
+	e.radarr = &pingArr{}
+	ready, _ = e.CheckReadiness(context.Background())
+	assert.True(t, ready)
+}
+
+func TestReadyFromStatusesIgnoresOptional(t *testing.T) {
+	assert.True(t, readyFromStatuses([]DependencyStatus{
+		{Name: "media_server", Mandatory: true, Reachable: true},
+		{Name: "jellyseerr", Reachable: false, Error: "timeout"},
+	}))
+	assert.True(t, readyFromStatuses(nil))
+}
+
+// pingStats is a stats backend whose Ping returns err.
+type pingStats struct {
+	stats.Statser
+	err error
+}
+
+func (p *pingStats) Ping(context.Context) error { return p.err }
+
+func TestCheckStats(t *testing.T) {
+	unreachable := &pingStats{err: errors.New("connection refused")}
+	tests := []struct {
+		name       string
+		stats      stats.Statser
+		failMode   config.StatsFailMode
+		skipStream bool
+		wantErr    bool
+	}{
+		{name: "reachable", stats: &pingStats{}},
+		{name: "default fail mode", stats: unreachable, wantErr: true},
+		{name: "skip deletions", stats: unreachable, failMode: config.StatsFailModeSkipDeletions, wantErr: true},
+		{name: "ignore stream filter", stats: unreachable, failMode: config.StatsFailModeIgnoreStreamFilter, skipStream: true},
+		{name: "continue", stats: unreachable, failMode: config.StatsFailModeContinue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Engine{cfg: &config.Config{Stats: &config.StatsConfig{FailMode: tt.failMode}}, stats: tt.stats}
+
+			skipStream, err := e.checkStats(context.Background())
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrStatsUnreachable)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.skipStream, skipStream)
+		})
+	}
+}
+
+func TestCleanupMediaSkippedIfStatsUnreachable(t *testing.T) {
+	db := &fakeDB{media: []database.Media{{ArrID: 1, Title: "Movie", MediaType: database.MediaTypeMovie}}}
+	e := &Engine{
+		cfg:    &config.Config{},
+		db:     db,
+		stats:  &pingStats{err: errors.New("connection refused")},
+		policy: policy.NewEngine(),
+	}
+	e.policy.SetPolicies(triggerPolicy{})
+
+	require.ErrorIs(t, e.cleanupMedia(context.Background()), ErrStatsUnreachable)
+	assert.Empty(t, db.deleted)
+}
+
+// keepDB stores a single media item and its keep request.
+type keepDB struct {
+	fakeDB
+	mu             sync.Mutex
+	user           database.User
+	item           database.Media
+	protectedUntil *time.Time
+}
+
+func (k *keepDB) GetUserByID(context.Context, uint) (*database.User, error) {
+	return &k.user, nil
+}
+
+func (k *keepDB) GetMediaItemByID(context.Context, uint) (*database.Media, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	item := k.item
+	return &item, nil
+}
+
+func (k *keepDB) CreateRequest(_ context.Context, _ uint, userID uint, keptSeasons database.Seasons) (*database.Request, error) {
+	k.item.Request = database.Request{Model: gorm.Model{ID: 1}, UserID: userID, Status: database.RequestStatusPending, KeptSeasons: keptSeasons}
+	return &k.item.Request, nil
+}
+
+func (k *keepDB) UpdateRequestStatus(_ context.Context, _ uint, expected, status database.RequestStatus) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.item.Request.Status != expected {
+		return database.ErrRequestStatusChanged
+	}
+	k.item.Request.Status = status
+	return nil
+}
+
+func (k *keepDB) SetMediaProtectedUntil(_ context.Context, _ uint, protectedUntil *time.Time, _ database.Seasons) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.protectedUntil = protectedUntil
+	return nil
+}
+
+func TestRequestKeepMediaAutoApproval(t *testing.T) {
+	tests := []struct {
+		name            string
+		hasAutoApproval bool
+		library         string
+		wantApproved    bool
+	}{
+		{name: "manual review", library: "Movies", wantApproved: false},
+		{name: "user permission", hasAutoApproval: true, library: "Movies", wantApproved: true},
+		{name: "trusted library", library: "Kids", wantApproved: true},
+		{name: "user permission and trusted library", hasAutoApproval: true, library: "kids", wantApproved: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &keepDB{
+				user: database.User{Username: "user", UserPermissions: database.UserPermissions{HasAutoApproval: tt.hasAutoApproval}},
+				item: database.Media{Model: gorm.Model{ID: 1}, Title: "Movie", MediaType: database.MediaTypeMovie, LibraryName: tt.library},
+			}
+			e := &Engine{
+				cfg: &config.Config{
+					AutoApproveLibraries: []string{"Kids"},
+					Libraries: map[string]*config.CleanupConfig{
+						"Movies": {Enabled: true},
+						"Kids":   {Enabled: true},
+					},
+				},
+				db: db,
+			}
+
+			approved, err := e.RequestKeepMedia(context.Background(), 1, 1, "user", nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantApproved, approved)
+			if tt.wantApproved {
+				assert.Equal(t, database.RequestStatusApproved, db.item.Request.Status)
+				assert.NotNil(t, db.protectedUntil)
+			} else {
+				assert.Equal(t, database.RequestStatusPending, db.item.Request.Status)
+				assert.Nil(t, db.protectedUntil)
+			}
+		})
+	}
+}
+
+// barrierKeepDB lets every caller read the media item before any of them continues.
+type barrierKeepDB struct {
+	*keepDB
+	read sync.WaitGroup
+}
+
+func (b *barrierKeepDB) GetMediaItemByID(ctx context.Context, id uint) (*database.Media, error) {
+	item, err := b.keepDB.GetMediaItemByID(ctx, id)
+	b.read.Done()
+	b.read.Wait()
+	return item, err
+}
+
+func TestHandleKeepRequestConcurrentApprovals(t *testing.T) {
+	db := &barrierKeepDB{keepDB: &keepDB{
+		item: database.Media{
+			Model: gorm.Model{ID: 1}, Title: "Movie", MediaType: database.MediaTypeMovie, LibraryName: "Movies",
+			Request: database.Request{Model: gorm.Model{ID: 1}, UserID: 2, Status: database.RequestStatusPending},
+		},
+	}}
+	e := &Engine{
+		cfg: &config.Config{Libraries: map[string]*config.CleanupConfig{"Movies": {Enabled: true}}},
+		db:  db,
+	}
+
+	errs := make([]error, 2)
+	db.read.Add(len(errs))
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Go(func() {
+			errs[i] = e.HandleKeepRequest(context.Background(), 1, 1, true, nil)
+		})
+	}
+	wg.Wait()
+
+	var won int
+	for _, err := range errs {
+		if err == nil {
+			won++
+			continue
+		}
+		require.ErrorIs(t, err, ErrRequestAlreadyProcessed)
+	}
+	assert.Equal(t, 1, won, "exactly one approval wins")
+	assert.Equal(t, database.RequestStatusApproved, db.item.Request.Status)
+}
+
+func TestSaveMediaItemsPosterFallback(t *testing.T) {
+	arrPoster := radarrAPI.NewMediaCover()
+	arrPoster.SetCoverType(radarrAPI.MEDIACOVERTYPES_POSTER)
+	arrPoster.SetRemoteUrl("https://arr.example.com/poster.jpg")
+
+	movie := func(id int32, images []radarrAPI.MediaCover) radarrAPI.MovieResource {
+		resource := radarrAPI.MovieResource{Images: images}
+		resource.SetId(id)
+		return resource
+	}
+
+	db := &fakeDB{}
+	e := &Engine{
+		cfg:    &config.Config{DefaultPosterURL: "https://example.com/default.jpg"},
+		db:     db,
+		policy: policy.NewEngine(),
+	}
+	e.policy.SetPolicies(triggerPolicy{})
+
+	require.NoError(t, e.saveMediaItemsToDatabase(context.Background(), []arr.MediaItem{
+		{MediaType: models.MediaTypeMovie, MovieResource: movie(1, []radarrAPI.MediaCover{*arrPoster}), PosterURL: "https://tmdb.example.com/poster.jpg"},
+		{MediaType: models.MediaTypeMovie, MovieResource: movie(2, nil), PosterURL: "https://tmdb.example.com/poster.jpg"},
+		{MediaType: models.MediaTypeMovie, MovieResource: movie(3, nil)},
+	}))
+
+	require.Len(t, db.media, 3)
+	assert.Equal(t, "https://arr.example.com/poster.jpg", db.media[0].PosterURL)
+	assert.Equal(t, "https://tmdb.example.com/poster.jpg", db.media[1].PosterURL)
+	assert.Equal(t, "https://example.com/default.jpg", db.media[2].PosterURL)
+}
+
+func TestMarkForDeletionNow(t *testing.T) {
+	movie := func(id int32, tagNames ...string) arr.MediaItem {
+		resource := radarrAPI.MovieResource{}
+		resource.SetId(id)
+		resource.SetTitle(fmt.Sprintf("Movie %d", id))
+		return arr.MediaItem{MediaType: models.MediaTypeMovie, MovieResource: resource, Tags: tagNames}
+	}
+
+	now := time.Now()
+	future := now.Add(7 * 24 * time.Hour)
+	db := &fakeDB{media: []database.Media{
+		{Model: gorm.Model{ID: 2}, ArrID: 2, Title: "Movie 2", MediaType: database.MediaTypeMovie, DefaultDeleteAt: future},
+		{Model: gorm.Model{ID: 3}, ArrID: 3, Title: "Movie 3", MediaType: database.MediaTypeMovie, DefaultDeleteAt: future, ProtectedUntil: &future},
+		{Model: gorm.Model{ID: 4}, ArrID: 4, Title: "Movie 4", MediaType: database.MediaTypeMovie, DefaultDeleteAt: future, Ignored: true},
+	}}
+	e := &Engine{cfg: &config.Config{}, db: db}
+
+	deleteNow, others := splitDeleteNowItems([]arr.MediaItem{
+		movie(1, tags.JellysweepDeleteNowTag),
+		movie(2, tags.JellysweepDeleteNowTag),
+		movie(3, tags.JellysweepDeleteNowTag),
+		movie(4, tags.JellysweepDeleteNowTag),
+		movie(5, tags.JellysweepDeleteNowTag, tags.JellysweepIgnoreTag),
+		movie(6),
+	})
+	require.Len(t, deleteNow, 4)
+	require.Len(t, others, 2)
+
+	require.NoError(t, e.markForDeletionNow(context.Background(), deleteNow))
+
+	require.Len(t, db.media, 4)
+	created := db.media[3]
+	assert.Equal(t, int32(1), created.ArrID)
+	assert.True(t, created.ForceDelete)
+	assert.True(t, created.Unkeepable)
+	assert.Equal(t, "tagged for immediate deletion", created.DeletionReason)
+	assert.False(t, created.DefaultDeleteAt.After(time.Now()))
+
+	assert.False(t, db.media[0].DefaultDeleteAt.After(time.Now()), "marked item is moved up")
+	assert.Equal(t, future, db.media[1].DefaultDeleteAt, "protected item is untouched")
+	assert.Equal(t, future, db.media[2].DefaultDeleteAt, "ignored item is untouched")
+}
+
+func TestMarkForDeletionWithoutJellyseerr(t *testing.T) {
+	movie := func(id int32, added time.Time) arr.MediaItem {
+		resource := radarrAPI.MovieResource{}
+		resource.SetId(id)
+		resource.SetTitle(fmt.Sprintf("Movie %d", id))
+		resource.SetAdded(added)
+		return arr.MediaItem{
+			JellyfinID:    fmt.Sprintf("%d", id),
+			Title:         resource.GetTitle(),
+			LibraryName:   "Movies",
+			MediaType:     models.MediaTypeMovie,
+			MovieResource: resource,
+			RequestedBy:   "stale@example.com",
+		}
+	}
+
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"Movies": {Enabled: true, Filter: config.FilterConfig{RequestAgeThreshold: 30}},
+		},
+		Email: &config.EmailConfig{Enabled: true},
+	}
+	db := &fakeDB{}
+	e := &Engine{
+		cfg:     cfg,
+		db:      db,
+		stats:   &pingStats{},
+		policy:  policy.NewEngine(),
+		filters: filter.New(requestagefilter.New(cfg)),
+		data:    &data{},
+	}
+
+	now := time.Now()
+	err := e.markForDeletion(context.Background(), []arr.MediaItem{
+		movie(1, now.AddDate(0, 0, -90)),
+		movie(2, now.AddDate(0, 0, -5)),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, db.media, 1, "the recently added movie is protected by the added date")
+	assert.Equal(t, "Movie 1", db.media[0].Title)
+	assert.Empty(t, db.media[0].RequestedBy)
+	assert.Empty(t, e.data.userNotifications)
+}
+
+func TestNotifyKeptMediaDeleted(t *testing.T) {
+	requester := func(id uint, status database.RequestStatus) database.Request {
+		return database.Request{Status: status, UserID: id, User: database.User{Model: gorm.Model{ID: id}, Username: fmt.Sprintf("user%d", id)}}
+	}
+	db := &fakeDB{expired: []database.Media{
+		{JellyfinID: "approved", DBDeleteReason: database.DBDeleteReasonProtectionExpired, Request: requester(1, database.RequestStatusApproved)},
+		{JellyfinID: "denied", DBDeleteReason: database.DBDeleteReasonProtectionExpired, Request: requester(2, database.RequestStatusDenied)},
+	}}
+	e := &Engine{cfg: &config.Config{}, db: db}
+
+	for _, jellyfinID := range []string{"approved", "denied", "never-kept"} {
+		e.notifyKeptMediaDeleted(context.Background(), database.Media{JellyfinID: jellyfinID, Title: jellyfinID})
+	}
+
+	assert.Equal(t, []uint{1}, db.prefsLookups, "only the requester of the approved keep request is notified")
+}
+
+func TestSendKeepExpiryRemindersRetriesUndelivered(t *testing.T) {
+	protectedUntil := time.Now().Add(24 * time.Hour)
+	db := &fakeDB{media: []database.Media{
+		{Model: gorm.Model{ID: 1}, Title: "Kept", ProtectedUntil: &protectedUntil, Request: database.Request{
+			UserID: 1,
+			User:   database.User{Model: gorm.Model{ID: 1}, Username: "user1"},
+		}},
+	}}
+	e := &Engine{cfg: &config.Config{KeepExpiryReminderDays: 3}, db: db}
+
+	require.NoError(t, e.sendKeepExpiryReminders(context.Background()))
+
+	assert.Equal(t, []uint{1}, db.prefsLookups)
+	assert.Empty(t, db.remindersSent, "the reminder isn't marked as sent without a delivery")
+}
+
+func TestJellyseerrMediaURL(t *testing.T) {
+	tmdbID := int32(438631)
+	e := &Engine{cfg: &config.Config{Jellyseerr: &config.JellyseerrConfig{URL: "https://jellyseerr.example.com"}}}
+
+	assert.Equal(t, "https://jellyseerr.example.com/movie/438631", e.jellyseerrMediaURL(database.Media{MediaType: database.MediaTypeMovie, TmdbId: &tmdbID}))
+	assert.Equal(t, "https://jellyseerr.example.com/tv/438631", e.jellyseerrMediaURL(database.Media{MediaType: database.MediaTypeTV, TmdbId: &tmdbID}))
+	assert.Empty(t, e.jellyseerrMediaURL(database.Media{MediaType: database.MediaTypeBook, TmdbId: &tmdbID}))
+	assert.Empty(t, e.jellyseerrMediaURL(database.Media{MediaType: database.MediaTypeMovie}))
+
+	e.cfg.Jellyseerr = nil
+	assert.Empty(t, e.jellyseerrMediaURL(database.Media{MediaType: database.MediaTypeMovie, TmdbId: &tmdbID}))
+}
+
+func TestForceDeleteExpiredDryRun(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(7 * 24 * time.Hour)
+	db := &fakeDB{media: []database.Media{
+		{Model: gorm.Model{ID: 1}, Title: "Expired", DefaultDeleteAt: past},
+		{Model: gorm.Model{ID: 2}, Title: "Pending", DefaultDeleteAt: future},
+		{Model: gorm.Model{ID: 3}, Title: "Disk Usage Expired", DefaultDeleteAt: future, DiskUsageDeletePolicies: []database.DiskUsageDeletePolicy{
+			{Threshold: 90, DeleteDate: past},
+		}},
+		{Model: gorm.Model{ID: 4}, Title: "Protected", DefaultDeleteAt: past, ProtectedUntil: &future},
+		{Model: gorm.Model{ID: 5}, Title: "Failed", DefaultDeleteAt: past},
+	}}
+	db.failures = []database.DeletionFailure{{MediaID: 5}}
+	e := &Engine{cfg: &config.Config{}, db: db}
+
+	titles := func(result *ForceDeleteResult) []string {
+		var titles []string
+		for _, item := range result.Items {
+			titles = append(titles, item.Title)
+		}
+		return titles
+	}
+
+	result, err := e.ForceDeleteExpired(context.Background(), true)
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, []string{"Expired", "Disk Usage Expired"}, titles(result), "protected items and items with a failed deletion are skipped")
+	assert.Empty(t, db.deleted, "nothing is deleted in a dry run")
+
+	e.cfg.DryRun = true
+	result, err = e.ForceDeleteExpired(context.Background(), false)
+	require.NoError(t, err)
+	assert.True(t, result.DryRun, "the dry-run mode turns a forced deletion into a dry run")
+	assert.Len(t, result.Items, 2)
+	assert.Empty(t, db.deleted)
+}
+
+func TestForceDeleteExpiredLibraryFloor(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	db := &fakeDB{media: []database.Media{
+		{Model: gorm.Model{ID: 1}, ArrID: 1, Title: "First", MediaType: database.MediaTypeMovie, LibraryName: "Movies", DefaultDeleteAt: past},
+		{Model: gorm.Model{ID: 2}, ArrID: 2, Title: "Second", MediaType: database.MediaTypeMovie, LibraryName: "Movies", DefaultDeleteAt: past},
+		{Model: gorm.Model{ID: 3}, ArrID: 3, Title: "Third", MediaType: database.MediaTypeMovie, LibraryName: "Movies", DefaultDeleteAt: past},
+	}}
+	e := &Engine{
+		cfg:      &config.Config{MinItemsPerLibrary: 2},
+		db:       db,
+		radarr:   &fakeArr{mediaType: models.MediaTypeMovie},
+		jellyfin: &fakeMediaServer{},
+		data:     &data{libraryItemCounts: map[string]int{"Movies": 4}},
+	}
+
+	result, err := e.ForceDeleteExpired(context.Background(), true)
+	require.NoError(t, err)
+	assert.Len(t, result.Items, 2, "the dry run stops at the minimum item count")
+
+	result, err = e.ForceDeleteExpired(context.Background(), false)
+	require.NoError(t, err)
+	require.Len(t, result.Items, 2)
+	assert.Len(t, db.deleted, 2)
+	assert.Equal(t, 4, e.data.libraryItemCounts["Movies"], "the counts of the last cleanup run aren't changed")
+}
+
+func TestForceDeleteExpiredDuringCleanup(t *testing.T) {
+	e := &Engine{cfg: &config.Config{}, db: &fakeDB{}}
+
+	e.deleteMu.Lock()
+	_, err := e.ForceDeleteExpired(context.Background(), false)
+	e.deleteMu.Unlock()
+	require.ErrorIs(t, err, ErrDeletionInProgress)
+
+	_, err = e.ForceDeleteExpired(context.Background(), true)
+	require.NoError(t, err, "a dry run doesn't wait for the cleanup")
+}
+
+func TestRetryFailedDeletionsProtectedCollection(t *testing.T) {
+	future := time.Now().Add(24 * time.Hour)
+	sequel := database.Media{Model: gorm.Model{ID: 2}, ArrID: 2, Title: "Sequel", MediaType: database.MediaTypeMovie, LibraryName: "Movies", CollectionID: 100}
+	standalone := database.Media{Model: gorm.Model{ID: 3}, ArrID: 3, Title: "Standalone", MediaType: database.MediaTypeMovie, LibraryName: "Movies"}
+	db := &fakeDB{
+		media: []database.Media{
+			{Model: gorm.Model{ID: 1}, ArrID: 1, Title: "Original", MediaType: database.MediaTypeMovie, CollectionID: 100, ProtectedUntil: &future},
+			sequel,
+			standalone,
+		},
+		failures: []database.DeletionFailure{
+			{MediaID: sequel.ID, Media: sequel},
+			{MediaID: standalone.ID, Media: standalone},
+		},
+	}
+	e := &Engine{
+		cfg:      &config.Config{TreatCollectionsAtomically: true},
+		db:       db,
+		radarr:   &fakeArr{mediaType: models.MediaTypeMovie},
+		jellyfin: &fakeMediaServer{},
+		data:     &data{libraryItemCounts: map[string]int{"Movies": 3}},
+	}
+
+	require.NoError(t, e.retryFailedDeletions(context.Background()))
+	require.Len(t, db.deleted, 1)
+	assert.Equal(t, "Standalone", db.deleted[0].Title, "the retry spares movies of a protected collection")
+}
+
+func TestDeleteItemDownloadInProgress(t *testing.T) {
+	db := &fakeDB{}
+	e := &Engine{
+		cfg:      &config.Config{SkipIfDownloading: true},
+		db:       db,
+		radarr:   &fakeArr{mediaType: models.MediaTypeMovie, downloading: map[int32]bool{1: true}},
+		jellyfin: &fakeMediaServer{},
+		data:     &data{},
+	}
+	upgrading := database.Media{Model: gorm.Model{ID: 1}, ArrID: 1, Title: "Upgrading", MediaType: database.MediaTypeMovie}
+	idle := database.Media{Model: gorm.Model{ID: 2}, ArrID: 2, Title: "Idle", MediaType: database.MediaTypeMovie}
+
+	deletedItems := make(map[string][]arr.MediaItem)
+	assert.False(t, e.deleteItem(context.Background(), upgrading, deletedItems, database.HistoryEventDeleted), "a download started after the item was marked")
+	assert.True(t, e.deleteItem(context.Background(), idle, deletedItems, database.HistoryEventDeleted))
+	require.Len(t, db.deleted, 1)
+	assert.Equal(t, "Idle", db.deleted[0].Title)
+
+	e.cfg.SkipIfDownloading = false
+	assert.True(t, e.deleteItem(context.Background(), upgrading, deletedItems, database.HistoryEventDeleted))
+}
+
+func TestStartCleanupRunLogger(t *testing.T) {
+	var buf bytes.Buffer
+	defaultLogger := log.Default()
+	e := &Engine{cfg: &config.Config{}, db: &fakeDB{}, data: &data{}}
+
+	ctx := e.startCleanupRun(log.WithContext(context.Background(), log.New(&buf)))
+	log.FromContext(ctx).Info("gathering media")
+	assert.Contains(t, buf.String(), "runID=7", "the log lines of the run carry the run ID")
+	assert.Same(t, defaultLogger, log.Default(), "the global logger isn't replaced")
+}
+
+func TestChunkEmailItems(t *testing.T) {
+	items := make([]email.MediaItem, 5)
+
+	assert.Len(t, chunkEmailItems(items, 0), 1, "all items in one email without a limit")
+	assert.Len(t, chunkEmailItems(items, 5), 1)
+
+	chunks := chunkEmailItems(items, 2)
+	require.Len(t, chunks, 3)
+	assert.Len(t, chunks[2], 1)
+}
+
+func TestAdminSummaryNotification(t *testing.T) {
+	e := &Engine{
+		cfg: &config.Config{Email: &config.EmailConfig{FromEmail: "jellysweep@example.com", BatchAboveTotal: 2}},
+		data: &data{userNotifications: map[string][]arr.MediaItem{
+			"bob@example.com":   {{Title: "Arrival", RequestedBy: "bob@example.com"}},
+			"alice@example.com": {{Title: "Dune", RequestedBy: "alice@example.com"}, {Title: "Tenet", RequestedBy: "alice@example.com"}},
+			"carol@example.com": {},
+		}},
+	}
+
+	assert.Equal(t, 3, e.userNotificationCount())
+
+	notification := e.adminSummaryNotification()
+	assert.Equal(t, "jellysweep@example.com", notification.AdminEmail, "falls back to the from email")
+	assert.Equal(t, []email.RequesterSummary{{UserEmail: "alice@example.com", Items: 2}, {UserEmail: "bob@example.com", Items: 1}}, notification.Requesters)
+	require.Len(t, notification.MediaItems, 3)
+	assert.Equal(t, "Arrival", notification.MediaItems[2].Title)
+}
+
+func TestReloadClients(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	writeConfig := func(apiKey string) {
+		t.Helper()
+		content := fmt.Sprintf(`session_key: secret
+libraries:
+  Movies:
+    enabled: true
+jellyfin:
+  url: http://jellyfin:8096
+  api_key: %s
+radarr:
+  url: http://radarr:7878
+  api_key: radarr-key
+jellystat:
+  url: http://jellystat:3000
+  api_key: jellystat-key
+`, apiKey)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	writeConfig("old-key")
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+	engineCache, err := cache.NewEngineCache(cfg.Cache)
+	require.NoError(t, err)
+	e := &Engine{cfg: cfg, db: &fakeDB{}, cache: engineCache}
+
+	writeConfig("new-key")
+	require.NoError(t, e.ReloadClients(path))
+	assert.Equal(t, "new-key", cfg.Jellyfin.APIKey, "the shared config is updated in place")
+	assert.NotNil(t, e.jellyfin)
+	assert.NotNil(t, e.filters)
+
+	// an invalid config keeps the current clients
+	jellyfinClient := e.jellyfin
+	require.NoError(t, os.WriteFile(path, []byte("cleanup_schedule: invalid\n"), 0o600))
+	require.Error(t, e.ReloadClients(path))
+	assert.Equal(t, "new-key", cfg.Jellyfin.APIKey)
+	assert.Same(t, jellyfinClient, e.jellyfin)
+}
+
+func TestKeepCollectionsTogether(t *testing.T) {
+	future := time.Now().Add(24 * time.Hour)
+	movie := func(id int32, title string, collectionID int32) arr.MediaItem {
+		resource := radarrAPI.MovieResource{}
+		resource.SetId(id)
+		if collectionID != 0 {
+			collection := radarrAPI.NewMovieCollectionResource()
+			collection.SetTmdbId(collectionID)
+			resource.SetCollection(*collection)
+		}
+		return arr.MediaItem{Title: title, MediaType: models.MediaTypeMovie, MovieResource: resource}
+	}
+
+	gathered := []arr.MediaItem{
+		// every movie of the first trilogy is eligible, one of them was already marked by a previous run
+		movie(1, "Trilogy 1", 100), movie(2, "Trilogy 2", 100), movie(3, "Trilogy 3", 100),
+		// a movie of the second trilogy was watched recently
+		movie(4, "Other 1", 200), movie(5, "Other 2", 200),
+		// a movie of the third trilogy is protected
+		movie(6, "Protected 1", 300), movie(7, "Protected 2", 300),
+		movie(8, "Standalone", 0),
+	}
+	marked := []arr.MediaItem{gathered[0], gathered[1], gathered[3], gathered[6], gathered[7]}
+
+	e := &Engine{
+		cfg: &config.Config{TreatCollectionsAtomically: true},
+		db: &fakeDB{media: []database.Media{
+			{ArrID: 3, Title: "Trilogy 3", MediaType: database.MediaTypeMovie, CollectionID: 100},
+			{ArrID: 6, Title: "Protected 1", MediaType: database.MediaTypeMovie, CollectionID: 300, ProtectedUntil: &future},
+		}},
+	}
+
+	result, err := e.keepCollectionsTogether(context.Background(), gathered, nil, marked)
+	require.NoError(t, err)
+	titles := make([]string, 0, len(result))
+	for _, item := range result {
+		titles = append(titles, item.Title)
+	}
+	assert.Equal(t, []string{"Trilogy 1", "Trilogy 2", "Standalone"}, titles)
+
+	protected, err := e.protectedCollections(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[int32]bool{300: true}, protected)
+}
+
+func TestMaintenanceMode(t *testing.T) {
+	ctx := context.Background()
+	db := &fakeDB{media: []database.Media{
+		{Model: gorm.Model{ID: 1}, Title: "Expired", DefaultDeleteAt: time.Now().Add(-time.Hour)},
+	}}
+	e := &Engine{cfg: &config.Config{}, db: db}
+
+	var runs int
+	job := e.skipInMaintenance("cleanup", func(context.Context) error {
+		runs++
+		return nil
+	})
+
+	require.NoError(t, e.SetMaintenanceMode(ctx, true))
+	assert.True(t, db.maintenance, "the maintenance mode is persisted")
+	assert.True(t, e.MaintenanceMode())
+
+	_, err := e.RequestKeepMedia(ctx, 1, 1, "user", nil)
+	assert.ErrorIs(t, err, ErrMaintenanceMode)
+	assert.ErrorIs(t, e.HandleKeepRequest(ctx, 1, 1, true, nil), ErrMaintenanceMode)
+	_, err = e.HandleKeepRequests(ctx, 1, []uint{1}, true)
+	assert.ErrorIs(t, err, ErrMaintenanceMode)
+	_, err = e.ForceDeleteExpired(ctx, false)
+	assert.ErrorIs(t, err, ErrMaintenanceMode)
+	assert.ErrorIs(t, e.RunJobNow("cleanup"), ErrMaintenanceMode)
+	assert.ErrorIs(t, e.SetMediaIgnored(ctx, 1, true), ErrMaintenanceMode)
+	assert.ErrorIs(t, e.MarkMediaAsProtected(ctx, 1, 1), ErrMaintenanceMode)
+	assert.ErrorIs(t, e.MarkMediaAsUnkeepable(ctx, 1, 1), ErrMaintenanceMode)
+	assert.ErrorIs(t, e.MarkMediaAsKeepForever(ctx, 1, 1), ErrMaintenanceMode)
+	_, err = e.ReevaluateItem(ctx, "jf-1")
+	assert.ErrorIs(t, err, ErrMaintenanceMode)
+	_, err = e.CleanOrphanedTags(ctx)
+	assert.ErrorIs(t, err, ErrMaintenanceMode)
+	assert.Empty(t, db.deleted, "nothing is written during the maintenance")
+
+	result, err := e.ForceDeleteExpired(ctx, true)
+	require.NoError(t, err, "a dry run is allowed")
+	assert.Len(t, result.Items, 1)
+
+	require.NoError(t, job(ctx))
+	assert.Zero(t, runs, "scheduled jobs are skipped")
+
+	require.NoError(t, e.SetMaintenanceMode(ctx, false))
+	assert.False(t, db.maintenance)
+	require.NoError(t, job(ctx))
+	assert.Equal(t, 1, runs)
+}
+
+// newRecordingWebhook returns a webhook notifier that records the type of every received event.
+func newRecordingWebhook(t *testing.T) (*webhook.Client, *config.WebhookConfig, func() []map[string]any) {
+	t.Helper()
+	var (
+		mu     sync.Mutex
+		events []map[string]any
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.WebhookConfig{URL: server.URL}
+	client, err := webhook.NewClient(cfg)
+	require.NoError(t, err)
+	return client, cfg, func() []map[string]any {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(events)
+	}
+}
+
+func TestRetryFailedDeletions(t *testing.T) {
+	movie := database.Media{Model: gorm.Model{ID: 1}, ArrID: 1, Title: "Retried", MediaType: database.MediaTypeMovie, LibraryName: "Movies"}
+	future := time.Now().Add(24 * time.Hour)
+	protected := database.Media{Model: gorm.Model{ID: 2}, ArrID: 2, Title: "Protected", MediaType: database.MediaTypeMovie, LibraryName: "Movies", ProtectedUntil: &future}
+	db := &fakeDB{
+		failures: []database.DeletionFailure{
+			{MediaID: movie.ID, Media: movie, Attempts: 2},
+			{MediaID: protected.ID, Media: protected, Attempts: 1},
+			{MediaID: 9, Attempts: 1}, // the media item was removed in the meantime
+		},
+	}
+	hook, hookCfg, events := newRecordingWebhook(t)
+	e := &Engine{
+		cfg:      &config.Config{DeletionRetry: &config.DeletionRetryConfig{MaxAttempts: 5}, Webhook: hookCfg},
+		db:       db,
+		radarr:   &fakeArr{mediaType: models.MediaTypeMovie},
+		jellyfin: &fakeMediaServer{},
+		webhook:  hook,
+		data:     &data{libraryItemCounts: map[string]int{"Movies": 10}},
+	}
+
+	require.NoError(t, e.retryFailedDeletions(context.Background()))
+	require.Len(t, db.deleted, 1)
+	assert.Equal(t, "Retried", db.deleted[0].Title)
+	assert.ElementsMatch(t, []uint{9, movie.ID}, db.clearedFailures, "the failure of the deleted and of the removed item are cleared")
+	assert.Empty(t, db.savedFailures)
+
+	require.Len(t, events(), 1)
+	assert.Equal(t, "deletion_completed", events()[0]["type"])
+}
+
+func TestRetryFailedDeletionsDryRun(t *testing.T) {
+	movie := database.Media{Model: gorm.Model{ID: 1}, ArrID: 1, Title: "Retried", MediaType: database.MediaTypeMovie}
+	db := &fakeDB{failures: []database.DeletionFailure{{MediaID: movie.ID, Media: movie}}}
+	e := &Engine{cfg: &config.Config{DryRun: true}, db: db, radarr: &fakeArr{mediaType: models.MediaTypeMovie}, data: &data{}}
+
+	require.NoError(t, e.retryFailedDeletions(context.Background()))
+	assert.Empty(t, db.deleted)
+}
+
+func TestRetryFailedDeletionsGivesUp(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		attempts   int
+		wantGaveUp bool
+	}{
+		{name: "retried again", attempts: 1},
+		{name: "last attempt", attempts: 2, wantGaveUp: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			movie := database.Media{Model: gorm.Model{ID: 1}, ArrID: 1, Title: "Stuck", Year: 2001, MediaType: database.MediaTypeMovie, LibraryName: "Movies"}
+			db := &fakeDB{failures: []database.DeletionFailure{{MediaID: movie.ID, Media: movie, Attempts: tt.attempts}}}
+			hook, hookCfg, events := newRecordingWebhook(t)
+			e := &Engine{
+				cfg: &config.Config{
+					DeletionRetry: &config.DeletionRetryConfig{MaxAttempts: 3, InitialDelay: 10, MaxDelay: 60},
+					Webhook:       hookCfg,
+				},
+				db:       db,
+				radarr:   &fakeArr{mediaType: models.MediaTypeMovie, deleteErr: errors.New("radarr unavailable")},
+				jellyfin: &fakeMediaServer{},
+				webhook:  hook,
+				data:     &data{libraryItemCounts: map[string]int{"Movies": 10}},
+			}
+
+			before := time.Now()
+			require.NoError(t, e.retryFailedDeletions(context.Background()))
+			assert.Empty(t, db.deleted)
+			require.Len(t, db.savedFailures, 1)
+			failure := db.savedFailures[0]
+			assert.Equal(t, tt.attempts+1, failure.Attempts)
+			assert.Equal(t, tt.wantGaveUp, failure.GaveUp)
+			assert.Contains(t, failure.Error, "radarr unavailable")
+			assert.WithinDuration(t, before.Add(e.retryDelay(failure.Attempts)), failure.NextRetryAt, time.Minute)
+
+			if !tt.wantGaveUp {
+				assert.Empty(t, events(), "the admins are only notified once the deletion is given up")
+				return
+			}
+			require.Len(t, events(), 1)
+			event := events()[0]
+			assert.Equal(t, "deletion_failed", event["type"])
+			assert.Equal(t, float64(3), event["attempts"])
+			assert.Equal(t, "Stuck", event["media"].(map[string]any)["title"])
+		})
+	}
+}
+
+func TestResetDeletionFailure(t *testing.T) {
+	db := &fakeDB{failures: []database.DeletionFailure{{MediaID: 1, Attempts: 3, GaveUp: true}}}
+	e := &Engine{cfg: &config.Config{}, db: db}
+
+	assert.ErrorIs(t, e.ResetDeletionFailure(context.Background(), 2), ErrNoDeletionFailure)
+	assert.Empty(t, db.clearedFailures)
+
+	e.maintenance.Store(true)
+	assert.ErrorIs(t, e.ResetDeletionFailure(context.Background(), 1), ErrMaintenanceMode)
+	assert.Empty(t, db.clearedFailures)
+
+	e.maintenance.Store(false)
+	require.NoError(t, e.ResetDeletionFailure(context.Background(), 1))
+	assert.Equal(t, []uint{1}, db.clearedFailures)
+}
+
+func TestReevaluateItemNewlyAdded(t *testing.T) {
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"Movies": {Enabled: true, Filter: config.FilterConfig{NewlyAddedGraceDays: 7}},
+		},
+	}
+	movie := database.Media{
+		Model:          gorm.Model{ID: 1},
+		JellyfinID:     "jf-1",
+		ArrID:          1,
+		Title:          "Fresh",
+		MediaType:      database.MediaTypeMovie,
+		LibraryName:    "Movies",
+		RuntimeMinutes: 95,
+		ArrAddedAt:     time.Now().Add(-48 * time.Hour),
+	}
+	db := &fakeDB{media: []database.Media{movie}}
+	e := &Engine{cfg: cfg, db: db, ageFilter: agefilter.New(cfg, db, nil, nil, nil)}
+
+	item := dbMediaToArrMediaItem(movie)
+	assert.Equal(t, movie.ArrAddedAt, filter.ArrAddedDate(item))
+	assert.Equal(t, 95, filter.ItemRuntime(item))
+
+	removed, err := e.ReevaluateItem(context.Background(), "jf-1")
+	require.NoError(t, err)
+	assert.True(t, removed, "the item is protected by the grace period again")
+	require.Len(t, db.deleted, 1)
+	assert.Equal(t, database.DBDeleteReasonReevaluated, db.deleted[0].DBDeleteReason)
+}
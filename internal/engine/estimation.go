@@ -0,0 +1,56 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/database"
+)
+
+// estimateDeletions stores the projected deletion date of every media item marked for deletion.
+// Estimates of items that are no longer marked are removed.
+func (e *Engine) estimateDeletions(ctx context.Context) error {
+	mediaItems, err := e.db.GetMediaItems(ctx, true)
+	if err != nil {
+		return fmt.Errorf("failed to get media items: %w", err)
+	}
+
+	estimates := make([]database.DeletionEstimate, 0, len(mediaItems))
+	for _, item := range mediaItems {
+		if item.Ignored {
+			continue
+		}
+		estimates = append(estimates, database.DeletionEstimate{
+			MediaID:           item.ID,
+			LibraryName:       item.LibraryName,
+			Title:             item.Title,
+			Year:              item.Year,
+			MediaType:         item.MediaType,
+			FileSize:          item.FileSize,
+			EstimatedDeleteAt: estimatedDeleteAt(item),
+		})
+	}
+
+	if err := e.db.UpsertDeletionEstimates(ctx, estimates); err != nil {
+		return fmt.Errorf("failed to store deletion estimates: %w", err)
+	}
+	log.FromContext(ctx).Debug("Updated deletion estimates", "count", len(estimates))
+	return nil
+}
+
+// estimatedDeleteAt returns the projected deletion date of a media item.
+// Protected items aren't deleted before their protection expires.
+func estimatedDeleteAt(item database.Media) time.Time {
+	deleteAt := item.DefaultDeleteAt
+	if item.ProtectedUntil != nil && item.ProtectedUntil.After(deleteAt) {
+		deleteAt = *item.ProtectedUntil
+	}
+	return deleteAt
+}
+
+// GetDeletionEstimates returns the paginated upcoming deletions, soonest first.
+func (e *Engine) GetDeletionEstimates(ctx context.Context, limit, offset int, since time.Time) ([]database.DeletionEstimate, int64, error) {
+	return e.db.GetDeletionEstimates(ctx, limit, offset, since)
+}
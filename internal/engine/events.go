@@ -47,10 +47,11 @@ func (e *Engine) CreateProtectionExpiredEvent(ctx context.Context, media *databa
 }
 
 // CreateDeletedEvent creates a history event when a media item is deleted.
-func (e *Engine) CreateDeletedEvent(ctx context.Context, media *database.Media) error {
+// eventType distinguishes regular deletions from forced ones.
+func (e *Engine) CreateDeletedEvent(ctx context.Context, media *database.Media, eventType database.HistoryEventType) error {
 	event := database.HistoryEvent{
 		MediaID:   media.ID,
-		EventType: database.HistoryEventDeleted,
+		EventType: eventType,
 	}
 
 	return e.db.CreateHistoryEvent(ctx, event)
@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+)
+
+// deletionDatePassed reports whether the default deletion date or any disk usage deletion date of the item has passed.
+// The current disk usage is not checked.
+func deletionDatePassed(item database.Media, now time.Time) bool {
+	if !item.DefaultDeleteAt.IsZero() && now.After(item.DefaultDeleteAt) {
+		return true
+	}
+	for _, policy := range item.DiskUsageDeletePolicies {
+		if !policy.DeleteDate.IsZero() && now.After(policy.DeleteDate) {
+			return true
+		}
+	}
+	return false
+}
+
+// ForceDeleteResult is the result of a forced deletion of the expired media items.
+type ForceDeleteResult struct {
+	// Items are the deleted items, or the items that would be deleted in a dry run.
+	Items []database.Media
+	// DryRun is set if nothing was deleted, either because a dry run was requested or the dry-run mode is enabled.
+	DryRun bool
+}
+
+// ForceDeleteExpired deletes every media item whose default or disk usage deletion date has passed,
+// bypassing the disk usage check of the deletion policies. Protected items, items of protected collections
+// and items with a failed deletion, which are handled by the retry job, are never deleted, and libraries
+// aren't emptied below the configured minimum item count.
+// With dryRun nothing is deleted and the items that would be deleted are returned.
+// Apart from a dry run, it's rejected with ErrMaintenanceMode while the maintenance mode is enabled
+// and with ErrDeletionInProgress while the cleanup deletes media.
+func (e *Engine) ForceDeleteExpired(ctx context.Context, dryRun bool) (*ForceDeleteResult, error) {
+	e.clientsMu.RLock()
+	defer e.clientsMu.RUnlock()
+
+	dryRun = dryRun || e.cfg.DryRun
+	if !dryRun {
+		if e.MaintenanceMode() {
+			return nil, ErrMaintenanceMode
+		}
+		if !e.deleteMu.TryLock() {
+			return nil, ErrDeletionInProgress
+		}
+		defer e.deleteMu.Unlock()
+	}
+
+	mediaItems, err := e.db.GetMediaItems(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media items: %w", err)
+	}
+
+	failedItems, err := e.getDeletionFailureIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deletion failures: %w", err)
+	}
+
+	protectedCollections, err := e.protectedCollections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get protected collections: %w", err)
+	}
+
+	remaining, err := e.forceDeleteItemCounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get library item counts: %w", err)
+	}
+
+	now := time.Now()
+	deletedItems := make(map[string][]arr.MediaItem)
+	result := &ForceDeleteResult{Items: make([]database.Media, 0), DryRun: dryRun}
+	for _, item := range mediaItems {
+		if !deletionDatePassed(item, now) {
+			continue
+		}
+		if item.ProtectedUntil != nil && item.ProtectedUntil.After(now) {
+			log.FromContext(ctx).Debug("skipping forced deletion of protected media item", "title", item.Title)
+			continue
+		}
+		if failedItems[item.ID] {
+			log.FromContext(ctx).Debug("skipping forced deletion of media item with failed deletion, handled by retry job", "title", item.Title)
+			continue
+		}
+		if protectedCollections[item.CollectionID] {
+			log.FromContext(ctx).Info("skipping forced deletion of media item, another movie of its collection is protected", "title", item.Title)
+			continue
+		}
+		if e.libraryFloorReached(remaining, item.LibraryName) {
+			log.FromContext(ctx).Warn("sparing media item, library would drop below the minimum item count", "title", item.Title, "library", item.LibraryName, "minItems", e.cfg.MinItemsPerLibrary)
+			continue
+		}
+
+		if dryRun {
+			log.FromContext(ctx).Info("[Dry Run] Would force delete expired media item", "title", item.Title, "library", item.LibraryName)
+			result.Items = append(result.Items, item)
+			remaining[item.LibraryName]--
+			continue
+		}
+
+		if !e.deleteItem(ctx, item, deletedItems, database.HistoryEventForceDeleted) {
+			continue
+		}
+		log.FromContext(ctx).Info("force deleted expired media item", "title", item.Title, "library", item.LibraryName)
+		result.Items = append(result.Items, item)
+		remaining[item.LibraryName]--
+	}
+
+	e.sendDeletionCompletedNotifications(ctx, deletedItems)
+
+	return result, nil
+}
+
+// forceDeleteItemCounts returns the number of items per library the minimum item count of a forced deletion is checked against.
+// The counts of the last cleanup run are used. If there was none yet, e.g. when run from the CLI, the items are gathered first.
+func (e *Engine) forceDeleteItemCounts(ctx context.Context) (map[string]int, error) {
+	if e.cfg.MinItemsPerLibrary <= 0 {
+		return make(map[string]int), nil
+	}
+	if e.data.libraryItemCounts != nil {
+		return maps.Clone(e.data.libraryItemCounts), nil
+	}
+	gathered, err := e.collectMediaItems(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return gathered.libraryItemCounts, nil
+}
@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/cache"
+	"github.com/jon4hz/jellysweep/internal/config"
+)
+
+// Pinger is implemented by clients whose reachability can be checked with a cheap request.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// DependencyStatus is the result of checking a single dependency.
+type DependencyStatus struct {
+	Name string `json:"name"`
+	// Mandatory dependencies must be reachable for jellysweep to be ready.
+	Mandatory bool   `json:"mandatory"`
+	Reachable bool   `json:"reachable"`
+	Error     string `json:"error,omitempty"`
+}
+
+// dependency is a configured service checked by CheckReadiness.
+type dependency struct {
+	name      string
+	mandatory bool
+	ping      func(ctx context.Context) error
+}
+
+// dependencies returns the configured services that can be checked.
+func (e *Engine) dependencies() []dependency {
+	var deps []dependency
+	add := func(name string, mandatory bool, client any) {
+		if p, ok := client.(Pinger); ok {
+			deps = append(deps, dependency{name: name, mandatory: mandatory, ping: p.Ping})
+		}
+	}
+
+	// unconfigured arrs are nil and never implement Pinger
+	add("media_server", true, e.jellyfin)
+	add("stats", true, e.stats)
+	add("sonarr", true, e.sonarr)
+	add("radarr", true, e.radarr)
+	add("readarr", true, e.readarr)
+	// Jellyseerr only enriches the media with requester information, so it's not required to run cleanups.
+	if e.jellyseerr != nil {
+		deps = append(deps, dependency{name: "jellyseerr", ping: e.jellyseerr.GetStatus})
+	}
+	if e.cfg.Cache != nil && e.cfg.Cache.Type == config.CacheTypeRedis {
+		deps = append(deps, dependency{name: "redis", mandatory: true, ping: func(ctx context.Context) error {
+			return cache.PingRedis(ctx, e.cfg.Cache)
+		}})
+	}
+	return deps
+}
+
+// CheckReadiness pings all configured dependencies in parallel, each with the configured readiness timeout.
+// It reports whether all mandatory dependencies are reachable and the status of every dependency.
+func (e *Engine) CheckReadiness(ctx context.Context) (bool, []DependencyStatus) {
+	e.clientsMu.RLock()
+	deps := e.dependencies()
+	e.clientsMu.RUnlock()
+	timeout := time.Duration(e.cfg.ReadinessTimeout) * time.Second
+
+	statuses := make([]DependencyStatus, len(deps))
+	var wg sync.WaitGroup
+	for i, dep := range deps {
+		wg.Go(func() {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			status := DependencyStatus{Name: dep.name, Mandatory: dep.mandatory, Reachable: true}
+			if err := dep.ping(ctx); err != nil {
+				log.FromContext(ctx).Warn("Dependency is not reachable", "dependency", dep.name, "error", err)
+				status.Reachable = false
+				status.Error = err.Error()
+			}
+			statuses[i] = status
+		})
+	}
+	wg.Wait()
+
+	return readyFromStatuses(statuses), statuses
+}
+
+// readyFromStatuses reports whether all mandatory dependencies are reachable.
+func readyFromStatuses(statuses []DependencyStatus) bool {
+	for _, status := range statuses {
+		if status.Mandatory && !status.Reachable {
+			return false
+		}
+	}
+	return true
+}
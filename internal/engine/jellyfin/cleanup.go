@@ -12,10 +12,10 @@ import (
 
 // RemoveItemWithCleanupMode removes an item from Jellyfin according to the cleanup mode.
 // For movies or "all" mode, it removes the entire item.
-// For TV series with "keep_episodes" or "keep_seasons" mode, it removes specific episodes/seasons.
+// For TV series with "keep_episodes", "keep_seasons" or "keep_latest_episodes" mode, it removes specific episodes/seasons.
 func (c *Client) RemoveItemWithCleanupMode(ctx context.Context, itemID, title string, itemType jellyfin.BaseItemKind, cleanupMode config.CleanupMode, keepCount int) error {
-	// For movies or "all" mode, remove the entire item
-	if itemType == jellyfin.BASEITEMKIND_MOVIE {
+	// For movies and books, remove the entire item
+	if itemType == jellyfin.BASEITEMKIND_MOVIE || itemType == jellyfin.BASEITEMKIND_BOOK {
 		if err := c.RemoveItem(ctx, itemID); err != nil {
 			log.Error("failed to remove jellyfin item", "jellyfinID", itemID, "error", err)
 			return err
@@ -41,7 +41,7 @@ func (c *Client) RemoveItemWithCleanupMode(ctx context.Context, itemID, title st
 		}
 		deletionDescription = "entire series"
 
-	case config.CleanupModeKeepEpisodes, config.CleanupModeKeepSeasons:
+	case config.CleanupModeKeepEpisodes, config.CleanupModeKeepSeasons, config.CleanupModeKeepLatestEpisodes:
 		// Get all episodes for the series
 		allEpisodes, seasonsWithoutEpisodes, err := c.GetEpisodes(ctx, itemID)
 		if err != nil {
@@ -50,7 +50,7 @@ func (c *Client) RemoveItemWithCleanupMode(ctx context.Context, itemID, title st
 		}
 
 		// Get episodes to determine what to delete
-		episodesToKeep := c.filterEpisodesToKeep(allEpisodes, title, cleanupMode, keepCount)
+		episodesToKeep := FilterEpisodesToKeep(allEpisodes, title, cleanupMode, keepCount)
 
 		// Group episodes by season ID to track which seasons will be empty after deletion
 		episodesBySeason := make(map[string][]jellyfin.BaseItemDto)
@@ -80,9 +80,12 @@ func (c *Client) RemoveItemWithCleanupMode(ctx context.Context, itemID, title st
 			// After deleting episodes, check which seasons are now empty and delete them
 			c.deleteEmptySeasons(ctx, title, episodesBySeason, episodesToDelete)
 
-			if cleanupMode == config.CleanupModeKeepEpisodes {
+			switch cleanupMode { //nolint: exhaustive
+			case config.CleanupModeKeepEpisodes:
 				deletionDescription = fmt.Sprintf("all but first %d episodes from Jellyfin", keepCount)
-			} else {
+			case config.CleanupModeKeepLatestEpisodes:
+				deletionDescription = fmt.Sprintf("all but latest %d episodes from Jellyfin", keepCount)
+			default:
 				deletionDescription = fmt.Sprintf("all but first %d seasons from Jellyfin", keepCount)
 			}
 		} else {
@@ -118,8 +121,8 @@ func (c *Client) RemoveItemWithCleanupMode(ctx context.Context, itemID, title st
 	return nil
 }
 
-// filterEpisodesToKeep determines which episodes to keep based on cleanup mode.
-func (c *Client) filterEpisodesToKeep(episodes []jellyfin.BaseItemDto, title string, cleanupMode config.CleanupMode, keepCount int) []string {
+// FilterEpisodesToKeep determines which episodes to keep based on cleanup mode.
+func FilterEpisodesToKeep(episodes []jellyfin.BaseItemDto, title string, cleanupMode config.CleanupMode, keepCount int) []string {
 	if cleanupMode == config.CleanupModeAll {
 		// For "all" mode, we delete the entire series (no episodes to keep)
 		return []string{}
@@ -154,6 +157,30 @@ func (c *Client) filterEpisodesToKeep(episodes []jellyfin.BaseItemDto, title str
 			}
 		}
 
+	case config.CleanupModeKeepLatestEpisodes:
+		// Keep the latest N episodes (by season and episode number)
+		// Note: Specials are already excluded by GetEpisodes()
+
+		// Sort episodes by season number descending, then by episode number descending
+		slices.SortFunc(episodes, func(a, b jellyfin.BaseItemDto) int {
+			if a.GetParentIndexNumber() != b.GetParentIndexNumber() {
+				return int(b.GetParentIndexNumber() - a.GetParentIndexNumber())
+			}
+			return int(b.GetIndexNumber() - a.GetIndexNumber())
+		})
+
+		// Keep the latest keepCount episodes
+		keptEpisodes := 0
+		for _, episode := range episodes {
+			if keptEpisodes >= keepCount {
+				break
+			}
+			if episode.Id != nil {
+				episodesToKeep = append(episodesToKeep, episode.GetId())
+				keptEpisodes++
+			}
+		}
+
 	case config.CleanupModeKeepSeasons:
 		// Keep the first N lowest-numbered seasons (typically the earliest seasons)
 		// Note: Specials are already excluded by GetEpisodes()
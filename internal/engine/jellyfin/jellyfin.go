@@ -3,16 +3,20 @@ package jellyfin
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"slices"
+	"time"
 
 	"github.com/ccoveille/go-safecast"
 	"github.com/charmbracelet/log"
 	"github.com/jon4hz/jellysweep/internal/config"
 	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/engine/mediaserver"
 	"github.com/jon4hz/jellysweep/internal/version"
 	jellyfin "github.com/sj14/jellyfin-go/api"
 )
 
+var _ mediaserver.MediaServer = (*Client)(nil)
+
 // Client provides a high-level interface for interacting with Jellyfin.
 type Client struct {
 	jellyfin *jellyfin.APIClient
@@ -22,13 +26,13 @@ type Client struct {
 // New creates a new Jellyfin client with the given configuration and cache.
 func New(cfg *config.Config) *Client {
 	return &Client{
-		jellyfin: newJellyfinClient(cfg.Jellyfin),
+		jellyfin: newJellyfinClient(cfg.Jellyfin, cfg.Proxy),
 		cfg:      cfg,
 	}
 }
 
 // newJellyfinClient creates a new low-level Jellyfin API client.
-func newJellyfinClient(cfg *config.JellyfinConfig) *jellyfin.APIClient {
+func newJellyfinClient(cfg *config.JellyfinConfig, proxy *config.ProxyConfig) *jellyfin.APIClient {
 	clientConfig := jellyfin.NewConfiguration()
 	clientConfig.Servers = jellyfin.ServerConfigurations{
 		{
@@ -38,7 +42,7 @@ func newJellyfinClient(cfg *config.JellyfinConfig) *jellyfin.APIClient {
 	}
 	clientConfig.DefaultHeader = map[string]string{"Authorization": fmt.Sprintf(`MediaBrowser Token="%s"`, cfg.APIKey)}
 	clientConfig.UserAgent = fmt.Sprintf("Jellysweep/%s", version.Version)
-	clientConfig.HTTPClient = &http.Client{Timeout: config.TimeoutDuration(cfg.Timeout)}
+	clientConfig.HTTPClient = config.NewHTTPClient(cfg.Timeout, proxy, cfg.Proxy)
 	return jellyfin.NewAPIClient(clientConfig)
 }
 
@@ -76,6 +80,10 @@ func (c *Client) GetLibraryFoldersMap(ctx context.Context) (map[string][]string,
 	for _, folder := range virtualFolders {
 		log.Debug("Found virtual folder", "name", folder.GetName())
 		libraryName := folder.GetName()
+		if !c.cfg.Jellyfin.IsLibraryScanned(libraryName) {
+			log.Debug("Skipping virtual folder for excluded library", "library", libraryName)
+			continue
+		}
 		libraryConfig := c.cfg.GetLibraryConfig(libraryName)
 		if libraryConfig == nil || !libraryConfig.Enabled {
 			log.Debug("Skipping virtual folder for disabled library", "library", libraryName)
@@ -113,6 +121,11 @@ func (c *Client) fetchJellyfinItems(ctx context.Context) ([]arr.JellyfinItem, er
 		libraryName := folder.GetName()
 		libraryID := folder.GetId()
 
+		if !c.cfg.Jellyfin.IsLibraryScanned(libraryName) {
+			log.Debug("Skipping excluded library", "library", libraryName)
+			continue
+		}
+
 		// Check if this library is enabled in the configuration
 		libraryConfig := c.cfg.GetLibraryConfig(libraryName)
 		if libraryConfig == nil || !libraryConfig.Enabled {
@@ -170,6 +183,8 @@ func (c *Client) getJellyfinItemsFromLibrary(ctx context.Context, libraryID, lib
 			IncludeItemTypes([]jellyfin.BaseItemKind{
 				jellyfin.BASEITEMKIND_MOVIE,
 				jellyfin.BASEITEMKIND_SERIES,
+				jellyfin.BASEITEMKIND_BOOK,
+				jellyfin.BASEITEMKIND_AUDIO_BOOK,
 			}).
 			Execute()
 		if err != nil {
@@ -470,3 +485,202 @@ func (c *Client) RemoveItemsFromCollection(ctx context.Context, collectionID str
 
 	return nil
 }
+
+// RemoveItemFromCollection removes a single item from the collection with the given name.
+// It does nothing if the collection doesn't exist.
+func (c *Client) RemoveItemFromCollection(ctx context.Context, collectionName, itemID string) error {
+	collectionID, err := c.FindCollectionByName(ctx, collectionName)
+	if err != nil {
+		return err
+	}
+	if collectionID == "" {
+		return nil
+	}
+	return c.RemoveItemsFromCollection(ctx, collectionID, []string{itemID})
+}
+
+// SetCollectionOverview sets the overview (description) of a collection.
+func (c *Client) SetCollectionOverview(ctx context.Context, collectionID, overview string) error {
+	collection, resp, err := c.jellyfin.UserLibraryAPI.GetItem(ctx, collectionID).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to get collection %s: %w", collectionID, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	collection.SetOverview(overview)
+	updateResp, err := c.jellyfin.ItemUpdateAPI.UpdateItem(ctx, collectionID).
+		BaseItemDto(*collection).
+		Execute()
+	if err != nil {
+		return fmt.Errorf("failed to update overview of collection %s: %w", collectionID, err)
+	}
+	defer updateResp.Body.Close() //nolint:errcheck
+
+	return nil
+}
+
+// GetCollectionMembership returns the names of the collections each of the given items belongs to.
+// All collections are fetched once and their members are matched against the requested items,
+// so the number of API calls scales with the number of collections instead of the number of items.
+func (c *Client) GetCollectionMembership(ctx context.Context, itemIDs []string) (map[string][]string, error) {
+	wanted := make(map[string]bool, len(itemIDs))
+	for _, id := range itemIDs {
+		wanted[id] = true
+	}
+
+	result, resp, err := c.jellyfin.ItemsAPI.GetItems(ctx).
+		IncludeItemTypes([]jellyfin.BaseItemKind{jellyfin.BASEITEMKIND_BOX_SET}).
+		Recursive(true).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collections: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	membership := make(map[string][]string)
+	for _, collection := range result.GetItems() {
+		members, err := c.GetCollectionItems(ctx, collection.GetId())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get items of collection %s: %w", collection.GetName(), err)
+		}
+		for id := range members {
+			if wanted[id] {
+				membership[id] = append(membership[id], collection.GetName())
+			}
+		}
+	}
+
+	return membership, nil
+}
+
+// GetFavoritedBy returns the names of the users who marked each of the given items as favorite.
+// The favorites are fetched once per user, so the number of API calls scales with the number of users instead of the number of items.
+// Disabled users are ignored.
+func (c *Client) GetFavoritedBy(ctx context.Context, itemIDs []string) (map[string][]string, error) {
+	wanted := make(map[string]bool, len(itemIDs))
+	for _, id := range itemIDs {
+		wanted[id] = true
+	}
+
+	users, resp, err := c.jellyfin.UserAPI.GetUsers(ctx).IsDisabled(false).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	favoritedBy := make(map[string][]string)
+	for _, user := range users {
+		result, itemsResp, err := c.jellyfin.ItemsAPI.GetItems(ctx).
+			UserId(user.GetId()).
+			IsFavorite(true).
+			IncludeItemTypes([]jellyfin.BaseItemKind{jellyfin.BASEITEMKIND_MOVIE, jellyfin.BASEITEMKIND_SERIES}).
+			Recursive(true).
+			Execute()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get favorites of user %s: %w", user.GetName(), err)
+		}
+		itemsResp.Body.Close() //nolint:errcheck,gosec
+
+		for _, item := range result.GetItems() {
+			if wanted[item.GetId()] {
+				favoritedBy[item.GetId()] = append(favoritedBy[item.GetId()], user.GetName())
+			}
+		}
+	}
+
+	return favoritedBy, nil
+}
+
+// GetInProgressBy returns the names of the users with a resume position in each of the given items.
+// The resumable movies and episodes are fetched once per user, episodes count for their series. Disabled users are ignored.
+func (c *Client) GetInProgressBy(ctx context.Context, itemIDs []string) (map[string][]string, error) {
+	wanted := make(map[string]bool, len(itemIDs))
+	for _, id := range itemIDs {
+		wanted[id] = true
+	}
+
+	users, resp, err := c.jellyfin.UserAPI.GetUsers(ctx).IsDisabled(false).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	inProgressBy := make(map[string][]string)
+	for _, user := range users {
+		result, itemsResp, err := c.jellyfin.ItemsAPI.GetItems(ctx).
+			UserId(user.GetId()).
+			Filters([]jellyfin.ItemFilter{jellyfin.ITEMFILTER_IS_RESUMABLE}).
+			IncludeItemTypes([]jellyfin.BaseItemKind{jellyfin.BASEITEMKIND_MOVIE, jellyfin.BASEITEMKIND_EPISODE}).
+			Recursive(true).
+			Execute()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resumable items of user %s: %w", user.GetName(), err)
+		}
+		itemsResp.Body.Close() //nolint:errcheck,gosec
+
+		for _, item := range result.GetItems() {
+			id := item.GetId()
+			if item.GetType() == jellyfin.BASEITEMKIND_EPISODE {
+				id = item.GetSeriesId()
+			}
+			if wanted[id] && !slices.Contains(inProgressBy[id], user.GetName()) {
+				inProgressBy[id] = append(inProgressBy[id], user.GetName())
+			}
+		}
+	}
+
+	return inProgressBy, nil
+}
+
+// GetLastPlayedByAnyUser returns the latest play of any user for each of the given items.
+// The played movies and episodes are fetched once per user, episodes count for their series. Disabled users are ignored.
+func (c *Client) GetLastPlayedByAnyUser(ctx context.Context, itemIDs []string) (map[string]time.Time, error) {
+	wanted := make(map[string]bool, len(itemIDs))
+	for _, id := range itemIDs {
+		wanted[id] = true
+	}
+
+	users, resp, err := c.jellyfin.UserAPI.GetUsers(ctx).IsDisabled(false).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	lastPlayed := make(map[string]time.Time)
+	for _, user := range users {
+		result, itemsResp, err := c.jellyfin.ItemsAPI.GetItems(ctx).
+			UserId(user.GetId()).
+			Filters([]jellyfin.ItemFilter{jellyfin.ITEMFILTER_IS_PLAYED}).
+			IncludeItemTypes([]jellyfin.BaseItemKind{jellyfin.BASEITEMKIND_MOVIE, jellyfin.BASEITEMKIND_EPISODE}).
+			EnableUserData(true).
+			Recursive(true).
+			Execute()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get played items of user %s: %w", user.GetName(), err)
+		}
+		itemsResp.Body.Close() //nolint:errcheck,gosec
+
+		for _, item := range result.GetItems() {
+			id := item.GetId()
+			if item.GetType() == jellyfin.BASEITEMKIND_EPISODE {
+				id = item.GetSeriesId()
+			}
+			userData := item.GetUserData()
+			if played := userData.GetLastPlayedDate(); wanted[id] && played.After(lastPlayed[id]) {
+				lastPlayed[id] = played
+			}
+		}
+	}
+
+	return lastPlayed, nil
+}
+
+// Ping checks whether Jellyfin is reachable by requesting its system info.
+func (c *Client) Ping(ctx context.Context) error {
+	_, resp, err := c.jellyfin.SystemAPI.GetSystemInfo(ctx).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to get jellyfin system info: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	return nil
+}
@@ -0,0 +1,186 @@
+package jellyfin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	jellyfin "github.com/sj14/jellyfin-go/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer serves one movie per library, named after the library.
+func newTestServer(t *testing.T, libraries []string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/Library/MediaFolders", func(w http.ResponseWriter, r *http.Request) {
+		items := make([]map[string]any, 0, len(libraries))
+		for _, name := range libraries {
+			items = append(items, map[string]any{"Id": name, "Name": name})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"Items": items, "TotalRecordCount": len(items)})
+	})
+	mux.HandleFunc("/Library/VirtualFolders", func(w http.ResponseWriter, r *http.Request) {
+		folders := make([]map[string]any, 0, len(libraries))
+		for _, name := range libraries {
+			folders = append(folders, map[string]any{"Name": name, "Locations": []string{"/media/" + name}})
+		}
+		_ = json.NewEncoder(w).Encode(folders)
+	})
+	mux.HandleFunc("/Items", func(w http.ResponseWriter, r *http.Request) {
+		library := r.URL.Query().Get("parentId")
+		items := []map[string]any{{"Id": library + "-movie", "Name": library + " Movie", "Type": "Movie"}}
+		_ = json.NewEncoder(w).Encode(map[string]any{"Items": items, "TotalRecordCount": len(items)})
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetJellyfinItemsLibraryFilter(t *testing.T) {
+	libraries := []string{"Movies", "Shows", "Home Videos", "Photos"}
+	server := newTestServer(t, libraries)
+
+	cfg := &config.Config{
+		Jellyfin: &config.JellyfinConfig{
+			URL:              server.URL,
+			APIKey:           "key",
+			IncludeLibraries: []string{"movies", "Shows", "Home Videos"},
+			ExcludeLibraries: []string{"home videos", "Photos"},
+		},
+		Libraries: map[string]*config.CleanupConfig{},
+	}
+	for _, name := range libraries {
+		cfg.Libraries[name] = &config.CleanupConfig{Enabled: true}
+	}
+
+	items, folders, err := New(cfg).GetJellyfinItems(context.Background())
+	require.NoError(t, err)
+
+	var scanned []string
+	for _, item := range items {
+		scanned = append(scanned, item.ParentLibraryName)
+	}
+	slices.Sort(scanned)
+	assert.Equal(t, []string{"Movies", "Shows"}, scanned)
+	assert.Equal(t, map[string][]string{"Movies": {"/media/Movies"}, "Shows": {"/media/Shows"}}, folders)
+}
+
+func TestIsLibraryScanned(t *testing.T) {
+	cfg := &config.JellyfinConfig{ExcludeLibraries: []string{"Photos"}}
+	assert.True(t, cfg.IsLibraryScanned("Movies"), "all libraries are scanned without an include list")
+	assert.False(t, cfg.IsLibraryScanned("photos"))
+
+	cfg.IncludeLibraries = []string{"Movies", "Photos"}
+	assert.True(t, cfg.IsLibraryScanned("movies"))
+	assert.False(t, cfg.IsLibraryScanned("Shows"))
+	assert.False(t, cfg.IsLibraryScanned("Photos"), "exclude wins over include")
+
+	assert.True(t, (*config.JellyfinConfig)(nil).IsLibraryScanned("Movies"))
+}
+
+func TestGetLastPlayedByAnyUser(t *testing.T) {
+	played := map[string][]map[string]any{
+		"alice": {
+			{"Id": "movie", "Type": "Movie", "UserData": map[string]any{"LastPlayedDate": "2026-01-10T20:00:00Z"}},
+			{"Id": "episode-1", "Type": "Episode", "SeriesId": "series", "UserData": map[string]any{"LastPlayedDate": "2026-03-01T20:00:00Z"}},
+		},
+		"bob": {
+			{"Id": "movie", "Type": "Movie", "UserData": map[string]any{"LastPlayedDate": "2026-02-10T20:00:00Z"}},
+			{"Id": "other", "Type": "Movie", "UserData": map[string]any{"LastPlayedDate": "2026-02-10T20:00:00Z"}},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/Users":
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"Id": "alice", "Name": "alice"}, {"Id": "bob", "Name": "bob"}})
+		case "/Items":
+			assert.Equal(t, "IsPlayed", r.URL.Query().Get("filters"))
+			items := played[r.URL.Query().Get("userId")]
+			_ = json.NewEncoder(w).Encode(map[string]any{"Items": items, "TotalRecordCount": len(items)})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{Jellyfin: &config.JellyfinConfig{URL: server.URL, APIKey: "key"}}
+	lastPlayed, err := New(cfg).GetLastPlayedByAnyUser(context.Background(), []string{"movie", "series"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{
+		"movie":  time.Date(2026, 2, 10, 20, 0, 0, 0, time.UTC),
+		"series": time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC),
+	}, lastPlayed, "the latest play of any user counts, episodes count for their series")
+}
+
+func TestGetCollectionMembership(t *testing.T) {
+	collections := map[string][]string{
+		"favorites": {"movie", "series"},
+		"classics":  {"movie", "other"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/Items" {
+			http.NotFound(w, r)
+			return
+		}
+		var items []map[string]any
+		if parentID := r.URL.Query().Get("parentId"); parentID != "" {
+			for _, id := range collections[parentID] {
+				items = append(items, map[string]any{"Id": id, "Type": "Movie"})
+			}
+		} else {
+			assert.Equal(t, "BoxSet", r.URL.Query().Get("includeItemTypes"))
+			items = []map[string]any{
+				{"Id": "favorites", "Name": "Favorites", "Type": "BoxSet"},
+				{"Id": "classics", "Name": "Classics", "Type": "BoxSet"},
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"Items": items, "TotalRecordCount": len(items)})
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{Jellyfin: &config.JellyfinConfig{URL: server.URL, APIKey: "key"}}
+	membership, err := New(cfg).GetCollectionMembership(context.Background(), []string{"movie", "series", "missing"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"movie":  {"Favorites", "Classics"},
+		"series": {"Favorites"},
+	}, membership, "only the requested items are returned")
+}
+
+func TestFilterEpisodesToKeepLatestEpisodes(t *testing.T) {
+	episode := func(id string, season, number int32) jellyfin.BaseItemDto {
+		ep := jellyfin.BaseItemDto{}
+		ep.SetId(id)
+		ep.SetParentIndexNumber(season)
+		ep.SetIndexNumber(number)
+		return ep
+	}
+	episodes := []jellyfin.BaseItemDto{
+		episode("s1e10", 1, 10),
+		episode("s2e1", 2, 1),
+		episode("s1e9", 1, 9),
+		episode("s2e2", 2, 2),
+	}
+
+	kept := FilterEpisodesToKeep(episodes, "Show", config.CleanupModeKeepLatestEpisodes, 3)
+	assert.Equal(t, []string{"s2e2", "s2e1", "s1e10"}, kept, "the latest episodes by season and episode number are kept")
+
+	kept = FilterEpisodesToKeep(episodes, "Show", config.CleanupModeKeepLatestEpisodes, 10)
+	assert.Len(t, kept, 4)
+}
@@ -0,0 +1,165 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/notify/email"
+)
+
+// sendKeepExpiryReminders reminds requesters that the protection of their kept media expires soon.
+func (e *Engine) sendKeepExpiryReminders(ctx context.Context) error {
+	if e.cfg.KeepExpiryReminderDays <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	until := now.Add(time.Duration(e.cfg.KeepExpiryReminderDays) * 24 * time.Hour)
+	mediaItems, err := e.db.GetMediaProtectionExpiringBetween(ctx, now, until)
+	if err != nil {
+		return err
+	}
+
+	for _, media := range mediaItems {
+		if media.Request.UserID == 0 || media.Request.User.Username == "" {
+			log.FromContext(ctx).Debug("media item has no requester, skipping keep expiry reminder", "title", media.Title)
+			continue
+		}
+
+		// the reminder is retried in the next run if no channel delivered it
+		if !e.sendKeepExpiryReminder(ctx, media) || e.cfg.DryRun {
+			continue
+		}
+		if err := e.db.MarkProtectionReminderSent(ctx, media.ID); err != nil {
+			log.FromContext(ctx).Error("failed to mark protection reminder as sent", "title", media.Title, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// sendKeepExpiryReminder notifies the requester of a media item via their preferred channels.
+// It reports whether at least one channel delivered the reminder.
+func (e *Engine) sendKeepExpiryReminder(ctx context.Context, media database.Media) bool {
+	user := media.Request.User
+
+	prefs, err := e.db.GetUserNotificationPrefs(ctx, user.ID)
+	if err != nil {
+		log.FromContext(ctx).Error("failed to get notification preferences", "userID", user.ID, "error", err)
+		return false
+	}
+
+	var channels []string
+
+	if e.webpush != nil && e.cfg.WebPush.Events.Includes(config.NotificationEventKeepExpiryReminder) && prefs.WebPushEnabled {
+		if err := e.webpush.SendProtectionExpiryNotification(ctx, user.Username, media.Title, string(media.MediaType), *media.ProtectedUntil); err != nil {
+			log.FromContext(ctx).Error("failed to send webpush keep expiry reminder", "title", media.Title, "error", err)
+		} else {
+			channels = append(channels, "webpush")
+		}
+	}
+
+	if e.email != nil && e.cfg.Email.Enabled && e.cfg.Email.Events.Includes(config.NotificationEventKeepExpiryReminder) && prefs.EmailEnabled && prefs.Email != "" {
+		notification := email.ProtectionExpiryNotification{
+			UserEmail: prefs.Email,
+			UserName:  user.Username,
+			MediaItem: email.MediaItem{
+				Title:     media.Title,
+				MediaType: string(media.MediaType),
+			},
+			ExpiresAt:     *media.ProtectedUntil,
+			JellysweepURL: e.cfg.ServerURL,
+			DryRun:        e.cfg.DryRun,
+		}
+		if err := e.email.SendProtectionExpiryNotification(ctx, notification); err != nil {
+			log.FromContext(ctx).Error("failed to send keep expiry reminder email", "email", prefs.Email, "title", media.Title, "error", err)
+		} else {
+			channels = append(channels, "email")
+		}
+	}
+
+	if len(channels) == 0 {
+		log.FromContext(ctx).Debug("keep expiry reminder wasn't delivered", "title", media.Title, "username", user.Username)
+		return false
+	}
+	log.FromContext(ctx).Info("sent keep expiry reminder", "title", media.Title, "username", user.Username, "channels", channels)
+	return true
+}
+
+// notifyKeptMediaDeleted informs the former keep requester of a deleted media item that its protection expired and it was removed.
+// Only media items that were protected by an approved keep request before are considered.
+// Channels the user disabled in their notification settings are skipped.
+func (e *Engine) notifyKeptMediaDeleted(ctx context.Context, item database.Media) {
+	expired, err := e.db.GetLatestExpiredProtection(ctx, item.JellyfinID)
+	if err != nil {
+		log.FromContext(ctx).Error("failed to get expired protection of deleted media item", "title", item.Title, "error", err)
+		return
+	}
+	if expired == nil || expired.Request.Status != database.RequestStatusApproved || expired.Request.User.Username == "" {
+		return
+	}
+	user := expired.Request.User
+
+	prefs, err := e.db.GetUserNotificationPrefs(ctx, user.ID)
+	if err != nil {
+		log.FromContext(ctx).Error("failed to get notification preferences", "userID", user.ID, "error", err)
+		return
+	}
+
+	jellyseerrURL := e.jellyseerrMediaURL(item)
+	var channels []string
+
+	if e.webpush != nil && e.cfg.WebPush.Events.Includes(config.NotificationEventKeptMediaDeleted) && prefs.WebPushEnabled {
+		if err := e.webpush.SendKeptMediaDeletedNotification(ctx, user.Username, item.Title, string(item.MediaType), jellyseerrURL); err != nil {
+			log.FromContext(ctx).Error("failed to send webpush kept media deleted notification", "title", item.Title, "error", err)
+		} else {
+			channels = append(channels, "webpush")
+		}
+	}
+
+	if e.email != nil && e.cfg.Email.Enabled && e.cfg.Email.Events.Includes(config.NotificationEventKeptMediaDeleted) && prefs.EmailEnabled && prefs.Email != "" {
+		notification := email.KeptMediaDeletedNotification{
+			UserEmail: prefs.Email,
+			UserName:  user.Username,
+			MediaItem: email.MediaItem{
+				Title:     item.Title,
+				MediaType: string(item.MediaType),
+				PosterURL: e.posterOrDefault(item.PosterURL),
+			},
+			JellyseerrURL: jellyseerrURL,
+			JellysweepURL: e.cfg.ServerURL,
+			DryRun:        e.cfg.DryRun,
+		}
+		if err := e.email.SendKeptMediaDeletedNotification(ctx, notification); err != nil {
+			log.FromContext(ctx).Error("failed to send kept media deleted email", "email", prefs.Email, "title", item.Title, "error", err)
+		} else {
+			channels = append(channels, "email")
+		}
+	}
+
+	if len(channels) == 0 {
+		log.FromContext(ctx).Debug("former keep requester wasn't notified about deleted media", "title", item.Title, "username", user.Username)
+		return
+	}
+	log.FromContext(ctx).Info("notified former keep requester about deleted media", "title", item.Title, "username", user.Username, "channels", channels)
+}
+
+// jellyseerrMediaURL returns the URL of the media item in Jellyseerr, so it can be requested again.
+// It is empty if Jellyseerr isn't configured or the media can't be requested there.
+func (e *Engine) jellyseerrMediaURL(item database.Media) string {
+	if e.cfg.Jellyseerr == nil || e.cfg.Jellyseerr.URL == "" || item.TmdbId == nil || *item.TmdbId == 0 {
+		return ""
+	}
+	switch item.MediaType {
+	case database.MediaTypeMovie:
+		return fmt.Sprintf("%s/movie/%d", e.cfg.Jellyseerr.URL, *item.TmdbId)
+	case database.MediaTypeTV:
+		return fmt.Sprintf("%s/tv/%d", e.cfg.Jellyseerr.URL, *item.TmdbId)
+	default:
+		return ""
+	}
+}
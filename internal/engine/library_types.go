@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"context"
+	"slices"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+)
+
+// restrictLibraryMediaTypes drops the items whose type doesn't match the media type configured for their library
+// and warns about libraries that contain different types of media without a configured media type.
+func (e *Engine) restrictLibraryMediaTypes(ctx context.Context, mediaItems []arr.MediaItem) []arr.MediaItem {
+	for libraryName, mediaTypes := range libraryMediaTypes(mediaItems) {
+		libraryConfig := e.cfg.GetLibraryConfig(libraryName)
+		if len(mediaTypes) > 1 && libraryConfig != nil && libraryConfig.MediaType == config.LibraryMediaTypeAny {
+			log.FromContext(ctx).Warn("library contains different types of media, the library config applies to all of them. Set media_type to restrict it to one type",
+				"library", libraryName, "mediaTypes", mediaTypes)
+		}
+	}
+
+	return slices.DeleteFunc(mediaItems, func(item arr.MediaItem) bool {
+		libraryConfig := e.cfg.GetLibraryConfig(item.LibraryName)
+		if libraryConfig == nil || libraryConfig.MediaType == config.LibraryMediaTypeAny {
+			return false
+		}
+		if string(item.MediaType) != string(libraryConfig.MediaType) {
+			log.FromContext(ctx).Debug("Skipping media item of another media type than configured for the library", "title", item.Title, "library", item.LibraryName, "type", item.MediaType, "libraryType", libraryConfig.MediaType)
+			return true
+		}
+		return false
+	})
+}
+
+// libraryMediaTypes returns the sorted media types found in each library.
+func libraryMediaTypes(mediaItems []arr.MediaItem) map[string][]models.MediaType {
+	types := make(map[string][]models.MediaType)
+	for _, item := range mediaItems {
+		if !slices.Contains(types[item.LibraryName], item.MediaType) {
+			types[item.LibraryName] = append(types[item.LibraryName], item.MediaType)
+		}
+	}
+	for _, mediaTypes := range types {
+		slices.Sort(mediaTypes)
+	}
+	return types
+}
@@ -0,0 +1,44 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/scheduler"
+)
+
+// SetMaintenanceMode enables or disables the maintenance mode and persists it.
+// While enabled, keep requests and manual triggers are rejected with ErrMaintenanceMode and scheduled jobs are skipped.
+func (e *Engine) SetMaintenanceMode(ctx context.Context, on bool) error {
+	if err := e.db.SetMaintenanceMode(ctx, on); err != nil {
+		return fmt.Errorf("failed to persist maintenance state: %w", err)
+	}
+	e.maintenance.Store(on)
+	log.FromContext(ctx).Info("Maintenance mode changed", "enabled", on)
+	return nil
+}
+
+// MaintenanceMode reports whether the maintenance mode is enabled.
+func (e *Engine) MaintenanceMode() bool {
+	return e.maintenance.Load()
+}
+
+// RunJobNow manually triggers a scheduler job, unless the maintenance mode is enabled.
+func (e *Engine) RunJobNow(id string) error {
+	if e.MaintenanceMode() {
+		return ErrMaintenanceMode
+	}
+	return e.scheduler.RunJobNow(id)
+}
+
+// skipInMaintenance skips the job while the maintenance mode is enabled.
+func (e *Engine) skipInMaintenance(id string, job scheduler.JobFunc) scheduler.JobFunc {
+	return func(ctx context.Context) error {
+		if e.MaintenanceMode() {
+			log.Info("Maintenance mode enabled, skipping job", "id", id)
+			return nil
+		}
+		return job(ctx)
+	}
+}
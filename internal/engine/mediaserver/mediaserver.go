@@ -0,0 +1,42 @@
+package mediaserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	jellyfin "github.com/sj14/jellyfin-go/api"
+)
+
+// MediaServer is the interface implemented by the supported media servers (Jellyfin, Emby and Plex).
+type MediaServer interface {
+	// GetJellyfinItems returns all movies and series from the enabled libraries and a map of library names to their folders.
+	GetJellyfinItems(ctx context.Context) ([]arr.JellyfinItem, map[string][]string, error)
+	// RemoveItemWithCleanupMode removes an item according to the cleanup mode.
+	RemoveItemWithCleanupMode(ctx context.Context, itemID, title string, itemType jellyfin.BaseItemKind, cleanupMode config.CleanupMode, keepCount int) error
+
+	// Collection management
+	FindCollectionByName(ctx context.Context, name string) (string, error)
+	GetCollectionItems(ctx context.Context, collectionID string) (map[string]bool, error)
+	CreateCollection(ctx context.Context, name string, itemIDs []string) error
+	AddItemsToCollection(ctx context.Context, collectionID string, itemIDs []string) error
+	RemoveItemsFromCollection(ctx context.Context, collectionID string, itemIDs []string) error
+	// RemoveItemFromCollection removes a single item from the collection with the given name.
+	// It does nothing if the collection doesn't exist.
+	RemoveItemFromCollection(ctx context.Context, collectionName, itemID string) error
+	SetCollectionOverview(ctx context.Context, collectionID, overview string) error
+	// GetCollectionMembership returns a map of item IDs to the names of the collections they belong to.
+	GetCollectionMembership(ctx context.Context, itemIDs []string) (map[string][]string, error)
+
+	// GetFavoritedBy returns a map of item IDs to the names of the users who marked the item as favorite.
+	// Items nobody favorited are missing in the map.
+	GetFavoritedBy(ctx context.Context, itemIDs []string) (map[string][]string, error)
+	// GetInProgressBy returns a map of item IDs to the names of the users with a resume position in the item,
+	// i.e. who started but didn't finish it. For series, a resume position in any episode counts.
+	// Items nobody is in the middle of are missing in the map.
+	GetInProgressBy(ctx context.Context, itemIDs []string) (map[string][]string, error)
+	// GetLastPlayedByAnyUser returns a map of item IDs to the latest play of any user, read from the user data of the media server.
+	// For series, the latest played episode counts. Items nobody played are missing in the map.
+	GetLastPlayedByAnyUser(ctx context.Context, itemIDs []string) (map[string]time.Time, error)
+}
@@ -0,0 +1,137 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/eko/gocache/lib/v4/store"
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/pkg/tmdb"
+	"github.com/samber/lo"
+)
+
+// tmdbCacheTTL is how long TMDB metadata is cached.
+const tmdbCacheTTL = 7 * 24 * time.Hour
+
+// getTMDBDetails returns the TMDB metadata of a movie or series, preferably from the cache.
+// Series without a TMDB ID are looked up by their TVDB ID.
+func (e *Engine) getTMDBDetails(ctx context.Context, mediaType models.MediaType, tmdbID, tvdbID int32) (*tmdb.Details, error) {
+	var key string
+	var fetch func() (*tmdb.Details, error)
+	switch {
+	case mediaType == models.MediaTypeMovie && tmdbID != 0:
+		key = fmt.Sprintf("movie-%d", tmdbID)
+		fetch = func() (*tmdb.Details, error) { return e.tmdb.GetMovie(ctx, tmdbID) }
+	case mediaType == models.MediaTypeTV && tmdbID != 0:
+		key = fmt.Sprintf("tv-%d", tmdbID)
+		fetch = func() (*tmdb.Details, error) { return e.tmdb.GetTvShow(ctx, tmdbID) }
+	case mediaType == models.MediaTypeTV && tvdbID != 0:
+		key = fmt.Sprintf("tvdb-%d", tvdbID)
+		fetch = func() (*tmdb.Details, error) { return e.tmdb.GetTvShowByTvdbID(ctx, tvdbID) }
+	default:
+		return nil, tmdb.ErrNotFound
+	}
+
+	if details, err := e.cache.TMDBDetailsCache.Get(ctx, key); err == nil {
+		return &details, nil
+	}
+
+	details, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.cache.TMDBDetailsCache.Set(ctx, key, *details, store.WithExpiration(tmdbCacheTTL)); err != nil {
+		log.FromContext(ctx).Warn("failed to cache tmdb details", "key", key, "error", err)
+	}
+	return details, nil
+}
+
+// enrichMetadata adds the overview, genres and poster from TMDB to the media items.
+// The items are returned unchanged if TMDB isn't configured.
+func (e *Engine) enrichMetadata(ctx context.Context, mediaItems []arr.MediaItem) []arr.MediaItem {
+	if e.tmdb == nil {
+		log.FromContext(ctx).Debug("TMDB client not available, skipping metadata enrichment")
+		return mediaItems
+	}
+
+	for i, item := range mediaItems {
+		// TMDB doesn't know books
+		if item.MediaType == models.MediaTypeBook {
+			continue
+		}
+		details, err := e.getTMDBDetails(ctx, item.MediaType, item.TmdbId, item.TvdbId)
+		if err != nil {
+			log.FromContext(ctx).Warn("failed to get tmdb details for item", "title", item.Title, "error", err)
+			continue
+		}
+		mediaItems[i].Overview = details.Overview
+		mediaItems[i].Genres = details.GenreNames()
+		mediaItems[i].PosterURL = details.PosterURL()
+	}
+
+	return mediaItems
+}
+
+// posterOrDefault returns posterURL, or the configured default poster if it's empty.
+func (e *Engine) posterOrDefault(posterURL string) string {
+	if posterURL == "" {
+		return e.cfg.DefaultPosterURL
+	}
+	return posterURL
+}
+
+// leavingCollectionOverview builds the description of a leaving collection.
+// It lists the items ordered by their deletion date, together with the reason they are leaving and their genres and overview from TMDB.
+func (e *Engine) leavingCollectionOverview(ctx context.Context, items []database.Media) string {
+	items = slices.Clone(items)
+	slices.SortFunc(items, func(a, b database.Media) int {
+		return a.DefaultDeleteAt.Compare(b.DefaultDeleteAt)
+	})
+
+	var b strings.Builder
+	b.WriteString("These items are leaving soon:")
+	for _, item := range items {
+		fmt.Fprintf(&b, "\n\n%s (%d), leaving on %s", item.Title, item.Year, item.DefaultDeleteAt.Format("January 2, 2006"))
+		if item.DeletionReason != "" {
+			fmt.Fprintf(&b, "\nReason: %s", item.DeletionReason)
+		}
+
+		details, err := e.getTMDBDetails(ctx, models.MediaType(item.MediaType), lo.FromPtr(item.TmdbId), lo.FromPtr(item.TvdbId))
+		if err != nil {
+			log.FromContext(ctx).Debug("no tmdb details for leaving item", "title", item.Title, "error", err)
+			continue
+		}
+		if genres := details.GenreNames(); len(genres) > 0 {
+			fmt.Fprintf(&b, "\n%s", strings.Join(genres, ", "))
+		}
+		if details.Overview != "" {
+			fmt.Fprintf(&b, "\n%s", details.Overview)
+		}
+	}
+	return b.String()
+}
+
+// updateLeavingCollectionOverview sets the description of a leaving collection.
+// Without TMDB the collections are left untouched.
+func (e *Engine) updateLeavingCollectionOverview(ctx context.Context, collectionName string, items []database.Media) {
+	if e.tmdb == nil {
+		return
+	}
+
+	collectionID, err := e.jellyfin.FindCollectionByName(ctx, collectionName)
+	if err != nil || collectionID == "" {
+		log.FromContext(ctx).Warn("Failed to find leaving collection to update its overview", "collection", collectionName, "error", err)
+		return
+	}
+
+	if err := e.jellyfin.SetCollectionOverview(ctx, collectionID, e.leavingCollectionOverview(ctx, items)); err != nil {
+		log.FromContext(ctx).Warn("Failed to update overview of leaving collection", "collection", collectionName, "error", err)
+	}
+}
@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+)
+
+// movieCollectionID returns the TMDB ID of the Radarr collection the item belongs to, 0 if it isn't a movie of a collection.
+func movieCollectionID(item arr.MediaItem) int32 {
+	if item.MediaType != models.MediaTypeMovie {
+		return 0
+	}
+	collection, ok := item.MovieResource.GetCollectionOk()
+	if !ok {
+		return 0
+	}
+	return collection.GetTmdbId()
+}
+
+// keepCollectionsTogether drops the marked movies of every collection that has a movie which isn't eligible for deletion,
+// so the movies of a collection are only marked together. A movie is eligible if it's marked in this run,
+// tagged for immediate deletion or already recorded for deletion without being protected.
+// gathered are all movies the run looked at, marked the ones that passed the filters.
+func (e *Engine) keepCollectionsTogether(ctx context.Context, gathered, deleteNow, marked []arr.MediaItem) ([]arr.MediaItem, error) {
+	recorded, err := e.db.GetMediaItems(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media items: %w", err)
+	}
+
+	now := time.Now()
+	eligible := make(map[int32]bool)
+	for _, item := range recorded {
+		if item.MediaType == database.MediaTypeMovie && (item.ProtectedUntil == nil || !item.ProtectedUntil.After(now)) {
+			eligible[item.ArrID] = true
+		}
+	}
+	for _, items := range [][]arr.MediaItem{deleteNow, marked} {
+		for _, item := range items {
+			if item.MediaType == models.MediaTypeMovie {
+				eligible[item.MovieResource.GetId()] = true
+			}
+		}
+	}
+
+	// key: collection TMDB ID, value: whether every movie of the collection is eligible
+	complete := make(map[int32]bool)
+	for _, items := range [][]arr.MediaItem{gathered, deleteNow} {
+		for _, item := range items {
+			collectionID := movieCollectionID(item)
+			if collectionID == 0 {
+				continue
+			}
+			if _, ok := complete[collectionID]; !ok {
+				complete[collectionID] = true
+			}
+			if !eligible[item.MovieResource.GetId()] {
+				complete[collectionID] = false
+			}
+		}
+	}
+
+	result := make([]arr.MediaItem, 0, len(marked))
+	for _, item := range marked {
+		if collectionID := movieCollectionID(item); collectionID != 0 && !complete[collectionID] {
+			collection := item.MovieResource.GetCollection()
+			log.FromContext(ctx).Info("Not marking movie, other movies of its collection aren't eligible for deletion", "title", item.Title, "collection", collection.GetTitle())
+			continue
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// protectedCollections returns the collections with a protected or permanently ignored movie.
+// The other movies of these collections must not be deleted if collections are treated atomically.
+func (e *Engine) protectedCollections(ctx context.Context) (map[int32]bool, error) {
+	if !e.cfg.TreatCollectionsAtomically {
+		return nil, nil
+	}
+
+	mediaItems, err := e.db.GetMediaItems(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media items: %w", err)
+	}
+	ignored, err := e.db.GetIgnoredMedia(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ignored media: %w", err)
+	}
+
+	now := time.Now()
+	protected := make(map[int32]bool)
+	for _, item := range mediaItems {
+		if item.CollectionID != 0 && item.ProtectedUntil != nil && item.ProtectedUntil.After(now) {
+			protected[item.CollectionID] = true
+		}
+	}
+	for _, item := range ignored {
+		if item.CollectionID != 0 {
+			protected[item.CollectionID] = true
+		}
+	}
+	return protected, nil
+}
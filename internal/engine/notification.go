@@ -3,41 +3,71 @@ package engine
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/charmbracelet/log"
-	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
 	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/filter"
+	"github.com/jon4hz/jellysweep/internal/notify/apprise"
 	"github.com/jon4hz/jellysweep/internal/notify/email"
+	"github.com/jon4hz/jellysweep/internal/notify/gotify"
+	"github.com/jon4hz/jellysweep/internal/notify/matrix"
 	"github.com/jon4hz/jellysweep/internal/notify/ntfy"
+	"github.com/jon4hz/jellysweep/internal/notify/pushover"
+	"github.com/jon4hz/jellysweep/internal/notify/slack"
+	"github.com/jon4hz/jellysweep/internal/notify/webhook"
 )
 
 // sendEmailNotifications sends email notifications to users about their media being marked for deletion.
-func (e *Engine) sendEmailNotifications() {
-	if e.email == nil || !e.cfg.Email.Enabled {
-		log.Debug("Email service not configured or disabled, skipping notifications")
+// Users can opt out of emails or receive one email per item instead of a digest via their notification preferences.
+// Digests are split into emails of at most MaxItemsPerEmail items. If more than BatchAboveTotal items were marked,
+// a single summary is sent to the admin instead, so a large first run doesn't flood the users.
+func (e *Engine) sendEmailNotifications(ctx context.Context) {
+	if e.email == nil || !e.cfg.Email.Enabled || !e.cfg.Email.Events.Includes(config.NotificationEventDeletionSummary) {
+		log.FromContext(ctx).Debug("Email service not configured or disabled, skipping notifications")
 		return
 	}
 
 	if len(e.data.userNotifications) == 0 {
-		log.Debug("No user notifications to send")
+		log.FromContext(ctx).Debug("No user notifications to send")
 		return
 	}
 
+	// all notifications are sent over a single SMTP connection
+	session := e.email.NewSession()
+	defer session.Close()
+
+	if total := e.userNotificationCount(); e.cfg.Email.BatchAboveTotal > 0 && total > e.cfg.Email.BatchAboveTotal {
+		log.FromContext(ctx).Info("Too many marked items for user notifications, sending an admin summary instead", "items", total, "batchAboveTotal", e.cfg.Email.BatchAboveTotal)
+		if err := session.SendAdminSummaryNotification(ctx, e.adminSummaryNotification()); err != nil {
+			log.FromContext(ctx).Error("failed to send admin summary email", "email", e.cfg.Email.GetAdminEmail(), "error", err)
+			return
+		}
+		log.FromContext(ctx).Info("sent admin summary notification", "email", e.cfg.Email.GetAdminEmail(), "items", total)
+		return
+	}
+
+	var failed []string
 	for userEmail, mediaItems := range e.data.userNotifications {
 		if len(mediaItems) == 0 {
 			continue
 		}
 
-		// Convert engine MediaItems to email MediaItems
-		emailMediaItems := make([]email.MediaItem, 0, len(mediaItems))
-		for _, item := range mediaItems {
-			emailMediaItems = append(emailMediaItems, email.MediaItem{
-				Title:       item.Title,
-				MediaType:   string(item.MediaType),
-				RequestedBy: item.RequestedBy,
-			})
+		prefs, err := e.db.GetUserNotificationPrefsByEmail(ctx, userEmail)
+		if err != nil {
+			log.FromContext(ctx).Error("failed to get notification preferences, using defaults", "email", userEmail, "error", err)
+			prefs = database.DefaultUserNotificationPrefs(0)
 		}
+		if !prefs.EmailEnabled {
+			log.FromContext(ctx).Debug("User opted out of email notifications", "email", userEmail)
+			continue
+		}
+
+		emailMediaItems := e.emailMediaItems(mediaItems)
 
 		// Calculate cleanup date (current time + cleanup delay)
 		cleanupDate := time.Now()
@@ -63,48 +93,127 @@ func (e *Engine) sendEmailNotifications() {
 			JellysweepURL: e.cfg.ServerURL,
 		}
 
-		if err := e.email.SendCleanupNotification(notification); err != nil {
-			log.Error("failed to send email notification", "email", userEmail, "error", err)
-		} else {
-			log.Info("sent cleanup notification", "email", userEmail, "items", len(emailMediaItems))
+		if prefs.Digest {
+			for _, chunk := range chunkEmailItems(emailMediaItems, e.cfg.Email.MaxItemsPerEmail) {
+				notification.MediaItems = chunk
+				if err := session.SendCleanupNotification(ctx, notification); err != nil {
+					log.FromContext(ctx).Warn("failed to send email notification", "email", userEmail, "error", err)
+					failed = append(failed, userEmail)
+					continue
+				}
+				log.FromContext(ctx).Info("sent cleanup notification", "email", userEmail, "items", len(chunk))
+			}
+			continue
+		}
+
+		sent := 0
+		for _, item := range emailMediaItems {
+			notification.MediaItems = []email.MediaItem{item}
+			if err := session.SendCleanupNotification(ctx, notification); err != nil {
+				log.FromContext(ctx).Warn("failed to send email notification", "email", userEmail, "title", item.Title, "error", err)
+				failed = append(failed, fmt.Sprintf("%s (%s)", userEmail, item.Title))
+				continue
+			}
+			sent++
+		}
+		log.FromContext(ctx).Info("sent cleanup notifications", "email", userEmail, "items", sent)
+	}
+
+	if len(failed) > 0 {
+		log.FromContext(ctx).Error("failed to send some email notifications", "count", len(failed), "notifications", failed)
+	}
+}
+
+// emailMediaItems converts the marked media items to email media items.
+func (e *Engine) emailMediaItems(mediaItems []arr.MediaItem) []email.MediaItem {
+	emailMediaItems := make([]email.MediaItem, 0, len(mediaItems))
+	for _, item := range mediaItems {
+		deletionDate := time.Now()
+		if libraryConfig := e.cfg.GetLibraryConfig(item.LibraryName); libraryConfig != nil {
+			deletionDate = deletionDate.Add(time.Duration(libraryConfig.GetCleanupDelay()) * 24 * time.Hour)
+		}
+		emailMediaItems = append(emailMediaItems, email.MediaItem{
+			Title:          item.Title,
+			MediaType:      string(item.MediaType),
+			RequestedBy:    item.RequestedBy,
+			Overview:       item.Overview,
+			Genres:         item.Genres,
+			PosterURL:      e.posterOrDefault(item.PosterURL),
+			DeletionDate:   deletionDate,
+			DeletionReason: filter.DeletionReason(item),
+		})
+	}
+	return emailMediaItems
+}
+
+// userNotificationCount returns the number of media items of all user notifications.
+func (e *Engine) userNotificationCount() int {
+	total := 0
+	for _, mediaItems := range e.data.userNotifications {
+		total += len(mediaItems)
+	}
+	return total
+}
+
+// adminSummaryNotification builds the summary of all user notifications, sorted by the requester's email.
+func (e *Engine) adminSummaryNotification() email.AdminSummaryNotification {
+	notification := email.AdminSummaryNotification{
+		AdminEmail:    e.cfg.Email.GetAdminEmail(),
+		DryRun:        e.cfg.DryRun,
+		JellysweepURL: e.cfg.ServerURL,
+	}
+	for _, userEmail := range slices.Sorted(maps.Keys(e.data.userNotifications)) {
+		mediaItems := e.data.userNotifications[userEmail]
+		if len(mediaItems) == 0 {
+			continue
 		}
+		notification.Requesters = append(notification.Requesters, email.RequesterSummary{UserEmail: userEmail, Items: len(mediaItems)})
+		notification.MediaItems = append(notification.MediaItems, e.emailMediaItems(mediaItems)...)
+	}
+	return notification
+}
+
+// chunkEmailItems splits the media items into chunks of at most size items.
+// All items are returned in a single chunk if size isn't positive.
+func chunkEmailItems(items []email.MediaItem, size int) [][]email.MediaItem {
+	if size <= 0 || len(items) <= size {
+		return [][]email.MediaItem{items}
 	}
+	return slices.Collect(slices.Chunk(items, size))
 }
 
 // sendNtfyDeletionSummary sends a summary notification about media marked for deletion.
 func (e *Engine) sendNtfyDeletionSummary(ctx context.Context, mediaItems []arr.MediaItem) error {
-	if e.ntfy == nil {
-		log.Debug("Ntfy service not configured, skipping deletion summary notification")
+	if e.ntfy == nil || !e.cfg.Ntfy.Events.Includes(config.NotificationEventDeletionSummary) {
+		log.FromContext(ctx).Debug("Ntfy service not configured or deletion summary notifications disabled, skipping")
 		return nil
 	}
 
 	if len(mediaItems) == 0 {
-		log.Debug("No media items marked for deletion")
+		log.FromContext(ctx).Debug("No media items marked for deletion")
 		return nil
 	}
 
 	// Calculate totals and prepare media items for notification
 	totalItems := len(mediaItems)
 	if totalItems == 0 {
-		log.Debug("No media items to notify about")
+		log.FromContext(ctx).Debug("No media items to notify about")
 		return nil
 	}
 
 	libraries := make(map[string][]ntfy.MediaItem)
 	for _, item := range mediaItems {
-		mediaType := "tv"
-		if item.MediaType == models.MediaTypeMovie {
-			mediaType = "movie"
-		}
+		mediaType := string(item.MediaType)
 
 		if _, exists := libraries[item.LibraryName]; !exists {
 			libraries[item.LibraryName] = make([]ntfy.MediaItem, 0)
 		}
 
 		libraries[item.LibraryName] = append(libraries[item.LibraryName], ntfy.MediaItem{
-			Title: item.Title,
-			Type:  mediaType,
-			Year:  item.Year,
+			Title:  item.Title,
+			Type:   mediaType,
+			Year:   item.Year,
+			Genres: item.Genres,
 		})
 	}
 
@@ -113,19 +222,132 @@ func (e *Engine) sendNtfyDeletionSummary(ctx context.Context, mediaItems []arr.M
 		return fmt.Errorf("failed to send deletion summary notification: %w", err)
 	}
 
-	log.Info("sent deletion summary notification", "items", totalItems, "libraries", len(libraries))
+	log.FromContext(ctx).Info("sent deletion summary notification", "items", totalItems, "libraries", len(libraries))
+	return nil
+}
+
+// sendGotifyDeletionSummary sends a gotify summary notification about media marked for deletion.
+func (e *Engine) sendGotifyDeletionSummary(ctx context.Context, mediaItems []arr.MediaItem) error {
+	if e.gotify == nil || !e.cfg.Gotify.Events.Includes(config.NotificationEventDeletionSummary) {
+		log.FromContext(ctx).Debug("Gotify service not configured or deletion summary notifications disabled, skipping")
+		return nil
+	}
+
+	if len(mediaItems) == 0 {
+		log.FromContext(ctx).Debug("No media items marked for deletion")
+		return nil
+	}
+
+	libraries := make(map[string][]gotify.MediaItem)
+	for _, item := range mediaItems {
+		mediaType := string(item.MediaType)
+
+		libraries[item.LibraryName] = append(libraries[item.LibraryName], gotify.MediaItem{
+			Title: item.Title,
+			Type:  mediaType,
+			Year:  item.Year,
+		})
+	}
+
+	if err := e.gotify.SendDeletionSummary(ctx, len(mediaItems), libraries); err != nil {
+		return fmt.Errorf("failed to send gotify deletion summary notification: %w", err)
+	}
+
+	log.FromContext(ctx).Info("sent gotify deletion summary notification", "items", len(mediaItems), "libraries", len(libraries))
+	return nil
+}
+
+// sendMatrixDeletionSummary sends a matrix summary notification about media marked for deletion.
+func (e *Engine) sendMatrixDeletionSummary(ctx context.Context, mediaItems []arr.MediaItem) error {
+	if e.matrix == nil || !e.cfg.Matrix.Events.Includes(config.NotificationEventDeletionSummary) {
+		log.FromContext(ctx).Debug("Matrix service not configured or deletion summary notifications disabled, skipping")
+		return nil
+	}
+
+	if len(mediaItems) == 0 {
+		log.FromContext(ctx).Debug("No media items marked for deletion")
+		return nil
+	}
+
+	libraries := make(map[string][]matrix.MediaItem)
+	for _, item := range mediaItems {
+		mediaType := string(item.MediaType)
+
+		libraries[item.LibraryName] = append(libraries[item.LibraryName], matrix.MediaItem{
+			Title: item.Title,
+			Type:  mediaType,
+			Year:  item.Year,
+		})
+	}
+
+	if err := e.matrix.SendDeletionSummary(ctx, len(mediaItems), libraries); err != nil {
+		return fmt.Errorf("failed to send matrix deletion summary notification: %w", err)
+	}
+
+	log.FromContext(ctx).Info("sent matrix deletion summary notification", "items", len(mediaItems), "libraries", len(libraries))
+	return nil
+}
+
+// sendAppriseDeletionSummary sends an apprise summary notification about media marked for deletion.
+func (e *Engine) sendAppriseDeletionSummary(ctx context.Context, mediaItems []arr.MediaItem) error {
+	if e.apprise == nil || !e.cfg.Apprise.Events.Includes(config.NotificationEventDeletionSummary) {
+		log.FromContext(ctx).Debug("Apprise service not configured or deletion summary notifications disabled, skipping")
+		return nil
+	}
+
+	if len(mediaItems) == 0 {
+		log.FromContext(ctx).Debug("No media items marked for deletion")
+		return nil
+	}
+
+	libraries := make(map[string][]apprise.MediaItem)
+	for _, item := range mediaItems {
+		mediaType := string(item.MediaType)
+
+		libraries[item.LibraryName] = append(libraries[item.LibraryName], apprise.MediaItem{
+			Title: item.Title,
+			Type:  mediaType,
+			Year:  item.Year,
+		})
+	}
+
+	if err := e.apprise.SendDeletionSummary(ctx, len(mediaItems), libraries); err != nil {
+		return fmt.Errorf("failed to send apprise deletion summary notification: %w", err)
+	}
+
+	log.FromContext(ctx).Info("sent apprise deletion summary notification", "items", len(mediaItems), "libraries", len(libraries))
 	return nil
 }
 
+// sendDeletionCompletedNotifications sends the summary of the deleted media items to all notifiers supporting it.
+// Nothing is sent if no items were deleted.
+func (e *Engine) sendDeletionCompletedNotifications(ctx context.Context, deletedItems map[string][]arr.MediaItem) {
+	if len(deletedItems) == 0 {
+		return
+	}
+	if err := e.sendNtfyDeletionCompletedNotification(ctx, deletedItems); err != nil {
+		log.FromContext(ctx).Error("failed to send deletion completed notification", "error", err)
+	}
+	if err := e.sendSlackDeletionCompletedNotification(ctx, deletedItems); err != nil {
+		log.FromContext(ctx).Error("failed to send slack deletion completed notification", "error", err)
+	}
+	if err := e.sendPushoverDeletionCompletedNotification(ctx, deletedItems); err != nil {
+		log.FromContext(ctx).Error("failed to send pushover deletion completed notification", "error", err)
+	}
+	if err := e.sendWebhookDeletionCompletedNotification(ctx, deletedItems); err != nil {
+		log.FromContext(ctx).Error("failed to send webhook deletion completed notification", "error", err)
+	}
+}
+
 // sendNtfyDeletionCompletedNotification sends a notification summary of media that was actually deleted.
 func (e *Engine) sendNtfyDeletionCompletedNotification(ctx context.Context, deletedItems map[string][]arr.MediaItem) error {
-	if e.ntfy == nil {
-		log.Debug("Ntfy service not configured, skipping deletion completed notification")
+	if e.ntfy == nil || !e.cfg.Ntfy.Events.Includes(config.NotificationEventDeletionCompleted) {
+		log.FromContext(ctx).Debug("Ntfy service not configured or deletion completed notifications disabled, skipping")
 		return nil
 	}
 
 	if len(deletedItems) == 0 {
-		log.Debug("No media items were deleted")
+		log.FromContext(ctx).Debug("No media items were deleted")
 		return nil
 	}
 
@@ -140,10 +362,7 @@ func (e *Engine) sendNtfyDeletionCompletedNotification(ctx context.Context, dele
 			// Convert engine MediaItems to ntfy MediaItems
 			ntfyItems := make([]ntfy.MediaItem, 0, len(items))
 			for _, item := range items {
-				mediaType := "tv"
-				if item.MediaType == models.MediaTypeMovie {
-					mediaType = "movie"
-				}
+				mediaType := string(item.MediaType)
 
 				ntfyItems = append(ntfyItems, ntfy.MediaItem{
 					Title: item.Title,
@@ -156,7 +375,7 @@ func (e *Engine) sendNtfyDeletionCompletedNotification(ctx context.Context, dele
 	}
 
 	if totalItems == 0 {
-		log.Debug("No media items to notify about")
+		log.FromContext(ctx).Debug("No media items to notify about")
 		return nil
 	}
 
@@ -165,6 +384,171 @@ func (e *Engine) sendNtfyDeletionCompletedNotification(ctx context.Context, dele
 		return fmt.Errorf("failed to send deletion completed notification: %w", err)
 	}
 
-	log.Info("sent deletion completed notification", "items", totalItems, "libraries", len(libraries))
+	log.FromContext(ctx).Info("sent deletion completed notification", "items", totalItems, "libraries", len(libraries))
+	return nil
+}
+
+// sendSlackDeletionCompletedNotification sends a slack summary of media that was actually deleted,
+// including the reclaimed disk space per library.
+func (e *Engine) sendSlackDeletionCompletedNotification(ctx context.Context, deletedItems map[string][]arr.MediaItem) error {
+	if e.slack == nil || !e.cfg.Slack.Events.Includes(config.NotificationEventDeletionCompleted) {
+		log.FromContext(ctx).Debug("Slack service not configured or deletion completed notifications disabled, skipping")
+		return nil
+	}
+
+	libraries := make(map[string][]slack.MediaItem)
+	for library, items := range deletedItems {
+		for _, item := range items {
+			mediaType := string(item.MediaType)
+
+			libraries[library] = append(libraries[library], slack.MediaItem{
+				Title:    item.Title,
+				Type:     mediaType,
+				Year:     item.Year,
+				FileSize: item.FileSize,
+			})
+		}
+	}
+
+	if len(libraries) == 0 {
+		log.FromContext(ctx).Debug("No media items to notify about")
+		return nil
+	}
+
+	var historyURL string
+	if e.cfg.ServerURL != "" {
+		historyURL = e.cfg.ServerURL + "/admin/history"
+	}
+
+	if err := e.slack.SendDeletionCompletedSummary(ctx, libraries, historyURL); err != nil {
+		return fmt.Errorf("failed to send slack deletion completed notification: %w", err)
+	}
+
+	log.FromContext(ctx).Info("sent slack deletion completed notification", "libraries", len(libraries))
 	return nil
 }
+
+// sendPushoverDeletionSummary sends a pushover summary notification about media marked for deletion.
+func (e *Engine) sendPushoverDeletionSummary(ctx context.Context, mediaItems []arr.MediaItem) error {
+	if e.pushover == nil || !e.cfg.Pushover.Events.Includes(config.NotificationEventDeletionSummary) {
+		log.FromContext(ctx).Debug("Pushover service not configured or deletion summary notifications disabled, skipping")
+		return nil
+	}
+
+	if len(mediaItems) == 0 {
+		log.FromContext(ctx).Debug("No media items marked for deletion")
+		return nil
+	}
+
+	libraries := make(map[string][]pushover.MediaItem)
+	for _, item := range mediaItems {
+		libraries[item.LibraryName] = append(libraries[item.LibraryName], toPushoverMediaItem(item))
+	}
+
+	if err := e.pushover.SendDeletionSummary(ctx, len(mediaItems), libraries); err != nil {
+		return fmt.Errorf("failed to send pushover deletion summary notification: %w", err)
+	}
+
+	log.FromContext(ctx).Info("sent pushover deletion summary notification", "items", len(mediaItems), "libraries", len(libraries))
+	return nil
+}
+
+// sendPushoverDeletionCompletedNotification sends a pushover summary of media that was actually deleted.
+func (e *Engine) sendPushoverDeletionCompletedNotification(ctx context.Context, deletedItems map[string][]arr.MediaItem) error {
+	if e.pushover == nil || !e.cfg.Pushover.Events.Includes(config.NotificationEventDeletionCompleted) {
+		log.FromContext(ctx).Debug("Pushover service not configured or deletion completed notifications disabled, skipping")
+		return nil
+	}
+
+	totalItems := 0
+	libraries := make(map[string][]pushover.MediaItem)
+	for library, items := range deletedItems {
+		for _, item := range items {
+			libraries[library] = append(libraries[library], toPushoverMediaItem(item))
+		}
+		totalItems += len(items)
+	}
+
+	if totalItems == 0 {
+		log.FromContext(ctx).Debug("No media items were deleted")
+		return nil
+	}
+
+	if err := e.pushover.SendDeletionCompleted(ctx, totalItems, libraries); err != nil {
+		return fmt.Errorf("failed to send pushover deletion completed notification: %w", err)
+	}
+
+	log.FromContext(ctx).Info("sent pushover deletion completed notification", "items", totalItems, "libraries", len(libraries))
+	return nil
+}
+
+func toPushoverMediaItem(item arr.MediaItem) pushover.MediaItem {
+	return pushover.MediaItem{
+		Title: item.Title,
+		Type:  string(item.MediaType),
+		Year:  item.Year,
+	}
+}
+
+// sendWebhookDeletionSummary sends a webhook notification about media marked for deletion.
+func (e *Engine) sendWebhookDeletionSummary(ctx context.Context, mediaItems []arr.MediaItem) error {
+	if e.webhook == nil || !e.cfg.Webhook.Events.Includes(config.NotificationEventDeletionSummary) {
+		log.FromContext(ctx).Debug("Webhook not configured or deletion summary notifications disabled, skipping")
+		return nil
+	}
+
+	if len(mediaItems) == 0 {
+		log.FromContext(ctx).Debug("No media items marked for deletion")
+		return nil
+	}
+
+	libraries := make(map[string][]webhook.MediaItem)
+	for _, item := range mediaItems {
+		libraries[item.LibraryName] = append(libraries[item.LibraryName], toWebhookMediaItem(item))
+	}
+
+	if err := e.webhook.SendDeletionSummary(ctx, len(mediaItems), libraries); err != nil {
+		return fmt.Errorf("failed to send webhook deletion summary notification: %w", err)
+	}
+
+	log.FromContext(ctx).Info("sent webhook deletion summary notification", "items", len(mediaItems), "libraries", len(libraries))
+	return nil
+}
+
+// sendWebhookDeletionCompletedNotification sends a webhook notification about media that was actually deleted.
+func (e *Engine) sendWebhookDeletionCompletedNotification(ctx context.Context, deletedItems map[string][]arr.MediaItem) error {
+	if e.webhook == nil || !e.cfg.Webhook.Events.Includes(config.NotificationEventDeletionCompleted) {
+		log.FromContext(ctx).Debug("Webhook not configured or deletion completed notifications disabled, skipping")
+		return nil
+	}
+
+	totalItems := 0
+	libraries := make(map[string][]webhook.MediaItem)
+	for library, items := range deletedItems {
+		for _, item := range items {
+			libraries[library] = append(libraries[library], toWebhookMediaItem(item))
+		}
+		totalItems += len(items)
+	}
+
+	if totalItems == 0 {
+		log.FromContext(ctx).Debug("No media items were deleted")
+		return nil
+	}
+
+	if err := e.webhook.SendDeletionCompleted(ctx, totalItems, libraries); err != nil {
+		return fmt.Errorf("failed to send webhook deletion completed notification: %w", err)
+	}
+
+	log.FromContext(ctx).Info("sent webhook deletion completed notification", "items", totalItems, "libraries", len(libraries))
+	return nil
+}
+
+func toWebhookMediaItem(item arr.MediaItem) webhook.MediaItem {
+	return webhook.MediaItem{
+		Title:   item.Title,
+		Type:    string(item.MediaType),
+		Year:    item.Year,
+		Library: item.LibraryName,
+	}
+}
@@ -0,0 +1,130 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+)
+
+// OrphanReport describes an arr item that carries jellysweep tags but has no media item in the database.
+type OrphanReport struct {
+	// Service is the arr the item belongs to, "sonarr" or "radarr".
+	Service string   `json:"service"`
+	ArrID   int32    `json:"arrId"`
+	Title   string   `json:"title"`
+	Tags    []string `json:"tags"`
+}
+
+// tagCleanerTarget is an arr that supports per item tag cleanup, with the media type it manages.
+type tagCleanerTarget struct {
+	service   string
+	cleaner   arr.TagCleaner
+	mediaType database.MediaType
+}
+
+// tagCleaners returns the configured arrs that support per item tag cleanup.
+// Readarr isn't included, since it tags authors instead of single books.
+func (e *Engine) tagCleaners() []tagCleanerTarget {
+	var targets []tagCleanerTarget
+	if c, ok := e.sonarr.(arr.TagCleaner); ok {
+		targets = append(targets, tagCleanerTarget{service: "sonarr", cleaner: c, mediaType: database.MediaTypeTV})
+	}
+	if c, ok := e.radarr.(arr.TagCleaner); ok {
+		targets = append(targets, tagCleanerTarget{service: "radarr", cleaner: c, mediaType: database.MediaTypeMovie})
+	}
+	return targets
+}
+
+// FindOrphanedTags lists the Sonarr and Radarr items that carry jellysweep tags without a corresponding media item in the database.
+// These are usually leftovers of the tag based system. The ignore tag is never reported, since it's still in use.
+func (e *Engine) FindOrphanedTags(ctx context.Context) ([]OrphanReport, error) {
+	e.clientsMu.RLock()
+	defer e.clientsMu.RUnlock()
+	return e.findOrphanedTags(ctx)
+}
+
+// findOrphanedTags implements FindOrphanedTags, the caller must hold the clients lock.
+func (e *Engine) findOrphanedTags(ctx context.Context) ([]OrphanReport, error) {
+	targets := e.tagCleaners()
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no Sonarr or Radarr client configured, cannot check tags")
+	}
+
+	mediaItems, err := e.db.GetMediaItems(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media items: %w", err)
+	}
+
+	reports := make([]OrphanReport, 0)
+	for _, target := range targets {
+		known := make(map[int32]bool)
+		for _, m := range mediaItems {
+			if m.MediaType == target.mediaType {
+				known[m.ArrID] = true
+			}
+		}
+
+		items, err := target.cleaner.GetTaggedItems(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tagged %s items: %w", target.service, err)
+		}
+
+		reports = append(reports, orphanedItems(target.service, items, known)...)
+	}
+
+	return reports, nil
+}
+
+// orphanedItems returns the reports of the tagged items whose arr ID isn't known.
+func orphanedItems(service string, items []arr.TaggedItem, known map[int32]bool) []OrphanReport {
+	var reports []OrphanReport
+	for _, item := range items {
+		if known[item.ID] {
+			continue
+		}
+		reports = append(reports, OrphanReport{
+			Service: service,
+			ArrID:   item.ID,
+			Title:   item.Title,
+			Tags:    item.Tags,
+		})
+	}
+	return reports
+}
+
+// CleanOrphanedTags removes the jellysweep tags from all items reported by FindOrphanedTags.
+// It returns the cleaned items. Items that fail are logged and skipped.
+// It's rejected with ErrMaintenanceMode while the maintenance mode is enabled.
+func (e *Engine) CleanOrphanedTags(ctx context.Context) ([]OrphanReport, error) {
+	if e.MaintenanceMode() {
+		return nil, ErrMaintenanceMode
+	}
+
+	e.clientsMu.RLock()
+	defer e.clientsMu.RUnlock()
+
+	orphans, err := e.findOrphanedTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cleaners := make(map[string]arr.TagCleaner)
+	for _, target := range e.tagCleaners() {
+		cleaners[target.service] = target.cleaner
+	}
+
+	cleaned := make([]OrphanReport, 0, len(orphans))
+	for _, orphan := range orphans {
+		if err := cleaners[orphan.Service].RemoveJellysweepTags(ctx, orphan.ArrID); err != nil {
+			log.FromContext(ctx).Error("failed to remove orphaned jellysweep tags", "service", orphan.Service, "title", orphan.Title, "error", err)
+			continue
+		}
+		cleaned = append(cleaned, orphan)
+	}
+
+	log.FromContext(ctx).Info("removed orphaned jellysweep tags", "count", len(cleaned), "orphans", len(orphans))
+	return cleaned, nil
+}
@@ -0,0 +1,129 @@
+package plex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jon4hz/jellysweep/internal/engine/stats"
+)
+
+var _ stats.Statser = (*Client)(nil)
+
+// historyTTL is how long the fetched play history is reused, so the per item lookups of a run share one fetch.
+const historyTTL = time.Minute
+
+// historyEntry is a single play in the Plex history.
+type historyEntry struct {
+	RatingKey            jsonString `json:"ratingKey"`
+	ParentRatingKey      jsonString `json:"parentRatingKey"`
+	GrandparentRatingKey jsonString `json:"grandparentRatingKey"`
+	ViewedAt             int64      `json:"viewedAt"`
+}
+
+// historyContainer is the response of the play history endpoint.
+type historyContainer struct {
+	MediaContainer struct {
+		TotalSize int            `json:"totalSize"`
+		Metadata  []historyEntry `json:"Metadata"`
+	} `json:"MediaContainer"`
+}
+
+// playStats are the plays of an item, including the plays of its seasons and episodes.
+type playStats struct {
+	lastPlayed time.Time
+	count      int
+}
+
+// GetItemLastPlayed returns when any Plex user last played the item, zero if it was never played.
+// The play of an episode counts for its season and series.
+func (c *Client) GetItemLastPlayed(ctx context.Context, itemID string) (time.Time, error) {
+	history, err := c.getHistory(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return history[itemID].lastPlayed, nil
+}
+
+// GetItemsLastPlayed returns when any Plex user last played the items. Items that were never played are missing.
+func (c *Client) GetItemsLastPlayed(ctx context.Context, itemIDs []string) (map[string]time.Time, error) {
+	history, err := c.getHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]time.Time, len(itemIDs))
+	for _, itemID := range itemIDs {
+		if played, ok := history[itemID]; ok {
+			result[itemID] = played.lastPlayed
+		}
+	}
+	return result, nil
+}
+
+// GetItemTotalPlayCount returns how often any Plex user played the item.
+func (c *Client) GetItemTotalPlayCount(ctx context.Context, itemID string) (int, error) {
+	history, err := c.getHistory(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return history[itemID].count, nil
+}
+
+// getHistory returns the plays of all items by their rating key, fetching the play history of all users if the cached one is outdated.
+func (c *Client) getHistory(ctx context.Context) (map[string]playStats, error) {
+	c.historyMu.Lock()
+	defer c.historyMu.Unlock()
+
+	if c.history != nil && time.Since(c.historyFetched) < historyTTL {
+		return c.history, nil
+	}
+
+	history := make(map[string]playStats)
+	add := func(key jsonString, viewedAt time.Time) {
+		if key == "" {
+			return
+		}
+		played := history[string(key)]
+		played.count++
+		if viewedAt.After(played.lastPlayed) {
+			played.lastPlayed = viewedAt
+		}
+		history[string(key)] = played
+	}
+
+	start := 0
+	const limit = 1000
+	for {
+		query := url.Values{}
+		query.Set("sort", "viewedAt:desc")
+		query.Set("X-Plex-Container-Start", strconv.Itoa(start))
+		query.Set("X-Plex-Container-Size", strconv.Itoa(limit))
+
+		var resp historyContainer
+		if err := c.do(ctx, http.MethodGet, "/status/sessions/history/all", query, &resp); err != nil {
+			return nil, fmt.Errorf("failed to get play history: %w", err)
+		}
+		entries := resp.MediaContainer.Metadata
+		if len(entries) == 0 {
+			break
+		}
+
+		for _, entry := range entries {
+			viewedAt := time.Unix(entry.ViewedAt, 0)
+			add(entry.RatingKey, viewedAt)
+			add(entry.ParentRatingKey, viewedAt)
+			add(entry.GrandparentRatingKey, viewedAt)
+		}
+		start += len(entries)
+		if start >= resp.MediaContainer.TotalSize {
+			break
+		}
+	}
+
+	c.history = history
+	c.historyFetched = time.Now()
+	return history, nil
+}
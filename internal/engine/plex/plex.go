@@ -0,0 +1,687 @@
+package plex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	jellyfinImpl "github.com/jon4hz/jellysweep/internal/engine/jellyfin"
+	"github.com/jon4hz/jellysweep/internal/engine/mediaserver"
+	"github.com/jon4hz/jellysweep/internal/version"
+	jellyfin "github.com/sj14/jellyfin-go/api"
+)
+
+var _ mediaserver.MediaServer = (*Client)(nil)
+
+// batchSize is the number of item IDs sent per request to avoid URL length limitations.
+const batchSize = 50
+
+// Client provides a high-level interface for interacting with Plex.
+//
+// Items are identified by their Plex rating key. It is stored in the JellyfinID of the media items,
+// which holds the item ID of whichever media server is configured.
+//
+// The play history of all Plex users is used as the stats backend, since Jellystat and Streamystats only know Jellyfin items.
+//
+// Plex collections belong to a single library section, so a collection is identified by its name.
+// If the items of a collection span multiple sections, a collection with the same name is managed in each of them.
+type Client struct {
+	baseURL    string
+	token      string
+	cfg        *config.Config
+	httpClient *http.Client
+
+	// machineIdentifier is the identifier of the Plex server, required to reference items in collections.
+	machineIdentifier string
+
+	historyMu      sync.Mutex
+	history        map[string]playStats
+	historyFetched time.Time
+}
+
+// mediaContainer is the envelope of all Plex API responses.
+type mediaContainer struct {
+	MediaContainer struct {
+		MachineIdentifier string      `json:"machineIdentifier"`
+		TotalSize         int         `json:"totalSize"`
+		Directory         []directory `json:"Directory"`
+		Metadata          []metadata  `json:"Metadata"`
+	} `json:"MediaContainer"`
+}
+
+// directory is a Plex library section.
+type directory struct {
+	Key      string     `json:"key"`
+	Title    string     `json:"title"`
+	Type     string     `json:"type"`
+	Location []location `json:"Location"`
+}
+
+type location struct {
+	Path string `json:"path"`
+}
+
+// metadata is the subset of a Plex metadata item used by jellysweep.
+type metadata struct {
+	RatingKey        string     `json:"ratingKey"`
+	Title            string     `json:"title"`
+	Type             string     `json:"type"`
+	Year             int32      `json:"year"`
+	AddedAt          int64      `json:"addedAt"`
+	Index            int32      `json:"index"`
+	ParentIndex      int32      `json:"parentIndex"`
+	LibrarySectionID jsonString `json:"librarySectionID"`
+	GUIDs            []guid     `json:"Guid"`
+	Media            []media    `json:"Media"`
+	Location         []location `json:"Location"`
+	Labels           []tag      `json:"Label"`
+}
+
+type guid struct {
+	ID string `json:"id"`
+}
+
+type media struct {
+	Part []part `json:"Part"`
+}
+
+type part struct {
+	File string `json:"file"`
+}
+
+type tag struct {
+	Tag string `json:"tag"`
+}
+
+// jsonString accepts both JSON strings and numbers, since Plex isn't consistent about the type of IDs.
+type jsonString string
+
+func (s *jsonString) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*s = ""
+		return nil
+	}
+	*s = jsonString(strings.Trim(string(data), `"`))
+	return nil
+}
+
+// New creates a new Plex client with the given configuration.
+func New(cfg *config.Config) *Client {
+	return &Client{
+		baseURL:    cfg.Plex.URL,
+		token:      cfg.Plex.Token,
+		cfg:        cfg,
+		httpClient: config.NewHTTPClient(cfg.Plex.Timeout, cfg.Proxy, cfg.Plex.Proxy),
+	}
+}
+
+// do sends a request to the Plex API and decodes the JSON response into out (if not nil).
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, out any) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Plex-Token", c.token)
+	req.Header.Set("X-Plex-Product", "Jellysweep")
+	req.Header.Set("X-Plex-Client-Identifier", "jellysweep")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("Jellysweep/%s", version.Version))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("plex returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// GetJellyfinItems retrieves all media items from enabled Plex libraries.
+// The items are converted to the jellyfin representation so they can be processed by the rest of the engine.
+func (c *Client) GetJellyfinItems(ctx context.Context) ([]arr.JellyfinItem, map[string][]string, error) {
+	sections, err := c.getSections(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(sections) == 0 {
+		return nil, nil, fmt.Errorf("no library sections found")
+	}
+
+	var allItems []arr.JellyfinItem
+	libraryFoldersMap := make(map[string][]string)
+	for _, section := range sections {
+		libraryConfig := c.cfg.GetLibraryConfig(section.Title)
+		if libraryConfig == nil || !libraryConfig.Enabled {
+			log.Debug("Skipping disabled library", "library", section.Title)
+			continue
+		}
+
+		for _, loc := range section.Location {
+			libraryFoldersMap[section.Title] = append(libraryFoldersMap[section.Title], loc.Path)
+		}
+
+		log.Info("Processing library", "library", section.Title, "id", section.Key)
+
+		libraryItems, err := c.getSectionItems(ctx, section.Key)
+		if err != nil {
+			log.Error("Failed to get items from library", "library", section.Title, "error", err)
+			continue
+		}
+
+		for _, it := range libraryItems {
+			allItems = append(allItems, arr.JellyfinItem{
+				BaseItemDto:       it.toJellyfin(),
+				ParentLibraryName: section.Title,
+			})
+		}
+		log.Info("Retrieved all items from library", "library", section.Title, "total", len(libraryItems))
+	}
+
+	return allItems, libraryFoldersMap, nil
+}
+
+// getSections returns all movie and show library sections.
+func (c *Client) getSections(ctx context.Context) ([]directory, error) {
+	var resp mediaContainer
+	if err := c.do(ctx, http.MethodGet, "/library/sections", nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get library sections: %w", err)
+	}
+
+	sections := make([]directory, 0, len(resp.MediaContainer.Directory))
+	for _, section := range resp.MediaContainer.Directory {
+		if section.Type != "movie" && section.Type != "show" {
+			continue
+		}
+		sections = append(sections, section)
+	}
+	return sections, nil
+}
+
+// getSectionItems retrieves all items of a library section, paginating through the results.
+func (c *Client) getSectionItems(ctx context.Context, sectionKey string) ([]metadata, error) {
+	var allItems []metadata
+
+	start := 0
+	const limit = 1000
+	for {
+		query := url.Values{}
+		query.Set("includeGuids", "1")
+		query.Set("X-Plex-Container-Start", strconv.Itoa(start))
+		query.Set("X-Plex-Container-Size", strconv.Itoa(limit))
+
+		var resp mediaContainer
+		if err := c.do(ctx, http.MethodGet, "/library/sections/"+url.PathEscape(sectionKey)+"/all", query, &resp); err != nil {
+			return nil, fmt.Errorf("failed to get items: %w", err)
+		}
+		items := resp.MediaContainer.Metadata
+		if len(items) == 0 {
+			break
+		}
+
+		allItems = append(allItems, items...)
+		start += len(items)
+		if start >= resp.MediaContainer.TotalSize {
+			break
+		}
+	}
+
+	return allItems, nil
+}
+
+// toJellyfin converts the plex item to a jellyfin BaseItemDto.
+func (m metadata) toJellyfin() jellyfin.BaseItemDto {
+	dto := jellyfin.BaseItemDto{}
+	dto.SetId(m.RatingKey)
+	dto.SetName(m.Title)
+	dto.SetProductionYear(m.Year)
+	dto.SetIndexNumber(m.Index)
+	dto.SetParentIndexNumber(m.ParentIndex)
+	if path := m.path(); path != "" {
+		dto.SetPath(path)
+	}
+	if m.AddedAt > 0 {
+		dto.SetDateCreated(time.Unix(m.AddedAt, 0))
+	}
+	if providerIDs := m.providerIDs(); len(providerIDs) > 0 {
+		dto.SetProviderIds(providerIDs)
+	}
+
+	tags := make([]string, 0, len(m.Labels))
+	for _, label := range m.Labels {
+		tags = append(tags, label.Tag)
+	}
+	dto.SetTags(tags)
+
+	switch m.Type {
+	case "movie":
+		dto.SetType(jellyfin.BASEITEMKIND_MOVIE)
+	case "show":
+		dto.SetType(jellyfin.BASEITEMKIND_SERIES)
+	case "season":
+		dto.SetType(jellyfin.BASEITEMKIND_SEASON)
+	case "episode":
+		dto.SetType(jellyfin.BASEITEMKIND_EPISODE)
+	case "collection":
+		dto.SetType(jellyfin.BASEITEMKIND_BOX_SET)
+	}
+
+	return dto
+}
+
+// path returns the file path of a movie or the folder of a show.
+func (m metadata) path() string {
+	if len(m.Location) > 0 {
+		return m.Location[0].Path
+	}
+	for _, md := range m.Media {
+		for _, p := range md.Part {
+			if p.File != "" {
+				return p.File
+			}
+		}
+	}
+	return ""
+}
+
+// providerIDs converts the plex guids (e.g. "tmdb://123") to the provider IDs used by jellyfin.
+func (m metadata) providerIDs() map[string]string {
+	providerIDs := make(map[string]string)
+	for _, g := range m.GUIDs {
+		provider, id, ok := strings.Cut(g.ID, "://")
+		if !ok || id == "" {
+			continue
+		}
+		switch provider {
+		case "tmdb":
+			providerIDs["Tmdb"] = id
+		case "tvdb":
+			providerIDs["Tvdb"] = id
+		case "imdb":
+			providerIDs["Imdb"] = id
+		}
+	}
+	return providerIDs
+}
+
+// RemoveItem removes an item from Plex by its rating key.
+// This requires "Allow media deletion" to be enabled in the Plex server settings.
+func (c *Client) RemoveItem(ctx context.Context, itemID string) error {
+	if err := c.do(ctx, http.MethodDelete, "/library/metadata/"+url.PathEscape(itemID), nil, nil); err != nil {
+		return fmt.Errorf("failed to remove item %s: %w", itemID, err)
+	}
+	return nil
+}
+
+// RemoveItemWithCleanupMode removes an item from Plex according to the cleanup mode.
+// For movies or "all" mode, it removes the entire item.
+// For TV series with "keep_episodes", "keep_seasons" or "keep_latest_episodes" mode, it removes specific episodes.
+func (c *Client) RemoveItemWithCleanupMode(ctx context.Context, itemID, title string, itemType jellyfin.BaseItemKind, cleanupMode config.CleanupMode, keepCount int) error {
+	if itemType == jellyfin.BASEITEMKIND_MOVIE || itemType == jellyfin.BASEITEMKIND_BOOK || cleanupMode == config.CleanupModeAll {
+		if err := c.RemoveItem(ctx, itemID); err != nil {
+			return err
+		}
+		log.Info("removed entire item from Plex", "title", title)
+		return nil
+	}
+
+	if itemType != jellyfin.BASEITEMKIND_SERIES {
+		return fmt.Errorf("unsupported item type for cleanup mode %s: %s", cleanupMode, itemType)
+	}
+
+	var resp mediaContainer
+	if err := c.do(ctx, http.MethodGet, "/library/metadata/"+url.PathEscape(itemID)+"/allLeaves", nil, &resp); err != nil {
+		return fmt.Errorf("failed to get episodes for series %s: %w", title, err)
+	}
+
+	episodes := make([]jellyfin.BaseItemDto, 0, len(resp.MediaContainer.Metadata))
+	for _, ep := range resp.MediaContainer.Metadata {
+		// Skip specials
+		if ep.ParentIndex == 0 {
+			continue
+		}
+		episodes = append(episodes, ep.toJellyfin())
+	}
+
+	episodesToKeep := jellyfinImpl.FilterEpisodesToKeep(episodes, title, cleanupMode, keepCount)
+	var deleted int
+	for _, episode := range episodes {
+		if slices.Contains(episodesToKeep, episode.GetId()) {
+			continue
+		}
+		if err := c.RemoveItem(ctx, episode.GetId()); err != nil {
+			return fmt.Errorf("failed to delete episode: %w", err)
+		}
+		deleted++
+	}
+
+	log.Info("deleted episodes from Plex series", "title", title, "deleted", deleted, "kept", len(episodesToKeep))
+	return nil
+}
+
+// getCollections returns all collections of all library sections, keyed by the section key.
+func (c *Client) getCollections(ctx context.Context) (map[string][]metadata, error) {
+	sections, err := c.getSections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	collections := make(map[string][]metadata, len(sections))
+	for _, section := range sections {
+		var resp mediaContainer
+		if err := c.do(ctx, http.MethodGet, "/library/sections/"+url.PathEscape(section.Key)+"/collections", nil, &resp); err != nil {
+			return nil, fmt.Errorf("failed to get collections of library %s: %w", section.Title, err)
+		}
+		collections[section.Key] = resp.MediaContainer.Metadata
+	}
+	return collections, nil
+}
+
+// getCollectionsByName returns the rating keys of the collections with the given name, keyed by the section key.
+func (c *Client) getCollectionsByName(ctx context.Context, name string) (map[string]string, error) {
+	collections, err := c.getCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]string)
+	for sectionKey, sectionCollections := range collections {
+		for _, collection := range sectionCollections {
+			if collection.Title == name {
+				byName[sectionKey] = collection.RatingKey
+				break
+			}
+		}
+	}
+	return byName, nil
+}
+
+// getChildren returns the rating keys of the items in a collection.
+func (c *Client) getChildren(ctx context.Context, collectionKey string) ([]string, error) {
+	var resp mediaContainer
+	if err := c.do(ctx, http.MethodGet, "/library/collections/"+url.PathEscape(collectionKey)+"/children", nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get collection items: %w", err)
+	}
+
+	ids := make([]string, 0, len(resp.MediaContainer.Metadata))
+	for _, it := range resp.MediaContainer.Metadata {
+		ids = append(ids, it.RatingKey)
+	}
+	return ids, nil
+}
+
+// FindCollectionByName searches for a collection by name.
+// Since plex collections are bound to a library section, the name is used as the collection ID.
+func (c *Client) FindCollectionByName(ctx context.Context, name string) (string, error) {
+	byName, err := c.getCollectionsByName(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if len(byName) == 0 {
+		return "", nil // Collection not found
+	}
+	return name, nil
+}
+
+// GetCollectionItems returns a map of item IDs currently in the collection.
+func (c *Client) GetCollectionItems(ctx context.Context, collectionID string) (map[string]bool, error) {
+	byName, err := c.getCollectionsByName(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentItems := make(map[string]bool)
+	for _, collectionKey := range byName {
+		ids, err := c.getChildren(ctx, collectionKey)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			currentItems[id] = true
+		}
+	}
+
+	return currentItems, nil
+}
+
+// GetCollectionMembership returns the names of the collections each of the given items belongs to.
+func (c *Client) GetCollectionMembership(ctx context.Context, itemIDs []string) (map[string][]string, error) {
+	wanted := make(map[string]bool, len(itemIDs))
+	for _, id := range itemIDs {
+		wanted[id] = true
+	}
+
+	collections, err := c.getCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	membership := make(map[string][]string)
+	for _, sectionCollections := range collections {
+		for _, collection := range sectionCollections {
+			ids, err := c.getChildren(ctx, collection.RatingKey)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get items of collection %s: %w", collection.Title, err)
+			}
+			for _, id := range ids {
+				if wanted[id] {
+					membership[id] = append(membership[id], collection.Title)
+				}
+			}
+		}
+	}
+
+	return membership, nil
+}
+
+// GetFavoritedBy always fails, since Plex has no favorites.
+// The config validation rejects protect_favorites with Plex, so this is never called by the favorites filter.
+func (c *Client) GetFavoritedBy(ctx context.Context, itemIDs []string) (map[string][]string, error) {
+	return nil, errors.New("plex has no favorites")
+}
+
+// GetInProgressBy always fails, the resume positions of the Plex users aren't looked up.
+// The config validation rejects protect_if_in_progress_by_any_user with Plex, so this is never called by the in progress filter.
+func (c *Client) GetInProgressBy(ctx context.Context, itemIDs []string) (map[string][]string, error) {
+	return nil, errors.New("resume positions aren't supported for plex")
+}
+
+// GetLastPlayedByAnyUser always returns an empty map, the play history of the Plex users isn't looked up.
+func (c *Client) GetLastPlayedByAnyUser(ctx context.Context, itemIDs []string) (map[string]time.Time, error) {
+	log.Debug("Per user play history isn't supported for Plex, skipping last played lookup")
+	return map[string]time.Time{}, nil
+}
+
+// CreateCollection creates a new collection with the given name and item IDs.
+func (c *Client) CreateCollection(ctx context.Context, name string, itemIDs []string) error {
+	return c.AddItemsToCollection(ctx, name, itemIDs)
+}
+
+// AddItemsToCollection adds items to an existing collection.
+// The items are added to the collection of their library section, which is created if it doesn't exist yet.
+func (c *Client) AddItemsToCollection(ctx context.Context, collectionID string, itemIDs []string) error {
+	byName, err := c.getCollectionsByName(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+
+	itemsBySection, err := c.getItemSections(ctx, itemIDs)
+	if err != nil {
+		return err
+	}
+
+	for sectionKey, sectionItems := range itemsBySection {
+		ids := sectionItems.ids
+		collectionKey, ok := byName[sectionKey]
+		if !ok {
+			collectionKey, err = c.createSectionCollection(ctx, collectionID, sectionKey, sectionItems.collectionType, ids[:min(batchSize, len(ids))])
+			if err != nil {
+				return err
+			}
+			ids = ids[min(batchSize, len(ids)):]
+		}
+
+		for batch := range slices.Chunk(ids, batchSize) {
+			uri, err := c.itemsURI(ctx, batch)
+			if err != nil {
+				return err
+			}
+			query := url.Values{}
+			query.Set("uri", uri)
+			if err := c.do(ctx, http.MethodPut, "/library/collections/"+url.PathEscape(collectionKey)+"/items", query, nil); err != nil {
+				return fmt.Errorf("failed to add items to collection %s: %w", collectionID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RemoveItemsFromCollection removes items from an existing collection.
+func (c *Client) RemoveItemsFromCollection(ctx context.Context, collectionID string, itemIDs []string) error {
+	byName, err := c.getCollectionsByName(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+
+	for _, collectionKey := range byName {
+		children, err := c.getChildren(ctx, collectionKey)
+		if err != nil {
+			return err
+		}
+		for _, id := range itemIDs {
+			if !slices.Contains(children, id) {
+				continue
+			}
+			if err := c.do(ctx, http.MethodDelete, "/library/collections/"+url.PathEscape(collectionKey)+"/items/"+url.PathEscape(id), nil, nil); err != nil {
+				return fmt.Errorf("failed to remove item %s from collection %s: %w", id, collectionID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RemoveItemFromCollection removes a single item from the collections with the given name in all library sections.
+func (c *Client) RemoveItemFromCollection(ctx context.Context, collectionName, itemID string) error {
+	return c.RemoveItemsFromCollection(ctx, collectionName, []string{itemID})
+}
+
+// SetCollectionOverview sets the summary of the collections with the given name in all library sections.
+func (c *Client) SetCollectionOverview(ctx context.Context, collectionID, overview string) error {
+	byName, err := c.getCollectionsByName(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+
+	for sectionKey, collectionKey := range byName {
+		query := url.Values{}
+		query.Set("type", "18") // collection
+		query.Set("id", collectionKey)
+		query.Set("summary.value", overview)
+		query.Set("summary.locked", "1")
+		if err := c.do(ctx, http.MethodPut, "/library/sections/"+url.PathEscape(sectionKey)+"/all", query, nil); err != nil {
+			return fmt.Errorf("failed to update summary of collection %s: %w", collectionID, err)
+		}
+	}
+	return nil
+}
+
+// sectionItems holds the items of a library section and the plex collection type matching them.
+type sectionItems struct {
+	collectionType string
+	ids            []string
+}
+
+// getItemSections groups the items by their library section.
+func (c *Client) getItemSections(ctx context.Context, itemIDs []string) (map[string]*sectionItems, error) {
+	bySection := make(map[string]*sectionItems)
+	for batch := range slices.Chunk(itemIDs, batchSize) {
+		var resp mediaContainer
+		if err := c.do(ctx, http.MethodGet, "/library/metadata/"+strings.Join(batch, ","), nil, &resp); err != nil {
+			return nil, fmt.Errorf("failed to get item metadata: %w", err)
+		}
+		for _, it := range resp.MediaContainer.Metadata {
+			sectionKey := string(it.LibrarySectionID)
+			if sectionKey == "" {
+				log.Warn("Plex item has no library section, skipping", "id", it.RatingKey, "title", it.Title)
+				continue
+			}
+			if _, ok := bySection[sectionKey]; !ok {
+				collectionType := "1" // movie
+				if it.Type == "show" {
+					collectionType = "2"
+				}
+				bySection[sectionKey] = &sectionItems{collectionType: collectionType}
+			}
+			bySection[sectionKey].ids = append(bySection[sectionKey].ids, it.RatingKey)
+		}
+	}
+	return bySection, nil
+}
+
+// createSectionCollection creates a collection in the given library section and returns its rating key.
+func (c *Client) createSectionCollection(ctx context.Context, name, sectionKey, collectionType string, itemIDs []string) (string, error) {
+	uri, err := c.itemsURI(ctx, itemIDs)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("type", collectionType)
+	query.Set("title", name)
+	query.Set("smart", "0")
+	query.Set("sectionId", sectionKey)
+	query.Set("uri", uri)
+
+	var resp mediaContainer
+	if err := c.do(ctx, http.MethodPost, "/library/collections", query, &resp); err != nil {
+		return "", fmt.Errorf("failed to create collection %s: %w", name, err)
+	}
+	if len(resp.MediaContainer.Metadata) == 0 {
+		return "", fmt.Errorf("plex didn't return the created collection %s", name)
+	}
+	return resp.MediaContainer.Metadata[0].RatingKey, nil
+}
+
+// itemsURI returns the library URI referencing the given items, as expected by the collection endpoints.
+func (c *Client) itemsURI(ctx context.Context, itemIDs []string) (string, error) {
+	if c.machineIdentifier == "" {
+		var resp mediaContainer
+		if err := c.do(ctx, http.MethodGet, "/identity", nil, &resp); err != nil {
+			return "", fmt.Errorf("failed to get server identity: %w", err)
+		}
+		c.machineIdentifier = resp.MediaContainer.MachineIdentifier
+	}
+	return fmt.Sprintf("server://%s/com.plexapp.plugins.library/library/metadata/%s", c.machineIdentifier, strings.Join(itemIDs, ",")), nil
+}
+
+// Ping checks whether Plex is reachable by requesting the server identity.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.do(ctx, http.MethodGet, "/identity", nil, nil)
+}
@@ -0,0 +1,240 @@
+package plex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	jellyfin "github.com/sj14/jellyfin-go/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testServer is a fake Plex server with a movie library, a show library and a music library.
+type testServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	deleted []string
+}
+
+// movieCount is the number of movies in the movie library, more than fit on one page.
+const movieCount = 1500
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	ts := &testServer{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /library/sections", func(w http.ResponseWriter, r *http.Request) {
+		writeContainer(w, map[string]any{"Directory": []map[string]any{
+			{"key": "1", "title": "Movies", "type": "movie", "Location": []map[string]any{{"path": "/media/movies"}, {"path": "/media/movies-4k"}}},
+			{"key": "2", "title": "TV Shows", "type": "show", "Location": []map[string]any{{"path": "/media/tv"}}},
+			{"key": "3", "title": "Music", "type": "artist", "Location": []map[string]any{{"path": "/media/music"}}},
+		}})
+	})
+	mux.HandleFunc("GET /library/sections/1/all", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1", r.URL.Query().Get("includeGuids"))
+		start, _ := strconv.Atoi(r.URL.Query().Get("X-Plex-Container-Start"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("X-Plex-Container-Size"))
+		items := []map[string]any{}
+		for i := start; i < min(start+size, movieCount); i++ {
+			items = append(items, map[string]any{"ratingKey": strconv.Itoa(1000 + i), "title": "Movie " + strconv.Itoa(i), "type": "movie"})
+		}
+		if start == 0 {
+			items[0] = map[string]any{
+				"ratingKey":        "1000",
+				"title":            "Heat",
+				"type":             "movie",
+				"year":             1995,
+				"addedAt":          1700000000,
+				"librarySectionID": 1,
+				"Guid":             []map[string]any{{"id": "tmdb://949"}, {"id": "imdb://tt0113277"}, {"id": "local://1000"}},
+				"Media":            []map[string]any{{"Part": []map[string]any{{"file": "/media/movies/Heat (1995)/Heat.mkv"}}}},
+				"Label":            []map[string]any{{"tag": "keep"}},
+			}
+		}
+		writeContainer(w, map[string]any{"totalSize": movieCount, "Metadata": items})
+	})
+	mux.HandleFunc("GET /library/sections/2/all", func(w http.ResponseWriter, r *http.Request) {
+		writeContainer(w, map[string]any{"totalSize": 1, "Metadata": []map[string]any{{
+			"ratingKey":        "2000",
+			"title":            "The Wire",
+			"type":             "show",
+			"year":             2002,
+			"librarySectionID": "2",
+			"Guid":             []map[string]any{{"id": "tvdb://79126"}, {"id": "tmdb://1438"}},
+			"Location":         []map[string]any{{"path": "/media/tv/The Wire"}},
+		}}})
+	})
+	mux.HandleFunc("GET /library/metadata/2000/allLeaves", func(w http.ResponseWriter, r *http.Request) {
+		writeContainer(w, map[string]any{"Metadata": []map[string]any{
+			{"ratingKey": "2100", "type": "episode", "parentIndex": 0, "index": 1},
+			{"ratingKey": "2101", "type": "episode", "parentIndex": 1, "index": 1},
+			{"ratingKey": "2102", "type": "episode", "parentIndex": 1, "index": 2},
+			{"ratingKey": "2201", "type": "episode", "parentIndex": 2, "index": 1},
+		}})
+	})
+	mux.HandleFunc("DELETE /library/metadata/{id}", func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		defer ts.mu.Unlock()
+		ts.deleted = append(ts.deleted, r.PathValue("id"))
+	})
+
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Plex-Token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func writeContainer(w http.ResponseWriter, container map[string]any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"MediaContainer": container})
+}
+
+func newTestClient(ts *testServer, libraries ...string) *Client {
+	cfg := &config.Config{
+		Plex:      &config.PlexConfig{URL: ts.URL, Token: "token"},
+		Libraries: make(map[string]*config.CleanupConfig),
+	}
+	for _, library := range libraries {
+		cfg.Libraries[library] = &config.CleanupConfig{Enabled: true}
+	}
+	return New(cfg)
+}
+
+func TestGetJellyfinItems(t *testing.T) {
+	ts := newTestServer(t)
+	client := newTestClient(ts, "Movies", "TV Shows", "Music")
+
+	items, folders, err := client.GetJellyfinItems(context.Background())
+	require.NoError(t, err)
+	require.Len(t, items, movieCount+1, "all pages of the movie library and the show are returned, the music library is skipped")
+
+	assert.Equal(t, map[string][]string{
+		"Movies":   {"/media/movies", "/media/movies-4k"},
+		"TV Shows": {"/media/tv"},
+	}, folders)
+
+	movie := items[0]
+	assert.Equal(t, "Movies", movie.ParentLibraryName)
+	assert.Equal(t, "1000", movie.GetId(), "the rating key is used as item ID")
+	assert.Equal(t, "Heat", movie.GetName())
+	assert.Equal(t, int32(1995), movie.GetProductionYear())
+	assert.Equal(t, jellyfin.BASEITEMKIND_MOVIE, movie.GetType())
+	assert.Equal(t, "/media/movies/Heat (1995)/Heat.mkv", movie.GetPath())
+	assert.Equal(t, time.Unix(1700000000, 0), movie.GetDateCreated())
+	assert.Equal(t, map[string]string{"Tmdb": "949", "Imdb": "tt0113277"}, movie.GetProviderIds())
+	assert.Equal(t, []string{"keep"}, movie.GetTags())
+
+	show := items[len(items)-1]
+	assert.Equal(t, "TV Shows", show.ParentLibraryName)
+	assert.Equal(t, "2000", show.GetId())
+	assert.Equal(t, jellyfin.BASEITEMKIND_SERIES, show.GetType())
+	assert.Equal(t, "/media/tv/The Wire", show.GetPath(), "the folder of a show is used as path")
+	assert.Equal(t, map[string]string{"Tvdb": "79126", "Tmdb": "1438"}, show.GetProviderIds())
+}
+
+func TestGetJellyfinItemsDisabledLibrary(t *testing.T) {
+	ts := newTestServer(t)
+	client := newTestClient(ts, "TV Shows")
+
+	items, folders, err := client.GetJellyfinItems(context.Background())
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "The Wire", items[0].GetName())
+	assert.NotContains(t, folders, "Movies")
+}
+
+func TestGetJellyfinItemsUnauthorized(t *testing.T) {
+	ts := newTestServer(t)
+	client := newTestClient(ts, "Movies")
+	client.token = "wrong"
+
+	_, _, err := client.GetJellyfinItems(context.Background())
+	require.ErrorContains(t, err, "status 401")
+}
+
+func TestRemoveItemWithCleanupMode(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		itemType    jellyfin.BaseItemKind
+		cleanupMode config.CleanupMode
+		keepCount   int
+		wantDeleted []string
+	}{
+		{name: "movie", itemType: jellyfin.BASEITEMKIND_MOVIE, cleanupMode: config.CleanupModeKeepEpisodes, keepCount: 1, wantDeleted: []string{"2000"}},
+		{name: "all", itemType: jellyfin.BASEITEMKIND_SERIES, cleanupMode: config.CleanupModeAll, wantDeleted: []string{"2000"}},
+		{name: "keep episodes", itemType: jellyfin.BASEITEMKIND_SERIES, cleanupMode: config.CleanupModeKeepEpisodes, keepCount: 2, wantDeleted: []string{"2201"}},
+		{name: "keep seasons", itemType: jellyfin.BASEITEMKIND_SERIES, cleanupMode: config.CleanupModeKeepSeasons, keepCount: 1, wantDeleted: []string{"2201"}},
+		{name: "keep latest episodes", itemType: jellyfin.BASEITEMKIND_SERIES, cleanupMode: config.CleanupModeKeepLatestEpisodes, keepCount: 2, wantDeleted: []string{"2101"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t)
+			client := newTestClient(ts, "TV Shows")
+
+			err := client.RemoveItemWithCleanupMode(context.Background(), "2000", "The Wire", tt.itemType, tt.cleanupMode, tt.keepCount)
+			require.NoError(t, err)
+			slices.Sort(ts.deleted)
+			assert.Equal(t, tt.wantDeleted, ts.deleted, "specials are never deleted")
+		})
+	}
+}
+
+func TestUnsupportedLookupsFail(t *testing.T) {
+	client := New(&config.Config{Plex: &config.PlexConfig{}})
+
+	_, err := client.GetFavoritedBy(context.Background(), []string{"1000"})
+	require.Error(t, err)
+	_, err = client.GetInProgressBy(context.Background(), []string{"1000"})
+	require.Error(t, err)
+}
+
+func TestPlayHistory(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/status/sessions/history/all", r.URL.Path)
+		requests++
+		start, _ := strconv.Atoi(r.URL.Query().Get("X-Plex-Container-Start"))
+		entries := []map[string]any{
+			{"ratingKey": "2101", "parentRatingKey": "2110", "grandparentRatingKey": 2000, "viewedAt": 1700000300},
+			{"ratingKey": "1000", "viewedAt": 1700000200},
+		}
+		if start > 0 {
+			entries = []map[string]any{
+				{"ratingKey": "2100", "parentRatingKey": "2109", "grandparentRatingKey": "2000", "viewedAt": 1700000100},
+			}
+		}
+		writeContainer(w, map[string]any{"totalSize": 3, "Metadata": entries})
+	}))
+	defer server.Close()
+	c := New(&config.Config{Plex: &config.PlexConfig{URL: server.URL, Token: "token"}})
+
+	lastPlayed, err := c.GetItemsLastPlayed(context.Background(), []string{"1000", "2000", "3000"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{
+		"1000": time.Unix(1700000200, 0),
+		"2000": time.Unix(1700000300, 0),
+	}, lastPlayed, "episode plays count for their series, unplayed items are missing")
+
+	played, err := c.GetItemLastPlayed(context.Background(), "3000")
+	require.NoError(t, err)
+	assert.True(t, played.IsZero())
+
+	count, err := c.GetItemTotalPlayCount(context.Background(), "2000")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 2, requests, "the history is fetched once and reused")
+}
@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+)
+
+// PreviewItem is a media item of a library preview.
+type PreviewItem struct {
+	Title     string
+	Year      int32
+	MediaType models.MediaType
+	FileSize  int64
+	// DroppedBy is the name of the filter that excluded the item, empty if the item would be marked for deletion.
+	DroppedBy string
+}
+
+// PreviewLibrary gathers and filters the media items of a single library like a cleanup run, without recording or deleting anything.
+// It returns all items of the library, the ones that would be marked for deletion first.
+func (e *Engine) PreviewLibrary(ctx context.Context, libraryName string) ([]PreviewItem, error) {
+	e.clientsMu.RLock()
+	defer e.clientsMu.RUnlock()
+
+	if !e.isLibraryEnabled(libraryName) {
+		return nil, fmt.Errorf("library %q is not configured or disabled", libraryName)
+	}
+
+	gathered, err := e.collectMediaItems(ctx, libraryName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to gather media items: %w", err)
+	}
+	mediaItems := gathered.items
+	if e.cfg.HasRequesterRules() {
+		mediaItems = e.populateRequesterInfo(ctx, mediaItems)
+	}
+
+	marked, droppedBy, err := e.estimateFilters.ApplyAllTracked(ctx, mediaItems)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter media items: %w", err)
+	}
+
+	preview := make([]PreviewItem, 0, len(mediaItems))
+	for _, item := range marked {
+		preview = append(preview, newPreviewItem(item, ""))
+	}
+	for _, item := range mediaItems {
+		if filterName, ok := droppedBy[item.JellyfinID]; ok {
+			preview = append(preview, newPreviewItem(item, filterName))
+		}
+	}
+	return preview, nil
+}
+
+func newPreviewItem(item arr.MediaItem, droppedBy string) PreviewItem {
+	return PreviewItem{
+		Title:     item.Title,
+		Year:      item.Year,
+		MediaType: item.MediaType,
+		FileSize:  arrMediaToDBMediaItem(item).FileSize,
+		DroppedBy: droppedBy,
+	}
+}
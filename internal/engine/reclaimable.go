@@ -0,0 +1,132 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/policy"
+)
+
+// EstimateReclaimable projects how much disk space a cleanup run started now would free.
+// The media is gathered and filtered like in a cleanup run and the items the run would delete are determined
+// with the same deletion policies and safeguards, without recording or deleting anything.
+// The state of the engine isn't touched, so it's safe to call while a cleanup runs.
+// It returns the bytes per library and in total.
+func (e *Engine) EstimateReclaimable(ctx context.Context) (map[string]int64, int64, error) {
+	e.clientsMu.RLock()
+	defer e.clientsMu.RUnlock()
+
+	gathered, err := e.collectMediaItems(ctx, "")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to gather media items: %w", err)
+	}
+	mediaItems := gathered.items
+	policies := policy.NewEngine()
+	policies.SetPolicies(deletionPolicies(e.cfg, gathered.libraryFolders)...)
+
+	recorded, err := e.db.GetMediaItems(ctx, false)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get media items: %w", err)
+	}
+
+	deleteNowItems, mediaItems := splitDeleteNowItems(mediaItems)
+	if e.cfg.HasRequesterRules() {
+		mediaItems = e.populateRequesterInfo(ctx, mediaItems)
+	}
+	marked, err := e.estimateFilters.ApplyAll(ctx, mediaItems, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to filter media items: %w", err)
+	}
+
+	candidates := e.reclaimableCandidates(ctx, policies, recorded, deleteNowItems, marked, time.Now())
+	perLibrary, total := e.reclaimableSize(ctx, policies, gathered.libraryItemCounts, candidates)
+	log.FromContext(ctx).Debug("Estimated reclaimable disk space", "bytes", total, "libraries", len(perLibrary))
+	return perLibrary, total, nil
+}
+
+// reclaimableCandidates returns the recorded items together with the items a cleanup run would newly mark,
+// as they would be stored in the database. Items tagged for immediate deletion are due right away.
+func (e *Engine) reclaimableCandidates(ctx context.Context, policies *policy.Engine, recorded []database.Media, deleteNowItems, marked []arr.MediaItem, now time.Time) []database.Media {
+	candidates := slices.Clone(recorded)
+	for _, item := range deleteNowItems {
+		dbItem := arrMediaToDBMediaItem(item)
+		i := slices.IndexFunc(candidates, func(other database.Media) bool {
+			return other.MediaType == dbItem.MediaType && other.ArrID == dbItem.ArrID
+		})
+		if i >= 0 {
+			if candidates[i].DefaultDeleteAt.After(now) {
+				candidates[i].DefaultDeleteAt = now
+			}
+			continue
+		}
+		dbItem.DefaultDeleteAt = now
+		dbItem.CreatedAt = now
+		candidates = append(candidates, dbItem)
+	}
+
+	// recorded items are already dropped by the database filter
+	for _, item := range marked {
+		dbItem := arrMediaToDBMediaItem(item)
+		if err := policies.ApplyAll(ctx, &dbItem); err != nil {
+			log.FromContext(ctx).Error("failed to apply policies to media item", "title", dbItem.Title, "error", err)
+			continue
+		}
+		dbItem.CreatedAt = now
+		candidates = append(candidates, dbItem)
+	}
+	return candidates
+}
+
+// reclaimableSize sums the size of the items cleanupMedia would delete, assuming every deletion succeeds.
+// libraryItemCounts is the number of items per library in the media server.
+func (e *Engine) reclaimableSize(ctx context.Context, policies *policy.Engine, libraryItemCounts map[string]int, mediaItems []database.Media) (map[string]int64, int64) {
+	if e.cfg.MaxItemsPerRun > 0 {
+		sortForCleanup(mediaItems, e.cfg.OrderBy)
+	}
+
+	if err := policies.Prepare(ctx, mediaItems); err != nil {
+		log.FromContext(ctx).Error("failed to prepare deletion policies", "error", err)
+	}
+
+	failedItems, err := e.getDeletionFailureIDs(ctx)
+	if err != nil {
+		log.FromContext(ctx).Error("failed to get deletion failures", "error", err)
+	}
+
+	protectedCollections, err := e.protectedCollections(ctx)
+	if err != nil {
+		log.FromContext(ctx).Error("failed to get protected collections", "error", err)
+	}
+
+	now := time.Now()
+	remaining := maps.Clone(libraryItemCounts)
+	perLibrary := make(map[string]int64)
+	var total int64
+	var attempted int
+	for _, item := range mediaItems {
+		if failedItems[item.ID] {
+			continue
+		}
+		if ok, err := policies.ShouldTriggerDeletion(ctx, item); err != nil || !ok {
+			continue
+		}
+		if protectedCollections[item.CollectionID] || e.inDeletionCoolDown(item, now) || e.libraryFloorReached(remaining, item.LibraryName) {
+			continue
+		}
+		if e.deletionBudgetReached(attempted) {
+			break
+		}
+		attempted++
+
+		perLibrary[item.LibraryName] += item.FileSize
+		total += item.FileSize
+		remaining[item.LibraryName]--
+	}
+	return perLibrary, total
+}
@@ -0,0 +1,119 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+)
+
+// ReevaluateItem reruns the age and stream filters for the media item with the given Jellyfin ID.
+// If the item no longer qualifies for deletion, it is removed from the deletion database.
+// It returns true if the item was removed. It's rejected with ErrMaintenanceMode while the maintenance mode is enabled.
+func (e *Engine) ReevaluateItem(ctx context.Context, jellyfinID string) (bool, error) {
+	if e.MaintenanceMode() {
+		return false, ErrMaintenanceMode
+	}
+
+	e.clientsMu.RLock()
+	defer e.clientsMu.RUnlock()
+
+	dbItems, err := e.db.GetMediaItemsByJellyfinID(ctx, jellyfinID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get media items: %w", err)
+	}
+	if len(dbItems) == 0 {
+		log.FromContext(ctx).Debug("Item is not marked for deletion, nothing to reevaluate", "jellyfinID", jellyfinID)
+		return false, nil
+	}
+
+	removed := false
+	for _, dbItem := range dbItems {
+		if dbItem.ForceDelete {
+			log.FromContext(ctx).Debug("Item is tagged for immediate deletion, skipping reevaluation", "title", dbItem.Title, "jellyfinID", jellyfinID)
+			continue
+		}
+		item := dbMediaToArrMediaItem(dbItem)
+
+		reason := database.DBDeleteReasonReevaluated
+		filtered, err := e.ageFilter.Apply(ctx, []arr.MediaItem{item})
+		if err != nil {
+			return removed, fmt.Errorf("failed to apply age filter: %w", err)
+		}
+		if len(filtered) > 0 {
+			filtered, err = e.streamFilter.Apply(ctx, filtered)
+			if err != nil {
+				return removed, fmt.Errorf("failed to apply stream filter: %w", err)
+			}
+			filtered, err = e.inProgressFilter.Apply(ctx, filtered)
+			if err != nil {
+				return removed, fmt.Errorf("failed to apply in progress filter: %w", err)
+			}
+			reason = database.DBDeleteReasonStreamed
+		}
+		if len(filtered) > 0 {
+			log.FromContext(ctx).Debug("Item still qualifies for deletion", "title", dbItem.Title, "jellyfinID", jellyfinID)
+			continue
+		}
+
+		log.FromContext(ctx).Info("Item no longer qualifies for deletion, removing from database", "title", dbItem.Title, "jellyfinID", jellyfinID, "reason", reason)
+		if reason == database.DBDeleteReasonStreamed {
+			if err := e.CreateStreamedEvent(ctx, &dbItem); err != nil {
+				log.FromContext(ctx).Error("failed to create streamed event", "title", dbItem.Title, "error", err)
+			}
+		}
+		dbItem.DBDeleteReason = reason
+		if err := e.db.DeleteMediaItem(ctx, &dbItem); err != nil {
+			return removed, fmt.Errorf("failed to remove media item: %w", err)
+		}
+		removed = true
+	}
+
+	return removed, nil
+}
+
+// dbMediaToArrMediaItem converts a database media item into the arr.MediaItem expected by the filters.
+// The arr added date and the runtime are restored from the database, so the grace period, the added fallback age
+// and the runtime rules apply like in a cleanup run.
+func dbMediaToArrMediaItem(dbItem database.Media) arr.MediaItem {
+	item := arr.MediaItem{
+		JellyfinID:  dbItem.JellyfinID,
+		LibraryName: dbItem.LibraryName,
+		Title:       dbItem.Title,
+		Year:        dbItem.Year,
+		FileSize:    dbItem.FileSize,
+		RequestedBy: dbItem.RequestedBy,
+	}
+	if dbItem.TmdbId != nil {
+		item.TmdbId = *dbItem.TmdbId
+	}
+	if dbItem.TvdbId != nil {
+		item.TvdbId = *dbItem.TvdbId
+	}
+
+	switch dbItem.MediaType {
+	case database.MediaTypeMovie:
+		item.MediaType = models.MediaTypeMovie
+		item.MovieResource.SetId(dbItem.ArrID)
+		item.MovieResource.SetRuntime(int32(dbItem.RuntimeMinutes))
+		if !dbItem.ArrAddedAt.IsZero() {
+			item.MovieResource.SetAdded(dbItem.ArrAddedAt)
+		}
+	case database.MediaTypeTV:
+		item.MediaType = models.MediaTypeTV
+		item.SeriesResource.SetId(dbItem.ArrID)
+		item.SeriesResource.SetRuntime(int32(dbItem.RuntimeMinutes))
+		if !dbItem.ArrAddedAt.IsZero() {
+			item.SeriesResource.SetAdded(dbItem.ArrAddedAt)
+		}
+	case database.MediaTypeBook:
+		item.MediaType = models.MediaTypeBook
+		item.BookResource.ID = dbItem.ArrID
+		item.BookResource.Added = dbItem.ArrAddedAt
+	}
+
+	return item
+}
@@ -0,0 +1,234 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/cache"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	radarrImpl "github.com/jon4hz/jellysweep/internal/engine/arr/radarr"
+	readarrImpl "github.com/jon4hz/jellysweep/internal/engine/arr/readarr"
+	sonarrImpl "github.com/jon4hz/jellysweep/internal/engine/arr/sonarr"
+	"github.com/jon4hz/jellysweep/internal/engine/emby"
+	"github.com/jon4hz/jellysweep/internal/engine/jellyfin"
+	"github.com/jon4hz/jellysweep/internal/engine/mediaserver"
+	"github.com/jon4hz/jellysweep/internal/engine/plex"
+	"github.com/jon4hz/jellysweep/internal/engine/stats"
+	"github.com/jon4hz/jellysweep/internal/engine/stats/jellystat"
+	"github.com/jon4hz/jellysweep/internal/engine/stats/streamystats"
+	"github.com/jon4hz/jellysweep/internal/filter"
+	agefilter "github.com/jon4hz/jellysweep/internal/filter/age_filter"
+	collectionfilter "github.com/jon4hz/jellysweep/internal/filter/collection_filter"
+	databasefilter "github.com/jon4hz/jellysweep/internal/filter/database_filter"
+	downloadingfilter "github.com/jon4hz/jellysweep/internal/filter/downloading_filter"
+	favoritesfilter "github.com/jon4hz/jellysweep/internal/filter/favorites_filter"
+	inprogressfilter "github.com/jon4hz/jellysweep/internal/filter/in_progress_filter"
+	requestagefilter "github.com/jon4hz/jellysweep/internal/filter/request_age_filter"
+	requesterfilter "github.com/jon4hz/jellysweep/internal/filter/requester_filter"
+	seriesfilter "github.com/jon4hz/jellysweep/internal/filter/series_filter"
+	sizefilter "github.com/jon4hz/jellysweep/internal/filter/size_filter"
+	statusfilter "github.com/jon4hz/jellysweep/internal/filter/status_filter"
+	streamfilter "github.com/jon4hz/jellysweep/internal/filter/stream_filter"
+	subtitlefilter "github.com/jon4hz/jellysweep/internal/filter/subtitle_filter"
+	tagsfilter "github.com/jon4hz/jellysweep/internal/filter/tags_filter"
+	tunarrfilter "github.com/jon4hz/jellysweep/internal/filter/tunarr_filter"
+	"github.com/jon4hz/jellysweep/internal/scheduler"
+	"github.com/samber/lo"
+)
+
+// clients are the media server, stats and arr clients, which are rebuilt on a reload.
+type clients struct {
+	jellyfin mediaserver.MediaServer
+	stats    stats.Statser
+	sonarr   arr.Arrer
+	radarr   arr.Arrer
+	readarr  arr.Arrer
+}
+
+// newClients creates the media server, stats and arr clients from the config.
+func newClients(cfg *config.Config, engineCache *cache.EngineCache) (*clients, error) {
+	c := &clients{}
+
+	if cfg.Jellystat != nil {
+		c.stats = jellystat.New(cfg.Jellystat, cfg.Proxy, cfg.GetConcurrency())
+	}
+
+	if cfg.Streamystats != nil {
+		statsClient, err := streamystats.New(cfg.Streamystats, cfg.Jellyfin.APIKey, cfg.Proxy, cfg.GetConcurrency())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create StreamyStats client: %w", err)
+		}
+		c.stats = statsClient
+	}
+
+	// Create the media server client, either Jellyfin, Emby or Plex
+	switch {
+	case cfg.Emby != nil:
+		c.jellyfin = emby.New(cfg)
+	case cfg.Plex != nil:
+		plexClient := plex.New(cfg)
+		c.jellyfin = plexClient
+		// Jellystat and Streamystats are rejected with Plex, the Plex play history is used instead
+		c.stats = plexClient
+	default:
+		c.jellyfin = jellyfin.New(cfg)
+	}
+
+	if cfg.Sonarr != nil {
+		c.sonarr = sonarrImpl.NewSonarr(cfg, c.stats, engineCache.SonarrTagsCache)
+	} else {
+		log.Warn("Sonarr configuration is missing, some features will be disabled")
+	}
+
+	if cfg.Radarr != nil {
+		c.radarr = radarrImpl.NewRadarr(cfg, c.stats, engineCache.RadarrTagsCache)
+	} else {
+		log.Warn("Radarr configuration is missing, some features will be disabled")
+	}
+
+	if cfg.Readarr != nil {
+		c.readarr = readarrImpl.NewReadarr(cfg, engineCache.ReadarrTagsCache)
+	}
+
+	return c, nil
+}
+
+// filterSet are the filters built on top of the clients.
+type filterSet struct {
+	filters              *filter.Filter
+	diffFilters          *filter.Filter
+	statslessDiffFilters *filter.Filter
+	recordFilters        *filter.Filter
+	estimateFilters      *filter.Filter
+	statslessFilters     *filter.Filter
+	ageFilter            filter.Filterer
+	streamFilter         filter.Filterer
+	inProgressFilter     filter.Filterer
+}
+
+// newFilterSet creates the filters for the given clients.
+func newFilterSet(cfg *config.Config, db database.DB, c *clients) *filterSet {
+	ageF := agefilter.New(cfg, db, c.sonarr, c.radarr, c.readarr)
+	streamF := streamfilter.New(cfg, c.stats, c.jellyfin)
+	inProgressF := inprogressfilter.New(cfg, c.jellyfin)
+	budgetF := newScanBudgetFilter(cfg, db)
+	dbF := databasefilter.New(db)
+	filterList := []filter.Filterer{
+		// the size filter runs first, so percent based thresholds are computed from the whole library
+		sizefilter.New(cfg),
+		budgetF,
+		dbF,
+		seriesfilter.New(cfg),
+		statusfilter.New(cfg),
+		tagsfilter.New(cfg),
+		requesterfilter.New(cfg),
+		requestagefilter.New(cfg),
+		ageF,
+		streamF,
+		// resume positions come from the media server, so items in progress stay protected without the stats backend
+		inProgressF,
+		collectionfilter.New(cfg, c.jellyfin),
+		favoritesfilter.New(cfg, c.jellyfin),
+		subtitlefilter.New(cfg, c.sonarr, c.radarr),
+		downloadingfilter.New(cfg, c.sonarr, c.radarr),
+	}
+
+	if cfg.Tunarr != nil {
+		tunarrF, err := tunarrfilter.New(cfg)
+		if err != nil {
+			log.Warn("failed to create Tunarr filter", "error", err)
+		} else {
+			filterList = append(filterList, tunarrF)
+		}
+	}
+
+	// the diff compares all items, so it skips the scan budget as well
+	diffList := slices.DeleteFunc(slices.Clone(filterList), func(f filter.Filterer) bool {
+		return f == budgetF || f == dbF
+	})
+
+	return &filterSet{
+		filters:     filter.New(filterList...),
+		diffFilters: filter.New(diffList...),
+		statslessDiffFilters: filter.New(slices.DeleteFunc(slices.Clone(diffList), func(f filter.Filterer) bool {
+			return f == streamF
+		})...),
+		recordFilters: filter.New(budgetF, dbF),
+		// the estimate checks the same items as the next run, without moving the scan cursor
+		estimateFilters: filter.New(lo.Map(filterList, func(f filter.Filterer, _ int) filter.Filterer {
+			if f == budgetF {
+				return budgetF.previewFilter()
+			}
+			return f
+		})...),
+		statslessFilters: filter.New(slices.DeleteFunc(slices.Clone(filterList), func(f filter.Filterer) bool {
+			return f == streamF
+		})...),
+		ageFilter:        ageF,
+		streamFilter:     streamF,
+		inProgressFilter: inProgressF,
+	}
+}
+
+// ReloadClients re-reads the config file and rebuilds the media server, stats and arr clients,
+// e.g. after an API key was rotated. Only the config sections of these services are applied,
+// all other settings still require a restart. The old clients are kept if the new config is invalid.
+// Running jobs are finished before the clients are swapped.
+func (e *Engine) ReloadClients(configPath string) error {
+	newCfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config, keeping the current clients: %w", err)
+	}
+
+	e.clientsMu.Lock()
+	defer e.clientsMu.Unlock()
+
+	// only the sections of the clients are replaced, so the reload can't change the cleanup behavior
+	oldCfg := *e.cfg
+	e.cfg.Jellyfin = newCfg.Jellyfin
+	e.cfg.Emby = newCfg.Emby
+	e.cfg.Plex = newCfg.Plex
+	e.cfg.Jellystat = newCfg.Jellystat
+	e.cfg.Streamystats = newCfg.Streamystats
+	e.cfg.Sonarr = newCfg.Sonarr
+	e.cfg.Radarr = newCfg.Radarr
+	e.cfg.Readarr = newCfg.Readarr
+
+	c, err := newClients(e.cfg, e.cache)
+	if err != nil {
+		*e.cfg = oldCfg
+		return fmt.Errorf("failed to create clients, keeping the current clients: %w", err)
+	}
+	filters := newFilterSet(e.cfg, e.db, c)
+
+	e.jellyfin = c.jellyfin
+	e.stats = c.stats
+	e.sonarr = c.sonarr
+	e.radarr = c.radarr
+	e.readarr = c.readarr
+	e.filters = filters.filters
+	e.diffFilters = filters.diffFilters
+	e.statslessDiffFilters = filters.statslessDiffFilters
+	e.recordFilters = filters.recordFilters
+	e.estimateFilters = filters.estimateFilters
+	e.statslessFilters = filters.statslessFilters
+	e.ageFilter = filters.ageFilter
+	e.streamFilter = filters.streamFilter
+	e.inProgressFilter = filters.inProgressFilter
+
+	log.Info("Reloaded the media server, stats and arr clients")
+	return nil
+}
+
+// lockedJob holds the clients lock while the job runs, so the clients aren't swapped in the middle of a job.
+func (e *Engine) lockedJob(job scheduler.JobFunc) scheduler.JobFunc {
+	return func(ctx context.Context) error {
+		e.clientsMu.RLock()
+		defer e.clientsMu.RUnlock()
+		return job(ctx)
+	}
+}
@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/database"
+)
+
+const (
+	// dryRunReasonMarked is used for items that would be marked for deletion.
+	dryRunReasonMarked = "marked_for_deletion"
+	// dryRunReasonDelete is used for items whose deletion policies triggered.
+	dryRunReasonDelete = "deletion_policy_triggered"
+	// dryRunReasonDeleteNow is used for items tagged for immediate deletion in the arrs.
+	dryRunReasonDeleteNow = "tagged_delete_now"
+	// dryRunReasonDeleteSeasons is used for protected series whose seasons outside of the kept ones would be deleted.
+	dryRunReasonDeleteSeasons = "unkept_seasons_deleted"
+)
+
+// dryRunReportEntry is a single item in the dry-run report.
+type dryRunReportEntry struct {
+	Title                 string    `json:"title"`
+	Library               string    `json:"library"`
+	MediaType             string    `json:"media_type"`
+	Size                  int64     `json:"size"`
+	Reason                string    `json:"reason"`
+	ProjectedDeletionDate time.Time `json:"projected_deletion_date"`
+}
+
+// addDryRunReportEntry records a media item in the dry-run report of the current run.
+func (e *Engine) addDryRunReportEntry(item database.Media, reason string, deletionDate time.Time) {
+	if !e.cfg.DryRun || e.cfg.DryRunReportPath == "" {
+		return
+	}
+	e.data.dryRunReport = append(e.data.dryRunReport, dryRunReportEntry{
+		Title:                 item.Title,
+		Library:               item.LibraryName,
+		MediaType:             string(item.MediaType),
+		Size:                  item.FileSize,
+		Reason:                reason,
+		ProjectedDeletionDate: deletionDate,
+	})
+}
+
+// writeDryRunReport writes the collected dry-run report to the configured path.
+// The format is determined by the file extension (.json or .csv).
+func (e *Engine) writeDryRunReport(ctx context.Context) error {
+	if !e.cfg.DryRun || e.cfg.DryRunReportPath == "" {
+		return nil
+	}
+
+	path := e.cfg.DryRunReportPath
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	entries := e.data.dryRunReport
+	if entries == nil {
+		entries = []dryRunReportEntry{}
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(entries); err != nil {
+			return fmt.Errorf("failed to write json report: %w", err)
+		}
+	case ".csv":
+		w := csv.NewWriter(f)
+		if err := w.Write([]string{"title", "library", "media_type", "size", "reason", "projected_deletion_date"}); err != nil {
+			return fmt.Errorf("failed to write csv report: %w", err)
+		}
+		for _, entry := range entries {
+			if err := w.Write([]string{
+				entry.Title,
+				entry.Library,
+				entry.MediaType,
+				strconv.FormatInt(entry.Size, 10),
+				entry.Reason,
+				entry.ProjectedDeletionDate.Format(time.RFC3339),
+			}); err != nil {
+				return fmt.Errorf("failed to write csv report: %w", err)
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return fmt.Errorf("failed to write csv report: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported dry-run report format %q", filepath.Ext(path))
+	}
+
+	log.FromContext(ctx).Info("Wrote dry-run report", "path", path, "items", len(entries))
+	return nil
+}
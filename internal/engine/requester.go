@@ -2,38 +2,59 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"regexp"
 
 	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/api/models"
 	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/pkg/jellyseerr"
 )
 
 var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
 
-// populateRequesterInfo populates the RequestedBy field for media items using Jellyseerr data.
+// populateRequesterInfo populates the RequestedBy and RequestedAt fields for media items using Jellyseerr data.
 func (e *Engine) populateRequesterInfo(ctx context.Context, mediaItems []arr.MediaItem) []arr.MediaItem {
 	if e.jellyseerr == nil {
-		log.Debug("Jellyseerr client not available, skipping requester info population")
+		// without a request manager nothing is requested, e.g. if all media is imported manually
+		log.FromContext(ctx).Debug("Jellyseerr client not available, skipping requester info population")
+		for i := range mediaItems {
+			mediaItems[i].RequestedBy = ""
+			mediaItems[i].RequestedAt = nil
+			mediaItems[i].Requesters = nil
+		}
 		return mediaItems
 	}
 
 	for i, item := range mediaItems {
+		// books can't be requested in Jellyseerr
+		if item.MediaType == models.MediaTypeBook {
+			continue
+		}
 		requestInfo, err := e.jellyseerr.GetRequestInfo(ctx, item.TmdbId, string(item.MediaType))
+		if errors.Is(err, jellyseerr.ErrMediaNotFound) {
+			log.FromContext(ctx).Debug("item not found in jellyseerr, skipping requester info", "title", item.Title)
+			continue
+		}
 		if err != nil {
-			log.Error("failed to get request info for item", "title", item.Title, "error", err)
+			log.FromContext(ctx).Error("failed to get request info for item", "title", item.Title, "error", err)
 			continue
 		}
 		if requestInfo == nil || requestInfo.RequestTime == nil {
-			log.Debug("no request info found for item", "title", item.Title)
+			log.FromContext(ctx).Debug("no request info found for item", "title", item.Title)
 			continue
 		}
 
+		item.RequestedAt = requestInfo.RequestTime
+		item.Requesters = requestInfo.Requesters
+		mediaItems[i] = item
+
 		if !emailRegex.MatchString(requestInfo.UserEmail) {
-			log.Warn("invalid email address for item, skipping", "title", item.Title, "email", requestInfo.UserEmail)
+			log.FromContext(ctx).Warn("invalid email address for item, skipping", "title", item.Title, "email", requestInfo.UserEmail)
 			continue
 		}
 		item.RequestedBy = requestInfo.UserEmail
-		log.Debug("populated requester info", "title", item.Title, "requestedBy", item.RequestedBy, "requestTime", requestInfo.RequestTime.Format("2006-01-02"))
+		log.FromContext(ctx).Debug("populated requester info", "title", item.Title, "requestedBy", item.RequestedBy, "requestTime", requestInfo.RequestTime.Format("2006-01-02"))
 
 		// Update the items in the map
 		mediaItems[i] = item
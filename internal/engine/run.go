@@ -0,0 +1,121 @@
+package engine
+
+import (
+	"context"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/filter"
+)
+
+// Names of the recorded cleanup run steps.
+const (
+	stepRemoveProtectedExpired = "remove_protected_expired"
+	stepGatherMedia            = "gather_media"
+	stepRemoveNotFound         = "remove_not_found"
+	stepMarkForDeletion        = "mark_for_deletion"
+	stepRemoveRecentlyPlayed   = "remove_recently_played"
+	stepCleanupMedia           = "cleanup_media"
+	stepLeavingCollections     = "leaving_collections"
+
+	// stepFilterPrefix prefixes the steps recorded for every filter, e.g. filter_tags.
+	stepFilterPrefix = "filter_"
+)
+
+// startCleanupRun records the start of a new cleanup run.
+// Failing to record the run is logged but never aborts the cleanup.
+// The returned context carries a logger with the run ID, so the log lines of the run can be correlated with the recorded run.
+// Other jobs and API calls running at the same time keep logging without it.
+func (e *Engine) startCleanupRun(ctx context.Context) context.Context {
+	run, err := e.db.CreateCleanupRun(ctx, e.cfg.DryRun)
+	if err != nil {
+		log.FromContext(ctx).Error("failed to record cleanup run", "error", err)
+	}
+	e.data.run = run
+	if run == nil {
+		return ctx
+	}
+	return log.WithContext(ctx, log.FromContext(ctx).With("runID", run.ID))
+}
+
+// completeCleanupRun records the result of the current cleanup run.
+func (e *Engine) completeCleanupRun(ctx context.Context, runErr error) {
+	if e.data.run == nil {
+		return
+	}
+	if err := e.db.CompleteCleanupRun(ctx, e.data.run, runErr); err != nil {
+		log.FromContext(ctx).Error("failed to record cleanup run result", "error", err)
+	}
+	e.data.run = nil
+}
+
+// startStep records the start of a step of the current cleanup run.
+func (e *Engine) startStep(ctx context.Context, name string) *database.CleanupRunStep {
+	if e.data.run == nil {
+		return nil
+	}
+	step, err := e.db.StartCleanupStep(ctx, e.data.run.ID, name)
+	if err != nil {
+		log.FromContext(ctx).Error("failed to record cleanup step", "step", name, "error", err)
+		return nil
+	}
+	return step
+}
+
+// completeStep records the result of a step of the current cleanup run.
+func (e *Engine) completeStep(ctx context.Context, step *database.CleanupRunStep, itemsProcessed int, stepErr error) {
+	if step == nil {
+		return
+	}
+	if err := e.db.CompleteCleanupStep(ctx, step, itemsProcessed, stepErr); err != nil {
+		log.FromContext(ctx).Error("failed to record cleanup step result", "step", step.Name, "error", err)
+	}
+}
+
+// filterStepName returns the step name of a filter, e.g. "Tags Filter" becomes "filter_tags".
+func filterStepName(name string) string {
+	name = strings.TrimSuffix(strings.ToLower(name), " filter")
+	return stepFilterPrefix + strings.ReplaceAll(strings.TrimSpace(name), " ", "_")
+}
+
+// recordFilterStep returns a filter.StepFunc which records every filter as step of the current cleanup run.
+func (e *Engine) recordFilterStep(ctx context.Context) filter.StepFunc {
+	return func(name string) func(int, error) {
+		step := e.startStep(ctx, filterStepName(name))
+		return func(remaining int, err error) {
+			e.completeStep(ctx, step, remaining, err)
+		}
+	}
+}
+
+// recordMarked adds the number of items marked for deletion to the current cleanup run.
+func (e *Engine) recordMarked(count int) {
+	if e.data.run != nil {
+		e.data.run.ItemsMarked += count
+	}
+}
+
+// recordDeleted adds a deleted item to the current cleanup run.
+func (e *Engine) recordDeleted(size int64) {
+	if e.data.run != nil {
+		e.data.run.ItemsDeleted++
+		e.data.run.BytesFreed += size
+	}
+}
+
+// itemsMarked returns the number of items marked for deletion in the current cleanup run.
+func (e *Engine) itemsMarked() int {
+	if e.data.run == nil {
+		return 0
+	}
+	return e.data.run.ItemsMarked
+}
+
+// itemsDeleted returns the number of items deleted in the current cleanup run.
+func (e *Engine) itemsDeleted() int {
+	if e.data.run == nil {
+		return 0
+	}
+	return e.data.run.ItemsDeleted
+}
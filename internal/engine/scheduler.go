@@ -3,6 +3,7 @@ package engine
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/go-co-op/gocron/v2"
@@ -14,6 +15,29 @@ func (e *Engine) GetScheduler() *scheduler.Scheduler {
 	return e.scheduler
 }
 
+// Pause suspends all scheduled jobs and persists the paused state.
+func (e *Engine) Pause(ctx context.Context) error {
+	if err := e.db.SetSchedulerPaused(ctx, true); err != nil {
+		return fmt.Errorf("failed to persist paused state: %w", err)
+	}
+	e.scheduler.Pause()
+	return nil
+}
+
+// Resume resumes all scheduled jobs and persists the state.
+func (e *Engine) Resume(ctx context.Context) error {
+	if err := e.db.SetSchedulerPaused(ctx, false); err != nil {
+		return fmt.Errorf("failed to persist paused state: %w", err)
+	}
+	e.scheduler.Resume()
+	return nil
+}
+
+// Paused reports whether the scheduled jobs are paused.
+func (e *Engine) Paused() bool {
+	return e.scheduler.Paused()
+}
+
 // Run starts the engine and all its background jobs.
 func (e *Engine) Run(ctx context.Context) error {
 	if ctx == nil {
@@ -36,33 +60,76 @@ func (e *Engine) Close() error {
 // setupJobs configures all scheduled jobs.
 func (e *Engine) setupJobs() error {
 	// Add cleanup job as singleton (only one instance can run at a time)
-	cleanupJobDef := gocron.CronJob(e.cfg.CleanupSchedule, false)
+	cleanupJobDef := gocron.CronJob(e.cfg.CleanupSchedule, e.cfg.CronWithSeconds())
 	if err := e.scheduler.AddSingletonJob(
 		"cleanup",
 		"Media Cleanup",
 		"Runs the cleanup loop",
 		e.cfg.CleanupSchedule,
 		cleanupJobDef,
-		e.runCleanupJob,
+		e.skipInMaintenance("cleanup", e.lockedJob(e.runCleanupJob)),
 		true,
 	); err != nil {
 		return fmt.Errorf("failed to add cleanup job: %w", err)
 	}
+	if err := e.scheduler.SetJitter("cleanup", time.Duration(e.cfg.CleanupScheduleJitterSeconds)*time.Second); err != nil {
+		return fmt.Errorf("failed to set cleanup job jitter: %w", err)
+	}
 
-	// Add job to clear image cache once a week
-	clearImageCacheJobDef := gocron.CronJob("0 0 * * 0", false) // Every Sunday at midnight
+	// Add job to remove expired images and enforce the image cache size limit once a day
+	cleanImageCacheJobDef := gocron.CronJob("0 0 * * *", false) // Every day at midnight
 	if err := e.scheduler.AddSingletonJob(
-		"clear_image_cache",
-		"Clear Image Cache",
-		"Clears the image cache to free up space",
-		"0 0 * * 0", // Every Sunday at midnight
-		clearImageCacheJobDef,
-		func(ctx context.Context) error {
-			return e.imageCache.Clear(ctx)
-		},
+		"clean_image_cache",
+		"Clean Image Cache",
+		"Removes expired images and enforces the image cache size limit",
+		"0 0 * * *", // Every day at midnight
+		cleanImageCacheJobDef,
+		e.skipInMaintenance("clean_image_cache", e.lockedJob(e.imageCache.Cleanup)),
 		false, // Not a singleton, can run multiple times
 	); err != nil {
-		return fmt.Errorf("failed to add clear image cache job: %w", err)
+		return fmt.Errorf("failed to add clean image cache job: %w", err)
+	}
+
+	// Add job to retry failed deletions
+	retryFailedDeletionsJobDef := gocron.CronJob("*/10 * * * *", false) // Every 10 minutes
+	if err := e.scheduler.AddSingletonJob(
+		"retry_failed_deletions",
+		"Retry Failed Deletions",
+		"Retries failed deletions with exponential backoff",
+		"*/10 * * * *", // Every 10 minutes
+		retryFailedDeletionsJobDef,
+		e.skipInMaintenance("retry_failed_deletions", e.lockedJob(e.retryFailedDeletions)),
+		true,
+	); err != nil {
+		return fmt.Errorf("failed to add retry failed deletions job: %w", err)
+	}
+
+	// Add job to remind requesters about expiring protection once a day
+	keepExpiryRemindersJobDef := gocron.CronJob("0 9 * * *", false) // Every day at 9am
+	if err := e.scheduler.AddSingletonJob(
+		"keep_expiry_reminders",
+		"Keep Expiry Reminders",
+		"Reminds requesters before the protection of their kept media expires",
+		"0 9 * * *", // Every day at 9am
+		keepExpiryRemindersJobDef,
+		e.skipInMaintenance("keep_expiry_reminders", e.lockedJob(e.sendKeepExpiryReminders)),
+		false,
+	); err != nil {
+		return fmt.Errorf("failed to add keep expiry reminders job: %w", err)
+	}
+
+	// Add job to refresh the projected deletion dates every hour
+	estimateDeletionsJobDef := gocron.CronJob("0 * * * *", false) // Every hour
+	if err := e.scheduler.AddSingletonJob(
+		"estimate_deletions",
+		"Estimate Deletions",
+		"Stores the projected deletion date of the media marked for deletion",
+		"0 * * * *", // Every hour
+		estimateDeletionsJobDef,
+		e.skipInMaintenance("estimate_deletions", e.lockedJob(e.estimateDeletions)),
+		true,
+	); err != nil {
+		return fmt.Errorf("failed to add estimate deletions job: %w", err)
 	}
 
 	log.Info("Scheduled jobs configured successfully")
@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+)
+
+// cleanupUnkeptSeasons deletes the seasons of protected TV series which weren't requested to be kept.
+// The series itself stays protected, only the seasons outside of the kept ones are deleted once the deletion policies trigger.
+// The same safeguards as for whole items apply, attempted is the number of deletions of the run so far and the updated number is returned.
+// The series with deleted seasons are added to deletedItems for the completion notifications.
+func (e *Engine) cleanupUnkeptSeasons(ctx context.Context, remaining map[string]int, attempted int, deletedItems map[string][]arr.MediaItem) int {
+	if e.sonarr == nil {
+		return attempted
+	}
+	deleter, ok := e.sonarr.(arr.SeasonDeleter)
+	if !ok {
+		return attempted
+	}
+
+	mediaItems, err := e.db.GetMediaItems(ctx, true)
+	if err != nil {
+		log.FromContext(ctx).Error("failed to get media items from database", "error", err)
+		return attempted
+	}
+
+	now := time.Now()
+	for _, item := range mediaItems {
+		if item.MediaType != database.MediaTypeTV || len(item.KeptSeasons) == 0 {
+			continue
+		}
+		if item.ProtectedUntil == nil || !item.ProtectedUntil.After(now) {
+			continue
+		}
+
+		// check the deletion policies as if the series wasn't protected
+		unprotected := item
+		unprotected.ProtectedUntil = nil
+		if ok, err := e.policy.ShouldTriggerDeletion(ctx, unprotected); err != nil {
+			log.FromContext(ctx).Error("failed to check deletion policy for media item", "title", item.Title, "error", err)
+			continue
+		} else if !ok {
+			continue
+		}
+
+		if e.inDeletionCoolDown(item, now) {
+			log.FromContext(ctx).Info("skipping deletion of seasons not kept, the series was marked too recently", "title", item.Title, "markedAt", item.CreatedAt, "minMarkToDeleteHours", e.cfg.MinMarkToDeleteHours)
+			continue
+		}
+
+		// the series stays in the library, but its content is still protected by the minimum library size
+		if e.libraryFloorReached(remaining, item.LibraryName) {
+			log.FromContext(ctx).Warn("sparing seasons not kept, library is at the minimum item count", "title", item.Title, "library", item.LibraryName, "minItems", e.cfg.MinItemsPerLibrary)
+			continue
+		}
+
+		if e.deletionBudgetReached(attempted) {
+			log.FromContext(ctx).Info("deletion limit of this run reached, deferring the deletion of seasons not kept to the next run", "maxItemsPerRun", e.cfg.MaxItemsPerRun)
+			break
+		}
+		attempted++
+
+		if e.cfg.DryRun {
+			log.FromContext(ctx).Info("[Dry Run] Would delete seasons not kept", "title", item.Title, "keptSeasons", item.KeptSeasons)
+			e.addDryRunReportEntry(item, dryRunReasonDeleteSeasons, now)
+			continue
+		}
+
+		if e.downloadInProgress(ctx, item) {
+			log.FromContext(ctx).Info("skipping deletion of seasons not kept, a download or import is in progress", "title", item.Title)
+			continue
+		}
+
+		if err := e.runPreDeleteHooks(ctx, item); err != nil {
+			log.FromContext(ctx).Error("pre-delete hook failed, skipping deletion of seasons not kept", "title", item.Title, "error", err)
+			continue
+		}
+
+		size, err := deleter.DeleteSeasonsExcept(ctx, item.ArrID, item.Title, item.KeptSeasons)
+		if err != nil {
+			log.FromContext(ctx).Error("failed to delete seasons not kept", "title", item.Title, "keptSeasons", item.KeptSeasons, "error", err)
+			continue
+		}
+		if size == 0 {
+			continue
+		}
+
+		e.recordDeleted(size)
+		deletedItems[item.LibraryName] = append(deletedItems[item.LibraryName], arr.MediaItem{
+			Title:       item.Title,
+			Year:        item.Year,
+			FileSize:    size,
+			MediaType:   models.MediaType(item.MediaType),
+			LibraryName: item.LibraryName,
+		})
+	}
+	return attempted
+}
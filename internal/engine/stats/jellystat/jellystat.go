@@ -10,12 +10,14 @@ import (
 )
 
 type jellystatClient struct {
-	client *jellystat.Client
+	client      *jellystat.Client
+	concurrency int
 }
 
-func New(cfg *config.JellystatConfig) stats.Statser {
+func New(cfg *config.JellystatConfig, proxy *config.ProxyConfig, concurrency int) stats.Statser {
 	return &jellystatClient{
-		client: jellystat.New(cfg),
+		client:      jellystat.New(cfg, proxy),
+		concurrency: concurrency,
 	}
 }
 
@@ -29,3 +31,17 @@ func (s *jellystatClient) GetItemLastPlayed(ctx context.Context, jellyfinID stri
 	}
 	return *lastPlayed.LastPlayed, nil
 }
+
+func (s *jellystatClient) GetItemTotalPlayCount(ctx context.Context, jellyfinID string) (int, error) {
+	return s.client.GetPlayCount(ctx, jellyfinID)
+}
+
+func (s *jellystatClient) GetItemsLastPlayed(ctx context.Context, jellyfinIDs []string) (map[string]time.Time, error) {
+	return stats.GetItemsLastPlayedEach(ctx, s, jellyfinIDs, s.concurrency)
+}
+
+// Ping checks whether Jellystat is reachable by requesting the library metadata.
+func (s *jellystatClient) Ping(ctx context.Context) error {
+	_, err := s.client.GetLibraryMetadata(ctx)
+	return err
+}
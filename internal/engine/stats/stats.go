@@ -2,9 +2,53 @@ package stats
 
 import (
 	"context"
+	"sync"
 	"time"
+
+	"github.com/charmbracelet/log"
+	"golang.org/x/sync/errgroup"
 )
 
 type Statser interface {
 	GetItemLastPlayed(ctx context.Context, itemID string) (time.Time, error)
+	// GetItemsLastPlayed returns the last played time of multiple items.
+	// Items that were never played are missing in the result.
+	GetItemsLastPlayed(ctx context.Context, itemIDs []string) (map[string]time.Time, error)
+	// GetItemTotalPlayCount returns how often an item was played over its whole history.
+	GetItemTotalPlayCount(ctx context.Context, itemID string) (int, error)
+}
+
+// GetItemsLastPlayedEach fetches the last played time of the items one by one with up to concurrency parallel requests.
+// It's used by backends without a bulk endpoint. Items that fail are logged and skipped.
+func GetItemsLastPlayedEach(ctx context.Context, s Statser, itemIDs []string, concurrency int) (map[string]time.Time, error) {
+	var mu sync.Mutex
+	result := make(map[string]time.Time, len(itemIDs))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(concurrency, 1))
+	for _, itemID := range itemIDs {
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			lastPlayed, err := s.GetItemLastPlayed(gctx, itemID)
+			if err != nil {
+				log.Error("Failed to get last played time for item", "jellyfinID", itemID, "error", err)
+				return nil
+			}
+			if !lastPlayed.IsZero() {
+				mu.Lock()
+				result[itemID] = lastPlayed
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
@@ -0,0 +1,95 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStatser returns a last played time derived from the item ID and tracks the number of parallel calls.
+type fakeStatser struct {
+	Statser
+	base     time.Time
+	inFlight atomic.Int32
+	maxSeen  atomic.Int32
+}
+
+func (f *fakeStatser) GetItemLastPlayed(_ context.Context, itemID string) (time.Time, error) {
+	current := f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+	for {
+		seen := f.maxSeen.Load()
+		if current <= seen || f.maxSeen.CompareAndSwap(seen, current) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+
+	var n int
+	if _, err := fmt.Sscanf(itemID, "item-%d", &n); err != nil {
+		return time.Time{}, err
+	}
+	switch {
+	case n%5 == 0:
+		return time.Time{}, nil // never played
+	case n%7 == 0:
+		return time.Time{}, errors.New("stats backend failed")
+	default:
+		return f.base.Add(time.Duration(n) * time.Hour), nil
+	}
+}
+
+func itemIDs(n int) []string {
+	ids := make([]string, 0, n)
+	for i := range n {
+		ids = append(ids, fmt.Sprintf("item-%d", i))
+	}
+	return ids
+}
+
+func TestGetItemsLastPlayedEachParallelMatchesSerial(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := append(itemIDs(50), "invalid")
+
+	serial := &fakeStatser{base: base}
+	serialResult, err := GetItemsLastPlayedEach(context.Background(), serial, ids, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), serial.maxSeen.Load())
+
+	parallel := &fakeStatser{base: base}
+	parallelResult, err := GetItemsLastPlayedEach(context.Background(), parallel, ids, 4)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, parallel.maxSeen.Load(), int32(4))
+
+	assert.Equal(t, serialResult, parallelResult)
+	assert.NotContains(t, parallelResult, "item-5", "never played items must be missing")
+	assert.NotContains(t, parallelResult, "item-7", "failed items must be skipped")
+	assert.Equal(t, base.Add(time.Hour), parallelResult["item-1"])
+}
+
+func TestGetItemsLastPlayedEachCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := GetItemsLastPlayedEach(ctx, &fakeStatser{}, itemIDs(10), 4)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func BenchmarkGetItemsLastPlayedEach(b *testing.B) {
+	ids := itemIDs(100)
+	for _, concurrency := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("concurrency-%d", concurrency), func(b *testing.B) {
+			for b.Loop() {
+				if _, err := GetItemsLastPlayedEach(context.Background(), &fakeStatser{}, ids, concurrency); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -2,34 +2,107 @@ package streamystats
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/charmbracelet/log"
 	"github.com/jon4hz/jellysweep/internal/config"
 	"github.com/jon4hz/jellysweep/internal/engine/stats"
 	"github.com/jon4hz/jellysweep/pkg/streamystats"
 )
 
+// streamystatsClient combines the play data of all configured Jellyfin servers in Streamystats.
 type streamystatsClient struct {
-	client *streamystats.Client
+	clients     []*streamystats.Client
+	concurrency int
 }
 
-func New(cfg *config.StreamystatsConfig, apiKey string) (stats.Statser, error) {
-	client, err := streamystats.New(cfg, apiKey)
-	if err != nil {
-		return nil, err
+func New(cfg *config.StreamystatsConfig, apiKey string, proxy *config.ProxyConfig, concurrency int) (stats.Statser, error) {
+	s := &streamystatsClient{concurrency: concurrency}
+	for _, serverID := range cfg.GetServerIDs() {
+		serverCfg := *cfg
+		serverCfg.ServerID = serverID
+		client, err := streamystats.New(&serverCfg, apiKey, proxy)
+		if err != nil {
+			return nil, err
+		}
+		s.clients = append(s.clients, client)
+	}
+	return s, nil
+}
+
+// getItemDetails returns the item details of every server that knows the item.
+// It returns streamystats.ErrItemNotFound if no server knows the item.
+func (s *streamystatsClient) getItemDetails(ctx context.Context, jellyfinID string) ([]*streamystats.ItemDetails, error) {
+	details := make([]*streamystats.ItemDetails, 0, len(s.clients))
+	for _, client := range s.clients {
+		d, err := client.GetItemDetails(ctx, jellyfinID)
+		if errors.Is(err, streamystats.ErrItemNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, d)
 	}
-	return &streamystatsClient{
-		client: client,
-	}, nil
+	if len(details) == 0 {
+		return nil, streamystats.ErrItemNotFound
+	}
+	return details, nil
 }
 
 func (s *streamystatsClient) GetItemLastPlayed(ctx context.Context, jellyfinID string) (time.Time, error) {
-	lastWatched, err := s.client.GetItemDetails(ctx, jellyfinID)
+	details, err := s.getItemDetails(ctx, jellyfinID)
 	if err != nil {
 		return time.Time{}, err
 	}
-	if lastWatched == nil || lastWatched.LastWatched.IsZero() {
-		return time.Time{}, nil // No playback history found
+	var lastPlayed time.Time
+	for _, d := range details {
+		if d != nil && d.LastWatched.After(lastPlayed) {
+			lastPlayed = d.LastWatched
+		}
+	}
+	return lastPlayed, nil // zero if no playback history was found
+}
+
+func (s *streamystatsClient) GetItemTotalPlayCount(ctx context.Context, jellyfinID string) (int, error) {
+	details, err := s.getItemDetails(ctx, jellyfinID)
+	if err != nil {
+		return 0, err
+	}
+	var playCount int
+	for _, d := range details {
+		playCount += d.PlayCount()
+	}
+	return playCount, nil
+}
+
+func (s *streamystatsClient) GetItemsLastPlayed(ctx context.Context, jellyfinIDs []string) (map[string]time.Time, error) {
+	result := make(map[string]time.Time, len(jellyfinIDs))
+	for _, client := range s.clients {
+		lastPlayed, err := client.GetItemsLastWatched(ctx, jellyfinIDs)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			log.Warn("Failed to fetch items from streamystats in bulk, falling back to single requests", "error", err)
+			return stats.GetItemsLastPlayedEach(ctx, s, jellyfinIDs, s.concurrency)
+		}
+		for id, t := range lastPlayed {
+			if t.After(result[id]) {
+				result[id] = t
+			}
+		}
+	}
+	return result, nil
+}
+
+// Ping checks whether Streamystats is reachable by requesting a single item of every server.
+func (s *streamystatsClient) Ping(ctx context.Context) error {
+	for _, client := range s.clients {
+		if _, err := client.GetItemsPage(ctx, 1, 1); err != nil {
+			return err
+		}
 	}
-	return lastWatched.LastWatched, nil
+	return nil
 }
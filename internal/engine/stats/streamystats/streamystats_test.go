@@ -0,0 +1,78 @@
+package streamystats
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/pkg/streamystats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer serves the item details by server ID, unknown items aren't found.
+func newTestServer(t *testing.T, details map[string]map[string]streamystats.ItemDetails) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		itemID := strings.TrimPrefix(r.URL.Path, "/api/get-item-details/")
+		item, ok := details[r.URL.Query().Get("serverId")][itemID]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(item)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMultipleServers(t *testing.T) {
+	older := time.Date(2025, 1, 10, 20, 0, 0, 0, time.UTC)
+	newer := time.Date(2025, 6, 1, 20, 0, 0, 0, time.UTC)
+	user := func(name string, watchCount int, lastWatched time.Time) streamystats.UserWatched {
+		return streamystats.UserWatched{User: streamystats.User{Name: name}, WatchCount: watchCount, LastWatched: lastWatched}
+	}
+
+	server := newTestServer(t, map[string]map[string]streamystats.ItemDetails{
+		"1": {
+			"movie": {LastWatched: older, TotalViews: 2, UsersWatched: []streamystats.UserWatched{user("alice", 2, older)}},
+		},
+		"2": {
+			"movie": {LastWatched: newer, TotalViews: 1, UsersWatched: []streamystats.UserWatched{user("alice", 1, newer)}},
+			"show":  {LastWatched: older, TotalViews: 1},
+		},
+	})
+
+	client, err := New(&config.StreamystatsConfig{URL: server.URL, ServerID: 1, ServerIDs: []int{2}}, "test-api-key", nil, 1)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	lastPlayed, err := client.GetItemLastPlayed(ctx, "movie")
+	require.NoError(t, err)
+	assert.Equal(t, newer, lastPlayed, "the most recent play of all servers is used")
+
+	lastPlayed, err = client.GetItemLastPlayed(ctx, "show")
+	require.NoError(t, err)
+	assert.Equal(t, older, lastPlayed, "items only known to one server are found")
+
+	_, err = client.GetItemLastPlayed(ctx, "unknown")
+	require.ErrorIs(t, err, streamystats.ErrItemNotFound)
+
+	playCount, err := client.GetItemTotalPlayCount(ctx, "movie")
+	require.NoError(t, err)
+	assert.Equal(t, 3, playCount)
+}
+
+func TestGetServerIDs(t *testing.T) {
+	assert.Equal(t, []int{1}, (&config.StreamystatsConfig{ServerID: 1}).GetServerIDs())
+	assert.Equal(t, []int{1, 2, 3}, (&config.StreamystatsConfig{ServerID: 1, ServerIDs: []int{2, 1, 3}}).GetServerIDs())
+	assert.Equal(t, []int{2}, (&config.StreamystatsConfig{ServerIDs: []int{2}}).GetServerIDs())
+	assert.Empty(t, (&config.StreamystatsConfig{}).GetServerIDs())
+}
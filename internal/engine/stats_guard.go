@@ -0,0 +1,38 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/config"
+)
+
+// ErrStatsUnreachable is returned if the stats backend can't be reached and the stats fail mode skips the deletions.
+var ErrStatsUnreachable = errors.New("stats backend is unreachable, skipping deletions")
+
+// checkStats pings the stats backend and applies the configured fail mode if it's unreachable.
+// It reports whether the stream filter must be skipped and returns ErrStatsUnreachable if nothing may be marked or deleted.
+func (e *Engine) checkStats(ctx context.Context) (skipStreamFilter bool, err error) {
+	pinger, ok := e.stats.(Pinger)
+	if !ok {
+		return false, nil
+	}
+	pingErr := pinger.Ping(ctx)
+	if pingErr == nil {
+		return false, nil
+	}
+
+	switch mode := e.cfg.GetStatsFailMode(); mode {
+	case config.StatsFailModeContinue:
+		log.FromContext(ctx).Warn("stats backend is unreachable, continuing the cleanup", "error", pingErr)
+		return false, nil
+	case config.StatsFailModeIgnoreStreamFilter:
+		log.FromContext(ctx).Warn("stats backend is unreachable, continuing the cleanup without the stream filter", "error", pingErr)
+		return true, nil
+	default:
+		log.FromContext(ctx).Error("stats backend is unreachable, skipping the cleanup", "failMode", mode, "error", pingErr)
+		return false, fmt.Errorf("%w: %w", ErrStatsUnreachable, pingErr)
+	}
+}
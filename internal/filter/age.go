@@ -0,0 +1,40 @@
+package filter
+
+import (
+	"time"
+
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+)
+
+// ArrAddedDate returns the date the item was added to Sonarr, Radarr or Readarr.
+// It's the zero time if the date is unknown.
+func ArrAddedDate(item arr.MediaItem) time.Time {
+	switch item.MediaType {
+	case models.MediaTypeMovie:
+		return item.MovieResource.GetAdded()
+	case models.MediaTypeTV:
+		return item.SeriesResource.GetAdded()
+	case models.MediaTypeBook:
+		return item.BookResource.Added
+	default:
+		return time.Time{}
+	}
+}
+
+// FallbackAgeDate returns the date the age filters use for items without an import history or Jellyseerr request.
+// It's the zero time if no fallback is configured or the date is unknown.
+func FallbackAgeDate(item arr.MediaItem, source config.FallbackAgeSource) time.Time {
+	switch source {
+	case config.FallbackAgeSourceAdded:
+		return ArrAddedDate(item)
+	case config.FallbackAgeSourceRelease:
+		if item.Year <= 0 {
+			return time.Time{}
+		}
+		return time.Date(int(item.Year), time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Time{}
+	}
+}
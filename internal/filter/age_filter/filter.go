@@ -2,6 +2,7 @@ package agefilter
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/charmbracelet/log"
@@ -14,28 +15,35 @@ import (
 
 // Filter implements the filter.Filterer interface.
 type Filter struct {
-	cfg    *config.Config
-	db     database.MediaDB
-	sonarr arr.Arrer
-	radarr arr.Arrer
+	cfg     *config.Config
+	db      database.MediaDB
+	sonarr  arr.Arrer
+	radarr  arr.Arrer
+	readarr arr.Arrer
 }
 
 var _ filter.Filterer = (*Filter)(nil)
 
 // New creates a new history Filter instance.
-func New(cfg *config.Config, db database.MediaDB, sonarr arr.Arrer, radarr arr.Arrer) *Filter {
+func New(cfg *config.Config, db database.MediaDB, sonarr, radarr, readarr arr.Arrer) *Filter {
 	return &Filter{
-		cfg:    cfg,
-		db:     db,
-		sonarr: sonarr,
-		radarr: radarr,
+		cfg:     cfg,
+		db:      db,
+		sonarr:  sonarr,
+		radarr:  radarr,
+		readarr: readarr,
 	}
 }
 
 // String returns the name of the filter.
 func (f *Filter) String() string { return "Age Filter" }
 
-// Apply filters out media items based on their deletion history.
+// Apply filters out media items that were imported or added recently.
+//
+// The content age is based on when the content landed in the library, not on the release year:
+// the content age threshold uses the first import after the last deletion from the arr history and
+// the newly added grace period uses the added date of the arr item.
+// Only items without any import history fall back to the fallback age source of their library, if configured.
 func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.MediaItem, error) {
 	filteredItems := make([]arr.MediaItem, 0)
 
@@ -46,6 +54,21 @@ func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.M
 		default:
 		}
 
+		if f.cfg.IsAlwaysEligibleRequester(item.LibraryName, item.RequestedBy) {
+			log.FromContext(ctx).Debug("including item of always eligible requester", "title", item.Title, "requestedBy", item.RequestedBy)
+			filteredItems = append(filteredItems, filter.WithReason(item, "requested by an always eligible requester"))
+			continue
+		}
+
+		libraryConfig := f.cfg.GetLibraryConfig(item.LibraryName)
+		if libraryConfig != nil && libraryConfig.Filter.NewlyAddedGraceDays > 0 {
+			added := filter.ArrAddedDate(item)
+			if !added.IsZero() && time.Since(added) < time.Duration(libraryConfig.Filter.NewlyAddedGraceDays)*24*time.Hour {
+				log.FromContext(ctx).Debug("excluding newly added item", "title", item.Title, "added", added.Format(time.RFC3339), "graceDays", libraryConfig.Filter.NewlyAddedGraceDays)
+				continue
+			}
+		}
+
 		// check if item was already deleted once
 		var deletedMedia []database.Media
 		var err error
@@ -55,7 +78,7 @@ func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.M
 			deletedMedia, err = f.db.GetDeletedMediaByTVDBID(ctx, item.TvdbId)
 		}
 		if err != nil {
-			log.Warn("Failed to check deleted media history", "title", item.Title, "error", err)
+			log.FromContext(ctx).Warn("Failed to check deleted media history", "title", item.Title, "error", err)
 		}
 
 		var lastDeleted time.Time
@@ -68,54 +91,64 @@ func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.M
 		}
 
 		if !lastDeleted.IsZero() {
-			log.Debug("Item was previously deleted", "title", item.Title, "last_deleted", lastDeleted)
+			log.FromContext(ctx).Debug("Item was previously deleted", "title", item.Title, "last_deleted", lastDeleted)
 		}
 
 		addedDate, err := f.getMediaItemAddedDate(ctx, item, lastDeleted)
 		if err != nil {
-			log.Error("failed to get added date for item", "title", item.Title, "error", err)
+			log.FromContext(ctx).Error("failed to get added date for item", "title", item.Title, "error", err)
 			// If we can't get the added date, continue processing but mark for deletion
 			// This maintains the current behavior for items without history
 			filteredItems = append(filteredItems, item)
 			continue
 		}
 
+		if addedDate == nil && libraryConfig != nil {
+			// No import history, e.g. for manually imported content, use the configured fallback
+			if fallback := filter.FallbackAgeDate(item, libraryConfig.Filter.FallbackAgeSource); !fallback.IsZero() {
+				log.FromContext(ctx).Debug("no import history for item, using fallback date", "title", item.Title, "source", libraryConfig.Filter.FallbackAgeSource, "date", fallback.Format(time.RFC3339))
+				addedDate = &fallback
+			}
+		}
+
 		if addedDate == nil {
 			// No added date found, include for deletion (maintaining current behavior)
 			filteredItems = append(filteredItems, item)
-			log.Debug("no added date for item, marking for deletion", "title", item.Title)
+			log.FromContext(ctx).Debug("no added date for item, marking for deletion", "title", item.Title)
 			continue
 		}
 
 		// Check if the content has been added longer ago than the configured threshold
-		libraryConfig := f.cfg.GetLibraryConfig(item.LibraryName)
 		if libraryConfig != nil {
-			contentAgeThreshold := time.Duration(libraryConfig.GetContentAgeThreshold()) * 24 * time.Hour
+			threshold := libraryConfig.GetContentAgeThresholdForRuntime(filter.ItemRuntime(item))
+			contentAgeThreshold := time.Duration(threshold) * 24 * time.Hour
 			timeSinceAdded := time.Since(*addedDate)
 
 			if timeSinceAdded > contentAgeThreshold {
-				filteredItems = append(filteredItems, item)
-				log.Debug("including item for deletion", "title", item.Title, "daysAgo", int(timeSinceAdded.Hours()/24), "threshold", libraryConfig.GetContentAgeThreshold())
+				filteredItems = append(filteredItems, filter.WithReason(item, fmt.Sprintf("added %d days ago", int(timeSinceAdded.Hours()/24))))
+				log.FromContext(ctx).Debug("including item for deletion", "title", item.Title, "daysAgo", int(timeSinceAdded.Hours()/24), "threshold", threshold)
 			} else {
-				log.Debug("excluding item due to recent addition", "title", item.Title, "addedDate", addedDate.Format(time.RFC3339), "daysAgo", int(timeSinceAdded.Hours()/24), "threshold", libraryConfig.GetContentAgeThreshold())
+				log.FromContext(ctx).Debug("excluding item due to recent addition", "title", item.Title, "addedDate", addedDate.Format(time.RFC3339), "daysAgo", int(timeSinceAdded.Hours()/24), "threshold", threshold)
 			}
 		} else {
 			// No library config, include for deletion
 			filteredItems = append(filteredItems, item)
-			log.Debug("no library config, marking for deletion", "library", item.LibraryName, "title", item.Title)
+			log.FromContext(ctx).Debug("no library config, marking for deletion", "library", item.LibraryName, "title", item.Title)
 		}
 	}
 
 	return filteredItems, nil
 }
 
-// getMediaItemAddedDate returns the first date when media content was added/imported for a given media item.
+// getMediaItemAddedDate returns the first date when media content was imported after since for a given media item.
 func (f *Filter) getMediaItemAddedDate(ctx context.Context, item arr.MediaItem, since time.Time) (*time.Time, error) {
 	switch item.MediaType {
 	case models.MediaTypeMovie:
 		return f.radarr.GetItemAddedDate(ctx, item.MovieResource.GetId(), since)
 	case models.MediaTypeTV:
 		return f.sonarr.GetItemAddedDate(ctx, item.SeriesResource.GetId(), since)
+	case models.MediaTypeBook:
+		return f.readarr.GetItemAddedDate(ctx, item.BookResource.ID, since)
 	default:
 		return nil, nil
 	}
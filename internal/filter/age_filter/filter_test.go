@@ -0,0 +1,118 @@
+package agefilter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devopsarr/radarr-go/radarr"
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeArr has no import history for any item.
+type fakeArr struct {
+	arr.Arrer
+}
+
+func (fakeArr) GetItemAddedDate(context.Context, int32, time.Time) (*time.Time, error) {
+	return nil, nil
+}
+
+func TestApplyNewlyAddedGrace(t *testing.T) {
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"Movies": {Enabled: true, Filter: config.FilterConfig{NewlyAddedGraceDays: 14}},
+		},
+	}
+	movie := func(title string, added time.Time) arr.MediaItem {
+		resource := radarr.NewMovieResource()
+		if !added.IsZero() {
+			resource.SetAdded(added)
+		}
+		return arr.MediaItem{
+			Title:         title,
+			LibraryName:   "Movies",
+			MediaType:     models.MediaTypeMovie,
+			Year:          1972,
+			MovieResource: *resource,
+		}
+	}
+
+	items := []arr.MediaItem{
+		movie("Just Added Classic", time.Now().AddDate(0, 0, -2)),
+		movie("Added Long Ago", time.Now().AddDate(0, 0, -60)),
+		movie("Unknown Added Date", time.Time{}),
+	}
+
+	f := New(cfg, nil, fakeArr{}, fakeArr{}, fakeArr{})
+	filtered, err := f.Apply(context.Background(), items)
+	require.NoError(t, err)
+
+	titles := make([]string, 0, len(filtered))
+	for _, item := range filtered {
+		titles = append(titles, item.Title)
+	}
+	assert.Equal(t, []string{"Added Long Ago", "Unknown Added Date"}, titles)
+}
+
+func TestApplyFallbackAgeSource(t *testing.T) {
+	movie := func(title string, year int32, added time.Time) arr.MediaItem {
+		resource := radarr.NewMovieResource()
+		resource.SetAdded(added)
+		return arr.MediaItem{
+			Title:         title,
+			LibraryName:   "Movies",
+			MediaType:     models.MediaTypeMovie,
+			Year:          year,
+			MovieResource: *resource,
+		}
+	}
+	items := []arr.MediaItem{
+		movie("Old Release, Just Added", 1972, time.Now().AddDate(0, 0, -2)),
+		movie("New Release, Added Long Ago", int32(time.Now().Year()+1), time.Now().AddDate(0, 0, -60)),
+	}
+
+	tests := []struct {
+		name   string
+		source config.FallbackAgeSource
+		want   []string
+	}{
+		{
+			name: "no fallback",
+			want: []string{"Old Release, Just Added", "New Release, Added Long Ago"},
+		},
+		{
+			name:   "added date",
+			source: config.FallbackAgeSourceAdded,
+			want:   []string{"New Release, Added Long Ago"},
+		},
+		{
+			name:   "release year",
+			source: config.FallbackAgeSourceRelease,
+			want:   []string{"Old Release, Just Added"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Libraries: map[string]*config.CleanupConfig{
+					"Movies": {Enabled: true, Filter: config.FilterConfig{ContentAgeThreshold: 30, FallbackAgeSource: tt.source}},
+				},
+			}
+
+			filtered, err := New(cfg, nil, fakeArr{}, fakeArr{}, fakeArr{}).Apply(context.Background(), items)
+			require.NoError(t, err)
+
+			titles := make([]string, 0, len(filtered))
+			for _, item := range filtered {
+				titles = append(titles, item.Title)
+			}
+			assert.Equal(t, tt.want, titles)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package collectionfilter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/engine/mediaserver"
+	"github.com/jon4hz/jellysweep/internal/filter"
+)
+
+// Filter implements the filter.Filterer interface for collection membership.
+type Filter struct {
+	cfg    *config.Config
+	server mediaserver.MediaServer
+}
+
+var _ filter.Filterer = (*Filter)(nil)
+
+// New creates a new collection Filter instance.
+func New(cfg *config.Config, server mediaserver.MediaServer) *Filter {
+	return &Filter{
+		cfg:    cfg,
+		server: server,
+	}
+}
+
+// String returns the name of the filter.
+func (f *Filter) String() string { return "Collection Filter" }
+
+// Apply excludes media items that are part of one of the protected collections configured for their library.
+// Collection names are matched case-insensitively. The leaving collections created by jellysweep are ignored.
+func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.MediaItem, error) {
+	itemIDs := make([]string, 0, len(mediaItems))
+	for _, item := range mediaItems {
+		if item.JellyfinID != "" && len(f.protectedCollections(item.LibraryName)) > 0 {
+			itemIDs = append(itemIDs, item.JellyfinID)
+		}
+	}
+	if len(itemIDs) == 0 {
+		return mediaItems, nil
+	}
+
+	membership, err := f.server.GetCollectionMembership(ctx, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection membership: %w", err)
+	}
+
+	filteredItems := make([]arr.MediaItem, 0, len(mediaItems))
+	for _, item := range mediaItems {
+		if collection, ok := f.findProtectedCollection(item, membership[item.JellyfinID]); ok {
+			log.FromContext(ctx).Debug("Excluding item due to protected collection", "item", item.Title, "library", item.LibraryName, "collection", collection)
+			continue
+		}
+		filteredItems = append(filteredItems, item)
+	}
+
+	return filteredItems, nil
+}
+
+// protectedCollections returns the protected collection names for the given library.
+func (f *Filter) protectedCollections(libraryName string) []string {
+	libraryConfig := f.cfg.GetLibraryConfig(libraryName)
+	if libraryConfig == nil {
+		return nil
+	}
+	return libraryConfig.Filter.ProtectCollections
+}
+
+// findProtectedCollection returns the first collection of the item that is protected in its library.
+func (f *Filter) findProtectedCollection(item arr.MediaItem, collections []string) (string, bool) {
+	protected := f.protectedCollections(item.LibraryName)
+	for _, collection := range collections {
+		if f.isLeavingCollection(collection) {
+			continue
+		}
+		for _, name := range protected {
+			if strings.EqualFold(collection, name) {
+				return collection, true
+			}
+		}
+	}
+	return "", false
+}
+
+// isLeavingCollection reports whether the collection is one of the leaving collections managed by jellysweep.
+func (f *Filter) isLeavingCollection(name string) bool {
+	return strings.EqualFold(name, f.cfg.LeavingCollectionsMovieName) ||
+		strings.EqualFold(name, f.cfg.LeavingCollectionsTVName)
+}
@@ -0,0 +1,86 @@
+package collectionfilter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/engine/mediaserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMediaServer returns the configured collections per item.
+type fakeMediaServer struct {
+	mediaserver.MediaServer
+	collections map[string][]string
+	requested   []string
+}
+
+func (f *fakeMediaServer) GetCollectionMembership(_ context.Context, itemIDs []string) (map[string][]string, error) {
+	f.requested = append(f.requested, itemIDs...)
+	membership := make(map[string][]string)
+	for _, id := range itemIDs {
+		if collections, ok := f.collections[id]; ok {
+			membership[id] = collections
+		}
+	}
+	return membership, nil
+}
+
+func titles(items []arr.MediaItem) []string {
+	titles := make([]string, 0, len(items))
+	for _, item := range items {
+		titles = append(titles, item.Title)
+	}
+	return titles
+}
+
+func TestApply(t *testing.T) {
+	cfg := &config.Config{
+		LeavingCollectionsMovieName: "Leaving Movies",
+		LeavingCollectionsTVName:    "Leaving TV Shows",
+		Libraries: map[string]*config.CleanupConfig{
+			"Movies": {Enabled: true, Filter: config.FilterConfig{ProtectCollections: []string{"Favorites", "Leaving Movies"}}},
+			"Shows":  {Enabled: true, Filter: config.FilterConfig{ProtectCollections: []string{"Classics"}}},
+		},
+	}
+	items := []arr.MediaItem{
+		{JellyfinID: "1", Title: "Favorite Movie", LibraryName: "Movies"},
+		{JellyfinID: "2", Title: "Classic Movie", LibraryName: "Movies"},
+		{JellyfinID: "3", Title: "Leaving Movie", LibraryName: "Movies"},
+		{JellyfinID: "4", Title: "Classic Show", LibraryName: "Shows"},
+		{JellyfinID: "5", Title: "Plain Show", LibraryName: "Shows"},
+	}
+	server := &fakeMediaServer{collections: map[string][]string{
+		"1": {"FAVORITES"},
+		"2": {"Classics"},
+		"3": {"leaving movies"},
+		"4": {"Other", "classics"},
+	}}
+
+	filtered, err := New(cfg, server).Apply(context.Background(), items)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Classic Movie", "Leaving Movie", "Plain Show"}, titles(filtered),
+		"collections match case-insensitively, per library and never as a leaving collection")
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, server.requested)
+}
+
+func TestApplyWithoutProtectedCollections(t *testing.T) {
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"Movies": {Enabled: true},
+		},
+	}
+	items := []arr.MediaItem{
+		{JellyfinID: "1", Title: "Favorite Movie", LibraryName: "Movies"},
+		{JellyfinID: "2", Title: "Unknown Library", LibraryName: "Other"},
+	}
+	server := &fakeMediaServer{collections: map[string][]string{"1": {"Favorites"}}}
+
+	filtered, err := New(cfg, server).Apply(context.Background(), items)
+	require.NoError(t, err)
+	assert.Equal(t, items, filtered)
+	assert.Empty(t, server.requested, "the media server isn't queried if no library protects collections")
+}
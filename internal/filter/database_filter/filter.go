@@ -2,6 +2,7 @@ package databasefilter
 
 import (
 	"context"
+	"slices"
 
 	"github.com/charmbracelet/log"
 	"github.com/jon4hz/jellysweep/internal/api/models"
@@ -27,25 +28,36 @@ func New(db database.MediaDB) *Filter {
 // String returns the name of the filter.
 func (f *Filter) String() string { return "Database Filter" }
 
-// Apply filters out media items that are already marked for deletion in the database.
+// Apply filters out media items that are permanently ignored or already marked for deletion in the database.
 func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.MediaItem, error) {
 	filteredItems := make([]arr.MediaItem, 0)
 
+	ignoredItems, err := f.db.GetIgnoredMedia(ctx)
+	if err != nil {
+		return nil, err
+	}
 	dbItems, err := f.db.GetMediaItems(ctx, true)
 	if err != nil {
 		return nil, err
 	}
 	for _, item := range mediaItems {
+		if slices.ContainsFunc(ignoredItems, func(dbItem database.Media) bool {
+			// the IDs of the arrs can overlap, so the media type has to match as well
+			return string(dbItem.MediaType) == string(item.MediaType) && arrItemIsEqual(item, dbItem)
+		}) {
+			log.FromContext(ctx).Debug("excluding permanently ignored item", "title", item.Title)
+			continue
+		}
 		markedForDeletion := false
 		for _, dbItem := range dbItems {
-			if arrItemIsEqual(item, dbItem) {
-				log.Debug("excluding item already marked for deletion in database", "title", item.Title)
+			if string(dbItem.MediaType) == string(item.MediaType) && arrItemIsEqual(item, dbItem) {
+				log.FromContext(ctx).Debug("excluding item already marked for deletion in database", "title", item.Title)
 				markedForDeletion = true
 				break
 			}
 		}
 		if !markedForDeletion {
-			log.Debug("including item not marked for deletion in database", "title", item.Title)
+			log.FromContext(ctx).Debug("including item not marked for deletion in database", "title", item.Title)
 			filteredItems = append(filteredItems, item)
 		}
 	}
@@ -59,6 +71,8 @@ func arrItemIsEqual(a arr.MediaItem, b database.Media) bool {
 		return a.MovieResource.GetId() == b.ArrID
 	case models.MediaTypeTV:
 		return a.SeriesResource.GetId() == b.ArrID
+	case models.MediaTypeBook:
+		return a.BookResource.ID == b.ArrID
 	default:
 		return false
 	}
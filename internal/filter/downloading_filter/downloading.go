@@ -0,0 +1,74 @@
+package downloadingfilter
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/filter"
+)
+
+// Filter implements the filter.Filterer interface for items with a download or import in progress.
+type Filter struct {
+	cfg    *config.Config
+	sonarr arr.Arrer
+	radarr arr.Arrer
+}
+
+var _ filter.Filterer = (*Filter)(nil)
+
+// New creates a new downloading Filter instance.
+func New(cfg *config.Config, sonarr arr.Arrer, radarr arr.Arrer) *Filter {
+	return &Filter{
+		cfg:    cfg,
+		sonarr: sonarr,
+		radarr: radarr,
+	}
+}
+
+// String returns the name of the filter.
+func (f *Filter) String() string { return "Downloading Filter" }
+
+// Apply excludes media items with a download or import in progress in the Sonarr/Radarr queue if skip_if_downloading is enabled.
+// Each queue is fetched once per run. If a queue can't be retrieved, the items of that arr are not excluded.
+func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.MediaItem, error) {
+	if !f.cfg.SkipIfDownloading {
+		return mediaItems, nil
+	}
+
+	seriesIDs := f.downloadingIDs(ctx, f.sonarr, "sonarr")
+	movieIDs := f.downloadingIDs(ctx, f.radarr, "radarr")
+
+	filteredItems := make([]arr.MediaItem, 0, len(mediaItems))
+	for _, item := range mediaItems {
+		var downloading bool
+		switch item.MediaType {
+		case models.MediaTypeTV:
+			downloading = seriesIDs[item.SeriesResource.GetId()]
+		case models.MediaTypeMovie:
+			downloading = movieIDs[item.MovieResource.GetId()]
+		}
+		if downloading {
+			log.FromContext(ctx).Debug("Excluding item with a download in progress", "title", item.Title, "library", item.LibraryName)
+			continue
+		}
+		filteredItems = append(filteredItems, item)
+	}
+
+	return filteredItems, nil
+}
+
+// downloadingIDs returns the IDs of the items with a download or import in progress in the queue of the arr.
+func (f *Filter) downloadingIDs(ctx context.Context, client arr.Arrer, name string) map[int32]bool {
+	if client == nil {
+		return nil
+	}
+	ids, err := client.GetDownloadingIDs(ctx)
+	if err != nil {
+		log.FromContext(ctx).Warn("Failed to get the download queue", "arr", name, "error", err)
+		return nil
+	}
+	return ids
+}
@@ -0,0 +1,103 @@
+package downloadingfilter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/devopsarr/radarr-go/radarr"
+	"github.com/devopsarr/sonarr-go/sonarr"
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	radarrarr "github.com/jon4hz/jellysweep/internal/engine/arr/radarr"
+	sonarrarr "github.com/jon4hz/jellysweep/internal/engine/arr/sonarr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sonarrQueueResponse is a trimmed response of the Sonarr queue endpoint.
+const sonarrQueueResponse = `{"page": 1, "pageSize": 250, "totalRecords": 3, "records": [
+	{"id": 1, "seriesId": 1, "status": "downloading", "trackedDownloadState": "downloading"},
+	{"id": 2, "seriesId": 2, "status": "completed", "trackedDownloadState": "importing"},
+	{"id": 3, "seriesId": 3, "status": "failed", "trackedDownloadState": "failed"}
+]}`
+
+// radarrQueueResponse is a trimmed response of the Radarr queue endpoint.
+const radarrQueueResponse = `{"page": 1, "pageSize": 250, "totalRecords": 3, "records": [
+	{"id": 1, "movieId": 10, "status": "completed", "trackedDownloadState": "importPending"},
+	{"id": 2, "movieId": 11, "status": "completed", "trackedDownloadState": "imported"},
+	{"id": 3, "status": "downloading", "trackedDownloadState": "downloading"}
+]}`
+
+func newQueueServer(t *testing.T, response string) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/queue" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func mediaItems() []arr.MediaItem {
+	var items []arr.MediaItem
+	for id, title := range map[int32]string{1: "Downloading Show", 2: "Importing Show", 3: "Failed Show", 4: "Idle Show"} {
+		series := sonarr.NewSeriesResource()
+		series.SetId(id)
+		items = append(items, arr.MediaItem{Title: title, MediaType: models.MediaTypeTV, SeriesResource: *series})
+	}
+	for id, title := range map[int32]string{10: "Pending Movie", 11: "Imported Movie", 12: "Idle Movie"} {
+		movie := radarr.NewMovieResource()
+		movie.SetId(id)
+		items = append(items, arr.MediaItem{Title: title, MediaType: models.MediaTypeMovie, MovieResource: *movie})
+	}
+	return items
+}
+
+func titles(items []arr.MediaItem) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.Title)
+	}
+	return result
+}
+
+func TestApply(t *testing.T) {
+	cfg := &config.Config{
+		SkipIfDownloading: true,
+		Sonarr:            &config.SonarrConfig{URL: newQueueServer(t, sonarrQueueResponse), APIKey: "key"},
+		Radarr:            &config.RadarrConfig{URL: newQueueServer(t, radarrQueueResponse), APIKey: "key"},
+	}
+	f := New(cfg, sonarrarr.NewSonarr(cfg, nil, nil), radarrarr.NewRadarr(cfg, nil, nil))
+
+	filtered, err := f.Apply(context.Background(), mediaItems())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Failed Show", "Idle Show", "Imported Movie", "Idle Movie"}, titles(filtered))
+
+	t.Run("disabled", func(t *testing.T) {
+		cfg.SkipIfDownloading = false
+		t.Cleanup(func() { cfg.SkipIfDownloading = true })
+
+		filtered, err := f.Apply(context.Background(), mediaItems())
+		require.NoError(t, err)
+		assert.Len(t, filtered, 7)
+	})
+
+	t.Run("queue unavailable", func(t *testing.T) {
+		cfg := &config.Config{
+			SkipIfDownloading: true,
+			Sonarr:            &config.SonarrConfig{URL: newQueueServer(t, "not json"), APIKey: "key"},
+		}
+		f := New(cfg, sonarrarr.NewSonarr(cfg, nil, nil), nil)
+
+		filtered, err := f.Apply(context.Background(), mediaItems())
+		require.NoError(t, err)
+		assert.Len(t, filtered, 7, "items aren't excluded if the queue can't be retrieved")
+	})
+}
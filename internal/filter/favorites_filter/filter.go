@@ -0,0 +1,67 @@
+package favoritesfilter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/engine/mediaserver"
+	"github.com/jon4hz/jellysweep/internal/filter"
+)
+
+// Filter implements the filter.Filterer interface for media server favorites.
+type Filter struct {
+	cfg    *config.Config
+	server mediaserver.MediaServer
+}
+
+var _ filter.Filterer = (*Filter)(nil)
+
+// New creates a new favorites Filter instance.
+func New(cfg *config.Config, server mediaserver.MediaServer) *Filter {
+	return &Filter{
+		cfg:    cfg,
+		server: server,
+	}
+}
+
+// String returns the name of the filter.
+func (f *Filter) String() string { return "Favorites Filter" }
+
+// Apply excludes media items that at least one user marked as favorite, if enabled for their library.
+// Only the movie or series itself counts, favorite episodes or seasons don't protect a series.
+func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.MediaItem, error) {
+	itemIDs := make([]string, 0, len(mediaItems))
+	for _, item := range mediaItems {
+		if item.JellyfinID != "" && f.enabled(item.LibraryName) {
+			itemIDs = append(itemIDs, item.JellyfinID)
+		}
+	}
+	if len(itemIDs) == 0 {
+		return mediaItems, nil
+	}
+
+	favoritedBy, err := f.server.GetFavoritedBy(ctx, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get favorites: %w", err)
+	}
+
+	filteredItems := make([]arr.MediaItem, 0, len(mediaItems))
+	for _, item := range mediaItems {
+		if users := favoritedBy[item.JellyfinID]; len(users) > 0 && f.enabled(item.LibraryName) {
+			log.FromContext(ctx).Debug("Excluding item marked as favorite", "item", item.Title, "library", item.LibraryName, "users", users)
+			continue
+		}
+		filteredItems = append(filteredItems, item)
+	}
+
+	return filteredItems, nil
+}
+
+// enabled reports whether favorites are protected in the given library.
+func (f *Filter) enabled(libraryName string) bool {
+	libraryConfig := f.cfg.GetLibraryConfig(libraryName)
+	return libraryConfig != nil && libraryConfig.Filter.ProtectFavorites
+}
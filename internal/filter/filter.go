@@ -3,6 +3,7 @@ package filter
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/jon4hz/jellysweep/internal/engine/arr"
@@ -15,6 +16,10 @@ type Filterer interface {
 	Apply(context.Context, []arr.MediaItem) ([]arr.MediaItem, error)
 }
 
+// StepFunc is called before a filter is applied with the name of the filter.
+// The returned function is called once the filter finished with the number of remaining items and the filter error.
+type StepFunc func(name string) func(remaining int, err error)
+
 // Filter applies all provided filters sequentially to media items.
 type Filter struct {
 	filters []Filterer
@@ -28,19 +33,60 @@ func New(filters ...Filterer) *Filter {
 }
 
 // ApplyAll applies all filters sequentially to the provided media items.
-func (f *Filter) ApplyAll(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.MediaItem, error) {
-	var err error
+// If onStep is not nil, it's called around every filter.
+func (f *Filter) ApplyAll(ctx context.Context, mediaItems []arr.MediaItem, onStep StepFunc) ([]arr.MediaItem, error) {
+	return f.applyAll(ctx, mediaItems, onStep, nil)
+}
+
+// ApplyAllTracked applies all filters sequentially like ApplyAll and additionally tracks which filter dropped an item.
+// The returned map holds the name of the dropping filter by the Jellyfin ID of each dropped item.
+func (f *Filter) ApplyAllTracked(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.MediaItem, map[string]string, error) {
+	droppedBy := make(map[string]string)
+	filteredItems, err := f.applyAll(ctx, mediaItems, nil, func(item arr.MediaItem, filter string) {
+		droppedBy[item.JellyfinID] = filter
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return filteredItems, droppedBy, nil
+}
+
+// applyAll applies all filters sequentially to the provided media items.
+// If onDrop is not nil, it's called for every item a filter dropped with the name of that filter.
+func (f *Filter) applyAll(ctx context.Context, mediaItems []arr.MediaItem, onStep StepFunc, onDrop func(arr.MediaItem, string)) ([]arr.MediaItem, error) {
 	filteredItems := mediaItems
 
 	for _, filter := range f.filters {
 		preFilterCount := len(filteredItems)
-		log.Info("Applying filter to media items.", "filter", filter.String(), "initial_items", preFilterCount)
-		filteredItems, err = filter.Apply(ctx, filteredItems)
+		log.FromContext(ctx).Info("Applying filter to media items.", "filter", filter.String(), "initial_items", preFilterCount)
+
+		var done func(int, error)
+		if onStep != nil {
+			done = onStep(filter.String())
+		}
+		start := time.Now()
+		remaining, err := filter.Apply(ctx, filteredItems)
+		if done != nil {
+			done(len(remaining), err)
+		}
 		if err != nil {
-			log.Error("Failed to apply filter.", "filter", filter.String(), "error", err)
-			return nil, err
+			log.FromContext(ctx).Error("Failed to apply filter.", "filter", filter.String(), "duration", time.Since(start), "error", err)
+			return nil, fmt.Errorf("failed to apply %s: %w", filter.String(), err)
+		}
+		log.FromContext(ctx).Info("Filter applied successfully.", "filter", filter.String(), "remaining_items", len(remaining), "filtered_out", preFilterCount-len(remaining), "duration", time.Since(start))
+
+		if onDrop != nil {
+			kept := make(map[string]struct{}, len(remaining))
+			for _, item := range remaining {
+				kept[item.JellyfinID] = struct{}{}
+			}
+			for _, item := range filteredItems {
+				if _, ok := kept[item.JellyfinID]; !ok {
+					onDrop(item, filter.String())
+				}
+			}
 		}
-		log.Info("Filter applied successfully.", "filter", filter.String(), "remaining_items", len(filteredItems), "filtered_out", preFilterCount-len(filteredItems))
+		filteredItems = remaining
 	}
 
 	return filteredItems, nil
@@ -0,0 +1,99 @@
+package filter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dropFilter drops the first n items.
+type dropFilter struct {
+	name string
+	n    int
+	err  error
+}
+
+func (f dropFilter) String() string { return f.name }
+
+func (f dropFilter) Apply(_ context.Context, items []arr.MediaItem) ([]arr.MediaItem, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return items[min(f.n, len(items)):], nil
+}
+
+type recordedStep struct {
+	name      string
+	remaining int
+	err       error
+}
+
+func recordSteps(steps *[]recordedStep) StepFunc {
+	return func(name string) func(int, error) {
+		return func(remaining int, err error) {
+			*steps = append(*steps, recordedStep{name: name, remaining: remaining, err: err})
+		}
+	}
+}
+
+func TestApplyAllReportsSteps(t *testing.T) {
+	items := make([]arr.MediaItem, 5)
+	f := New(dropFilter{name: "First Filter", n: 1}, dropFilter{name: "Second Filter", n: 3})
+
+	var steps []recordedStep
+	filtered, err := f.ApplyAll(context.Background(), items, recordSteps(&steps))
+	require.NoError(t, err)
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, []recordedStep{
+		{name: "First Filter", remaining: 4},
+		{name: "Second Filter", remaining: 1},
+	}, steps)
+}
+
+func TestApplyAllReportsFailedStep(t *testing.T) {
+	errFilter := errors.New("boom")
+	f := New(dropFilter{name: "Failing Filter", err: errFilter}, dropFilter{name: "Skipped Filter"})
+
+	var steps []recordedStep
+	_, err := f.ApplyAll(context.Background(), make([]arr.MediaItem, 2), recordSteps(&steps))
+	require.ErrorIs(t, err, errFilter)
+	assert.Equal(t, []recordedStep{{name: "Failing Filter", err: errFilter}}, steps)
+}
+
+func TestApplyAllWithoutSteps(t *testing.T) {
+	filtered, err := New(dropFilter{name: "Filter", n: 1}).ApplyAll(context.Background(), make([]arr.MediaItem, 2), nil)
+	require.NoError(t, err)
+	assert.Len(t, filtered, 1)
+}
+
+func TestApplyAllTracked(t *testing.T) {
+	items := []arr.MediaItem{{JellyfinID: "1"}, {JellyfinID: "2"}, {JellyfinID: "3"}, {JellyfinID: "4"}}
+	f := New(dropFilter{name: "First Filter", n: 1}, dropFilter{name: "Second Filter", n: 2})
+
+	filtered, droppedBy, err := f.ApplyAllTracked(context.Background(), items)
+	require.NoError(t, err)
+	assert.Equal(t, []arr.MediaItem{{JellyfinID: "4"}}, filtered)
+	assert.Equal(t, map[string]string{
+		"1": "First Filter",
+		"2": "Second Filter",
+		"3": "Second Filter",
+	}, droppedBy)
+
+	_, _, err = New(dropFilter{name: "Failing Filter", err: errors.New("boom")}).ApplyAllTracked(context.Background(), items)
+	require.Error(t, err)
+}
+
+func TestWithReason(t *testing.T) {
+	base := WithReason(arr.MediaItem{}, "added 120 days ago")
+	first := WithReason(base, "never played")
+	second := WithReason(base, "larger than 10 GB")
+
+	assert.Equal(t, "added 120 days ago", DeletionReason(base))
+	assert.Equal(t, "added 120 days ago, never played", DeletionReason(first))
+	assert.Equal(t, "added 120 days ago, larger than 10 GB", DeletionReason(second), "items sharing reasons don't affect each other")
+	assert.Empty(t, DeletionReason(arr.MediaItem{}))
+}
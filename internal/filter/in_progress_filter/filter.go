@@ -0,0 +1,71 @@
+package inprogressfilter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/engine/mediaserver"
+	"github.com/jon4hz/jellysweep/internal/filter"
+)
+
+// Filter implements the filter.Filterer interface for items in progress.
+type Filter struct {
+	cfg    *config.Config
+	server mediaserver.MediaServer
+}
+
+var _ filter.Filterer = (*Filter)(nil)
+
+// New creates a new in progress Filter instance.
+func New(cfg *config.Config, server mediaserver.MediaServer) *Filter {
+	return &Filter{
+		cfg:    cfg,
+		server: server,
+	}
+}
+
+// String returns the name of the filter.
+func (f *Filter) String() string { return "In Progress Filter" }
+
+// Apply excludes media items that any user started but didn't finish, if enabled for their library.
+// The stats services don't track resume positions, so they're read from the media server.
+func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.MediaItem, error) {
+	itemIDs := make([]string, 0, len(mediaItems))
+	for _, item := range mediaItems {
+		if item.JellyfinID != "" && f.enabled(item) {
+			itemIDs = append(itemIDs, item.JellyfinID)
+		}
+	}
+	if len(itemIDs) == 0 || f.server == nil {
+		return mediaItems, nil
+	}
+
+	inProgressBy, err := f.server.GetInProgressBy(ctx, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get items in progress: %w", err)
+	}
+
+	filteredItems := make([]arr.MediaItem, 0, len(mediaItems))
+	for _, item := range mediaItems {
+		if users := inProgressBy[item.JellyfinID]; len(users) > 0 && f.enabled(item) {
+			log.FromContext(ctx).Debug("Excluding item in progress", "item", item.Title, "library", item.LibraryName, "users", users)
+			continue
+		}
+		filteredItems = append(filteredItems, item)
+	}
+
+	return filteredItems, nil
+}
+
+// enabled reports whether the item is protected while in progress.
+// Items of always eligible requesters are never protected.
+func (f *Filter) enabled(item arr.MediaItem) bool {
+	libraryConfig := f.cfg.GetLibraryConfig(item.LibraryName)
+	if libraryConfig == nil || !libraryConfig.Filter.ProtectIfInProgressByAnyUser {
+		return false
+	}
+	return !f.cfg.IsAlwaysEligibleRequester(item.LibraryName, item.RequestedBy)
+}
@@ -0,0 +1,71 @@
+package inprogressfilter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/engine/mediaserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMediaServer returns the configured users with a resume position per item.
+type fakeMediaServer struct {
+	mediaserver.MediaServer
+	inProgressBy map[string][]string
+	requested    []string
+}
+
+func (f *fakeMediaServer) GetInProgressBy(_ context.Context, itemIDs []string) (map[string][]string, error) {
+	f.requested = append(f.requested, itemIDs...)
+	inProgressBy := make(map[string][]string)
+	for _, id := range itemIDs {
+		if users, ok := f.inProgressBy[id]; ok {
+			inProgressBy[id] = users
+		}
+	}
+	return inProgressBy, nil
+}
+
+func TestApply(t *testing.T) {
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"Movies": {Enabled: true, Filter: config.FilterConfig{ProtectIfInProgressByAnyUser: true}},
+			"Shows":  {Enabled: true},
+		},
+	}
+	items := []arr.MediaItem{
+		{JellyfinID: "1", Title: "Half Watched Movie", LibraryName: "Movies", MediaType: models.MediaTypeMovie},
+		{JellyfinID: "2", Title: "Old Movie", LibraryName: "Movies", MediaType: models.MediaTypeMovie},
+		{JellyfinID: "3", Title: "Half Watched Show", LibraryName: "Shows", MediaType: models.MediaTypeTV},
+	}
+
+	server := &fakeMediaServer{inProgressBy: map[string][]string{"1": {"alice"}, "3": {"bob"}}}
+	filtered, err := New(cfg, server).Apply(context.Background(), items)
+	require.NoError(t, err)
+
+	titles := make([]string, 0, len(filtered))
+	for _, item := range filtered {
+		titles = append(titles, item.Title)
+	}
+	assert.Equal(t, []string{"Old Movie", "Half Watched Show"}, titles, "only libraries with the option enabled protect items in progress")
+	assert.Equal(t, []string{"1", "2"}, server.requested)
+}
+
+func TestApplyDisabled(t *testing.T) {
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"Movies": {Enabled: true},
+		},
+	}
+	items := []arr.MediaItem{{JellyfinID: "1", Title: "Half Watched Movie", LibraryName: "Movies"}}
+
+	server := &fakeMediaServer{inProgressBy: map[string][]string{"1": {"alice"}}}
+	filtered, err := New(cfg, server).Apply(context.Background(), items)
+	require.NoError(t, err)
+	assert.Len(t, filtered, 1)
+	assert.Empty(t, server.requested, "the media server isn't queried if no library protects items in progress")
+}
@@ -0,0 +1,20 @@
+package filter
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+)
+
+// WithReason returns the item with a reason why it's eligible for deletion.
+// The reasons are copied, so items sharing the same reasons aren't affected.
+func WithReason(item arr.MediaItem, reason string) arr.MediaItem {
+	item.DeletionReasons = append(slices.Clip(item.DeletionReasons), reason)
+	return item
+}
+
+// DeletionReason joins the reasons why the item is eligible for deletion, empty if no filter added a reason.
+func DeletionReason(item arr.MediaItem) string {
+	return strings.Join(item.DeletionReasons, ", ")
+}
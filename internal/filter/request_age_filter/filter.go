@@ -0,0 +1,72 @@
+package requestagefilter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/filter"
+)
+
+// Filter implements the filter.Filterer interface.
+type Filter struct {
+	cfg *config.Config
+}
+
+var _ filter.Filterer = (*Filter)(nil)
+
+// New creates a new request age Filter instance.
+func New(cfg *config.Config) *Filter {
+	return &Filter{
+		cfg: cfg,
+	}
+}
+
+// String returns the name of the filter.
+func (f *Filter) String() string { return "Request Age Filter" }
+
+// Apply filters out media items whose Jellyseerr request is younger than the request age threshold of their library.
+// Items without a known request date use the fallback age source of their library and are treated as old enough without one.
+// Without Jellyseerr, no item has a request date and the date the item was added to the arr is used unless another source is set.
+func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.MediaItem, error) {
+	filteredItems := make([]arr.MediaItem, 0, len(mediaItems))
+	for _, item := range mediaItems {
+		libraryConfig := f.cfg.GetLibraryConfig(item.LibraryName)
+		if libraryConfig == nil || libraryConfig.Filter.RequestAgeThreshold <= 0 {
+			filteredItems = append(filteredItems, item)
+			continue
+		}
+
+		requestedAt := item.RequestedAt
+		if requestedAt == nil {
+			source := libraryConfig.Filter.FallbackAgeSource
+			if source == "" && f.cfg.Jellyseerr == nil {
+				source = config.FallbackAgeSourceAdded
+			}
+			fallback := filter.FallbackAgeDate(item, source)
+			if fallback.IsZero() {
+				filteredItems = append(filteredItems, item)
+				continue
+			}
+			requestedAt = &fallback
+		}
+
+		if f.cfg.IsAlwaysEligibleRequester(item.LibraryName, item.RequestedBy) {
+			log.FromContext(ctx).Debug("including item of always eligible requester", "title", item.Title, "requestedBy", item.RequestedBy)
+			filteredItems = append(filteredItems, item)
+			continue
+		}
+
+		threshold := time.Duration(libraryConfig.Filter.RequestAgeThreshold) * 24 * time.Hour
+		if time.Since(*requestedAt) < threshold {
+			log.FromContext(ctx).Debug("excluding recently requested item", "title", item.Title, "requestedAt", requestedAt.Format(time.RFC3339), "threshold", libraryConfig.Filter.RequestAgeThreshold)
+			continue
+		}
+		filteredItems = append(filteredItems, filter.WithReason(item, fmt.Sprintf("requested %d days ago", int(time.Since(*requestedAt).Hours()/24))))
+	}
+
+	return filteredItems, nil
+}
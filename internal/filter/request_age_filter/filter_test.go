@@ -0,0 +1,103 @@
+package requestagefilter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func daysAgo(days int) *time.Time {
+	t := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+	return &t
+}
+
+func TestApply(t *testing.T) {
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"Movies": {
+				Enabled: true,
+				Filter: config.FilterConfig{
+					RequestAgeThreshold:      30,
+					AlwaysEligibleRequesters: []string{"guest@example.com"},
+				},
+			},
+			"TV Shows": {Enabled: true},
+		},
+	}
+
+	items := []arr.MediaItem{
+		{Title: "Fresh Movie", LibraryName: "Movies", RequestedAt: daysAgo(5)},
+		{Title: "Old Movie", LibraryName: "Movies", RequestedAt: daysAgo(45)},
+		{Title: "Unrequested Movie", LibraryName: "Movies"},
+		{Title: "Guest Movie", LibraryName: "Movies", RequestedBy: "guest@example.com", RequestedAt: daysAgo(1)},
+		{Title: "Fresh Show", LibraryName: "TV Shows", RequestedAt: daysAgo(1)},
+	}
+
+	filtered, err := New(cfg).Apply(context.Background(), items)
+	require.NoError(t, err)
+
+	titles := make([]string, 0, len(filtered))
+	for _, item := range filtered {
+		titles = append(titles, item.Title)
+	}
+	assert.Equal(t, []string{"Old Movie", "Unrequested Movie", "Guest Movie", "Fresh Show"}, titles)
+}
+
+func TestApplyFallbackAgeSource(t *testing.T) {
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"Movies": {
+				Enabled: true,
+				Filter: config.FilterConfig{
+					RequestAgeThreshold: 30,
+					FallbackAgeSource:   config.FallbackAgeSourceRelease,
+				},
+			},
+		},
+	}
+
+	items := []arr.MediaItem{
+		{Title: "Imported Classic", LibraryName: "Movies", Year: 1972},
+		{Title: "Imported Upcoming", LibraryName: "Movies", Year: int32(time.Now().Year() + 1)},
+		{Title: "Imported Unknown Year", LibraryName: "Movies"},
+		{Title: "Fresh Request", LibraryName: "Movies", Year: 1972, RequestedAt: daysAgo(5)},
+	}
+
+	filtered, err := New(cfg).Apply(context.Background(), items)
+	require.NoError(t, err)
+
+	titles := make([]string, 0, len(filtered))
+	for _, item := range filtered {
+		titles = append(titles, item.Title)
+	}
+	assert.Equal(t, []string{"Imported Classic", "Imported Unknown Year"}, titles)
+}
+
+func TestApplyWithoutJellyseerr(t *testing.T) {
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"Movies": {Enabled: true, Filter: config.FilterConfig{RequestAgeThreshold: 30}},
+		},
+	}
+	imported := func(title string, added time.Time) arr.MediaItem {
+		item := arr.MediaItem{Title: title, LibraryName: "Movies", MediaType: models.MediaTypeMovie}
+		item.MovieResource.SetAdded(added)
+		return item
+	}
+
+	items := []arr.MediaItem{
+		imported("Imported Long Ago", *daysAgo(90)),
+		imported("Imported Recently", *daysAgo(5)),
+	}
+
+	filtered, err := New(cfg).Apply(context.Background(), items)
+	require.NoError(t, err)
+	require.Len(t, filtered, 1, "the added date is used without Jellyseerr")
+	assert.Equal(t, "Imported Long Ago", filtered[0].Title)
+}
@@ -0,0 +1,49 @@
+package requesterfilter
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/filter"
+)
+
+// Filter implements the filter.Filterer interface.
+type Filter struct {
+	cfg *config.Config
+}
+
+var _ filter.Filterer = (*Filter)(nil)
+
+// New creates a new requester Filter instance.
+func New(cfg *config.Config) *Filter {
+	return &Filter{
+		cfg: cfg,
+	}
+}
+
+// String returns the name of the filter.
+func (f *Filter) String() string { return "Requester Filter" }
+
+// Apply excludes media items requested by a protected requester or by too many distinct requesters.
+// Items of always eligible requesters are kept here and skip the age and stream filters.
+func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.MediaItem, error) {
+	filteredItems := make([]arr.MediaItem, 0, len(mediaItems))
+	for _, item := range mediaItems {
+		if f.cfg.IsProtectedRequester(item.LibraryName, item.RequestedBy) {
+			log.FromContext(ctx).Debug("excluding item requested by protected requester", "title", item.Title, "requestedBy", item.RequestedBy)
+			continue
+		}
+		if f.cfg.IsProtectedByRequesterCount(item.LibraryName, len(item.Requesters)) {
+			log.FromContext(ctx).Debug("excluding item requested by multiple users", "title", item.Title, "requesters", item.Requesters)
+			continue
+		}
+		if f.cfg.IsAlwaysEligibleRequester(item.LibraryName, item.RequestedBy) {
+			log.FromContext(ctx).Debug("item requested by always eligible requester", "title", item.Title, "requestedBy", item.RequestedBy)
+		}
+		filteredItems = append(filteredItems, item)
+	}
+
+	return filteredItems, nil
+}
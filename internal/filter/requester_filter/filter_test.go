@@ -0,0 +1,78 @@
+package requesterfilter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApply(t *testing.T) {
+	cfg := &config.Config{
+		ProtectRequesters: []string{"power@example.com"},
+		Libraries: map[string]*config.CleanupConfig{
+			"Movies": {
+				Enabled: true,
+				Filter: config.FilterConfig{
+					ProtectRequesters:        []string{"Movie-Fan@example.com"},
+					AlwaysEligibleRequesters: []string{"guest@example.com", "power@example.com"},
+				},
+			},
+			"TV Shows": {Enabled: true},
+		},
+	}
+
+	items := []arr.MediaItem{
+		{Title: "Power User Movie", LibraryName: "Movies", RequestedBy: "POWER@example.com"},
+		{Title: "Fan Movie", LibraryName: "Movies", RequestedBy: "movie-fan@example.com"},
+		{Title: "Fan Show", LibraryName: "TV Shows", RequestedBy: "movie-fan@example.com"},
+		{Title: "Guest Movie", LibraryName: "Movies", RequestedBy: "guest@example.com"},
+		{Title: "Unknown Movie", LibraryName: "Movies"},
+	}
+
+	filtered, err := New(cfg).Apply(context.Background(), items)
+	require.NoError(t, err)
+
+	titles := make([]string, 0, len(filtered))
+	for _, item := range filtered {
+		titles = append(titles, item.Title)
+	}
+	assert.Equal(t, []string{"Fan Show", "Guest Movie", "Unknown Movie"}, titles)
+
+	assert.True(t, cfg.IsAlwaysEligibleRequester("Movies", "guest@example.com"))
+	assert.False(t, cfg.IsAlwaysEligibleRequester("TV Shows", "guest@example.com"))
+	// protection takes precedence over always eligible
+	assert.False(t, cfg.IsAlwaysEligibleRequester("Movies", "power@example.com"))
+	// an unknown requester is neither protected nor always eligible
+	assert.False(t, cfg.IsProtectedRequester("Movies", ""))
+	assert.False(t, cfg.IsAlwaysEligibleRequester("Movies", ""))
+}
+
+func TestApplyProtectIfRequestedByMultiple(t *testing.T) {
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"Movies":   {Enabled: true, Filter: config.FilterConfig{ProtectIfRequestedByMultiple: 2}},
+			"TV Shows": {Enabled: true},
+		},
+	}
+
+	items := []arr.MediaItem{
+		{Title: "Shared Movie", LibraryName: "Movies", Requesters: []string{"a@example.com", "b@example.com"}},
+		{Title: "Single Movie", LibraryName: "Movies", Requesters: []string{"a@example.com"}},
+		{Title: "Unknown Movie", LibraryName: "Movies"},
+		{Title: "Shared Show", LibraryName: "TV Shows", Requesters: []string{"a@example.com", "b@example.com"}},
+	}
+
+	filtered, err := New(cfg).Apply(context.Background(), items)
+	require.NoError(t, err)
+
+	titles := make([]string, 0, len(filtered))
+	for _, item := range filtered {
+		titles = append(titles, item.Title)
+	}
+	assert.Equal(t, []string{"Single Movie", "Unknown Movie", "Shared Show"}, titles)
+	assert.True(t, cfg.HasRequesterRules())
+}
@@ -0,0 +1,19 @@
+package filter
+
+import (
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+)
+
+// ItemRuntime returns the runtime of a media item in minutes.
+// For series it's the episode runtime Sonarr reports for the series. It's 0 if the runtime is unknown, e.g. for books.
+func ItemRuntime(item arr.MediaItem) int {
+	switch item.MediaType {
+	case models.MediaTypeMovie:
+		return int(item.MovieResource.GetRuntime())
+	case models.MediaTypeTV:
+		return int(item.SeriesResource.GetRuntime())
+	default:
+		return 0
+	}
+}
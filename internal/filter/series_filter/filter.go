@@ -47,24 +47,24 @@ func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.M
 			continue
 		}
 
-		if f.shouldSkipSeriesForDeletion(item.SeriesResource, cleanupMode, keepCount) {
-			log.Debug("excluded series - already meets keep criteria", "title", item.Title, "cleanupMode", cleanupMode, "keepCount", keepCount)
+		if f.shouldSkipSeriesForDeletion(ctx, item.SeriesResource, cleanupMode, keepCount) {
+			log.FromContext(ctx).Debug("excluded series - already meets keep criteria", "title", item.Title, "cleanupMode", cleanupMode, "keepCount", keepCount)
 			skippedCount++
 		} else {
-			log.Debug("included series for deletion", "title", item.Title)
+			log.FromContext(ctx).Debug("included series for deletion", "title", item.Title)
 			filteredItems = append(filteredItems, item)
 		}
 	}
 
 	if skippedCount > 0 {
-		log.Info("total filtered out series that already meet keep criteria", "count", skippedCount)
+		log.FromContext(ctx).Info("total filtered out series that already meet keep criteria", "count", skippedCount)
 	}
 
 	return filteredItems, nil
 }
 
 // shouldSkipSeriesForDeletion checks if a series already meets the keep criteria and should not be marked for deletion.
-func (f *Filter) shouldSkipSeriesForDeletion(series sonarr.SeriesResource, cleanupMode config.CleanupMode, keepCount int) bool {
+func (f *Filter) shouldSkipSeriesForDeletion(ctx context.Context, series sonarr.SeriesResource, cleanupMode config.CleanupMode, keepCount int) bool {
 	if cleanupMode == config.CleanupModeAll {
 		// For "all" mode, we always want to delete the entire series, so never skip
 		return false
@@ -84,7 +84,7 @@ func (f *Filter) shouldSkipSeriesForDeletion(series sonarr.SeriesResource, clean
 	}
 
 	switch cleanupMode { //nolint: exhaustive
-	case config.CleanupModeKeepEpisodes:
+	case config.CleanupModeKeepEpisodes, config.CleanupModeKeepLatestEpisodes:
 		// Count regular episodes (excluding Season 0 specials) that have files
 		var regularEpisodesWithFiles int
 		for _, season := range seasons {
@@ -108,7 +108,7 @@ func (f *Filter) shouldSkipSeriesForDeletion(series sonarr.SeriesResource, clean
 
 		// If the series has exactly the desired number of episodes (or fewer), skip marking for deletion
 		if regularEpisodesWithFiles <= keepCount {
-			log.Debug("series has episodes <= keep count, skipping deletion", "title", series.GetTitle(), "episodes", regularEpisodesWithFiles, "keepCount", keepCount)
+			log.FromContext(ctx).Debug("series has episodes <= keep count, skipping deletion", "title", series.GetTitle(), "episodes", regularEpisodesWithFiles, "keepCount", keepCount)
 			return true
 		}
 
@@ -136,7 +136,7 @@ func (f *Filter) shouldSkipSeriesForDeletion(series sonarr.SeriesResource, clean
 
 		// If the series has exactly the desired number of seasons (or fewer), skip marking for deletion
 		if regularSeasonsWithFiles <= keepCount {
-			log.Debug("series has seasons <= keep count, skipping deletion", "title", series.GetTitle(), "seasons", regularSeasonsWithFiles, "keepCount", keepCount)
+			log.FromContext(ctx).Debug("series has seasons <= keep count, skipping deletion", "title", series.GetTitle(), "seasons", regularSeasonsWithFiles, "keepCount", keepCount)
 			return true
 		}
 	}
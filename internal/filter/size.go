@@ -0,0 +1,27 @@
+package filter
+
+import (
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+)
+
+// ItemSize returns the size on disk of a media item.
+// It returns false if the media type is unknown.
+func ItemSize(item arr.MediaItem) (int64, bool) {
+	switch item.MediaType {
+	case models.MediaTypeTV:
+		if item.SeriesResource.HasStatistics() {
+			stats := item.SeriesResource.GetStatistics()
+			if stats.HasSizeOnDisk() {
+				return stats.GetSizeOnDisk(), true
+			}
+		}
+		return 0, true
+	case models.MediaTypeMovie:
+		return item.MovieResource.GetSizeOnDisk(), true
+	case models.MediaTypeBook:
+		return item.BookResource.SizeOnDisk(), true
+	default:
+		return 0, false
+	}
+}
@@ -5,7 +5,6 @@ import (
 
 	"github.com/charmbracelet/log"
 	"github.com/dustin/go-humanize"
-	"github.com/jon4hz/jellysweep/internal/api/models"
 	"github.com/jon4hz/jellysweep/internal/config"
 	"github.com/jon4hz/jellysweep/internal/engine/arr"
 	"github.com/jon4hz/jellysweep/internal/filter"
@@ -30,6 +29,14 @@ func (f *Filter) String() string { return "Size Filter" }
 
 // Apply filters media items based on size-specific keep criteria.
 func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.MediaItem, error) {
+	// the library totals are computed once per run and are required for percent based thresholds
+	libraryTotals := make(map[string]int64)
+	for _, item := range mediaItems {
+		if fileSize, ok := filter.ItemSize(item); ok {
+			libraryTotals[item.LibraryName] += fileSize
+		}
+	}
+
 	filteredItems := make([]arr.MediaItem, 0)
 	for _, item := range mediaItems {
 		select {
@@ -39,38 +46,29 @@ func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.M
 		}
 
 		// Get the file size for this media item
-		var fileSize int64
-		switch item.MediaType {
-		case models.MediaTypeTV:
-			if item.SeriesResource.HasStatistics() {
-				stats := item.SeriesResource.GetStatistics()
-				if stats.HasSizeOnDisk() {
-					fileSize = stats.GetSizeOnDisk()
-				}
-			}
-		case models.MediaTypeMovie:
-			fileSize = item.MovieResource.GetSizeOnDisk()
-		default:
-			log.Warn("unknown media type for item", "mediaType", item.MediaType, "title", item.Title)
+		fileSize, ok := filter.ItemSize(item)
+		if !ok {
+			log.FromContext(ctx).Warn("unknown media type for item", "mediaType", item.MediaType, "title", item.Title)
 			continue
 		}
 
 		// Check if the content size meets the configured threshold
 		libraryConfig := f.cfg.GetLibraryConfig(item.LibraryName)
-		if libraryConfig != nil && libraryConfig.GetContentSizeThreshold() > 0 {
-			if fileSize >= libraryConfig.GetContentSizeThreshold() {
-				filteredItems = append(filteredItems, item)
-				log.Debug("including item for deletion", "title", item.Title, "size", humanize.Bytes(safeUint64(fileSize)), "threshold", humanize.Bytes(safeUint64(libraryConfig.GetContentSizeThreshold())))
+		threshold := sizeThreshold(libraryConfig, libraryTotals[item.LibraryName])
+		if threshold > 0 {
+			if fileSize >= threshold {
+				filteredItems = append(filteredItems, filter.WithReason(item, "larger than "+humanize.Bytes(safeUint64(threshold))))
+				log.FromContext(ctx).Debug("including item for deletion", "title", item.Title, "size", humanize.Bytes(safeUint64(fileSize)), "threshold", humanize.Bytes(safeUint64(threshold)))
 			} else {
-				log.Debug("excluding item due to small size", "title", item.Title, "size", humanize.Bytes(safeUint64(fileSize)), "threshold", humanize.Bytes(safeUint64(libraryConfig.GetContentSizeThreshold())))
+				log.FromContext(ctx).Debug("excluding item due to small size", "title", item.Title, "size", humanize.Bytes(safeUint64(fileSize)), "threshold", humanize.Bytes(safeUint64(threshold)))
 			}
 		} else {
 			// No size threshold configured or threshold is 0, include the item
 			filteredItems = append(filteredItems, item)
 			if libraryConfig == nil {
-				log.Debug("no library config, including for deletion", "library", item.LibraryName, "title", item.Title)
+				log.FromContext(ctx).Debug("no library config, including for deletion", "library", item.LibraryName, "title", item.Title)
 			} else {
-				log.Debug("no size threshold configured, including for deletion", "library", item.LibraryName, "title", item.Title)
+				log.FromContext(ctx).Debug("no size threshold configured, including for deletion", "library", item.LibraryName, "title", item.Title)
 			}
 		}
 	}
@@ -78,6 +76,19 @@ func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.M
 	return filteredItems, nil
 }
 
+// sizeThreshold returns the size threshold in bytes of a library with the given total size.
+// If both an absolute and a percent based threshold are configured, the larger one is used.
+func sizeThreshold(libraryConfig *config.CleanupConfig, libraryTotal int64) int64 {
+	if libraryConfig == nil {
+		return 0
+	}
+	threshold := libraryConfig.GetContentSizeThreshold()
+	if percent := libraryConfig.Filter.ContentSizeThresholdPercent; percent > 0 {
+		threshold = max(threshold, int64(float64(libraryTotal)*percent/100))
+	}
+	return threshold
+}
+
 // safeUint64 safely converts int64 to uint64, returning 0 for negative values.
 func safeUint64(value int64) uint64 {
 	if value < 0 {
@@ -0,0 +1,115 @@
+package sizefilter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devopsarr/radarr-go/radarr"
+	"github.com/devopsarr/sonarr-go/sonarr"
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gb = int64(1 << 30)
+
+func movie(title, library string, size int64) arr.MediaItem {
+	resource := radarr.MovieResource{}
+	resource.SetSizeOnDisk(size)
+	return arr.MediaItem{
+		Title:         title,
+		LibraryName:   library,
+		MediaType:     models.MediaTypeMovie,
+		MovieResource: resource,
+	}
+}
+
+func series(title, library string, size int64) arr.MediaItem {
+	stats := sonarr.SeriesStatisticsResource{}
+	stats.SetSizeOnDisk(size)
+	resource := sonarr.SeriesResource{}
+	resource.SetStatistics(stats)
+	return arr.MediaItem{
+		Title:          title,
+		LibraryName:    library,
+		MediaType:      models.MediaTypeTV,
+		SeriesResource: resource,
+	}
+}
+
+// mixedLibrary returns a library with a total size of 100 GB and a second small library.
+func mixedLibrary() []arr.MediaItem {
+	return []arr.MediaItem{
+		movie("Tiny", "Movies", 2*gb),
+		movie("Small", "Movies", 8*gb),
+		movie("Medium", "Movies", 20*gb),
+		movie("Large", "Movies", 70*gb),
+		series("Short Show", "TV Shows", 1*gb),
+		series("Long Show", "TV Shows", 9*gb),
+	}
+}
+
+func titles(items []arr.MediaItem) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.Title)
+	}
+	return result
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name      string
+		movies    config.FilterConfig
+		tvShows   config.FilterConfig
+		wantItems []string
+	}{
+		{
+			name:      "no thresholds",
+			wantItems: []string{"Tiny", "Small", "Medium", "Large", "Short Show", "Long Show"},
+		},
+		{
+			name:      "absolute threshold",
+			movies:    config.FilterConfig{ContentSizeThreshold: 10 * gb},
+			wantItems: []string{"Medium", "Large", "Short Show", "Long Show"},
+		},
+		{
+			name:      "percent threshold",
+			movies:    config.FilterConfig{ContentSizeThresholdPercent: 5},
+			wantItems: []string{"Small", "Medium", "Large", "Short Show", "Long Show"},
+		},
+		{
+			name:      "percent threshold per library",
+			movies:    config.FilterConfig{ContentSizeThresholdPercent: 50},
+			tvShows:   config.FilterConfig{ContentSizeThresholdPercent: 50},
+			wantItems: []string{"Large", "Long Show"},
+		},
+		{
+			name:      "percent threshold larger than absolute",
+			movies:    config.FilterConfig{ContentSizeThreshold: 1 * gb, ContentSizeThresholdPercent: 15},
+			wantItems: []string{"Medium", "Large", "Short Show", "Long Show"},
+		},
+		{
+			name:      "absolute threshold larger than percent",
+			movies:    config.FilterConfig{ContentSizeThreshold: 50 * gb, ContentSizeThresholdPercent: 15},
+			wantItems: []string{"Large", "Short Show", "Long Show"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New(&config.Config{
+				Libraries: map[string]*config.CleanupConfig{
+					"movies":   {Enabled: true, Filter: tt.movies},
+					"tv shows": {Enabled: true, Filter: tt.tvShows},
+				},
+			})
+
+			items, err := f.Apply(context.Background(), mixedLibrary())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantItems, titles(items))
+		})
+	}
+}
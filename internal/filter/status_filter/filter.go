@@ -0,0 +1,70 @@
+package statusfilter
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log"
+	"github.com/devopsarr/sonarr-go/sonarr"
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/filter"
+)
+
+// Filter implements the filter.Filterer interface.
+type Filter struct {
+	cfg *config.Config
+}
+
+var _ filter.Filterer = (*Filter)(nil)
+
+// New creates a new status Filter instance.
+func New(cfg *config.Config) *Filter {
+	return &Filter{
+		cfg: cfg,
+	}
+}
+
+// String returns the name of the filter.
+func (f *Filter) String() string { return "Status Filter" }
+
+// Apply excludes series which haven't ended yet and items which aren't monitored in their arr, if enabled for their library.
+func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.MediaItem, error) {
+	filteredItems := make([]arr.MediaItem, 0, len(mediaItems))
+	for _, item := range mediaItems {
+		libraryConfig := f.cfg.GetLibraryConfig(item.LibraryName)
+		if libraryConfig == nil {
+			filteredItems = append(filteredItems, item)
+			continue
+		}
+
+		if libraryConfig.Filter.OnlyCleanupEndedSeries && item.MediaType == models.MediaTypeTV &&
+			item.SeriesResource.GetStatus() != sonarr.SERIESSTATUSTYPE_ENDED {
+			log.FromContext(ctx).Debug("excluding series which hasn't ended", "title", item.Title, "status", item.SeriesResource.GetStatus())
+			continue
+		}
+
+		if libraryConfig.Filter.SkipUnmonitored && !monitored(item) {
+			log.FromContext(ctx).Debug("excluding unmonitored item", "title", item.Title, "type", item.MediaType)
+			continue
+		}
+
+		filteredItems = append(filteredItems, item)
+	}
+
+	return filteredItems, nil
+}
+
+// monitored reports whether the item is monitored in its arr.
+func monitored(item arr.MediaItem) bool {
+	switch item.MediaType {
+	case models.MediaTypeTV:
+		return item.SeriesResource.GetMonitored()
+	case models.MediaTypeMovie:
+		return item.MovieResource.GetMonitored()
+	case models.MediaTypeBook:
+		return item.BookResource.Monitored
+	default:
+		return true
+	}
+}
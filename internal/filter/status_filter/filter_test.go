@@ -0,0 +1,90 @@
+package statusfilter
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/devopsarr/radarr-go/radarr"
+	"github.com/devopsarr/sonarr-go/sonarr"
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/pkg/readarr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sonarrSeriesResponse is a trimmed response of the Sonarr series endpoint.
+const sonarrSeriesResponse = `[
+	{"id": 1, "title": "Running Show", "status": "continuing", "monitored": true},
+	{"id": 2, "title": "Finished Show", "status": "ended", "monitored": true},
+	{"id": 3, "title": "Abandoned Show", "status": "ended", "monitored": false},
+	{"id": 4, "title": "Upcoming Show", "status": "upcoming", "monitored": true}
+]`
+
+func seriesItems(t *testing.T, library string) []arr.MediaItem {
+	t.Helper()
+	var series []sonarr.SeriesResource
+	require.NoError(t, json.Unmarshal([]byte(sonarrSeriesResponse), &series))
+
+	items := make([]arr.MediaItem, 0, len(series))
+	for _, s := range series {
+		items = append(items, arr.MediaItem{
+			Title:          s.GetTitle(),
+			LibraryName:    library,
+			MediaType:      models.MediaTypeTV,
+			SeriesResource: s,
+		})
+	}
+	return items
+}
+
+func titles(items []arr.MediaItem) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.Title)
+	}
+	return result
+}
+
+func TestApplyOnlyEndedSeries(t *testing.T) {
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"TV Shows": {Enabled: true, Filter: config.FilterConfig{OnlyCleanupEndedSeries: true}},
+			"Anime":    {Enabled: true},
+		},
+	}
+
+	filtered, err := New(cfg).Apply(context.Background(), seriesItems(t, "TV Shows"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Finished Show", "Abandoned Show"}, titles(filtered))
+
+	// libraries without the flag keep continuing series
+	filtered, err = New(cfg).Apply(context.Background(), seriesItems(t, "Anime"))
+	require.NoError(t, err)
+	assert.Len(t, filtered, 4)
+}
+
+func TestApplySkipUnmonitored(t *testing.T) {
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"TV Shows": {Enabled: true, Filter: config.FilterConfig{SkipUnmonitored: true}},
+			"Movies":   {Enabled: true, Filter: config.FilterConfig{SkipUnmonitored: true}},
+			"Books":    {Enabled: true, Filter: config.FilterConfig{SkipUnmonitored: true}},
+		},
+	}
+
+	monitoredMovie := radarr.NewMovieResource()
+	monitoredMovie.SetMonitored(true)
+	items := append(seriesItems(t, "TV Shows"),
+		arr.MediaItem{Title: "Monitored Movie", LibraryName: "Movies", MediaType: models.MediaTypeMovie, MovieResource: *monitoredMovie},
+		arr.MediaItem{Title: "Unmonitored Movie", LibraryName: "Movies", MediaType: models.MediaTypeMovie, MovieResource: *radarr.NewMovieResource()},
+		arr.MediaItem{Title: "Monitored Book", LibraryName: "Books", MediaType: models.MediaTypeBook, BookResource: readarr.Book{Monitored: true}},
+		arr.MediaItem{Title: "Unmonitored Book", LibraryName: "Books", MediaType: models.MediaTypeBook},
+	)
+
+	filtered, err := New(cfg).Apply(context.Background(), items)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Running Show", "Finished Show", "Upcoming Show", "Monitored Movie", "Monitored Book"}, titles(filtered))
+}
@@ -3,11 +3,14 @@ package streamfilter
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/api/models"
 	"github.com/jon4hz/jellysweep/internal/config"
 	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/engine/mediaserver"
 	"github.com/jon4hz/jellysweep/internal/engine/stats"
 	"github.com/jon4hz/jellysweep/internal/filter"
 	"github.com/jon4hz/jellysweep/pkg/streamystats"
@@ -15,17 +18,19 @@ import (
 
 // Filter implements the filter.Filterer interface.
 type Filter struct {
-	cfg   *config.Config
-	stats stats.Statser
+	cfg    *config.Config
+	stats  stats.Statser
+	server mediaserver.MediaServer
 }
 
 var _ filter.Filterer = (*Filter)(nil)
 
 // New creates a new stream Filter instance.
-func New(cfg *config.Config, stats stats.Statser) *Filter {
+func New(cfg *config.Config, stats stats.Statser, server mediaserver.MediaServer) *Filter {
 	return &Filter{
-		cfg:   cfg,
-		stats: stats,
+		cfg:    cfg,
+		stats:  stats,
+		server: server,
 	}
 }
 
@@ -34,6 +39,11 @@ func (f *Filter) String() string { return "Stream Filter" }
 
 // Apply filters media items based on stream-specific keep criteria.
 func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.MediaItem, error) {
+	lastPlayedByAnyUser, err := f.lastPlayedByAnyUser(ctx, mediaItems)
+	if err != nil {
+		return nil, err
+	}
+
 	filteredItems := make([]arr.MediaItem, 0)
 	for _, item := range mediaItems {
 		select {
@@ -42,29 +52,95 @@ func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.M
 		default:
 		}
 
+		if f.cfg.IsAlwaysEligibleRequester(item.LibraryName, item.RequestedBy) {
+			log.FromContext(ctx).Debug("including item of always eligible requester", "title", item.Title, "requestedBy", item.RequestedBy)
+			filteredItems = append(filteredItems, item)
+			continue
+		}
+
 		lastStreamed, err := f.stats.GetItemLastPlayed(ctx, item.JellyfinID)
+		if item.MediaType == models.MediaTypeBook {
+			// Reading ebooks isn't tracked by the stats services. Without a playback (e.g. of an audiobook),
+			// the date the book was added is used instead, so new books aren't deleted right away.
+			if err != nil && !errors.Is(err, streamystats.ErrItemNotFound) {
+				log.FromContext(ctx).Warn("Failed to get last read time for book, using the date it was added", "title", item.Title, "error", err)
+			}
+			if err != nil || lastStreamed.IsZero() {
+				lastStreamed, err = item.BookResource.Added, nil
+			}
+		}
 		if err != nil {
 			if errors.Is(err, streamystats.ErrItemNotFound) {
-				log.Warn("Item not found in StreamyStats", "jellyfinID", item.JellyfinID)
-				log.Debug("Excluding item without streaming history", "jellyfinID", item.JellyfinID)
+				log.FromContext(ctx).Warn("Item not found in StreamyStats", "jellyfinID", item.JellyfinID)
+				log.FromContext(ctx).Debug("Excluding item without streaming history", "jellyfinID", item.JellyfinID)
 				continue
 			}
-			log.Error("Failed to get last streamed time for item", "jellyfinID", item.JellyfinID, "error", err)
+			log.FromContext(ctx).Error("Failed to get last streamed time for item", "jellyfinID", item.JellyfinID, "error", err)
 			return nil, err
 		}
+		libraryConfig := f.cfg.GetLibraryConfig(item.LibraryName)
+		if lastPlayed := lastPlayedByAnyUser[item.JellyfinID]; lastPlayed.After(lastStreamed) {
+			log.FromContext(ctx).Debug("using the latest play of any user", "title", item.Title, "lastPlayed", lastPlayed.Format(time.RFC3339))
+			lastStreamed = lastPlayed
+		}
 		if lastStreamed.IsZero() {
-			filteredItems = append(filteredItems, item) // No last streamed time, mark for deletion
+			filteredItems = append(filteredItems, filter.WithReason(item, "never played")) // No last streamed time, mark for deletion
 			continue
 		}
 		// Check if the last streamed time is older than the configured threshold
-		libraryConfig := f.cfg.GetLibraryConfig(item.LibraryName)
-		if libraryConfig != nil && time.Since(lastStreamed) > time.Duration(libraryConfig.GetLastStreamThreshold())*24*time.Hour {
-			log.Debug("including item - last streamed outside threshold", "title", item.Title, "lastStreamed", lastStreamed.Format(time.RFC3339))
-			filteredItems = append(filteredItems, item)
+		if libraryConfig != nil && time.Since(lastStreamed) > time.Duration(libraryConfig.GetLastStreamThresholdForRuntime(filter.ItemRuntime(item)))*24*time.Hour {
+			playCount, protected, err := f.protectedByPlayCount(ctx, item.JellyfinID, libraryConfig.Filter.MinHistoricalPlayCountProtect)
+			if err != nil {
+				log.FromContext(ctx).Error("Failed to get total play count for item", "jellyfinID", item.JellyfinID, "error", err)
+				return nil, err
+			}
+			if protected {
+				log.FromContext(ctx).Debug("excluding item due to historical play count", "title", item.Title, "playCount", playCount, "lastStreamed", lastStreamed.Format(time.RFC3339))
+				continue
+			}
+			log.FromContext(ctx).Debug("including item - last streamed outside threshold", "title", item.Title, "lastStreamed", lastStreamed.Format(time.RFC3339))
+			filteredItems = append(filteredItems, filter.WithReason(item, fmt.Sprintf("last played %d days ago", int(time.Since(lastStreamed).Hours()/24))))
 			continue
 		}
-		log.Debug("excluding item due to recent stream", "title", item.Title, "lastStreamed", lastStreamed.Format(time.RFC3339))
+		log.FromContext(ctx).Debug("excluding item due to recent stream", "title", item.Title, "lastStreamed", lastStreamed.Format(time.RFC3339))
 	}
 
 	return filteredItems, nil
 }
+
+// protectedByPlayCount reports whether the item was played at least minPlayCount times in total.
+// A minPlayCount of 0 never protects.
+func (f *Filter) protectedByPlayCount(ctx context.Context, itemID string, minPlayCount int) (int, bool, error) {
+	if minPlayCount <= 0 {
+		return 0, false, nil
+	}
+	playCount, err := f.stats.GetItemTotalPlayCount(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, streamystats.ErrItemNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return playCount, playCount >= minPlayCount, nil
+}
+
+// lastPlayedByAnyUser returns the latest play of any user for each of the items whose library uses it.
+// The stats services only report the plays of the whole server, so the plays per user are read from the media server.
+func (f *Filter) lastPlayedByAnyUser(ctx context.Context, mediaItems []arr.MediaItem) (map[string]time.Time, error) {
+	itemIDs := make([]string, 0)
+	for _, item := range mediaItems {
+		libraryConfig := f.cfg.GetLibraryConfig(item.LibraryName)
+		if item.JellyfinID != "" && libraryConfig != nil && libraryConfig.Filter.ProtectIfWatchedByAnyUser {
+			itemIDs = append(itemIDs, item.JellyfinID)
+		}
+	}
+	if len(itemIDs) == 0 || f.server == nil {
+		return nil, nil
+	}
+
+	lastPlayed, err := f.server.GetLastPlayedByAnyUser(ctx, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the last plays per user: %w", err)
+	}
+	return lastPlayed, nil
+}
@@ -0,0 +1,234 @@
+package streamfilter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/engine/mediaserver"
+	"github.com/jon4hz/jellysweep/internal/engine/stats"
+	"github.com/jon4hz/jellysweep/pkg/readarr"
+	"github.com/jon4hz/jellysweep/pkg/streamystats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStats returns the configured last played times, unknown items aren't found.
+type fakeStats struct {
+	stats.Statser
+	lastPlayed map[string]time.Time
+	playCounts map[string]int
+}
+
+func (f *fakeStats) GetItemLastPlayed(_ context.Context, itemID string) (time.Time, error) {
+	lastPlayed, ok := f.lastPlayed[itemID]
+	if !ok {
+		return time.Time{}, streamystats.ErrItemNotFound
+	}
+	return lastPlayed, nil
+}
+
+func (f *fakeStats) GetItemTotalPlayCount(_ context.Context, itemID string) (int, error) {
+	return f.playCounts[itemID], nil
+}
+
+func TestApplyBooksFallBackToAddedDate(t *testing.T) {
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"Books": {Enabled: true, Filter: config.FilterConfig{LastStreamThreshold: 30}},
+		},
+	}
+	now := time.Now()
+	book := func(id, title string, added time.Time) arr.MediaItem {
+		return arr.MediaItem{
+			JellyfinID:   id,
+			Title:        title,
+			LibraryName:  "Books",
+			MediaType:    models.MediaTypeBook,
+			BookResource: readarr.Book{Added: added},
+		}
+	}
+
+	items := []arr.MediaItem{
+		book("1", "Old Unread Book", now.AddDate(0, 0, -90)),
+		book("2", "New Unread Book", now.AddDate(0, 0, -5)),
+		book("3", "Recently Played Audiobook", now.AddDate(0, 0, -90)),
+		book("4", "Never Played Audiobook", now.AddDate(0, 0, -5)),
+		// movies without streaming history are still excluded
+		{JellyfinID: "5", Title: "Unknown Movie", LibraryName: "Books", MediaType: models.MediaTypeMovie},
+	}
+
+	f := New(cfg, &fakeStats{lastPlayed: map[string]time.Time{
+		"3": now.AddDate(0, 0, -1),
+		"4": {},
+	}}, nil)
+	filtered, err := f.Apply(context.Background(), items)
+	require.NoError(t, err)
+
+	titles := make([]string, 0, len(filtered))
+	for _, item := range filtered {
+		titles = append(titles, item.Title)
+	}
+	assert.Equal(t, []string{"Old Unread Book"}, titles)
+}
+
+// fakeMediaServer returns the configured latest play of any user per item.
+type fakeMediaServer struct {
+	mediaserver.MediaServer
+	lastPlayed map[string]time.Time
+	requested  []string
+}
+
+func (f *fakeMediaServer) GetLastPlayedByAnyUser(_ context.Context, itemIDs []string) (map[string]time.Time, error) {
+	f.requested = append(f.requested, itemIDs...)
+	return f.lastPlayed, nil
+}
+
+func TestApplyProtectIfWatchedByAnyUser(t *testing.T) {
+	now := time.Now()
+	statser := &fakeStats{
+		lastPlayed: map[string]time.Time{
+			"1": now.AddDate(0, 0, -90),
+			"2": now.AddDate(0, 0, -90),
+		},
+	}
+	// the global stats missed a recent play of a user with access to the restricted library
+	server := &fakeMediaServer{lastPlayed: map[string]time.Time{
+		"1": now.AddDate(0, 0, -2),
+		"2": now.AddDate(0, 0, -95),
+	}}
+	items := []arr.MediaItem{
+		{JellyfinID: "1", Title: "Watched By Bob", LibraryName: "Kids"},
+		{JellyfinID: "2", Title: "Watched Long Ago", LibraryName: "Kids"},
+	}
+
+	for _, tt := range []struct {
+		name          string
+		anyUser       bool
+		wantTitle     []string
+		wantRequested []string
+	}{
+		{name: "global stats only", anyUser: false, wantTitle: []string{"Watched By Bob", "Watched Long Ago"}},
+		{name: "any user", anyUser: true, wantTitle: []string{"Watched Long Ago"}, wantRequested: []string{"1", "2"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Libraries: map[string]*config.CleanupConfig{
+					"Kids": {Enabled: true, Filter: config.FilterConfig{LastStreamThreshold: 30, ProtectIfWatchedByAnyUser: tt.anyUser}},
+				},
+			}
+			server.requested = nil
+			filtered, err := New(cfg, statser, server).Apply(context.Background(), items)
+			require.NoError(t, err)
+
+			titles := make([]string, 0, len(filtered))
+			for _, item := range filtered {
+				titles = append(titles, item.Title)
+			}
+			assert.Equal(t, tt.wantTitle, titles)
+			assert.Equal(t, tt.wantRequested, server.requested, "the media server is only asked if the library uses the plays of every user")
+		})
+	}
+}
+
+func TestApplyMinHistoricalPlayCountProtect(t *testing.T) {
+	now := time.Now()
+	statser := &fakeStats{
+		lastPlayed: map[string]time.Time{
+			"1": now.AddDate(0, 0, -90),
+			"2": now.AddDate(0, 0, -90),
+			"3": now.AddDate(0, 0, -2),
+		},
+		playCounts: map[string]int{"1": 10, "2": 1, "3": 1},
+	}
+	items := []arr.MediaItem{
+		{JellyfinID: "1", Title: "Loved Movie", LibraryName: "Movies"},
+		{JellyfinID: "2", Title: "Watched Once", LibraryName: "Movies"},
+		{JellyfinID: "3", Title: "Watched Recently", LibraryName: "Movies"},
+	}
+
+	for _, tt := range []struct {
+		name         string
+		minPlayCount int
+		wantTitle    []string
+	}{
+		{name: "disabled", minPlayCount: 0, wantTitle: []string{"Loved Movie", "Watched Once"}},
+		{name: "threshold met", minPlayCount: 10, wantTitle: []string{"Watched Once"}},
+		{name: "threshold not met", minPlayCount: 11, wantTitle: []string{"Loved Movie", "Watched Once"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Libraries: map[string]*config.CleanupConfig{
+					"Movies": {Enabled: true, Filter: config.FilterConfig{LastStreamThreshold: 30, MinHistoricalPlayCountProtect: tt.minPlayCount}},
+				},
+			}
+			filtered, err := New(cfg, statser, nil).Apply(context.Background(), items)
+			require.NoError(t, err)
+
+			titles := make([]string, 0, len(filtered))
+			for _, item := range filtered {
+				titles = append(titles, item.Title)
+			}
+			assert.Equal(t, tt.wantTitle, titles)
+		})
+	}
+}
+
+func TestApplyAddsDeletionReason(t *testing.T) {
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"Movies": {Enabled: true, Filter: config.FilterConfig{LastStreamThreshold: 30}},
+		},
+	}
+	items := []arr.MediaItem{
+		{JellyfinID: "1", Title: "Never Played", LibraryName: "Movies", MediaType: models.MediaTypeMovie},
+		{JellyfinID: "2", Title: "Played Long Ago", LibraryName: "Movies", MediaType: models.MediaTypeMovie},
+	}
+
+	f := New(cfg, &fakeStats{lastPlayed: map[string]time.Time{
+		"1": {},
+		"2": time.Now().AddDate(0, 0, -90).Add(-time.Hour),
+	}}, nil)
+	filtered, err := f.Apply(context.Background(), items)
+	require.NoError(t, err)
+	require.Len(t, filtered, 2)
+
+	assert.Equal(t, []string{"never played"}, filtered[0].DeletionReasons)
+	assert.Equal(t, []string{"last played 90 days ago"}, filtered[1].DeletionReasons)
+}
+
+func TestApplyRuntimeRules(t *testing.T) {
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"Movies": {
+				Enabled:      true,
+				Filter:       config.FilterConfig{LastStreamThreshold: 90},
+				RuntimeRules: []config.RuntimeRule{{MaxRuntimeMinutes: 45, LastStreamThreshold: 14}},
+			},
+		},
+	}
+	movie := func(id, title string, runtime int32) arr.MediaItem {
+		item := arr.MediaItem{JellyfinID: id, Title: title, LibraryName: "Movies", MediaType: models.MediaTypeMovie}
+		item.MovieResource.SetRuntime(runtime)
+		return item
+	}
+	items := []arr.MediaItem{
+		movie("1", "Short Documentary", 30),
+		movie("2", "Feature Film", 150),
+		movie("3", "Unknown Runtime", 0),
+	}
+
+	lastPlayed := time.Now().AddDate(0, 0, -30)
+	f := New(cfg, &fakeStats{lastPlayed: map[string]time.Time{"1": lastPlayed, "2": lastPlayed, "3": lastPlayed}}, nil)
+	filtered, err := f.Apply(context.Background(), items)
+	require.NoError(t, err)
+
+	titles := make([]string, 0, len(filtered))
+	for _, item := range filtered {
+		titles = append(titles, item.Title)
+	}
+	assert.Equal(t, []string{"Short Documentary"}, titles, "only the short item uses the shorter threshold")
+}
@@ -0,0 +1,83 @@
+package subtitlefilter
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/jon4hz/jellysweep/internal/filter"
+)
+
+// Filter implements the filter.Filterer interface for external subtitle files.
+type Filter struct {
+	cfg    *config.Config
+	sonarr arr.Arrer
+	radarr arr.Arrer
+}
+
+var _ filter.Filterer = (*Filter)(nil)
+
+// New creates a new subtitle Filter instance.
+func New(cfg *config.Config, sonarr arr.Arrer, radarr arr.Arrer) *Filter {
+	return &Filter{
+		cfg:    cfg,
+		sonarr: sonarr,
+		radarr: radarr,
+	}
+}
+
+// String returns the name of the filter.
+func (f *Filter) String() string { return "Subtitle Filter" }
+
+// Apply excludes media items with external subtitle files if their library has protect_if_external_subtitles enabled.
+// The arr APIs are only queried for items in such libraries. Items whose subtitle info can't be retrieved are not protected.
+func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.MediaItem, error) {
+	filteredItems := make([]arr.MediaItem, 0, len(mediaItems))
+	for _, item := range mediaItems {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		libraryConfig := f.cfg.GetLibraryConfig(item.LibraryName)
+		if libraryConfig == nil || !libraryConfig.Filter.ProtectIfExternalSubtitles {
+			filteredItems = append(filteredItems, item)
+			continue
+		}
+
+		hasSubtitles, err := f.hasExternalSubtitles(ctx, item)
+		if err != nil {
+			log.FromContext(ctx).Warn("Failed to check external subtitles", "title", item.Title, "error", err)
+			filteredItems = append(filteredItems, item)
+			continue
+		}
+		if hasSubtitles {
+			log.FromContext(ctx).Debug("Excluding item with external subtitles", "title", item.Title, "library", item.LibraryName)
+			continue
+		}
+		filteredItems = append(filteredItems, item)
+	}
+
+	return filteredItems, nil
+}
+
+// hasExternalSubtitles asks the responsible arr whether the item has external subtitle files.
+func (f *Filter) hasExternalSubtitles(ctx context.Context, item arr.MediaItem) (bool, error) {
+	switch item.MediaType {
+	case models.MediaTypeMovie:
+		if f.radarr == nil {
+			return false, nil
+		}
+		return f.radarr.HasExternalSubtitles(ctx, item.MovieResource.GetId())
+	case models.MediaTypeTV:
+		if f.sonarr == nil {
+			return false, nil
+		}
+		return f.sonarr.HasExternalSubtitles(ctx, item.SeriesResource.GetId())
+	default:
+		return false, nil
+	}
+}
@@ -3,6 +3,7 @@ package tunarrfilter
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/log"
@@ -42,97 +43,183 @@ type ChannelPrograms struct {
 	jellyfinMovies map[string]bool
 	// Map of Jellyfin Show ID to whether any episode from that show is in use
 	jellyfinShows map[string]bool
+	// Maps of TMDB and TVDB IDs of movies and shows in use, independent of the program's media source
+	tmdbMovies map[int32]bool
+	tmdbShows  map[int32]bool
+	tvdbShows  map[int32]bool
+	// Maps of lowercase title and year of movies and shows in use, used if an item exposes no matching ID
+	movieTitles map[string]bool
+	showTitles  map[string]bool
 }
 
-// fetchAllChannelPrograms retrieves all programs from all channels and indexes them.
+// fetchAllChannelPrograms retrieves all programs from all channels matching the configured name patterns and indexes them.
 func (f *Filter) fetchAllChannelPrograms(ctx context.Context) (*ChannelPrograms, error) {
-	log.Debug("Fetching all Tunarr channels")
+	log.FromContext(ctx).Debug("Fetching all Tunarr channels")
 
 	channels, err := f.client.GetChannels(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get channels: %w", err)
 	}
 
-	log.Debug("found Tunarr channels", "count", len(channels))
+	log.FromContext(ctx).Debug("found Tunarr channels", "count", len(channels))
 
 	cp := &ChannelPrograms{
 		jellyfinMovies: make(map[string]bool),
 		jellyfinShows:  make(map[string]bool),
+		tmdbMovies:     make(map[int32]bool),
+		tmdbShows:      make(map[int32]bool),
+		tvdbShows:      make(map[int32]bool),
+		movieTitles:    make(map[string]bool),
+		showTitles:     make(map[string]bool),
 	}
 
 	// Fetch programs from all channels
 	for _, channel := range channels {
-		log.Debug("fetching programs for channel", "name", channel.Name, "id", channel.ID)
+		if !f.cfg.Tunarr.MatchesChannel(channel.Name) {
+			log.FromContext(ctx).Debug("skipping Tunarr channel not matching the channel name patterns", "name", channel.Name, "id", channel.ID)
+			continue
+		}
+
+		log.FromContext(ctx).Debug("fetching programs for channel", "name", channel.Name, "id", channel.ID)
 
 		programs, err := f.client.GetAllChannelPrograms(ctx, channel.ID)
 		if err != nil {
-			log.Warn("failed to get programs for channel", "name", channel.Name, "error", err)
+			log.FromContext(ctx).Warn("failed to get programs for channel", "name", channel.Name, "error", err)
 			continue
 		}
 
-		log.Debug("found programs in channel", "count", len(programs), "channel", channel.Name)
+		log.FromContext(ctx).Debug("found programs in channel", "count", len(programs), "channel", channel.Name)
 
-		// Index programs by their Jellyfin IDs
 		for _, program := range programs {
-			// Only process content from Jellyfin
-			if strings.ToLower(program.ExternalSourceType) != "jellyfin" { //nolint:goconst
-				continue
-			}
+			cp.index(program)
+		}
+	}
 
-			// Process movies
-			if program.Subtype == "movie" {
-				// Use the externalKey (Jellyfin item ID) as the identifier
-				if program.ExternalKey != "" {
-					cp.jellyfinMovies[program.ExternalKey] = true
-					// log.Debugf("Indexed movie: %s (Jellyfin ID: %s)", program.Title, program.ExternalKey)
-				}
-			}
+	log.FromContext(ctx).Info("indexed Tunarr channel content", "movies", len(cp.jellyfinMovies), "shows", len(cp.jellyfinShows),
+		"moviesByTitle", len(cp.movieTitles), "showsByTitle", len(cp.showTitles))
 
-			// Process episodes - we care about the show ID
-			if program.Subtype == "episode" {
-				// Get the show ID from grandparent or ShowID field
-				var showID string
-				if program.Grandparent != nil && program.Grandparent.ExternalKey != "" {
-					showID = program.Grandparent.ExternalKey
-				} else if program.ShowID != "" {
-					showID = program.ShowID
-				}
+	return cp, nil
+}
 
-				// Also check external IDs for Jellyfin multi-type IDs
-				if showID == "" {
-					for _, extID := range program.ExternalIDs {
-						if extID.Type == "multi" && strings.ToLower(extID.Source) == "jellyfin" {
-							// For episodes, we want the show ID, which might be in the parent
-							if program.Grandparent != nil {
-								for _, parentExtID := range program.Grandparent.ExternalIDs {
-									if parentExtID.Type == "multi" && strings.ToLower(parentExtID.Source) == "jellyfin" {
-										showID = parentExtID.ID
-										break
-									}
-								}
-							}
-							break
-						}
-					}
-				}
+// index adds a single program to the lookup maps.
+func (cp *ChannelPrograms) index(program tunarr.Program) {
+	fromJellyfin := strings.ToLower(program.ExternalSourceType) == "jellyfin" //nolint:goconst
+
+	switch program.Subtype {
+	case "movie":
+		// Use the externalKey (Jellyfin item ID) as the identifier
+		if fromJellyfin && program.ExternalKey != "" {
+			cp.jellyfinMovies[program.ExternalKey] = true
+		}
+		if id := externalID(program.ExternalIDs, "tmdb"); id != 0 {
+			cp.tmdbMovies[id] = true
+		}
+		if program.Title != "" {
+			cp.movieTitles[titleKey(program.Title, program.Year)] = true
+		}
+
+	case "episode":
+		// Episodes protect the whole show
+		if fromJellyfin {
+			if showID := jellyfinShowID(program); showID != "" {
+				cp.jellyfinShows[showID] = true
+			}
+		}
+		if program.Grandparent != nil {
+			if id := externalID(program.Grandparent.ExternalIDs, "tvdb"); id != 0 {
+				cp.tvdbShows[id] = true
+			}
+			if id := externalID(program.Grandparent.ExternalIDs, "tmdb"); id != 0 {
+				cp.tmdbShows[id] = true
+			}
+			if program.Grandparent.Title != "" {
+				cp.showTitles[titleKey(program.Grandparent.Title, program.Grandparent.Year)] = true
+			}
+		}
+	}
+}
+
+// jellyfinShowID returns the Jellyfin ID of the show an episode belongs to.
+func jellyfinShowID(program tunarr.Program) string {
+	// Get the show ID from grandparent or ShowID field
+	if program.Grandparent != nil && program.Grandparent.ExternalKey != "" {
+		return program.Grandparent.ExternalKey
+	}
+	if program.ShowID != "" {
+		return program.ShowID
+	}
 
-				if showID != "" {
-					cp.jellyfinShows[showID] = true
-					// log.Debugf("Indexed episode from show: %s (Show Jellyfin ID: %s)", program.Title, showID)
+	// Also check external IDs for Jellyfin multi-type IDs
+	if program.Grandparent == nil {
+		return ""
+	}
+	for _, extID := range program.ExternalIDs {
+		if extID.Type == "multi" && strings.ToLower(extID.Source) == "jellyfin" {
+			// For episodes, we want the show ID, which might be in the parent
+			for _, parentExtID := range program.Grandparent.ExternalIDs {
+				if parentExtID.Type == "multi" && strings.ToLower(parentExtID.Source) == "jellyfin" {
+					return parentExtID.ID
 				}
 			}
+			break
 		}
 	}
+	return ""
+}
 
-	log.Info("indexed Tunarr channel content", "movies", len(cp.jellyfinMovies), "shows", len(cp.jellyfinShows))
+// externalID returns the numeric ID of the given source, 0 if there is none.
+func externalID(ids []tunarr.ExternalID, source string) int32 {
+	for _, extID := range ids {
+		if strings.ToLower(extID.Source) != source {
+			continue
+		}
+		id, err := strconv.ParseInt(extID.ID, 10, 32)
+		if err == nil && id > 0 {
+			return int32(id)
+		}
+	}
+	return 0
+}
 
-	return cp, nil
+// titleKey builds the key of the title lookup maps.
+func titleKey(title string, year int) string {
+	return fmt.Sprintf("%s|%d", strings.ToLower(strings.TrimSpace(title)), year)
+}
+
+// inUse reports whether the item is used by a program and returns what matched.
+func (cp *ChannelPrograms) inUse(item arr.MediaItem) (bool, string) {
+	switch item.MediaType {
+	case models.MediaTypeMovie:
+		switch {
+		case item.JellyfinID != "" && cp.jellyfinMovies[item.JellyfinID]:
+			return true, "jellyfinID"
+		case item.TmdbId != 0 && cp.tmdbMovies[item.TmdbId]:
+			return true, "tmdbID"
+		case item.Title != "" && cp.movieTitles[titleKey(item.Title, int(item.Year))]:
+			return true, "title"
+		}
+
+	case models.MediaTypeTV:
+		// Check if any episode from this series is in any Tunarr channel
+		switch {
+		case item.JellyfinID != "" && cp.jellyfinShows[item.JellyfinID]:
+			return true, "jellyfinID"
+		case item.TvdbId != 0 && cp.tvdbShows[item.TvdbId]:
+			return true, "tvdbID"
+		case item.TmdbId != 0 && cp.tmdbShows[item.TmdbId]:
+			return true, "tmdbID"
+		case item.Title != "" && cp.showTitles[titleKey(item.Title, int(item.Year))]:
+			return true, "title"
+		}
+	}
+	return false, ""
 }
 
 // Apply filters media items based on whether they're being used in Tunarr channels.
-// For movies: checks if the movie's Jellyfin ID is in any channel.
-// For TV shows: checks if any episode from the series is in any channel.
-// Respects per-library tunarr_enabled setting in filter configuration.
+// For movies: checks if the movie is in any matching channel.
+// For TV shows: checks if any episode from the series is in any matching channel.
+// Items are matched by their Jellyfin ID, TMDB/TVDB ID or title and year.
+// Respects per-library tunarr_enabled setting in filter configuration, unless protect_all_libraries is set.
 func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.MediaItem, error) {
 	// Fetch all channel programs once
 	channelPrograms, err := f.fetchAllChannelPrograms(ctx)
@@ -143,41 +230,22 @@ func (f *Filter) Apply(ctx context.Context, mediaItems []arr.MediaItem) ([]arr.M
 	filteredItems := make([]arr.MediaItem, 0)
 
 	for _, item := range mediaItems {
-		skip := false
-
 		// Check if Tunarr filter is enabled for this library
-		libraryConfig := f.cfg.GetLibraryConfig(item.LibraryName)
-		tunarrEnabled := false
-		if libraryConfig != nil {
-			tunarrEnabled = libraryConfig.Filter.TunarrEnabled
+		tunarrEnabled := f.cfg.Tunarr.ProtectAllLibraries
+		if libraryConfig := f.cfg.GetLibraryConfig(item.LibraryName); libraryConfig != nil && libraryConfig.Filter.TunarrEnabled {
+			tunarrEnabled = true
 		}
 
 		// Only apply Tunarr filtering if enabled for this library
 		if tunarrEnabled {
-			switch item.MediaType {
-			case models.MediaTypeMovie:
-				// Check if this movie is in any Tunarr channel
-				if item.JellyfinID != "" && channelPrograms.jellyfinMovies[item.JellyfinID] {
-					log.Debug("Excluding movie due to tunarr usage", "movie", item.Title, "library", item.LibraryName, "jellyfinID", item.JellyfinID)
-					skip = true
-				}
-
-			case models.MediaTypeTV:
-				// Check if any episode from this series is in any Tunarr channel
-				// For series, we need to check the series' Jellyfin ID
-				if item.JellyfinID != "" && channelPrograms.jellyfinShows[item.JellyfinID] {
-					log.Debug("Excluding series due to tunarr usage", "series", item.Title, "library", item.LibraryName, "jellyfinID", item.JellyfinID)
-					skip = true
-				}
+			if used, matchedBy := channelPrograms.inUse(item); used {
+				log.FromContext(ctx).Debug("Excluding item due to tunarr usage", "item", item.Title, "type", item.MediaType, "library", item.LibraryName, "matchedBy", matchedBy)
+				continue
 			}
+			log.FromContext(ctx).Debug("Including item not used by tunarr", "item", item.Title, "library", item.LibraryName, "jellyfinID", item.JellyfinID)
 		}
 
-		if !skip {
-			filteredItems = append(filteredItems, item)
-			if tunarrEnabled {
-				log.Debug("Including item not used by tunarr", "item", item.Title, "library", item.LibraryName, "jellyfinID", item.JellyfinID)
-			}
-		}
+		filteredItems = append(filteredItems, item)
 	}
 
 	return filteredItems, nil
@@ -0,0 +1,125 @@
+package tunarrfilter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jon4hz/jellysweep/internal/api/models"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/engine/arr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const channelsResponse = `[
+	{"id": "movies", "name": "Movie Night", "number": 1, "programCount": 2},
+	{"id": "kids", "name": "Kids TV", "number": 2, "programCount": 1}
+]`
+
+// programmingResponses are trimmed responses of the channel programming endpoint.
+var programmingResponses = map[string]string{
+	"movies": `{"name": "Movie Night", "number": 1, "totalPrograms": 2, "programs": {
+		"p1": {"id": "p1", "type": "content", "subtype": "movie", "title": "Jellyfin Movie", "year": 2001,
+			"externalSourceType": "jellyfin", "externalKey": "jf-movie"},
+		"p2": {"id": "p2", "type": "content", "subtype": "movie", "title": "Plex Movie", "year": 2002,
+			"externalSourceType": "plex", "externalKey": "1234",
+			"externalIds": [{"type": "single", "source": "tmdb", "id": "42"}]}
+	}}`,
+	"kids": `{"name": "Kids TV", "number": 2, "totalPrograms": 1, "programs": {
+		"p3": {"id": "p3", "type": "content", "subtype": "episode", "title": "Pilot",
+			"externalSourceType": "plex", "externalKey": "5678",
+			"grandparent": {"id": "g1", "type": "show", "title": "Cartoon Show", "year": 1999,
+				"externalIds": [{"type": "single", "source": "tvdb", "id": "7"}]}}
+	}}`,
+}
+
+func newTunarrServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/channels", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(channelsResponse))
+	})
+	mux.HandleFunc("GET /api/channels/{id}/programming", func(w http.ResponseWriter, r *http.Request) {
+		response, ok := programmingResponses[r.PathValue("id")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testItems(library string) []arr.MediaItem {
+	return []arr.MediaItem{
+		{Title: "Jellyfin Movie", Year: 2001, JellyfinID: "jf-movie", LibraryName: library, MediaType: models.MediaTypeMovie},
+		{Title: "Renamed Movie", Year: 2002, TmdbId: 42, LibraryName: library, MediaType: models.MediaTypeMovie},
+		{Title: "Unused Movie", Year: 2003, TmdbId: 43, LibraryName: library, MediaType: models.MediaTypeMovie},
+		{Title: "Cartoon Show", Year: 1999, TvdbId: 8, LibraryName: library, MediaType: models.MediaTypeTV},
+		{Title: "Drama Show", Year: 1999, TvdbId: 9, LibraryName: library, MediaType: models.MediaTypeTV},
+	}
+}
+
+func titles(items []arr.MediaItem) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.Title)
+	}
+	return result
+}
+
+func newFilter(t *testing.T, tunarrCfg *config.TunarrConfig, libraries map[string]*config.CleanupConfig) *Filter {
+	t.Helper()
+	tunarrCfg.URL = newTunarrServer(t).URL
+	f, err := New(&config.Config{Tunarr: tunarrCfg, Libraries: libraries})
+	require.NoError(t, err)
+	return f
+}
+
+func TestApplyMatchesByIDAndTitle(t *testing.T) {
+	f := newFilter(t, &config.TunarrConfig{}, map[string]*config.CleanupConfig{
+		"Media": {Enabled: true, Filter: config.FilterConfig{TunarrEnabled: true}},
+	})
+
+	filtered, err := f.Apply(context.Background(), testItems("Media"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Unused Movie", "Drama Show"}, titles(filtered))
+}
+
+func TestApplyRespectsLibraryFlag(t *testing.T) {
+	libraries := map[string]*config.CleanupConfig{
+		"Media": {Enabled: true},
+	}
+
+	f := newFilter(t, &config.TunarrConfig{}, libraries)
+	filtered, err := f.Apply(context.Background(), testItems("Media"))
+	require.NoError(t, err)
+	assert.Len(t, filtered, 5, "tunarr is disabled for the library")
+
+	f = newFilter(t, &config.TunarrConfig{ProtectAllLibraries: true}, libraries)
+	filtered, err = f.Apply(context.Background(), testItems("Media"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Unused Movie", "Drama Show"}, titles(filtered))
+}
+
+func TestApplyChannelNamePatterns(t *testing.T) {
+	f := newFilter(t, &config.TunarrConfig{ProtectAllLibraries: true, ChannelNamePatterns: []string{"kids*"}}, nil)
+
+	filtered, err := f.Apply(context.Background(), testItems("Media"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Jellyfin Movie", "Renamed Movie", "Unused Movie", "Drama Show"}, titles(filtered))
+}
+
+func TestMatchesChannel(t *testing.T) {
+	cfg := &config.TunarrConfig{}
+	assert.True(t, cfg.MatchesChannel("anything"))
+
+	cfg.ChannelNamePatterns = []string{"Movie*", "*news"}
+	assert.True(t, cfg.MatchesChannel("movie night"))
+	assert.True(t, cfg.MatchesChannel("Evening News"))
+	assert.False(t, cfg.MatchesChannel("Kids TV"))
+}
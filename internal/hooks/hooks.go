@@ -0,0 +1,113 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/config"
+)
+
+// Payload holds the metadata of a media item passed to the hooks.
+type Payload struct {
+	Title     string `json:"title"`
+	Year      int32  `json:"year"`
+	MediaType string `json:"media_type"`
+	Library   string `json:"library"`
+	Path      string `json:"path"`
+	TmdbID    *int32 `json:"tmdb_id,omitempty"`
+	TvdbID    *int32 `json:"tvdb_id,omitempty"`
+	Size      int64  `json:"size"`
+}
+
+// Runner executes the configured hooks.
+// Hooks are executed sequentially to avoid hammering external systems.
+type Runner struct {
+	preDeleteCommand    string
+	preDeleteWebhookURL string
+	timeout             time.Duration
+	httpClient          *http.Client
+}
+
+// New creates a new hook runner.
+func New(cfg *config.HooksConfig) *Runner {
+	timeout := config.TimeoutDuration(cfg.Timeout)
+	return &Runner{
+		preDeleteCommand:    cfg.PreDeleteCommand,
+		preDeleteWebhookURL: cfg.PreDeleteWebhookURL,
+		timeout:             timeout,
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// PreDelete runs the pre-delete command and webhook for the given item.
+// An error is returned if any of the hooks failed, in which case the item must not be deleted.
+func (r *Runner) PreDelete(ctx context.Context, payload Payload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal hook payload: %w", err)
+	}
+
+	if r.preDeleteCommand != "" {
+		if err := r.runCommand(ctx, r.preDeleteCommand, data); err != nil {
+			return fmt.Errorf("pre-delete command failed: %w", err)
+		}
+	}
+
+	if r.preDeleteWebhookURL != "" {
+		if err := r.callWebhook(ctx, r.preDeleteWebhookURL, data); err != nil {
+			return fmt.Errorf("pre-delete webhook failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// runCommand executes the command through the shell and passes the payload over stdin.
+func (r *Runner) runCommand(ctx context.Context, command string, payload []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	cmd.Stdin = bytes.NewReader(payload)
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(output.String()))
+	}
+
+	log.Debug("Pre-delete command succeeded", "output", strings.TrimSpace(output.String()))
+	return nil
+}
+
+// callWebhook posts the payload to the webhook and expects a 2xx response.
+func (r *Runner) callWebhook(ctx context.Context, url string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPayload() Payload {
+	tmdbID := int32(603)
+	return Payload{
+		Title:     "The Matrix",
+		Year:      1999,
+		MediaType: "movie",
+		Library:   "Movies",
+		Path:      "/movies/The Matrix (1999)",
+		TmdbID:    &tmdbID,
+		Size:      1024,
+	}
+}
+
+func TestPreDeleteCommandPayload(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "payload.json")
+	r := New(&config.HooksConfig{PreDeleteCommand: "cat > '" + out + "'", Timeout: 5})
+
+	require.NoError(t, r.PreDelete(context.Background(), testPayload()))
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	var got Payload
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, testPayload(), got, "the payload is passed as JSON on stdin")
+}
+
+func TestPreDeleteCommandFailure(t *testing.T) {
+	r := New(&config.HooksConfig{PreDeleteCommand: "echo not allowed; exit 3", Timeout: 5})
+
+	err := r.PreDelete(context.Background(), testPayload())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exit status 3")
+	assert.Contains(t, err.Error(), "not allowed", "the output of the command is part of the error")
+}
+
+func TestPreDeleteCommandTimeout(t *testing.T) {
+	r := &Runner{preDeleteCommand: "exec sleep 5", timeout: 100 * time.Millisecond}
+
+	start := time.Now()
+	err := r.PreDelete(context.Background(), testPayload())
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 4*time.Second, "the command is killed after the timeout")
+}
+
+func TestPreDeleteWebhook(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "ok", status: http.StatusOK},
+		{name: "no content", status: http.StatusNoContent},
+		{name: "server error", status: http.StatusInternalServerError, wantErr: true},
+		{name: "redirect without location", status: http.StatusMultipleChoices, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Payload
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+				w.WriteHeader(tt.status)
+			}))
+			t.Cleanup(server.Close)
+
+			r := New(&config.HooksConfig{PreDeleteWebhookURL: server.URL, Timeout: 5})
+			err := r.PreDelete(context.Background(), testPayload())
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "webhook returned status")
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, testPayload(), got)
+		})
+	}
+}
+
+func TestPreDeleteCommandFailureSkipsWebhook(t *testing.T) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	t.Cleanup(server.Close)
+
+	r := New(&config.HooksConfig{PreDeleteCommand: "exit 1", PreDeleteWebhookURL: server.URL, Timeout: 5})
+	require.Error(t, r.PreDelete(context.Background(), testPayload()))
+	assert.False(t, called, "the webhook isn't called once the command failed")
+}
@@ -27,6 +27,20 @@ func SetLevel(level string) {
 	}
 }
 
+// SetFormat sets the global log format. Valid values: text, json.
+// Defaults to text.
+func SetFormat(format string) {
+	switch format {
+	case "", "text":
+		log.SetFormatter(log.TextFormatter)
+	case "json":
+		log.SetFormatter(log.JSONFormatter)
+	default:
+		log.Warn("unknown log format, defaulting to text", "format", format)
+		log.SetFormatter(log.TextFormatter)
+	}
+}
+
 // SetOutputFile sets logs destination file. If path is non-empty, logs to
 // both stdout and file; otherwise stdout only.
 func SetOutputFile(path string) {
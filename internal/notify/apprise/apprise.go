@@ -0,0 +1,176 @@
+package apprise
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/config"
+)
+
+// Notification types supported by apprise.
+const (
+	TypeInfo    = "info"
+	TypeSuccess = "success"
+	TypeWarning = "warning"
+	TypeFailure = "failure"
+)
+
+// Client represents an apprise API notification client.
+type Client struct {
+	notifyURL  string
+	urls       []string
+	httpClient *http.Client
+}
+
+// Message represents an apprise notification.
+type Message struct {
+	// URLs are the apprise URLs to notify, only used if no config key is configured.
+	URLs   string `json:"urls,omitempty"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	Type   string `json:"type,omitempty"`
+	Format string `json:"format,omitempty"`
+	// Attach is the URL of a file apprise downloads and attaches to the notification.
+	Attach string `json:"attach,omitempty"`
+}
+
+// MediaItem represents a media item for notifications.
+type MediaItem struct {
+	Title string
+	Type  string // "movie" or "tv"
+	Year  int32
+}
+
+// NewClient creates a new apprise client.
+// With a config key, the URLs stored in the apprise API server are notified, otherwise the configured URLs.
+func NewClient(cfg *config.AppriseConfig) *Client {
+	// Validate server URL
+	if cfg.ServerURL != "" {
+		if _, err := url.Parse(cfg.ServerURL); err != nil {
+			log.Error("invalid apprise server URL", "error", err)
+		}
+	}
+
+	notifyURL := cfg.ServerURL + "/notify/"
+	if cfg.ConfigKey != "" {
+		notifyURL += url.PathEscape(cfg.ConfigKey)
+	}
+
+	return &Client{
+		notifyURL: notifyURL,
+		urls:      cfg.URLs,
+		httpClient: &http.Client{
+			Timeout: config.TimeoutDuration(cfg.Timeout),
+		},
+	}
+}
+
+// SendMessage sends a notification through the apprise API.
+func (c *Client) SendMessage(ctx context.Context, msg Message) error {
+	if msg.URLs == "" && len(c.urls) > 0 {
+		msg.URLs = strings.Join(c.urls, " ")
+	}
+	if msg.Type == "" {
+		msg.Type = TypeInfo
+	}
+	if msg.Format == "" {
+		msg.Format = "markdown"
+	}
+
+	jsonData, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.notifyURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode >= 400 {
+		// Try to read response body for better error information
+		var errorMsg strings.Builder
+		if resp.Body != nil {
+			buf := make([]byte, 256)
+			if n, _ := resp.Body.Read(buf); n > 0 {
+				errorMsg.WriteString(": ")
+				errorMsg.Write(buf[:n])
+			}
+		}
+		return fmt.Errorf("apprise server returned status %d%s", resp.StatusCode, errorMsg.String())
+	}
+
+	log.Debug("Sent apprise notification", "title", msg.Title)
+	return nil
+}
+
+// SendKeepRequest sends a notification about a new keep request.
+// If posterURL is set, the poster is attached to the notification.
+func (c *Client) SendKeepRequest(ctx context.Context, mediaTitle, mediaType, username, posterURL string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**User:** %s  \n", username)
+	fmt.Fprintf(&b, "**Type:** %s  \n", mediaType)
+	fmt.Fprintf(&b, "**Title:** %s\n\n", mediaTitle)
+	b.WriteString("Please review this keep request in the admin panel.")
+
+	return c.SendMessage(ctx, Message{
+		Title:  "Jellysweep Keep Request",
+		Body:   b.String(),
+		Attach: posterURL,
+	})
+}
+
+// SendDeletionFailed sends a notification about a deletion that failed too many times and was given up.
+func (c *Client) SendDeletionFailed(ctx context.Context, mediaTitle, mediaType, errMsg string, attempts int) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Type:** %s  \n", mediaType)
+	fmt.Fprintf(&b, "**Title:** %s  \n", mediaTitle)
+	fmt.Fprintf(&b, "**Attempts:** %d  \n", attempts)
+	fmt.Fprintf(&b, "**Error:** %s\n\n", errMsg)
+	b.WriteString("The deletion won't be retried anymore, please check the item manually.")
+
+	return c.SendMessage(ctx, Message{
+		Title: "Jellysweep Deletion Failed",
+		Body:  b.String(),
+		Type:  TypeFailure,
+	})
+}
+
+// SendDeletionSummary sends a summary of media marked for deletion.
+func (c *Client) SendDeletionSummary(ctx context.Context, totalItems int, libraries map[string][]MediaItem) error {
+	if totalItems == 0 {
+		log.Debug("No media marked for deletion, skipping apprise notification")
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Total Items:** %d\n", totalItems)
+	for library, items := range libraries {
+		fmt.Fprintf(&b, "\n**%s** (%d items)\n", library, len(items))
+		for _, item := range items {
+			fmt.Fprintf(&b, "- %s (%d)\n", item.Title, item.Year)
+		}
+	}
+	b.WriteString("\nMedia will be deleted after the cleanup delay period.")
+
+	return c.SendMessage(ctx, Message{
+		Title: "Jellysweep Cleanup Summary",
+		Body:  b.String(),
+		Type:  TypeWarning,
+	})
+}
@@ -2,6 +2,7 @@ package email
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"embed"
 	"fmt"
@@ -10,12 +11,16 @@ import (
 
 	"github.com/charmbracelet/log"
 	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/notify/email/emailtemplate"
 	mail "github.com/xhit/go-simple-mail/v2"
 )
 
 // NotificationService handles email notifications for cleanup actions.
 type NotificationService struct {
-	config *config.EmailConfig
+	config    *config.EmailConfig
+	templates *template.Template
+	// cleanupTemplate is the custom template of the cleanup notification, nil if the embedded default is used.
+	cleanupTemplate *template.Template
 }
 
 // MediaItem represents a media item that was marked for deletion.
@@ -23,6 +28,15 @@ type MediaItem struct {
 	Title       string
 	MediaType   string
 	RequestedBy string
+	// Overview and Genres are only set if TMDB is configured.
+	// PosterURL falls back to the default poster without a TMDB poster.
+	Overview  string
+	Genres    []string
+	PosterURL string
+	// DeletionDate is the date the item gets deleted.
+	DeletionDate time.Time
+	// DeletionReason explains why the item was marked for deletion, empty if unknown.
+	DeletionReason string
 }
 
 // UserNotification contains the data for a user's notification email.
@@ -35,16 +49,139 @@ type UserNotification struct {
 	DryRun        bool
 }
 
+// ServerURL returns the URL of the Jellysweep server, so custom templates can use {{.ServerURL}}.
+func (n UserNotification) ServerURL() string {
+	return n.JellysweepURL
+}
+
+// ProtectionExpiryNotification contains the data for a reminder email about expiring protection.
+type ProtectionExpiryNotification struct {
+	UserEmail     string
+	UserName      string
+	MediaItem     MediaItem
+	ExpiresAt     time.Time
+	JellysweepURL string
+	DryRun        bool
+}
+
+// KeptMediaDeletedNotification contains the data for an email about formerly kept media that was deleted.
+type KeptMediaDeletedNotification struct {
+	UserEmail string
+	UserName  string
+	MediaItem MediaItem
+	// JellyseerrURL links to the media in Jellyseerr, empty if Jellyseerr isn't configured.
+	JellyseerrURL string
+	JellysweepURL string
+	DryRun        bool
+}
+
+// RequesterSummary is the number of marked items requested by a single user.
+type RequesterSummary struct {
+	UserEmail string
+	Items     int
+}
+
+// AdminSummaryNotification contains the data for the summary email that replaces the user notifications of a large run.
+type AdminSummaryNotification struct {
+	AdminEmail    string
+	Requesters    []RequesterSummary
+	MediaItems    []MediaItem
+	JellysweepURL string
+	DryRun        bool
+}
+
 // New creates a new email notification service.
+// The custom cleanup template is loaded from the configured path, falling back to the embedded default if it can't be parsed.
 func New(cfg *config.EmailConfig) *NotificationService {
-	return &NotificationService{
-		config: cfg,
+	n := &NotificationService{
+		config:    cfg,
+		templates: template.Must(template.New("").Funcs(emailtemplate.Funcs(cfg.Locale)).ParseFS(templatesFS, "templates/*.html")),
+	}
+
+	if cfg.TemplatePath != "" {
+		t, err := emailtemplate.ParseFile(cfg.TemplatePath, cfg.Locale)
+		if err != nil {
+			log.Error("Failed to load email template, using the default template", "path", cfg.TemplatePath, "error", err)
+		} else {
+			n.cleanupTemplate = t
+		}
+	}
+
+	return n
+}
+
+// SendCleanupNotification sends an email notification to users about their media being marked for deletion.
+// Use a Session to send multiple notifications over a single connection.
+func (n *NotificationService) SendCleanupNotification(ctx context.Context, notification UserNotification) error {
+	session := n.NewSession()
+	defer session.Close()
+	return session.SendCleanupNotification(ctx, notification)
+}
+
+// SendProtectionExpiryNotification reminds a user that the protection of media they requested to keep expires soon.
+func (n *NotificationService) SendProtectionExpiryNotification(ctx context.Context, notification ProtectionExpiryNotification) error {
+	session := n.NewSession()
+	defer session.Close()
+	return session.SendProtectionExpiryNotification(ctx, notification)
+}
+
+// SendKeptMediaDeletedNotification informs a user that media they requested to keep was deleted after its protection expired.
+func (n *NotificationService) SendKeptMediaDeletedNotification(ctx context.Context, notification KeptMediaDeletedNotification) error {
+	session := n.NewSession()
+	defer session.Close()
+	return session.SendKeptMediaDeletedNotification(ctx, notification)
+}
+
+// SendAdminSummaryNotification sends a single summary of all marked media to the admin.
+func (n *NotificationService) SendAdminSummaryNotification(ctx context.Context, notification AdminSummaryNotification) error {
+	session := n.NewSession()
+	defer session.Close()
+	return session.SendAdminSummaryNotification(ctx, notification)
+}
+
+//go:embed templates/*.html
+var templatesFS embed.FS
+
+// generateEmailBody creates the HTML email body.
+func (n *NotificationService) generateEmailBody(notification UserNotification) (string, error) {
+	if n.cleanupTemplate != nil {
+		var buf bytes.Buffer
+		if err := n.cleanupTemplate.Execute(&buf, notification); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	return n.renderTemplate("email.html", notification)
+}
+
+// renderTemplate renders the given embedded email template with the provided data.
+func (n *NotificationService) renderTemplate(name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := n.templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
 	}
+
+	return buf.String(), nil
+}
+
+// retryDelay is the delay before the first retry of a failed email, doubled after every attempt.
+var retryDelay = 2 * time.Second
+
+// Session sends multiple emails over a single SMTP connection.
+// The connection is opened on the first email and must be closed with Close.
+type Session struct {
+	n      *NotificationService
+	client *mail.SMTPClient
+}
+
+// NewSession creates a new session for sending multiple emails.
+func (n *NotificationService) NewSession() *Session {
+	return &Session{n: n}
 }
 
 // SendCleanupNotification sends an email notification to users about their media being marked for deletion.
-func (n *NotificationService) SendCleanupNotification(notification UserNotification) error {
-	if !n.config.Enabled {
+func (s *Session) SendCleanupNotification(ctx context.Context, notification UserNotification) error {
+	if !s.n.config.Enabled {
 		log.Debug("Email notifications are disabled, skipping notification")
 		return nil
 	}
@@ -65,35 +202,171 @@ func (n *NotificationService) SendCleanupNotification(notification UserNotificat
 		return nil
 	}
 
-	body, err := n.generateEmailBody(notification)
+	body, err := s.n.generateEmailBody(notification)
 	if err != nil {
 		return fmt.Errorf("failed to generate email body: %w", err)
 	}
 
-	return n.sendEmail(notification.UserEmail, subject, body)
+	return s.send(ctx, notification.UserEmail, subject, body)
 }
 
-//go:embed templates/*.html
-var templatesFS embed.FS
+// SendProtectionExpiryNotification reminds a user that the protection of media they requested to keep expires soon.
+func (s *Session) SendProtectionExpiryNotification(ctx context.Context, notification ProtectionExpiryNotification) error {
+	if !s.n.config.Enabled {
+		log.Debug("Email notifications are disabled, skipping notification")
+		return nil
+	}
 
-// generateEmailBody creates the HTML email body.
-func (n *NotificationService) generateEmailBody(notification UserNotification) (string, error) {
-	t, err := template.New("").ParseFS(templatesFS, "templates/*.html")
+	if notification.UserEmail == "" {
+		log.Warn("User email is empty, skipping notification", "user", notification.UserName)
+		return nil
+	}
+
+	subject := fmt.Sprintf("[Jellysweep] Protection of %s expires soon", notification.MediaItem.Title)
+
+	if notification.DryRun {
+		log.Debug("DRY RUN: Would send email notification",
+			"to", notification.UserEmail,
+			"subject", subject)
+		return nil
+	}
+
+	body, err := s.n.renderTemplate("protection_expiry.html", notification)
 	if err != nil {
-		return "", err
+		return fmt.Errorf("failed to generate email body: %w", err)
 	}
 
-	var buf bytes.Buffer
-	if err := t.ExecuteTemplate(&buf, "email.html", notification); err != nil {
-		return "", err
+	return s.send(ctx, notification.UserEmail, subject, body)
+}
+
+// SendKeptMediaDeletedNotification informs a user that media they requested to keep was deleted after its protection expired.
+func (s *Session) SendKeptMediaDeletedNotification(ctx context.Context, notification KeptMediaDeletedNotification) error {
+	if !s.n.config.Enabled {
+		log.Debug("Email notifications are disabled, skipping notification")
+		return nil
 	}
 
-	return buf.String(), nil
+	if notification.UserEmail == "" {
+		log.Warn("User email is empty, skipping notification", "user", notification.UserName)
+		return nil
+	}
+
+	subject := fmt.Sprintf("[Jellysweep] %s was deleted", notification.MediaItem.Title)
+
+	if notification.DryRun {
+		log.Debug("DRY RUN: Would send email notification",
+			"to", notification.UserEmail,
+			"subject", subject)
+		return nil
+	}
+
+	body, err := s.n.renderTemplate("kept_media_deleted.html", notification)
+	if err != nil {
+		return fmt.Errorf("failed to generate email body: %w", err)
+	}
+
+	return s.send(ctx, notification.UserEmail, subject, body)
+}
+
+// SendAdminSummaryNotification sends a single summary of all marked media to the admin.
+func (s *Session) SendAdminSummaryNotification(ctx context.Context, notification AdminSummaryNotification) error {
+	if !s.n.config.Enabled {
+		log.Debug("Email notifications are disabled, skipping notification")
+		return nil
+	}
+
+	if notification.AdminEmail == "" {
+		log.Warn("Admin email is empty, skipping summary notification")
+		return nil
+	}
+
+	subject := fmt.Sprintf("[Jellysweep] Media Cleanup Summary - %d items affected", len(notification.MediaItems))
+
+	if notification.DryRun {
+		log.Debug("DRY RUN: Would send email notification",
+			"to", notification.AdminEmail,
+			"subject", subject,
+			"media_count", len(notification.MediaItems))
+		return nil
+	}
+
+	body, err := s.n.renderTemplate("admin_summary.html", notification)
+	if err != nil {
+		return fmt.Errorf("failed to generate email body: %w", err)
+	}
+
+	return s.send(ctx, notification.AdminEmail, subject, body)
+}
+
+// Close closes the SMTP connection of the session.
+func (s *Session) Close() {
+	if s.client == nil {
+		return
+	}
+	if err := s.client.Quit(); err != nil {
+		log.Debug("Failed to quit SMTP session", "error", err)
+	}
+	if err := s.client.Close(); err != nil {
+		log.Warn("Failed to close SMTP client", "error", err)
+	}
+	s.client = nil
+}
+
+// send sends an email, retrying transient failures with exponential backoff.
+// It stops waiting for the next attempt once ctx is done.
+func (s *Session) send(ctx context.Context, to, subject, body string) error {
+	email := s.n.newMessage(to, subject, body)
+	if email.Error != nil {
+		return fmt.Errorf("failed to create email: %w", email.Error)
+	}
+
+	var err error
+	for attempt := 0; attempt <= s.n.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			delay := retryDelay << (attempt - 1)
+			log.Warn("Retrying email", "to", to, "attempt", attempt, "delay", delay, "error", err)
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return fmt.Errorf("%w: %w", ctx.Err(), err)
+			case <-timer.C:
+			}
+		}
+
+		if err = s.trySend(email); err == nil {
+			log.Info("Email notification sent successfully", "to", to, "subject", subject)
+			return nil
+		}
+	}
+
+	return err
+}
+
+// trySend sends the email over the session connection, connecting first if necessary.
+// The connection is dropped after a failure, so the next attempt starts with a fresh connection.
+func (s *Session) trySend(email *mail.Email) error {
+	if s.client == nil {
+		client, err := s.n.smtpServer().Connect()
+		if err != nil {
+			return fmt.Errorf("failed to connect to SMTP server: %w", err)
+		}
+		s.client = client
+	}
+
+	if err := email.Send(s.client); err != nil {
+		if closeErr := s.client.Close(); closeErr != nil {
+			log.Debug("Failed to close SMTP client", "error", closeErr)
+		}
+		s.client = nil
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
 }
 
-// sendEmail sends an email using go-simple-mail library.
-func (n *NotificationService) sendEmail(to, subject, body string) error {
-	// Create SMTP server configuration
+// smtpServer returns the SMTP server configuration.
+func (n *NotificationService) smtpServer() *mail.SMTPServer {
 	server := mail.NewSMTPClient()
 	server.Host = n.config.SMTPHost
 	server.Port = n.config.SMTPPort
@@ -114,23 +387,16 @@ func (n *NotificationService) sendEmail(to, subject, body string) error {
 		server.TLSConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec
 	}
 
-	// Keep connection alive for sending multiple emails if needed
-	server.KeepAlive = false
-	server.ConnectTimeout = 10 * time.Second
-	server.SendTimeout = 10 * time.Second
+	// Keep the connection alive to send all emails of a session over it
+	server.KeepAlive = true
+	server.ConnectTimeout = config.TimeoutDuration(n.config.TimeoutSeconds)
+	server.SendTimeout = config.TimeoutDuration(n.config.TimeoutSeconds)
 
-	// Create SMTP client
-	smtpClient, err := server.Connect()
-	if err != nil {
-		return fmt.Errorf("failed to connect to SMTP server: %w", err)
-	}
-	defer func() {
-		if closeErr := smtpClient.Close(); closeErr != nil {
-			log.Warn("Failed to close SMTP client", "error", closeErr)
-		}
-	}()
+	return server
+}
 
-	// Create email
+// newMessage creates a new HTML email.
+func (n *NotificationService) newMessage(to, subject, body string) *mail.Email {
 	email := mail.NewMSG()
 
 	// Set sender
@@ -141,18 +407,8 @@ func (n *NotificationService) sendEmail(to, subject, body string) error {
 	email.SetFrom(fmt.Sprintf("%s <%s>", fromName, n.config.FromEmail))
 
 	email.AddTo(to)
-
-	// Set subject
 	email.SetSubject(subject)
-
-	// Set HTML body
 	email.SetBody(mail.TextHTML, body)
 
-	// Send email
-	if err := email.Send(smtpClient); err != nil {
-		return fmt.Errorf("failed to send email: %w", err)
-	}
-
-	log.Info("Email notification sent successfully", "to", to, "subject", subject)
-	return nil
+	return email
 }
@@ -0,0 +1,295 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer is a minimal SMTP server that records connections and delivered messages.
+type fakeSMTPServer struct {
+	listener net.Listener
+
+	mu          sync.Mutex
+	connections int
+	recipients  []string
+	// failures is the number of DATA commands that are rejected with a transient error.
+	failures int
+}
+
+func newFakeSMTPServer(t *testing.T, failures int) *fakeSMTPServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &fakeSMTPServer{listener: listener, failures: failures}
+	go s.serve()
+	t.Cleanup(func() { listener.Close() }) //nolint:errcheck
+
+	return s
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.connections++
+		s.mu.Unlock()
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close() //nolint:errcheck
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		_, _ = conn.Write([]byte(line + "\r\n"))
+	}
+
+	reply("220 localhost ESMTP fake")
+	var rcpt string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL FROM"), strings.HasPrefix(cmd, "RSET"), strings.HasPrefix(cmd, "NOOP"):
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO"):
+			rcpt = strings.Trim(strings.TrimSpace(line)[len("RCPT TO:"):], "<>")
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "DATA"):
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+			}
+			s.mu.Lock()
+			if s.failures > 0 {
+				s.failures--
+				s.mu.Unlock()
+				reply("451 Temporary failure")
+				continue
+			}
+			s.recipients = append(s.recipients, rcpt)
+			s.mu.Unlock()
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "QUIT"):
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func newTestService(port, maxRetries int) *NotificationService {
+	return New(&config.EmailConfig{
+		Enabled:        true,
+		SMTPHost:       "127.0.0.1",
+		SMTPPort:       port,
+		FromEmail:      "jellysweep@example.com",
+		MaxRetries:     maxRetries,
+		TimeoutSeconds: 5,
+	})
+}
+
+func testNotification(to string) UserNotification {
+	return UserNotification{
+		UserEmail:   to,
+		UserName:    to,
+		MediaItems:  []MediaItem{{Title: "Movie", MediaType: "movie"}},
+		CleanupDate: time.Now(),
+	}
+}
+
+func TestSessionReusesConnection(t *testing.T) {
+	server := newFakeSMTPServer(t, 0)
+	service := newTestService(server.port(), 0)
+
+	session := service.NewSession()
+	for i := range 3 {
+		require.NoError(t, session.SendCleanupNotification(context.Background(), testNotification("user"+strconv.Itoa(i)+"@example.com")))
+	}
+	session.Close()
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Equal(t, 1, server.connections)
+	assert.Equal(t, []string{"user0@example.com", "user1@example.com", "user2@example.com"}, server.recipients)
+}
+
+func TestSessionRetriesTransientFailures(t *testing.T) {
+	retryDelay = time.Millisecond
+
+	t.Run("succeeds after retry", func(t *testing.T) {
+		server := newFakeSMTPServer(t, 2)
+		service := newTestService(server.port(), 2)
+
+		session := service.NewSession()
+		defer session.Close()
+		require.NoError(t, session.SendCleanupNotification(context.Background(), testNotification("user@example.com")))
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		assert.Equal(t, []string{"user@example.com"}, server.recipients)
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		server := newFakeSMTPServer(t, 2)
+		service := newTestService(server.port(), 1)
+
+		session := service.NewSession()
+		defer session.Close()
+		require.Error(t, session.SendCleanupNotification(context.Background(), testNotification("user@example.com")))
+
+		// the next email is still sent
+		require.NoError(t, session.SendCleanupNotification(context.Background(), testNotification("other@example.com")))
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		assert.Equal(t, []string{"other@example.com"}, server.recipients)
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		retryDelay = time.Hour
+		defer func() { retryDelay = time.Millisecond }()
+
+		server := newFakeSMTPServer(t, 2)
+		service := newTestService(server.port(), 2)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		session := service.NewSession()
+		defer session.Close()
+		err := session.SendCleanupNotification(ctx, testNotification("user@example.com"))
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestGenerateEmailBodyMetadata(t *testing.T) {
+	n := New(&config.EmailConfig{Enabled: true})
+
+	body, err := n.generateEmailBody(UserNotification{
+		UserEmail: "user@example.com",
+		MediaItems: []MediaItem{
+			{
+				Title:          "Dune",
+				MediaType:      "movie",
+				Overview:       "A noble family becomes embroiled in a war.",
+				Genres:         []string{"Science Fiction", "Adventure"},
+				PosterURL:      "https://image.tmdb.org/t/p/w342/dune.jpg",
+				DeletionReason: "added 120 days ago, never played",
+			},
+		},
+		CleanupDate: time.Now(),
+	})
+	require.NoError(t, err)
+	assert.Contains(t, body, "added 120 days ago, never played")
+	assert.Contains(t, body, "Science Fiction, Adventure")
+	assert.Contains(t, body, "A noble family becomes embroiled in a war.")
+	assert.Contains(t, body, `src="https://image.tmdb.org/t/p/w342/dune.jpg"`)
+
+	// without metadata only the title and type are shown
+	body, err = n.generateEmailBody(UserNotification{
+		UserEmail:   "user@example.com",
+		MediaItems:  []MediaItem{{Title: "Dune", MediaType: "movie"}},
+		CleanupDate: time.Now(),
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, body, `class="media-poster"`)
+	assert.NotContains(t, body, `class="media-overview"`)
+}
+
+func TestGenerateEmailBodyCustomTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.html")
+	tmpl := `Hallo {{.UserName}}: {{range .MediaItems}}{{.Title}} am {{formatDate .DeletionDate}};{{end}} {{.ServerURL}}`
+	require.NoError(t, os.WriteFile(path, []byte(tmpl), 0o600))
+
+	n := New(&config.EmailConfig{Enabled: true, TemplatePath: path, Locale: "de"})
+
+	body, err := n.generateEmailBody(UserNotification{
+		UserName:      "user",
+		MediaItems:    []MediaItem{{Title: "Dune", DeletionDate: time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC)}},
+		JellysweepURL: "https://jellysweep.example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Hallo user: Dune am 2. März 2025; https://jellysweep.example.com", body)
+}
+
+func TestNewFallsBackToDefaultTemplate(t *testing.T) {
+	n := New(&config.EmailConfig{Enabled: true, TemplatePath: filepath.Join(t.TempDir(), "missing.html")})
+
+	body, err := n.generateEmailBody(testNotification("user@example.com"))
+	require.NoError(t, err)
+	assert.Contains(t, body, "Media Items (1 total)")
+}
+
+func TestKeptMediaDeletedTemplate(t *testing.T) {
+	n := New(&config.EmailConfig{Enabled: true})
+
+	body, err := n.renderTemplate("kept_media_deleted.html", KeptMediaDeletedNotification{
+		UserName:      "user",
+		MediaItem:     MediaItem{Title: "Dune", MediaType: "movie"},
+		JellyseerrURL: "https://jellyseerr.example.com/movie/438631",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, body, "Dune")
+	assert.Contains(t, body, `href="https://jellyseerr.example.com/movie/438631"`)
+
+	// without Jellyseerr the user is pointed to the administrator
+	body, err = n.renderTemplate("kept_media_deleted.html", KeptMediaDeletedNotification{
+		UserName:  "user",
+		MediaItem: MediaItem{Title: "Dune", MediaType: "movie"},
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, body, "Re-request in Jellyseerr")
+	assert.Contains(t, body, "contact your administrator")
+}
+
+func TestAdminSummaryTemplate(t *testing.T) {
+	n := New(&config.EmailConfig{Enabled: true})
+
+	body, err := n.renderTemplate("admin_summary.html", AdminSummaryNotification{
+		AdminEmail: "admin@example.com",
+		Requesters: []RequesterSummary{{UserEmail: "alice@example.com", Items: 2}},
+		MediaItems: []MediaItem{
+			{Title: "Dune", MediaType: "movie", RequestedBy: "alice@example.com"},
+			{Title: "Arrival", MediaType: "movie", RequestedBy: "alice@example.com"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, body, "2 media items requested by 1 users")
+	assert.Contains(t, body, "alice@example.com")
+	assert.Contains(t, body, "Arrival")
+}
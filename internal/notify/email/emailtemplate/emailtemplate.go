@@ -0,0 +1,104 @@
+// Package emailtemplate provides the template functions and locales of the email notifications.
+// It's separate from the email package so the config can validate custom templates when it's loaded.
+package emailtemplate
+
+import (
+	"fmt"
+	"html/template"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultLocale is used if no locale is configured.
+const DefaultLocale = "en"
+
+// locale holds the month names and the long date layout of a language.
+type locale struct {
+	months [12]string
+	// layout formats the day, month name and year of a date.
+	layout func(day int, month string, year int) string
+}
+
+var locales = map[string]locale{
+	"en": {
+		months: [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		layout: func(day int, month string, year int) string { return fmt.Sprintf("%s %d, %d", month, day, year) },
+	},
+	"de": {
+		months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		layout: func(day int, month string, year int) string { return fmt.Sprintf("%d. %s %d", day, month, year) },
+	},
+	"fr": {
+		months: [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		layout: func(day int, month string, year int) string { return fmt.Sprintf("%d %s %d", day, month, year) },
+	},
+	"es": {
+		months: [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		layout: func(day int, month string, year int) string { return fmt.Sprintf("%d de %s de %d", day, month, year) },
+	},
+	"it": {
+		months: [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		layout: func(day int, month string, year int) string { return fmt.Sprintf("%d %s %d", day, month, year) },
+	},
+	"nl": {
+		months: [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		layout: func(day int, month string, year int) string { return fmt.Sprintf("%d %s %d", day, month, year) },
+	},
+	"pt": {
+		months: [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		layout: func(day int, month string, year int) string { return fmt.Sprintf("%d de %s de %d", day, month, year) },
+	},
+}
+
+// normalizeLocale reduces a locale like "de_CH" or "de-CH" to its language.
+func normalizeLocale(l string) string {
+	l = strings.ToLower(strings.TrimSpace(l))
+	if i := strings.IndexAny(l, "_-"); i >= 0 {
+		l = l[:i]
+	}
+	if l == "" {
+		return DefaultLocale
+	}
+	return l
+}
+
+// ValidLocale reports whether the locale is supported. An empty locale is valid and uses the default.
+func ValidLocale(l string) bool {
+	_, ok := locales[normalizeLocale(l)]
+	return ok
+}
+
+// Locales returns the supported languages.
+func Locales() []string {
+	return slices.Sorted(maps.Keys(locales))
+}
+
+// FormatDate formats the date with the month name and layout of the locale.
+// Unsupported locales fall back to English.
+func FormatDate(t time.Time, l string) string {
+	loc, ok := locales[normalizeLocale(l)]
+	if !ok {
+		loc = locales[DefaultLocale]
+	}
+	return loc.layout(t.Day(), loc.months[t.Month()-1], t.Year())
+}
+
+// Funcs returns the functions available in the email templates.
+func Funcs(l string) template.FuncMap {
+	return template.FuncMap{
+		"join":       strings.Join,
+		"formatDate": func(t time.Time) string { return FormatDate(t, l) },
+	}
+}
+
+// ParseFile parses a custom email template.
+func ParseFile(path, l string) (*template.Template, error) {
+	t, err := template.New(filepath.Base(path)).Funcs(Funcs(l)).ParseFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse email template %s: %w", path, err)
+	}
+	return t, nil
+}
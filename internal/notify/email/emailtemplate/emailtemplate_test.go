@@ -0,0 +1,38 @@
+package emailtemplate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDate(t *testing.T) {
+	date := time.Date(2025, time.August, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{locale: "", want: "August 2, 2025"},
+		{locale: "en", want: "August 2, 2025"},
+		{locale: "de", want: "2. August 2025"},
+		{locale: "de_CH", want: "2. August 2025"},
+		{locale: "fr-FR", want: "2 août 2025"},
+		{locale: "es", want: "2 de agosto de 2025"},
+		{locale: "xx", want: "August 2, 2025"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormatDate(date, tt.locale))
+		})
+	}
+}
+
+func TestValidLocale(t *testing.T) {
+	assert.True(t, ValidLocale(""))
+	assert.True(t, ValidLocale("DE"))
+	assert.True(t, ValidLocale("pt_BR"))
+	assert.False(t, ValidLocale("xx"))
+}
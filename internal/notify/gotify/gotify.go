@@ -0,0 +1,156 @@
+package gotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/config"
+)
+
+// Client represents a gotify notification client.
+type Client struct {
+	serverURL  string
+	token      string
+	priority   int
+	httpClient *http.Client
+}
+
+// Message represents a gotify message.
+type Message struct {
+	Title    string         `json:"title"`
+	Message  string         `json:"message"`
+	Priority int            `json:"priority"`
+	Extras   map[string]any `json:"extras,omitempty"`
+}
+
+// MediaItem represents a media item for notifications.
+type MediaItem struct {
+	Title string
+	Type  string // "movie" or "tv"
+	Year  int32
+}
+
+// NewClient creates a new gotify client.
+func NewClient(cfg *config.GotifyConfig) *Client {
+	// Validate server URL
+	if cfg.ServerURL != "" {
+		if _, err := url.Parse(cfg.ServerURL); err != nil {
+			log.Error("invalid gotify server URL", "error", err)
+		}
+	}
+
+	return &Client{
+		serverURL: cfg.ServerURL,
+		token:     cfg.Token,
+		priority:  cfg.Priority,
+		httpClient: &http.Client{
+			Timeout: config.TimeoutDuration(cfg.Timeout),
+		},
+	}
+}
+
+// SendMessage sends a message to gotify.
+func (c *Client) SendMessage(ctx context.Context, msg Message) error {
+	if msg.Priority == 0 {
+		msg.Priority = c.priority
+	}
+	if msg.Extras == nil {
+		// Render messages as markdown in gotify clients
+		msg.Extras = map[string]any{
+			"client::display": map[string]string{"contentType": "text/markdown"},
+		}
+	}
+
+	jsonData, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.serverURL+"/message", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gotify-Key", c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode >= 400 {
+		// Try to read response body for better error information
+		var errorMsg strings.Builder
+		if resp.Body != nil {
+			buf := make([]byte, 256)
+			if n, _ := resp.Body.Read(buf); n > 0 {
+				errorMsg.WriteString(": ")
+				errorMsg.Write(buf[:n])
+			}
+		}
+		return fmt.Errorf("gotify server returned status %d%s", resp.StatusCode, errorMsg.String())
+	}
+
+	log.Debug("Sent gotify notification", "title", msg.Title)
+	return nil
+}
+
+// SendKeepRequest sends a notification about a new keep request.
+func (c *Client) SendKeepRequest(ctx context.Context, mediaTitle, mediaType, username string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**User:** %s  \n", username)
+	fmt.Fprintf(&b, "**Type:** %s  \n", mediaType)
+	fmt.Fprintf(&b, "**Title:** %s\n\n", mediaTitle)
+	b.WriteString("Please review this keep request in the admin panel.")
+
+	return c.SendMessage(ctx, Message{
+		Title:   "Jellysweep Keep Request",
+		Message: b.String(),
+	})
+}
+
+// SendDeletionFailed sends a notification about a deletion that failed too many times and was given up.
+func (c *Client) SendDeletionFailed(ctx context.Context, mediaTitle, mediaType, errMsg string, attempts int) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Type:** %s  \n", mediaType)
+	fmt.Fprintf(&b, "**Title:** %s  \n", mediaTitle)
+	fmt.Fprintf(&b, "**Attempts:** %d  \n", attempts)
+	fmt.Fprintf(&b, "**Error:** %s\n\n", errMsg)
+	b.WriteString("The deletion won't be retried anymore, please check the item manually.")
+
+	return c.SendMessage(ctx, Message{
+		Title:   "Jellysweep Deletion Failed",
+		Message: b.String(),
+	})
+}
+
+// SendDeletionSummary sends a summary of media marked for deletion.
+func (c *Client) SendDeletionSummary(ctx context.Context, totalItems int, libraries map[string][]MediaItem) error {
+	if totalItems == 0 {
+		log.Debug("No media marked for deletion, skipping gotify notification")
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Total Items:** %d\n", totalItems)
+	for library, items := range libraries {
+		fmt.Fprintf(&b, "\n**%s** (%d items)\n", library, len(items))
+		for _, item := range items {
+			fmt.Fprintf(&b, "- %s (%d)\n", item.Title, item.Year)
+		}
+	}
+	b.WriteString("\nMedia will be deleted after the cleanup delay period.")
+
+	return c.SendMessage(ctx, Message{
+		Title:   "Jellysweep Cleanup Summary",
+		Message: b.String(),
+	})
+}
@@ -0,0 +1,226 @@
+package matrix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/config"
+)
+
+const (
+	// maxRetries is the maximum number of retries when a message couldn't be delivered.
+	maxRetries = 3
+	// initialBackoff is the initial backoff duration used when the homeserver does not tell us how long to wait.
+	initialBackoff = 1 * time.Second
+)
+
+// Client represents a matrix notification client.
+type Client struct {
+	homeserverURL string
+	accessToken   string
+	roomID        string
+	httpClient    *http.Client
+	// txnCounter makes the transaction IDs unique within the same nanosecond.
+	txnCounter atomic.Uint64
+}
+
+// Message represents a matrix m.room.message event with HTML formatting.
+type Message struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format,omitempty"`
+	FormattedBody string `json:"formatted_body,omitempty"`
+}
+
+// MediaItem represents a media item for notifications.
+type MediaItem struct {
+	Title string
+	Type  string // "movie" or "tv"
+	Year  int32
+}
+
+// errorResponse is the standard error body of the matrix client-server API.
+type errorResponse struct {
+	ErrCode      string `json:"errcode"`
+	Error        string `json:"error"`
+	RetryAfterMs int64  `json:"retry_after_ms"`
+}
+
+// NewClient creates a new matrix client.
+func NewClient(cfg *config.MatrixConfig) *Client {
+	return &Client{
+		homeserverURL: cfg.HomeserverURL,
+		accessToken:   cfg.AccessToken,
+		roomID:        cfg.RoomID,
+		httpClient: &http.Client{
+			Timeout: config.TimeoutDuration(cfg.Timeout),
+		},
+	}
+}
+
+// newTxnID returns a new transaction ID for a message.
+func (c *Client) newTxnID() string {
+	return fmt.Sprintf("jellysweep-%d-%d", time.Now().UnixNano(), c.txnCounter.Add(1))
+}
+
+// SendMessage sends a HTML formatted message to the configured room.
+// plain is used as fallback for clients that can't render HTML.
+// Failed requests are retried with the same transaction ID, so the homeserver
+// doesn't post the message twice if an earlier attempt reached it after all.
+func (c *Client) SendMessage(ctx context.Context, plain, formatted string) error {
+	jsonData, err := json.Marshal(Message{
+		MsgType:       "m.text",
+		Body:          plain,
+		Format:        "org.matrix.custom.html",
+		FormattedBody: formatted,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	txnID := c.newTxnID()
+	backoff := initialBackoff
+	for attempt := 0; ; attempt++ {
+		retryAfter, err := c.put(ctx, txnID, jsonData)
+		if err == nil {
+			log.Debug("Sent matrix notification", "room", c.roomID, "txnID", txnID)
+			return nil
+		}
+		if retryAfter < 0 || attempt >= maxRetries {
+			return err
+		}
+
+		wait := backoff
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		log.Warn("failed to send matrix notification, retrying", "attempt", attempt+1, "wait", wait, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+// put sends the event to the room. If the request may succeed when it's retried, it returns the duration
+// the homeserver asked us to wait (or 0 if unknown). For all other outcomes the returned duration is negative.
+func (c *Client) put(ctx context.Context, txnID string, payload []byte) (time.Duration, error) {
+	endpoint := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+		c.homeserverURL, url.PathEscape(c.roomID), url.PathEscape(txnID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return -1, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 400 {
+		return -1, nil
+	}
+
+	var errResp errorResponse
+	_ = json.NewDecoder(resp.Body).Decode(&errResp)
+	err = fmt.Errorf("matrix homeserver returned status %d: %s %s", resp.StatusCode, errResp.ErrCode, errResp.Error)
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if errResp.RetryAfterMs > 0 {
+			return time.Duration(errResp.RetryAfterMs) * time.Millisecond, err
+		}
+		if seconds, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second, err
+		}
+		return 0, err
+	case resp.StatusCode >= 500:
+		return 0, err
+	default:
+		return -1, err
+	}
+}
+
+// SendKeepRequest sends a notification about a new keep request.
+func (c *Client) SendKeepRequest(ctx context.Context, mediaTitle, mediaType, username string) error {
+	plain := fmt.Sprintf("Jellysweep Keep Request\nUser: %s\nType: %s\nTitle: %s\n\nPlease review this keep request in the admin panel.",
+		username, mediaType, mediaTitle)
+
+	var b strings.Builder
+	b.WriteString("<h4>Jellysweep Keep Request</h4>")
+	fmt.Fprintf(&b, "<strong>User:</strong> %s<br>", html.EscapeString(username))
+	fmt.Fprintf(&b, "<strong>Type:</strong> %s<br>", html.EscapeString(mediaType))
+	fmt.Fprintf(&b, "<strong>Title:</strong> %s", html.EscapeString(mediaTitle))
+	b.WriteString("<p>Please review this keep request in the admin panel.</p>")
+
+	return c.SendMessage(ctx, plain, b.String())
+}
+
+// SendDeletionFailed sends a notification about a deletion that failed too many times and was given up.
+func (c *Client) SendDeletionFailed(ctx context.Context, mediaTitle, mediaType, errMsg string, attempts int) error {
+	plain := fmt.Sprintf("Jellysweep Deletion Failed\nType: %s\nTitle: %s\nAttempts: %d\nError: %s\n\nThe deletion won't be retried anymore, please check the item manually.",
+		mediaType, mediaTitle, attempts, errMsg)
+
+	var b strings.Builder
+	b.WriteString("<h4>Jellysweep Deletion Failed</h4>")
+	fmt.Fprintf(&b, "<strong>Type:</strong> %s<br>", html.EscapeString(mediaType))
+	fmt.Fprintf(&b, "<strong>Title:</strong> %s<br>", html.EscapeString(mediaTitle))
+	fmt.Fprintf(&b, "<strong>Attempts:</strong> %d<br>", attempts)
+	fmt.Fprintf(&b, "<strong>Error:</strong> %s", html.EscapeString(errMsg))
+	b.WriteString("<p>The deletion won't be retried anymore, please check the item manually.</p>")
+
+	return c.SendMessage(ctx, plain, b.String())
+}
+
+// SendDeletionSummary sends a summary of media marked for deletion.
+func (c *Client) SendDeletionSummary(ctx context.Context, totalItems int, libraries map[string][]MediaItem) error {
+	if totalItems == 0 {
+		log.Debug("No media marked for deletion, skipping matrix notification")
+		return nil
+	}
+
+	libraryNames := make([]string, 0, len(libraries))
+	for library := range libraries {
+		libraryNames = append(libraryNames, library)
+	}
+	sort.Strings(libraryNames)
+
+	var plain, formatted strings.Builder
+	fmt.Fprintf(&plain, "Jellysweep Cleanup Summary\nTotal Items: %d\n", totalItems)
+	formatted.WriteString("<h4>Jellysweep Cleanup Summary</h4>")
+	fmt.Fprintf(&formatted, "<strong>Total Items:</strong> %d", totalItems)
+
+	for _, library := range libraryNames {
+		items := libraries[library]
+		fmt.Fprintf(&plain, "\n%s (%d items)\n", library, len(items))
+		fmt.Fprintf(&formatted, "<p><strong>%s</strong> (%d items)</p><ul>", html.EscapeString(library), len(items))
+		for _, item := range items {
+			fmt.Fprintf(&plain, "- %s (%d)\n", item.Title, item.Year)
+			fmt.Fprintf(&formatted, "<li>%s (%d)</li>", html.EscapeString(item.Title), item.Year)
+		}
+		formatted.WriteString("</ul>")
+	}
+
+	plain.WriteString("\nMedia will be deleted after the cleanup delay period.")
+	formatted.WriteString("<p>Media will be deleted after the cleanup delay period.</p>")
+
+	return c.SendMessage(ctx, plain.String(), formatted.String())
+}
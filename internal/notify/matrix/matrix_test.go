@@ -0,0 +1,72 @@
+package matrix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendMessageRetriesWithSameTxnID(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		paths = append(paths, r.URL.EscapedPath())
+
+		if len(paths) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"errcode": "M_LIMIT_EXCEEDED", "error": "Too many requests", "retry_after_ms": 1}`)
+			return
+		}
+
+		var msg Message
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		assert.Equal(t, "m.text", msg.MsgType)
+		assert.Equal(t, "org.matrix.custom.html", msg.Format)
+		assert.Contains(t, msg.FormattedBody, "<strong>Title:</strong> Tom &amp; Jerry")
+		assert.Contains(t, msg.Body, "Title: Tom & Jerry")
+		fmt.Fprint(w, `{"event_id": "$event"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.MatrixConfig{
+		HomeserverURL: server.URL,
+		AccessToken:   "secret",
+		RoomID:        "!room:example.com",
+	})
+
+	require.NoError(t, client.SendKeepRequest(context.Background(), "Tom & Jerry", "movie", "alice"))
+	require.Len(t, paths, 2)
+	assert.True(t, strings.HasPrefix(paths[0], "/_matrix/client/v3/rooms/%21room:example.com/send/m.room.message/jellysweep-"))
+	assert.Equal(t, paths[0], paths[1], "a retry must reuse the transaction ID")
+
+	// every new message gets its own transaction ID
+	require.NoError(t, client.SendKeepRequest(context.Background(), "Tom & Jerry", "movie", "alice"))
+	require.Len(t, paths, 3)
+	assert.NotEqual(t, paths[1], paths[2])
+}
+
+func TestSendMessageDoesNotRetryClientErrors(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"errcode": "M_FORBIDDEN", "error": "not in room"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(&config.MatrixConfig{HomeserverURL: server.URL, AccessToken: "secret", RoomID: "!room:example.com"})
+
+	err := client.SendMessage(context.Background(), "plain", "<b>formatted</b>")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "M_FORBIDDEN")
+	assert.Equal(t, 1, calls)
+}
@@ -135,11 +135,31 @@ func (c *Client) SendKeepRequest(ctx context.Context, mediaTitle, mediaType, use
 	return c.SendMessage(ctx, msg)
 }
 
+// SendDeletionFailed sends a notification about a deletion that failed too many times and was given up.
+func (c *Client) SendDeletionFailed(ctx context.Context, mediaTitle, mediaType, errMsg string, attempts int) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "📋 **Type:** %s\n", mediaType)
+	fmt.Fprintf(&b, "🎯 **Title:** %s\n", mediaTitle)
+	fmt.Fprintf(&b, "🔁 **Attempts:** %d\n", attempts)
+	fmt.Fprintf(&b, "💥 **Error:** %s\n\n", errMsg)
+	b.WriteString("⚠️ The deletion won't be retried anymore, please check the item manually.")
+
+	msg := Message{
+		Title:    "❌ Deletion Failed",
+		Message:  b.String(),
+		Priority: 4, // High priority
+		Tags:     []string{"x", "jellysweep", "deletion-failed"},
+	}
+
+	return c.SendMessage(ctx, msg)
+}
+
 // MediaItem represents a media item for notifications.
 type MediaItem struct {
-	Title string
-	Type  string // "movie" or "tv"
-	Year  int32
+	Title  string
+	Type   string // "movie" or "tv"
+	Year   int32
+	Genres []string // optional, only known if TMDB is configured
 }
 
 // SendDeletionSummary sends a summary of media marked for deletion.
@@ -169,7 +189,11 @@ func (c *Client) SendDeletionSummary(ctx context.Context, totalItems int, librar
 
 		// Add all media titles to detailed list
 		for _, item := range items {
-			mediaDetails = append(mediaDetails, fmt.Sprintf("  • %s (%d)", item.Title, item.Year))
+			detail := fmt.Sprintf("  • %s (%d)", item.Title, item.Year)
+			if len(item.Genres) > 0 {
+				detail += " - " + strings.Join(item.Genres, ", ")
+			}
+			mediaDetails = append(mediaDetails, detail)
 		}
 	}
 
@@ -0,0 +1,217 @@
+package pushover
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/config"
+)
+
+const (
+	// apiURL is the pushover endpoint for sending messages.
+	apiURL = "https://api.pushover.net/1/messages.json"
+	// maxMessageLength is the maximum length of a pushover message.
+	maxMessageLength = 1024
+	// keepRequestSound is the sound played for keep requests.
+	keepRequestSound = "siren"
+)
+
+// Pushover message priorities.
+const (
+	PriorityNormal = 0
+	PriorityHigh   = 1
+)
+
+// Client represents a pushover notification client.
+type Client struct {
+	apiURL     string
+	token      string
+	userKey    string
+	device     string
+	httpClient *http.Client
+}
+
+// Message represents a pushover message.
+type Message struct {
+	Title    string
+	Message  string
+	Priority int
+	Sound    string
+}
+
+// MediaItem represents a media item for notifications.
+type MediaItem struct {
+	Title string
+	Type  string // "movie" or "tv"
+	Year  int32
+}
+
+// NewClient creates a new pushover client.
+func NewClient(cfg *config.PushoverConfig) *Client {
+	return &Client{
+		apiURL:  apiURL,
+		token:   cfg.Token,
+		userKey: cfg.UserKey,
+		device:  cfg.Device,
+		httpClient: &http.Client{
+			Timeout: config.TimeoutDuration(cfg.Timeout),
+		},
+	}
+}
+
+// SendMessage sends a message to pushover.
+func (c *Client) SendMessage(ctx context.Context, msg Message) error {
+	form := url.Values{}
+	form.Set("token", c.token)
+	form.Set("user", c.userKey)
+	form.Set("title", msg.Title)
+	form.Set("message", msg.Message)
+	form.Set("priority", strconv.Itoa(msg.Priority))
+	if msg.Sound != "" {
+		form.Set("sound", msg.Sound)
+	}
+	if c.device != "" {
+		form.Set("device", c.device)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode >= 400 {
+		// Pushover describes the problem in the errors field of the response
+		var result struct {
+			Errors []string `json:"errors"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err == nil && len(result.Errors) > 0 {
+			return fmt.Errorf("pushover returned status %d: %s", resp.StatusCode, strings.Join(result.Errors, ", "))
+		}
+		return fmt.Errorf("pushover returned status %d", resp.StatusCode)
+	}
+
+	log.Debug("Sent pushover notification", "title", msg.Title)
+	return nil
+}
+
+// SendKeepRequest sends a high priority notification about a new keep request.
+func (c *Client) SendKeepRequest(ctx context.Context, mediaTitle, mediaType, username string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "User: %s\n", username)
+	fmt.Fprintf(&b, "Type: %s\n", mediaType)
+	fmt.Fprintf(&b, "Title: %s\n\n", mediaTitle)
+	b.WriteString("Please review this keep request in the admin panel.")
+
+	return c.SendMessage(ctx, Message{
+		Title:    "Jellysweep Keep Request",
+		Message:  b.String(),
+		Priority: PriorityHigh,
+		Sound:    keepRequestSound,
+	})
+}
+
+// SendDeletionFailed sends a high priority notification about a deletion that failed too many times and was given up.
+func (c *Client) SendDeletionFailed(ctx context.Context, mediaTitle, mediaType, errMsg string, attempts int) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Type: %s\n", mediaType)
+	fmt.Fprintf(&b, "Title: %s\n", mediaTitle)
+	fmt.Fprintf(&b, "Attempts: %d\n", attempts)
+	fmt.Fprintf(&b, "Error: %s\n\n", errMsg)
+	b.WriteString("The deletion won't be retried anymore, please check the item manually.")
+
+	return c.SendMessage(ctx, Message{
+		Title:    "Jellysweep Deletion Failed",
+		Message:  b.String(),
+		Priority: PriorityHigh,
+	})
+}
+
+// SendDeletionSummary sends a summary of media marked for deletion.
+func (c *Client) SendDeletionSummary(ctx context.Context, totalItems int, libraries map[string][]MediaItem) error {
+	if totalItems == 0 {
+		log.Debug("No media marked for deletion, skipping pushover notification")
+		return nil
+	}
+
+	return c.SendMessage(ctx, Message{
+		Title: "Jellysweep Cleanup Summary",
+		Message: summaryMessage(
+			fmt.Sprintf("Total Items: %d\n", totalItems),
+			libraries,
+			"\nMedia will be deleted after the cleanup delay period.",
+		),
+		Priority: PriorityNormal,
+	})
+}
+
+// SendDeletionCompleted sends a summary of media that was actually deleted.
+func (c *Client) SendDeletionCompleted(ctx context.Context, totalItems int, libraries map[string][]MediaItem) error {
+	if totalItems == 0 {
+		log.Debug("No media deleted, skipping pushover notification")
+		return nil
+	}
+
+	return c.SendMessage(ctx, Message{
+		Title: "Jellysweep Deletion Completed",
+		Message: summaryMessage(
+			fmt.Sprintf("Deleted Items: %d\n", totalItems),
+			libraries,
+			"\nThe media has been removed from your libraries.",
+		),
+		Priority: PriorityNormal,
+	})
+}
+
+// summaryMessage lists the items of all libraries between intro and outro.
+// If the message would exceed the pushover limit, the list is cut off and ends with the number of omitted items.
+func summaryMessage(intro string, libraries map[string][]MediaItem, outro string) string {
+	total := 0
+	for _, items := range libraries {
+		total += len(items)
+	}
+
+	// reserve space for the outro and the "+N more" line
+	limit := maxMessageLength - len(outro) - len(fmt.Sprintf("+%d more\n", total))
+
+	var b strings.Builder
+	b.WriteString(intro)
+	written := 0
+libraryLoop:
+	for _, library := range slices.Sorted(maps.Keys(libraries)) {
+		items := libraries[library]
+		header := fmt.Sprintf("\n%s (%d items)\n", library, len(items))
+		if b.Len()+len(header) > limit {
+			break
+		}
+		b.WriteString(header)
+		for _, item := range items {
+			line := fmt.Sprintf("- %s (%d)\n", item.Title, item.Year)
+			if b.Len()+len(line) > limit {
+				break libraryLoop
+			}
+			b.WriteString(line)
+			written++
+		}
+	}
+	if written < total {
+		fmt.Fprintf(&b, "+%d more\n", total-written)
+	}
+	b.WriteString(outro)
+
+	return b.String()
+}
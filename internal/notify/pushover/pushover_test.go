@@ -0,0 +1,99 @@
+package pushover
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient(&config.PushoverConfig{Token: "app-token", UserKey: "user-key", Device: "phone"})
+	client.apiURL = server.URL
+	return client
+}
+
+func TestSendKeepRequestHighPriority(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "app-token", r.PostForm.Get("token"))
+		assert.Equal(t, "user-key", r.PostForm.Get("user"))
+		assert.Equal(t, "phone", r.PostForm.Get("device"))
+		assert.Equal(t, "1", r.PostForm.Get("priority"))
+		assert.Equal(t, keepRequestSound, r.PostForm.Get("sound"))
+		assert.Contains(t, r.PostForm.Get("message"), "Title: Dune")
+		fmt.Fprint(w, `{"status": 1}`)
+	})
+
+	require.NoError(t, client.SendKeepRequest(context.Background(), "Dune", "movie", "alice"))
+}
+
+func TestSendDeletionFailedHighPriority(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "Jellysweep Deletion Failed", r.PostForm.Get("title"))
+		assert.Equal(t, "1", r.PostForm.Get("priority"))
+		assert.Contains(t, r.PostForm.Get("message"), "Attempts: 5")
+		assert.Contains(t, r.PostForm.Get("message"), "Error: radarr unavailable")
+		fmt.Fprint(w, `{"status": 1}`)
+	})
+
+	require.NoError(t, client.SendDeletionFailed(context.Background(), "Dune", "movie", "radarr unavailable", 5))
+}
+
+func TestSendDeletionSummaryTruncatesLongMessages(t *testing.T) {
+	var message string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "0", r.PostForm.Get("priority"))
+		assert.Empty(t, r.PostForm.Get("sound"))
+		message = r.PostForm.Get("message")
+		fmt.Fprint(w, `{"status": 1}`)
+	})
+
+	items := make([]MediaItem, 100)
+	for i := range items {
+		items[i] = MediaItem{Title: fmt.Sprintf("Some Fairly Long Movie Title %d", i), Type: "movie", Year: 2000}
+	}
+	require.NoError(t, client.SendDeletionSummary(context.Background(), len(items), map[string][]MediaItem{"Movies": items}))
+
+	assert.LessOrEqual(t, utf8.RuneCountInString(message), maxMessageLength)
+	assert.Contains(t, message, "- Some Fairly Long Movie Title 0 (2000)")
+	assert.NotContains(t, message, "Title 99 ")
+
+	written := strings.Count(message, "\n- ")
+	assert.Contains(t, message, fmt.Sprintf("+%d more\n", len(items)-written))
+	assert.True(t, strings.HasSuffix(message, "Media will be deleted after the cleanup delay period."))
+}
+
+func TestSummaryMessageFitsWithoutTruncation(t *testing.T) {
+	message := summaryMessage("Total Items: 2\n", map[string][]MediaItem{
+		"TV Shows": {{Title: "Severance", Year: 2022}},
+		"Movies":   {{Title: "Dune", Year: 2021}},
+	}, "\nDone.")
+
+	assert.Equal(t, "Total Items: 2\n\nMovies (1 items)\n- Dune (2021)\n\nTV Shows (1 items)\n- Severance (2022)\n\nDone.", message)
+}
+
+func TestSendMessageError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"user": "invalid", "errors": ["user identifier is invalid"], "status": 0}`)
+	})
+
+	err := client.SendMessage(context.Background(), Message{Title: "title", Message: "message"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "user identifier is invalid")
+}
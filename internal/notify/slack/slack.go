@@ -0,0 +1,257 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/dustin/go-humanize"
+	"github.com/jon4hz/jellysweep/internal/config"
+)
+
+const (
+	// maxRetries is the maximum number of retries when slack rate limits a request.
+	maxRetries = 5
+	// initialBackoff is the initial backoff duration used when slack does not send a Retry-After header.
+	initialBackoff = 1 * time.Second
+)
+
+// Client represents a slack notification client.
+type Client struct {
+	webhookURL string
+	channel    string
+	httpClient *http.Client
+}
+
+// Message represents a slack webhook message.
+type Message struct {
+	Channel string  `json:"channel,omitempty"`
+	Text    string  `json:"text"`
+	Blocks  []Block `json:"blocks,omitempty"`
+}
+
+// Block represents a slack block kit block.
+type Block struct {
+	Type     string    `json:"type"`
+	Text     *Text     `json:"text,omitempty"`
+	Fields   []Text    `json:"fields,omitempty"`
+	Elements []Element `json:"elements,omitempty"`
+}
+
+// Text represents a slack block kit text object.
+type Text struct {
+	Type  string `json:"type"`
+	Text  string `json:"text"`
+	Emoji bool   `json:"emoji,omitempty"`
+}
+
+// Element represents a slack block kit element (e.g. a button).
+type Element struct {
+	Type string `json:"type"`
+	Text *Text  `json:"text,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// MediaItem represents a media item for notifications.
+type MediaItem struct {
+	Title    string
+	Type     string // "movie" or "tv"
+	Year     int32
+	FileSize int64
+}
+
+// NewClient creates a new slack client.
+func NewClient(cfg *config.SlackConfig) *Client {
+	return &Client{
+		webhookURL: cfg.WebhookURL,
+		channel:    cfg.Channel,
+		httpClient: &http.Client{
+			Timeout: config.TimeoutDuration(cfg.Timeout),
+		},
+	}
+}
+
+// SendMessage sends a message to the slack webhook.
+// If slack responds with HTTP 429, the request is retried with backoff.
+func (c *Client) SendMessage(ctx context.Context, msg Message) error {
+	if c.channel != "" {
+		msg.Channel = c.channel
+	}
+
+	jsonData, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	backoff := initialBackoff
+	for attempt := 0; ; attempt++ {
+		retryAfter, err := c.post(ctx, jsonData)
+		if err == nil {
+			log.Debug("Sent slack notification", "channel", msg.Channel)
+			return nil
+		}
+		if retryAfter < 0 || attempt >= maxRetries {
+			return err
+		}
+
+		wait := backoff
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		log.Warn("slack rate limit reached, retrying", "attempt", attempt+1, "wait", wait)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+// post sends the payload to slack. If the request was rate limited, it returns the duration
+// slack asked us to wait (or 0 if unknown). For all other outcomes the returned duration is negative.
+func (c *Client) post(ctx context.Context, payload []byte) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return -1, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return -1, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		var retryAfter time.Duration
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+		return retryAfter, fmt.Errorf("slack returned status %d", resp.StatusCode)
+	}
+
+	if resp.StatusCode >= 400 {
+		// Try to read response body for better error information
+		var errorMsg strings.Builder
+		buf := make([]byte, 256)
+		if n, _ := resp.Body.Read(buf); n > 0 {
+			errorMsg.WriteString(": ")
+			errorMsg.Write(buf[:n])
+		}
+		return -1, fmt.Errorf("slack returned status %d%s", resp.StatusCode, errorMsg.String())
+	}
+
+	return -1, nil
+}
+
+// SendDeletionCompletedSummary sends a summary of media that was actually deleted,
+// including the reclaimed disk space per library.
+func (c *Client) SendDeletionCompletedSummary(ctx context.Context, libraries map[string][]MediaItem, historyURL string) error {
+	if len(libraries) == 0 {
+		log.Debug("No media was deleted, skipping slack notification")
+		return nil
+	}
+
+	libraryNames := make([]string, 0, len(libraries))
+	for library := range libraries {
+		libraryNames = append(libraryNames, library)
+	}
+	sort.Strings(libraryNames)
+
+	var totalItems int
+	var totalBytes int64
+	fields := make([]Text, 0, len(libraryNames))
+	for _, library := range libraryNames {
+		items := libraries[library]
+		var libraryBytes int64
+		for _, item := range items {
+			libraryBytes += item.FileSize
+		}
+		totalItems += len(items)
+		totalBytes += libraryBytes
+
+		fields = append(fields, Text{
+			Type: "mrkdwn",
+			Text: fmt.Sprintf("*%s*\n%d items • %s", library, len(items), formatBytes(libraryBytes)),
+		})
+	}
+
+	summary := fmt.Sprintf("Jellysweep deleted %d items and freed %s.", totalItems, formatBytes(totalBytes))
+
+	blocks := []Block{
+		{
+			Type: "header",
+			Text: &Text{Type: "plain_text", Text: "✅🪼 Cleanup Completed", Emoji: true},
+		},
+		{
+			Type: "section",
+			Text: &Text{Type: "mrkdwn", Text: summary},
+		},
+	}
+
+	// slack allows at most 10 fields per section block
+	for chunk := range slices.Chunk(fields, 10) {
+		blocks = append(blocks, Block{
+			Type:   "section",
+			Fields: chunk,
+		})
+	}
+
+	if historyURL != "" {
+		blocks = append(blocks, Block{
+			Type: "actions",
+			Elements: []Element{
+				{
+					Type: "button",
+					Text: &Text{Type: "plain_text", Text: "View deletion history"},
+					URL:  historyURL,
+				},
+			},
+		})
+	}
+
+	return c.SendMessage(ctx, Message{
+		Text:   summary,
+		Blocks: blocks,
+	})
+}
+
+// SendDeletionFailed sends a notification about a deletion that failed too many times and was given up.
+func (c *Client) SendDeletionFailed(ctx context.Context, mediaTitle, mediaType, errMsg string, attempts int) error {
+	summary := fmt.Sprintf("Jellysweep gave up deleting %s after %d attempts.", mediaTitle, attempts)
+
+	return c.SendMessage(ctx, Message{
+		Text: summary,
+		Blocks: []Block{
+			{
+				Type: "header",
+				Text: &Text{Type: "plain_text", Text: "❌🪼 Deletion Failed", Emoji: true},
+			},
+			{
+				Type: "section",
+				Text: &Text{Type: "mrkdwn", Text: summary},
+				Fields: []Text{
+					{Type: "mrkdwn", Text: fmt.Sprintf("*Type*\n%s", mediaType)},
+					{Type: "mrkdwn", Text: fmt.Sprintf("*Error*\n%s", errMsg)},
+				},
+			},
+		},
+	})
+}
+
+func formatBytes(bytes int64) string {
+	if bytes <= 0 {
+		return "0 B"
+	}
+	return humanize.Bytes(uint64(bytes)) //nolint:gosec
+}
@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"text/template"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/notify/webhook/webhooktemplate"
+)
+
+// defaultRetryDelay is the delay before a failed request is retried, it grows with every attempt.
+const defaultRetryDelay = 2 * time.Second
+
+// MediaItem represents a media item for notifications.
+type MediaItem = webhooktemplate.MediaItem
+
+// Client represents a generic webhook notification client.
+type Client struct {
+	url        string
+	method     string
+	headers    map[string]string
+	template   *template.Template
+	retries    int
+	retryDelay time.Duration
+	httpClient *http.Client
+}
+
+// NewClient creates a new webhook client. It fails if the payload template is invalid.
+func NewClient(cfg *config.WebhookConfig) (*Client, error) {
+	tmpl, err := webhooktemplate.Parse(cfg.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	method := cfg.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	return &Client{
+		url:        cfg.URL,
+		method:     method,
+		headers:    cfg.Headers,
+		template:   tmpl,
+		retries:    cfg.Retries,
+		retryDelay: defaultRetryDelay,
+		httpClient: &http.Client{
+			Timeout: config.TimeoutDuration(cfg.Timeout),
+		},
+	}, nil
+}
+
+// Send renders the payload of the event and sends it to the webhook.
+// Failed requests are retried as often as configured, the last error is returned.
+func (c *Client) Send(ctx context.Context, event webhooktemplate.Event) error {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	payload, err := webhooktemplate.Render(c.template, event)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = c.send(ctx, payload)
+		if err == nil {
+			log.Debug("Sent webhook notification", "event", event.Type)
+			return nil
+		}
+		if attempt >= c.retries {
+			return err
+		}
+
+		log.Warn("Webhook notification failed, retrying", "event", event.Type, "attempt", attempt+1, "error", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.retryDelay * time.Duration(attempt+1)):
+		}
+	}
+}
+
+// send sends the payload once.
+func (c *Client) send(ctx context.Context, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, c.method, c.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SendKeepRequest sends a notification about a new keep request.
+func (c *Client) SendKeepRequest(ctx context.Context, media MediaItem, username string) error {
+	return c.Send(ctx, webhooktemplate.Event{
+		Type:     webhooktemplate.EventKeepRequest,
+		Media:    &media,
+		Username: username,
+	})
+}
+
+// SendDeletionFailed sends a notification about a deletion that failed too many times and was given up.
+func (c *Client) SendDeletionFailed(ctx context.Context, media MediaItem, errMsg string, attempts int) error {
+	return c.Send(ctx, webhooktemplate.Event{
+		Type:     webhooktemplate.EventDeletionFailed,
+		Media:    &media,
+		Error:    errMsg,
+		Attempts: attempts,
+	})
+}
+
+// SendDeletionSummary sends a summary of media marked for deletion.
+func (c *Client) SendDeletionSummary(ctx context.Context, totalItems int, libraries map[string][]MediaItem) error {
+	return c.sendDeletionEvent(ctx, webhooktemplate.EventDeletionSummary, totalItems, libraries)
+}
+
+// SendDeletionCompleted sends a summary of media that was actually deleted.
+func (c *Client) SendDeletionCompleted(ctx context.Context, totalItems int, libraries map[string][]MediaItem) error {
+	return c.sendDeletionEvent(ctx, webhooktemplate.EventDeletionCompleted, totalItems, libraries)
+}
+
+func (c *Client) sendDeletionEvent(ctx context.Context, eventType webhooktemplate.EventType, totalItems int, libraries map[string][]MediaItem) error {
+	if totalItems == 0 {
+		log.Debug("No media items, skipping webhook notification", "event", eventType)
+		return nil
+	}
+	return c.Send(ctx, webhooktemplate.Event{
+		Type:       eventType,
+		TotalItems: totalItems,
+		Libraries:  libraries,
+	})
+}
@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, cfg config.WebhookConfig, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg.URL = server.URL
+	client, err := NewClient(&cfg)
+	require.NoError(t, err)
+	client.retryDelay = 0
+	return client
+}
+
+func TestSendKeepRequestRendersTemplate(t *testing.T) {
+	var body map[string]any
+	client := newTestClient(t, config.WebhookConfig{
+		Method:   http.MethodPut,
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+		Template: `{"kind": {{ json .Type }}{{ with .Media }}, "text": {{ printf "%s wants to keep %s" $.Username .Title | json }}{{ end }}}`,
+	}, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+	})
+
+	require.NoError(t, client.SendKeepRequest(context.Background(), MediaItem{Title: `The "Movie"`, Type: "movie"}, "alice"))
+	assert.Equal(t, map[string]any{"kind": "keep_request", "text": `alice wants to keep The "Movie"`}, body)
+}
+
+func TestSendDeletionSummaryDefaultPayload(t *testing.T) {
+	var payload []byte
+	client := newTestClient(t, config.WebhookConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		payload, _ = io.ReadAll(r.Body)
+	})
+
+	libraries := map[string][]MediaItem{"Movies": {{Title: "Dune", Type: "movie", Year: 2021, Library: "Movies"}}}
+	require.NoError(t, client.SendDeletionSummary(context.Background(), 1, libraries))
+
+	var event struct {
+		Type       string                 `json:"type"`
+		TotalItems int                    `json:"totalItems"`
+		Libraries  map[string][]MediaItem `json:"libraries"`
+	}
+	require.NoError(t, json.Unmarshal(payload, &event))
+	assert.Equal(t, "deletion_summary", event.Type)
+	assert.Equal(t, 1, event.TotalItems)
+	assert.Equal(t, libraries, event.Libraries)
+}
+
+func TestSendRetriesFailedRequests(t *testing.T) {
+	t.Run("succeeds after a retry", func(t *testing.T) {
+		var calls atomic.Int32
+		client := newTestClient(t, config.WebhookConfig{Retries: 2}, func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+			}
+		})
+
+		require.NoError(t, client.SendKeepRequest(context.Background(), MediaItem{Title: "Dune"}, "alice"))
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("gives up", func(t *testing.T) {
+		var calls atomic.Int32
+		client := newTestClient(t, config.WebhookConfig{Retries: 2}, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+
+		err := client.SendKeepRequest(context.Background(), MediaItem{Title: "Dune"}, "alice")
+		require.ErrorContains(t, err, "status 500")
+		assert.Equal(t, int32(3), calls.Load(), "the first attempt and two retries")
+	})
+}
+
+func TestNewClientRejectsInvalidTemplate(t *testing.T) {
+	for name, tmpl := range map[string]string{
+		"syntax error":  `{"type": {{ .Type }`,
+		"unknown field": `{"type": {{ json .Missing }}}`,
+		"invalid json":  `type: {{ .Type }}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewClient(&config.WebhookConfig{URL: "http://localhost", Template: tmpl})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Package webhooktemplate provides the events and the payload template of the webhook notifications.
+// It's separate from the webhook package so the config can validate the template when it's loaded.
+package webhooktemplate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+	"time"
+)
+
+// EventType is the type of a webhook event.
+type EventType string
+
+const (
+	// EventDeletionSummary is sent after a cleanup run marked media for deletion.
+	EventDeletionSummary EventType = "deletion_summary"
+	// EventDeletionCompleted is sent after media was deleted.
+	EventDeletionCompleted EventType = "deletion_completed"
+	// EventKeepRequest is sent if a keep request needs to be reviewed by an admin.
+	EventKeepRequest EventType = "keep_request"
+	// EventDeletionFailed is sent if the deletion of an item was given up after all retries.
+	EventDeletionFailed EventType = "deletion_failed"
+)
+
+// MediaItem represents a media item of an event.
+type MediaItem struct {
+	Title   string `json:"title"`
+	Type    string `json:"type"` // "movie", "tv" or "book"
+	Year    int32  `json:"year"`
+	Library string `json:"library"`
+}
+
+// Event is the data the payload template is rendered with.
+type Event struct {
+	Type EventType `json:"type"`
+	Time time.Time `json:"time"`
+	// TotalItems is the number of items of a deletion event.
+	TotalItems int `json:"totalItems"`
+	// Libraries holds the items of a deletion event grouped by library.
+	Libraries map[string][]MediaItem `json:"libraries,omitempty"`
+	// Media is the requested item of a keep request or the item of a failed deletion.
+	Media *MediaItem `json:"media,omitempty"`
+	// Username is the user who submitted the keep request.
+	Username string `json:"username,omitempty"`
+	// Error is the last error of a failed deletion.
+	Error string `json:"error,omitempty"`
+	// Attempts is the number of attempts of a failed deletion.
+	Attempts int `json:"attempts,omitempty"`
+}
+
+// Funcs returns the functions available in the payload template.
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		// json encodes a value, so strings are quoted and escaped properly
+		"json": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}
+}
+
+// Parse parses the payload template. An empty template sends the event as JSON.
+// The template is rendered with a sample event of every type to make sure it produces valid JSON.
+func Parse(text string) (*template.Template, error) {
+	if text == "" {
+		text = "{{ json . }}"
+	}
+	t, err := template.New("webhook").Funcs(Funcs()).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse webhook template: %w", err)
+	}
+
+	movie := MediaItem{Title: "Sample Movie", Type: "movie", Year: 2000, Library: "Movies"}
+	samples := []Event{
+		{Type: EventDeletionSummary, TotalItems: 1, Libraries: map[string][]MediaItem{"Movies": {movie}}},
+		{Type: EventDeletionCompleted, TotalItems: 1, Libraries: map[string][]MediaItem{"Movies": {movie}}},
+		{Type: EventKeepRequest, Media: &movie, Username: "user"},
+		{Type: EventDeletionFailed, Media: &movie, Error: "sample error", Attempts: 5},
+	}
+	for _, event := range samples {
+		if _, err := Render(t, event); err != nil {
+			return nil, fmt.Errorf("invalid webhook template: %w", err)
+		}
+	}
+	return t, nil
+}
+
+// Render renders the payload of the event and checks that it's valid JSON.
+func Render(t *template.Template, event Event) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, event); err != nil {
+		return nil, fmt.Errorf("failed to render %s payload: %w", event.Type, err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("%s payload isn't valid JSON: %s", event.Type, buf.String())
+	}
+	return buf.Bytes(), nil
+}
@@ -31,6 +31,7 @@ func (e *ErrAllSubscriptionsInvalid) Error() string {
 // Client represents a webpush notification client.
 type Client struct {
 	config        *Config
+	serverURL     string
 	subscriptions map[string]map[string]*Subscription // userID -> subscriptionID -> subscription
 	mu            sync.RWMutex
 }
@@ -53,22 +54,27 @@ type NotificationPayload struct {
 	Title   string                 `json:"title"`
 	Body    string                 `json:"body"`
 	Icon    string                 `json:"icon"`
+	Image   string                 `json:"image,omitempty"`
 	Badge   string                 `json:"badge"`
 	Data    map[string]interface{} `json:"data"`
 	Actions []NotificationAction   `json:"actions,omitempty"`
 }
 
 // NotificationAction represents an action button in the notification.
+// URL is opened by the service worker when the action is clicked.
 type NotificationAction struct {
 	Action string `json:"action"`
 	Title  string `json:"title"`
 	Icon   string `json:"icon,omitempty"`
+	URL    string `json:"url,omitempty"`
 }
 
 // NewClient creates a new webpush client.
-func NewClient(config *Config) *Client {
+// The serverURL is used to link the notifications to the Jellysweep dashboard.
+func NewClient(config *Config, serverURL string) *Client {
 	return &Client{
 		config:        config,
+		serverURL:     serverURL,
 		subscriptions: make(map[string]map[string]*Subscription),
 	}
 }
@@ -260,26 +266,25 @@ func (c *Client) SendNotification(ctx context.Context, userID string, payload *N
 }
 
 // SendKeepRequestNotification sends a notification about a keep request decision.
-func (c *Client) SendKeepRequestNotification(ctx context.Context, userID, mediaTitle, mediaType string, approved bool) error {
-	userID = strings.ToLower(userID)
-
-	var title, body string
-	var icon string
+// If a posterURL is given, it is shown as image of the notification.
+func (c *Client) SendKeepRequestNotification(ctx context.Context, userID, mediaTitle, mediaType, posterURL string, approved bool) error {
+	return c.SendNotification(ctx, strings.ToLower(userID), c.keepRequestPayload(mediaTitle, mediaType, posterURL, approved))
+}
 
+// keepRequestPayload builds the notification payload of a keep request decision.
+func (c *Client) keepRequestPayload(mediaTitle, mediaType, posterURL string, approved bool) *NotificationPayload {
+	title := "❌ Keep Request Denied"
+	body := fmt.Sprintf("Your request to keep \"%s\" has been denied.", mediaTitle)
 	if approved {
 		title = "✅ Keep Request Approved"
 		body = fmt.Sprintf("Your request to keep \"%s\" has been approved!", mediaTitle)
-		icon = "/static/icons/icon-192x192.png"
-	} else {
-		title = "❌ Keep Request Denied"
-		body = fmt.Sprintf("Your request to keep \"%s\" has been denied.", mediaTitle)
-		icon = "/static/icons/icon-192x192.png"
 	}
 
 	payload := &NotificationPayload{
 		Title: title,
 		Body:  body,
-		Icon:  icon,
+		Icon:  "/static/icons/icon-192x192.png",
+		Image: posterURL,
 		Badge: "/static/icons/icon-192x192.png",
 		Data: map[string]interface{}{
 			"type":       "keep_request_decision",
@@ -288,13 +293,114 @@ func (c *Client) SendKeepRequestNotification(ctx context.Context, userID, mediaT
 			"mediaType":  mediaType,
 			"timestamp":  time.Now().Unix(),
 		},
-		Actions: []NotificationAction{
+		Actions: c.actions(),
+	}
+	if c.serverURL != "" {
+		payload.Data["url"] = c.serverURL + "/"
+	}
+	return payload
+}
+
+// actions returns the action buttons of a notification.
+// With a server URL, the notification links to the dashboard, otherwise it opens the app.
+func (c *Client) actions() []NotificationAction {
+	if c.serverURL == "" {
+		return []NotificationAction{
 			{
 				Action: "open_app",
 				Title:  "Open Jellysweep",
 			},
+		}
+	}
+	return []NotificationAction{
+		{
+			Action: "view",
+			Title:  "View",
+			URL:    c.serverURL + "/",
 		},
 	}
+}
+
+// SendKeepRequestsSummaryNotification sends a single notification about multiple keep request decisions.
+func (c *Client) SendKeepRequestsSummaryNotification(ctx context.Context, userID string, mediaTitles []string, approved bool) error {
+	if len(mediaTitles) == 1 {
+		return c.SendKeepRequestNotification(ctx, userID, mediaTitles[0], "", "", approved)
+	}
+
+	userID = strings.ToLower(userID)
+
+	title := "❌ Keep Requests Denied"
+	body := fmt.Sprintf("Your requests to keep %d items have been denied: %s", len(mediaTitles), strings.Join(mediaTitles, ", "))
+	if approved {
+		title = "✅ Keep Requests Approved"
+		body = fmt.Sprintf("Your requests to keep %d items have been approved: %s", len(mediaTitles), strings.Join(mediaTitles, ", "))
+	}
+
+	payload := &NotificationPayload{
+		Title: title,
+		Body:  body,
+		Icon:  "/static/icons/icon-192x192.png",
+		Badge: "/static/icons/icon-192x192.png",
+		Data: map[string]interface{}{
+			"type":        "keep_request_decision",
+			"approved":    approved,
+			"mediaTitles": mediaTitles,
+			"timestamp":   time.Now().Unix(),
+		},
+		Actions: c.actions(),
+	}
+
+	return c.SendNotification(ctx, userID, payload)
+}
+
+// SendProtectionExpiryNotification reminds a user that the protection of media they requested to keep expires soon.
+func (c *Client) SendProtectionExpiryNotification(ctx context.Context, userID, mediaTitle, mediaType string, expiresAt time.Time) error {
+	userID = strings.ToLower(userID)
+
+	payload := &NotificationPayload{
+		Title: "⏳ Protection Expires Soon",
+		Body:  fmt.Sprintf("The protection of \"%s\" expires on %s. Request to keep it again once it shows up in Jellysweep.", mediaTitle, expiresAt.Format("January 2, 2006")),
+		Icon:  "/static/icons/icon-192x192.png",
+		Badge: "/static/icons/icon-192x192.png",
+		Data: map[string]interface{}{
+			"type":       "protection_expiry",
+			"mediaTitle": mediaTitle,
+			"mediaType":  mediaType,
+			"expiresAt":  expiresAt.Unix(),
+			"timestamp":  time.Now().Unix(),
+		},
+		Actions: c.actions(),
+	}
+
+	return c.SendNotification(ctx, userID, payload)
+}
+
+// SendKeptMediaDeletedNotification informs a user that media they requested to keep was deleted after its protection expired.
+func (c *Client) SendKeptMediaDeletedNotification(ctx context.Context, userID, mediaTitle, mediaType, jellyseerrURL string) error {
+	userID = strings.ToLower(userID)
+
+	body := fmt.Sprintf("The protection of \"%s\" expired and it was deleted.", mediaTitle)
+	if jellyseerrURL != "" {
+		body += " You can request it again in Jellyseerr."
+	}
+
+	payload := &NotificationPayload{
+		Title: "🗑️ Kept Media Deleted",
+		Body:  body,
+		Icon:  "/static/icons/icon-192x192.png",
+		Badge: "/static/icons/icon-192x192.png",
+		Data: map[string]interface{}{
+			"type":       "kept_media_deleted",
+			"mediaTitle": mediaTitle,
+			"mediaType":  mediaType,
+			"timestamp":  time.Now().Unix(),
+		},
+		Actions: c.actions(),
+	}
+	if jellyseerrURL != "" {
+		// clicking the notification opens the media in Jellyseerr
+		payload.Data["url"] = jellyseerrURL
+	}
 
 	return c.SendNotification(ctx, userID, payload)
 }
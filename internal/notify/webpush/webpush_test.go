@@ -0,0 +1,39 @@
+package webpush
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeepRequestPayload(t *testing.T) {
+	c := NewClient(&Config{Enabled: true}, "https://jellysweep.example.com")
+
+	payload := c.keepRequestPayload("Dune", "movie", "https://image.tmdb.org/t/p/w342/dune.jpg", true)
+
+	assert.Equal(t, "✅ Keep Request Approved", payload.Title)
+	assert.Equal(t, "/static/icons/icon-192x192.png", payload.Icon)
+	assert.Equal(t, "https://image.tmdb.org/t/p/w342/dune.jpg", payload.Image)
+	assert.Equal(t, "https://jellysweep.example.com/", payload.Data["url"])
+	require.Len(t, payload.Actions, 1)
+	assert.Equal(t, NotificationAction{Action: "view", Title: "View", URL: "https://jellysweep.example.com/"}, payload.Actions[0])
+}
+
+func TestKeepRequestPayloadWithoutServerURL(t *testing.T) {
+	c := NewClient(&Config{Enabled: true}, "")
+
+	payload := c.keepRequestPayload("Dune", "movie", "", false)
+
+	assert.Equal(t, "❌ Keep Request Denied", payload.Title)
+	assert.NotContains(t, payload.Data, "url")
+	require.Len(t, payload.Actions, 1)
+	assert.Equal(t, "open_app", payload.Actions[0].Action)
+
+	// Clients which don't know the new fields still receive the same payload as before.
+	raw, err := json.Marshal(payload)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), `"image"`)
+	assert.NotContains(t, string(raw), `"url"`)
+}
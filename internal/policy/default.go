@@ -25,7 +25,7 @@ func NewDefaultDelete(cfg *config.Config) *DefaultDelete {
 }
 
 // Apply sets the DefaultDeleteAt field based on the library's cleanup delay.
-func (p *DefaultDelete) Apply(media *database.Media) error {
+func (p *DefaultDelete) Apply(ctx context.Context, media *database.Media) error {
 	libraryConfig := p.cfg.GetLibraryConfig(media.LibraryName)
 	if libraryConfig == nil {
 		return fmt.Errorf("no configuration found for library: %s", media.LibraryName)
@@ -34,7 +34,7 @@ func (p *DefaultDelete) Apply(media *database.Media) error {
 	media.DefaultDeleteAt = time.Now().Add(
 		time.Duration(libraryConfig.GetCleanupDelay()) * 24 * time.Hour,
 	)
-	log.Debug("Set default delete policy", "item", media.Title, "library", media.LibraryName, "deleteAt", media.DefaultDeleteAt)
+	log.FromContext(ctx).Debug("Set default delete policy", "item", media.Title, "library", media.LibraryName, "deleteAt", media.DefaultDeleteAt)
 
 	return nil
 }
@@ -0,0 +1,180 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// DiskUsageTargetDelete applies when disk usage exceeds a threshold with a target usage.
+// Instead of deleting all eligible items, it selects the largest items until the
+// projected disk usage drops below the target.
+type DiskUsageTargetDelete struct {
+	cfg               *config.Config
+	libraryFoldersMap map[string][]string
+	selected          map[uint]bool
+}
+
+var (
+	_ Policy   = (*DiskUsageTargetDelete)(nil)
+	_ Preparer = (*DiskUsageTargetDelete)(nil)
+)
+
+// NewDiskUsageTargetDelete creates a new instance of DiskUsageTargetDelete.
+// The library folders are rewritten with the configured path mappings.
+func NewDiskUsageTargetDelete(cfg *config.Config, libraryFoldersMap map[string][]string) *DiskUsageTargetDelete {
+	return &DiskUsageTargetDelete{
+		cfg:               cfg,
+		libraryFoldersMap: mapLibraryFolders(libraryFoldersMap, cfg.PathMappings),
+		selected:          make(map[uint]bool),
+	}
+}
+
+// Apply is a no-op, the disk usage policies are added by DiskUsageDelete.
+func (p *DiskUsageTargetDelete) Apply(context.Context, *database.Media) error {
+	return nil
+}
+
+// Prepare selects the media items which have to be deleted to reach the target usage of each library.
+func (p *DiskUsageTargetDelete) Prepare(ctx context.Context, media []database.Media) error {
+	p.selected = make(map[uint]bool)
+
+	byLibrary := make(map[string][]database.Media)
+	for _, item := range media {
+		byLibrary[item.LibraryName] = append(byLibrary[item.LibraryName], item)
+	}
+
+	for libraryName, items := range byLibrary {
+		libraryConfig := p.cfg.GetLibraryConfig(libraryName)
+		if libraryConfig == nil {
+			continue
+		}
+		if !slices.ContainsFunc(libraryConfig.DiskUsageThresholds, func(t config.DiskUsageThreshold) bool {
+			return t.TargetUsagePercent > 0
+		}) {
+			continue
+		}
+
+		usage, err := p.getLibraryDiskUsage(ctx, libraryName)
+		if err != nil {
+			log.FromContext(ctx).Warn("could not determine disk usage for library", "library", libraryName, "error", err)
+			continue
+		}
+
+		threshold := exceededTargetThreshold(libraryConfig.DiskUsageThresholds, usage.UsedPercent)
+		if threshold == nil {
+			log.FromContext(ctx).Debug("Disk usage below all target thresholds", "library", libraryName, "currentUsage", usage.UsedPercent)
+			continue
+		}
+
+		p.selectItems(ctx, libraryName, items, *threshold, usage)
+	}
+
+	return nil
+}
+
+// selectItems selects the largest eligible items until the projected usage drops below the target.
+func (p *DiskUsageTargetDelete) selectItems(ctx context.Context, libraryName string, items []database.Media, threshold config.DiskUsageThreshold, usage *disk.UsageStat) {
+	now := time.Now()
+	eligible := make([]database.Media, 0, len(items))
+	for _, item := range items {
+		for _, policy := range item.DiskUsageDeletePolicies {
+			if policy.Threshold == threshold.UsagePercent && !policy.DeleteDate.IsZero() && now.After(policy.DeleteDate) {
+				eligible = append(eligible, item)
+				break
+			}
+		}
+	}
+
+	slices.SortFunc(eligible, func(a, b database.Media) int {
+		switch {
+		case a.FileSize > b.FileSize:
+			return -1
+		case a.FileSize < b.FileSize:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	targetBytes := threshold.TargetUsagePercent / 100 * float64(usage.Total)
+	toFree := float64(usage.Used) - targetBytes
+
+	var freed float64
+	var selected int
+	for _, item := range eligible {
+		if freed >= toFree {
+			break
+		}
+		p.selected[item.ID] = true
+		freed += float64(item.FileSize)
+		selected++
+		log.FromContext(ctx).Debug("Selected item to reach disk usage target",
+			"item", item.Title,
+			"library", libraryName,
+			"size", item.FileSize,
+		)
+	}
+
+	log.FromContext(ctx).Info("Selected items to reach disk usage target",
+		"library", libraryName,
+		"currentUsage", usage.UsedPercent,
+		"threshold", threshold.UsagePercent,
+		"target", threshold.TargetUsagePercent,
+		"selected", selected,
+		"eligible", len(eligible),
+	)
+}
+
+// ShouldTriggerDeletion returns whether the item was selected to reach the disk usage target.
+func (p *DiskUsageTargetDelete) ShouldTriggerDeletion(_ context.Context, media database.Media) (bool, error) {
+	return p.selected[media.ID], nil
+}
+
+// getLibraryDiskUsage returns the usage of the fullest disk of the library.
+// The items aren't grouped by disk, so selectItems assumes that all items of the library are stored on this disk.
+// If a library spans several disks, items on the other disks count as freed space too, so fewer items than needed
+// may be selected. The threshold then stays exceeded and the next run selects more.
+func (p *DiskUsageTargetDelete) getLibraryDiskUsage(ctx context.Context, libraryName string) (*disk.UsageStat, error) {
+	folders, ok := p.libraryFoldersMap[libraryName]
+	if !ok || len(folders) == 0 {
+		return nil, fmt.Errorf("no library folders found for library: %s", libraryName)
+	}
+
+	var fullest *disk.UsageStat
+	for _, path := range folders {
+		usage, err := diskUsage(ctx, path)
+		if err != nil {
+			log.FromContext(ctx).Error("failed to get disk usage", "path", path, "error", err)
+			continue
+		}
+		if fullest == nil || usage.UsedPercent > fullest.UsedPercent {
+			fullest = usage
+		}
+	}
+	if fullest == nil {
+		return nil, fmt.Errorf("failed to get disk usage of any library folder")
+	}
+	return fullest, nil
+}
+
+// exceededTargetThreshold returns the highest exceeded threshold which has a target usage configured.
+func exceededTargetThreshold(thresholds []config.DiskUsageThreshold, currentUsage float64) *config.DiskUsageThreshold {
+	var exceeded *config.DiskUsageThreshold
+	for i := range thresholds {
+		t := &thresholds[i]
+		if t.TargetUsagePercent <= 0 || currentUsage < t.UsagePercent {
+			continue
+		}
+		if exceeded == nil || t.UsagePercent > exceeded.UsagePercent {
+			exceeded = t
+		}
+	}
+	return exceeded
+}
@@ -0,0 +1,184 @@
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestExceededTargetThreshold(t *testing.T) {
+	thresholds := []config.DiskUsageThreshold{
+		{UsagePercent: 80},
+		{UsagePercent: 85, TargetUsagePercent: 75},
+		{UsagePercent: 90, TargetUsagePercent: 80},
+		{UsagePercent: 95},
+	}
+
+	tests := []struct {
+		name    string
+		usage   float64
+		wantNil bool
+		want    float64
+	}{
+		{name: "below all thresholds", usage: 70, wantNil: true},
+		{name: "only threshold without target exceeded", usage: 82, wantNil: true},
+		{name: "lower target threshold exceeded", usage: 87, want: 85},
+		{name: "highest target threshold wins", usage: 97, want: 90},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := exceededTargetThreshold(thresholds, tt.usage)
+			if tt.wantNil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tt.want, got.UsagePercent)
+		})
+	}
+}
+
+func TestDiskUsageTargetDeletePrepare(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	item := func(id uint, size int64, threshold float64, deleteDate time.Time) database.Media {
+		return database.Media{
+			Model:       gorm.Model{ID: id},
+			LibraryName: "Movies",
+			FileSize:    size,
+			DiskUsageDeletePolicies: []database.DiskUsageDeletePolicy{
+				{Threshold: threshold, DeleteDate: deleteDate},
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		usage *disk.UsageStat
+		items []database.Media
+		want  []uint
+	}{
+		{
+			name:  "largest items first until the target is reached",
+			usage: &disk.UsageStat{Total: 1000 * gib, Used: 950 * gib, UsedPercent: 95},
+			items: []database.Media{
+				item(1, 40*gib, 90, past),
+				item(2, 100*gib, 90, past),
+				item(3, 10*gib, 90, past),
+				item(4, 60*gib, 90, past),
+			},
+			want: []uint{2, 4},
+		},
+		{
+			name:  "all eligible items if the target can't be reached",
+			usage: &disk.UsageStat{Total: 1000 * gib, Used: 950 * gib, UsedPercent: 95},
+			items: []database.Media{
+				item(1, 40*gib, 90, past),
+				item(2, 10*gib, 90, past),
+			},
+			want: []uint{1, 2},
+		},
+		{
+			name:  "items before their delete date or of another threshold are skipped",
+			usage: &disk.UsageStat{Total: 1000 * gib, Used: 950 * gib, UsedPercent: 95},
+			items: []database.Media{
+				item(1, 200*gib, 90, future),
+				item(2, 200*gib, 70, past),
+				item(3, 50*gib, 90, past),
+			},
+			want: []uint{3},
+		},
+		{
+			name:  "usage below the threshold",
+			usage: &disk.UsageStat{Total: 1000 * gib, Used: 850 * gib, UsedPercent: 85},
+			items: []database.Media{
+				item(1, 100*gib, 90, past),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDiskUsage(t, map[string]*disk.UsageStat{"/media": tt.usage})
+
+			cfg := &config.Config{
+				Libraries: map[string]*config.CleanupConfig{
+					"Movies": {DiskUsageThresholds: []config.DiskUsageThreshold{
+						{UsagePercent: 90, TargetUsagePercent: 80},
+					}},
+				},
+			}
+			p := NewDiskUsageTargetDelete(cfg, map[string][]string{"Movies": {"/media"}})
+			require.NoError(t, p.Prepare(context.Background(), tt.items))
+
+			var got []uint
+			for _, item := range tt.items {
+				trigger, err := p.ShouldTriggerDeletion(context.Background(), item)
+				require.NoError(t, err)
+				if trigger {
+					got = append(got, item.ID)
+				}
+			}
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiskUsageTargetDeletePrepareWithoutDiskUsage(t *testing.T) {
+	mockDiskUsage(t, map[string]*disk.UsageStat{})
+
+	cfg := &config.Config{
+		Libraries: map[string]*config.CleanupConfig{
+			"Movies": {DiskUsageThresholds: []config.DiskUsageThreshold{{UsagePercent: 90, TargetUsagePercent: 80}}},
+		},
+	}
+	p := NewDiskUsageTargetDelete(cfg, map[string][]string{"Movies": {"/media"}})
+	media := database.Media{Model: gorm.Model{ID: 1}, LibraryName: "Movies", DiskUsageDeletePolicies: []database.DiskUsageDeletePolicy{
+		{Threshold: 90, DeleteDate: time.Now().Add(-time.Hour)},
+	}}
+
+	require.NoError(t, p.Prepare(context.Background(), []database.Media{media}), "an unreadable disk skips the library")
+	got, err := p.ShouldTriggerDeletion(context.Background(), media)
+	require.NoError(t, err)
+	assert.False(t, got)
+}
+
+func TestDiskUsageDeleteSkipsTargetThresholds(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold config.DiskUsageThreshold
+		want      bool
+	}{
+		{name: "threshold without target", threshold: config.DiskUsageThreshold{UsagePercent: 90}, want: true},
+		{name: "threshold with target", threshold: config.DiskUsageThreshold{UsagePercent: 90, TargetUsagePercent: 80}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDiskUsage(t, map[string]*disk.UsageStat{"/media": {UsedPercent: 95, Free: 50 * gib}})
+
+			cfg := &config.Config{
+				Libraries: map[string]*config.CleanupConfig{
+					"Movies": {DiskUsageThresholds: []config.DiskUsageThreshold{tt.threshold}},
+				},
+			}
+			p := NewDiskUsageDelete(cfg, map[string][]string{"Movies": {"/media"}})
+
+			media := database.Media{LibraryName: "Movies"}
+			require.NoError(t, p.Apply(context.Background(), &media))
+			media.DiskUsageDeletePolicies[0].DeleteDate = time.Now().Add(-time.Hour)
+
+			got, err := p.ShouldTriggerDeletion(context.Background(), media)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
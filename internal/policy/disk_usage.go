@@ -20,15 +20,16 @@ type DiskUsageDelete struct {
 var _ Policy = (*DiskUsageDelete)(nil)
 
 // NewDiskUsageDelete creates a new instance of DiskUsageDelete.
+// The library folders are rewritten with the configured path mappings.
 func NewDiskUsageDelete(cfg *config.Config, libraryFoldersMap map[string][]string) *DiskUsageDelete {
 	return &DiskUsageDelete{
 		cfg:               cfg,
-		libraryFoldersMap: libraryFoldersMap,
+		libraryFoldersMap: mapLibraryFolders(libraryFoldersMap, cfg.PathMappings),
 	}
 }
 
 // Apply adds a DiskUsageDeletePolicy if the library has a disk usage threshold set.
-func (p *DiskUsageDelete) Apply(media *database.Media) error {
+func (p *DiskUsageDelete) Apply(ctx context.Context, media *database.Media) error {
 	libraryConfig := p.cfg.GetLibraryConfig(media.LibraryName)
 	if libraryConfig == nil {
 		return fmt.Errorf("no configuration found for library: %s", media.LibraryName)
@@ -43,10 +44,11 @@ func (p *DiskUsageDelete) Apply(media *database.Media) error {
 		for _, threshold := range libraryConfig.DiskUsageThresholds {
 			deletionDate := time.Now().Add(time.Duration(threshold.MaxCleanupDelay) * 24 * time.Hour)
 			media.DiskUsageDeletePolicies = append(media.DiskUsageDeletePolicies, database.DiskUsageDeletePolicy{
-				Threshold:  threshold.UsagePercent,
-				DeleteDate: deletionDate,
+				Threshold:         threshold.UsagePercent,
+				MinFreeSpaceBytes: threshold.MinFreeSpaceBytes,
+				DeleteDate:        deletionDate,
 			})
-			log.Debug("Added disk usage delete policy",
+			log.FromContext(ctx).Debug("Added disk usage delete policy",
 				"item", media.Title,
 				"library", media.LibraryName,
 				"threshold", threshold.UsagePercent,
@@ -74,46 +76,57 @@ func (p *DiskUsageDelete) ShouldTriggerDeletion(ctx context.Context, media datab
 		return false, fmt.Errorf("no library folders found for library: %s", media.LibraryName)
 	}
 
-	// Get current disk usage
+	// Get current disk usage and free space of the fullest disk
 	var currentDiskUsage float64
+	var freeBytes uint64
+	var diskUsageKnown bool
 	var diskUsageError error
 	for _, path := range folders {
-		usage, err := getLibraryDiskUsage(ctx, path)
+		usage, err := diskUsage(ctx, path)
 		if err != nil {
-			log.Error("failed to get disk usage", "path", path, "error", err)
+			log.FromContext(ctx).Error("failed to get disk usage", "path", path, "error", err)
 			diskUsageError = err
 			continue
 		}
-		// Use the highest disk usage among all paths
-		if usage > currentDiskUsage {
-			currentDiskUsage = usage
+		// Use the highest disk usage and the lowest free space among all paths
+		if usage.UsedPercent > currentDiskUsage {
+			currentDiskUsage = usage.UsedPercent
 		}
+		if !diskUsageKnown || usage.Free < freeBytes {
+			freeBytes = usage.Free
+		}
+		diskUsageKnown = true
 	}
 
-	if diskUsageError != nil && currentDiskUsage == 0 {
-		log.Warn("could not determine disk usage for library", "library", media.LibraryName)
+	if diskUsageError != nil && !diskUsageKnown {
+		log.FromContext(ctx).Warn("could not determine disk usage for library", "library", media.LibraryName)
 		// abort but dont return an error
 		return false, nil
 	}
 
 	for _, policy := range media.DiskUsageDeletePolicies {
-		if currentDiskUsage >= policy.Threshold {
+		// Thresholds with a target usage are handled by the DiskUsageTargetDelete policy.
+		if hasTargetUsage(libraryConfig.DiskUsageThresholds, policy.Threshold) {
+			continue
+		}
+		if thresholdExceeded(policy, currentDiskUsage, freeBytes) {
 			if policy.DeleteDate.IsZero() {
-				log.Warn("Disk usage threshold exceeded but no delete date set in policy. This should not happen.")
+				log.FromContext(ctx).Warn("Disk usage threshold exceeded but no delete date set in policy. This should not happen.")
 				continue
 			}
 
 			if time.Now().After(policy.DeleteDate) {
-				log.Info("Disk usage threshold exceeded, marking media for deletion",
+				log.FromContext(ctx).Info("Disk usage threshold exceeded, marking media for deletion",
 					"item", media.Title,
 					"library", media.LibraryName,
 					"currentUsage", currentDiskUsage,
+					"freeBytes", freeBytes,
 					"threshold", policy.Threshold,
 					"deleteAt", policy.DeleteDate,
 				)
 				return true, nil
 			}
-			log.Debug("Disk usage threshold exceeded, but not yet time to delete",
+			log.FromContext(ctx).Debug("Disk usage threshold exceeded, but not yet time to delete",
 				"item", media.Title,
 				"library", media.LibraryName,
 				"currentUsage", currentDiskUsage,
@@ -121,7 +134,7 @@ func (p *DiskUsageDelete) ShouldTriggerDeletion(ctx context.Context, media datab
 				"deleteAt", policy.DeleteDate,
 			)
 		} else {
-			log.Debug("Disk usage below threshold, no deletion needed",
+			log.FromContext(ctx).Debug("Disk usage below threshold, no deletion needed",
 				"item", media.Title,
 				"library", media.LibraryName,
 				"currentUsage", currentDiskUsage,
@@ -133,11 +146,33 @@ func (p *DiskUsageDelete) ShouldTriggerDeletion(ctx context.Context, media datab
 	return false, nil
 }
 
-// getLibraryDiskUsage gets disk usage in percentage for a given library path.
-func getLibraryDiskUsage(ctx context.Context, path string) (float64, error) {
-	usage, err := disk.UsageWithContext(ctx, path)
-	if err != nil {
-		return 0, err
+// hasTargetUsage reports whether the threshold with the given usage percent has a target usage.
+// Thresholds with a target always have a usage percent, so floor-only thresholds never match.
+func hasTargetUsage(thresholds []config.DiskUsageThreshold, usagePercent float64) bool {
+	if usagePercent <= 0 {
+		return false
+	}
+	for _, threshold := range thresholds {
+		if threshold.UsagePercent == usagePercent && threshold.TargetUsagePercent > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// diskUsage returns the usage of the disk the path is located on.
+// It's a variable so tests can replace it.
+var diskUsage = disk.UsageWithContext
+
+// thresholdExceeded reports whether the disk usage reached the usage percent of the policy
+// or the free space dropped below its minimum free space.
+// A policy with only a minimum free space is never exceeded by the usage percent, one without either never at all.
+func thresholdExceeded(policy database.DiskUsageDeletePolicy, currentUsage float64, freeBytes uint64) bool {
+	if policy.MinFreeSpaceBytes > 0 && freeBytes < policy.MinFreeSpaceBytes {
+		return true
+	}
+	if policy.Threshold <= 0 {
+		return false
 	}
-	return usage.UsedPercent, nil
+	return currentUsage >= policy.Threshold
 }
@@ -0,0 +1,190 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/database"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gib = 1024 * 1024 * 1024
+
+func mockDiskUsage(t *testing.T, usage map[string]*disk.UsageStat) {
+	t.Helper()
+	orig := diskUsage
+	diskUsage = func(_ context.Context, path string) (*disk.UsageStat, error) {
+		u, ok := usage[path]
+		if !ok {
+			return nil, errors.New("unknown path")
+		}
+		return u, nil
+	}
+	t.Cleanup(func() { diskUsage = orig })
+}
+
+func TestDiskUsageDeleteShouldTriggerDeletion(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold config.DiskUsageThreshold
+		usage     map[string]*disk.UsageStat
+		want      bool
+	}{
+		{
+			name:      "usage percent exceeded",
+			threshold: config.DiskUsageThreshold{UsagePercent: 80},
+			usage:     map[string]*disk.UsageStat{"/media": {UsedPercent: 85, Free: 500 * gib}},
+			want:      true,
+		},
+		{
+			name:      "usage percent not exceeded",
+			threshold: config.DiskUsageThreshold{UsagePercent: 80},
+			usage:     map[string]*disk.UsageStat{"/media": {UsedPercent: 70, Free: 500 * gib}},
+			want:      false,
+		},
+		{
+			name:      "free space above floor",
+			threshold: config.DiskUsageThreshold{MinFreeSpaceBytes: 200 * gib},
+			usage:     map[string]*disk.UsageStat{"/media": {UsedPercent: 95, Free: 300 * gib}},
+			want:      false,
+		},
+		{
+			name:      "free space below floor",
+			threshold: config.DiskUsageThreshold{MinFreeSpaceBytes: 200 * gib},
+			usage:     map[string]*disk.UsageStat{"/media": {UsedPercent: 50, Free: 100 * gib}},
+			want:      true,
+		},
+		{
+			name:      "free space below floor but usage percent not exceeded",
+			threshold: config.DiskUsageThreshold{UsagePercent: 90, MinFreeSpaceBytes: 200 * gib},
+			usage:     map[string]*disk.UsageStat{"/media": {UsedPercent: 50, Free: 100 * gib}},
+			want:      true,
+		},
+		{
+			name:      "usage percent exceeded but free space above floor",
+			threshold: config.DiskUsageThreshold{UsagePercent: 90, MinFreeSpaceBytes: 200 * gib},
+			usage:     map[string]*disk.UsageStat{"/media": {UsedPercent: 95, Free: 300 * gib}},
+			want:      true,
+		},
+		{
+			name:      "lowest free space of all folders is used",
+			threshold: config.DiskUsageThreshold{MinFreeSpaceBytes: 200 * gib},
+			usage: map[string]*disk.UsageStat{
+				"/media":  {UsedPercent: 50, Free: 500 * gib},
+				"/media2": {UsedPercent: 50, Free: 100 * gib},
+			},
+			want: true,
+		},
+		{
+			name:      "unreadable folders are skipped",
+			threshold: config.DiskUsageThreshold{MinFreeSpaceBytes: 200 * gib},
+			usage:     map[string]*disk.UsageStat{"/media2": {UsedPercent: 50, Free: 100 * gib}},
+			want:      true,
+		},
+		{
+			name:      "no readable folder",
+			threshold: config.DiskUsageThreshold{MinFreeSpaceBytes: 200 * gib},
+			usage:     map[string]*disk.UsageStat{},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDiskUsage(t, tt.usage)
+
+			cfg := &config.Config{
+				Libraries: map[string]*config.CleanupConfig{
+					"Movies": {DiskUsageThresholds: []config.DiskUsageThreshold{tt.threshold}},
+				},
+			}
+			p := NewDiskUsageDelete(cfg, map[string][]string{"Movies": {"/media", "/media2"}})
+
+			media := database.Media{LibraryName: "Movies"}
+			require.NoError(t, p.Apply(context.Background(), &media))
+			for i := range media.DiskUsageDeletePolicies {
+				media.DiskUsageDeletePolicies[i].DeleteDate = time.Now().Add(-time.Hour)
+			}
+
+			got, err := p.ShouldTriggerDeletion(context.Background(), media)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiskUsageDeleteShouldTriggerDeletionFloorOnlyThresholds(t *testing.T) {
+	thresholds := []config.DiskUsageThreshold{
+		{MinFreeSpaceBytes: 200 * gib},
+		{MinFreeSpaceBytes: 50 * gib},
+	}
+
+	tests := []struct {
+		name      string
+		free      uint64
+		deleteDue []bool
+		want      bool
+	}{
+		{
+			name:      "free space above both floors",
+			free:      300 * gib,
+			deleteDue: []bool{true, true},
+			want:      false,
+		},
+		{
+			name:      "free space below higher floor",
+			free:      100 * gib,
+			deleteDue: []bool{true, false},
+			want:      true,
+		},
+		{
+			name:      "free space below higher floor before its delete date",
+			free:      100 * gib,
+			deleteDue: []bool{false, true},
+			want:      false,
+		},
+		{
+			name:      "free space below lower floor",
+			free:      10 * gib,
+			deleteDue: []bool{false, true},
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDiskUsage(t, map[string]*disk.UsageStat{"/media": {UsedPercent: 99, Free: tt.free}})
+
+			cfg := &config.Config{
+				Libraries: map[string]*config.CleanupConfig{
+					"Movies": {DiskUsageThresholds: thresholds},
+				},
+			}
+			p := NewDiskUsageDelete(cfg, map[string][]string{"Movies": {"/media"}})
+
+			media := database.Media{LibraryName: "Movies"}
+			require.NoError(t, p.Apply(context.Background(), &media))
+			require.Len(t, media.DiskUsageDeletePolicies, len(tt.deleteDue))
+			for i, due := range tt.deleteDue {
+				if due {
+					media.DiskUsageDeletePolicies[i].DeleteDate = time.Now().Add(-time.Hour)
+				} else {
+					media.DiskUsageDeletePolicies[i].DeleteDate = time.Now().Add(time.Hour)
+				}
+			}
+
+			got, err := p.ShouldTriggerDeletion(context.Background(), media)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestThresholdExceededWithoutPercentOrFloor(t *testing.T) {
+	assert.False(t, thresholdExceeded(database.DiskUsageDeletePolicy{}, 100, 0))
+}
@@ -0,0 +1,54 @@
+package policy
+
+import (
+	"strings"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+)
+
+// mapLibraryFolders rewrites the library folders reported by the media server with the configured path mappings,
+// so the disk usage is read from the paths as they are visible to jellysweep.
+func mapLibraryFolders(libraryFoldersMap map[string][]string, mappings []config.PathMapping) map[string][]string {
+	if len(mappings) == 0 {
+		return libraryFoldersMap
+	}
+
+	mapped := make(map[string][]string, len(libraryFoldersMap))
+	for libraryName, folders := range libraryFoldersMap {
+		mappedFolders := make([]string, 0, len(folders))
+		for _, folder := range folders {
+			mappedFolders = append(mappedFolders, mapPath(folder, mappings))
+		}
+		mapped[libraryName] = mappedFolders
+	}
+	return mapped
+}
+
+// mapPath replaces the longest matching "from" prefix of the path with its "to" prefix.
+// Prefixes only match whole path segments and trailing slashes are ignored.
+// The path is returned unchanged if no mapping matches.
+func mapPath(path string, mappings []config.PathMapping) string {
+	var (
+		best     config.PathMapping
+		bestFrom string
+		found    bool
+	)
+	for _, mapping := range mappings {
+		from := strings.TrimRight(mapping.From, "/")
+		if path != from && !strings.HasPrefix(path, from+"/") {
+			continue
+		}
+		if !found || len(from) > len(bestFrom) {
+			best, bestFrom, found = mapping, from, true
+		}
+	}
+	if !found {
+		return path
+	}
+
+	mapped := strings.TrimRight(best.To, "/") + strings.TrimPrefix(path, bestFrom)
+	if mapped == "" {
+		return "/"
+	}
+	return mapped
+}
@@ -0,0 +1,90 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		mappings []config.PathMapping
+		want     string
+	}{
+		{
+			name: "no mappings",
+			path: "/media/movies",
+			want: "/media/movies",
+		},
+		{
+			name:     "prefix rewrite",
+			path:     "/media/movies",
+			mappings: []config.PathMapping{{From: "/media", To: "/mnt/storage"}},
+			want:     "/mnt/storage/movies",
+		},
+		{
+			name:     "exact match",
+			path:     "/media",
+			mappings: []config.PathMapping{{From: "/media", To: "/mnt/storage"}},
+			want:     "/mnt/storage",
+		},
+		{
+			name:     "trailing slashes are normalized",
+			path:     "/media/movies",
+			mappings: []config.PathMapping{{From: "/media/", To: "/mnt/storage/"}},
+			want:     "/mnt/storage/movies",
+		},
+		{
+			name:     "partial segment does not match",
+			path:     "/media2/movies",
+			mappings: []config.PathMapping{{From: "/media", To: "/mnt/storage"}},
+			want:     "/media2/movies",
+		},
+		{
+			name: "longest prefix wins",
+			path: "/media/tv/shows",
+			mappings: []config.PathMapping{
+				{From: "/media", To: "/mnt/storage"},
+				{From: "/media/tv", To: "/mnt/tv"},
+			},
+			want: "/mnt/tv/shows",
+		},
+		{
+			name:     "mapping to root",
+			path:     "/data/movies",
+			mappings: []config.PathMapping{{From: "/data", To: "/"}},
+			want:     "/movies",
+		},
+		{
+			name:     "root mapped exactly",
+			path:     "/data",
+			mappings: []config.PathMapping{{From: "/data/", To: "/"}},
+			want:     "/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mapPath(tt.path, tt.mappings))
+		})
+	}
+}
+
+func TestMapLibraryFolders(t *testing.T) {
+	folders := map[string][]string{
+		"Movies": {"/media/movies", "/other/movies"},
+		"TV":     {"/media/tv/"},
+	}
+	mappings := []config.PathMapping{{From: "/media", To: "/mnt/storage"}}
+
+	assert.Equal(t, map[string][]string{
+		"Movies": {"/mnt/storage/movies", "/other/movies"},
+		"TV":     {"/mnt/storage/tv/"},
+	}, mapLibraryFolders(folders, mappings))
+
+	// Without mappings the folders are returned as they are.
+	assert.Equal(t, folders, mapLibraryFolders(folders, nil))
+}
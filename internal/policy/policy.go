@@ -9,10 +9,15 @@ import (
 
 // Policy is the interface for all deletion policies.
 type Policy interface {
-	Apply(*database.Media) error
+	Apply(context.Context, *database.Media) error
 	ShouldTriggerDeletion(context.Context, database.Media) (bool, error)
 }
 
+// Preparer is implemented by policies that need to know all media items before deciding about a single one.
+type Preparer interface {
+	Prepare(context.Context, []database.Media) error
+}
+
 // Engine is the policy engine that applies all available policies to a media item.
 type Engine struct {
 	policies []Policy
@@ -31,15 +36,28 @@ func (e *Engine) SetPolicies(policies ...Policy) {
 }
 
 // ApplyAll applies all registered policies to a media item.
-func (e *Engine) ApplyAll(media *database.Media) error {
+func (e *Engine) ApplyAll(ctx context.Context, media *database.Media) error {
 	for _, policy := range e.policies {
-		if err := policy.Apply(media); err != nil {
+		if err := policy.Apply(ctx, media); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// Prepare passes all media items to the policies implementing the Preparer interface.
+// It must be called before ShouldTriggerDeletion is checked for the items.
+func (e *Engine) Prepare(ctx context.Context, media []database.Media) error {
+	for _, policy := range e.policies {
+		if preparer, ok := policy.(Preparer); ok {
+			if err := preparer.Prepare(ctx, media); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // ShouldTriggerDeletion checks if any policy indicates that the media should be deleted.
 // All policies will be checked until one returns true.
 func (e *Engine) ShouldTriggerDeletion(ctx context.Context, media database.Media) (bool, error) {
@@ -2,7 +2,12 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/charmbracelet/log"
@@ -19,6 +24,9 @@ const (
 	JobStatusScheduled JobStatus = "scheduled"
 )
 
+// ErrPaused is returned when a job is triggered manually while the scheduler is paused.
+var ErrPaused = errors.New("scheduler is paused")
+
 // JobInfo contains information about a scheduled job.
 type JobInfo struct {
 	ID                string     `json:"id"`
@@ -32,9 +40,28 @@ type JobInfo struct {
 	RunCount          int        `json:"runCount"`
 	ErrorCount        int        `json:"errorCount"`
 	LastError         string     `json:"lastError,omitempty"`
+	LastStatus        JobStatus  `json:"lastStatus,omitempty"` // Result of the last finished run, empty if the job never finished
 	Singleton         bool       `json:"singleton"`
 	GocronJob         gocron.Job `json:"-"`                           // Store gocron job reference, exclude from JSON
 	InstantAfterStart bool       `json:"instantAfterStart,omitempty"` // Whether to run immediately after adding
+	// Jitter delays every scheduled run by a random duration of up to this long, manual runs start immediately.
+	Jitter time.Duration `json:"-"`
+
+	// manualRun is set while a manually triggered run is pending, so it isn't delayed by the jitter
+	manualRun atomic.Bool
+}
+
+// JobRunStatus is a snapshot of the run state of a job.
+type JobRunStatus struct {
+	ID         string
+	Name       string
+	Schedule   string
+	Enabled    bool
+	LastRun    time.Time
+	LastStatus JobStatus
+	LastError  string
+	NextRun    time.Time
+	Running    bool
 }
 
 // JobFunc represents a function that can be scheduled.
@@ -47,11 +74,16 @@ type Scheduler struct {
 	jobFuncs map[string]JobFunc
 	ctx      context.Context
 	cancel   context.CancelFunc
+	// paused suspends all jobs without stopping the underlying gocron scheduler
+	paused atomic.Bool
 }
 
-// New creates a new scheduler.
-func New() (*Scheduler, error) {
-	gocronScheduler, err := gocron.NewScheduler(gocron.WithLogger(newLogger()))
+// New creates a new scheduler. Schedules are evaluated in the given location.
+func New(location *time.Location) (*Scheduler, error) {
+	gocronScheduler, err := gocron.NewScheduler(
+		gocron.WithLogger(newLogger()),
+		gocron.WithLocation(location),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create gocron scheduler: %w", err)
 	}
@@ -87,6 +119,11 @@ func (s *Scheduler) Start() {
 		}
 	}
 
+	if s.Paused() {
+		log.Warn("Job scheduler is paused, no jobs will run until it is resumed")
+		return
+	}
+
 	// Start jobs marked for immediate execution
 	for id, jobInfo := range s.jobs {
 		if jobInfo.InstantAfterStart {
@@ -105,6 +142,24 @@ func (s *Scheduler) Stop() error {
 	return s.gocron.Shutdown()
 }
 
+// Pause suspends all registered jobs until Resume is called.
+// Scheduled runs are skipped and manual runs are refused with ErrPaused.
+func (s *Scheduler) Pause() {
+	s.paused.Store(true)
+	log.Info("Paused job scheduler")
+}
+
+// Resume resumes all registered jobs.
+func (s *Scheduler) Resume() {
+	s.paused.Store(false)
+	log.Info("Resumed job scheduler")
+}
+
+// Paused reports whether the scheduler is paused.
+func (s *Scheduler) Paused() bool {
+	return s.paused.Load()
+}
+
 // AddJob adds a new job to the scheduler.
 func (s *Scheduler) AddJob(
 	id, name, description, definitionString string,
@@ -192,10 +247,16 @@ func (s *Scheduler) RunJobNow(id string) error {
 		return fmt.Errorf("gocron job reference not found for job %s", id)
 	}
 
+	if s.Paused() {
+		return fmt.Errorf("cannot run job %s: %w", id, ErrPaused)
+	}
+
 	log.Info("Manually triggering job", "id", id, "name", jobInfo.Name)
 
 	// Use gocron's RunNow method to trigger the job
+	jobInfo.manualRun.Store(true)
 	if err := jobInfo.GocronJob.RunNow(); err != nil {
+		jobInfo.manualRun.Store(false)
 		return fmt.Errorf("failed to trigger job %s: %w", id, err)
 	}
 
@@ -213,6 +274,44 @@ func (s *Scheduler) GetJob(id string) (*JobInfo, bool) {
 	return job, exists
 }
 
+// NextRun returns the next scheduled run of a job.
+func (s *Scheduler) NextRun(jobID string) (time.Time, error) {
+	jobInfo, exists := s.jobs[jobID]
+	if !exists {
+		return time.Time{}, fmt.Errorf("job %s not found", jobID)
+	}
+	if jobInfo.GocronJob == nil {
+		return time.Time{}, fmt.Errorf("gocron job reference not found for job %s", jobID)
+	}
+	return jobInfo.GocronJob.NextRun()
+}
+
+// JobStatuses returns the run state of all registered jobs, sorted by ID.
+func (s *Scheduler) JobStatuses() []JobRunStatus {
+	statuses := make([]JobRunStatus, 0, len(s.jobs))
+	for id, jobInfo := range s.jobs {
+		status := JobRunStatus{
+			ID:         id,
+			Name:       jobInfo.Name,
+			Schedule:   jobInfo.Schedule,
+			Enabled:    jobInfo.Enabled,
+			LastRun:    jobInfo.LastRun,
+			LastStatus: jobInfo.LastStatus,
+			LastError:  jobInfo.LastError,
+			NextRun:    jobInfo.NextRun,
+			Running:    jobInfo.Status == JobStatusRunning,
+		}
+		if nextRun, err := s.NextRun(id); err == nil {
+			status.NextRun = nextRun
+		}
+		statuses = append(statuses, status)
+	}
+	slices.SortFunc(statuses, func(a, b JobRunStatus) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return statuses
+}
+
 // EnableJob enables a job.
 func (s *Scheduler) EnableJob(id string) error {
 	jobInfo, exists := s.jobs[id]
@@ -241,6 +340,25 @@ func (s *Scheduler) DisableJob(id string) error {
 	return nil
 }
 
+// SetJitter delays every scheduled run of the job by a random duration of up to jitter.
+// The delay is part of the run, so a singleton job still never overlaps with itself.
+func (s *Scheduler) SetJitter(id string, jitter time.Duration) error {
+	jobInfo, exists := s.jobs[id]
+	if !exists {
+		return fmt.Errorf("job %s not found", id)
+	}
+	jobInfo.Jitter = jitter
+	return nil
+}
+
+// jitterDelay returns a random delay in [0, jitter].
+func jitterDelay(jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return 0
+	}
+	return rand.N(jitter + 1)
+}
+
 // wrapJobFunc wraps a job function to update job statistics.
 func (s *Scheduler) wrapJobFunc(id string, jobFunc JobFunc) func() {
 	return func() {
@@ -256,6 +374,29 @@ func (s *Scheduler) wrapJobFunc(id string, jobFunc JobFunc) func() {
 			return
 		}
 
+		if s.Paused() {
+			log.Info("Job scheduler is paused, skipping job", "id", id)
+			if nextRun, err := jobInfo.GocronJob.NextRun(); err == nil {
+				jobInfo.NextRun = nextRun
+			}
+			return
+		}
+
+		if manual := jobInfo.manualRun.Swap(false); !manual {
+			if delay := jitterDelay(jobInfo.Jitter); delay > 0 {
+				log.Info("Delaying job by the configured jitter", "id", id, "delay", delay.Round(time.Second))
+				select {
+				case <-time.After(delay):
+				case <-s.ctx.Done():
+					return
+				}
+				if s.Paused() {
+					log.Info("Job scheduler was paused during the jitter delay, skipping job", "id", id)
+					return
+				}
+			}
+		}
+
 		log.Info("Starting job", "id", id, "name", jobInfo.Name)
 		jobInfo.Status = JobStatusRunning
 		jobInfo.LastRun = time.Now()
@@ -268,11 +409,13 @@ func (s *Scheduler) wrapJobFunc(id string, jobFunc JobFunc) func() {
 		if err := jobFunc(s.ctx); err != nil {
 			log.Error("Job failed", "id", id, "name", jobInfo.Name, "error", err)
 			jobInfo.Status = JobStatusFailed
+			jobInfo.LastStatus = JobStatusFailed
 			jobInfo.ErrorCount++
 			jobInfo.LastError = err.Error()
 		} else {
 			log.Info("Job completed successfully", "id", id, "name", jobInfo.Name)
 			jobInfo.Status = JobStatusCompleted
+			jobInfo.LastStatus = JobStatusCompleted
 			jobInfo.LastError = ""
 		}
 	}
@@ -0,0 +1,31 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJitterDelay(t *testing.T) {
+	assert.Zero(t, jitterDelay(0))
+	assert.Zero(t, jitterDelay(-time.Second))
+
+	for range 100 {
+		delay := jitterDelay(time.Minute)
+		assert.GreaterOrEqual(t, delay, time.Duration(0))
+		assert.LessOrEqual(t, delay, time.Minute)
+	}
+}
+
+func TestSetJitter(t *testing.T) {
+	s, err := New(time.UTC)
+	require.NoError(t, err)
+
+	assert.Error(t, s.SetJitter("missing", time.Minute))
+
+	s.jobs["cleanup"] = &JobInfo{ID: "cleanup"}
+	assert.NoError(t, s.SetJitter("cleanup", time.Minute))
+	assert.Equal(t, time.Minute, s.jobs["cleanup"].Jitter)
+}
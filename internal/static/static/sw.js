@@ -131,21 +131,36 @@ self.addEventListener("push", (event) => {
     return;
   }
 
+  const actions = notificationData.actions || [
+    {
+      action: "open",
+      title: "Open Jellysweep",
+    },
+  ];
+
+  // Notification actions can't carry a URL, so remember them in the data
+  // to open the right page once an action is clicked.
+  const data = notificationData.data || {};
+  data.actionUrls = {};
+  for (const action of actions) {
+    if (action.url) {
+      data.actionUrls[action.action] = action.url;
+    }
+  }
+
   const options = {
     body: notificationData.body || "New notification from Jellysweep",
     icon: notificationData.icon || "/static/jellysweep.png",
     badge: notificationData.badge || "/static/jellysweep.png",
     tag: "jellysweep-notification",
-    data: notificationData.data || {},
-    actions: notificationData.actions || [
-      {
-        action: "open",
-        title: "Open Jellysweep",
-      },
-    ],
+    data: data,
+    actions: actions.map(({ action, title, icon }) => ({ action, title, icon })),
     requireInteraction: true,
     vibrate: [100, 50, 100],
   };
+  if (notificationData.image) {
+    options.image = notificationData.image;
+  }
 
   event.waitUntil(
     self.registration.showNotification(
@@ -164,14 +179,16 @@ self.addEventListener("notificationclick", (event) => {
   const action = event.action;
   const data = event.notification.data;
 
+  // Prefer the URL of the clicked action, then the URL of the notification
   let url = "/";
-
-  // Handle different notification types
-  if (data && data.type === "keep_request_decision") {
-    url = "/"; // Could be more specific based on the type
+  if (data && data.actionUrls && action && data.actionUrls[action]) {
+    url = data.actionUrls[action];
+  } else if (data && data.url) {
+    url = data.url;
   }
+  const targetUrl = new URL(url, self.location.origin).href;
 
-  if (action === "open" || !action) {
+  if (action === "open" || action === "open_app" || !action || (data && data.actionUrls && data.actionUrls[action])) {
     event.waitUntil(
       clients
         .matchAll({ type: "window", includeUncontrolled: true })
@@ -179,7 +196,7 @@ self.addEventListener("notificationclick", (event) => {
           // Check if there's already a window/tab open with the target URL
           for (const client of clients) {
             if (
-              client.url === self.location.origin + url &&
+              client.url === targetUrl &&
               "focus" in client
             ) {
               return client.focus();
@@ -188,7 +205,7 @@ self.addEventListener("notificationclick", (event) => {
 
           // If no existing window, open a new one
           if (clients.openWindow) {
-            return clients.openWindow(url);
+            return clients.openWindow(targetUrl);
           }
         })
     );
@@ -12,21 +12,46 @@ import (
 	"time"
 )
 
-// Tag type constants for jellysweep tagging system.
-const (
+// DefaultPrefix is the default base prefix of all jellysweep tags.
+const DefaultPrefix = "jellysweep"
+
+// Tag names for the jellysweep tagging system.
+// They are derived from the base prefix and can be changed with SetPrefix.
+var (
 	// Tag prefixes for different types of jellysweep tags.
-	JellysweepTagPrefix         = "jellysweep-delete-"
-	JellysweepKeepRequestPrefix = "jellysweep-keep-request-"
-	JellysweepKeepPrefix        = "jellysweep-must-keep-"
+	JellysweepTagPrefix         string
+	JellysweepKeepRequestPrefix string
+	JellysweepKeepPrefix        string
 
 	// Special tags.
-	JellysweepDeleteForSureTag = "jellysweep-must-delete-for-sure"
-	JellysweepIgnoreTag        = "jellysweep-ignore"
+	JellysweepDeleteForSureTag string
+	JellysweepIgnoreTag        string
+	JellysweepDeleteNowTag     string
 
 	// jellysweepDiskUsageTagPrefix is the prefix for disk usage-based deletion tags.
-	jellysweepDiskUsageTagPrefix = "jellysweep-delete-du"
+	jellysweepDiskUsageTagPrefix string
 )
 
+func init() {
+	SetPrefix(DefaultPrefix)
+}
+
+// SetPrefix sets the base prefix used to build and parse all jellysweep tags.
+// An empty prefix resets it to DefaultPrefix.
+// It must be called before any tags are created or parsed.
+func SetPrefix(prefix string) {
+	if prefix == "" {
+		prefix = DefaultPrefix
+	}
+	JellysweepTagPrefix = prefix + "-delete-"
+	JellysweepKeepRequestPrefix = prefix + "-keep-request-"
+	JellysweepKeepPrefix = prefix + "-must-keep-"
+	JellysweepDeleteForSureTag = prefix + "-must-delete-for-sure"
+	JellysweepIgnoreTag = prefix + "-ignore"
+	JellysweepDeleteNowTag = prefix + "-delete-now"
+	jellysweepDiskUsageTagPrefix = prefix + "-delete-du"
+}
+
 // TagInfo contains information about a jellysweep tag.
 type TagInfo struct {
 	DiskUsage      float64 // For disk usage tags (du90, du70, etc.)
@@ -45,14 +70,14 @@ func ParseJellysweepTag(tagName string) (*TagInfo, error) {
 	// Handle disk usage tags (jellysweep-delete-du90-2025-08-23)
 	switch {
 	case strings.HasPrefix(tagName, jellysweepDiskUsageTagPrefix):
-		// Extract parts: jellysweep-delete-du90-2025-08-23
-		parts := strings.Split(tagName, "-")
-		if len(parts) < 6 {
+		// Extract parts after the prefix: du90-2025-08-23
+		parts := strings.Split(strings.TrimPrefix(tagName, JellysweepTagPrefix), "-")
+		if len(parts) < 4 {
 			return nil, fmt.Errorf("invalid disk usage tag format: %s", tagName)
 		}
 
 		// Parse disk usage percentage (du90 -> 90.0)
-		duPart := parts[2] // "du90"
+		duPart := parts[0] // "du90"
 		if !strings.HasPrefix(duPart, "du") {
 			return nil, fmt.Errorf("invalid disk usage tag format, missing 'du' prefix: %s", tagName)
 		}
@@ -63,12 +88,15 @@ func ParseJellysweepTag(tagName string) (*TagInfo, error) {
 		}
 
 		// Parse date (2025-08-23)
-		dateStr := strings.Join(parts[3:], "-")
+		dateStr := strings.Join(parts[1:], "-")
 		info.DeletionDate, err = time.Parse("2006-01-02", dateStr)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse date from tag %s: %v", tagName, err)
 		}
 
+	case tagName == JellysweepDeleteNowTag:
+		return nil, fmt.Errorf("delete now tag has no legacy meaning: %s", tagName)
+
 	case strings.HasPrefix(tagName, JellysweepTagPrefix):
 		dateStr := strings.TrimPrefix(tagName, JellysweepTagPrefix)
 		var err error
@@ -104,9 +132,10 @@ func IsJellysweepTag(tagName string) bool {
 		tagName == JellysweepIgnoreTag
 }
 
-// IsJellysweepTagWithoutIgnore checks if a tag is a jellysweep tag excluding the ignore tag.
+// IsJellysweepTagWithoutIgnore checks if a tag is a jellysweep tag excluding the tags set by users,
+// i.e. the ignore and the delete now tag.
 func IsJellysweepTagWithoutIgnore(tagName string) bool {
-	return IsJellysweepTag(tagName) && tagName != JellysweepIgnoreTag
+	return IsJellysweepTag(tagName) && tagName != JellysweepIgnoreTag && tagName != JellysweepDeleteNowTag
 }
 
 // IsJellysweepOrAdditionalTag checks if a tag is a jellysweep tag or in the additional tags list.
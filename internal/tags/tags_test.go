@@ -0,0 +1,105 @@
+package tags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetPrefix(t *testing.T) {
+	t.Cleanup(func() { SetPrefix(DefaultPrefix) })
+
+	SetPrefix("jellysweep-test")
+	assert.Equal(t, "jellysweep-test-delete-", JellysweepTagPrefix)
+	assert.Equal(t, "jellysweep-test-keep-request-", JellysweepKeepRequestPrefix)
+	assert.Equal(t, "jellysweep-test-must-keep-", JellysweepKeepPrefix)
+	assert.Equal(t, "jellysweep-test-must-delete-for-sure", JellysweepDeleteForSureTag)
+	assert.Equal(t, "jellysweep-test-ignore", JellysweepIgnoreTag)
+	assert.Equal(t, "jellysweep-test-delete-now", JellysweepDeleteNowTag)
+
+	SetPrefix("")
+	assert.Equal(t, "jellysweep-delete-", JellysweepTagPrefix)
+}
+
+func TestParseJellysweepTagCustomPrefix(t *testing.T) {
+	t.Cleanup(func() { SetPrefix(DefaultPrefix) })
+	SetPrefix("staging")
+
+	tests := []struct {
+		name    string
+		tag     string
+		want    *TagInfo
+		wantErr bool
+	}{
+		{
+			name: "delete tag",
+			tag:  "staging-delete-2025-08-23",
+			want: &TagInfo{DeletionDate: time.Date(2025, 8, 23, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "disk usage tag",
+			tag:  "staging-delete-du90-2025-08-23",
+			want: &TagInfo{DiskUsage: 90, DeletionDate: time.Date(2025, 8, 23, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "keep tag",
+			tag:  "staging-must-keep-2025-09-01-alice",
+			want: &TagInfo{ProtectedUntil: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "delete for sure tag",
+			tag:  "staging-must-delete-for-sure",
+			want: &TagInfo{MustDelete: true},
+		},
+		{
+			name:    "default prefix is not recognized",
+			tag:     "jellysweep-delete-2025-08-23",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseJellysweepTag(tt.tag)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsJellysweepTagCustomPrefix(t *testing.T) {
+	t.Cleanup(func() { SetPrefix(DefaultPrefix) })
+	SetPrefix("prod")
+
+	tests := []struct {
+		tag  string
+		want bool
+	}{
+		{"prod-delete-2025-08-23", true},
+		{"prod-keep-request-2025-08-23-bob", true},
+		{"prod-must-keep-2025-08-23", true},
+		{"prod-delete-du70-2025-08-23", true},
+		{"prod-must-delete-for-sure", true},
+		{"prod-ignore", true},
+		{"prod-delete-now", true},
+		{"jellysweep-delete-2025-08-23", false},
+		{"jellysweep-ignore", false},
+		{"favorites", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsJellysweepTag(tt.tag))
+		})
+	}
+
+	assert.False(t, IsJellysweepTagWithoutIgnore("prod-ignore"))
+	assert.False(t, IsJellysweepTagWithoutIgnore("prod-delete-now"))
+	assert.True(t, IsJellysweepTagWithoutIgnore("prod-delete-2025-08-23"))
+}
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jon4hz/jellysweep/internal/config"
@@ -20,14 +23,33 @@ type Client struct {
 }
 
 // NewClient creates a new Jellyseerr API client.
-func New(cfg *config.JellyseerrConfig) *Client {
+func New(cfg *config.JellyseerrConfig, proxy *config.ProxyConfig, retry *config.RetryConfig) *Client {
 	return &Client{
 		baseURL:    cfg.URL,
 		apiKey:     cfg.APIKey,
-		httpClient: &http.Client{Timeout: config.TimeoutDuration(cfg.Timeout)},
+		httpClient: config.NewRetryHTTPClient(cfg.Timeout, proxy, cfg.Proxy, retry),
 	}
 }
 
+// ErrMediaNotFound is returned if Jellyseerr doesn't track the requested media.
+var ErrMediaNotFound = errors.New("media not found in jellyseerr")
+
+// MediaStatus represents the availability status of media in Jellyseerr.
+type MediaStatus string
+
+const (
+	// MediaStatusAvailable marks the media as available.
+	MediaStatusAvailable MediaStatus = "available"
+	// MediaStatusPartial marks the media as partially available.
+	MediaStatusPartial MediaStatus = "partial"
+	// MediaStatusProcessing marks the media as requested and processing.
+	MediaStatusProcessing MediaStatus = "processing"
+	// MediaStatusPending marks the media as pending.
+	MediaStatusPending MediaStatus = "pending"
+	// MediaStatusUnknown resets the media status.
+	MediaStatusUnknown MediaStatus = "unknown"
+)
+
 // MediaInfo represents media information from the API.
 type MediaInfo struct {
 	ID       int            `json:"id"`
@@ -105,6 +127,10 @@ func (c *Client) doRequest(ctx context.Context, method, endpoint string, body in
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		defer resp.Body.Close() //nolint:errcheck
 		bodyBytes, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode == http.StatusNotFound {
+			// stale media or requests, e.g. removed in Jellyseerr since they were looked up
+			return nil, fmt.Errorf("%w: %s", ErrMediaNotFound, endpoint)
+		}
 		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
 	}
 
@@ -213,6 +239,8 @@ type RequestInfo struct {
 	RequestTime *time.Time
 	UserEmail   string
 	UserName    string
+	// Requesters are all distinct users that requested the media, identified by their email or display name.
+	Requesters []string
 }
 
 // GetRequestInfo returns detailed information about who requested specific media and when.
@@ -236,6 +264,7 @@ func (c *Client) GetRequestInfo(ctx context.Context, tmdbID int32, mediaType str
 				RequestTime: &lastRequest.CreatedAt,
 				UserEmail:   lastRequest.RequestedBy.Email,
 				UserName:    getDisplayName(lastRequest.RequestedBy),
+				Requesters:  getRequesters(mediaItem.Requests),
 			}, nil
 		}
 	}
@@ -243,6 +272,70 @@ func (c *Client) GetRequestInfo(ctx context.Context, tmdbID int32, mediaType str
 	return &RequestInfo{}, nil
 }
 
+// UpdateMediaStatus sets the status of the media with the given TMDB ID in Jellyseerr.
+func (c *Client) UpdateMediaStatus(ctx context.Context, tmdbID int32, mediaType string, status MediaStatus) error {
+	mediaItem, err := c.GetMediaItem(ctx, tmdbID, mediaType)
+	if err != nil {
+		return err
+	}
+	if mediaItem.MediaInfo.ID == 0 {
+		return ErrMediaNotFound
+	}
+
+	endpoint := fmt.Sprintf("/api/v1/media/%d/%s", mediaItem.MediaInfo.ID, status)
+	resp, err := c.doRequest(ctx, "POST", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	return nil
+}
+
+// DeleteMediaRequest removes the media with the given TMDB ID from Jellyseerr, together with all its requests.
+// Afterwards the media can be requested again and is no longer shown as available.
+func (c *Client) DeleteMediaRequest(ctx context.Context, tmdbID int32, mediaType string) error {
+	mediaItem, err := c.GetMediaItem(ctx, tmdbID, mediaType)
+	if err != nil {
+		return err
+	}
+	if mediaItem.MediaInfo.ID == 0 {
+		return ErrMediaNotFound
+	}
+
+	endpoint := fmt.Sprintf("/api/v1/media/%d", mediaItem.MediaInfo.ID)
+	resp, err := c.doRequest(ctx, "DELETE", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	return nil
+}
+
+// getRequesters returns the distinct users of the requests, identified by their email or display name.
+// Requests of the same Jellyseerr user are only counted once.
+func getRequesters(requests []MediaRequest) []string {
+	seen := make(map[string]bool, len(requests))
+	requesters := make([]string, 0, len(requests))
+	for _, request := range requests {
+		name := request.RequestedBy.Email
+		if name == "" {
+			name = getDisplayName(request.RequestedBy)
+		}
+		key := strings.ToLower(name)
+		if request.RequestedBy.ID != 0 {
+			key = strconv.Itoa(request.RequestedBy.ID)
+		}
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		requesters = append(requesters, name)
+	}
+	return requesters
+}
+
 // getDisplayName returns the best display name for a user.
 func getDisplayName(user User) string {
 	if user.DisplayName != "" {
@@ -253,3 +346,13 @@ func getDisplayName(user User) string {
 	}
 	return user.Email
 }
+
+// GetStatus requests the status of Jellyseerr, which is a cheap way to check the connection.
+func (c *Client) GetStatus(ctx context.Context) error {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/api/v1/status", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	return nil
+}
@@ -2,9 +2,11 @@ package jellyseerr
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
 	"time"
 
@@ -48,7 +50,7 @@ func TestGetMovie(t *testing.T) {
 	client := New(&config.JellyseerrConfig{
 		URL:    server.URL,
 		APIKey: "test-api-key",
-	})
+	}, nil, nil)
 
 	// Test GetMovie
 	movie, err := client.GetMovie(context.Background(), 12345)
@@ -107,7 +109,7 @@ func TestGetTvShow(t *testing.T) {
 	client := New(&config.JellyseerrConfig{
 		URL:    server.URL,
 		APIKey: "test-api-key",
-	})
+	}, nil, nil)
 
 	// Test GetTvShow
 	tvShow, err := client.GetTvShow(context.Background(), 67890)
@@ -166,7 +168,7 @@ func TestGetRequestTime(t *testing.T) {
 	client := New(&config.JellyseerrConfig{
 		URL:    server.URL,
 		APIKey: "test-api-key",
-	})
+	}, nil, nil)
 
 	// Test GetRequestTime
 	requestTime, err := client.GetRequestTime(context.Background(), 12345, "movie")
@@ -209,7 +211,7 @@ func TestGetRequestTimeNoRequests(t *testing.T) {
 	client := New(&config.JellyseerrConfig{
 		URL:    server.URL,
 		APIKey: "test-api-key",
-	})
+	}, nil, nil)
 
 	// Test GetRequestTime with no requests - should return no error but nil time
 	requestTime, err := client.GetRequestTime(context.Background(), 12345, "movie")
@@ -221,3 +223,130 @@ func TestGetRequestTimeNoRequests(t *testing.T) {
 		t.Errorf("Expected nil request time, got %v", *requestTime)
 	}
 }
+
+func TestUpdateMediaStatus(t *testing.T) {
+	var statusPath string
+	// Create a mock HTTP server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tv/67890":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"id": 67890, "name": "Test TV Show", "mediaInfo": {"id": 7, "tmdbId": 67890}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/movie/12345":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"id": 12345, "title": "Unrequested Movie"}`)
+		case r.Method == http.MethodPost:
+			statusPath = r.URL.Path
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	// Create client with test server URL
+	client := New(&config.JellyseerrConfig{
+		URL:    server.URL,
+		APIKey: "test-api-key",
+	}, nil, nil)
+
+	if err := client.UpdateMediaStatus(context.Background(), 67890, "tv", MediaStatusAvailable); err != nil {
+		t.Fatalf("UpdateMediaStatus failed: %v", err)
+	}
+	if statusPath != "/api/v1/media/7/available" {
+		t.Errorf("Expected status update on /api/v1/media/7/available, got %s", statusPath)
+	}
+
+	// Media without media info isn't tracked by jellyseerr
+	err := client.UpdateMediaStatus(context.Background(), 12345, "movie", MediaStatusAvailable)
+	if !errors.Is(err, ErrMediaNotFound) {
+		t.Errorf("Expected ErrMediaNotFound, got %v", err)
+	}
+}
+
+func TestDeleteMediaRequest(t *testing.T) {
+	var deletePath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/movie/12345":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"id": 12345, "title": "Test Movie", "mediaInfo": {"id": 3, "tmdbId": 12345, "requests": [{"id": 9}]}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tv/67890":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"id": 67890, "name": "Unrequested TV Show"}`)
+		case r.Method == http.MethodDelete:
+			deletePath = r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := New(&config.JellyseerrConfig{
+		URL:    server.URL,
+		APIKey: "test-api-key",
+	}, nil, nil)
+
+	if err := client.DeleteMediaRequest(context.Background(), 12345, "movie"); err != nil {
+		t.Fatalf("DeleteMediaRequest failed: %v", err)
+	}
+	if deletePath != "/api/v1/media/3" {
+		t.Errorf("Expected deletion of /api/v1/media/3, got %s", deletePath)
+	}
+
+	err := client.DeleteMediaRequest(context.Background(), 67890, "tv")
+	if !errors.Is(err, ErrMediaNotFound) {
+		t.Errorf("Expected ErrMediaNotFound, got %v", err)
+	}
+
+	// stale media that Jellyseerr doesn't know anymore
+	err = client.DeleteMediaRequest(context.Background(), 11111, "movie")
+	if !errors.Is(err, ErrMediaNotFound) {
+		t.Errorf("Expected ErrMediaNotFound for a 404, got %v", err)
+	}
+}
+
+func TestGetRequestInfoRequesters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/movie/12345" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"id": 12345,
+			"title": "Test Movie",
+			"mediaInfo": {
+				"id": 1,
+				"tmdbId": 12345,
+				"requests": [
+					{"createdAt": "2023-01-01T00:00:00.000Z", "requestedBy": {"id": 1, "email": "first@example.com"}},
+					{"createdAt": "2023-02-01T00:00:00.000Z", "requestedBy": {"id": 2, "email": "second@example.com"}},
+					{"createdAt": "2023-03-01T00:00:00.000Z", "requestedBy": {"id": 1, "email": "first@example.com"}},
+					{"createdAt": "2023-04-01T00:00:00.000Z", "requestedBy": {"id": 3, "username": "jellyfin-user"}}
+				]
+			}
+		}`)
+	}))
+	defer server.Close()
+
+	client := New(&config.JellyseerrConfig{
+		URL:    server.URL,
+		APIKey: "test-api-key",
+	}, nil, nil)
+
+	info, err := client.GetRequestInfo(context.Background(), 12345, "movie")
+	if err != nil {
+		t.Fatalf("GetRequestInfo failed: %v", err)
+	}
+
+	expected := []string{"first@example.com", "second@example.com", "jellyfin-user"}
+	if !slices.Equal(info.Requesters, expected) {
+		t.Errorf("Expected requesters %v, got %v", expected, info.Requesters)
+	}
+	if info.UserName != "jellyfin-user" {
+		t.Errorf("Expected newest requester jellyfin-user, got %s", info.UserName)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"time"
 
@@ -27,13 +28,11 @@ type Client struct {
 }
 
 // New creates a new Jellystat client.
-func New(cfg *config.JellystatConfig) *Client {
+func New(cfg *config.JellystatConfig, proxy *config.ProxyConfig) *Client {
 	return &Client{
 		baseURL: cfg.URL,
 		apiKey:  cfg.APIKey,
-		client: &http.Client{
-			Timeout: config.TimeoutDuration(cfg.Timeout),
-		},
+		client:  config.NewHTTPClient(cfg.Timeout, proxy, cfg.Proxy),
 	}
 }
 
@@ -213,6 +212,37 @@ func (c *Client) GetLastPlayed(ctx context.Context, itemID string) (*LastPlayedI
 	return info, nil
 }
 
+const (
+	// playCountPageSize is the number of history entries requested per page when counting plays.
+	playCountPageSize = 100
+	// maxPlayCountPages limits the pages requested when counting plays, the count stops at 10000 plays.
+	maxPlayCountPages = 100
+)
+
+// GetPlayCount returns the number of playback history entries of an item.
+// The count stops after maxPlayCountPages pages, or if the server returns the same page twice, e.g. because it ignores the page parameter.
+func (c *Client) GetPlayCount(ctx context.Context, itemID string) (int, error) {
+	var (
+		count    int
+		previous []PlaybackHistory
+	)
+	for page := 1; page <= maxPlayCountPages; page++ {
+		history, err := c.GetItemHistory(ctx, itemID, &ItemHistoryParams{Size: playCountPageSize, Page: page})
+		if err != nil {
+			return 0, err
+		}
+		if page > 1 && slices.Equal(history.Results, previous) {
+			return count, nil
+		}
+		count += len(history.Results)
+		if len(history.Results) < playCountPageSize {
+			return count, nil
+		}
+		previous = history.Results
+	}
+	return count, nil
+}
+
 // GetLibraryMetadata retrieves metadata for all libraries.
 func (c *Client) GetLibraryMetadata(ctx context.Context) ([]LibraryMetadata, error) {
 	// Build URL
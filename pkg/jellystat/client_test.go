@@ -0,0 +1,85 @@
+package jellystat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newHistoryServer serves total history entries in pages. If ignorePage is set, every request returns the first page.
+func newHistoryServer(t *testing.T, total int, ignorePage bool) (*Client, *int) {
+	t.Helper()
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/api/getItemHistory", r.URL.Path)
+		size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if ignorePage {
+			page = 1
+		}
+
+		results := make([]PlaybackHistory, 0, size)
+		for i := (page - 1) * size; i < min(page*size, total); i++ {
+			results = append(results, PlaybackHistory{
+				UserName:             fmt.Sprintf("user-%d", i),
+				ActivityDateInserted: time.Date(2026, 1, 1, 0, 0, i, 0, time.UTC),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ItemHistoryResponse{Results: results})
+	}))
+	t.Cleanup(server.Close)
+
+	return New(&config.JellystatConfig{URL: server.URL, APIKey: "key"}, nil), &requests
+}
+
+func TestGetPlayCount(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		total        int
+		wantCount    int
+		wantRequests int
+	}{
+		{name: "no plays", total: 0, wantCount: 0, wantRequests: 1},
+		{name: "single page", total: 42, wantCount: 42, wantRequests: 1},
+		{name: "multiple pages", total: 250, wantCount: 250, wantRequests: 3},
+		{name: "full last page", total: 200, wantCount: 200, wantRequests: 3},
+		{name: "more than the page limit", total: 20000, wantCount: playCountPageSize * maxPlayCountPages, wantRequests: maxPlayCountPages},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			client, requests := newHistoryServer(t, tt.total, false)
+			count, err := client.GetPlayCount(context.Background(), "item")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCount, count)
+			assert.Equal(t, tt.wantRequests, *requests)
+		})
+	}
+}
+
+func TestGetPlayCountRepeatedPage(t *testing.T) {
+	client, requests := newHistoryServer(t, 500, true)
+	count, err := client.GetPlayCount(context.Background(), "item")
+	require.NoError(t, err)
+	assert.Equal(t, playCountPageSize, count, "a repeated page isn't counted twice")
+	assert.Equal(t, 2, *requests)
+}
+
+func TestGetPlayCountError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := New(&config.JellystatConfig{URL: server.URL, APIKey: "key"}, nil).GetPlayCount(context.Background(), "item")
+	require.Error(t, err)
+}
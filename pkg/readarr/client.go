@@ -0,0 +1,264 @@
+package readarr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ccoveille/go-safecast"
+	"github.com/jon4hz/jellysweep/internal/config"
+	"github.com/jon4hz/jellysweep/internal/version"
+)
+
+// Client represents a Readarr API client.
+type Client struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// New creates a new Readarr API client.
+func New(cfg *config.ReadarrConfig, proxy *config.ProxyConfig, retry *config.RetryConfig) *Client {
+	return &Client{
+		baseURL:    cfg.URL,
+		apiKey:     cfg.APIKey,
+		httpClient: config.NewRetryHTTPClient(cfg.Timeout, proxy, cfg.Proxy, retry),
+	}
+}
+
+// Book represents a book in Readarr.
+type Book struct {
+	ID            int32           `json:"id"`
+	Title         string          `json:"title"`
+	AuthorID      int32           `json:"authorId"`
+	ForeignBookID string          `json:"foreignBookId"`
+	ReleaseDate   *time.Time      `json:"releaseDate,omitempty"`
+	Added         time.Time       `json:"added"`
+	Monitored     bool            `json:"monitored"`
+	Statistics    *BookStatistics `json:"statistics,omitempty"`
+	Images        []Image         `json:"images,omitempty"`
+}
+
+// BookStatistics holds the file statistics of a book.
+type BookStatistics struct {
+	BookFileCount int32 `json:"bookFileCount"`
+	SizeOnDisk    int64 `json:"sizeOnDisk"`
+}
+
+// Image represents a cover image of a book or author.
+type Image struct {
+	CoverType string `json:"coverType"`
+	URL       string `json:"url"`
+	RemoteURL string `json:"remoteUrl"`
+}
+
+// Year returns the release year of the book or 0 if it is unknown.
+func (b *Book) Year() int32 {
+	if b.ReleaseDate == nil {
+		return 0
+	}
+	year, err := safecast.Convert[int32](b.ReleaseDate.Year())
+	if err != nil {
+		return 0
+	}
+	return year
+}
+
+// SizeOnDisk returns the size of all files of the book.
+func (b *Book) SizeOnDisk() int64 {
+	if b.Statistics == nil {
+		return 0
+	}
+	return b.Statistics.SizeOnDisk
+}
+
+// CoverURL returns the remote URL of the book cover or an empty string if there is no cover.
+func (b *Book) CoverURL() string {
+	for _, img := range b.Images {
+		if img.CoverType == "cover" {
+			return img.RemoteURL
+		}
+	}
+	return ""
+}
+
+// Author represents an author in Readarr.
+// Readarr manages tags per author, so all books of an author share the same tags.
+type Author struct {
+	ID         int32   `json:"id"`
+	AuthorName string  `json:"authorName"`
+	Path       string  `json:"path"`
+	Tags       []int32 `json:"tags"`
+}
+
+// Tag represents a Readarr tag.
+type Tag struct {
+	ID    int32  `json:"id"`
+	Label string `json:"label"`
+}
+
+// HistoryRecord represents a single entry of the Readarr history.
+type HistoryRecord struct {
+	BookID    int32     `json:"bookId"`
+	EventType string    `json:"eventType"`
+	Date      time.Time `json:"date"`
+}
+
+// HistoryPage is a page of the Readarr history.
+type HistoryPage struct {
+	Page         int32           `json:"page"`
+	PageSize     int32           `json:"pageSize"`
+	TotalRecords int32           `json:"totalRecords"`
+	Records      []HistoryRecord `json:"records"`
+}
+
+// History event types marking the import of a book file.
+const (
+	EventTypeBookFileImported = "bookFileImported"
+	EventTypeDownloadImported = "downloadImported"
+)
+
+// doRequest performs a request to the Readarr API. If in is not nil, it's sent as JSON body.
+// If out is not nil, the JSON response is decoded into it.
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, query url.Values, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("error encoding request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	reqURL := c.baseURL + "/api/v1" + endpoint
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("X-Api-Key", c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("Jellysweep/%s", version.Version))
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error performing request: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error decoding response: %w", err)
+	}
+	return nil
+}
+
+// GetSystemStatus requests the system status, which is a cheap way to check the connection and the API key.
+func (c *Client) GetSystemStatus(ctx context.Context) error {
+	return c.doRequest(ctx, http.MethodGet, "/system/status", nil, nil, nil)
+}
+
+// ListBooks returns all books.
+func (c *Client) ListBooks(ctx context.Context) ([]Book, error) {
+	var books []Book
+	if err := c.doRequest(ctx, http.MethodGet, "/book", nil, nil, &books); err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+// GetBook returns the book with the given ID.
+func (c *Client) GetBook(ctx context.Context, bookID int32) (*Book, error) {
+	var book Book
+	if err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/book/%d", bookID), nil, nil, &book); err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+// DeleteBook deletes a book together with its files.
+func (c *Client) DeleteBook(ctx context.Context, bookID int32) error {
+	query := url.Values{}
+	query.Set("deleteFiles", "true")
+	return c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/book/%d", bookID), query, nil, nil)
+}
+
+// ListAuthors returns all authors.
+func (c *Client) ListAuthors(ctx context.Context) ([]Author, error) {
+	var authors []Author
+	if err := c.doRequest(ctx, http.MethodGet, "/author", nil, nil, &authors); err != nil {
+		return nil, err
+	}
+	return authors, nil
+}
+
+// SetAuthorTags replaces the tags of an author.
+// The author is updated from its raw representation, so fields unknown to this client are preserved.
+func (c *Client) SetAuthorTags(ctx context.Context, authorID int32, tags []int32) error {
+	endpoint := fmt.Sprintf("/author/%d", authorID)
+
+	var author map[string]any
+	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, nil, &author); err != nil {
+		return err
+	}
+	author["tags"] = tags
+
+	return c.doRequest(ctx, http.MethodPut, endpoint, nil, author, nil)
+}
+
+// ListTags returns all tags.
+func (c *Client) ListTags(ctx context.Context) ([]Tag, error) {
+	var tags []Tag
+	if err := c.doRequest(ctx, http.MethodGet, "/tag", nil, nil, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// CreateTag creates a new tag with the given label.
+func (c *Client) CreateTag(ctx context.Context, label string) (*Tag, error) {
+	var tag Tag
+	if err := c.doRequest(ctx, http.MethodPost, "/tag", nil, Tag{Label: label}, &tag); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// DeleteTag deletes the tag with the given ID.
+func (c *Client) DeleteTag(ctx context.Context, tagID int32) error {
+	return c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/tag/%d", tagID), nil, nil, nil)
+}
+
+// GetHistory returns a page of the history of a book, sorted by date descending.
+func (c *Client) GetHistory(ctx context.Context, bookID, page, pageSize int32) (*HistoryPage, error) {
+	query := url.Values{}
+	query.Set("bookId", fmt.Sprintf("%d", bookID))
+	query.Set("page", fmt.Sprintf("%d", page))
+	query.Set("pageSize", fmt.Sprintf("%d", pageSize))
+	query.Set("sortKey", "date")
+	query.Set("sortDirection", "descending")
+
+	var history HistoryPage
+	if err := c.doRequest(ctx, http.MethodGet, "/history", query, nil, &history); err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
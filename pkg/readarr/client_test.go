@@ -0,0 +1,98 @@
+package readarr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return New(&config.ReadarrConfig{URL: server.URL, APIKey: "test-api-key"}, nil, nil)
+}
+
+func TestListBooks(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/book" || r.Header.Get("X-Api-Key") != "test-api-key" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{
+			"id": 7,
+			"title": "Dune",
+			"authorId": 3,
+			"releaseDate": "1965-08-01T00:00:00Z",
+			"added": "2024-01-02T03:04:05Z",
+			"statistics": {"bookFileCount": 1, "sizeOnDisk": 1048576},
+			"images": [{"coverType": "cover", "remoteUrl": "https://example.com/dune.jpg"}]
+		}, {"id": 8, "title": "Unreleased"}]`)
+	})
+
+	books, err := client.ListBooks(context.Background())
+	if err != nil {
+		t.Fatalf("ListBooks failed: %v", err)
+	}
+	if len(books) != 2 {
+		t.Fatalf("expected 2 books, got %d", len(books))
+	}
+
+	dune := books[0]
+	if dune.Year() != 1965 {
+		t.Errorf("unexpected year: %d", dune.Year())
+	}
+	if dune.SizeOnDisk() != 1048576 {
+		t.Errorf("unexpected size: %d", dune.SizeOnDisk())
+	}
+	if dune.CoverURL() != "https://example.com/dune.jpg" {
+		t.Errorf("unexpected cover URL: %q", dune.CoverURL())
+	}
+	if dune.Added.IsZero() {
+		t.Error("expected added date to be set")
+	}
+
+	unreleased := books[1]
+	if unreleased.Year() != 0 || unreleased.SizeOnDisk() != 0 || unreleased.CoverURL() != "" {
+		t.Errorf("expected zero values for book without metadata, got %+v", unreleased)
+	}
+}
+
+func TestSetAuthorTagsPreservesFields(t *testing.T) {
+	var updated map[string]any
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/author/3" {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"id": 3, "authorName": "Frank Herbert", "monitored": true, "qualityProfileId": 2, "tags": [1, 2]}`)
+		case http.MethodPut:
+			if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{}`)
+		}
+	})
+
+	if err := client.SetAuthorTags(context.Background(), 3, []int32{2, 5}); err != nil {
+		t.Fatalf("SetAuthorTags failed: %v", err)
+	}
+
+	if updated["monitored"] != true || updated["qualityProfileId"] != float64(2) {
+		t.Errorf("expected unknown author fields to be preserved, got %v", updated)
+	}
+	if tags, ok := updated["tags"].([]any); !ok || len(tags) != 2 || tags[0] != float64(2) || tags[1] != float64(5) {
+		t.Errorf("unexpected tags: %v", updated["tags"])
+	}
+}
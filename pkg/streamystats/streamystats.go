@@ -20,9 +20,55 @@ type Client struct {
 
 type ItemDetails struct {
 	LastWatched time.Time `json:"lastWatched"`
+	// TotalViews is the number of times the item was watched by all users.
+	TotalViews int `json:"totalViews"`
+	// UsersWatched holds the watch statistics of every user who watched the item.
+	UsersWatched []UserWatched `json:"usersWatched,omitempty"`
 }
 
-func New(cfg *config.StreamystatsConfig, apiKey string) (*Client, error) {
+// PlayCount returns the number of times the item was watched.
+// Older Streamystats versions don't report the total views, the watch counts of the users are summed up instead.
+func (d *ItemDetails) PlayCount() int {
+	if d.TotalViews > 0 {
+		return d.TotalViews
+	}
+	var count int
+	for _, watched := range d.UsersWatched {
+		count += watched.WatchCount
+	}
+	return count
+}
+
+// UserWatched holds the watch statistics of a single user for an item.
+type UserWatched struct {
+	User        User      `json:"user"`
+	WatchCount  int       `json:"watchCount"`
+	LastWatched time.Time `json:"lastWatched"`
+}
+
+// User is a Jellyfin user known to Streamystats.
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ItemLastWatched holds the last watch time of a single item.
+type ItemLastWatched struct {
+	ID          string    `json:"id"`
+	LastWatched time.Time `json:"lastWatched"`
+}
+
+// ItemsPage is a single page of the items endpoint.
+type ItemsPage struct {
+	Items      []ItemLastWatched `json:"items"`
+	Page       int               `json:"page"`
+	TotalPages int               `json:"totalPages"`
+}
+
+// itemsPageSize is the number of items requested per page.
+const itemsPageSize = 500
+
+func New(cfg *config.StreamystatsConfig, apiKey string, proxy *config.ProxyConfig) (*Client, error) {
 	baseURL, err := url.Parse(cfg.URL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid streamystats URL: %w", err)
@@ -32,7 +78,7 @@ func New(cfg *config.StreamystatsConfig, apiKey string) (*Client, error) {
 		cfg:        cfg,
 		apiKey:     apiKey,
 		baseURL:    baseURL,
-		httpClient: &http.Client{Timeout: config.TimeoutDuration(cfg.Timeout)},
+		httpClient: config.NewHTTPClient(cfg.Timeout, proxy, cfg.Proxy),
 	}, nil
 }
 
@@ -69,3 +115,67 @@ func (c *Client) GetItemDetails(ctx context.Context, itemID string) (*ItemDetail
 
 	return &itemDetails, nil
 }
+
+// GetItemsPage returns a single page of items with their last watch time.
+// Pages start at 1.
+func (c *Client) GetItemsPage(ctx context.Context, page, limit int) (*ItemsPage, error) {
+	itemsURL := fmt.Sprintf("%s/api/get-items?serverId=%d&page=%d&limit=%d", c.baseURL.String(), c.cfg.ServerID, page, limit)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", itemsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create items request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute items request: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get items with status %d", resp.StatusCode)
+	}
+
+	var itemsPage ItemsPage
+	if err := json.NewDecoder(resp.Body).Decode(&itemsPage); err != nil {
+		return nil, fmt.Errorf("failed to decode items response: %w", err)
+	}
+
+	return &itemsPage, nil
+}
+
+// GetItemsLastWatched pages through all items of the server and returns the last watch time of the requested items.
+// Items without a watch history or unknown to Streamystats are missing in the result.
+func (c *Client) GetItemsLastWatched(ctx context.Context, itemIDs []string) (map[string]time.Time, error) {
+	wanted := make(map[string]struct{}, len(itemIDs))
+	for _, id := range itemIDs {
+		wanted[id] = struct{}{}
+	}
+
+	result := make(map[string]time.Time, len(itemIDs))
+	for page := 1; len(wanted) > 0; page++ {
+		itemsPage, err := c.GetItemsPage(ctx, page, itemsPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range itemsPage.Items {
+			if _, ok := wanted[item.ID]; !ok {
+				continue
+			}
+			delete(wanted, item.ID)
+			if !item.LastWatched.IsZero() {
+				result[item.ID] = item.LastWatched
+			}
+		}
+
+		// Don't rely on the page size, the server may cap the limit.
+		if len(itemsPage.Items) == 0 || (itemsPage.TotalPages > 0 && page >= itemsPage.TotalPages) {
+			break
+		}
+	}
+
+	return result, nil
+}
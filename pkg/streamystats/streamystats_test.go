@@ -3,8 +3,10 @@ package streamystats
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
@@ -39,7 +41,7 @@ func TestNew(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			client, err := New(tt.cfg, "test-api-key")
+			client, err := New(tt.cfg, "test-api-key", nil)
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Nil(t, client)
@@ -125,7 +127,7 @@ func TestClient_GetItemDetails(t *testing.T) {
 				ServerID: tt.serverID,
 			}
 
-			client, err := New(cfg, tt.apiKey)
+			client, err := New(cfg, tt.apiKey, nil)
 			require.NoError(t, err)
 
 			item, err := client.GetItemDetails(context.Background(), tt.itemID)
@@ -139,3 +141,71 @@ func TestClient_GetItemDetails(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_GetItemsLastWatched(t *testing.T) {
+	lastWatched := time.Date(2025, 7, 24, 11, 39, 7, 0, time.UTC)
+
+	// 12 items spread over 3 pages of 5, 5 and 2 items; the server caps the requested limit.
+	const pageSize = 5
+	allItems := make([]ItemLastWatched, 0, 12)
+	for i := range 12 {
+		item := ItemLastWatched{ID: fmt.Sprintf("item-%d", i)}
+		if i%2 == 0 {
+			item.LastWatched = lastWatched.Add(time.Duration(i) * time.Hour)
+		}
+		allItems = append(allItems, item)
+	}
+	totalPages := (len(allItems) + pageSize - 1) / pageSize
+
+	var requestedPages []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/get-items", r.URL.Path)
+		assert.Equal(t, "1", r.URL.Query().Get("serverId"))
+		assert.Equal(t, "Bearer test-api-key", r.Header.Get("Authorization"))
+
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		require.NoError(t, err)
+		requestedPages = append(requestedPages, page)
+
+		start := min((page-1)*pageSize, len(allItems))
+		end := min(start+pageSize, len(allItems))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ItemsPage{
+			Items:      allItems[start:end],
+			Page:       page,
+			TotalPages: totalPages,
+		})
+	}))
+	defer server.Close()
+
+	client, err := New(&config.StreamystatsConfig{URL: server.URL, ServerID: 1}, "test-api-key", nil)
+	require.NoError(t, err)
+
+	t.Run("finds items beyond the first page", func(t *testing.T) {
+		requestedPages = nil
+
+		result, err := client.GetItemsLastWatched(context.Background(), []string{"item-0", "item-3", "item-6", "item-10", "unknown"})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]time.Time{
+			"item-0":  lastWatched,
+			"item-6":  lastWatched.Add(6 * time.Hour),
+			"item-10": lastWatched.Add(10 * time.Hour),
+		}, result)
+		assert.Equal(t, []int{1, 2, 3}, requestedPages)
+	})
+
+	t.Run("stops once all items are found", func(t *testing.T) {
+		requestedPages = nil
+
+		result, err := client.GetItemsLastWatched(context.Background(), []string{"item-2", "item-8"})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]time.Time{
+			"item-2": lastWatched.Add(2 * time.Hour),
+			"item-8": lastWatched.Add(8 * time.Hour),
+		}, result)
+		assert.Equal(t, []int{1, 2}, requestedPages)
+	})
+}
@@ -0,0 +1,143 @@
+package tmdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+)
+
+const (
+	defaultBaseURL = "https://api.themoviedb.org/3"
+	// imageBaseURL is the base URL of poster images, using a width suitable for emails.
+	imageBaseURL = "https://image.tmdb.org/t/p/w342"
+)
+
+// ErrNotFound is returned if TMDB doesn't know the requested media.
+var ErrNotFound = errors.New("media not found in tmdb")
+
+// Client represents a TMDB API client.
+type Client struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// New creates a new TMDB API client.
+func New(cfg *config.TMDBConfig) *Client {
+	return &Client{
+		baseURL:    defaultBaseURL,
+		apiKey:     cfg.APIKey,
+		httpClient: &http.Client{Timeout: config.TimeoutDuration(cfg.Timeout)},
+	}
+}
+
+// Genre represents a TMDB genre.
+type Genre struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Details holds the metadata of a movie or TV show.
+type Details struct {
+	ID         int     `json:"id"`
+	Overview   string  `json:"overview"`
+	PosterPath string  `json:"poster_path"`
+	Genres     []Genre `json:"genres"`
+}
+
+// PosterURL returns the full URL of the poster image or an empty string if there is no poster.
+func (d *Details) PosterURL() string {
+	if d.PosterPath == "" {
+		return ""
+	}
+	return imageBaseURL + d.PosterPath
+}
+
+// GenreNames returns the names of all genres.
+func (d *Details) GenreNames() []string {
+	names := make([]string, 0, len(d.Genres))
+	for _, genre := range d.Genres {
+		names = append(names, genre.Name)
+	}
+	return names
+}
+
+// findResponse represents the response of /find/{external_id}.
+type findResponse struct {
+	TvResults []struct {
+		ID int32 `json:"id"`
+	} `json:"tv_results"`
+}
+
+// doRequest performs a GET request to the TMDB API and decodes the JSON response into out.
+func (c *Client) doRequest(ctx context.Context, endpoint string, query url.Values, out any) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("api_key", c.apiKey)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error performing request: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error decoding response: %w", err)
+	}
+	return nil
+}
+
+// GetMovie retrieves movie details by TMDB ID.
+func (c *Client) GetMovie(ctx context.Context, tmdbID int32) (*Details, error) {
+	var details Details
+	if err := c.doRequest(ctx, fmt.Sprintf("/movie/%d", tmdbID), nil, &details); err != nil {
+		return nil, err
+	}
+	return &details, nil
+}
+
+// GetTvShow retrieves TV show details by TMDB ID.
+func (c *Client) GetTvShow(ctx context.Context, tmdbID int32) (*Details, error) {
+	var details Details
+	if err := c.doRequest(ctx, fmt.Sprintf("/tv/%d", tmdbID), nil, &details); err != nil {
+		return nil, err
+	}
+	return &details, nil
+}
+
+// GetTvShowByTvdbID retrieves TV show details by TVDB ID.
+// It's used for series that Sonarr doesn't know the TMDB ID of.
+func (c *Client) GetTvShowByTvdbID(ctx context.Context, tvdbID int32) (*Details, error) {
+	query := url.Values{}
+	query.Set("external_source", "tvdb_id")
+
+	var found findResponse
+	if err := c.doRequest(ctx, fmt.Sprintf("/find/%d", tvdbID), query, &found); err != nil {
+		return nil, err
+	}
+	if len(found.TvResults) == 0 {
+		return nil, ErrNotFound
+	}
+	return c.GetTvShow(ctx, found.TvResults[0].ID)
+}
@@ -0,0 +1,93 @@
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jon4hz/jellysweep/internal/config"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := New(&config.TMDBConfig{APIKey: "test-api-key"})
+	client.baseURL = server.URL
+	return client
+}
+
+func TestGetMovie(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/movie/603" || r.URL.Query().Get("api_key") != "test-api-key" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"id": 603,
+			"title": "The Matrix",
+			"overview": "A hacker learns about the true nature of reality.",
+			"poster_path": "/matrix.jpg",
+			"genres": [{"id": 28, "name": "Action"}, {"id": 878, "name": "Science Fiction"}]
+		}`)
+	})
+
+	details, err := client.GetMovie(context.Background(), 603)
+	if err != nil {
+		t.Fatalf("GetMovie failed: %v", err)
+	}
+
+	if details.Overview != "A hacker learns about the true nature of reality." {
+		t.Errorf("unexpected overview: %q", details.Overview)
+	}
+	if got := details.PosterURL(); got != "https://image.tmdb.org/t/p/w342/matrix.jpg" {
+		t.Errorf("unexpected poster URL: %q", got)
+	}
+	if got := details.GenreNames(); len(got) != 2 || got[0] != "Action" || got[1] != "Science Fiction" {
+		t.Errorf("unexpected genres: %v", got)
+	}
+}
+
+func TestGetTvShowByTvdbID(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/find/81189":
+			if r.URL.Query().Get("external_source") != "tvdb_id" {
+				http.Error(w, "missing external source", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"tv_results": [{"id": 1396}]}`)
+		case "/find/1":
+			fmt.Fprint(w, `{"tv_results": []}`)
+		case "/tv/1396":
+			fmt.Fprint(w, `{"id": 1396, "overview": "A chemistry teacher turns to crime.", "genres": [{"id": 18, "name": "Drama"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	details, err := client.GetTvShowByTvdbID(context.Background(), 81189)
+	if err != nil {
+		t.Fatalf("GetTvShowByTvdbID failed: %v", err)
+	}
+	if details.ID != 1396 {
+		t.Errorf("expected TMDB ID 1396, got %d", details.ID)
+	}
+	if details.PosterURL() != "" {
+		t.Errorf("expected no poster URL, got %q", details.PosterURL())
+	}
+
+	if _, err := client.GetTvShowByTvdbID(context.Background(), 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unknown TVDB ID, got %v", err)
+	}
+	if _, err := client.GetTvShow(context.Background(), 2); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unknown TMDB ID, got %v", err)
+	}
+}
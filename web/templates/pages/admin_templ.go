@@ -14,7 +14,7 @@ import (
 	"github.com/jon4hz/jellysweep/web/templates/components"
 )
 
-func AdminPanel(user *models.User, requestedMedia []models.AdminMediaItem, mediaItems []models.AdminMediaItem, isDryRun bool) templ.Component {
+func AdminPanel(user *models.User, requestedMedia []models.AdminMediaItem, mediaItems []models.AdminMediaItem, isDryRun bool, isMaintenance bool) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
@@ -154,7 +154,7 @@ func AdminPanel(user *models.User, requestedMedia []models.AdminMediaItem, media
 			}
 			return nil
 		})
-		templ_7745c5c3_Err = templates.LayoutWithPendingRequests("Admin Panel", user, len(requestedMedia), isDryRun).Render(templ.WithChildren(ctx, templ_7745c5c3_Var2), templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = templates.LayoutWithPendingRequests("Admin Panel", user, len(requestedMedia), isDryRun, isMaintenance).Render(templ.WithChildren(ctx, templ_7745c5c3_Var2), templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}